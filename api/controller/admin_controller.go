@@ -0,0 +1,293 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type AdminController struct {
+	adminUsecase    usecase.AdminUsecase
+	securityUsecase usecase.SecurityUsecase
+	jobUsecase      usecase.JobUseCase
+	urls            *response.URLBuilder
+	validator       *validator.Validate
+}
+
+func NewAdminController(adminUsecase usecase.AdminUsecase, securityUsecase usecase.SecurityUsecase, jobUsecase usecase.JobUseCase, urls *response.URLBuilder) *AdminController {
+	return &AdminController{
+		adminUsecase:    adminUsecase,
+		securityUsecase: securityUsecase,
+		jobUsecase:      jobUsecase,
+		urls:            urls,
+		validator:       validator.New(),
+	}
+}
+
+// ListUsers handles GET /api/v1/admin/users
+// Supports searching by name or email (?q=) and filtering by ?role= and ?status=
+func (c *AdminController) ListUsers(ctx *gin.Context) {
+	filter := domain.UserFilter{
+		Query:  ctx.Query("q"),
+		Role:   domain.Role(ctx.Query("role")),
+		Status: domain.UserStatus(ctx.Query("status")),
+	}
+
+	switch filter.Status {
+	case "", domain.UserActive, domain.UserSuspended, domain.UserDeleted:
+	default:
+		ctx.JSON(http.StatusBadRequest, domain.UserListResponse{
+			Success: false,
+			Message: "Invalid status filter",
+			Errors:  []string{"status must be active, suspended or deleted"},
+		})
+		return
+	}
+
+	// Get pagination parameters
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	// Call use case
+	resp, err := c.adminUsecase.ListUsers(ctx.Request.Context(), filter, page, limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve users")
+		return
+	}
+
+	response.List(ctx, http.StatusOK, resp, "users", func() response.Table {
+		users, _ := resp.Data.([]*domain.User)
+		return response.UsersTable(users)
+	})
+}
+
+// GetUser handles GET /api/v1/admin/users/:id
+func (c *AdminController) GetUser(ctx *gin.Context) {
+	// Call use case
+	resp, err := c.adminUsecase.GetUser(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve user")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// CreateAuditor handles POST /api/v1/admin/auditors
+// Auditor accounts can't sign up, an admin opens them
+func (c *AdminController) CreateAuditor(ctx *gin.Context) {
+	var req domain.CreateAuditorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.UserResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.UserResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.adminUsecase.CreateAuditor(ctx.Request.Context(), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to create auditor account")
+		return
+	}
+
+	auditor := resp.Data.(*domain.User)
+	response.Created(ctx, c.urls.AdminUser(auditor.ID.Hex()), resp)
+}
+
+// SuspendUser handles POST /api/v1/admin/users/:id/suspend
+func (c *AdminController) SuspendUser(ctx *gin.Context) {
+	// Get admin ID from context
+	adminID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.UserResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.adminUsecase.SuspendUser(ctx.Request.Context(), adminID.(string), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to suspend user")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ReactivateUser handles POST /api/v1/admin/users/:id/reactivate
+func (c *AdminController) ReactivateUser(ctx *gin.Context) {
+	// Call use case
+	resp, err := c.adminUsecase.ReactivateUser(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to reactivate user")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetSecurityDashboard handles GET /api/v1/admin/security/dashboard
+// Summarizes logins, failed logins and security alerts over the last ?hours= (default 24)
+func (c *AdminController) GetSecurityDashboard(ctx *gin.Context) {
+	hours, _ := strconv.Atoi(ctx.DefaultQuery("hours", "24"))
+
+	// Call use case
+	resp, err := c.securityUsecase.GetDashboard(ctx.Request.Context(), hours)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve security dashboard")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ListJobAbuseFlags handles GET /api/v1/admin/job-flags
+// Lists jobs throttled for churning edits, publish toggles or reposts. Resolved
+// flags are included with ?all=true.
+func (c *AdminController) ListJobAbuseFlags(ctx *gin.Context) {
+	all, _ := strconv.ParseBool(ctx.DefaultQuery("all", "false"))
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	// Call use case
+	resp, err := c.jobUsecase.ListAbuseFlags(ctx.Request.Context(), all, page, limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve job abuse flags")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ResolveJobAbuseFlag handles POST /api/v1/admin/job-flags/:id/resolve
+func (c *AdminController) ResolveJobAbuseFlag(ctx *gin.Context) {
+	adminID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobAbuseFlagResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.jobUsecase.ResolveAbuseFlag(ctx.Request.Context(), ctx.Param("id"), adminID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to resolve job abuse flag")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ListPendingJobs handles GET /api/v1/admin/jobs/moderation
+// Lists the jobs waiting for review, the longest waiting first
+func (c *AdminController) ListPendingJobs(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	// Call use case
+	resp, err := c.jobUsecase.ListPendingReview(ctx.Request.Context(), page, limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve jobs waiting for review")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ApproveJob handles POST /api/v1/admin/jobs/moderation/:id/approve
+func (c *AdminController) ApproveJob(ctx *gin.Context) {
+	adminID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.jobUsecase.ApproveJob(ctx.Request.Context(), ctx.Param("id"), adminID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to approve job")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RejectJob handles POST /api/v1/admin/jobs/moderation/:id/reject
+func (c *AdminController) RejectJob(ctx *gin.Context) {
+	adminID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.RejectJobRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.JobResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.JobResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.jobUsecase.RejectJob(ctx.Request.Context(), ctx.Param("id"), &req, adminID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to reject job")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
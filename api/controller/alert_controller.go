@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type AlertController struct {
+	alertUsecase usecase.AlertUsecase
+	validator    *validator.Validate
+}
+
+func NewAlertController(alertUsecase usecase.AlertUsecase) *AlertController {
+	return &AlertController{
+		alertUsecase: alertUsecase,
+		validator:    validator.New(),
+	}
+}
+
+// GetPreferences handles GET /api/v1/users/me/alert-preferences
+func (c *AlertController) GetPreferences(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.AlertPreferencesResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.alertUsecase.GetPreferences(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve alert preferences")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdatePreferences handles PUT /api/v1/users/me/alert-preferences
+// The request replaces the stored exclusion lists
+func (c *AlertController) UpdatePreferences(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.AlertPreferencesResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.UpdateAlertPreferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.AlertPreferencesResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.AlertPreferencesResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.alertUsecase.UpdatePreferences(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to update alert preferences")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetRecommendedJobs handles GET /api/v1/jobs/recommended
+// Lists the latest published jobs, skipping the applicant's excluded companies and keywords
+func (c *AlertController) GetRecommendedJobs(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobListResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Get pagination parameters
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	jobs, total, err := c.alertUsecase.RecommendJobs(ctx.Request.Context(), userID.(string), page, limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve recommended jobs")
+		return
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+	if totalPages < 1 && total > 0 {
+		totalPages = 1
+	}
+
+	response.List(ctx, http.StatusOK, domain.JobListResponse{
+		Success:    true,
+		Message:    "Recommended jobs retrieved successfully",
+		Data:       jobs,
+		PageNumber: page,
+		PageSize:   len(jobs),
+		TotalItems: total,
+		TotalPages: totalPages,
+	}, "jobs", func() response.Table { return response.JobsTable(jobs) })
+}
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type AnnouncementController struct {
+	announcementUsecase usecase.AnnouncementUsecase
+	validator           *validator.Validate
+	urls                *response.URLBuilder
+}
+
+func NewAnnouncementController(announcementUsecase usecase.AnnouncementUsecase, urls *response.URLBuilder) *AnnouncementController {
+	return &AnnouncementController{
+		announcementUsecase: announcementUsecase,
+		validator:           validator.New(),
+		urls:                urls,
+	}
+}
+
+// GetAnnouncements handles GET /api/v1/announcements
+// Visitors get the announcements targeting everyone, signed-in users those
+// targeting their role they haven't dismissed
+func (c *AnnouncementController) GetAnnouncements(ctx *gin.Context) {
+	userID, _ := ctx.Get("userID")
+	userRole, _ := ctx.Get("userRole")
+	id, _ := userID.(string)
+	role, _ := userRole.(string)
+
+	// Call use case
+	resp, err := c.announcementUsecase.GetAnnouncements(ctx.Request.Context(), id, domain.Role(role))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve announcements")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DismissAnnouncement handles POST /api/v1/announcements/:id/dismiss
+func (c *AnnouncementController) DismissAnnouncement(ctx *gin.Context) {
+	// Get user ID from context
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.AnnouncementResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.announcementUsecase.DismissAnnouncement(ctx.Request.Context(), ctx.Param("id"), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to dismiss announcement")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ListAnnouncements handles GET /api/v1/admin/announcements
+func (c *AnnouncementController) ListAnnouncements(ctx *gin.Context) {
+	// Call use case
+	resp, err := c.announcementUsecase.ListAnnouncements(ctx.Request.Context())
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve announcements")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// CreateAnnouncement handles POST /api/v1/admin/announcements
+func (c *AnnouncementController) CreateAnnouncement(ctx *gin.Context) {
+	// Get admin ID from context
+	adminID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.AnnouncementResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.CreateAnnouncementRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.AnnouncementResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	if !c.validate(ctx, req) {
+		return
+	}
+
+	// Call use case
+	resp, err := c.announcementUsecase.CreateAnnouncement(ctx.Request.Context(), &req, adminID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to create announcement")
+		return
+	}
+
+	announcement := resp.Data.(*domain.Announcement)
+	resp.URL = c.urls.Announcement(announcement.ID.Hex())
+	response.Created(ctx, resp.URL, resp)
+}
+
+// UpdateAnnouncement handles PUT /api/v1/admin/announcements/:id
+func (c *AnnouncementController) UpdateAnnouncement(ctx *gin.Context) {
+	var req domain.UpdateAnnouncementRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.AnnouncementResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	if !c.validate(ctx, req) {
+		return
+	}
+
+	// Call use case
+	resp, err := c.announcementUsecase.UpdateAnnouncement(ctx.Request.Context(), ctx.Param("id"), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to update announcement")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteAnnouncement handles DELETE /api/v1/admin/announcements/:id
+func (c *AnnouncementController) DeleteAnnouncement(ctx *gin.Context) {
+	// Call use case
+	resp, err := c.announcementUsecase.DeleteAnnouncement(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to delete announcement")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *AnnouncementController) validate(ctx *gin.Context, req interface{}) bool {
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.AnnouncementResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type APIKeyController struct {
+	apiKeyUsecase usecase.APIKeyUsecase
+	validator     *validator.Validate
+	urls          *response.URLBuilder
+}
+
+func NewAPIKeyController(apiKeyUsecase usecase.APIKeyUsecase, urls *response.URLBuilder) *APIKeyController {
+	return &APIKeyController{
+		apiKeyUsecase: apiKeyUsecase,
+		validator:     validator.New(),
+		urls:          urls,
+	}
+}
+
+// CreateKey handles POST /api/v1/companies/me/api-keys
+func (c *APIKeyController) CreateKey(ctx *gin.Context) {
+	companyID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.APIKeyResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.CreateAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.APIKeyResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.APIKeyResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.apiKeyUsecase.CreateKey(ctx.Request.Context(), companyID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to create API key")
+		return
+	}
+
+	key := resp.Data.(*domain.CreatedAPIKey)
+	resp.URL = c.urls.APIKey(key.ID.Hex())
+	response.Created(ctx, resp.URL, resp)
+}
+
+// ListKeys handles GET /api/v1/companies/me/api-keys
+func (c *APIKeyController) ListKeys(ctx *gin.Context) {
+	companyID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.APIKeyResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.apiKeyUsecase.ListKeys(ctx.Request.Context(), companyID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve API keys")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RevokeKey handles DELETE /api/v1/companies/me/api-keys/:id
+func (c *APIKeyController) RevokeKey(ctx *gin.Context) {
+	companyID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.APIKeyResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.apiKeyUsecase.RevokeKey(ctx.Request.Context(), companyID.(string), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to revoke API key")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type APIUsageController struct {
+	apiUsageUsecase usecase.APIUsageUsecase
+}
+
+func NewAPIUsageController(apiUsageUsecase usecase.APIUsageUsecase) *APIUsageController {
+	return &APIUsageController{
+		apiUsageUsecase: apiUsageUsecase,
+	}
+}
+
+// GetUsage handles GET /api/v1/me/usage
+// Hourly requests, errors and rate limit consumption of the company's API keys
+// and the delivery of its webhook events over the last ?days= (default 7)
+func (c *APIUsageController) GetUsage(ctx *gin.Context) {
+	companyID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.APIUsageResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	days, _ := strconv.Atoi(ctx.DefaultQuery("days", strconv.Itoa(domain.DefaultAPIUsageDays)))
+
+	// Call use case
+	resp, err := c.apiUsageUsecase.GetUsage(ctx.Request.Context(), companyID.(string), days)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve API usage")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -2,34 +2,37 @@ package controller
 
 import (
 	"context"
-	"io"
 	"mime/multipart"
 	"net/http"
-	"os"
-	"path/filepath"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"github.com/google/uuid"
 
+	"job-portal-backend/api/response"
 	"job-portal-backend/domain"
+	"job-portal-backend/pkg/storage"
 	"job-portal-backend/usecase"
 )
 
 type ApplicationController struct {
 	appUseCase usecase.ApplicationUseCase
+	storage    storage.Storage
+	urls       *response.URLBuilder
 	validator  *validator.Validate
 }
 
-func NewApplicationController(appUseCase usecase.ApplicationUseCase) *ApplicationController {
+func NewApplicationController(appUseCase usecase.ApplicationUseCase, store storage.Storage, urls *response.URLBuilder) *ApplicationController {
 	return &ApplicationController{
 		appUseCase: appUseCase,
-		validator:   validator.New(),
+		storage:    store,
+		urls:       urls,
+		validator:  validator.New(),
 	}
 }
 
 // ApplyForJob handles POST /api/v1/applications
+// The resume is either uploaded in the resume field or picked from the library with resume_id
 func (c *ApplicationController) ApplyForJob(ctx *gin.Context) {
 	// Get user ID from context
 	userID, exists := ctx.Get("userID")
@@ -89,110 +92,68 @@ func (c *ApplicationController) ApplyForJob(ctx *gin.Context) {
 		return
 	}
 
-	// Process the uploaded resume file
-	file, err := req.ResumeFile.Open()
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, domain.ApplicationResponse{
-			Success: false,
-			Message: "Failed to process resume file",
-			Errors:  []string{err.Error()},
-		})
-		return
-	}
-	defer file.Close()
-
-	// Upload the resume to Cloudinary
-	resumeURL, err := c.uploadToCloudinary(file, req.ResumeFile)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, domain.ApplicationResponse{
-			Success: false,
-			Message: "Failed to upload resume",
-			Errors:  []string{err.Error()},
-		})
-		return
+	// Upload the resume unless one from the applicant's library was picked
+	var resumeURL string
+	if req.ResumeFile != nil {
+		file, err := req.ResumeFile.Open()
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, domain.ApplicationResponse{
+				Success: false,
+				Message: "Failed to process resume file",
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
+		defer file.Close()
+
+		// Upload the resume to the storage provider
+		resumeURL, err = c.uploadResume(ctx.Request.Context(), file, req.ResumeFile)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, domain.ApplicationResponse{
+				Success: false,
+				Message: "Failed to upload resume",
+				Errors:  []string{err.Error()},
+			})
+			return
+		}
 	}
 
 	// Call use case to create application
-	response, err := c.appUseCase.ApplyForJob(context.Background(), &req, userID.(string), resumeURL)
+	resp, err := c.appUseCase.ApplyForJob(context.Background(), &req, userID.(string), resumeURL)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, domain.ApplicationResponse{
-			Success: false,
-			Message: "Failed to submit application",
-			Errors:  []string{err.Error()},
-		})
-		return
-	}
-
-	ctx.JSON(http.StatusCreated, response)
-
-	// Parse form data
-	var applyRequest domain.ApplyRequest
-	if err := ctx.ShouldBind(&applyRequest); err != nil {
-		ctx.JSON(http.StatusBadRequest, domain.ApplicationResponse{
-			Success: false,
-			Message: "Invalid request",
-			Errors:  []string{err.Error()},
-		})
+		response.Error(ctx, err, "Failed to submit application")
 		return
 	}
 
-	// Validate request
-	if err := c.validator.Struct(req); err != nil {
-		errs := make([]string, len(err.(validator.ValidationErrors)))
-		for i, e := range err.(validator.ValidationErrors) {
-			errs[i] = e.Translate(nil)
-		}
-
-		ctx.JSON(http.StatusBadRequest, domain.ApplicationResponse{
-			Success: false,
-			Message: "Validation failed",
-			Errors:  errs,
-		})
-		return
-	}
-
-	// Handle file upload
-	file, header, err := ctx.Request.FormFile("resume")
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, domain.ApplicationResponse{
-			Success: false,
-			Message: "Resume file is required",
-			Errors:  []string{err.Error()},
-		})
-		return
-	}
-	defer file.Close()
+	application := resp.Data.(*domain.Application)
+	resp.URL = c.urls.Application(application.ID.Hex())
+	response.Created(ctx, resp.URL, resp)
+}
 
-	// Upload file to Cloudinary
-	// Note: You'll need to implement the actual file upload to Cloudinary
-	// This is a placeholder for the upload logic
-	resumeLink, err := uploadToCloudinary(file, header)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, domain.ApplicationResponse{
+// GetApplication handles GET /api/v1/applications/:id
+// Applicants can view their own applications, companies the applications to their jobs
+func (c *ApplicationController) GetApplication(ctx *gin.Context) {
+	// Get user ID and role from context
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.ApplicationResponse{
 			Success: false,
-			Message: "Failed to upload resume",
-			Errors:  []string{err.Error()},
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
 		})
 		return
 	}
+	userRole, _ := ctx.Get("userRole")
+	role, _ := userRole.(string)
 
-	// Call use case to create application
-	response, err := c.appUseCase.ApplyForJob(context.Background(), &req, userID.(string), resumeLink)
+	// Call use case
+	resp, err := c.appUseCase.GetApplication(ctx.Request.Context(), ctx.Param("id"), userID.(string), role)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, domain.ApplicationResponse{
-			Success: false,
-			Message: "Failed to submit application",
-			Errors:  []string{err.Error()},
-		})
-		return
-	 }
-
-	if !response.Success {
-		ctx.JSON(http.StatusBadRequest, response)
+		response.Error(ctx, err, "Failed to retrieve application")
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, response)
+	ctx.JSON(http.StatusOK, resp)
 }
 
 // GetMyApplications handles GET /api/v1/applications/me
@@ -224,17 +185,16 @@ func (c *ApplicationController) GetMyApplications(ctx *gin.Context) {
 	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
 
 	// Call use case
-	response, err := c.appUseCase.GetMyApplications(context.Background(), userID.(string), page, limit)
+	resp, err := c.appUseCase.GetMyApplications(context.Background(), userID.(string), page, limit)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, domain.ApplicationListResponse{
-			Success: false,
-			Message: "Failed to retrieve applications",
-			Errors:  []string{err.Error()},
-		})
+		response.Error(ctx, err, "Failed to retrieve applications")
 		return
 	}
 
-	ctx.JSON(http.StatusOK, response)
+	response.List(ctx, http.StatusOK, resp, "applications", func() response.Table {
+		rows, _ := resp.Data.([]map[string]interface{})
+		return response.MapsTable(response.MyApplicationColumns, rows)
+	})
 }
 
 // GetJobApplications handles GET /api/v1/jobs/:id/applications
@@ -276,22 +236,16 @@ func (c *ApplicationController) GetJobApplications(ctx *gin.Context) {
 	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
 
 	// Call use case
-	response, err := c.appUseCase.GetJobApplications(context.Background(), jobID, userID.(string), page, limit)
+	resp, err := c.appUseCase.GetJobApplications(context.Background(), jobID, userID.(string), page, limit)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, domain.ApplicationListResponse{
-			Success: false,
-			Message: "Failed to retrieve job applications",
-			Errors:  []string{err.Error()},
-		})
-		return
-	}
-
-	if !response.Success {
-		ctx.JSON(http.StatusBadRequest, response)
+		response.Error(ctx, err, "Failed to retrieve job applications")
 		return
 	}
 
-	ctx.JSON(http.StatusOK, response)
+	response.List(ctx, http.StatusOK, resp, "applications", func() response.Table {
+		rows, _ := resp.Data.([]map[string]interface{})
+		return response.MapsTable(response.JobApplicationColumns, rows)
+	})
 }
 
 // UpdateApplicationStatus handles PUT /api/v1/applications/:id/status
@@ -355,51 +309,176 @@ func (c *ApplicationController) UpdateApplicationStatus(ctx *gin.Context) {
 	}
 
 	// Call use case
-	response, err := c.appUseCase.UpdateApplicationStatus(context.Background(), applicationID, userID.(string), &req)
+	resp, err := c.appUseCase.UpdateApplicationStatus(context.Background(), applicationID, userID.(string), &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, domain.ApplicationResponse{
+		response.Error(ctx, err, "Failed to update application status")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetAllowedTransitions handles GET /api/v1/applications/:id/allowed-transitions
+func (c *ApplicationController) GetAllowedTransitions(ctx *gin.Context) {
+	// Get user ID from context
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.ApplicationResponse{
 			Success: false,
-			Message: "Failed to update application status",
-			Errors:  []string{err.Error()},
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Check if user has company role
+	userRole, exists := ctx.Get("userRole")
+	if !exists || userRole != "company" {
+		ctx.JSON(http.StatusForbidden, domain.ApplicationResponse{
+			Success: false,
+			Message: "Forbidden",
+			Errors:  []string{"Only company users can view allowed status transitions"},
+		})
+		return
+	}
+
+	// Get application ID from URL
+	applicationID := ctx.Param("id")
+	if applicationID == "" {
+		ctx.JSON(http.StatusBadRequest, domain.ApplicationResponse{
+			Success: false,
+			Message: "Application ID is required",
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.appUseCase.GetAllowedTransitions(ctx.Request.Context(), applicationID, userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve allowed transitions")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// WithdrawApplication handles POST /api/v1/applications/:id/withdraw
+func (c *ApplicationController) WithdrawApplication(ctx *gin.Context) {
+	// Get user ID from context
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.ApplicationResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.appUseCase.WithdrawApplication(ctx.Request.Context(), ctx.Param("id"), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to withdraw application")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetApplicationEvents handles GET /api/v1/applications/:id/events
+func (c *ApplicationController) GetApplicationEvents(ctx *gin.Context) {
+	// Get user ID from context
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.ApplicationResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
 		})
 		return
 	}
 
-	if !response.Success {
-		ctx.JSON(http.StatusBadRequest, response)
+	// Call use case
+	resp, err := c.appUseCase.GetApplicationEvents(ctx.Request.Context(), ctx.Param("id"), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve application history")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetStatusGraph handles GET /api/v1/admin/status-transitions
+// Returns the active application status transition graph
+func (c *ApplicationController) GetStatusGraph(ctx *gin.Context) {
+	resp, err := c.appUseCase.GetStatusGraph(ctx.Request.Context())
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve status transitions")
 		return
 	}
 
-	ctx.JSON(http.StatusOK, response)
+	ctx.JSON(http.StatusOK, resp)
 }
 
-// uploadToCloudinary is a helper function to handle file uploads to Cloudinary
-func (c *ApplicationController) uploadToCloudinary(file multipart.File, header *multipart.FileHeader) (string, error) {
-	// In a real implementation, you would upload the file to Cloudinary here
-	// This is a simplified version that saves the file locally for demonstration
+// UpdateStatusGraph handles PUT /api/v1/admin/status-transitions
+// Stores a transition graph replacing the configured one
+func (c *ApplicationController) UpdateStatusGraph(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.ApplicationResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.UpdateStatusTransitionsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.ApplicationResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
 
-	// Generate a unique filename
-	ext := filepath.Ext(header.Filename)
-	filename := uuid.New().String() + ext
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
 
-	// Create the uploads folder if it doesn't exist
-	if err := os.MkdirAll("uploads", 0755); err != nil {
-		return "", err
+		ctx.JSON(http.StatusBadRequest, domain.ApplicationResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
 	}
 
-	// Create a new file in the uploads directory
-	dst, err := os.Create(filepath.Join("uploads", filename))
+	resp, err := c.appUseCase.UpdateStatusTransitions(ctx.Request.Context(), &req, userID.(string))
 	if err != nil {
-		return "", err
+		response.Error(ctx, err, "Failed to update status transitions")
+		return
 	}
-	defer dst.Close()
 
-	// Copy the uploaded file to the destination file
-	if _, err := io.Copy(dst, file); err != nil {
-		return "", err
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ResetStatusGraph handles DELETE /api/v1/admin/status-transitions
+// Goes back to the transition graph of the configuration
+func (c *ApplicationController) ResetStatusGraph(ctx *gin.Context) {
+	resp, err := c.appUseCase.ResetStatusTransitions(ctx.Request.Context())
+	if err != nil {
+		response.Error(ctx, err, "Failed to reset status transitions")
+		return
 	}
 
-	// In a real implementation, you would upload to Cloudinary here
-	// For now, we'll just return a placeholder URL
-	return "/uploads/" + filename, nil
-}
\ No newline at end of file
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// uploadResume is a helper function to handle resume uploads to the storage provider
+func (c *ApplicationController) uploadResume(ctx context.Context, file multipart.File, header *multipart.FileHeader) (string, error) {
+	return uploadFile(ctx, c.storage, file, header.Filename, header.Header.Get("Content-Type"))
+}
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type ApplicationNoteController struct {
+	noteUsecase     usecase.ApplicationNoteUsecase
+	activityUsecase usecase.ActivityUsecase
+	validator       *validator.Validate
+	urls            *response.URLBuilder
+}
+
+func NewApplicationNoteController(noteUsecase usecase.ApplicationNoteUsecase, activityUsecase usecase.ActivityUsecase, urls *response.URLBuilder) *ApplicationNoteController {
+	return &ApplicationNoteController{
+		noteUsecase:     noteUsecase,
+		activityUsecase: activityUsecase,
+		validator:       validator.New(),
+		urls:            urls,
+	}
+}
+
+// AddNote handles POST /api/v1/applications/:id/notes
+// Teammates mentioned as @email in the body are notified
+func (c *ApplicationNoteController) AddNote(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.ApplicationNoteResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.CreateApplicationNoteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.ApplicationNoteResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.ApplicationNoteResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.noteUsecase.AddNote(ctx.Request.Context(), ctx.Param("id"), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to add note")
+		return
+	}
+
+	note := resp.Data.(*domain.ApplicationNote)
+	resp.URL = c.urls.ApplicationNotes(note.ApplicationID)
+	response.Created(ctx, resp.URL, resp)
+}
+
+// ListNotes handles GET /api/v1/applications/:id/notes
+func (c *ApplicationNoteController) ListNotes(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.ApplicationNoteResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.noteUsecase.ListNotes(ctx.Request.Context(), ctx.Param("id"), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve notes")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ListActivity handles GET /api/v1/users/me/activity
+func (c *ApplicationNoteController) ListActivity(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.ActivityListResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Parse query parameters
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	// Call use case
+	resp, err := c.activityUsecase.ListActivity(ctx.Request.Context(), userID.(string), page, limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve activity")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// MarkActivityRead handles POST /api/v1/users/me/activity/read
+func (c *ApplicationNoteController) MarkActivityRead(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.ActivityListResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.activityUsecase.MarkAllRead(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to mark activity as read")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type BackupController struct {
+	backupUsecase usecase.BackupUsecase
+}
+
+func NewBackupController(backupUsecase usecase.BackupUsecase) *BackupController {
+	return &BackupController{
+		backupUsecase: backupUsecase,
+	}
+}
+
+// StartBackup handles POST /api/v1/admin/backups
+// The backup runs in the background; poll GET /api/v1/admin/backups/:id for its status
+func (c *BackupController) StartBackup(ctx *gin.Context) {
+	adminID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.BackupResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.backupUsecase.StartBackup(ctx.Request.Context(), adminID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to start backup")
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, resp)
+}
+
+// ListBackups handles GET /api/v1/admin/backups
+func (c *BackupController) ListBackups(ctx *gin.Context) {
+	// Parse query parameters
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	// Call use case
+	resp, err := c.backupUsecase.ListBackups(ctx.Request.Context(), page, limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve backups")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetBackup handles GET /api/v1/admin/backups/:id
+func (c *BackupController) GetBackup(ctx *gin.Context) {
+	// Call use case
+	resp, err := c.backupUsecase.GetBackup(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve backup")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// VerifyBackup handles POST /api/v1/admin/backups/:id/verify
+// The archive is read back in the background; the outcome is reported in the backup's verification
+func (c *BackupController) VerifyBackup(ctx *gin.Context) {
+	adminID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.BackupResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.backupUsecase.VerifyBackup(ctx.Request.Context(), adminID.(string), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to verify backup")
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, resp)
+}
@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type CategoryController struct {
+	categoryUsecase usecase.CategoryUsecase
+	validator       *validator.Validate
+	urls            *response.URLBuilder
+}
+
+func NewCategoryController(categoryUsecase usecase.CategoryUsecase, urls *response.URLBuilder) *CategoryController {
+	return &CategoryController{
+		categoryUsecase: categoryUsecase,
+		validator:       validator.New(),
+		urls:            urls,
+	}
+}
+
+// ListCategories handles GET /api/v1/categories and GET /api/v1/admin/categories
+// Categories come with their number of published jobs, browse a category's
+// jobs with GET /api/v1/jobs?category=<slug>
+func (c *CategoryController) ListCategories(ctx *gin.Context) {
+	// Call use case
+	resp, err := c.categoryUsecase.ListCategories(ctx.Request.Context())
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve categories")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// CreateCategory handles POST /api/v1/admin/categories
+func (c *CategoryController) CreateCategory(ctx *gin.Context) {
+	var req domain.CreateCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.CategoryResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	if !c.validate(ctx, req) {
+		return
+	}
+
+	// Call use case
+	resp, err := c.categoryUsecase.CreateCategory(ctx.Request.Context(), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to create category")
+		return
+	}
+
+	category := resp.Data.(*domain.Category)
+	resp.URL = c.urls.Category(category.ID.Hex())
+	response.Created(ctx, resp.URL, resp)
+}
+
+// UpdateCategory handles PUT /api/v1/admin/categories/:id
+// The slug can't be changed since jobs reference it
+func (c *CategoryController) UpdateCategory(ctx *gin.Context) {
+	var req domain.UpdateCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.CategoryResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	if !c.validate(ctx, req) {
+		return
+	}
+
+	// Call use case
+	resp, err := c.categoryUsecase.UpdateCategory(ctx.Request.Context(), ctx.Param("id"), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to update category")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteCategory handles DELETE /api/v1/admin/categories/:id
+// Categories still used by jobs can't be deleted
+func (c *CategoryController) DeleteCategory(ctx *gin.Context) {
+	// Call use case
+	resp, err := c.categoryUsecase.DeleteCategory(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to delete category")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *CategoryController) validate(ctx *gin.Context, req interface{}) bool {
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.CategoryResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type CompanyProfileController struct {
+	profileUsecase usecase.CompanyProfileUsecase
+	validator      *validator.Validate
+}
+
+func NewCompanyProfileController(profileUsecase usecase.CompanyProfileUsecase) *CompanyProfileController {
+	return &CompanyProfileController{
+		profileUsecase: profileUsecase,
+		validator:      validator.New(),
+	}
+}
+
+// GetProfile handles GET /api/v1/companies/me/profile
+func (c *CompanyProfileController) GetProfile(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.CompanyProfileResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.profileUsecase.GetProfile(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve company profile")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// SaveProfile handles PUT /api/v1/companies/me/profile
+// The request replaces the stored profile
+func (c *CompanyProfileController) SaveProfile(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.CompanyProfileResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.SaveCompanyProfileRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.CompanyProfileResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.CompanyProfileResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.profileUsecase.SaveProfile(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to save company profile")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteProfile handles DELETE /api/v1/companies/me/profile
+func (c *CompanyProfileController) DeleteProfile(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.CompanyProfileResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.profileUsecase.DeleteProfile(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to delete company profile")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetPublicPage handles GET /api/v1/companies/:id
+// Public employer page: the company's profile and its published jobs, paginated with ?page= and ?limit=
+func (c *CompanyProfileController) GetPublicPage(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	// Call use case
+	resp, err := c.profileUsecase.GetPublicPage(ctx.Request.Context(), ctx.Param("id"), page, limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve company page")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
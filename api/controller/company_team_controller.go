@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type CompanyTeamController struct {
+	teamUsecase usecase.CompanyTeamUsecase
+	validator   *validator.Validate
+}
+
+func NewCompanyTeamController(teamUsecase usecase.CompanyTeamUsecase) *CompanyTeamController {
+	return &CompanyTeamController{
+		teamUsecase: teamUsecase,
+		validator:   validator.New(),
+	}
+}
+
+// ListMembers handles GET /api/v1/companies/me/members
+func (c *CompanyTeamController) ListMembers(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.CompanyTeamResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.teamUsecase.ListMembers(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve team members")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// InviteMember handles POST /api/v1/companies/me/members/invite
+func (c *CompanyTeamController) InviteMember(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.CompanyTeamResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.InviteMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.CompanyTeamResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.CompanyTeamResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.teamUsecase.InviteMember(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to invite member")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// AcceptInvitation handles POST /api/v1/companies/invitations/accept
+// The invitation must have been sent to the signed in user's email address
+func (c *CompanyTeamController) AcceptInvitation(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.CompanyTeamResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.AcceptInvitationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.CompanyTeamResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.CompanyTeamResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.teamUsecase.AcceptInvitation(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to accept invitation")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RemoveMember handles DELETE /api/v1/companies/me/members/:id
+func (c *CompanyTeamController) RemoveMember(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.CompanyTeamResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.teamUsecase.RemoveMember(ctx.Request.Context(), userID.(string), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to remove member")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
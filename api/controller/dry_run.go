@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/api/response"
+	apperrors "job-portal-backend/pkg/errors"
+)
+
+// parseDryRun reads the ?dry_run= flag of destructive endpoints. With
+// dry_run=true the use case validates the request and reports what would
+// change without changing anything. An invalid value writes a 400 response
+// and returns ok false.
+func parseDryRun(ctx *gin.Context) (dryRun bool, ok bool) {
+	value := ctx.Query("dry_run")
+	if value == "" {
+		return false, true
+	}
+
+	dryRun, err := strconv.ParseBool(value)
+	if err != nil {
+		response.Error(ctx, apperrors.NewBadRequestError("Invalid dry_run flag", []string{"dry_run must be true or false"}), "")
+		return false, false
+	}
+	return dryRun, true
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type EventController struct {
+	eventBus  usecase.EventBus
+	validator *validator.Validate
+}
+
+func NewEventController(eventBus usecase.EventBus) *EventController {
+	return &EventController{
+		eventBus:  eventBus,
+		validator: validator.New(),
+	}
+}
+
+// ListEvents handles GET /api/v1/admin/events
+// Supports filtering by ?type= and ?status= (pending, delivered or failed)
+func (c *EventController) ListEvents(ctx *gin.Context) {
+	filter := domain.DomainEventFilter{
+		Type:   domain.DomainEventType(ctx.Query("type")),
+		Status: domain.DomainEventStatus(ctx.Query("status")),
+	}
+
+	switch filter.Status {
+	case "", domain.EventStatusPending, domain.EventStatusDelivered, domain.EventStatusFailed:
+	default:
+		ctx.JSON(http.StatusBadRequest, domain.DomainEventListResponse{
+			Success: false,
+			Message: "Invalid status filter",
+			Errors:  []string{"status must be pending, delivered or failed"},
+		})
+		return
+	}
+
+	// Get pagination parameters
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	// Call use case
+	resp, err := c.eventBus.ListEvents(ctx.Request.Context(), filter, page, limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve events")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ReplayEvents handles POST /api/v1/admin/events/replay
+// Events that occurred in the period are delivered again to every webhook
+func (c *EventController) ReplayEvents(ctx *gin.Context) {
+	var req domain.ReplayEventsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.DomainEventResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.DomainEventResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.eventBus.ReplayEvents(ctx.Request.Context(), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to replay events")
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, resp)
+}
+
+// ReplayEvent handles POST /api/v1/admin/events/:id/replay
+func (c *EventController) ReplayEvent(ctx *gin.Context) {
+	// Call use case
+	resp, err := c.eventBus.ReplayEvent(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to replay event")
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, resp)
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/constants"
+	"job-portal-backend/usecase"
+)
+
+// FeedTokenController manages the tokens of the job board feed. Companies
+// manage the tokens of their own jobs, admins those listing every job.
+type FeedTokenController struct {
+	feedTokenUsecase usecase.FeedTokenUsecase
+	validator        *validator.Validate
+	urls             *response.URLBuilder
+}
+
+func NewFeedTokenController(feedTokenUsecase usecase.FeedTokenUsecase, urls *response.URLBuilder) *FeedTokenController {
+	return &FeedTokenController{
+		feedTokenUsecase: feedTokenUsecase,
+		validator:        validator.New(),
+		urls:             urls,
+	}
+}
+
+// CreateToken handles POST /api/v1/companies/me/feed-tokens and POST /api/v1/admin/feed-tokens
+func (c *FeedTokenController) CreateToken(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.FeedTokenResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.CreateFeedTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.FeedTokenResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.FeedTokenResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.feedTokenUsecase.CreateToken(ctx.Request.Context(), userID.(string), isAdmin(ctx), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to create feed token")
+		return
+	}
+
+	token := resp.Data.(*domain.CreatedFeedToken)
+	if isAdmin(ctx) {
+		resp.URL = c.urls.AdminFeedToken(token.ID.Hex())
+	} else {
+		resp.URL = c.urls.FeedToken(token.ID.Hex())
+	}
+	response.Created(ctx, resp.URL, resp)
+}
+
+// ListTokens handles GET /api/v1/companies/me/feed-tokens and GET /api/v1/admin/feed-tokens
+func (c *FeedTokenController) ListTokens(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.FeedTokenResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.feedTokenUsecase.ListTokens(ctx.Request.Context(), userID.(string), isAdmin(ctx))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve feed tokens")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RevokeToken handles DELETE /api/v1/companies/me/feed-tokens/:id and DELETE /api/v1/admin/feed-tokens/:id
+func (c *FeedTokenController) RevokeToken(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.FeedTokenResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.feedTokenUsecase.RevokeToken(ctx.Request.Context(), userID.(string), isAdmin(ctx), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to revoke feed token")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// isAdmin reports whether the request is an admin's, whose feed tokens list
+// the jobs of every company
+func isAdmin(ctx *gin.Context) bool {
+	userRole, _ := ctx.Get("userRole")
+	role, _ := userRole.(string)
+	return role == constants.RoleAdmin
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/pkg/storage"
+	"job-portal-backend/usecase"
+)
+
+type FileController struct {
+	downloader storage.Downloader
+	fileAccess usecase.FileAccessUsecase
+	baseURL    string
+}
+
+// NewFileController serves the files stored by downloader, whose URLs are
+// baseURL + "/" + key
+func NewFileController(downloader storage.Downloader, fileAccess usecase.FileAccessUsecase, baseURL string) *FileController {
+	return &FileController{
+		downloader: downloader,
+		fileAccess: fileAccess,
+		baseURL:    baseURL,
+	}
+}
+
+// DownloadFile handles GET /api/v1/files/:key
+// Streams a stored file (e.g. a resume) from the storage provider to the users
+// allowed to see the record it belongs to
+func (c *FileController) DownloadFile(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.UserResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+	userRole, _ := ctx.Get("userRole")
+	role, _ := userRole.(string)
+
+	key := ctx.Param("key")
+	if key == "" {
+		response.Error(ctx, apperrors.NewBadRequestError("File key is required", nil), "Failed to download file")
+		return
+	}
+
+	if err := c.fileAccess.AuthorizeDownload(ctx.Request.Context(), c.baseURL+"/"+key, userID.(string), role); err != nil {
+		if _, ok := apperrors.As(err); !ok {
+			log.Printf("Failed to authorize download of %s: %v", key, err)
+			err = apperrors.NewInternalServerError(err)
+		}
+		response.Error(ctx, err, "Failed to download file")
+		return
+	}
+
+	reader, info, err := c.downloader.Download(ctx.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrFileNotFound) {
+			response.Error(ctx, apperrors.NewNotFoundError("File not found"), "Failed to download file")
+			return
+		}
+		log.Printf("Failed to download %s: %v", key, err)
+		response.Error(ctx, apperrors.NewInternalServerError(err), "Failed to download file")
+		return
+	}
+	defer reader.Close()
+
+	contentType := info.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	// Stream the file instead of buffering it in memory
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Length", strconv.FormatInt(info.Size, 10))
+	ctx.Header("Content-Disposition", "inline; filename=\""+info.Key+"\"")
+	ctx.Status(http.StatusOK)
+	_, _ = io.Copy(ctx.Writer, reader)
+}
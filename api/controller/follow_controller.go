@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type FollowController struct {
+	followUsecase usecase.FollowUsecase
+}
+
+func NewFollowController(followUsecase usecase.FollowUsecase) *FollowController {
+	return &FollowController{
+		followUsecase: followUsecase,
+	}
+}
+
+// Follow handles POST /api/v1/companies/:id/follow
+// Following a company twice is not an error
+func (c *FollowController) Follow(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.FollowResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.followUsecase.Follow(ctx.Request.Context(), userID.(string), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to follow company")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Unfollow handles DELETE /api/v1/companies/:id/follow
+func (c *FollowController) Unfollow(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.FollowResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.followUsecase.Unfollow(ctx.Request.Context(), userID.(string), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to unfollow company")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ListFollowing handles GET /api/v1/users/me/following
+func (c *FollowController) ListFollowing(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.FollowListResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Parse query parameters
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	// Call use case
+	resp, err := c.followUsecase.ListFollowing(ctx.Request.Context(), userID.(string), page, limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve followed companies")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type InterviewFeedbackController struct {
+	feedbackUsecase usecase.InterviewFeedbackUsecase
+	validator       *validator.Validate
+}
+
+func NewInterviewFeedbackController(feedbackUsecase usecase.InterviewFeedbackUsecase) *InterviewFeedbackController {
+	return &InterviewFeedbackController{
+		feedbackUsecase: feedbackUsecase,
+		validator:       validator.New(),
+	}
+}
+
+// ScheduleInterview handles PUT /api/v1/applications/:id/interview
+func (c *InterviewFeedbackController) ScheduleInterview(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.ApplicationResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.ScheduleInterviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.ApplicationResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.ApplicationResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.feedbackUsecase.ScheduleInterview(ctx.Request.Context(), ctx.Param("id"), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to schedule interview")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// SubmitFeedback handles POST /api/v1/applications/:id/interview-feedback
+// The feedback is stored anonymously and is about the process, not the decision
+func (c *InterviewFeedbackController) SubmitFeedback(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.InterviewFeedbackResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.SubmitInterviewFeedbackRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.InterviewFeedbackResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.InterviewFeedbackResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.feedbackUsecase.SubmitFeedback(ctx.Request.Context(), ctx.Param("id"), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to submit interview feedback")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// GetCompanyFeedback handles GET /api/v1/companies/me/reports/interview-feedback
+func (c *InterviewFeedbackController) GetCompanyFeedback(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.InterviewFeedbackResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.feedbackUsecase.CompanySummary(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve interview feedback")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ListFeedbackSummaries handles GET /api/v1/admin/reports/interview-feedback
+// With ?company_id= it returns that company's summary and recent comments
+func (c *InterviewFeedbackController) ListFeedbackSummaries(ctx *gin.Context) {
+	var (
+		resp *domain.InterviewFeedbackResponse
+		err  error
+	)
+	if companyID := ctx.Query("company_id"); companyID != "" {
+		resp, err = c.feedbackUsecase.AdminCompanyReport(ctx.Request.Context(), companyID)
+	} else {
+		resp, err = c.feedbackUsecase.AdminSummaries(ctx.Request.Context())
+	}
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve interview feedback")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
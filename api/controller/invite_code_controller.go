@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type InviteCodeController struct {
+	inviteCodeUsecase usecase.InviteCodeUsecase
+	validator         *validator.Validate
+	urls              *response.URLBuilder
+}
+
+func NewInviteCodeController(inviteCodeUsecase usecase.InviteCodeUsecase, urls *response.URLBuilder) *InviteCodeController {
+	return &InviteCodeController{
+		inviteCodeUsecase: inviteCodeUsecase,
+		validator:         validator.New(),
+		urls:              urls,
+	}
+}
+
+// ListCodes handles GET /api/v1/admin/invite-codes
+func (c *InviteCodeController) ListCodes(ctx *gin.Context) {
+	// Call use case
+	resp, err := c.inviteCodeUsecase.ListCodes(ctx.Request.Context())
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve invite codes")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// CreateCode handles POST /api/v1/admin/invite-codes
+func (c *InviteCodeController) CreateCode(ctx *gin.Context) {
+	// Get admin ID from context
+	adminID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.InviteCodeResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.CreateInviteCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.InviteCodeResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.InviteCodeResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.inviteCodeUsecase.CreateCode(ctx.Request.Context(), &req, adminID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to create invite code")
+		return
+	}
+
+	code := resp.Data.(*domain.InviteCode)
+	resp.URL = c.urls.InviteCode(code.ID.Hex())
+	response.Created(ctx, resp.URL, resp)
+}
+
+// DisableCode handles DELETE /api/v1/admin/invite-codes/:id
+func (c *InviteCodeController) DisableCode(ctx *gin.Context) {
+	// Call use case
+	resp, err := c.inviteCodeUsecase.DisableCode(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to disable invite code")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
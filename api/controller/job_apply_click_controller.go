@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type JobApplyClickController struct {
+	clickUsecase usecase.JobApplyClickUsecase
+}
+
+func NewJobApplyClickController(clickUsecase usecase.JobApplyClickUsecase) *JobApplyClickController {
+	return &JobApplyClickController{
+		clickUsecase: clickUsecase,
+	}
+}
+
+// ApplyRedirect handles GET /api/v1/jobs/:id/apply-redirect
+// Counts the click and redirects to the job's external apply link
+func (c *JobApplyClickController) ApplyRedirect(ctx *gin.Context) {
+	_, signedIn := ctx.Get("userID")
+
+	// Call use case
+	applyURL, err := c.clickUsecase.RecordClick(ctx.Request.Context(), ctx.Param("id"), signedIn)
+	if err != nil {
+		response.Error(ctx, err, "Failed to follow apply link")
+		return
+	}
+
+	// Every click goes through the portal, so the redirect must not be cached
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Redirect(http.StatusFound, applyURL)
+}
+
+// GetClickStats handles GET /api/v1/jobs/:id/apply-clicks
+func (c *JobApplyClickController) GetClickStats(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobApplyClickResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.clickUsecase.GetClickStats(ctx.Request.Context(), ctx.Param("id"), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve apply clicks")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type JobComparisonController struct {
+	comparisonUsecase usecase.JobComparisonUsecase
+}
+
+func NewJobComparisonController(comparisonUsecase usecase.JobComparisonUsecase) *JobComparisonController {
+	return &JobComparisonController{
+		comparisonUsecase: comparisonUsecase,
+	}
+}
+
+// CompareJobs handles GET /api/v1/jobs/compare?ids=a,b,c
+// Compares 2 to 5 published jobs side by side: salary per year (in ?currency=
+// when exchange rates are available), location, remote, required skills,
+// company rating and response time
+func (c *JobComparisonController) CompareJobs(ctx *gin.Context) {
+	preferredCurrency, ok := parseCurrency(ctx)
+	if !ok {
+		return
+	}
+
+	var ids []string
+	if raw := ctx.Query("ids"); raw != "" {
+		ids = strings.Split(raw, ",")
+	}
+
+	// Call use case
+	comparison, err := c.comparisonUsecase.CompareJobs(ctx.Request.Context(), ids, preferredCurrency)
+	if err != nil {
+		response.Error(ctx, err, "Failed to compare jobs")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, domain.JobResponse{
+		Success: true,
+		Message: "Jobs compared successfully",
+		Data:    comparison,
+	})
+}
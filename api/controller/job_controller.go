@@ -2,26 +2,37 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 
+	"job-portal-backend/api/response"
 	"job-portal-backend/domain"
+	"job-portal-backend/pkg/currency"
 	"job-portal-backend/usecase"
 )
 
 type JobController struct {
-	jobUseCase usecase.JobUseCase
-	validator  *validator.Validate
+	jobUseCase      usecase.JobUseCase
+	savedJobUseCase usecase.SavedJobUsecase
+	trendingUseCase usecase.TrendingUsecase
+	urls            *response.URLBuilder
+	validator       *validator.Validate
 }
 
-func NewJobController(jobUseCase usecase.JobUseCase) *JobController {
+func NewJobController(jobUseCase usecase.JobUseCase, savedJobUseCase usecase.SavedJobUsecase, trendingUseCase usecase.TrendingUsecase, urls *response.URLBuilder) *JobController {
 	return &JobController{
-		jobUseCase: jobUseCase,
-		validator:   validator.New(),
+		jobUseCase:      jobUseCase,
+		savedJobUseCase: savedJobUseCase,
+		trendingUseCase: trendingUseCase,
+		urls:            urls,
+		validator:       validator.New(),
 	}
 }
 
@@ -73,13 +84,15 @@ func (c *JobController) CreateJob(ctx *gin.Context) {
 		return
 	}
 
-	response, err := c.jobUseCase.CreateJob(context.Background(), &req, userID.(string))
+	resp, err := c.jobUseCase.CreateJob(context.Background(), &req, userID.(string))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response)
+		response.Error(ctx, err, "Failed to create job")
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, response)
+	job := resp.Data.(*domain.Job)
+	resp.URL = c.urls.Job(job.ID.Hex())
+	response.Created(ctx, resp.URL, resp)
 }
 
 // UpdateJob handles PUT /api/v1/jobs/:id
@@ -141,7 +154,9 @@ func (c *JobController) UpdateJob(ctx *gin.Context) {
 	}
 
 	// Check if any fields are provided for update
-	if req.Title == nil && req.Description == nil && req.Location == nil && req.IsPublished == nil {
+	if req.Title == nil && req.Description == nil && req.Location == nil && req.EmploymentType == nil && req.Category == nil &&
+		req.ExperienceLevel == nil && req.Remote == nil && req.Salary == nil && req.Skills == nil && req.IsPublished == nil && req.BlindScreening == nil &&
+		req.Deadline == nil && !req.ClearDeadline && req.PublishAt == nil && !req.ClearPublishAt && req.ExpiresAt == nil && !req.ClearExpiresAt {
 		ctx.JSON(http.StatusBadRequest, domain.JobResponse{
 			Success: false,
 			Message: "No fields to update",
@@ -149,30 +164,13 @@ func (c *JobController) UpdateJob(ctx *gin.Context) {
 		return
 	}
 
-	response, err := c.jobUseCase.UpdateJob(context.Background(), jobID, &req, userID.(string))
+	resp, err := c.jobUseCase.UpdateJob(context.Background(), jobID, &req, userID.(string))
 	if err != nil {
-		switch err.Error() {
-		case "job not found":
-			ctx.JSON(http.StatusNotFound, domain.JobResponse{
-				Success: false,
-				Message: "Job not found",
-			})
-		case "unauthorized access":
-			ctx.JSON(http.StatusForbidden, domain.JobResponse{
-				Success: false,
-				Message: "You don't have permission to update this job",
-			})
-		default:
-			ctx.JSON(http.StatusInternalServerError, domain.JobResponse{
-				Success: false,
-				Message: "Failed to update job",
-				Errors:  []string{err.Error()},
-			})
-		}
+		response.Error(ctx, err, "Failed to update job")
 		return
 	}
 
-	ctx.JSON(http.StatusOK, response)
+	ctx.JSON(http.StatusOK, resp)
 }
 
 // DeleteJob handles DELETE /api/v1/jobs/:id
@@ -210,46 +208,422 @@ func (c *JobController) DeleteJob(ctx *gin.Context) {
 	}
 
 	// Call use case to delete job
-	response, err := c.jobUseCase.DeleteJob(context.Background(), jobID, userID.(string))
+	resp, err := c.jobUseCase.DeleteJob(context.Background(), jobID, userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to delete job")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ConfirmHiring handles POST /api/v1/jobs/:id/confirm-hiring
+// Renews the job's actively hiring signal, which otherwise lapses after two weeks
+func (c *JobController) ConfirmHiring(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	resp, err := c.jobUseCase.ConfirmHiring(ctx.Request.Context(), ctx.Param("id"), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to confirm hiring status")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RepostJob handles POST /api/v1/jobs/:id/repost
+// Moves the job back to the top of the listings by refreshing its posting date
+func (c *JobController) RepostJob(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	resp, err := c.jobUseCase.RepostJob(ctx.Request.Context(), ctx.Param("id"), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to repost job")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// CloneJob handles POST /api/v1/jobs/:id/clone
+// Copies the job into a new unpublished draft
+func (c *JobController) CloneJob(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	resp, err := c.jobUseCase.CloneJob(ctx.Request.Context(), ctx.Param("id"), userID.(string))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, domain.JobResponse{
+		response.Error(ctx, err, "Failed to clone job")
+		return
+	}
+
+	job := resp.Data.(*domain.Job)
+	resp.URL = c.urls.Job(job.ID.Hex())
+	response.Created(ctx, resp.URL, resp)
+}
+
+// CreateTemplate handles POST /api/v1/jobs/templates
+// Saves an existing posting (job_id) or the given content as a reusable template
+func (c *JobController) CreateTemplate(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobTemplateResponse{
 			Success: false,
-			Message: "Failed to delete job",
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.CreateJobTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.JobTemplateResponse{
+			Success: false,
+			Message: "Invalid request body",
 			Errors:  []string{err.Error()},
 		})
 		return
 	}
 
-	if !response.Success {
-		ctx.JSON(http.StatusBadRequest, response)
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.JobTemplateResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	resp, err := c.jobUseCase.CreateTemplate(ctx.Request.Context(), &req, userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to save job template")
 		return
 	}
 
-	ctx.JSON(http.StatusOK, domain.JobResponse{
-		Success: true,
-		Message: "Job deleted successfully",
-	})
+	template := resp.Data.(*domain.JobTemplate)
+	resp.URL = c.urls.JobTemplate(template.ID.Hex())
+	response.Created(ctx, resp.URL, resp)
+}
+
+// ListTemplates handles GET /api/v1/jobs/templates
+func (c *JobController) ListTemplates(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobTemplateResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	resp, err := c.jobUseCase.ListTemplates(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve job templates")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteTemplate handles DELETE /api/v1/jobs/templates/:templateId
+func (c *JobController) DeleteTemplate(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobTemplateResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	resp, err := c.jobUseCase.DeleteTemplate(ctx.Request.Context(), ctx.Param("templateId"), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to delete job template")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// CreateJobFromTemplate handles POST /api/v1/jobs/from-template/:templateId
+// Creates a new unpublished draft with the template's content
+func (c *JobController) CreateJobFromTemplate(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	resp, err := c.jobUseCase.CreateJobFromTemplate(ctx.Request.Context(), ctx.Param("templateId"), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to create job from template")
+		return
+	}
+
+	job := resp.Data.(*domain.Job)
+	resp.URL = c.urls.Job(job.ID.Hex())
+	response.Created(ctx, resp.URL, resp)
+}
+
+// PublishJob handles POST /api/v1/jobs/:id/publish
+// The job must pass the publish checks, e.g. a complete description
+func (c *JobController) PublishJob(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	resp, err := c.jobUseCase.PublishJob(ctx.Request.Context(), ctx.Param("id"), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to publish job")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UnpublishJob handles POST /api/v1/jobs/:id/unpublish
+// Takes the job down, or cancels its publish schedule
+func (c *JobController) UnpublishJob(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	resp, err := c.jobUseCase.UnpublishJob(ctx.Request.Context(), ctx.Param("id"), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to unpublish job")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RestoreJob handles POST /api/v1/jobs/:id/restore
+// Brings a deleted job back from the archive as an unpublished draft
+func (c *JobController) RestoreJob(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	resp, err := c.jobUseCase.RestoreJob(ctx.Request.Context(), ctx.Param("id"), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to restore job")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetJobHistory handles GET /api/v1/jobs/:id/history
+// Lists the job's edits with the value of each changed field before and after
+func (c *JobController) GetJobHistory(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobRevisionListResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Get pagination parameters
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	resp, err := c.jobUseCase.GetJobHistory(ctx.Request.Context(), ctx.Param("id"), userID.(string), page, limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve job history")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// CloseJob handles POST /api/v1/jobs/:id/close
+// Unpublishes the job for good and records why it was closed
+func (c *JobController) CloseJob(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.CloseJobRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.JobResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.JobResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	resp, err := c.jobUseCase.CloseJob(ctx.Request.Context(), ctx.Param("id"), &req, userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to close job")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// FeatureJob handles POST /api/v1/jobs/:id/feature
+// Featured jobs come first in the job listings until their feature runs out
+func (c *JobController) FeatureJob(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.FeatureJobRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.JobResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.JobResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	resp, err := c.jobUseCase.FeatureJob(ctx.Request.Context(), ctx.Param("id"), &req, userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to feature job")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UnfeatureJob handles DELETE /api/v1/jobs/:id/feature
+func (c *JobController) UnfeatureJob(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.JobResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	resp, err := c.jobUseCase.UnfeatureJob(ctx.Request.Context(), ctx.Param("id"), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to unfeature job")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
 }
 
 // ListJobs handles GET /api/v1/jobs
 func (c *JobController) ListJobs(ctx *gin.Context) {
 	// Get query parameters
-	title := ctx.Query("title")
-	location := ctx.Query("location")
-	companyName := ctx.Query("company")
+	filter, ok := parseJobFilter(ctx)
+	if !ok {
+		return
+	}
 	
 	// Get pagination parameters
 	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
 
 	// Call use case to list jobs with filters
-	jobs, total, err := c.jobUseCase.ListJobs(context.Background(), title, location, companyName, page, limit)
+	jobs, total, err := c.jobUseCase.ListJobs(ctx.Request.Context(), filter, page, limit)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, domain.JobListResponse{
-			Success: false,
-			Message: "Failed to retrieve jobs",
-			Errors:  []string{err.Error()},
-		})
+		response.Error(ctx, err, "Failed to retrieve jobs")
+		return
+	}
+	if !c.markSaved(ctx, jobs...) {
 		return
 	}
 
@@ -259,8 +633,8 @@ func (c *JobController) ListJobs(ctx *gin.Context) {
 		totalPages = 1
 	}
 
-	// Return paginated response
-	ctx.JSON(http.StatusOK, domain.JobListResponse{
+	// Return paginated response in the format requested by the client
+	response.List(ctx, http.StatusOK, domain.JobListResponse{
 		Success:    true,
 		Message:    "Jobs retrieved successfully",
 		Data:       jobs,
@@ -274,11 +648,191 @@ func (c *JobController) ListJobs(ctx *gin.Context) {
 			TotalItems: total,
 			TotalPages: totalPages,
 		},
+	}, "jobs", func() response.Table { return response.JobsTable(jobs) })
+}
+
+// GetJobChanges handles GET /api/v1/jobs/changes?since=
+// Lets mobile clients sync the job list incrementally. since is an RFC 3339
+// timestamp (usually the checkpoint returned by the previous sync) or Unix seconds.
+func (c *JobController) GetJobChanges(ctx *gin.Context) {
+	since, err := parseSince(ctx.Query("since"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.JobResponse{
+			Success: false,
+			Message: "Invalid since parameter",
+			Errors:  []string{"since must be an RFC 3339 timestamp or Unix seconds"},
+		})
+		return
+	}
+
+	changes, err := c.jobUseCase.GetJobChanges(ctx.Request.Context(), since)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve job changes")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, domain.JobResponse{
+		Success: true,
+		Message: "Job changes retrieved successfully",
+		Data:    changes,
+	})
+}
+
+// parseSince parses a sync checkpoint given as RFC 3339 or Unix seconds
+func parseSince(value string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+// GetJobFormMeta handles GET /api/v1/meta/job-form
+// Describes the job form fields and their validation limits so client forms
+// follow the server's rules without hard-coding them
+func (c *JobController) GetJobFormMeta(ctx *gin.Context) {
+	meta, err := c.jobUseCase.GetJobFormMeta(ctx.Request.Context())
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve job form metadata")
+		return
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, domain.JobResponse{
+		Success: true,
+		Message: "Job form metadata retrieved successfully",
+		Data:    meta,
+	})
+}
+
+// GetSkillFacets handles GET /api/v1/meta/skills
+// Lists the skills most required by published jobs, with their job counts,
+// for skill filter suggestions
+func (c *JobController) GetSkillFacets(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+
+	facets, err := c.jobUseCase.ListSkillFacets(ctx.Request.Context(), limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve skills")
+		return
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, domain.JobResponse{
+		Success: true,
+		Message: "Skills retrieved successfully",
+		Data:    facets,
+	})
+}
+
+// GetJobFacets handles GET /api/v1/jobs/facets
+// Counts the jobs matching the listing filters (same query parameters as
+// GET /api/v1/jobs) per location, category, employment type and company,
+// for filter sidebars
+func (c *JobController) GetJobFacets(ctx *gin.Context) {
+	filter, ok := parseJobFilter(ctx)
+	if !ok {
+		return
+	}
+
+	facets, err := c.jobUseCase.GetJobFacets(ctx.Request.Context(), filter)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve job facets")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, domain.JobResponse{
+		Success: true,
+		Message: "Job facets retrieved successfully",
+		Data:    facets,
+	})
+}
+
+// SuggestJobs handles GET /api/v1/jobs/suggest
+// Lists the job titles, locations and company names starting with ?q= for
+// search box autocomplete, up to ?limit= (5 by default, at most 10) of each
+func (c *JobController) SuggestJobs(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "5"))
+
+	suggestions, err := c.jobUseCase.SuggestJobs(ctx.Request.Context(), ctx.Query("q"), limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve suggestions")
+		return
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=60")
+	ctx.JSON(http.StatusOK, domain.JobResponse{
+		Success: true,
+		Message: "Suggestions retrieved successfully",
+		Data:    suggestions,
+	})
+}
+
+// GetSimilarJobs handles GET /api/v1/jobs/:id/similar
+// Lists other published jobs sharing skills, the category or the location
+// with the job, most in common first, up to ?limit= (5 by default, at most 20)
+func (c *JobController) GetSimilarJobs(ctx *gin.Context) {
+	preferredCurrency, ok := parseCurrency(ctx)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "5"))
+
+	jobs, err := c.jobUseCase.GetSimilarJobs(ctx.Request.Context(), ctx.Param("id"), limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve similar jobs")
+		return
+	}
+	c.jobUseCase.ConvertSalaries(ctx.Request.Context(), preferredCurrency, jobs...)
+	if !c.markSaved(ctx, jobs...) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, domain.JobResponse{
+		Success: true,
+		Message: "Similar jobs retrieved successfully",
+		Data:    jobs,
+	})
+}
+
+// GetTrendingJobs handles GET /api/v1/jobs/trending
+// Lists the jobs with the most views and applications over the last week,
+// or with ?by=applications the most applied jobs, up to ?limit= (10 by
+// default, at most 50). Rankings are refreshed every 15 minutes.
+func (c *JobController) GetTrendingJobs(ctx *gin.Context) {
+	preferredCurrency, ok := parseCurrency(ctx)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	trending, err := c.trendingUseCase.ListTrending(ctx.Request.Context(), ctx.Query("by"), limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve trending jobs")
+		return
+	}
+
+	jobs := make([]*domain.Job, len(trending))
+	for i, t := range trending {
+		jobs[i] = t.Job
+	}
+	c.jobUseCase.ConvertSalaries(ctx.Request.Context(), preferredCurrency, jobs...)
+	if !c.markSaved(ctx, jobs...) {
+		return
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=60")
+	ctx.JSON(http.StatusOK, domain.JobResponse{
+		Success: true,
+		Message: "Trending jobs retrieved successfully",
+		Data:    trending,
 	})
 }
 
 // GetMyJobs handles GET /api/v1/me/jobs
 // User Story 8: View My Posted Jobs (Company Only)
+// Filters: status (draft, published, pending_review or archived), title, from and to (YYYY-MM-DD,
+// both included) on the creation date. Deleted jobs are only listed with
+// ?status=archived. Each job comes with its number of applications.
 func (c *JobController) GetMyJobs(ctx *gin.Context) {
 	// Get user ID from context
 	userID, exists := ctx.Get("userID")
@@ -302,25 +856,67 @@ func (c *JobController) GetMyJobs(ctx *gin.Context) {
 		return
 	}
 
+	filter := domain.CompanyJobFilter{
+		Status: domain.JobListStatus(ctx.Query("status")),
+		Title:  ctx.Query("title"),
+	}
+	switch filter.Status {
+	case "", domain.JobStatusDraft, domain.JobStatusPublished, domain.JobStatusPendingReview, domain.JobStatusArchived:
+	default:
+		ctx.JSON(http.StatusBadRequest, domain.JobListResponse{
+			Success: false,
+			Message: "Invalid status filter",
+			Errors:  []string{"status must be one of draft, published, pending_review or archived"},
+		})
+		return
+	}
+	if from := ctx.Query("from"); from != "" {
+		day, err := time.Parse(reportDateLayout, from)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, domain.JobListResponse{
+				Success: false,
+				Message: "Invalid from date",
+				Errors:  []string{"from must be formatted YYYY-MM-DD"},
+			})
+			return
+		}
+		filter.CreatedFrom = day
+	}
+	if to := ctx.Query("to"); to != "" {
+		day, err := time.Parse(reportDateLayout, to)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, domain.JobListResponse{
+				Success: false,
+				Message: "Invalid to date",
+				Errors:  []string{"to must be formatted YYYY-MM-DD"},
+			})
+			return
+		}
+		filter.CreatedTo = day.AddDate(0, 0, 1)
+	}
+
 	// Parse pagination parameters
 	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
 
+	// Team members see the jobs of the company they work for
+	companyID, err := c.jobUseCase.ActingCompany(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve jobs")
+		return
+	}
+
 	// Get jobs for the company
-	jobs, total, err := c.jobUseCase.GetJobsByCompanyID(ctx, userID.(string), page, limit)
+	jobs, total, err := c.jobUseCase.GetJobsByCompanyID(ctx, companyID, filter, page, limit)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, domain.JobListResponse{
-			Success: false,
-			Message: "Failed to retrieve jobs",
-			Errors:  []string{err.Error()},
-		})
+		response.Error(ctx, err, "Failed to retrieve jobs")
 		return
 	}
 
 	// Calculate pagination metadata
 	totalPages := int(math.Ceil(float64(total) / float64(limit)))
 
-	ctx.JSON(http.StatusOK, domain.JobListResponse{
+	response.List(ctx, http.StatusOK, domain.JobListResponse{
 		Success:    true,
 		Message:    "Jobs retrieved successfully",
 		Data:       jobs,
@@ -334,7 +930,7 @@ func (c *JobController) GetMyJobs(ctx *gin.Context) {
 			TotalItems: total,
 			TotalPages: totalPages,
 		},
-	})
+	}, "jobs", func() response.Table { return response.JobsTable(jobs) })
 }
 
 // GetJobDetails handles GET /api/v1/jobs/:id/details
@@ -351,23 +947,15 @@ func (c *JobController) GetJobDetails(ctx *gin.Context) {
 		return
 	}
 
+	preferredCurrency, ok := parseCurrency(ctx)
+	if !ok {
+		return
+	}
+
 	// Get job details
 	job, err := c.jobUseCase.GetJobByID(ctx, jobID)
 	if err != nil {
-		if err.Error() == "job not found" {
-			ctx.JSON(http.StatusNotFound, domain.JobResponse{
-				Success: false,
-				Message: "Not Found",
-				Errors:  []string{"Job not found"},
-			})
-			return
-		}
-
-		ctx.JSON(http.StatusInternalServerError, domain.JobResponse{
-			Success: false,
-			Message: "Internal Server Error",
-			Errors:  []string{err.Error()},
-		})
+		response.Error(ctx, err, "Internal Server Error")
 		return
 	}
 
@@ -375,11 +963,20 @@ func (c *JobController) GetJobDetails(ctx *gin.Context) {
 	userID, _ := ctx.Get("userID")
 	userRole, _ := ctx.Get("userRole")
 
-	// Check if job is published or if the user is the owner
-	isOwner := job.CreatedBy == userID
+	// Check if job is published or if the user is the owner or on the owner's team
+	isOwner := false
+	if id, ok := userID.(string); ok && userRole == "company" {
+		companyID, err := c.jobUseCase.ActingCompany(ctx.Request.Context(), id)
+		if err != nil {
+			response.Error(ctx, err, "Internal Server Error")
+			return
+		}
+		isOwner = job.CreatedBy == companyID
+	}
 
-	// If job is not published and user is not the owner, return 404
-	if !job.IsPublished && !isOwner && userRole != "admin" {
+	// If job is not published and user is not the owner, return 404. Jobs
+	// closed by their application cap stay visible, marked closed.
+	if !job.IsPublished && !job.ApplicationsCapped() && !isOwner && userRole != "admin" {
 		ctx.JSON(http.StatusNotFound, domain.JobResponse{
 			Success: false,
 			Message: "Not Found",
@@ -388,12 +985,30 @@ func (c *JobController) GetJobDetails(ctx *gin.Context) {
 		return
 	}
 
+	// Views of the company's own team and of admins don't make a job trend
+	if job.IsPublished && !isOwner && userRole != "admin" {
+		c.trendingUseCase.RecordView(job.ID)
+	}
+
+	// Embed the company instead of leaving clients with the created_by ID
+	company, err := c.jobUseCase.GetCompanyInfo(ctx.Request.Context(), job.CreatedBy)
+	if err != nil {
+		response.Error(ctx, err, "Internal Server Error")
+		return
+	}
+	c.jobUseCase.ConvertSalaries(ctx.Request.Context(), preferredCurrency, job)
+	if !c.markSaved(ctx, job) {
+		return
+	}
+
 	// Create response DTO
-	response := struct {
+	jobDetails := struct {
 		*domain.Job
-		IsOwner bool `json:"is_owner,omitempty"`
+		Company *domain.CompanyInfo `json:"company,omitempty"`
+		IsOwner bool                `json:"is_owner,omitempty"`
 	}{
 		Job:     job,
+		Company: company,
 		IsOwner: isOwner,
 	}
 
@@ -408,6 +1023,182 @@ func (c *JobController) GetJobDetails(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, domain.JobResponse{
 		Success: true,
 		Message: "Job retrieved successfully",
-		Data:    response,
+		Data:    jobDetails,
 	})
-}
\ No newline at end of file
+}
+
+// markSaved flags the jobs a signed-in applicant saved. It writes the error
+// response and returns false when the saved jobs can't be read.
+func (c *JobController) markSaved(ctx *gin.Context, jobs ...*domain.Job) bool {
+	userID, _ := ctx.Get("userID")
+	id, ok := userID.(string)
+	if !ok || ctx.GetString("userRole") != "applicant" {
+		return true
+	}
+
+	if err := c.savedJobUseCase.MarkSaved(ctx.Request.Context(), id, jobs...); err != nil {
+		response.Error(ctx, err, "Failed to retrieve saved jobs")
+		return false
+	}
+	return true
+}
+
+// parseJobFilter reads the job listing filters from the query string
+func parseJobFilter(ctx *gin.Context) (domain.JobFilter, bool) {
+	filter := domain.JobFilter{
+		Query:       strings.TrimSpace(ctx.Query("q")),
+		Location:    ctx.Query("location"),
+		CompanyName: ctx.Query("company"),
+
+		Category:        ctx.Query("category"),
+		EmploymentType:  domain.EmploymentType(ctx.Query("employment_type")),
+		ExperienceLevel: domain.ExperienceLevel(ctx.Query("experience_level")),
+	}
+	// title is the search parameter of older clients
+	if filter.Query == "" {
+		filter.Query = strings.TrimSpace(ctx.Query("title"))
+	}
+
+	switch filter.EmploymentType {
+	case "", domain.FullTime, domain.PartTime, domain.Contract, domain.Internship, domain.Temporary:
+	default:
+		ctx.JSON(http.StatusBadRequest, domain.JobListResponse{
+			Success: false,
+			Message: "Invalid employment type filter",
+			Errors:  []string{"employment_type must be full_time, part_time, contract, internship or temporary"},
+		})
+		return filter, false
+	}
+
+	switch filter.ExperienceLevel {
+	case "", domain.EntryLevel, domain.JuniorLevel, domain.MidLevel, domain.SeniorLevel, domain.LeadLevel:
+	default:
+		ctx.JSON(http.StatusBadRequest, domain.JobListResponse{
+			Success: false,
+			Message: "Invalid experience level filter",
+			Errors:  []string{"experience_level must be entry, junior, mid, senior or lead"},
+		})
+		return filter, false
+	}
+
+	if raw := ctx.Query("remote"); raw != "" {
+		remote, err := strconv.ParseBool(raw)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, domain.JobListResponse{
+				Success: false,
+				Message: "Invalid remote filter",
+				Errors:  []string{"remote must be true or false"},
+			})
+			return filter, false
+		}
+		filter.Remote = &remote
+	}
+
+	if raw := ctx.Query("skills"); raw != "" {
+		filter.Skills = domain.NormalizeSkills(strings.Split(raw, ","))
+		if len(filter.Skills) > domain.MaxSkillFilters {
+			ctx.JSON(http.StatusBadRequest, domain.JobListResponse{
+				Success: false,
+				Message: "Invalid skills filter",
+				Errors:  []string{fmt.Sprintf("skills can list at most %d skills", domain.MaxSkillFilters)},
+			})
+			return filter, false
+		}
+	}
+
+	var ok bool
+	if filter.Currency, ok = parseCurrency(ctx); !ok {
+		return filter, false
+	}
+	if filter.SalaryMin, ok = parseSalaryBound(ctx, "salary_min"); !ok {
+		return filter, false
+	}
+	if filter.SalaryMax, ok = parseSalaryBound(ctx, "salary_max"); !ok {
+		return filter, false
+	}
+	if filter.SalaryMin != nil && filter.SalaryMax != nil && *filter.SalaryMin > *filter.SalaryMax {
+		ctx.JSON(http.StatusBadRequest, domain.JobListResponse{
+			Success: false,
+			Message: "Invalid salary filter",
+			Errors:  []string{"salary_min must not be greater than salary_max"},
+		})
+		return filter, false
+	}
+
+	if filter.Near, ok = parseGeoCircle(ctx); !ok {
+		return filter, false
+	}
+
+	return filter, true
+}
+
+// parseGeoCircle reads the optional lat, lng and radius_km distance filter
+// from the query string. radius_km defaults to domain.DefaultJobSearchRadiusKm.
+func parseGeoCircle(ctx *gin.Context) (*domain.GeoCircle, bool) {
+	rawLat, rawLng, rawRadius := ctx.Query("lat"), ctx.Query("lng"), ctx.Query("radius_km")
+	if rawLat == "" && rawLng == "" && rawRadius == "" {
+		return nil, true
+	}
+
+	invalid := func(message string) (*domain.GeoCircle, bool) {
+		ctx.JSON(http.StatusBadRequest, domain.JobListResponse{
+			Success: false,
+			Message: "Invalid distance filter",
+			Errors:  []string{message},
+		})
+		return nil, false
+	}
+
+	lat, err := strconv.ParseFloat(rawLat, 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return invalid("lat must be a latitude between -90 and 90")
+	}
+	lng, err := strconv.ParseFloat(rawLng, 64)
+	if err != nil || lng < -180 || lng > 180 {
+		return invalid("lng must be a longitude between -180 and 180")
+	}
+
+	radius := float64(domain.DefaultJobSearchRadiusKm)
+	if rawRadius != "" {
+		radius, err = strconv.ParseFloat(rawRadius, 64)
+		if err != nil || radius <= 0 || radius > domain.MaxJobSearchRadiusKm {
+			return invalid(fmt.Sprintf("radius_km must be greater than 0 and at most %d", domain.MaxJobSearchRadiusKm))
+		}
+	}
+
+	return &domain.GeoCircle{Lat: lat, Lng: lng, RadiusKm: radius}, true
+}
+
+// parseCurrency reads the ?currency= ISO 4217 code salaries are shown and
+// filtered in. Empty defaults to the currency of the requester's country.
+func parseCurrency(ctx *gin.Context) (string, bool) {
+	code := strings.ToUpper(strings.TrimSpace(ctx.Query("currency")))
+	if code != "" && !currency.Valid(code) {
+		ctx.JSON(http.StatusBadRequest, domain.JobListResponse{
+			Success: false,
+			Message: "Invalid currency",
+			Errors:  []string{"currency must be an ISO 4217 code, e.g. EUR"},
+		})
+		return "", false
+	}
+	return code, true
+}
+
+// parseSalaryBound reads an optional salary amount from the query string
+func parseSalaryBound(ctx *gin.Context, param string) (*float64, bool) {
+	raw := ctx.Query(param)
+	if raw == "" {
+		return nil, true
+	}
+
+	amount, err := strconv.ParseFloat(raw, 64)
+	if err != nil || amount < 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		ctx.JSON(http.StatusBadRequest, domain.JobListResponse{
+			Success: false,
+			Message: "Invalid salary filter",
+			Errors:  []string{param + " must be a non-negative number"},
+		})
+		return nil, false
+	}
+	return &amount, true
+}
@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+const (
+	// feedPublisher names the portal in the job feeds
+	feedPublisher = "Job Portal"
+	// feedTitle is the title of the job feeds, filters are appended to it
+	feedTitle = feedPublisher + ": latest jobs"
+)
+
+// boardJobTypes maps employment types to the job types of job board feeds
+var boardJobTypes = map[domain.EmploymentType]string{
+	domain.FullTime:   "fulltime",
+	domain.PartTime:   "parttime",
+	domain.Contract:   "contract",
+	domain.Internship: "internship",
+	domain.Temporary:  "temporary",
+}
+
+type JobFeedController struct {
+	feedUseCase      usecase.JobFeedUsecase
+	feedTokenUseCase usecase.FeedTokenUsecase
+	frontendURL      string
+	apiBaseURL       string
+}
+
+func NewJobFeedController(feedUseCase usecase.JobFeedUsecase, feedTokenUseCase usecase.FeedTokenUsecase, frontendURL, apiBaseURL string) *JobFeedController {
+	return &JobFeedController{
+		feedUseCase:      feedUseCase,
+		feedTokenUseCase: feedTokenUseCase,
+		frontendURL:      strings.TrimRight(frontendURL, "/"),
+		apiBaseURL:       strings.TrimRight(apiBaseURL, "/"),
+	}
+}
+
+// GetJobsRSS handles GET /feeds/jobs.rss?category=&location=
+// Lists the latest published jobs as an RSS 2.0 feed for aggregators and feed readers
+func (c *JobFeedController) GetJobsRSS(ctx *gin.Context) {
+	filter := domain.JobFilter{
+		Category: strings.TrimSpace(ctx.Query("category")),
+		Location: strings.TrimSpace(ctx.Query("location")),
+	}
+
+	jobs, err := c.feedUseCase.ListLatest(ctx.Request.Context(), filter)
+	if err != nil {
+		response.Error(ctx, err, "Failed to build job feed")
+		return
+	}
+
+	title := feedTitle
+	if filter.Category != "" {
+		title += " in " + filter.Category
+	}
+	if filter.Location != "" {
+		title += " near " + filter.Location
+	}
+
+	items := make([]response.RSSItem, 0, len(jobs))
+	for _, job := range jobs {
+		link := c.feedUseCase.JobURL(job.ID, job.Title)
+		item := response.RSSItem{
+			Title:       feedItemTitle(job),
+			Link:        link,
+			GUID:        response.RSSGUID{Value: link},
+			Description: job.Description,
+			PubDate:     job.PostedAt().UTC().Format(time.RFC1123Z),
+		}
+		if job.Category != "" {
+			item.Categories = []string{job.Category}
+		}
+		items = append(items, item)
+	}
+
+	ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(domain.JobFeedMaxAge.Seconds())))
+	response.RSS(ctx, http.StatusOK, response.RSSChannel{
+		Title:         title,
+		Link:          c.frontendURL + "/jobs",
+		Description:   "The latest jobs published on the job portal",
+		SelfURL:       c.apiBaseURL + ctx.Request.URL.RequestURI(),
+		LastBuildDate: time.Now().UTC().Format(time.RFC1123Z),
+		TTL:           int(domain.JobFeedMaxAge.Minutes()),
+		Items:         items,
+	})
+}
+
+// GetJobBoardFeed handles GET /feeds/indeed.xml?token=
+// Lists the published jobs of the token's company, or of every company for
+// the admins' tokens, in the XML feed format of Indeed for external job
+// boards. The token can be sent in the X-Feed-Token header instead.
+func (c *JobFeedController) GetJobBoardFeed(ctx *gin.Context) {
+	rawToken := ctx.GetHeader("X-Feed-Token")
+	if rawToken == "" {
+		rawToken = ctx.Query("token")
+	}
+	if rawToken == "" {
+		ctx.JSON(http.StatusUnauthorized, domain.FeedTokenResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"A feed token is required"},
+		})
+		return
+	}
+
+	token, err := c.feedTokenUseCase.Authenticate(ctx.Request.Context(), rawToken)
+	if err != nil {
+		response.Error(ctx, err, "Failed to authenticate feed token")
+		return
+	}
+
+	jobs, err := c.feedUseCase.ListForBoard(ctx.Request.Context(), token.CompanyID)
+	if err != nil {
+		response.Error(ctx, err, "Failed to build job feed")
+		return
+	}
+
+	feed := response.JobBoardFeed{
+		Publisher:     feedPublisher,
+		PublisherURL:  c.frontendURL,
+		LastBuildDate: time.Now().UTC().Format(time.RFC1123),
+		Jobs:          make([]response.JobBoardJob, 0, len(jobs)),
+	}
+	for _, job := range jobs {
+		feed.Jobs = append(feed.Jobs, boardJob(job, c.feedUseCase.JobURL(job.ID, job.Title)))
+	}
+
+	// Feeds are per token, they must not be cached by shared proxies
+	ctx.Header("Cache-Control", "private, no-store")
+	response.JobBoard(ctx, http.StatusOK, feed)
+}
+
+// boardJob describes a job for a job board feed
+func boardJob(job *domain.FeedJob, url string) response.JobBoardJob {
+	entry := response.JobBoardJob{
+		Title:           response.CDATA{Text: job.Title},
+		Date:            response.CDATA{Text: job.PostedAt().UTC().Format(time.RFC1123)},
+		ReferenceNumber: response.CDATA{Text: job.ID.Hex()},
+		URL:             response.CDATA{Text: url},
+		Company:         response.CDATA{Text: job.CompanyName()},
+		City:            response.CDATA{Text: job.Location},
+		Description:     response.CDATA{Text: job.Description},
+	}
+
+	optional := func(text string) *response.CDATA {
+		if text == "" {
+			return nil
+		}
+		return &response.CDATA{Text: text}
+	}
+	if job.Salary != nil {
+		entry.Salary = optional(fmt.Sprintf("%.0f - %.0f %s per %s", job.Salary.Min, job.Salary.Max, job.Salary.Currency, job.Salary.Period))
+	}
+	entry.JobType = optional(boardJobTypes[job.EmploymentType])
+	entry.Category = optional(job.Category)
+	entry.Experience = optional(string(job.ExperienceLevel))
+	if job.Remote {
+		entry.RemoteType = optional("Fully remote")
+	}
+	if validThrough := job.ValidThrough(); validThrough != nil {
+		entry.ExpirationDate = optional(validThrough.UTC().Format("2006-01-02"))
+	}
+	return entry
+}
+
+// GetJobPosting handles GET /api/v1/jobs/:id/jsonld
+// Describes a published job as schema.org JobPosting JSON-LD, for the job page
+// to embed so search engines such as Google for Jobs index it
+func (c *JobFeedController) GetJobPosting(ctx *gin.Context) {
+	posting, err := c.feedUseCase.GetJobPosting(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to describe job")
+		return
+	}
+
+	body, err := json.Marshal(posting)
+	if err != nil {
+		response.Error(ctx, err, "Failed to describe job")
+		return
+	}
+
+	ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(domain.JobFeedMaxAge.Seconds())))
+	ctx.Data(http.StatusOK, "application/ld+json; charset=utf-8", body)
+}
+
+// GetSitemap handles GET /sitemap.xml
+// Lists the pages of the published jobs for search engines
+func (c *JobFeedController) GetSitemap(ctx *gin.Context) {
+	urls, generatedAt, err := c.feedUseCase.Sitemap(ctx.Request.Context())
+	if err != nil {
+		response.Error(ctx, err, "Failed to build sitemap")
+		return
+	}
+
+	ctx.Header("Last-Modified", generatedAt.UTC().Format(http.TimeFormat))
+	ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(domain.SitemapRefreshInterval.Seconds())))
+	response.Sitemap(ctx, http.StatusOK, urls)
+}
+
+// feedItemTitle names the job, its company and where it is
+func feedItemTitle(job *domain.FeedJob) string {
+	title := job.Title
+	if company := job.CompanyName(); company != "" {
+		title += " at " + company
+	}
+	switch {
+	case job.Remote:
+		title += " (remote)"
+	case job.Location != "":
+		title += " (" + job.Location + ")"
+	}
+	return title
+}
@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/utils"
+)
+
+type JWKSController struct {
+	tokens *utils.TokenService
+}
+
+func NewJWKSController(tokens *utils.TokenService) *JWKSController {
+	return &JWKSController{
+		tokens: tokens,
+	}
+}
+
+// GetJWKS handles GET /.well-known/jwks.json
+// It publishes the public keys other services verify portal-issued tokens with.
+// The document has no keys while tokens are signed with an HMAC secret.
+func (c *JWKSController) GetJWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, c.tokens.JWKS())
+}
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type MaintenanceController struct {
+	maintenanceUsecase usecase.MaintenanceUsecase
+}
+
+func NewMaintenanceController(maintenanceUsecase usecase.MaintenanceUsecase) *MaintenanceController {
+	return &MaintenanceController{
+		maintenanceUsecase: maintenanceUsecase,
+	}
+}
+
+// PurgeExpired handles POST /api/v1/admin/retention/purge
+// Deletes the data past its retention period; with ?dry_run=true the expired
+// documents are only counted
+func (c *MaintenanceController) PurgeExpired(ctx *gin.Context) {
+	dryRun, ok := parseDryRun(ctx)
+	if !ok {
+		return
+	}
+
+	// Call use case
+	results, err := c.maintenanceUsecase.PurgeExpired(ctx.Request.Context(), dryRun)
+	if err != nil {
+		response.Error(ctx, err, "Failed to purge expired data")
+		return
+	}
+
+	message := "Expired data purged"
+	if dryRun {
+		message = "Dry run, nothing was deleted"
+	}
+	ctx.JSON(http.StatusOK, domain.PurgeResponse{
+		Success: true,
+		Message: message,
+		DryRun:  dryRun,
+		Data:    results,
+	})
+}
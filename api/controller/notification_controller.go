@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type NotificationController struct {
+	notificationUsecase usecase.NotificationUsecase
+	validator           *validator.Validate
+}
+
+func NewNotificationController(notificationUsecase usecase.NotificationUsecase) *NotificationController {
+	return &NotificationController{
+		notificationUsecase: notificationUsecase,
+		validator:           validator.New(),
+	}
+}
+
+// GetPreferences handles GET /api/v1/users/me/preferences
+func (c *NotificationController) GetPreferences(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.NotificationPreferencesResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.notificationUsecase.GetPreferences(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve notification preferences")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdatePreferences handles PUT /api/v1/users/me/preferences
+// Fields left out of the request keep their current value
+func (c *NotificationController) UpdatePreferences(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.NotificationPreferencesResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.UpdateNotificationPreferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.NotificationPreferencesResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.NotificationPreferencesResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.notificationUsecase.UpdatePreferences(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to update notification preferences")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
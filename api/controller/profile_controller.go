@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type ProfileController struct {
+	profileUsecase usecase.ProfileUsecase
+	validator      *validator.Validate
+}
+
+func NewProfileController(profileUsecase usecase.ProfileUsecase) *ProfileController {
+	return &ProfileController{
+		profileUsecase: profileUsecase,
+		validator:      validator.New(),
+	}
+}
+
+// GetProfile handles GET /api/v1/users/me/profile
+func (c *ProfileController) GetProfile(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.ApplicantProfileResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.profileUsecase.GetProfile(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve profile")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdateProfile handles PUT /api/v1/users/me/profile
+// The request replaces the stored profile
+func (c *ProfileController) UpdateProfile(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.ApplicantProfileResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.UpdateApplicantProfileRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.ApplicantProfileResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.ApplicantProfileResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.profileUsecase.UpdateProfile(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to update profile")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
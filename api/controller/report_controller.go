@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+// reportDateLayout is the format of the from and to query parameters
+const reportDateLayout = "2006-01-02"
+
+type ReportController struct {
+	reportUsecase usecase.ReportUsecase
+}
+
+func NewReportController(reportUsecase usecase.ReportUsecase) *ReportController {
+	return &ReportController{
+		reportUsecase: reportUsecase,
+	}
+}
+
+// GetCompanyHiringOutcomes handles GET /api/v1/companies/me/reports/hiring-outcomes
+// Reports on the company's own jobs, as JSON or CSV (?format=csv)
+func (c *ReportController) GetCompanyHiringOutcomes(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.HiringReportResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	c.hiringOutcomes(ctx, userID.(string))
+}
+
+// GetHiringOutcomes handles GET /api/v1/admin/reports/hiring-outcomes
+// Reports on every job, or on one company's jobs with ?company_id=
+func (c *ReportController) GetHiringOutcomes(ctx *gin.Context) {
+	c.hiringOutcomes(ctx, ctx.Query("company_id"))
+}
+
+func (c *ReportController) hiringOutcomes(ctx *gin.Context, companyID string) {
+	filter, ok := parseReportPeriod(ctx)
+	if !ok {
+		return
+	}
+	filter.CompanyID = companyID
+
+	report, err := c.reportUsecase.HiringOutcomes(ctx.Request.Context(), filter)
+	if err != nil {
+		response.Error(ctx, err, "Failed to build hiring outcome report")
+		return
+	}
+
+	response.List(ctx, http.StatusOK, domain.HiringReportResponse{
+		Success: true,
+		Message: "Hiring outcome report generated successfully",
+		Data:    report,
+	}, "hiring_outcomes", func() response.Table { return response.HiringOutcomesTable(report) })
+}
+
+// GetCompanyJobClosings handles GET /api/v1/companies/me/reports/job-closings
+// Sums up the company's jobs closed in the period per reason, as JSON or CSV (?format=csv)
+func (c *ReportController) GetCompanyJobClosings(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.HiringReportResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	c.jobClosings(ctx, userID.(string))
+}
+
+// GetJobClosings handles GET /api/v1/admin/reports/job-closings
+// Covers the whole platform, or one company with ?company_id=
+func (c *ReportController) GetJobClosings(ctx *gin.Context) {
+	c.jobClosings(ctx, ctx.Query("company_id"))
+}
+
+func (c *ReportController) jobClosings(ctx *gin.Context, companyID string) {
+	filter, ok := parseReportPeriod(ctx)
+	if !ok {
+		return
+	}
+	filter.CompanyID = companyID
+
+	report, err := c.reportUsecase.JobClosings(ctx.Request.Context(), filter)
+	if err != nil {
+		response.Error(ctx, err, "Failed to build job closing report")
+		return
+	}
+
+	response.List(ctx, http.StatusOK, domain.HiringReportResponse{
+		Success: true,
+		Message: "Job closing report generated successfully",
+		Data:    report,
+	}, "job_closings", func() response.Table { return response.JobClosingsTable(report) })
+}
+
+// GetCompanyFunnel handles GET /api/v1/companies/me/reports/funnel
+// Stage counts, time in stage and time to hire of the company's jobs, as JSON or CSV (?format=csv)
+func (c *ReportController) GetCompanyFunnel(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.HiringReportResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	c.funnel(ctx, userID.(string))
+}
+
+// GetFunnel handles GET /api/v1/admin/reports/funnel
+// Covers every job with a breakdown per company, or one company with ?company_id=
+func (c *ReportController) GetFunnel(ctx *gin.Context) {
+	c.funnel(ctx, ctx.Query("company_id"))
+}
+
+func (c *ReportController) funnel(ctx *gin.Context, companyID string) {
+	filter, ok := parseReportPeriod(ctx)
+	if !ok {
+		return
+	}
+	filter.CompanyID = companyID
+
+	report, err := c.reportUsecase.Funnel(ctx.Request.Context(), filter)
+	if err != nil {
+		response.Error(ctx, err, "Failed to build funnel report")
+		return
+	}
+
+	response.List(ctx, http.StatusOK, domain.HiringReportResponse{
+		Success: true,
+		Message: "Funnel report generated successfully",
+		Data:    report,
+	}, "funnel", func() response.Table { return response.FunnelTable(report) })
+}
+
+// parseReportPeriod reads the from and to dates (YYYY-MM-DD, both included).
+// The period defaults to the last 90 days.
+func parseReportPeriod(ctx *gin.Context) (domain.HiringReportFilter, bool) {
+	filter := domain.HiringReportFilter{To: time.Now().UTC()}
+
+	if to := ctx.Query("to"); to != "" {
+		day, err := time.Parse(reportDateLayout, to)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, domain.HiringReportResponse{
+				Success: false,
+				Message: "Invalid to date",
+				Errors:  []string{"to must be formatted YYYY-MM-DD"},
+			})
+			return filter, false
+		}
+		filter.To = day.AddDate(0, 0, 1)
+	}
+
+	filter.From = filter.To.Add(-domain.DefaultHiringReportPeriod)
+	if from := ctx.Query("from"); from != "" {
+		day, err := time.Parse(reportDateLayout, from)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, domain.HiringReportResponse{
+				Success: false,
+				Message: "Invalid from date",
+				Errors:  []string{"from must be formatted YYYY-MM-DD"},
+			})
+			return filter, false
+		}
+		filter.From = day
+	}
+
+	return filter, true
+}
@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/storage"
+	"job-portal-backend/usecase"
+)
+
+type ResumeController struct {
+	resumeUsecase usecase.ResumeUsecase
+	storage       storage.Storage
+	urls          *response.URLBuilder
+}
+
+func NewResumeController(resumeUsecase usecase.ResumeUsecase, store storage.Storage, urls *response.URLBuilder) *ResumeController {
+	return &ResumeController{
+		resumeUsecase: resumeUsecase,
+		storage:       store,
+		urls:          urls,
+	}
+}
+
+// ListResumes handles GET /api/v1/users/me/resumes
+func (c *ResumeController) ListResumes(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.ResumeResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.resumeUsecase.ListResumes(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to list resumes")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UploadResume handles POST /api/v1/users/me/resumes (multipart, file in the resume field)
+func (c *ResumeController) UploadResume(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.ResumeResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Leave room for the multipart envelope around the file
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, domain.MaxResumeBytes+1<<20)
+	header, err := ctx.FormFile("resume")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.ResumeResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Errors:  []string{"A file is required in the resume field, up to 10 MB"},
+		})
+		return
+	}
+	if header.Size > domain.MaxResumeBytes {
+		ctx.JSON(http.StatusBadRequest, domain.ResumeResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  []string{"The resume cannot be larger than 10 MB"},
+		})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, domain.ResumeResponse{
+			Success: false,
+			Message: "Failed to process resume file",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	defer file.Close()
+
+	contentType := header.Header.Get("Content-Type")
+	resumeURL, err := uploadFile(ctx.Request.Context(), c.storage, file, header.Filename, contentType)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, domain.ResumeResponse{
+			Success: false,
+			Message: "Failed to upload resume",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.resumeUsecase.AddResume(ctx.Request.Context(), userID.(string), &domain.Resume{
+		Name:        header.Filename,
+		URL:         resumeURL,
+		ContentType: contentType,
+		Size:        header.Size,
+	})
+	if err != nil {
+		response.Error(ctx, err, "Failed to upload resume")
+		return
+	}
+
+	resume := resp.Data.(*domain.Resume)
+	resp.URL = c.urls.Resume(resume.ID.Hex())
+	response.Created(ctx, resp.URL, resp)
+}
+
+// DeleteResume handles DELETE /api/v1/users/me/resumes/:id
+func (c *ResumeController) DeleteResume(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.ResumeResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.resumeUsecase.DeleteResume(ctx.Request.Context(), userID.(string), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to delete resume")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
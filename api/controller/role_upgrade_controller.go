@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type RoleUpgradeController struct {
+	upgradeUsecase usecase.RoleUpgradeUsecase
+	validator      *validator.Validate
+	urls           *response.URLBuilder
+}
+
+func NewRoleUpgradeController(upgradeUsecase usecase.RoleUpgradeUsecase, urls *response.URLBuilder) *RoleUpgradeController {
+	return &RoleUpgradeController{
+		upgradeUsecase: upgradeUsecase,
+		validator:      validator.New(),
+		urls:           urls,
+	}
+}
+
+// RequestUpgrade handles POST /api/v1/users/me/role-upgrade
+func (c *RoleUpgradeController) RequestUpgrade(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.RoleUpgradeResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.CreateRoleUpgradeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.RoleUpgradeResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if !c.validate(ctx, req) {
+		return
+	}
+
+	// Call use case
+	resp, err := c.upgradeUsecase.RequestUpgrade(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to request role upgrade")
+		return
+	}
+
+	resp.URL = c.urls.RoleUpgrade()
+	response.Created(ctx, resp.URL, resp)
+}
+
+// GetMyUpgrade handles GET /api/v1/users/me/role-upgrade
+func (c *RoleUpgradeController) GetMyUpgrade(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.RoleUpgradeResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.upgradeUsecase.GetMyUpgrade(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve role upgrade request")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ListUpgrades handles GET /api/v1/admin/role-upgrades
+// Lists the requests oldest first, filtered by ?status= (pending, approved or rejected)
+func (c *RoleUpgradeController) ListUpgrades(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	status := domain.RoleUpgradeStatus(ctx.Query("status"))
+
+	// Call use case
+	resp, err := c.upgradeUsecase.ListUpgrades(ctx.Request.Context(), status, page, limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to list role upgrade requests")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ApproveUpgrade handles POST /api/v1/admin/role-upgrades/:id/approve
+func (c *RoleUpgradeController) ApproveUpgrade(ctx *gin.Context) {
+	c.review(ctx, c.upgradeUsecase.ApproveUpgrade, "Failed to approve role upgrade")
+}
+
+// RejectUpgrade handles POST /api/v1/admin/role-upgrades/:id/reject
+func (c *RoleUpgradeController) RejectUpgrade(ctx *gin.Context) {
+	c.review(ctx, c.upgradeUsecase.RejectUpgrade, "Failed to reject role upgrade")
+}
+
+// review runs an approve or reject decision. The request body with the
+// reviewer's note is optional.
+func (c *RoleUpgradeController) review(ctx *gin.Context, decide func(ctx context.Context, adminID, upgradeID string, req *domain.ReviewRoleUpgradeRequest) (*domain.RoleUpgradeResponse, error), failure string) {
+	adminID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.RoleUpgradeResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.ReviewRoleUpgradeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, domain.RoleUpgradeResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if !c.validate(ctx, req) {
+		return
+	}
+
+	// Call use case
+	resp, err := decide(ctx.Request.Context(), adminID.(string), ctx.Param("id"), &req)
+	if err != nil {
+		response.Error(ctx, err, failure)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// validate checks req and writes the validation errors, reporting whether it is valid
+func (c *RoleUpgradeController) validate(ctx *gin.Context, req interface{}) bool {
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.RoleUpgradeResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return false
+	}
+	return true
+}
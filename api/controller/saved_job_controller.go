@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type SavedJobController struct {
+	savedJobUsecase usecase.SavedJobUsecase
+}
+
+func NewSavedJobController(savedJobUsecase usecase.SavedJobUsecase) *SavedJobController {
+	return &SavedJobController{
+		savedJobUsecase: savedJobUsecase,
+	}
+}
+
+// SaveJob handles POST /api/v1/jobs/:id/save
+// Saving a job twice is not an error
+func (c *SavedJobController) SaveJob(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SavedJobResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.savedJobUsecase.SaveJob(ctx.Request.Context(), userID.(string), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to save job")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UnsaveJob handles DELETE /api/v1/jobs/:id/save
+func (c *SavedJobController) UnsaveJob(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SavedJobResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.savedJobUsecase.UnsaveJob(ctx.Request.Context(), userID.(string), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to remove saved job")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ListSavedJobs handles GET /api/v1/users/me/saved-jobs
+func (c *SavedJobController) ListSavedJobs(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SavedJobListResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Parse query parameters
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	// Call use case
+	resp, err := c.savedJobUsecase.ListSavedJobs(ctx.Request.Context(), userID.(string), page, limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve saved jobs")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
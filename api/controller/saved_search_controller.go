@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type SavedSearchController struct {
+	savedSearchUsecase usecase.SavedSearchUsecase
+	validator          *validator.Validate
+	urls               *response.URLBuilder
+}
+
+func NewSavedSearchController(savedSearchUsecase usecase.SavedSearchUsecase, urls *response.URLBuilder) *SavedSearchController {
+	return &SavedSearchController{
+		savedSearchUsecase: savedSearchUsecase,
+		validator:          validator.New(),
+		urls:               urls,
+	}
+}
+
+// SaveSearch handles POST /api/v1/users/me/saved-searches
+func (c *SavedSearchController) SaveSearch(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SavedSearchResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.SaveSearchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.SavedSearchResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.SavedSearchResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.savedSearchUsecase.SaveSearch(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to save search")
+		return
+	}
+
+	search := resp.Data.(*domain.SavedSearch)
+	resp.URL = c.urls.SavedSearch(search.ID.Hex())
+	response.Created(ctx, resp.URL, resp)
+}
+
+// ListSearches handles GET /api/v1/users/me/saved-searches
+func (c *SavedSearchController) ListSearches(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SavedSearchResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.savedSearchUsecase.ListSearches(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to list saved searches")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteSearch handles DELETE /api/v1/users/me/saved-searches/:id
+func (c *SavedSearchController) DeleteSearch(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SavedSearchResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.savedSearchUsecase.DeleteSearch(ctx.Request.Context(), userID.(string), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to delete saved search")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
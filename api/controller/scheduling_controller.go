@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type SchedulingController struct {
+	schedulingUsecase usecase.SchedulingUsecase
+	validator         *validator.Validate
+}
+
+func NewSchedulingController(schedulingUsecase usecase.SchedulingUsecase) *SchedulingController {
+	return &SchedulingController{
+		schedulingUsecase: schedulingUsecase,
+		validator:         validator.New(),
+	}
+}
+
+// GetRules handles GET /api/v1/companies/me/scheduling-rules
+func (c *SchedulingController) GetRules(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SchedulingRulesResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.schedulingUsecase.GetRules(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to get scheduling rules")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// SaveRules handles PUT /api/v1/companies/me/scheduling-rules
+func (c *SchedulingController) SaveRules(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SchedulingRulesResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.SaveSchedulingRulesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.SchedulingRulesResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.SchedulingRulesResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.schedulingUsecase.SaveRules(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to save scheduling rules")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// CheckSlot handles GET /api/v1/companies/me/scheduling-rules/check?at=<RFC 3339 time>
+func (c *SchedulingController) CheckSlot(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SchedulingRulesResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	at, err := time.Parse(time.RFC3339, ctx.Query("at"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.SchedulingRulesResponse{
+			Success: false,
+			Message: "Invalid query parameters",
+			Errors:  []string{"at must be an RFC 3339 time, e.g. 2024-05-01T10:00:00+02:00"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.schedulingUsecase.CheckSlot(ctx.Request.Context(), userID.(string), at)
+	if err != nil {
+		response.Error(ctx, err, "Failed to check interview time")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
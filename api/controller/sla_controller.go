@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type SLAController struct {
+	slaUsecase usecase.SLAUsecase
+	validator  *validator.Validate
+}
+
+func NewSLAController(slaUsecase usecase.SLAUsecase) *SLAController {
+	return &SLAController{
+		slaUsecase: slaUsecase,
+		validator:  validator.New(),
+	}
+}
+
+// GetPolicy handles GET /api/v1/companies/me/sla
+func (c *SLAController) GetPolicy(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SLAResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.slaUsecase.GetPolicy(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to get SLA targets")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// SavePolicy handles PUT /api/v1/companies/me/sla
+func (c *SLAController) SavePolicy(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SLAResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.SaveSLAPolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.SLAResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.SLAResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.slaUsecase.SavePolicy(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to save SLA targets")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetDashboard handles GET /api/v1/companies/me/dashboard
+func (c *SLAController) GetDashboard(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SLAResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.slaUsecase.GetDashboard(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to get dashboard")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
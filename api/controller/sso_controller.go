@@ -0,0 +1,202 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+	"job-portal-backend/utils"
+)
+
+// ssoStateCookie holds the anti-CSRF state (and company) between the redirect to the IdP and the callback
+const ssoStateCookie = "sso_state"
+
+type SSOController struct {
+	ssoUsecase usecase.SSOUsecase
+	validator  *validator.Validate
+}
+
+func NewSSOController(ssoUsecase usecase.SSOUsecase) *SSOController {
+	return &SSOController{
+		ssoUsecase: ssoUsecase,
+		validator:  validator.New(),
+	}
+}
+
+// GetConfig handles GET /api/v1/companies/me/sso
+func (c *SSOController) GetConfig(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SSOResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.ssoUsecase.GetConfig(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to get SSO configuration")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// SaveConfig handles PUT /api/v1/companies/me/sso
+func (c *SSOController) SaveConfig(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SSOResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.SaveSSOConfigRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.SSOResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.SSOResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.ssoUsecase.SaveConfig(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to save SSO configuration")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteConfig handles DELETE /api/v1/companies/me/sso
+func (c *SSOController) DeleteConfig(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SSOResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.ssoUsecase.DeleteConfig(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to delete SSO configuration")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// VerifyDomains handles POST /api/v1/companies/me/sso/verify-domains
+func (c *SSOController) VerifyDomains(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SSOResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.ssoUsecase.VerifyDomains(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to verify SSO domains")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Login handles GET /api/v1/auth/sso/:company_id and redirects to the company's IdP
+func (c *SSOController) Login(ctx *gin.Context) {
+	state, err := utils.GenerateSecureToken()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, domain.AuthResponse{
+			Success: false,
+			Message: "Failed to start SSO login: " + err.Error(),
+		})
+		return
+	}
+
+	companyID := ctx.Param("company_id")
+	url, err := c.ssoUsecase.LoginURL(ctx.Request.Context(), companyID, state)
+	if err != nil {
+		response.Error(ctx, err, "Failed to start SSO login")
+		return
+	}
+
+	// Bind the state to the company so a callback can't be replayed against another IdP
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(ssoStateCookie, state+"|"+companyID, 600, "/api/v1/auth/sso", "", ctx.Request.TLS != nil, true)
+
+	ctx.Redirect(http.StatusTemporaryRedirect, url)
+}
+
+// Callback handles GET /api/v1/auth/sso/:company_id/callback
+func (c *SSOController) Callback(ctx *gin.Context) {
+	// Verify the state matches the one issued in Login
+	cookieValue, err := ctx.Cookie(ssoStateCookie)
+	state, companyID, _ := strings.Cut(cookieValue, "|")
+	if err != nil || state == "" || state != ctx.Query("state") || companyID != ctx.Param("company_id") {
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: "Invalid SSO state",
+		})
+		return
+	}
+	ctx.SetCookie(ssoStateCookie, "", -1, "/api/v1/auth/sso", "", ctx.Request.TLS != nil, true)
+
+	code := ctx.Query("code")
+	if code == "" {
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: "Authorization code is required",
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.ssoUsecase.Callback(ctx.Request.Context(), &domain.SSOCallbackRequest{
+		CompanyID: companyID,
+		Code:      code,
+	})
+	if err != nil {
+		response.Error(ctx, err, "SSO login failed")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
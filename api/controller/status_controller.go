@@ -0,0 +1,32 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/usecase"
+)
+
+type StatusController struct {
+	statusUsecase usecase.StatusUsecase
+}
+
+func NewStatusController(statusUsecase usecase.StatusUsecase) *StatusController {
+	return &StatusController{
+		statusUsecase: statusUsecase,
+	}
+}
+
+// GetStatus handles GET /status
+// Public data for a status page: uptime, error rate and dependency health
+func (c *StatusController) GetStatus(ctx *gin.Context) {
+	resp, err := c.statusUsecase.GetStatus(ctx.Request.Context())
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve status")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
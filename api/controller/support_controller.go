@@ -0,0 +1,295 @@
+package controller
+
+import (
+	"bufio"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/storage"
+	"job-portal-backend/usecase"
+)
+
+type SupportController struct {
+	supportUsecase usecase.SupportUsecase
+	storage        storage.Storage
+	validator      *validator.Validate
+	urls           *response.URLBuilder
+}
+
+func NewSupportController(supportUsecase usecase.SupportUsecase, store storage.Storage, urls *response.URLBuilder) *SupportController {
+	return &SupportController{
+		supportUsecase: supportUsecase,
+		storage:        store,
+		validator:      validator.New(),
+		urls:           urls,
+	}
+}
+
+// CreateTicket handles POST /api/v1/support/tickets
+// Multipart form with category, message and an optional attachment (image,
+// PDF or text, up to 5 MB); a JSON body is accepted when there is no attachment.
+func (c *SupportController) CreateTicket(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SupportTicketResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Leave room for the multipart envelope and the other fields
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, domain.MaxSupportAttachmentBytes+1<<20)
+
+	var req domain.CreateSupportTicketRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.SupportTicketResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	if !c.validate(ctx, req) {
+		return
+	}
+
+	attachmentURL, ok := c.uploadAttachment(ctx)
+	if !ok {
+		return
+	}
+
+	// Call use case
+	resp, err := c.supportUsecase.CreateTicket(ctx.Request.Context(), userID.(string), &req, attachmentURL)
+	if err != nil {
+		response.Error(ctx, err, "Failed to create support ticket")
+		return
+	}
+
+	ticket := resp.Data.(*domain.SupportTicket)
+	resp.URL = c.urls.SupportTicket(ticket.ID.Hex())
+	response.Created(ctx, resp.URL, resp)
+}
+
+// uploadAttachment stores the file of the attachment field, if any, and
+// returns its URL. It writes the error response and returns false on failure.
+func (c *SupportController) uploadAttachment(ctx *gin.Context) (string, bool) {
+	if !strings.HasPrefix(ctx.ContentType(), "multipart/") {
+		return "", true
+	}
+	header, err := ctx.FormFile("attachment")
+	if err == http.ErrMissingFile {
+		return "", true
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.SupportTicketResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Errors:  []string{err.Error()},
+		})
+		return "", false
+	}
+	if header.Size > domain.MaxSupportAttachmentBytes {
+		ctx.JSON(http.StatusBadRequest, domain.SupportTicketResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  []string{"The attachment cannot be larger than 5 MB"},
+		})
+		return "", false
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, domain.SupportTicketResponse{
+			Success: false,
+			Message: "Failed to process attachment",
+			Errors:  []string{err.Error()},
+		})
+		return "", false
+	}
+	defer file.Close()
+
+	// Trust the bytes, not the declared content type
+	reader := bufio.NewReader(file)
+	head, _ := reader.Peek(512)
+	contentType := strings.TrimSpace(strings.Split(http.DetectContentType(head), ";")[0])
+	extension, allowed := domain.SupportAttachmentTypes[contentType]
+	if !allowed {
+		ctx.JSON(http.StatusBadRequest, domain.SupportTicketResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  []string{"The attachment must be a PNG, JPEG or GIF image, a PDF or a text file"},
+		})
+		return "", false
+	}
+
+	attachmentURL, err := uploadFile(ctx.Request.Context(), c.storage, reader, "attachment"+extension, contentType)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, domain.SupportTicketResponse{
+			Success: false,
+			Message: "Failed to upload attachment",
+			Errors:  []string{err.Error()},
+		})
+		return "", false
+	}
+	return attachmentURL, true
+}
+
+// ListMyTickets handles GET /api/v1/support/tickets
+func (c *SupportController) ListMyTickets(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SupportTicketResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	// Call use case
+	resp, err := c.supportUsecase.ListMyTickets(ctx.Request.Context(), userID.(string), page, limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to list support tickets")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ReplyToMyTicket handles POST /api/v1/support/tickets/:id/reply
+func (c *SupportController) ReplyToMyTicket(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SupportTicketResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	req, ok := c.bindReply(ctx)
+	if !ok {
+		return
+	}
+	// Only support closes tickets
+	req.Close = false
+
+	// Call use case
+	resp, err := c.supportUsecase.ReplyAsUser(ctx.Request.Context(), userID.(string), ctx.Param("id"), req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to reply to support ticket")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ListTickets handles GET /api/v1/admin/support/tickets
+// Lists the tickets longest waiting first, filtered by ?status= (open,
+// answered or closed) and ?category=
+func (c *SupportController) ListTickets(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	filter := domain.SupportTicketFilter{
+		Status:   domain.SupportTicketStatus(ctx.Query("status")),
+		Category: domain.SupportTicketCategory(ctx.Query("category")),
+	}
+
+	// Call use case
+	resp, err := c.supportUsecase.ListTickets(ctx.Request.Context(), filter, page, limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to list support tickets")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetTicket handles GET /api/v1/admin/support/tickets/:id
+func (c *SupportController) GetTicket(ctx *gin.Context) {
+	// Call use case
+	resp, err := c.supportUsecase.GetTicket(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve support ticket")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ReplyToTicket handles POST /api/v1/admin/support/tickets/:id/reply
+// The answer is emailed to the user, "close": true resolves the ticket
+func (c *SupportController) ReplyToTicket(ctx *gin.Context) {
+	adminID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.SupportTicketResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	req, ok := c.bindReply(ctx)
+	if !ok {
+		return
+	}
+
+	// Call use case
+	resp, err := c.supportUsecase.ReplyAsSupport(ctx.Request.Context(), adminID.(string), ctx.Param("id"), req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to reply to support ticket")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// bindReply reads and validates a reply, writing the error response on failure
+func (c *SupportController) bindReply(ctx *gin.Context) (*domain.ReplySupportTicketRequest, bool) {
+	var req domain.ReplySupportTicketRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.SupportTicketResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return nil, false
+	}
+
+	if !c.validate(ctx, req) {
+		return nil, false
+	}
+	return &req, true
+}
+
+// validate checks req and writes the validation errors, reporting whether it is valid
+func (c *SupportController) validate(ctx *gin.Context, req interface{}) bool {
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.SupportTicketResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return false
+	}
+	return true
+}
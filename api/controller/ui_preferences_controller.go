@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/domain"
+	"job-portal-backend/usecase"
+)
+
+type UIPreferencesController struct {
+	prefsUsecase usecase.UIPreferencesUsecase
+	validator    *validator.Validate
+}
+
+func NewUIPreferencesController(prefsUsecase usecase.UIPreferencesUsecase) *UIPreferencesController {
+	return &UIPreferencesController{
+		prefsUsecase: prefsUsecase,
+		validator:    validator.New(),
+	}
+}
+
+// GetPreferences handles GET /api/v1/users/me/ui-preferences
+func (c *UIPreferencesController) GetPreferences(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.UIPreferencesResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.prefsUsecase.GetPreferences(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve preferences")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdatePreferences handles PUT /api/v1/users/me/ui-preferences
+// The body maps preference keys to their value. Keys left out keep their
+// value and a null value removes the key. Unknown keys and fields are refused.
+func (c *UIPreferencesController) UpdatePreferences(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.UIPreferencesResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Errors:  []string{"User not authenticated"},
+		})
+		return
+	}
+
+	var req domain.UpdateUIPreferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.UIPreferencesResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	if len(req) == 0 {
+		ctx.JSON(http.StatusBadRequest, domain.UIPreferencesResponse{
+			Success: false,
+			Message: "No preferences to update",
+		})
+		return
+	}
+
+	values, errs := c.decodeValues(req)
+	if len(errs) > 0 {
+		ctx.JSON(http.StatusBadRequest, domain.UIPreferencesResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.prefsUsecase.UpdatePreferences(ctx.Request.Context(), userID.(string), values)
+	if err != nil {
+		response.Error(ctx, err, "Failed to update preferences")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// decodeValues decodes and validates every value for its key
+func (c *UIPreferencesController) decodeValues(req domain.UpdateUIPreferencesRequest) (map[domain.UIPreferenceKey]interface{}, []string) {
+	// Sorted so the errors come back in a stable order
+	keys := make([]string, 0, len(req))
+	for key := range req {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+
+	values := make(map[domain.UIPreferenceKey]interface{}, len(req))
+	var errs []string
+	for _, k := range keys {
+		key := domain.UIPreferenceKey(k)
+		value, known := domain.NewUIPreferenceValue(key)
+		if !known {
+			errs = append(errs, fmt.Sprintf("%s: unknown preference", key))
+			continue
+		}
+
+		raw := req[key]
+		if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			values[key] = nil
+			continue
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(value); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+		if err := c.validator.Struct(value); err != nil {
+			for _, e := range err.(validator.ValidationErrors) {
+				errs = append(errs, fmt.Sprintf("%s: %s", key, e.Translate(nil)))
+			}
+			continue
+		}
+		values[key] = value
+	}
+	return values, errs
+}
@@ -0,0 +1,20 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+
+	"github.com/google/uuid"
+
+	"job-portal-backend/pkg/storage"
+)
+
+// uploadFile stores an uploaded file under a random name with the extension
+// of the original filename and returns the URL it can be fetched from
+func uploadFile(ctx context.Context, store storage.Storage, file io.Reader, filename, contentType string) (string, error) {
+	// Generate a unique filename
+	key := uuid.New().String() + filepath.Ext(filename)
+
+	return store.Upload(ctx, key, contentType, file)
+}
@@ -2,24 +2,44 @@ package controller
 
 import (
 	// "context"
+	"bytes"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 
+	"job-portal-backend/api/response"
 	"job-portal-backend/domain"
+	"job-portal-backend/pkg/constants"
+	"job-portal-backend/pkg/imaging"
+	"job-portal-backend/pkg/storage"
 	"job-portal-backend/usecase"
+	"job-portal-backend/utils"
 )
 
+// oauthStateCookie holds the anti-CSRF state (and requested role) between the redirect and the callback
+const oauthStateCookie = "oauth_state"
+
 type UserController struct {
-	userUsecase usecase.UserUsecase
-	validator   *validator.Validate
+	userUsecase    usecase.UserUsecase
+	accountUsecase usecase.AccountUsecase
+	urls           *response.URLBuilder
+	validator      *validator.Validate
+	// storage receives uploaded profile pictures
+	storage storage.Storage
 }
 
-func NewUserController(userUsecase usecase.UserUsecase) *UserController {
+func NewUserController(userUsecase usecase.UserUsecase, accountUsecase usecase.AccountUsecase, store storage.Storage, urls *response.URLBuilder) *UserController {
 	return &UserController{
-		userUsecase: userUsecase,
-		validator:   validator.New(),
+		userUsecase:    userUsecase,
+		accountUsecase: accountUsecase,
+		urls:           urls,
+		validator:      validator.New(),
+		storage:        store,
 	}
 }
 
@@ -63,20 +83,12 @@ func (c *UserController) SignUp(ctx *gin.Context) {
 	// Call use case
 	resp, err := c.userUsecase.SignUp(ctx.Request.Context(), &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, domain.AuthResponse{
-			Success: false,
-			Message: "Failed to create user: " + err.Error(),
-		})
+		response.Error(ctx, err, "Failed to create user")
 		return
 	}
 
-	// Return response
-	if !resp.Success {
-		ctx.JSON(http.StatusBadRequest, resp)
-		return
-	}
-
-	ctx.JSON(http.StatusCreated, resp)
+	resp.URL = c.urls.CurrentUser()
+	response.Created(ctx, resp.URL, resp)
 }
 
 // Login handles user login
@@ -120,16 +132,7 @@ func (c *UserController) Login(ctx *gin.Context) {
 	// Call use case
 	resp, err := c.userUsecase.Login(ctx.Request.Context(), &req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, domain.AuthResponse{
-			Success: false,
-			Message: "Login failed: " + err.Error(),
-		})
-		return
-	}
-
-	// Return response
-	if !resp.Success {
-		ctx.JSON(http.StatusUnauthorized, resp)
+		response.Error(ctx, err, "Login failed")
 		return
 	}
 
@@ -161,20 +164,916 @@ func (c *UserController) GetProfile(ctx *gin.Context) {
 	// Call use case
 	user, err := c.userUsecase.GetProfile(ctx.Request.Context(), userID.(string))
 	if err != nil {
-		if err == domain.ErrUserNotFound {
-			ctx.JSON(http.StatusNotFound, domain.AuthResponse{
+		response.Error(ctx, err, "Failed to get user profile")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user)
+}
+
+// GetPrivacy returns the authenticated applicant's privacy settings
+// @Summary Get my privacy settings
+// @Description Get when companies see the applicant's contact details and whether the profile is searchable
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} domain.UserResponse
+// @Failure 401 {object} domain.UserResponse
+// @Failure 500 {object} domain.UserResponse
+// @Router /api/v1/users/me/privacy [get]
+func (c *UserController) GetPrivacy(ctx *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.UserResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.userUsecase.GetPrivacy(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to get privacy settings")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdateProfile changes the authenticated user's account details
+// @Summary Update my profile
+// @Description Change the name or phone number. Omitted fields are left unchanged, an empty phone removes it.
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body domain.UpdateUserRequest true "Account details"
+// @Success 200 {object} domain.UserResponse
+// @Failure 400 {object} domain.UserResponse
+// @Failure 401 {object} domain.UserResponse
+// @Failure 404 {object} domain.UserResponse
+// @Failure 500 {object} domain.UserResponse
+// @Router /api/v1/users/me [put]
+func (c *UserController) UpdateProfile(ctx *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.UserResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req domain.UpdateUserRequest
+
+	// Bind JSON request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.UserResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.UserResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	if req.Name == nil && req.Phone == nil {
+		ctx.JSON(http.StatusBadRequest, domain.UserResponse{
+			Success: false,
+			Message: "No fields to update",
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.userUsecase.UpdateProfile(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to update profile")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RequestEmailChange starts changing the authenticated user's email
+// @Summary Change my email
+// @Description Send a confirmation link to the new address. The email is only changed once the link is used. Accounts with a password must confirm it.
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body domain.EmailChangeRequest true "New email and current password"
+// @Success 200 {object} domain.UserResponse
+// @Failure 400 {object} domain.UserResponse
+// @Failure 401 {object} domain.UserResponse
+// @Failure 409 {object} domain.UserResponse
+// @Failure 500 {object} domain.UserResponse
+// @Router /api/v1/users/me/email-change [post]
+func (c *UserController) RequestEmailChange(ctx *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.UserResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req domain.EmailChangeRequest
+
+	// Bind JSON request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.UserResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.UserResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.userUsecase.RequestEmailChange(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to change email")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ConfirmEmailChange completes an email change
+// @Summary Confirm email change
+// @Description Swap the account's email using the token sent to the new address. Every session is signed out.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body domain.ConfirmEmailChangeRequest true "Confirmation token"
+// @Success 200 {object} domain.AuthResponse
+// @Failure 400 {object} domain.AuthResponse
+// @Failure 409 {object} domain.AuthResponse
+// @Failure 500 {object} domain.AuthResponse
+// @Router /api/v1/auth/email-change/confirm [post]
+func (c *UserController) ConfirmEmailChange(ctx *gin.Context) {
+	var req domain.ConfirmEmailChangeRequest
+
+	// Bind JSON request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: "Token is required",
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.userUsecase.ConfirmEmailChange(ctx.Request.Context(), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to confirm email change")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdatePrivacy changes the authenticated applicant's privacy settings
+// @Summary Update my privacy settings
+// @Description Hide the email and phone from companies until the Interview stage. Omitted fields are left unchanged.
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body domain.UpdatePrivacyRequest true "Privacy settings"
+// @Success 200 {object} domain.UserResponse
+// @Failure 400 {object} domain.UserResponse
+// @Failure 401 {object} domain.UserResponse
+// @Failure 500 {object} domain.UserResponse
+// @Router /api/v1/users/me/privacy [put]
+func (c *UserController) UpdatePrivacy(ctx *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.UserResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req domain.UpdatePrivacyRequest
+
+	// Bind JSON request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.UserResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errs := make([]string, len(err.(validator.ValidationErrors)))
+		for i, e := range err.(validator.ValidationErrors) {
+			errs[i] = e.Translate(nil)
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.UserResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.userUsecase.UpdatePrivacy(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to update privacy settings")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UploadAvatar sets the authenticated user's profile picture
+// @Summary Upload my profile picture
+// @Description Upload a JPEG, PNG or WebP image of up to 5 MB in the "avatar" form field. It is cropped to a square and resized to 256x256 pixels.
+// @Tags users
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param avatar formData file true "Profile picture"
+// @Success 200 {object} domain.UserResponse
+// @Failure 400 {object} domain.UserResponse
+// @Failure 401 {object} domain.UserResponse
+// @Failure 500 {object} domain.UserResponse
+// @Router /api/v1/users/me/avatar [post]
+func (c *UserController) UploadAvatar(ctx *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.UserResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	// Leave room for the multipart envelope around the image
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, domain.MaxAvatarBytes+1<<20)
+	header, err := ctx.FormFile("avatar")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.UserResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Errors:  []string{"An image is required in the avatar field, up to 5 MB"},
+		})
+		return
+	}
+	if header.Size > domain.MaxAvatarBytes {
+		ctx.JSON(http.StatusBadRequest, domain.UserResponse{
+			Success: false,
+			Message: "Validation failed",
+			Errors:  []string{"The image cannot be larger than 5 MB"},
+		})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, domain.UserResponse{
+			Success: false,
+			Message: "Failed to process image",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	defer file.Close()
+
+	image, contentType, err := imaging.Square(file, domain.AvatarSize)
+	if err != nil {
+		if errors.Is(err, imaging.ErrUnsupportedFormat) || errors.Is(err, imaging.ErrTooLarge) {
+			ctx.JSON(http.StatusBadRequest, domain.UserResponse{
 				Success: false,
-				Message: "User not found",
+				Message: "Validation failed",
+				Errors:  []string{err.Error()},
 			})
 			return
 		}
+		ctx.JSON(http.StatusInternalServerError, domain.UserResponse{
+			Success: false,
+			Message: "Failed to process image",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
 
-		ctx.JSON(http.StatusInternalServerError, domain.AuthResponse{
+	filename := "avatar.jpg"
+	if contentType == "image/png" {
+		filename = "avatar.png"
+	}
+	avatarURL, err := uploadFile(ctx.Request.Context(), c.storage, bytes.NewReader(image), filename, contentType)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, domain.UserResponse{
 			Success: false,
-			Message: "Failed to get user profile: " + err.Error(),
+			Message: "Failed to upload image",
+			Errors:  []string{err.Error()},
 		})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, user)
-}
\ No newline at end of file
+	// Call use case
+	resp, err := c.userUsecase.UpdateAvatar(ctx.Request.Context(), userID.(string), avatarURL)
+	if err != nil {
+		response.Error(ctx, err, "Failed to update profile picture")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteAccount closes the authenticated user's account
+// @Summary Delete my account
+// @Description Soft-delete the account and erase personal data. Applications are anonymized, posted jobs unpublished and all sessions signed out.
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} domain.UserResponse
+// @Failure 401 {object} domain.UserResponse
+// @Failure 403 {object} domain.UserResponse
+// @Failure 409 {object} domain.UserResponse
+// @Failure 500 {object} domain.UserResponse
+// @Router /api/v1/users/me [delete]
+func (c *UserController) DeleteAccount(ctx *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.UserResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.accountUsecase.DeleteAccount(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to delete account")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ForgotPassword starts the password recovery flow
+// @Summary Request a password reset link
+// @Description Send a single-use password reset link to the user's email address
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body domain.ForgotPasswordRequest true "Account email"
+// @Success 200 {object} domain.AuthResponse
+// @Failure 400 {object} domain.AuthResponse
+// @Failure 429 {object} domain.AuthResponse
+// @Failure 500 {object} domain.AuthResponse
+// @Router /api/v1/auth/forgot-password [post]
+func (c *UserController) ForgotPassword(ctx *gin.Context) {
+	var req domain.ForgotPasswordRequest
+
+	// Bind JSON request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errMsg := ""
+		for _, err := range err.(validator.ValidationErrors) {
+			errMsg += err.Field() + " is invalid; "
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: errMsg,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.userUsecase.ForgotPassword(ctx.Request.Context(), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to process password reset")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RequestMagicLink starts a passwordless login
+// @Summary Request a login link
+// @Description Email a single-use login link to an applicant account
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body domain.MagicLinkRequest true "Account email"
+// @Success 200 {object} domain.AuthResponse
+// @Failure 400 {object} domain.AuthResponse
+// @Failure 429 {object} domain.AuthResponse
+// @Failure 500 {object} domain.AuthResponse
+// @Router /api/v1/auth/magic-link [post]
+func (c *UserController) RequestMagicLink(ctx *gin.Context) {
+	var req domain.MagicLinkRequest
+
+	// Bind JSON request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errMsg := ""
+		for _, err := range err.(validator.ValidationErrors) {
+			errMsg += err.Field() + " is invalid; "
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: errMsg,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.userUsecase.RequestMagicLink(ctx.Request.Context(), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to send login link")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// VerifyMagicLink completes a passwordless login
+// @Summary Log in with a login link
+// @Description Exchange the token of an emailed login link for an access token. Each link works once.
+// @Tags auth
+// @Produce json
+// @Param token query string true "Token from the login link"
+// @Success 200 {object} domain.AuthResponse
+// @Failure 400 {object} domain.AuthResponse
+// @Failure 401 {object} domain.AuthResponse
+// @Failure 403 {object} domain.AuthResponse
+// @Failure 500 {object} domain.AuthResponse
+// @Router /api/v1/auth/magic-link/verify [get]
+func (c *UserController) VerifyMagicLink(ctx *gin.Context) {
+	token := ctx.Query("token")
+	if token == "" {
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: "Login link token is required",
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.userUsecase.VerifyMagicLink(ctx.Request.Context(), token)
+	if err != nil {
+		response.Error(ctx, err, "Failed to log in")
+		return
+	}
+
+	// The response carries a session token
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ResetPassword completes the password recovery flow
+// @Summary Reset password
+// @Description Set a new password using a reset token received by email
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body domain.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} domain.AuthResponse
+// @Failure 400 {object} domain.AuthResponse
+// @Failure 500 {object} domain.AuthResponse
+// @Router /api/v1/auth/reset-password [post]
+func (c *UserController) ResetPassword(ctx *gin.Context) {
+	var req domain.ResetPasswordRequest
+
+	// Bind JSON request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errMsg := ""
+		for _, err := range err.(validator.ValidationErrors) {
+			errMsg += err.Field() + " is invalid; "
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: errMsg,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.userUsecase.ResetPassword(ctx.Request.Context(), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to reset password")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Logout revokes the token used for the request
+// @Summary Logout
+// @Description Revoke the current token, or every token of the user with ?all=true
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Param all query bool false "Log out from all sessions"
+// @Success 200 {object} domain.AuthResponse
+// @Failure 400 {object} domain.AuthResponse
+// @Failure 401 {object} domain.AuthResponse
+// @Failure 500 {object} domain.AuthResponse
+// @Router /api/v1/auth/logout [post]
+func (c *UserController) Logout(ctx *gin.Context) {
+	// Get user and token info from context (set by auth middleware)
+	userID, exists := ctx.Get(constants.ContextUserIDKey)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.AuthResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+	tokenID := ctx.GetString(constants.ContextTokenIDKey)
+	expiresAt := ctx.GetTime(constants.ContextTokenExpKey)
+	if expiresAt.IsZero() {
+		expiresAt = time.Now().Add(24 * time.Hour)
+	}
+
+	// Call use case
+	resp, err := c.userUsecase.Logout(ctx.Request.Context(), userID.(string), tokenID, expiresAt, ctx.Query("all") == "true")
+	if err != nil {
+		response.Error(ctx, err, "Failed to logout")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RefreshToken exchanges the token used for the request for a new one
+// @Summary Refresh access token
+// @Description Issue a new token carrying the user's current role and scopes, and revoke the current one
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} domain.AuthResponse
+// @Failure 401 {object} domain.AuthResponse
+// @Failure 403 {object} domain.AuthResponse
+// @Failure 500 {object} domain.AuthResponse
+// @Router /api/v1/auth/refresh [post]
+func (c *UserController) RefreshToken(ctx *gin.Context) {
+	// Get user and token info from context (set by auth middleware)
+	userID, exists := ctx.Get(constants.ContextUserIDKey)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.AuthResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+	tokenID := ctx.GetString(constants.ContextTokenIDKey)
+	expiresAt := ctx.GetTime(constants.ContextTokenExpKey)
+	if expiresAt.IsZero() {
+		expiresAt = time.Now().Add(24 * time.Hour)
+	}
+
+	// Call use case
+	resp, err := c.userUsecase.RefreshToken(ctx.Request.Context(), userID.(string), tokenID, expiresAt)
+	if err != nil {
+		response.Error(ctx, err, "Failed to refresh token")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetSecurityLog lists the authentication events of the authenticated user's account
+// @Summary Get my security log
+// @Description List signups, logins, failed logins, password changes and token refreshes, newest first
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Page size (max 50)"
+// @Success 200 {object} domain.UserListResponse
+// @Failure 401 {object} domain.AuthResponse
+// @Failure 500 {object} domain.AuthResponse
+// @Router /api/v1/users/me/security-log [get]
+func (c *UserController) GetSecurityLog(ctx *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := ctx.Get(constants.ContextUserIDKey)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.AuthResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	// Get pagination parameters
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+
+	// Call use case
+	resp, err := c.userUsecase.GetSecurityLog(ctx.Request.Context(), userID.(string), page, limit)
+	if err != nil {
+		response.Error(ctx, err, "Failed to retrieve security log")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// OAuthLogin redirects the user to the social login provider
+// @Summary Start social login
+// @Description Redirect to the OAuth provider's consent page
+// @Tags auth
+// @Param provider path string true "OAuth provider (google, linkedin)"
+// @Param role query string false "Role for new accounts (applicant, company)"
+// @Param invite_code query string false "Invite code for new accounts while signups are invite-only"
+// @Success 307
+// @Failure 404 {object} domain.AuthResponse
+// @Router /api/v1/auth/oauth/{provider} [get]
+func (c *UserController) OAuthLogin(ctx *gin.Context) {
+	state, err := utils.GenerateSecureToken()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, domain.AuthResponse{
+			Success: false,
+			Message: "Failed to start social login: " + err.Error(),
+		})
+		return
+	}
+
+	url, err := c.userUsecase.OAuthLoginURL(ctx.Param("provider"), state)
+	if err != nil {
+		response.Error(ctx, err, "Failed to start social login")
+		return
+	}
+
+	// Remember the state (and requested role and invite code) to verify the callback
+	cookieValue := state + "|" + ctx.Query("role") + "|" + ctx.Query("invite_code")
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(oauthStateCookie, cookieValue, 600, "/api/v1/auth/oauth", "", ctx.Request.TLS != nil, true)
+
+	ctx.Redirect(http.StatusTemporaryRedirect, url)
+}
+
+// OAuthCallback completes the social login and issues a JWT
+// @Summary Social login callback
+// @Description Exchange the provider's authorization code, create or link the user and return a JWT
+// @Tags auth
+// @Produce json
+// @Param provider path string true "OAuth provider (google, linkedin)"
+// @Param code query string true "Authorization code"
+// @Param state query string true "Anti-CSRF state"
+// @Success 200 {object} domain.AuthResponse
+// @Failure 400 {object} domain.AuthResponse
+// @Failure 401 {object} domain.AuthResponse
+// @Failure 500 {object} domain.AuthResponse
+// @Router /api/v1/auth/oauth/{provider}/callback [get]
+func (c *UserController) OAuthCallback(ctx *gin.Context) {
+	// Verify the state matches the one issued in OAuthLogin
+	cookieValue, err := ctx.Cookie(oauthStateCookie)
+	state, rest, _ := strings.Cut(cookieValue, "|")
+	role, inviteCode, _ := strings.Cut(rest, "|")
+	if err != nil || state == "" || state != ctx.Query("state") {
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: "Invalid OAuth state",
+		})
+		return
+	}
+	ctx.SetCookie(oauthStateCookie, "", -1, "/api/v1/auth/oauth", "", ctx.Request.TLS != nil, true)
+
+	code := ctx.Query("code")
+	if code == "" {
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: "Authorization code is required",
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.userUsecase.OAuthCallback(ctx.Request.Context(), &domain.OAuthCallbackRequest{
+		Provider:   ctx.Param("provider"),
+		Code:       code,
+		Role:       domain.Role(role),
+		InviteCode: inviteCode,
+	})
+	if err != nil {
+		response.Error(ctx, err, "Social login failed")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// EnrollTwoFactor handles POST /api/v1/users/me/2fa/enroll
+// @Summary Start two-factor enrollment
+// @Description Generate a TOTP secret and otpauth URL (rendered as a QR code by the client) for a company account
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} domain.AuthResponse
+// @Failure 400 {object} domain.AuthResponse
+// @Failure 401 {object} domain.AuthResponse
+// @Failure 500 {object} domain.AuthResponse
+// @Router /api/v1/users/me/2fa/enroll [post]
+func (c *UserController) EnrollTwoFactor(ctx *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := ctx.Get(constants.ContextUserIDKey)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.AuthResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.userUsecase.EnrollTwoFactor(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		response.Error(ctx, err, "Failed to start two-factor enrollment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ConfirmTwoFactor handles POST /api/v1/users/me/2fa/confirm
+// @Summary Confirm two-factor enrollment
+// @Description Enable two-factor authentication by proving the authenticator app generates valid codes
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body domain.TwoFactorConfirmRequest true "Code from the authenticator app"
+// @Success 200 {object} domain.AuthResponse
+// @Failure 400 {object} domain.AuthResponse
+// @Failure 401 {object} domain.AuthResponse
+// @Failure 500 {object} domain.AuthResponse
+// @Router /api/v1/users/me/2fa/confirm [post]
+func (c *UserController) ConfirmTwoFactor(ctx *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := ctx.Get(constants.ContextUserIDKey)
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, domain.AuthResponse{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req domain.TwoFactorConfirmRequest
+
+	// Bind JSON request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errMsg := ""
+		for _, err := range err.(validator.ValidationErrors) {
+			errMsg += err.Field() + " is invalid; "
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: errMsg,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.userUsecase.ConfirmTwoFactor(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		response.Error(ctx, err, "Failed to confirm two-factor enrollment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// VerifyTwoFactorLogin handles POST /api/v1/auth/login/2fa
+// @Summary Complete a two-factor login
+// @Description Exchange the challenge token returned by login and a TOTP code for a JWT token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body domain.TwoFactorLoginRequest true "Challenge token and TOTP code"
+// @Success 200 {object} domain.AuthResponse
+// @Failure 400 {object} domain.AuthResponse
+// @Failure 401 {object} domain.AuthResponse
+// @Failure 500 {object} domain.AuthResponse
+// @Router /api/v1/auth/login/2fa [post]
+func (c *UserController) VerifyTwoFactorLogin(ctx *gin.Context) {
+	var req domain.TwoFactorLoginRequest
+
+	// Bind JSON request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: "Invalid request body",
+		})
+		return
+	}
+
+	// Validate request
+	if err := c.validator.Struct(req); err != nil {
+		errMsg := ""
+		for _, err := range err.(validator.ValidationErrors) {
+			errMsg += err.Field() + " is invalid; "
+		}
+
+		ctx.JSON(http.StatusBadRequest, domain.AuthResponse{
+			Success: false,
+			Message: errMsg,
+		})
+		return
+	}
+
+	// Call use case
+	resp, err := c.userUsecase.VerifyTwoFactorLogin(ctx.Request.Context(), &req)
+	if err != nil {
+		response.Error(ctx, err, "Login failed")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
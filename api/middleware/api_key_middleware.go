@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/api/response"
+	"job-portal-backend/pkg/constants"
+	"job-portal-backend/pkg/ratelimit"
+	"job-portal-backend/usecase"
+)
+
+// APIKeyHeader is the request header carrying a company API key
+const APIKeyHeader = "X-Api-Key"
+
+// APIKeyRateWindow is the window API key rate limits are counted over
+const APIKeyRateWindow = time.Minute
+
+// APIKeyMiddleware authenticates requests carrying an X-Api-Key header as the
+// company that owns the key and enforces the key's rate limit. Keys can only
+// call routes listed in the scope policy; the scope itself is checked by
+// ScopeMiddleware. Requests without the header are left to AuthMiddleware,
+// which must run after it. Requests made with a valid key are counted in the
+// company's API usage with the status they were answered with.
+func APIKeyMiddleware(keys usecase.APIKeyUsecase, usage usecase.APIUsageUsecase, policy ScopePolicy, limiter *ratelimit.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawKey := c.GetHeader(APIKeyHeader)
+		if rawKey == "" {
+			c.Next()
+			return
+		}
+
+		key, err := keys.Authenticate(c.Request.Context(), rawKey)
+		if err != nil {
+			response.Error(c, err, "Failed to validate API key")
+			c.Abort()
+			return
+		}
+
+		if _, ok := policy.Routes[c.Request.Method+" "+c.FullPath()]; !ok {
+			usage.RecordRequest(key, http.StatusForbidden, 0)
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"message": "This endpoint cannot be called with an API key",
+			})
+			return
+		}
+
+		result := limiter.Allow(key.ID.Hex(), key.RateLimit)
+		c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(result.Reset.Unix(), 10))
+		if !result.Allowed {
+			retryAfter := int(time.Until(result.Reset).Seconds()) + 1
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			usage.RecordRequest(key, http.StatusTooManyRequests, result.Limit)
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"success": false,
+				"message": "API key rate limit exceeded",
+			})
+			return
+		}
+
+		// The key acts on behalf of the company that created it
+		c.Set(constants.ContextUserIDKey, key.CompanyID)
+		c.Set(constants.ContextUserRoleKey, constants.RoleCompany)
+		c.Set(constants.ContextAPIKeyIDKey, key.ID.Hex())
+		c.Set(constants.ContextScopesKey, key.Scopes)
+
+		c.Next()
+
+		usage.RecordRequest(key, c.Writer.Status(), result.Limit-result.Remaining)
+	}
+}
@@ -4,17 +4,25 @@ import (
 	// "context"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 
-	"Job-Portal-Backend/domain"
-	"Job-Portal-Backend/pkg/constants"
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/constants"
+	"job-portal-backend/repository"
+	"job-portal-backend/utils"
 )
 
-// AuthMiddleware handles JWT authentication
-func AuthMiddleware() gin.HandlerFunc {
+// AuthMiddleware handles JWT authentication and rejects revoked tokens.
+// Requests already authenticated by APIKeyMiddleware are passed through.
+func AuthMiddleware(tokens *utils.TokenService, revokedTokens repository.RevokedTokenRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if _, ok := c.Get(constants.ContextAPIKeyIDKey); ok {
+			c.Next()
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
@@ -33,17 +41,9 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Parse and validate the JWT token
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			// Verify the token signing method is HMAC
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			// TODO: Replace with config.JWTSecret from environment variables
-			return []byte("your_jwt_secret"), nil
-		})
-
-		// Handle token validation errors or invalid tokens
+		// Parse and validate the JWT token (signature, exp, iss and aud).
+		// Purpose-bound tokens such as 2FA challenges are rejected.
+		claims, err := tokens.ParseAccessToken(tokenString)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"success": false,
@@ -52,51 +52,66 @@ func AuthMiddleware() gin.HandlerFunc {
 			return // Stop further processing for invalid tokens
 		}
 
-		if !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"message": "Expired or invalid token",
-			})
-			return // Stop further processing for invalid tokens
-		}
-
-		// Extract and verify token claims
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
+		if claims.Role == "" {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"success": false,
-				"message": "Invalid token claims",
+				"message": "Invalid user role in token",
 			})
 			return
 		}
 
-		// Add user info to context
-		userID, ok := claims["user_id"].(string)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+		// Reject tokens that were revoked (logout, password reset, session limit, ...) before they expired
+		var issuedAt time.Time
+		if claims.IssuedAt != nil {
+			issuedAt = claims.IssuedAt.Time
+		}
+		revoked, err := revokedTokens.IsRevoked(c.Request.Context(), claims.ID, claims.UserID, issuedAt)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
 				"success": false,
-				"message": "Invalid user ID in token",
+				"message": "Failed to validate authentication token",
 			})
 			return
 		}
-
-		userRole, ok := claims["role"].(string)
-		if !ok {
+		if revoked {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"success": false,
-				"message": "Invalid user role in token",
+				"message": "Token has been revoked",
 			})
 			return
 		}
 
 		// Set user info in context
-		c.Set(constants.ContextUserIDKey, userID)
-		c.Set(constants.ContextUserRoleKey, userRole)
+		c.Set(constants.ContextUserIDKey, claims.UserID)
+		c.Set(constants.ContextUserRoleKey, claims.Role)
+		c.Set(constants.ContextTokenIDKey, claims.ID)
+		c.Set(constants.ContextTokenExpKey, claims.ExpiresAt.Time)
+
+		// Tokens issued before scopes were introduced get the scopes of their role
+		scopes := claims.Scopes()
+		if claims.Scope == "" {
+			scopes = domain.ScopesForRole(domain.Role(claims.Role))
+		}
+		c.Set(constants.ContextScopesKey, scopes)
 
 		c.Next()
 	}
 }
 
+// OptionalAuthMiddleware authenticates requests that carry a bearer token and
+// lets anonymous ones through. It is used on public routes that show more to
+// signed-in users, e.g. unpublished jobs to their owner.
+func OptionalAuthMiddleware(tokens *utils.TokenService, revokedTokens repository.RevokedTokenRepository) gin.HandlerFunc {
+	auth := AuthMiddleware(tokens, revokedTokens)
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		auth(c)
+	}
+}
+
 // RequireRole is a middleware that checks if the user has the required role
 func RequireRole(requiredRole string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -110,8 +125,10 @@ func RequireRole(requiredRole string) gin.HandlerFunc {
 			return
 		}
 
-		// Check if user has the required role
-		if userRole != requiredRole {
+		// Check if user has the required role. Read-only roles may read what
+		// every role can, ReadOnlyMiddleware keeps them from changing anything.
+		role, _ := userRole.(string)
+		if role != requiredRole && !(isSafeMethod(c.Request.Method) && isReadOnlyRole(role)) {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
 				"success": false,
 				"message": "Insufficient permissions. Required role: " + requiredRole,
@@ -135,7 +152,8 @@ func RoleMiddleware(requiredRole string) gin.HandlerFunc {
 			return
 		}
 
-		if userRole != requiredRole {
+		role, _ := userRole.(string)
+		if role != requiredRole && !(isSafeMethod(c.Request.Method) && isReadOnlyRole(role)) {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
 				"success": false,
 				"message": "Insufficient permissions",
@@ -160,4 +178,4 @@ func GetUserFromContext(c *gin.Context) (string, string, bool) {
 	}
 
 	return userID.(string), userRole.(string), true
-}
\ No newline at end of file
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/pkg/constants"
+)
+
+// Auditors read through the role checks, their writes are still refused
+func TestRequireRoleLetsReadOnlyRolesRead(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		role   string
+		method string
+		want   int
+	}{
+		{constants.RoleCompany, http.MethodGet, http.StatusOK},
+		{constants.RoleAuditor, http.MethodGet, http.StatusOK},
+		{constants.RoleAuditor, http.MethodHead, http.StatusOK},
+		{constants.RoleAuditor, http.MethodPost, http.StatusForbidden},
+		{constants.RoleApplicant, http.MethodGet, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		router := gin.New()
+		router.Use(func(c *gin.Context) { c.Set(constants.ContextUserRoleKey, tt.role) })
+		router.Handle(tt.method, "/jobs", RequireRole(constants.RoleCompany), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(tt.method, "/jobs", nil))
+		if w.Code != tt.want {
+			t.Errorf("%s %s as %s = %d, want %d", tt.method, "/jobs", tt.role, w.Code, tt.want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChaosHeader is set on responses a fault was injected into, with the kind of
+// fault: "latency", "error" or both
+const ChaosHeader = "X-Chaos-Injected"
+
+// ChaosRule injects faults into a share of a route's requests, to test how
+// clients cope with slow or failing responses. Route is the method and
+// registered route pattern, as in ScopePolicy ("GET /api/v1/jobs/:id"), or
+// "*" for every route.
+type ChaosRule struct {
+	Route string `json:"route"`
+	// LatencyMs is added to LatencyPercent (0-100) of the requests
+	LatencyMs      int     `json:"latency_ms"`
+	LatencyPercent float64 `json:"latency_percent"`
+	// ErrorStatus is returned instead of running the handler for ErrorPercent (0-100) of the requests
+	ErrorStatus  int     `json:"error_status"`
+	ErrorPercent float64 `json:"error_percent"`
+}
+
+// ChaosConfig holds the fault injection rules. The first rule matching a
+// request applies. Random returns numbers in [0, 1) and defaults to math/rand.
+type ChaosConfig struct {
+	Rules  []ChaosRule
+	Random func() float64
+}
+
+// ParseChaosRules parses a JSON array of rules and checks their values
+func ParseChaosRules(raw string) ([]ChaosRule, error) {
+	var rules []ChaosRule
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		return nil, err
+	}
+
+	for i, rule := range rules {
+		switch {
+		case rule.Route == "":
+			return nil, fmt.Errorf("rule %d: route is required", i)
+		case rule.LatencyMs < 0:
+			return nil, fmt.Errorf("rule %d: latency_ms can't be negative", i)
+		case rule.LatencyPercent < 0 || rule.LatencyPercent > 100:
+			return nil, fmt.Errorf("rule %d: latency_percent must be between 0 and 100", i)
+		case rule.ErrorPercent < 0 || rule.ErrorPercent > 100:
+			return nil, fmt.Errorf("rule %d: error_percent must be between 0 and 100", i)
+		case rule.ErrorPercent > 0 && (rule.ErrorStatus < 400 || rule.ErrorStatus > 599):
+			return nil, fmt.Errorf("rule %d: error_status must be a 4xx or 5xx status", i)
+		}
+	}
+	return rules, nil
+}
+
+// ChaosMiddleware delays or fails requests according to the rules. It must
+// only be installed outside production.
+func ChaosMiddleware(cfg ChaosConfig) gin.HandlerFunc {
+	if len(cfg.Rules) == 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	if cfg.Random == nil {
+		cfg.Random = rand.Float64
+	}
+
+	return func(c *gin.Context) {
+		rule := matchChaosRule(cfg.Rules, c.Request.Method+" "+c.FullPath())
+		if rule == nil {
+			c.Next()
+			return
+		}
+
+		injected := ""
+		if rule.LatencyMs > 0 && cfg.Random()*100 < rule.LatencyPercent {
+			injected = "latency"
+			select {
+			case <-time.After(time.Duration(rule.LatencyMs) * time.Millisecond):
+			case <-c.Request.Context().Done():
+				c.Abort()
+				return
+			}
+		}
+
+		if cfg.Random()*100 < rule.ErrorPercent {
+			if injected != "" {
+				injected += ","
+			}
+			c.Header(ChaosHeader, injected+"error")
+			// Lets clients exercise their retry logic
+			if rule.ErrorStatus == http.StatusServiceUnavailable || rule.ErrorStatus == http.StatusTooManyRequests {
+				c.Header("Retry-After", "1")
+			}
+			c.AbortWithStatusJSON(rule.ErrorStatus, gin.H{
+				"success": false,
+				"message": "Injected fault",
+			})
+			return
+		}
+
+		if injected != "" {
+			c.Header(ChaosHeader, injected)
+		}
+		c.Next()
+	}
+}
+
+func matchChaosRule(rules []ChaosRule, route string) *ChaosRule {
+	for i := range rules {
+		if rules[i].Route == "*" || rules[i].Route == route {
+			return &rules[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/domain"
+)
+
+// ClientInfoMiddleware stores the client IP and user agent in the request
+// context so use cases can record where a request came from
+func ClientInfoMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := domain.WithClientInfo(c.Request.Context(), domain.ClientInfo{
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
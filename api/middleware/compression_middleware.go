@@ -0,0 +1,183 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CompressionConfig controls which responses are compressed.
+// Level 0 disables compression.
+type CompressionConfig struct {
+	Level        int
+	MinSize      int
+	ContentTypes []string
+}
+
+// DefaultCompressionConfig compresses JSON responses and list exports of 1KB or more
+func DefaultCompressionConfig() CompressionConfig {
+	return CompressionConfig{
+		Level:   gzip.DefaultCompression,
+		MinSize: 1024,
+		ContentTypes: []string{
+			"application/json",
+			"text/csv",
+			"application/xml",
+			"text/xml",
+		},
+	}
+}
+
+// CompressionMiddleware gzip or deflate compresses responses when the client
+// accepts it. The body is buffered until MinSize bytes have been written, so
+// small responses are sent as is.
+func CompressionMiddleware(cfg CompressionConfig) gin.HandlerFunc {
+	if cfg.Level == 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		c.Header("Vary", "Accept-Encoding")
+		w := &compressWriter{ResponseWriter: c.Writer, cfg: &cfg, encoding: encoding}
+		c.Writer = w
+		defer func() {
+			w.finish()
+			c.Writer = w.ResponseWriter
+		}()
+
+		c.Next()
+	}
+}
+
+// negotiateEncoding picks gzip or deflate from an Accept-Encoding header
+func negotiateEncoding(header string) string {
+	accepted := map[string]bool{}
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.ReplaceAll(params, " ", "") == "q=0" {
+			continue
+		}
+		accepted[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
+	switch {
+	case accepted["gzip"]:
+		return "gzip"
+	case accepted["deflate"]:
+		return "deflate"
+	default:
+		return ""
+	}
+}
+
+// compressWriter buffers the start of the body until it knows whether the
+// response is worth compressing
+type compressWriter struct {
+	gin.ResponseWriter
+	cfg        *CompressionConfig
+	encoding   string
+	buf        bytes.Buffer
+	compressor io.WriteCloser
+	decided    bool
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if w.decided {
+		if w.compressor != nil {
+			return w.compressor.Write(p)
+		}
+		return w.ResponseWriter.Write(p)
+	}
+
+	n, _ := w.buf.Write(p)
+	if w.buf.Len() >= w.cfg.MinSize {
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends whatever has been written so far, for streaming handlers
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if gz, ok := w.compressor.(interface{ Flush() error }); ok {
+		_ = gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide compresses the response if it is large enough and of a whitelisted
+// content type, then writes out the buffered bytes
+func (w *compressWriter) decide() error {
+	w.decided = true
+
+	if w.shouldCompress() {
+		header := w.Header()
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+
+		var err error
+		if w.encoding == "gzip" {
+			w.compressor, err = gzip.NewWriterLevel(w.ResponseWriter, w.cfg.Level)
+		} else {
+			w.compressor, err = zlib.NewWriterLevel(w.ResponseWriter, w.cfg.Level)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	defer w.buf.Reset()
+	if w.compressor != nil {
+		_, err := w.compressor.Write(w.buf.Bytes())
+		return err
+	}
+	_, err := w.ResponseWriter.Write(w.buf.Bytes())
+	return err
+}
+
+func (w *compressWriter) shouldCompress() bool {
+	if w.buf.Len() < w.cfg.MinSize || w.Header().Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType, _, _ := strings.Cut(w.Header().Get("Content-Type"), ";")
+	contentType = strings.TrimSpace(contentType)
+	for _, allowed := range w.cfg.ContentTypes {
+		if strings.EqualFold(contentType, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// finish writes out responses that stayed below the threshold and closes the compressor
+func (w *compressWriter) finish() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.compressor != nil {
+		_ = w.compressor.Close()
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/geoip"
+)
+
+// GeoPolicy lists the routes country rules apply to and the countries they
+// block or flag. Countries are ISO 3166-1 alpha-2 codes, e.g. "FR".
+type GeoPolicy struct {
+	Routes  map[string]bool
+	Blocked map[string]bool
+	Flagged map[string]bool
+}
+
+// DefaultGeoPolicy applies the country rules to signups and job postings
+func DefaultGeoPolicy(blocked, flagged []string) GeoPolicy {
+	policy := GeoPolicy{
+		Routes: map[string]bool{
+			"POST /api/v1/auth/signup":                  true,
+			"GET /api/v1/auth/oauth/:provider/callback": true,
+			"GET /api/v1/auth/sso/:company_id/callback": true,
+			"POST /api/v1/jobs":                         true,
+		},
+		Blocked: map[string]bool{},
+		Flagged: map[string]bool{},
+	}
+	for _, country := range blocked {
+		policy.Blocked[country] = true
+	}
+	for _, country := range flagged {
+		policy.Flagged[country] = true
+	}
+	return policy
+}
+
+// GeoIPMiddleware adds the client's country to the client info and enforces
+// the country rules. Requests from blocked countries are rejected on the policy
+// routes; requests from flagged countries go through marked as flagged so the
+// use cases can record it. Must come after ClientInfoMiddleware.
+func GeoIPMiddleware(locator geoip.Locator, policy GeoPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		info := domain.ClientInfoFromContext(c.Request.Context())
+		info.Country = locator.Country(info.IP)
+
+		if policy.Routes[c.Request.Method+" "+c.FullPath()] {
+			if policy.Blocked[info.Country] {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"success": false,
+					"message": "This action is not available in your region",
+				})
+				return
+			}
+			info.GeoFlagged = policy.Flagged[info.Country]
+		}
+
+		c.Request = c.Request.WithContext(domain.WithClientInfo(c.Request.Context(), info))
+		c.Next()
+	}
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/pkg/metrics"
+)
+
+// MetricsMiddleware counts every request and its response status, for the
+// error rate shown on the status page
+func MetricsMiddleware(recorder *metrics.Recorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		recorder.RecordRequest(c.Writer.Status())
+	}
+}
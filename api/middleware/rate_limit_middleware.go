@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/pkg/ratelimit"
+)
+
+// AccountEmailRateWindow is the window the limit of the public endpoints
+// sending account emails (password reset, login link) is counted over
+const AccountEmailRateWindow = 15 * time.Minute
+
+// AccountEmailRateLimit is how many account emails a client IP may request
+// from one endpoint per window
+const AccountEmailRateLimit = 5
+
+// RateLimitByIP limits the requests each client IP makes to the route. Routes
+// sharing the limiter are counted separately.
+func RateLimitByIP(limiter *ratelimit.Limiter, limit int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		result := limiter.Allow(c.FullPath()+" "+c.ClientIP(), limit)
+		c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(result.Reset.Unix(), 10))
+		if !result.Allowed {
+			retryAfter := int(time.Until(result.Reset).Seconds()) + 1
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"success": false,
+				"message": "Too many requests, try again later",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/pkg/constants"
+)
+
+// ReadOnlyPolicy describes which roles are restricted to read-only access and
+// which mutating routes they may still call. Routes are identified by method
+// and the registered route pattern, e.g. "POST /api/v1/auth/logout".
+type ReadOnlyPolicy struct {
+	Roles         []string
+	AllowedWrites []string
+}
+
+// DefaultReadOnlyPolicy returns the policy applied to auditor tokens
+func DefaultReadOnlyPolicy() ReadOnlyPolicy {
+	return ReadOnlyPolicy{
+		Roles: []string{constants.RoleAuditor},
+		AllowedWrites: []string{
+			"POST /api/v1/auth/logout",
+			"POST /api/v1/auth/refresh",
+			// Dismissing a banner only changes what the auditor is shown
+			"POST /api/v1/announcements/:id/dismiss",
+			// Auditors can report problems like any other user
+			"POST /api/v1/support/tickets",
+			"POST /api/v1/support/tickets/:id/reply",
+		},
+	}
+}
+
+// ReadOnlyMiddleware rejects mutating requests made by read-only roles.
+// It must run after AuthMiddleware so the user role is available in the context.
+func ReadOnlyMiddleware(policy ReadOnlyPolicy) gin.HandlerFunc {
+	roles := make(map[string]bool, len(policy.Roles))
+	for _, role := range policy.Roles {
+		roles[role] = true
+	}
+
+	allowed := make(map[string]bool, len(policy.AllowedWrites))
+	for _, route := range policy.AllowedWrites {
+		allowed[route] = true
+	}
+
+	return func(c *gin.Context) {
+		userRole, exists := c.Get(constants.ContextUserRoleKey)
+		if !exists {
+			c.Next()
+			return
+		}
+
+		role, _ := userRole.(string)
+		if !roles[role] || isSafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		// Allow explicitly whitelisted routes (matched on the route pattern, not the raw path)
+		if allowed[c.Request.Method+" "+c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"message": "Read-only role cannot perform this action",
+		})
+	}
+}
+
+// isReadOnlyRole reports whether the default policy restricts the role to reads
+func isReadOnlyRole(role string) bool {
+	for _, readOnly := range DefaultReadOnlyPolicy().Roles {
+		if role == readOnly {
+			return true
+		}
+	}
+	return false
+}
+
+// isSafeMethod reports whether the HTTP method does not modify server state
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
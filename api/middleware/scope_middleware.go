@@ -0,0 +1,227 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/constants"
+)
+
+// ScopePolicy maps routes to the scope they require. Routes are identified by
+// method and the registered route pattern, e.g. "GET /api/v1/jobs/:id/details".
+// Routes in Unscoped only need an authenticated caller; routes listed in
+// neither are refused, so a new route can't be reached before it is assigned
+// a scope or deliberately left without one.
+type ScopePolicy struct {
+	Routes   map[string]string
+	Unscoped []string
+}
+
+// DefaultScopePolicy returns the scopes required by the job, application and
+// user management routes. The account, company settings and admin routes are
+// left to the role checks.
+func DefaultScopePolicy() ScopePolicy {
+	return ScopePolicy{
+		Routes: map[string]string{
+			"GET /api/v1/jobs/changes":                         domain.ScopeJobsRead,
+			"GET /api/v1/jobs/recommended":                     domain.ScopeJobsRead,
+			"GET /api/v1/jobs/:id/details":                     domain.ScopeJobsRead,
+			"GET /api/v1/users/me/jobs":                        domain.ScopeJobsRead,
+			"POST /api/v1/jobs":                                domain.ScopeJobsWrite,
+			"PUT /api/v1/jobs/:id":                             domain.ScopeJobsWrite,
+			"DELETE /api/v1/jobs/:id":                          domain.ScopeJobsWrite,
+			"POST /api/v1/jobs/:id/confirm-hiring":             domain.ScopeJobsWrite,
+			"POST /api/v1/jobs/:id/repost":                     domain.ScopeJobsWrite,
+			"POST /api/v1/jobs/:id/close":                      domain.ScopeJobsWrite,
+			"POST /api/v1/jobs/:id/clone":                      domain.ScopeJobsWrite,
+			"GET /api/v1/jobs/templates":                       domain.ScopeJobsRead,
+			"POST /api/v1/jobs/templates":                      domain.ScopeJobsWrite,
+			"DELETE /api/v1/jobs/templates/:templateId":        domain.ScopeJobsWrite,
+			"POST /api/v1/jobs/from-template/:templateId":      domain.ScopeJobsWrite,
+			"POST /api/v1/jobs/:id/publish":                    domain.ScopeJobsWrite,
+			"POST /api/v1/jobs/:id/unpublish":                  domain.ScopeJobsWrite,
+			"POST /api/v1/jobs/:id/restore":                    domain.ScopeJobsWrite,
+			"GET /api/v1/jobs/:id/history":                     domain.ScopeJobsRead,
+			"POST /api/v1/jobs/:id/feature":                    domain.ScopeJobsWrite,
+			"DELETE /api/v1/jobs/:id/feature":                  domain.ScopeJobsWrite,
+			"GET /api/v1/jobs/:id/apply-clicks":                domain.ScopeJobsRead,
+			"GET /api/v1/jobs/:id/applications":                domain.ScopeApplicationsRead,
+			"GET /api/v1/applications/me":                      domain.ScopeApplicationsRead,
+			"GET /api/v1/applications/:id":                     domain.ScopeApplicationsRead,
+			"GET /api/v1/applications/:id/allowed-transitions": domain.ScopeApplicationsRead,
+			"GET /api/v1/applications/:id/events":              domain.ScopeApplicationsRead,
+			"GET /api/v1/applications/:id/notes":               domain.ScopeApplicationsRead,
+			"POST /api/v1/jobs/:id/applications":               domain.ScopeApplicationsWrite,
+			"PUT /api/v1/applications/:id/status":              domain.ScopeApplicationsWrite,
+			"POST /api/v1/applications/:id/notes":              domain.ScopeApplicationsWrite,
+			"PUT /api/v1/applications/:id/interview":           domain.ScopeApplicationsWrite,
+			"POST /api/v1/applications/:id/interview-feedback": domain.ScopeApplicationsWrite,
+			"POST /api/v1/applications/:id/withdraw":           domain.ScopeApplicationsWrite,
+			"GET /api/v1/admin/users":                          domain.ScopeUsersManage,
+			"GET /api/v1/admin/users/:id":                      domain.ScopeUsersManage,
+			"POST /api/v1/admin/auditors":                      domain.ScopeUsersManage,
+			"POST /api/v1/admin/users/:id/suspend":             domain.ScopeUsersManage,
+			"POST /api/v1/admin/users/:id/reactivate":          domain.ScopeUsersManage,
+			"GET /api/v1/admin/role-upgrades":                  domain.ScopeUsersManage,
+			"POST /api/v1/admin/role-upgrades/:id/approve":     domain.ScopeUsersManage,
+			"POST /api/v1/admin/role-upgrades/:id/reject":      domain.ScopeUsersManage,
+		},
+		Unscoped: []string{
+			"POST /api/v1/auth/logout",
+			"POST /api/v1/auth/refresh",
+			"GET /api/v1/files/:key",
+			"POST /api/v1/announcements/:id/dismiss",
+			"GET /api/v1/me/usage",
+
+			// The caller's own account
+			"GET /api/v1/users/me",
+			"PUT /api/v1/users/me",
+			"DELETE /api/v1/users/me",
+			"POST /api/v1/users/me/2fa/enroll",
+			"POST /api/v1/users/me/2fa/confirm",
+			"GET /api/v1/users/me/activity",
+			"POST /api/v1/users/me/activity/read",
+			"GET /api/v1/users/me/alert-preferences",
+			"PUT /api/v1/users/me/alert-preferences",
+			"POST /api/v1/users/me/avatar",
+			"POST /api/v1/users/me/email-change",
+			"GET /api/v1/users/me/following",
+			"GET /api/v1/users/me/preferences",
+			"PUT /api/v1/users/me/preferences",
+			"GET /api/v1/users/me/privacy",
+			"PUT /api/v1/users/me/privacy",
+			"GET /api/v1/users/me/profile",
+			"PUT /api/v1/users/me/profile",
+			"GET /api/v1/users/me/resumes",
+			"POST /api/v1/users/me/resumes",
+			"DELETE /api/v1/users/me/resumes/:id",
+			"GET /api/v1/users/me/role-upgrade",
+			"POST /api/v1/users/me/role-upgrade",
+			"GET /api/v1/users/me/saved-jobs",
+			"POST /api/v1/jobs/:id/save",
+			"DELETE /api/v1/jobs/:id/save",
+			"GET /api/v1/users/me/saved-searches",
+			"POST /api/v1/users/me/saved-searches",
+			"DELETE /api/v1/users/me/saved-searches/:id",
+			"GET /api/v1/users/me/security-log",
+			"GET /api/v1/users/me/ui-preferences",
+			"PUT /api/v1/users/me/ui-preferences",
+			"POST /api/v1/companies/:id/follow",
+			"DELETE /api/v1/companies/:id/follow",
+			"GET /api/v1/support/tickets",
+			"POST /api/v1/support/tickets",
+			"POST /api/v1/support/tickets/:id/reply",
+
+			// Company settings
+			"POST /api/v1/companies/invitations/accept",
+			"GET /api/v1/companies/me/api-keys",
+			"POST /api/v1/companies/me/api-keys",
+			"DELETE /api/v1/companies/me/api-keys/:id",
+			"GET /api/v1/companies/me/dashboard",
+			"GET /api/v1/companies/me/feed-tokens",
+			"POST /api/v1/companies/me/feed-tokens",
+			"DELETE /api/v1/companies/me/feed-tokens/:id",
+			"GET /api/v1/companies/me/members",
+			"POST /api/v1/companies/me/members/invite",
+			"DELETE /api/v1/companies/me/members/:id",
+			"GET /api/v1/companies/me/profile",
+			"PUT /api/v1/companies/me/profile",
+			"DELETE /api/v1/companies/me/profile",
+			"GET /api/v1/companies/me/reports/funnel",
+			"GET /api/v1/companies/me/reports/hiring-outcomes",
+			"GET /api/v1/companies/me/reports/interview-feedback",
+			"GET /api/v1/companies/me/reports/job-closings",
+			"GET /api/v1/companies/me/scheduling-rules",
+			"PUT /api/v1/companies/me/scheduling-rules",
+			"GET /api/v1/companies/me/scheduling-rules/check",
+			"GET /api/v1/companies/me/sla",
+			"PUT /api/v1/companies/me/sla",
+			"GET /api/v1/companies/me/sso",
+			"PUT /api/v1/companies/me/sso",
+			"DELETE /api/v1/companies/me/sso",
+			"POST /api/v1/companies/me/sso/verify-domains",
+
+			// Administration
+			"GET /api/v1/admin/announcements",
+			"POST /api/v1/admin/announcements",
+			"PUT /api/v1/admin/announcements/:id",
+			"DELETE /api/v1/admin/announcements/:id",
+			"GET /api/v1/admin/backups",
+			"POST /api/v1/admin/backups",
+			"GET /api/v1/admin/backups/:id",
+			"POST /api/v1/admin/backups/:id/verify",
+			"GET /api/v1/admin/categories",
+			"POST /api/v1/admin/categories",
+			"PUT /api/v1/admin/categories/:id",
+			"DELETE /api/v1/admin/categories/:id",
+			"GET /api/v1/admin/events",
+			"POST /api/v1/admin/events/replay",
+			"POST /api/v1/admin/events/:id/replay",
+			"GET /api/v1/admin/feed-tokens",
+			"POST /api/v1/admin/feed-tokens",
+			"DELETE /api/v1/admin/feed-tokens/:id",
+			"GET /api/v1/admin/invite-codes",
+			"POST /api/v1/admin/invite-codes",
+			"DELETE /api/v1/admin/invite-codes/:id",
+			"GET /api/v1/admin/job-flags",
+			"POST /api/v1/admin/job-flags/:id/resolve",
+			"GET /api/v1/admin/jobs/moderation",
+			"POST /api/v1/admin/jobs/moderation/:id/approve",
+			"POST /api/v1/admin/jobs/moderation/:id/reject",
+			"GET /api/v1/admin/reports/funnel",
+			"GET /api/v1/admin/reports/hiring-outcomes",
+			"GET /api/v1/admin/reports/interview-feedback",
+			"GET /api/v1/admin/reports/job-closings",
+			"POST /api/v1/admin/retention/purge",
+			"GET /api/v1/admin/security/dashboard",
+			"GET /api/v1/admin/status-transitions",
+			"PUT /api/v1/admin/status-transitions",
+			"DELETE /api/v1/admin/status-transitions",
+			"GET /api/v1/admin/support/tickets",
+			"GET /api/v1/admin/support/tickets/:id",
+			"POST /api/v1/admin/support/tickets/:id/reply",
+		},
+	}
+}
+
+// ScopeMiddleware rejects requests whose token or API key lacks the scope the
+// route requires. It runs in addition to the role checks and must come after
+// AuthMiddleware, which puts the granted scopes in the context.
+func ScopeMiddleware(policy ScopePolicy) gin.HandlerFunc {
+	unscoped := make(map[string]bool, len(policy.Unscoped))
+	for _, route := range policy.Unscoped {
+		unscoped[route] = true
+	}
+
+	return func(c *gin.Context) {
+		route := c.Request.Method + " " + c.FullPath()
+		required, ok := policy.Routes[route]
+		if !ok {
+			if unscoped[route] {
+				c.Next()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"message": "This endpoint is not covered by the scope policy",
+			})
+			return
+		}
+
+		scopes, _ := c.Get(constants.ContextScopesKey)
+		granted, _ := scopes.([]string)
+		for _, scope := range granted {
+			if scope == required {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"message": "Missing required scope: " + required,
+		})
+	}
+}
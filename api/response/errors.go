@@ -0,0 +1,29 @@
+package response
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "job-portal-backend/pkg/errors"
+)
+
+// Error writes err as a JSON error response. Business errors returned by the use
+// cases keep their status code and message (404 not found, 403 forbidden,
+// 409 conflict, ...); anything else is reported as a 500 with message.
+func Error(c *gin.Context, err error, message string) {
+	if appErr, ok := apperrors.As(err); ok {
+		c.JSON(appErr.Code, apperrors.ErrorResponse{
+			Success: false,
+			Message: appErr.Message,
+			Errors:  appErr.Details,
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, apperrors.ErrorResponse{
+		Success: false,
+		Message: message,
+		Errors:  []string{err.Error()},
+	})
+}
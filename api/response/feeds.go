@@ -0,0 +1,185 @@
+package response
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"job-portal-backend/domain"
+)
+
+// atomNamespace declares the Atom elements used in RSS feeds
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+// sitemapNamespace is the namespace of the sitemaps protocol
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// RSSChannel is an RSS 2.0 feed
+type RSSChannel struct {
+	Title       string
+	Link        string
+	Description string
+	// SelfURL is where the feed itself is served, recommended by feed validators
+	SelfURL       string
+	LastBuildDate string
+	// TTL is how many minutes readers may cache the feed
+	TTL   int
+	Items []RSSItem
+}
+
+// RSSItem is an entry of an RSS feed. PubDate is formatted as RFC 1123.
+type RSSItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        RSSGUID  `xml:"guid"`
+	Description string   `xml:"description"`
+	Categories  []string `xml:"category,omitempty"`
+	PubDate     string   `xml:"pubDate"`
+}
+
+// RSSGUID identifies an item, its link unless IsPermaLink is "false"
+type RSSGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink string `xml:"isPermaLink,attr,omitempty"`
+}
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	AtomLink      *atomLink `xml:"atom:link,omitempty"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	TTL           int       `xml:"ttl,omitempty"`
+	Items         []RSSItem `xml:"item"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+// RSS writes the channel as an RSS 2.0 document
+func RSS(c *gin.Context, status int, channel RSSChannel) {
+	doc := rssDocument{
+		Version: "2.0",
+		Atom:    atomNamespace,
+		Channel: rssChannel{
+			Title:         channel.Title,
+			Link:          channel.Link,
+			Description:   channel.Description,
+			LastBuildDate: channel.LastBuildDate,
+			TTL:           channel.TTL,
+			Items:         channel.Items,
+		},
+	}
+	if channel.SelfURL != "" {
+		doc.Channel.AtomLink = &atomLink{Href: channel.SelfURL, Rel: "self", Type: "application/rss+xml"}
+	}
+
+	writeXML(c, status, "application/rss+xml; charset=utf-8", doc)
+}
+
+type sitemapDocument struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// Sitemap writes the URLs as a sitemap, see https://www.sitemaps.org/protocol.html
+func Sitemap(c *gin.Context, status int, urls []domain.SitemapURL) {
+	doc := sitemapDocument{XMLNS: sitemapNamespace, URLs: make([]sitemapURL, 0, len(urls))}
+	for _, u := range urls {
+		entry := sitemapURL{Loc: u.Loc}
+		if !u.LastMod.IsZero() {
+			entry.LastMod = u.LastMod.UTC().Format(time.RFC3339)
+		}
+		doc.URLs = append(doc.URLs, entry)
+	}
+
+	writeXML(c, status, "application/xml; charset=utf-8", doc)
+}
+
+// JobBoardFeed is the XML job feed external job boards such as Indeed fetch
+type JobBoardFeed struct {
+	Publisher     string
+	PublisherURL  string
+	LastBuildDate string
+	Jobs          []JobBoardJob
+}
+
+// JobBoardJob is a job of a job board feed. Free text is written as CDATA.
+type JobBoardJob struct {
+	Title           CDATA  `xml:"title"`
+	Date            CDATA  `xml:"date"`
+	ReferenceNumber CDATA  `xml:"referencenumber"`
+	URL             CDATA  `xml:"url"`
+	Company         CDATA  `xml:"company"`
+	City            CDATA  `xml:"city"`
+	Description     CDATA  `xml:"description"`
+	Salary          *CDATA `xml:"salary,omitempty"`
+	JobType         *CDATA `xml:"jobtype,omitempty"`
+	Category        *CDATA `xml:"category,omitempty"`
+	Experience      *CDATA `xml:"experience,omitempty"`
+	RemoteType      *CDATA `xml:"remotetype,omitempty"`
+	ExpirationDate  *CDATA `xml:"expirationdate,omitempty"`
+}
+
+// CDATA is text written as a CDATA section
+type CDATA struct {
+	Text string `xml:",cdata"`
+}
+
+type jobBoardDocument struct {
+	XMLName       xml.Name      `xml:"source"`
+	Publisher     string        `xml:"publisher"`
+	PublisherURL  string        `xml:"publisherurl"`
+	LastBuildDate string        `xml:"lastBuildDate"`
+	Jobs          []JobBoardJob `xml:"job"`
+}
+
+// JobBoard writes the feed in the XML format of Indeed and the job boards following it
+func JobBoard(c *gin.Context, status int, feed JobBoardFeed) {
+	writeXML(c, status, "application/xml; charset=utf-8", jobBoardDocument{
+		Publisher:     feed.Publisher,
+		PublisherURL:  feed.PublisherURL,
+		LastBuildDate: feed.LastBuildDate,
+		Jobs:          feed.Jobs,
+	})
+}
+
+// writeXML writes v as an XML document with the given content type
+func writeXML(c *gin.Context, status int, contentType string, v interface{}) {
+	c.Status(status)
+	c.Header("Content-Type", contentType)
+	if err := WriteXMLDocument(c.Writer, v); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// WriteXMLDocument encodes v as an indented XML document
+func WriteXMLDocument(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
@@ -0,0 +1,184 @@
+package response
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Supported list output formats
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+	FormatXML  = "xml"
+)
+
+// Table is the tabular form of list data, shared by the CSV and XML encoders
+type Table struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// Negotiate picks the output format from the ?format= query parameter or the Accept header.
+// JSON is the default when the client doesn't ask for anything else.
+func Negotiate(c *gin.Context) string {
+	switch strings.ToLower(c.Query("format")) {
+	case FormatCSV:
+		return FormatCSV
+	case FormatXML:
+		return FormatXML
+	case FormatJSON:
+		return FormatJSON
+	}
+
+	switch c.NegotiateFormat(gin.MIMEJSON, "text/csv", gin.MIMEXML, gin.MIMEXML2) {
+	case "text/csv":
+		return FormatCSV
+	case gin.MIMEXML, gin.MIMEXML2:
+		return FormatXML
+	default:
+		return FormatJSON
+	}
+}
+
+// List writes a list response in the negotiated format. JSON clients receive body
+// unchanged; CSV and XML clients receive the rows produced by table.
+func List(c *gin.Context, status int, body interface{}, name string, table func() Table) {
+	switch Negotiate(c) {
+	case FormatCSV:
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".csv"))
+		c.Status(status)
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		if err := WriteCSV(c.Writer, table()); err != nil {
+			_ = c.Error(err)
+		}
+	case FormatXML:
+		c.Status(status)
+		c.Header("Content-Type", "application/xml; charset=utf-8")
+		if err := WriteXML(c.Writer, name, table()); err != nil {
+			_ = c.Error(err)
+		}
+	default:
+		c.JSON(status, body)
+	}
+}
+
+// WriteCSV encodes a table as CSV with a header row. Cells are escaped so
+// spreadsheets don't evaluate user input as formulas, see csvCell.
+func WriteCSV(w io.Writer, t Table) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvRecord(t.Columns)); err != nil {
+		return err
+	}
+	for _, row := range t.Rows {
+		if err := cw.Write(csvRecord(row)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvRecord(cells []string) []string {
+	record := make([]string, len(cells))
+	for i, cell := range cells {
+		record[i] = csvCell(cell)
+	}
+	return record
+}
+
+// csvCell prefixes cells a spreadsheet would read as a formula (starting with
+// = + - @, a tab or a carriage return) with a quote, so they are shown as text.
+// Numbers are left alone, negative amounts stay numeric.
+func csvCell(cell string) string {
+	if cell == "" || !strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return cell
+	}
+	if _, err := strconv.ParseFloat(cell, 64); err == nil {
+		return cell
+	}
+	return "'" + cell
+}
+
+// WriteXML encodes a table as <name><item><column>value</column>...</item></name>
+func WriteXML(w io.Writer, name string, t Table) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	root := xml.StartElement{Name: xml.Name{Local: name}}
+	if err := enc.EncodeToken(root); err != nil {
+		return err
+	}
+
+	for _, row := range t.Rows {
+		item := xml.StartElement{Name: xml.Name{Local: "item"}}
+		if err := enc.EncodeToken(item); err != nil {
+			return err
+		}
+		for i, column := range t.Columns {
+			value := ""
+			if i < len(row) {
+				value = row[i]
+			}
+			if err := enc.EncodeElement(value, xml.StartElement{Name: xml.Name{Local: column}}); err != nil {
+				return err
+			}
+		}
+		if err := enc.EncodeToken(item.End()); err != nil {
+			return err
+		}
+	}
+
+	if err := enc.EncodeToken(root.End()); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
+
+// MapsTable builds a table from map rows using the given column order
+func MapsTable(columns []string, rows []map[string]interface{}) Table {
+	t := Table{Columns: columns, Rows: make([][]string, 0, len(rows))}
+	for _, row := range rows {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = FormatValue(row[column])
+		}
+		t.Rows = append(t.Rows, values)
+	}
+	return t
+}
+
+// FormatValue converts a value to its textual representation for CSV and XML output
+func FormatValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case primitive.ObjectID:
+		return value.Hex()
+	case time.Time:
+		if value.IsZero() {
+			return ""
+		}
+		return value.Format(time.RFC3339)
+	case *time.Time:
+		if value == nil {
+			return ""
+		}
+		return FormatValue(*value)
+	case fmt.Stringer:
+		return value.String()
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,34 @@
+package response
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	table := Table{
+		Columns: []string{"title", "salary"},
+		Rows: [][]string{
+			{"=HYPERLINK(\"https://evil.example\")", "-1500"},
+			{"+1+1", "2.5"},
+			{"-2+3", ""},
+			{"@SUM(A1:A2)", "\tindent"},
+			{"Go developer", "-"},
+		},
+	}
+
+	var b strings.Builder
+	if err := WriteCSV(&b, table); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+
+	want := "title,salary\n" +
+		"\"'=HYPERLINK(\"\"https://evil.example\"\")\",-1500\n" +
+		"'+1+1,2.5\n" +
+		"'-2+3,\n" +
+		"'@SUM(A1:A2),'\tindent\n" +
+		"Go developer,'-\n"
+	if got := b.String(); got != want {
+		t.Errorf("WriteCSV() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,172 @@
+package response
+
+import (
+	"strconv"
+	"strings"
+
+	"job-portal-backend/domain"
+)
+
+// JobColumns is the column order used when exporting jobs
+var JobColumns = []string{"id", "title", "description", "location", "is_published", "created_by", "created_at", "updated_at"}
+
+// JobsTable converts jobs into a table for CSV and XML output
+func JobsTable(jobs []*domain.Job) Table {
+	t := Table{Columns: JobColumns, Rows: make([][]string, 0, len(jobs))}
+	for _, job := range jobs {
+		t.Rows = append(t.Rows, []string{
+			job.ID.Hex(),
+			job.Title,
+			job.Description,
+			job.Location,
+			strconv.FormatBool(job.IsPublished),
+			job.CreatedBy,
+			FormatValue(job.CreatedAt),
+			FormatValue(job.UpdatedAt),
+		})
+	}
+	return t
+}
+
+// MyApplicationColumns is the column order used when exporting an applicant's applications
+var MyApplicationColumns = []string{"id", "job_id", "job_title", "company_name", "status", "applied_at", "resume_link"}
+
+// JobApplicationColumns is the column order used when exporting a job's applications
+var JobApplicationColumns = []string{"id", "job_id", "job_title", "applicant_id", "applicant_name", "email", "status", "applied_at", "resume_link", "cover_letter"}
+
+// UserColumns is the column order used when exporting users
+var UserColumns = []string{"id", "name", "email", "role", "status", "created_at"}
+
+// UsersTable converts users into a table for CSV and XML output
+func UsersTable(users []*domain.User) Table {
+	t := Table{Columns: UserColumns, Rows: make([][]string, 0, len(users))}
+	for _, user := range users {
+		status := user.Status
+		if status == "" {
+			status = domain.UserActive
+		}
+		t.Rows = append(t.Rows, []string{
+			user.ID.Hex(),
+			user.Name,
+			user.Email,
+			string(user.Role),
+			string(status),
+			FormatValue(user.CreatedAt),
+		})
+	}
+	return t
+}
+
+// HiringOutcomesTable converts a hiring outcome report into a table for CSV and
+// XML output, one row per job followed by the totals. Rejections are broken
+// down into one column per reason.
+func HiringOutcomesTable(report *domain.HiringOutcomeReport) Table {
+	columns := []string{"job_id", "job_title", "company_id", "applications", "interviews", "hires", "rejections"}
+	for _, reason := range domain.RejectionReasons {
+		columns = append(columns, "rejected_"+string(reason))
+	}
+
+	row := func(o *domain.JobHiringOutcome) []string {
+		values := []string{
+			o.JobID,
+			o.JobTitle,
+			o.CompanyID,
+			strconv.FormatInt(o.Applications, 10),
+			strconv.FormatInt(o.Interviews, 10),
+			strconv.FormatInt(o.Hires, 10),
+			strconv.FormatInt(o.Rejections, 10),
+		}
+		for _, reason := range domain.RejectionReasons {
+			values = append(values, strconv.FormatInt(o.RejectionReasons[reason], 10))
+		}
+		return values
+	}
+
+	t := Table{Columns: columns, Rows: make([][]string, 0, len(report.Jobs)+1)}
+	for _, job := range report.Jobs {
+		t.Rows = append(t.Rows, row(job))
+	}
+	totals := *report.Totals
+	totals.JobTitle = "Total"
+	t.Rows = append(t.Rows, row(&totals))
+	return t
+}
+
+// JobClosingsTable converts a job closing report into a table for CSV and XML
+// output, one row per closing reason followed by the totals
+func JobClosingsTable(report *domain.JobClosingReport) Table {
+	row := func(s *domain.JobClosingSummary, reason string) []string {
+		timeToHire := ""
+		if s.AvgTimeToHireDays != nil {
+			timeToHire = strconv.FormatFloat(*s.AvgTimeToHireDays, 'f', 1, 64)
+		}
+		return []string{
+			reason,
+			strconv.FormatInt(s.Jobs, 10),
+			strconv.FormatFloat(s.AvgApplicants, 'f', 1, 64),
+			strconv.FormatFloat(s.AvgDaysOpen, 'f', 1, 64),
+			strconv.FormatInt(s.Hires, 10),
+			timeToHire,
+		}
+	}
+
+	t := Table{
+		Columns: []string{"reason", "jobs", "avg_applicants", "avg_days_open", "hires", "avg_time_to_hire_days"},
+		Rows:    make([][]string, 0, len(report.Reasons)+1),
+	}
+	for _, summary := range report.Reasons {
+		t.Rows = append(t.Rows, row(summary, string(summary.Reason)))
+	}
+	t.Rows = append(t.Rows, row(report.Totals, "Total"))
+	return t
+}
+
+// FunnelTable converts a funnel report into a table for CSV and XML output,
+// one row per job, then per company, followed by the totals. Time in stage is
+// given for the stages applications move on from.
+func FunnelTable(report *domain.FunnelReport) Table {
+	stages := []domain.ApplicationStatus{domain.StatusApplied, domain.StatusReviewed, domain.StatusInterview}
+
+	columns := []string{"job_id", "job_title", "company_id"}
+	for _, status := range domain.ApplicationStatuses {
+		columns = append(columns, "reached_"+strings.ToLower(string(status)))
+	}
+	for _, stage := range stages {
+		name := strings.ToLower(string(stage))
+		columns = append(columns, name+"_avg_days", name+"_p50_days", name+"_p90_days")
+	}
+	columns = append(columns, "time_to_hire_avg_days", "time_to_hire_p50_days", "time_to_hire_p75_days", "time_to_hire_p90_days")
+
+	days := func(d float64) string { return strconv.FormatFloat(d, 'f', 1, 64) }
+	row := func(f *domain.JobFunnel) []string {
+		values := []string{f.JobID, f.JobTitle, f.CompanyID}
+		byStage := make(map[domain.ApplicationStatus]*domain.StageFunnel, len(f.Stages))
+		for _, stage := range f.Stages {
+			byStage[stage.Stage] = stage
+			values = append(values, strconv.FormatInt(stage.Reached, 10))
+		}
+		for _, stage := range stages {
+			if s := byStage[stage]; s != nil && s.TimeInStage != nil {
+				values = append(values, days(s.TimeInStage.AvgDays), days(s.TimeInStage.P50Days), days(s.TimeInStage.P90Days))
+			} else {
+				values = append(values, "", "", "")
+			}
+		}
+		if t := f.TimeToHire; t != nil {
+			values = append(values, days(t.AvgDays), days(t.P50Days), days(t.P75Days), days(t.P90Days))
+		} else {
+			values = append(values, "", "", "", "")
+		}
+		return values
+	}
+
+	t := Table{Columns: columns, Rows: make([][]string, 0, len(report.Jobs)+len(report.Companies)+1)}
+	for _, job := range report.Jobs {
+		t.Rows = append(t.Rows, row(job))
+	}
+	for _, company := range report.Companies {
+		t.Rows = append(t.Rows, row(company))
+	}
+	t.Rows = append(t.Rows, row(report.Totals))
+	return t
+}
@@ -0,0 +1,117 @@
+package response
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiPrefix is the path every versioned resource lives under
+const apiPrefix = "/api/v1"
+
+// URLBuilder builds canonical, absolute URLs for API resources so every
+// handler reports resource locations the same way
+type URLBuilder struct {
+	baseURL string
+}
+
+// NewURLBuilder returns a builder for URLs rooted at the public API base URL
+func NewURLBuilder(baseURL string) *URLBuilder {
+	return &URLBuilder{baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// Job returns the URL of a job posting
+func (b *URLBuilder) Job(id string) string {
+	return b.build("jobs", id)
+}
+
+// Application returns the URL of a job application
+func (b *URLBuilder) Application(id string) string {
+	return b.build("applications", id)
+}
+
+// CurrentUser returns the URL of the authenticated user's profile
+func (b *URLBuilder) CurrentUser() string {
+	return b.build("users", "me")
+}
+
+// AdminUser returns the URL an admin reads a user account at
+func (b *URLBuilder) AdminUser(id string) string {
+	return b.build("admin", "users", id)
+}
+
+// JobTemplate returns the URL of a company's reusable job template
+func (b *URLBuilder) JobTemplate(id string) string {
+	return b.build("jobs", "templates", id)
+}
+
+// ApplicationNotes returns the URL listing the hiring team's notes on an application
+func (b *URLBuilder) ApplicationNotes(applicationID string) string {
+	return b.build("applications", applicationID, "notes")
+}
+
+// Resume returns the URL of a resume in the applicant's library
+func (b *URLBuilder) Resume(id string) string {
+	return b.build("users", "me", "resumes", id)
+}
+
+// SavedSearch returns the URL of one of the applicant's saved searches
+func (b *URLBuilder) SavedSearch(id string) string {
+	return b.build("users", "me", "saved-searches", id)
+}
+
+// RoleUpgrade returns the URL of the authenticated user's role upgrade request
+func (b *URLBuilder) RoleUpgrade() string {
+	return b.build("users", "me", "role-upgrade")
+}
+
+// SupportTicket returns the URL of a support ticket
+func (b *URLBuilder) SupportTicket(id string) string {
+	return b.build("support", "tickets", id)
+}
+
+// APIKey returns the URL of one of the company's API keys
+func (b *URLBuilder) APIKey(id string) string {
+	return b.build("companies", "me", "api-keys", id)
+}
+
+// FeedToken returns the URL of one of the company's job feed tokens
+func (b *URLBuilder) FeedToken(id string) string {
+	return b.build("companies", "me", "feed-tokens", id)
+}
+
+// AdminFeedToken returns the URL an admin manages a portal-wide feed token at
+func (b *URLBuilder) AdminFeedToken(id string) string {
+	return b.build("admin", "feed-tokens", id)
+}
+
+// Category returns the URL an admin manages a job category at
+func (b *URLBuilder) Category(id string) string {
+	return b.build("admin", "categories", id)
+}
+
+// Announcement returns the URL an admin manages an announcement at
+func (b *URLBuilder) Announcement(id string) string {
+	return b.build("admin", "announcements", id)
+}
+
+// InviteCode returns the URL an admin manages an invite code at
+func (b *URLBuilder) InviteCode(id string) string {
+	return b.build("admin", "invite-codes", id)
+}
+
+func (b *URLBuilder) build(segments ...string) string {
+	path := apiPrefix
+	for _, s := range segments {
+		path += "/" + url.PathEscape(s)
+	}
+	return b.baseURL + path
+}
+
+// Created writes a 201 response with a Location header pointing at the new resource
+func Created(c *gin.Context, location string, body interface{}) {
+	c.Header("Location", location)
+	c.JSON(http.StatusCreated, body)
+}
@@ -1,44 +1,674 @@
 package router
 
 import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"time"
+
 	"job-portal-backend/api/controller"
 	"job-portal-backend/api/middleware"
+	"job-portal-backend/api/response"
+	"job-portal-backend/config"
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/currency"
+	"job-portal-backend/pkg/email"
+	"job-portal-backend/pkg/geocode"
+	"job-portal-backend/pkg/geoip"
+	"job-portal-backend/pkg/holidays"
+	"job-portal-backend/pkg/imaging"
+	"job-portal-backend/pkg/metrics"
+	"job-portal-backend/pkg/oauth"
+	"job-portal-backend/pkg/ratelimit"
+	"job-portal-backend/pkg/storage"
+	"job-portal-backend/pkg/webhook"
 	"job-portal-backend/repository"
 	"job-portal-backend/usecase"
+	"job-portal-backend/utils"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 type Router struct {
-	authController        *controller.UserController
-	jobController         *controller.JobController
-	applicationController *controller.ApplicationController
+	authController           *controller.UserController
+	jobController            *controller.JobController
+	applicationController    *controller.ApplicationController
+	fileController           *controller.FileController
+	adminController          *controller.AdminController
+	apiKeyController         *controller.APIKeyController
+	apiUsageController       *controller.APIUsageController
+	alertController          *controller.AlertController
+	jwksController           *controller.JWKSController
+	roleUpgradeController    *controller.RoleUpgradeController
+	ssoController            *controller.SSOController
+	profileController        *controller.ProfileController
+	resumeController         *controller.ResumeController
+	companyProfileController *controller.CompanyProfileController
+	reportController         *controller.ReportController
+	slaController            *controller.SLAController
+	schedulingController     *controller.SchedulingController
+	notificationController   *controller.NotificationController
+	backupController         *controller.BackupController
+	companyTeamController    *controller.CompanyTeamController
+	categoryController       *controller.CategoryController
+	followController         *controller.FollowController
+	savedSearchController    *controller.SavedSearchController
+	savedJobController       *controller.SavedJobController
+	jobComparisonController  *controller.JobComparisonController
+	jobFeedController        *controller.JobFeedController
+	uiPreferencesController  *controller.UIPreferencesController
+	noteController           *controller.ApplicationNoteController
+	feedbackController       *controller.InterviewFeedbackController
+	statusController         *controller.StatusController
+	eventController          *controller.EventController
+	announcementController   *controller.AnnouncementController
+	inviteCodeController     *controller.InviteCodeController
+	feedTokenController      *controller.FeedTokenController
+	applyClickController     *controller.JobApplyClickController
+	supportController        *controller.SupportController
+	maintenanceController    *controller.MaintenanceController
+	apiKeyUseCase            usecase.APIKeyUsecase
+	apiUsageUseCase          usecase.APIUsageUsecase
+	apiKeyLimiter            *ratelimit.Limiter
+	accountEmailLimiter      *ratelimit.Limiter
+	resumeSpool              *storage.SpoolingStorage
+	jobUseCase               usecase.JobUseCase
+	appUseCase               usecase.ApplicationUseCase
+	securityUseCase          usecase.SecurityUsecase
+	slaUseCase               usecase.SLAUsecase
+	notificationUseCase      usecase.NotificationUsecase
+	followUseCase            usecase.FollowUsecase
+	savedSearchUseCase       usecase.SavedSearchUsecase
+	trendingUseCase          usecase.TrendingUsecase
+	jobFeedUseCase           usecase.JobFeedUsecase
+	feedbackUseCase          usecase.InterviewFeedbackUsecase
+	statusUseCase            usecase.StatusUsecase
+	eventBus                 usecase.EventBus
+	thumbnailUseCase         usecase.ResumeThumbnailUsecase
+	geocodeUseCase           usecase.JobGeocodeUsecase
+	metrics                  *metrics.Recorder
+	revokedTokenRepo         repository.RevokedTokenRepository
+	tokens                   *utils.TokenService
+	compression              middleware.CompressionConfig
+	chaos                    middleware.ChaosConfig
+	geoLocator               geoip.Locator
+	geoPolicy                middleware.GeoPolicy
+	trustedProxies           []string
 }
 
+// filesBaseURL is where the API serves the files kept in GridFS
+const filesBaseURL = "/api/v1/files"
+
 func NewRouter(db *mongo.Database) *Router {
+	cfg := config.GetEnv()
+
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db)
 	jobRepo := repository.NewJobRepository(db)
 	appRepo := repository.NewApplicationRepository(db)
+	appEventRepo := repository.NewApplicationEventRepository(db)
+	authTokenRepo := repository.NewAuthTokenRepository(db)
+	revokedTokenRepo := repository.NewRevokedTokenRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	apiUsageRepo := repository.NewAPIUsageRepository(db)
+	authEventRepo := repository.NewAuthEventRepository(db)
+	securityAlertRepo := repository.NewSecurityAlertRepository(db)
+	roleUpgradeRepo := repository.NewRoleUpgradeRepository(db)
+	ssoConfigRepo := repository.NewSSOConfigRepository(db)
+	companyMemberRepo := repository.NewCompanyMemberRepository(db)
+	companyInvitationRepo := repository.NewCompanyInvitationRepository(db)
+	alertPrefsRepo := repository.NewAlertPreferencesRepository(db)
+	profileRepo := repository.NewApplicantProfileRepository(db)
+	companyProfileRepo := repository.NewCompanyProfileRepository(db)
+	slaPolicyRepo := repository.NewSLAPolicyRepository(db)
+	schedulingRulesRepo := repository.NewSchedulingRulesRepository(db)
+	resumeRepo := repository.NewResumeRepository(db)
+	notificationPrefsRepo := repository.NewNotificationPreferencesRepository(db)
+	pendingNotificationRepo := repository.NewPendingNotificationRepository(db)
+	followRepo := repository.NewFollowRepository(db)
+	savedSearchRepo := repository.NewSavedSearchRepository(db)
+	savedJobRepo := repository.NewSavedJobRepository(db)
+	jobViewRepo := repository.NewJobViewRepository(db)
+	applyClickRepo := repository.NewJobApplyClickRepository(db)
+	jobAbuseFlagRepo := repository.NewJobAbuseFlagRepository(db)
+	uiPrefsRepo := repository.NewUIPreferencesRepository(db)
+	noteRepo := repository.NewApplicationNoteRepository(db)
+	activityRepo := repository.NewActivityRepository(db)
+	feedbackRepo := repository.NewInterviewFeedbackRepository(db)
+	templateRepo := repository.NewJobTemplateRepository(db)
+	revisionRepo := repository.NewJobRevisionRepository(db)
+	domainEventRepo := repository.NewDomainEventRepository(db)
+	backupRepo := repository.NewBackupRepository(db)
+	categoryRepo := repository.NewCategoryRepository(db)
+	announcementRepo := repository.NewAnnouncementRepository(db)
+	inviteCodeRepo := repository.NewInviteCodeRepository(db)
+	feedTokenRepo := repository.NewFeedTokenRepository(db)
+	supportTicketRepo := repository.NewSupportTicketRepository(db)
+	retentionRepo := repository.NewRetentionRepository(db)
+	statusTransitionsRepo := repository.NewStatusTransitionsRepository(db)
+
+	// Initialize email sender (log only when no SMTP relay is configured)
+	mailer := email.NewLogSender()
+	if cfg.SMTPHost != "" {
+		mailer = email.NewSMTPSender(email.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.EmailFrom,
+		})
+	}
+
+	// Security alerts and domain events are posted to webhooks
+	webhooks := webhook.NewHTTPSender(10 * time.Second)
+
+	// Initialize file storage. Uploads are spooled locally while the provider is down
+	// and the application's and library's resume links are patched once the upload succeeds.
+	var primaryStorage storage.Store = storage.NewLocalStorage(cfg.UploadDir, "/uploads")
+	var fileController *controller.FileController
+	if cfg.StorageDriver == "gridfs" {
+		gridFS, err := storage.NewGridFSStorage(db, filesBaseURL, cfg.StorageQuotaBytes)
+		if err != nil {
+			log.Fatalf("Failed to initialize GridFS storage: %v", err)
+		}
+		primaryStorage = gridFS
+		fileAccessUseCase := usecase.NewFileAccessUsecase(userRepo, resumeRepo, appRepo, jobRepo, companyMemberRepo, supportTicketRepo)
+		fileController = controller.NewFileController(gridFS, fileAccessUseCase, filesBaseURL)
+	}
+	resumeSpool := storage.NewSpoolingStorage(primaryStorage, cfg.SpoolDir, func(ctx context.Context, pendingURL, url string) error {
+		if err := resumeRepo.ReplaceURL(ctx, pendingURL, url); err != nil {
+			return err
+		}
+		return appRepo.ReplaceResumeLink(ctx, pendingURL, url)
+	})
+
+	// Resume thumbnails are rendered with poppler, when it is installed
+	var thumbnailUseCase usecase.ResumeThumbnailUsecase
+	if renderer, err := imaging.NewPopplerRenderer(cfg.PDFToPPMPath); err != nil {
+		log.Printf("Resume thumbnails disabled: %v", err)
+	} else {
+		thumbnailUseCase = usecase.NewResumeThumbnailUsecase(appRepo, primaryStorage, renderer)
+	}
+
+	// Job locations are geocoded for distance search, when a geocoder is configured
+	var geocodeUseCase usecase.JobGeocodeUsecase
+	if cfg.GeocoderURL != "" {
+		geocoder := geocode.NewNominatimGeocoder(cfg.GeocoderURL, "job-portal-backend ("+cfg.APIBaseURL+")", time.Second, 10*time.Second)
+		geocodeUseCase = usecase.NewJobGeocodeUsecase(jobRepo, geocoder)
+	}
+
+	// Salaries are converted into the requester's currency when an exchange rate feed is configured
+	exchangeRates := currency.NewNoopProvider()
+	if cfg.ExchangeRatesURL != "" {
+		exchangeRates = currency.NewCachedProvider(currency.NewHTTPProvider(cfg.ExchangeRatesURL, 10*time.Second), time.Hour)
+	}
+
+	// Interview slots are checked for public holidays when a holiday calendar is configured
+	holidayCalendar := holidays.NewNoopCalendar()
+	if cfg.HolidaysURL != "" {
+		holidayCalendar = holidays.NewCachedCalendar(holidays.NewNagerCalendar(cfg.HolidaysURL, 10*time.Second), 24*time.Hour)
+	}
+
+	// Request metrics and dependency checks for the status page
+	recorder := metrics.NewRecorder()
+	statusUseCase := usecase.NewStatusUsecase(recorder, newDependencyChecks(db, resumeSpool)...)
+
+	// Initialize social login providers that have credentials configured
+	var oauthProviders []*oauth.Provider
+	if cfg.GoogleClientID != "" {
+		oauthProviders = append(oauthProviders, oauth.NewGoogleProvider(oauth.ProviderConfig{
+			ClientID:     cfg.GoogleClientID,
+			ClientSecret: cfg.GoogleClientSecret,
+			RedirectURL:  cfg.APIBaseURL + "/api/v1/auth/oauth/google/callback",
+		}))
+	}
+	if cfg.LinkedInClientID != "" {
+		oauthProviders = append(oauthProviders, oauth.NewLinkedInProvider(oauth.ProviderConfig{
+			ClientID:     cfg.LinkedInClientID,
+			ClientSecret: cfg.LinkedInClientSecret,
+			RedirectURL:  cfg.APIBaseURL + "/api/v1/auth/oauth/linkedin/callback",
+		}))
+	}
+
+	// Access tokens are signed with the active key and expire after 24 hours
+	if cfg.JWTClockSkewSeconds < 0 {
+		log.Fatalf("Invalid JWT_CLOCK_SKEW_SECONDS %d", cfg.JWTClockSkewSeconds)
+	}
+	tokens := utils.NewTokenService(newKeySet(cfg), cfg.JWTIssuer, cfg.JWTAudience, 24*time.Hour, time.Duration(cfg.JWTClockSkewSeconds)*time.Second)
 
 	// Initialize use cases
-	// TODO: Move JWT secret to config
-	jwtSecret := "your-secret-key" // Replace with your actual JWT secret from config
-	userUseCase := usecase.NewUserUsecase(userRepo, jwtSecret)
-	jobUseCase := usecase.NewJobUseCase(jobRepo)
-	appUseCase := usecase.NewApplicationUseCase(appRepo, jobRepo, userRepo)
+	eventBus := usecase.NewEventBus(domainEventRepo, webhooks, cfg.EventWebhookURLs)
+	sessionLimitMode := domain.SessionLimitMode(cfg.SessionLimitMode)
+	if sessionLimitMode != domain.SessionLimitReject && sessionLimitMode != domain.SessionLimitRevokeOldest {
+		log.Fatalf("Invalid SESSION_LIMIT_MODE %q", cfg.SessionLimitMode)
+	}
+	sessionUseCase := usecase.NewSessionUsecase(sessionRepo, revokedTokenRepo, tokens, int(cfg.MaxSessions), sessionLimitMode)
+	userUseCase := usecase.NewUserUsecase(userRepo, authTokenRepo, revokedTokenRepo, authEventRepo, eventBus, mailer, oauthProviders, tokens, sessionUseCase, cfg.FrontendURL, inviteCodeRepo, cfg.InviteOnly)
+	notificationUseCase := usecase.NewNotificationUsecase(notificationPrefsRepo, pendingNotificationRepo, userRepo, mailer, cfg.FrontendURL)
+	jobUseCase := usecase.NewJobUseCase(jobRepo, appRepo, userRepo, companyProfileRepo, jobAbuseFlagRepo, companyMemberRepo, categoryRepo, templateRepo, revisionRepo, mailer, notificationUseCase, exchangeRates, cfg.FrontendURL, cfg.JobModeration)
+	appUseCase := usecase.NewApplicationUseCase(appRepo, appEventRepo, jobRepo, userRepo, profileRepo, alertPrefsRepo, slaPolicyRepo, resumeRepo, companyMemberRepo, notificationUseCase, newStatusMachine(cfg), statusTransitionsRepo, cfg.FrontendURL)
+	loadStatusTransitions(appUseCase)
+	adminUseCase := usecase.NewAdminUsecase(userRepo, revokedTokenRepo, eventBus, tokens)
+	seedAdmin(cfg, adminUseCase)
+	apiKeyUseCase := usecase.NewAPIKeyUsecase(apiKeyRepo, userRepo)
+	apiUsageUseCase := usecase.NewAPIUsageUsecase(apiUsageRepo, apiKeyRepo, domainEventRepo)
+	alertUseCase := usecase.NewAlertUsecase(alertPrefsRepo, jobRepo)
+	profileUseCase := usecase.NewProfileUsecase(profileRepo)
+	resumeUseCase := usecase.NewResumeUsecase(resumeRepo)
+	uiPrefsUseCase := usecase.NewUIPreferencesUsecase(uiPrefsRepo)
+	noteUseCase := usecase.NewApplicationNoteUsecase(noteRepo, activityRepo, appRepo, appEventRepo, jobRepo, userRepo, companyMemberRepo, notificationUseCase, cfg.FrontendURL)
+	activityUseCase := usecase.NewActivityUsecase(activityRepo)
+	schedulingUseCase := usecase.NewSchedulingUsecase(schedulingRulesRepo, companyMemberRepo, holidayCalendar, cfg.HolidayCountries)
+	feedbackUseCase := usecase.NewInterviewFeedbackUsecase(feedbackRepo, appRepo, jobRepo, userRepo, companyMemberRepo, notificationUseCase, schedulingUseCase, cfg.FrontendURL, cfg.ShareInterviewFeedback)
+	companyProfileUseCase := usecase.NewCompanyProfileUsecase(companyProfileRepo, userRepo, jobRepo)
+	companyTeamUseCase := usecase.NewCompanyTeamUsecase(companyMemberRepo, companyInvitationRepo, userRepo, jobRepo, mailer, cfg.FrontendURL)
+	backupUseCase := usecase.NewBackupUsecase(backupRepo, repository.NewDumpRepository(db), newBackupStore(cfg))
+	followUseCase := usecase.NewFollowUsecase(followRepo, userRepo, jobRepo, companyProfileRepo, notificationUseCase, cfg.FrontendURL)
+	savedJobUseCase := usecase.NewSavedJobUsecase(savedJobRepo, jobRepo)
+	savedSearchUseCase := usecase.NewSavedSearchUsecase(savedSearchRepo, jobRepo, alertPrefsRepo, notificationUseCase, exchangeRates, cfg.FrontendURL)
+	reportUseCase := usecase.NewReportUsecase(appRepo, jobRepo)
+	categoryUseCase := usecase.NewCategoryUsecase(categoryRepo, jobRepo)
+	seedCategories(categoryUseCase)
+	announcementUseCase := usecase.NewAnnouncementUsecase(announcementRepo)
+	inviteCodeUseCase := usecase.NewInviteCodeUsecase(inviteCodeRepo)
+	maintenanceUseCase := usecase.NewMaintenanceUsecase(retentionRepo, userRepo, appRepo, appEventRepo, jobRepo, profileRepo, resumeRepo, alertPrefsRepo, notificationPrefsRepo, companyProfileRepo, pendingNotificationRepo)
+	supportUseCase := usecase.NewSupportUsecase(supportTicketRepo, userRepo, mailer, cfg.SupportEmail, cfg.FrontendURL)
+	slaUseCase := usecase.NewSLAUsecase(slaPolicyRepo, appRepo, userRepo, mailer, cfg.FrontendURL)
+	securityUseCase := usecase.NewSecurityUsecase(authEventRepo, securityAlertRepo, mailer, webhooks, cfg.SecurityAlertEmail, cfg.SecurityAlertWebhookURL)
+	roleUpgradeUseCase := usecase.NewRoleUpgradeUsecase(roleUpgradeRepo, userRepo, mailer, newTxFunc(db.Client()))
+	ssoUseCase := usecase.NewSSOUsecase(ssoConfigRepo, companyMemberRepo, userRepo, authEventRepo, eventBus, sessionUseCase, tokens, net.DefaultResolver, cfg.APIBaseURL)
+	jobComparisonUseCase := usecase.NewJobComparisonUsecase(jobUseCase, appRepo, feedbackRepo)
+	jobFeedUseCase := usecase.NewJobFeedUsecase(jobRepo, jobUseCase, cfg.FrontendURL)
+	applyClickUseCase := usecase.NewJobApplyClickUsecase(jobRepo, applyClickRepo, companyMemberRepo)
+	feedTokenUseCase := usecase.NewFeedTokenUsecase(feedTokenRepo, userRepo, companyMemberRepo, cfg.APIBaseURL)
+	trendingUseCase := usecase.NewTrendingUsecase(jobViewRepo, appRepo, jobRepo)
+	accountUseCase := usecase.NewAccountUsecase(userRepo, appRepo, appEventRepo, jobRepo, authTokenRepo, revokedTokenRepo, apiKeyRepo, alertPrefsRepo, profileRepo, resumeRepo, companyProfileRepo, notificationPrefsRepo, pendingNotificationRepo, followRepo, companyMemberRepo, companyInvitationRepo, uiPrefsRepo, activityRepo, templateRepo, supportTicketRepo, savedSearchRepo, savedJobRepo, resumeSpool, tokens, newTxFunc(db.Client()))
 
 	// Initialize controllers
-	authController := controller.NewUserController(userUseCase)
-	jobController := controller.NewJobController(jobUseCase)
-	appController := controller.NewApplicationController(appUseCase)
+	urls := response.NewURLBuilder(cfg.APIBaseURL)
+	authController := controller.NewUserController(userUseCase, accountUseCase, primaryStorage, urls)
+	jobController := controller.NewJobController(jobUseCase, savedJobUseCase, trendingUseCase, urls)
+	appController := controller.NewApplicationController(appUseCase, resumeSpool, urls)
+	adminController := controller.NewAdminController(adminUseCase, securityUseCase, jobUseCase, urls)
+	apiKeyController := controller.NewAPIKeyController(apiKeyUseCase, urls)
+	apiUsageController := controller.NewAPIUsageController(apiUsageUseCase)
+	alertController := controller.NewAlertController(alertUseCase)
+	jwksController := controller.NewJWKSController(tokens)
+	roleUpgradeController := controller.NewRoleUpgradeController(roleUpgradeUseCase, urls)
+	ssoController := controller.NewSSOController(ssoUseCase)
+	profileController := controller.NewProfileController(profileUseCase)
+	resumeController := controller.NewResumeController(resumeUseCase, resumeSpool, urls)
+	companyProfileController := controller.NewCompanyProfileController(companyProfileUseCase)
+	reportController := controller.NewReportController(reportUseCase)
+	slaController := controller.NewSLAController(slaUseCase)
+	schedulingController := controller.NewSchedulingController(schedulingUseCase)
+	notificationController := controller.NewNotificationController(notificationUseCase)
+	followController := controller.NewFollowController(followUseCase)
+	savedSearchController := controller.NewSavedSearchController(savedSearchUseCase, urls)
+	savedJobController := controller.NewSavedJobController(savedJobUseCase)
+	jobComparisonController := controller.NewJobComparisonController(jobComparisonUseCase)
+	jobFeedController := controller.NewJobFeedController(jobFeedUseCase, feedTokenUseCase, cfg.FrontendURL, cfg.APIBaseURL)
+	uiPreferencesController := controller.NewUIPreferencesController(uiPrefsUseCase)
+	noteController := controller.NewApplicationNoteController(noteUseCase, activityUseCase, urls)
+	feedbackController := controller.NewInterviewFeedbackController(feedbackUseCase)
+	statusController := controller.NewStatusController(statusUseCase)
+	eventController := controller.NewEventController(eventBus)
+	backupController := controller.NewBackupController(backupUseCase)
+	companyTeamController := controller.NewCompanyTeamController(companyTeamUseCase)
+	categoryController := controller.NewCategoryController(categoryUseCase, urls)
+	announcementController := controller.NewAnnouncementController(announcementUseCase, urls)
+	inviteCodeController := controller.NewInviteCodeController(inviteCodeUseCase, urls)
+	feedTokenController := controller.NewFeedTokenController(feedTokenUseCase, urls)
+	applyClickController := controller.NewJobApplyClickController(applyClickUseCase)
+	supportController := controller.NewSupportController(supportUseCase, primaryStorage, urls)
+	maintenanceController := controller.NewMaintenanceController(maintenanceUseCase)
+
+	// Compress large JSON responses and list exports
+	compression := middleware.DefaultCompressionConfig()
+	compression.Level = int(cfg.CompressionLevel)
+	compression.MinSize = int(cfg.CompressionMinBytes)
+	if compression.Level < gzip.HuffmanOnly || compression.Level > gzip.BestCompression {
+		log.Fatalf("Invalid COMPRESSION_LEVEL %d", compression.Level)
+	}
 
 	return &Router{
-		authController:        authController,
-		jobController:         jobController,
-		applicationController: appController,
+		authController:           authController,
+		jobController:            jobController,
+		applicationController:    appController,
+		fileController:           fileController,
+		adminController:          adminController,
+		apiKeyController:         apiKeyController,
+		apiUsageController:       apiUsageController,
+		alertController:          alertController,
+		jwksController:           jwksController,
+		roleUpgradeController:    roleUpgradeController,
+		ssoController:            ssoController,
+		profileController:        profileController,
+		resumeController:         resumeController,
+		companyProfileController: companyProfileController,
+		reportController:         reportController,
+		slaController:            slaController,
+		schedulingController:     schedulingController,
+		notificationController:   notificationController,
+		followController:         followController,
+		savedSearchController:    savedSearchController,
+		savedJobController:       savedJobController,
+		jobComparisonController:  jobComparisonController,
+		jobFeedController:        jobFeedController,
+		uiPreferencesController:  uiPreferencesController,
+		noteController:           noteController,
+		feedbackController:       feedbackController,
+		statusController:         statusController,
+		eventController:          eventController,
+		backupController:         backupController,
+		companyTeamController:    companyTeamController,
+		categoryController:       categoryController,
+		announcementController:   announcementController,
+		inviteCodeController:     inviteCodeController,
+		feedTokenController:      feedTokenController,
+		applyClickController:     applyClickController,
+		supportController:        supportController,
+		maintenanceController:    maintenanceController,
+		apiKeyUseCase:            apiKeyUseCase,
+		apiUsageUseCase:          apiUsageUseCase,
+		apiKeyLimiter:            ratelimit.NewLimiter(middleware.APIKeyRateWindow),
+		accountEmailLimiter:      ratelimit.NewLimiter(middleware.AccountEmailRateWindow),
+		resumeSpool:              resumeSpool,
+		jobUseCase:               jobUseCase,
+		appUseCase:               appUseCase,
+		securityUseCase:          securityUseCase,
+		slaUseCase:               slaUseCase,
+		notificationUseCase:      notificationUseCase,
+		followUseCase:            followUseCase,
+		savedSearchUseCase:       savedSearchUseCase,
+		trendingUseCase:          trendingUseCase,
+		jobFeedUseCase:           jobFeedUseCase,
+		feedbackUseCase:          feedbackUseCase,
+		statusUseCase:            statusUseCase,
+		eventBus:                 eventBus,
+		thumbnailUseCase:         thumbnailUseCase,
+		geocodeUseCase:           geocodeUseCase,
+		metrics:                  recorder,
+		revokedTokenRepo:         revokedTokenRepo,
+		tokens:                   tokens,
+		compression:              compression,
+		chaos:                    newChaosConfig(cfg),
+		geoLocator:               newGeoLocator(cfg),
+		geoPolicy:                middleware.DefaultGeoPolicy(cfg.GeoIPBlockedCountries, cfg.GeoIPFlaggedCountries),
+		trustedProxies:           cfg.TrustedProxies,
+	}
+}
+
+// newDependencyChecks returns the checks of the dependencies shown on the
+// status page. File storage is degraded while uploads wait in the spool,
+// which only happens when the storage provider failed.
+func newDependencyChecks(db *mongo.Database, spool *storage.SpoolingStorage) []usecase.DependencyCheck {
+	return []usecase.DependencyCheck{
+		{Name: "database", Check: func(ctx context.Context) (domain.StatusLevel, error) {
+			if err := db.Client().Ping(ctx, readpref.Primary()); err != nil {
+				return domain.StatusDown, err
+			}
+			return domain.StatusOperational, nil
+		}},
+		{Name: "file_storage", Check: func(ctx context.Context) (domain.StatusLevel, error) {
+			pending, err := spool.Pending()
+			if err != nil {
+				return domain.StatusDown, err
+			}
+			if pending > 0 {
+				return domain.StatusDegraded, nil
+			}
+			return domain.StatusOperational, nil
+		}},
+	}
+}
+
+// newGeoLocator opens the geo-IP database. Without one countries are unknown,
+// so configuring country rules without a database is an error.
+func newGeoLocator(cfg *config.Config) geoip.Locator {
+	if cfg.GeoIPDatabase == "" {
+		if len(cfg.GeoIPBlockedCountries) > 0 || len(cfg.GeoIPFlaggedCountries) > 0 {
+			log.Fatalf("GEOIP_BLOCKED_COUNTRIES and GEOIP_FLAGGED_COUNTRIES need GEOIP_DATABASE")
+		}
+		return geoip.NewNoopLocator()
+	}
+
+	locator, err := geoip.OpenMMDB(cfg.GeoIPDatabase)
+	if err != nil {
+		log.Fatalf("Failed to open GEOIP_DATABASE: %v", err)
+	}
+	return locator
+}
+
+// newStatusMachine builds the application status state machine, using the
+// transition graph from the configuration when one is provided
+func newStatusMachine(cfg *config.Config) *domain.StatusMachine {
+	transitions, source := domain.DefaultStatusTransitions, "default"
+	if cfg.StatusTransitions != "" {
+		transitions, source = domain.StatusTransitions{}, "config"
+		if err := json.Unmarshal([]byte(cfg.StatusTransitions), &transitions); err != nil {
+			log.Fatalf("Invalid APPLICATION_STATUS_TRANSITIONS: %v", err)
+		}
+	}
+
+	machine, err := domain.NewStatusMachine(transitions, source)
+	if err != nil {
+		log.Fatalf("Invalid application status transitions: %v", err)
+	}
+	return machine
+}
+
+// newChaosConfig parses the fault injection rules. They are never applied in production.
+func newChaosConfig(cfg *config.Config) middleware.ChaosConfig {
+	if cfg.ChaosRules == "" {
+		return middleware.ChaosConfig{}
+	}
+	if cfg.IsProduction() {
+		log.Printf("CHAOS_RULES is ignored in production")
+		return middleware.ChaosConfig{}
+	}
+
+	rules, err := middleware.ParseChaosRules(cfg.ChaosRules)
+	if err != nil {
+		log.Fatalf("Invalid CHAOS_RULES: %v", err)
+	}
+	log.Printf("Fault injection enabled with %d rules", len(rules))
+	return middleware.ChaosConfig{Rules: rules}
+}
+
+// newKeySet loads the JWT signing keys. Tokens are signed with JWT_PRIVATE_KEY
+// when set, else with the JWT_KEYS key named by JWT_SIGNING_KEY_ID, else with
+// JWT_SECRET. Once keys are configured JWT_SECRET only verifies tokens issued
+// before the first rotation, if it is set at all, and can be emptied once
+// they have expired.
+func newKeySet(cfg *config.Config) *utils.KeySet {
+	var keys map[string]utils.Key
+	if cfg.JWTKeys != "" {
+		var err error
+		keys, err = utils.LoadJWKS(context.Background(), cfg.JWTKeys)
+		if err != nil {
+			log.Fatalf("Failed to load JWT_KEYS: %v", err)
+		}
+	}
+	keySet := utils.NewKeySet(keys, []byte(cfg.JWTSecret))
+
+	var err error
+	switch {
+	case cfg.JWTPrivateKey != "":
+		private, loadErr := utils.LoadPrivateKey(cfg.JWTPrivateKey)
+		if loadErr != nil {
+			log.Fatalf("Failed to load JWT_PRIVATE_KEY: %v", loadErr)
+		}
+		err = keySet.UsePrivateKey(cfg.JWTSigningKeyID, private)
+	case len(keys) > 0:
+		err = keySet.UseKey(cfg.JWTSigningKeyID)
+	case cfg.JWTSecret == "":
+		err = errors.New("JWT_SECRET, JWT_KEYS or JWT_PRIVATE_KEY must be set")
+	}
+	if err != nil {
+		log.Fatalf("Invalid JWT key configuration: %v", err)
+	}
+	return keySet
+}
+
+// newTxFunc runs usecase work inside a MongoDB transaction. Transactions need a
+// replica set; on a standalone server (local development) the work runs without one.
+func newTxFunc(client *mongo.Client) usecase.TxFunc {
+	return func(ctx context.Context, fn func(ctx context.Context) error) error {
+		_, err := config.WithTransaction(client, func(sessionCtx mongo.SessionContext) (interface{}, error) {
+			return nil, fn(sessionCtx)
+		})
+
+		var cmdErr mongo.CommandError
+		if errors.As(err, &cmdErr) && cmdErr.Code == illegalOperationCode {
+			log.Printf("Transactions are not supported by the MongoDB deployment, running without one")
+			return fn(ctx)
+		}
+		return err
+	}
+}
+
+// illegalOperationCode is returned by standalone servers when a transaction is started
+const illegalOperationCode = 20
+
+// newBackupStore returns the store backup archives are kept in
+func newBackupStore(cfg *config.Config) storage.Store {
+	if cfg.BackupStorage == "s3" {
+		return storage.NewS3Storage(storage.S3Config{
+			Endpoint:        cfg.BackupS3Endpoint,
+			Region:          cfg.BackupS3Region,
+			Bucket:          cfg.BackupS3Bucket,
+			AccessKeyID:     cfg.BackupS3AccessKeyID,
+			SecretAccessKey: cfg.BackupS3SecretAccessKey,
+		}, 30*time.Minute)
+	}
+	return storage.NewLocalStorage(cfg.BackupDir, "")
+}
+
+// seedAdmin creates the admin account from the configuration. Without it there
+// would be no way to reach the admin endpoints, since admins can't self-register.
+func seedAdmin(cfg *config.Config, admins usecase.AdminUsecase) {
+	if cfg.AdminEmail == "" {
+		return
+	}
+	if cfg.AdminPassword == "" {
+		log.Printf("ADMIN_EMAIL is set without ADMIN_PASSWORD, skipping admin account seeding")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := admins.EnsureAdmin(ctx, cfg.AdminName, cfg.AdminEmail, cfg.AdminPassword); err != nil {
+		log.Printf("Failed to seed admin account: %v", err)
+	}
+}
+
+// loadStatusTransitions applies the transition graph an admin stored, if any.
+// The configured graph stays in use when it can't be loaded.
+func loadStatusTransitions(applications usecase.ApplicationUseCase) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := applications.ReloadStatusTransitions(ctx); err != nil {
+		log.Printf("Failed to load the stored status transitions: %v", err)
+	}
+}
+
+// seedCategories creates the default job categories on first start, so jobs
+// can be categorized before an admin set up the taxonomy
+func seedCategories(categories usecase.CategoryUsecase) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := categories.EnsureDefaults(ctx); err != nil {
+		log.Printf("Failed to seed job categories: %v", err)
+	}
+}
+
+// StartBackgroundJobs starts the periodic workers. They stop when ctx is cancelled.
+func (r *Router) StartBackgroundJobs(ctx context.Context) {
+	// Retry uploads that were spooled while the storage provider was unavailable
+	go r.resumeSpool.Run(ctx, time.Minute)
+
+	// Remind companies to confirm they are still hiring before the signal lapses
+	go runPeriodically(ctx, time.Hour, "hiring reminders", r.jobUseCase.SendHiringReminders)
+
+	// Pick up the status transitions changed through another instance
+	go runPeriodically(ctx, time.Minute, "status transitions reload", r.appUseCase.ReloadStatusTransitions)
+
+	// Close jobs to applications once their deadline passes
+	go runPeriodically(ctx, time.Minute, "application deadlines", r.jobUseCase.UnpublishPastDeadline)
+
+	// Publish scheduled jobs and take expired ones down
+	go runPeriodically(ctx, time.Minute, "job schedules", r.jobUseCase.RunSchedules)
+
+	// Alert on brute force patterns in failed logins
+	go runPeriodically(ctx, time.Minute, "security anomaly detection", r.securityUseCase.DetectAnomalies)
+
+	// Warn companies about applications close to breaching their response time targets
+	go runPeriodically(ctx, time.Hour, "SLA breach warnings", r.slaUseCase.SendSLAWarnings)
+
+	// Send the daily and weekly notification digests that are due
+	go runPeriodically(ctx, time.Hour, "notification digests", r.notificationUseCase.SendDigests)
+
+	// Tell followers about the jobs their companies published
+	go runPeriodically(ctx, time.Minute, "followed company job notifications", r.followUseCase.NotifyFollowers)
+
+	// Alert applicants of new jobs matching their saved searches
+	go runPeriodically(ctx, 5*time.Minute, "saved search alerts", r.savedSearchUseCase.SendAlerts)
+
+	// Store the job page views and recompute the trending jobs
+	go runPeriodically(ctx, time.Minute, "job view flush", r.trendingUseCase.Flush)
+	go runPeriodically(ctx, domain.TrendingRefreshInterval, "trending jobs", r.trendingUseCase.Refresh)
+
+	// Regenerate the sitemap with the jobs published since
+	go runPeriodically(ctx, domain.SitemapRefreshInterval, "sitemap", r.jobFeedUseCase.RefreshSitemap)
+
+	// Check the dependencies shown on the status page
+	go runPeriodically(ctx, time.Minute, "dependency health checks", r.statusUseCase.CheckDependencies)
+
+	// Ask applicants for feedback once their interview is over
+	go runPeriodically(ctx, time.Hour, "interview feedback requests", r.feedbackUseCase.RequestFeedback)
+
+	// Deliver user lifecycle events to the subscribed webhooks
+	go runPeriodically(ctx, time.Minute, "domain event delivery", r.eventBus.DispatchEvents)
+
+	// Store the API key request counts of the usage dashboard
+	go runPeriodically(ctx, time.Minute, "API usage flush", r.apiUsageUseCase.Flush)
+
+	// Preview the first page of uploaded PDF resumes
+	if r.thumbnailUseCase != nil {
+		go runPeriodically(ctx, time.Minute, "resume thumbnails", r.thumbnailUseCase.GenerateThumbnails)
+	}
+
+	// Look up the coordinates of new and moved job locations
+	if r.geocodeUseCase != nil {
+		go runPeriodically(ctx, time.Minute, "job geocoding", r.geocodeUseCase.GeocodeJobs)
+	}
+}
+
+// runPeriodically calls fn every interval until ctx is cancelled, logging failures
+func runPeriodically(ctx context.Context, interval time.Duration, name string, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := fn(ctx); err != nil {
+				log.Printf("Failed to run %s: %v", name, err)
+			}
+		}
 	}
 }
 
@@ -46,12 +676,34 @@ func (r *Router) SetupRoutes() *gin.Engine {
 	// Create a new Gin router
 	router := gin.Default()
 
+	// The client IP feeds the geo-IP rules, abuse detection and the security
+	// log, so X-Forwarded-For is only believed from the configured proxies
+	if err := router.SetTrustedProxies(r.trustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	// Configure CORS
 	config := cors.DefaultConfig()
 	config.AllowAllOrigins = true
-	config.AllowHeaders = append(config.AllowHeaders, "Authorization")
+	config.AllowHeaders = append(config.AllowHeaders, "Authorization", middleware.APIKeyHeader)
+	config.ExposeHeaders = append(config.ExposeHeaders, "Location", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", middleware.ChaosHeader)
 	router.Use(cors.New(config))
 
+	// Count requests and server errors for the status page
+	router.Use(middleware.MetricsMiddleware(r.metrics))
+
+	// Inject latency and errors for resilience testing (CHAOS_RULES, never in production)
+	router.Use(middleware.ChaosMiddleware(r.chaos))
+
+	// Make the client IP and user agent available to the use cases (security log)
+	router.Use(middleware.ClientInfoMiddleware())
+
+	// Resolve the client's country and apply the country rules to signups and job postings
+	router.Use(middleware.GeoIPMiddleware(r.geoLocator, r.geoPolicy))
+
+	// Public keys for verifying access tokens in other services
+	router.GET("/.well-known/jwks.json", func(c *gin.Context) { r.jwksController.GetJWKS(c) })
+
 	// Health check endpoint
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{
@@ -59,48 +711,231 @@ func (r *Router) SetupRoutes() *gin.Engine {
 		})
 	})
 
+	// Uptime, error rate and dependency health for a public status page
+	router.GET("/status", func(c *gin.Context) { r.statusController.GetStatus(c) })
+
+	// Syndication feeds of the published jobs
+	router.GET("/feeds/jobs.rss", func(c *gin.Context) { r.jobFeedController.GetJobsRSS(c) })
+	router.GET("/sitemap.xml", func(c *gin.Context) { r.jobFeedController.GetSitemap(c) })
+	router.GET("/feeds/indeed.xml", func(c *gin.Context) { r.jobFeedController.GetJobBoardFeed(c) })
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
+	v1.Use(middleware.CompressionMiddleware(r.compression))
 	{
 		// Auth routes
 		authGroup := v1.Group("/auth")
 		{
 			authGroup.POST("/signup", func(c *gin.Context) { r.authController.SignUp(c) })
 			authGroup.POST("/login", func(c *gin.Context) { r.authController.Login(c) })
+			authGroup.POST("/login/2fa", func(c *gin.Context) { r.authController.VerifyTwoFactorLogin(c) })
+			authGroup.POST("/forgot-password", middleware.RateLimitByIP(r.accountEmailLimiter, middleware.AccountEmailRateLimit), func(c *gin.Context) { r.authController.ForgotPassword(c) })
+			authGroup.POST("/reset-password", func(c *gin.Context) { r.authController.ResetPassword(c) })
+			authGroup.POST("/email-change/confirm", func(c *gin.Context) { r.authController.ConfirmEmailChange(c) })
+			authGroup.POST("/magic-link", middleware.RateLimitByIP(r.accountEmailLimiter, middleware.AccountEmailRateLimit), func(c *gin.Context) { r.authController.RequestMagicLink(c) })
+			authGroup.GET("/magic-link/verify", func(c *gin.Context) { r.authController.VerifyMagicLink(c) })
+			authGroup.GET("/oauth/:provider", func(c *gin.Context) { r.authController.OAuthLogin(c) })
+			authGroup.GET("/oauth/:provider/callback", func(c *gin.Context) { r.authController.OAuthCallback(c) })
+			// Enterprise SSO through the company's OpenID Connect identity provider
+			authGroup.GET("/sso/:company_id", func(c *gin.Context) { r.ssoController.Login(c) })
+			authGroup.GET("/sso/:company_id/callback", func(c *gin.Context) { r.ssoController.Callback(c) })
+		}
+
+		// Form metadata for clients, public so it can be fetched before signing in
+		v1.GET("/meta/job-form", func(c *gin.Context) { r.jobController.GetJobFormMeta(c) })
+		v1.GET("/meta/skills", func(c *gin.Context) { r.jobController.GetSkillFacets(c) })
+
+		// Public routes, browsable without an account. A bearer token is still
+		// honoured so owners and admins can see unpublished jobs.
+		public := v1.Group("")
+		public.Use(middleware.OptionalAuthMiddleware(r.tokens, r.revokedTokenRepo))
+		{
+			public.GET("/jobs", func(c *gin.Context) { r.jobController.ListJobs(c) })
+			// Job counts per filter value of the current listing filters
+			public.GET("/jobs/facets", func(c *gin.Context) { r.jobController.GetJobFacets(c) })
+			// Search box autocomplete
+			public.GET("/jobs/suggest", func(c *gin.Context) { r.jobController.SuggestJobs(c) })
+			public.GET("/jobs/compare", func(c *gin.Context) { r.jobComparisonController.CompareJobs(c) })
+			public.GET("/jobs/trending", func(c *gin.Context) { r.jobController.GetTrendingJobs(c) })
+			public.GET("/jobs/:id", func(c *gin.Context) { r.jobController.GetJobDetails(c) })
+			public.GET("/jobs/:id/similar", func(c *gin.Context) { r.jobController.GetSimilarJobs(c) })
+			// schema.org JobPosting for the job page to embed, indexed by Google for Jobs
+			public.GET("/jobs/:id/jsonld", func(c *gin.Context) { r.jobFeedController.GetJobPosting(c) })
+			public.GET("/jobs/:id/apply-redirect", func(c *gin.Context) { r.applyClickController.ApplyRedirect(c) })
+
+			// Shareable employer pages
+			public.GET("/categories", func(c *gin.Context) { r.categoryController.ListCategories(c) })
+			public.GET("/companies/:id", func(c *gin.Context) { r.companyProfileController.GetPublicPage(c) })
+
+			// Banners for maintenance windows and new features, targeted by role
+			public.GET("/announcements", func(c *gin.Context) { r.announcementController.GetAnnouncements(c) })
 		}
 
 		// Protected routes
 		protected := v1.Group("")
-		protected.Use(middleware.AuthMiddleware())
+		// Company integrations authenticate with an X-Api-Key header instead of a JWT
+		protected.Use(middleware.APIKeyMiddleware(r.apiKeyUseCase, r.apiUsageUseCase, middleware.DefaultScopePolicy(), r.apiKeyLimiter))
+		protected.Use(middleware.AuthMiddleware(r.tokens, r.revokedTokenRepo))
+		// Auditors may read everything they can reach but never mutate state
+		protected.Use(middleware.ReadOnlyMiddleware(middleware.DefaultReadOnlyPolicy()))
+		// Tokens and API keys must carry the scope the route requires, on top of the role checks
+		protected.Use(middleware.ScopeMiddleware(middleware.DefaultScopePolicy()))
 		{
+			// Logout needs a valid token, so it lives with the protected routes
+			protected.POST("/auth/logout", func(c *gin.Context) { r.authController.Logout(c) })
+			protected.POST("/auth/refresh", func(c *gin.Context) { r.authController.RefreshToken(c) })
+
+			// Files served by the API when using the GridFS storage provider
+			if r.fileController != nil {
+				protected.GET("/files/:key", func(c *gin.Context) { r.fileController.DownloadFile(c) })
+			}
+
 			// User routes
 			userGroup := protected.Group("/users")
 			{
 				userGroup.GET("/me", func(c *gin.Context) { r.authController.GetProfile(c) })
-				
+				userGroup.PUT("/me", func(c *gin.Context) { r.authController.UpdateProfile(c) })
+				userGroup.DELETE("/me", func(c *gin.Context) { r.authController.DeleteAccount(c) })
+				userGroup.POST("/me/email-change", func(c *gin.Context) { r.authController.RequestEmailChange(c) })
+				userGroup.GET("/me/security-log", func(c *gin.Context) { r.authController.GetSecurityLog(c) })
+				userGroup.POST("/me/avatar", func(c *gin.Context) { r.authController.UploadAvatar(c) })
+
+				// Applicants asking to become a company account
+				userGroup.POST("/me/role-upgrade", middleware.RequireRole("applicant"), func(c *gin.Context) { r.roleUpgradeController.RequestUpgrade(c) })
+				userGroup.GET("/me/role-upgrade", func(c *gin.Context) { r.roleUpgradeController.GetMyUpgrade(c) })
+
 				// User Story 8: Get my posted jobs (company only)
 				userGroup.GET("/me/jobs", middleware.RequireRole("company"), func(c *gin.Context) { r.jobController.GetMyJobs(c) })
+
+				// Two-factor authentication (company only)
+				userGroup.POST("/me/2fa/enroll", middleware.RequireRole("company"), func(c *gin.Context) { r.authController.EnrollTwoFactor(c) })
+				userGroup.POST("/me/2fa/confirm", middleware.RequireRole("company"), func(c *gin.Context) { r.authController.ConfirmTwoFactor(c) })
+
+				// Application notes the company member was mentioned in
+				userGroup.GET("/me/activity", middleware.RequireRole("company"), func(c *gin.Context) { r.noteController.ListActivity(c) })
+				userGroup.POST("/me/activity/read", middleware.RequireRole("company"), func(c *gin.Context) { r.noteController.MarkActivityRead(c) })
+
+				// Which optional emails the user receives, and whether they come as a digest
+				userGroup.GET("/me/preferences", func(c *gin.Context) { r.notificationController.GetPreferences(c) })
+				userGroup.PUT("/me/preferences", func(c *gin.Context) { r.notificationController.UpdatePreferences(c) })
+
+				// Companies the applicant follows
+				userGroup.GET("/me/following", middleware.RequireRole("applicant"), func(c *gin.Context) { r.followController.ListFollowing(c) })
+
+				// Saved job searches, run for new matches to alert on (applicant only)
+				userGroup.POST("/me/saved-searches", middleware.RequireRole("applicant"), func(c *gin.Context) { r.savedSearchController.SaveSearch(c) })
+				userGroup.GET("/me/saved-searches", middleware.RequireRole("applicant"), func(c *gin.Context) { r.savedSearchController.ListSearches(c) })
+				userGroup.DELETE("/me/saved-searches/:id", middleware.RequireRole("applicant"), func(c *gin.Context) { r.savedSearchController.DeleteSearch(c) })
+
+				// Bookmarked jobs (applicant only)
+				userGroup.GET("/me/saved-jobs", middleware.RequireRole("applicant"), func(c *gin.Context) { r.savedJobController.ListSavedJobs(c) })
+
+				// Job alert preferences, including excluded companies and keywords (applicant only)
+				userGroup.GET("/me/alert-preferences", middleware.RequireRole("applicant"), func(c *gin.Context) { r.alertController.GetPreferences(c) })
+				userGroup.PUT("/me/alert-preferences", middleware.RequireRole("applicant"), func(c *gin.Context) { r.alertController.UpdatePreferences(c) })
+
+				// Last used filters and sorts of the applications page and job search, synced across devices (applicant only)
+				userGroup.GET("/me/ui-preferences", middleware.RequireRole("applicant"), func(c *gin.Context) { r.uiPreferencesController.GetPreferences(c) })
+				userGroup.PUT("/me/ui-preferences", middleware.RequireRole("applicant"), func(c *gin.Context) { r.uiPreferencesController.UpdatePreferences(c) })
+
+				// Structured applicant profile, shown to companies with applications
+				userGroup.GET("/me/profile", middleware.RequireRole("applicant"), func(c *gin.Context) { r.profileController.GetProfile(c) })
+				userGroup.PUT("/me/profile", middleware.RequireRole("applicant"), func(c *gin.Context) { r.profileController.UpdateProfile(c) })
+
+				// Resume library, resumes are picked by ID when applying
+				userGroup.GET("/me/resumes", middleware.RequireRole("applicant"), func(c *gin.Context) { r.resumeController.ListResumes(c) })
+				userGroup.POST("/me/resumes", middleware.RequireRole("applicant"), func(c *gin.Context) { r.resumeController.UploadResume(c) })
+				userGroup.DELETE("/me/resumes/:id", middleware.RequireRole("applicant"), func(c *gin.Context) { r.resumeController.DeleteResume(c) })
+
+				// Applicant privacy settings
+				userGroup.GET("/me/privacy", middleware.RequireRole("applicant"), func(c *gin.Context) { r.authController.GetPrivacy(c) })
+				userGroup.PUT("/me/privacy", middleware.RequireRole("applicant"), func(c *gin.Context) { r.authController.UpdatePrivacy(c) })
+			}
+
+			// Dismissed announcements are no longer returned to the user
+			protected.POST("/announcements/:id/dismiss", func(c *gin.Context) { r.announcementController.DismissAnnouncement(c) })
+
+			// Problems reported to the support team
+			protected.POST("/support/tickets", func(c *gin.Context) { r.supportController.CreateTicket(c) })
+			protected.GET("/support/tickets", func(c *gin.Context) { r.supportController.ListMyTickets(c) })
+			protected.POST("/support/tickets/:id/reply", func(c *gin.Context) { r.supportController.ReplyToMyTicket(c) })
+
+			// Applicants follow companies to hear about their new jobs
+			protected.POST("/companies/:id/follow", middleware.RequireRole("applicant"), func(c *gin.Context) { r.followController.Follow(c) })
+			protected.DELETE("/companies/:id/follow", middleware.RequireRole("applicant"), func(c *gin.Context) { r.followController.Unfollow(c) })
+
+			// Recruiters accept invitations to a company's team from their own company account
+			protected.POST("/companies/invitations/accept", middleware.RequireRole("company"), func(c *gin.Context) { r.companyTeamController.AcceptInvitation(c) })
+
+			// Company account settings
+			companyGroup := protected.Group("/companies/me", middleware.RequireRole("company"))
+			{
+				// Team members (owner, admins, recruiters) share the company's jobs and applications
+				companyGroup.GET("/members", func(c *gin.Context) { r.companyTeamController.ListMembers(c) })
+				companyGroup.POST("/members/invite", func(c *gin.Context) { r.companyTeamController.InviteMember(c) })
+				companyGroup.DELETE("/members/:id", func(c *gin.Context) { r.companyTeamController.RemoveMember(c) })
+
+				companyGroup.GET("/profile", func(c *gin.Context) { r.companyProfileController.GetProfile(c) })
+				companyGroup.PUT("/profile", func(c *gin.Context) { r.companyProfileController.SaveProfile(c) })
+				companyGroup.DELETE("/profile", func(c *gin.Context) { r.companyProfileController.DeleteProfile(c) })
+
+				companyGroup.GET("/reports/hiring-outcomes", func(c *gin.Context) { r.reportController.GetCompanyHiringOutcomes(c) })
+				companyGroup.GET("/reports/job-closings", func(c *gin.Context) { r.reportController.GetCompanyJobClosings(c) })
+				companyGroup.GET("/reports/funnel", func(c *gin.Context) { r.reportController.GetCompanyFunnel(c) })
+				companyGroup.GET("/reports/interview-feedback", func(c *gin.Context) { r.feedbackController.GetCompanyFeedback(c) })
+
+				companyGroup.GET("/sla", func(c *gin.Context) { r.slaController.GetPolicy(c) })
+				companyGroup.PUT("/sla", func(c *gin.Context) { r.slaController.SavePolicy(c) })
+				companyGroup.GET("/dashboard", func(c *gin.Context) { r.slaController.GetDashboard(c) })
+
+				companyGroup.GET("/scheduling-rules", func(c *gin.Context) { r.schedulingController.GetRules(c) })
+				companyGroup.PUT("/scheduling-rules", func(c *gin.Context) { r.schedulingController.SaveRules(c) })
+				companyGroup.GET("/scheduling-rules/check", func(c *gin.Context) { r.schedulingController.CheckSlot(c) })
+
+				companyGroup.GET("/sso", func(c *gin.Context) { r.ssoController.GetConfig(c) })
+				companyGroup.PUT("/sso", func(c *gin.Context) { r.ssoController.SaveConfig(c) })
+				companyGroup.DELETE("/sso", func(c *gin.Context) { r.ssoController.DeleteConfig(c) })
+				companyGroup.POST("/sso/verify-domains", func(c *gin.Context) { r.ssoController.VerifyDomains(c) })
 			}
 
 			// Job routes
 			jobGroup := protected.Group("/jobs")
 			{
-				// Public routes (no role restriction)
-				jobGroup.GET("", func(c *gin.Context) { r.jobController.ListJobs(c) })
-				jobGroup.GET("/:id", func(c *gin.Context) { r.jobController.GetJobDetails(c) })
+				// Any signed-in role, the listing and job pages themselves are public
+				jobGroup.GET("/changes", func(c *gin.Context) { r.jobController.GetJobChanges(c) })
+				jobGroup.GET("/recommended", middleware.RequireRole("applicant"), func(c *gin.Context) { r.alertController.GetRecommendedJobs(c) })
+				jobGroup.POST("/:id/save", middleware.RequireRole("applicant"), func(c *gin.Context) { r.savedJobController.SaveJob(c) })
+				jobGroup.DELETE("/:id/save", middleware.RequireRole("applicant"), func(c *gin.Context) { r.savedJobController.UnsaveJob(c) })
 
 				// Company role required routes
 				companyJobs := jobGroup.Group("")
 				companyJobs.Use(middleware.RequireRole("company"))
 				{
 					companyJobs.POST("", func(c *gin.Context) { r.jobController.CreateJob(c) })
+
+					// Reusable postings for recurring roles
+					companyJobs.POST("/templates", func(c *gin.Context) { r.jobController.CreateTemplate(c) })
+					companyJobs.GET("/templates", func(c *gin.Context) { r.jobController.ListTemplates(c) })
+					companyJobs.DELETE("/templates/:templateId", func(c *gin.Context) { r.jobController.DeleteTemplate(c) })
+					companyJobs.POST("/from-template/:templateId", func(c *gin.Context) { r.jobController.CreateJobFromTemplate(c) })
+
 					companyJobs.PUT("/:id", func(c *gin.Context) { r.jobController.UpdateJob(c) })
 					companyJobs.DELETE("/:id", func(c *gin.Context) { r.jobController.DeleteJob(c) })
+					companyJobs.POST("/:id/confirm-hiring", func(c *gin.Context) { r.jobController.ConfirmHiring(c) })
+					companyJobs.POST("/:id/repost", func(c *gin.Context) { r.jobController.RepostJob(c) })
+					companyJobs.POST("/:id/close", func(c *gin.Context) { r.jobController.CloseJob(c) })
+					companyJobs.POST("/:id/clone", func(c *gin.Context) { r.jobController.CloneJob(c) })
+					companyJobs.POST("/:id/publish", func(c *gin.Context) { r.jobController.PublishJob(c) })
+					companyJobs.POST("/:id/unpublish", func(c *gin.Context) { r.jobController.UnpublishJob(c) })
+					companyJobs.POST("/:id/restore", func(c *gin.Context) { r.jobController.RestoreJob(c) })
+					companyJobs.POST("/:id/feature", func(c *gin.Context) { r.jobController.FeatureJob(c) })
+					companyJobs.DELETE("/:id/feature", func(c *gin.Context) { r.jobController.UnfeatureJob(c) })
+					companyJobs.GET("/:id/history", func(c *gin.Context) { r.jobController.GetJobHistory(c) })
+					companyJobs.GET("/:id/apply-clicks", func(c *gin.Context) { r.applyClickController.GetClickStats(c) })
 
 					// User Story 10: Get applications for a job (company only)
 					companyJobs.GET("/:id/applications", func(c *gin.Context) { r.applicationController.GetJobApplications(c) })
-					
+
 					// User Story 9: Get job details (public, but with additional info for company owners)
 					companyJobs.GET("/:id/details", func(c *gin.Context) { r.jobController.GetJobDetails(c) })
 				}
@@ -113,6 +948,107 @@ func (r *Router) SetupRoutes() *gin.Engine {
 				}
 			}
 
+			// API keys for programmatic company access
+			apiKeyGroup := protected.Group("/companies/me/api-keys")
+			apiKeyGroup.Use(middleware.RequireRole("company"))
+			{
+				apiKeyGroup.GET("", func(c *gin.Context) { r.apiKeyController.ListKeys(c) })
+				apiKeyGroup.POST("", func(c *gin.Context) { r.apiKeyController.CreateKey(c) })
+				apiKeyGroup.DELETE("/:id", func(c *gin.Context) { r.apiKeyController.RevokeKey(c) })
+			}
+
+			// Tokens of the job board feed listing the company's jobs
+			feedTokenGroup := protected.Group("/companies/me/feed-tokens")
+			feedTokenGroup.Use(middleware.RequireRole("company"))
+			{
+				feedTokenGroup.GET("", func(c *gin.Context) { r.feedTokenController.ListTokens(c) })
+				feedTokenGroup.POST("", func(c *gin.Context) { r.feedTokenController.CreateToken(c) })
+				feedTokenGroup.DELETE("/:id", func(c *gin.Context) { r.feedTokenController.RevokeToken(c) })
+			}
+
+			// Requests made with the company's API keys and delivery of its webhook events
+			protected.GET("/me/usage", middleware.RequireRole("company"), func(c *gin.Context) { r.apiUsageController.GetUsage(c) })
+
+			// Admin routes
+			adminGroup := protected.Group("/admin")
+			adminGroup.Use(middleware.RequireRole("admin"))
+			{
+				adminGroup.GET("/status-transitions", func(c *gin.Context) { r.applicationController.GetStatusGraph(c) })
+				adminGroup.PUT("/status-transitions", func(c *gin.Context) { r.applicationController.UpdateStatusGraph(c) })
+				adminGroup.DELETE("/status-transitions", func(c *gin.Context) { r.applicationController.ResetStatusGraph(c) })
+
+				// User management
+				adminGroup.GET("/users", func(c *gin.Context) { r.adminController.ListUsers(c) })
+				adminGroup.GET("/users/:id", func(c *gin.Context) { r.adminController.GetUser(c) })
+				adminGroup.POST("/auditors", func(c *gin.Context) { r.adminController.CreateAuditor(c) })
+				adminGroup.POST("/users/:id/suspend", func(c *gin.Context) { r.adminController.SuspendUser(c) })
+				adminGroup.POST("/users/:id/reactivate", func(c *gin.Context) { r.adminController.ReactivateUser(c) })
+
+				// Role upgrade review queue
+				adminGroup.GET("/role-upgrades", func(c *gin.Context) { r.roleUpgradeController.ListUpgrades(c) })
+				adminGroup.POST("/role-upgrades/:id/approve", func(c *gin.Context) { r.roleUpgradeController.ApproveUpgrade(c) })
+				adminGroup.POST("/role-upgrades/:id/reject", func(c *gin.Context) { r.roleUpgradeController.RejectUpgrade(c) })
+
+				// Authentication security
+				adminGroup.GET("/security/dashboard", func(c *gin.Context) { r.adminController.GetSecurityDashboard(c) })
+
+				// Logical database backups and checks that they can be restored
+				adminGroup.GET("/backups", func(c *gin.Context) { r.backupController.ListBackups(c) })
+				adminGroup.POST("/backups", func(c *gin.Context) { r.backupController.StartBackup(c) })
+				adminGroup.GET("/backups/:id", func(c *gin.Context) { r.backupController.GetBackup(c) })
+				adminGroup.POST("/backups/:id/verify", func(c *gin.Context) { r.backupController.VerifyBackup(c) })
+
+				// Data past its retention period, ?dry_run=true only counts it
+				adminGroup.POST("/retention/purge", func(c *gin.Context) { r.maintenanceController.PurgeExpired(c) })
+
+				// Job category taxonomy
+				adminGroup.GET("/categories", func(c *gin.Context) { r.categoryController.ListCategories(c) })
+				adminGroup.POST("/categories", func(c *gin.Context) { r.categoryController.CreateCategory(c) })
+				adminGroup.PUT("/categories/:id", func(c *gin.Context) { r.categoryController.UpdateCategory(c) })
+				adminGroup.DELETE("/categories/:id", func(c *gin.Context) { r.categoryController.DeleteCategory(c) })
+
+				// Portal-wide announcements
+				adminGroup.GET("/announcements", func(c *gin.Context) { r.announcementController.ListAnnouncements(c) })
+				adminGroup.POST("/announcements", func(c *gin.Context) { r.announcementController.CreateAnnouncement(c) })
+				adminGroup.PUT("/announcements/:id", func(c *gin.Context) { r.announcementController.UpdateAnnouncement(c) })
+				adminGroup.DELETE("/announcements/:id", func(c *gin.Context) { r.announcementController.DeleteAnnouncement(c) })
+
+				// Invite codes required to sign up while INVITE_ONLY is on
+				adminGroup.GET("/invite-codes", func(c *gin.Context) { r.inviteCodeController.ListCodes(c) })
+				adminGroup.POST("/invite-codes", func(c *gin.Context) { r.inviteCodeController.CreateCode(c) })
+				adminGroup.DELETE("/invite-codes/:id", func(c *gin.Context) { r.inviteCodeController.DisableCode(c) })
+
+				// Tokens of the job board feed listing every company's jobs
+				adminGroup.GET("/feed-tokens", func(c *gin.Context) { r.feedTokenController.ListTokens(c) })
+				adminGroup.POST("/feed-tokens", func(c *gin.Context) { r.feedTokenController.CreateToken(c) })
+				adminGroup.DELETE("/feed-tokens/:id", func(c *gin.Context) { r.feedTokenController.RevokeToken(c) })
+
+				// Support ticket queue
+				adminGroup.GET("/support/tickets", func(c *gin.Context) { r.supportController.ListTickets(c) })
+				adminGroup.GET("/support/tickets/:id", func(c *gin.Context) { r.supportController.GetTicket(c) })
+				adminGroup.POST("/support/tickets/:id/reply", func(c *gin.Context) { r.supportController.ReplyToTicket(c) })
+
+				// Jobs throttled for gaming the listings
+				adminGroup.GET("/job-flags", func(c *gin.Context) { r.adminController.ListJobAbuseFlags(c) })
+				adminGroup.POST("/job-flags/:id/resolve", func(c *gin.Context) { r.adminController.ResolveJobAbuseFlag(c) })
+
+				// Review of the jobs companies publish, with JOB_MODERATION
+				adminGroup.GET("/jobs/moderation", func(c *gin.Context) { r.adminController.ListPendingJobs(c) })
+				adminGroup.POST("/jobs/moderation/:id/approve", func(c *gin.Context) { r.adminController.ApproveJob(c) })
+				adminGroup.POST("/jobs/moderation/:id/reject", func(c *gin.Context) { r.adminController.RejectJob(c) })
+
+				// Equal-opportunity reporting
+				adminGroup.GET("/reports/hiring-outcomes", func(c *gin.Context) { r.reportController.GetHiringOutcomes(c) })
+				adminGroup.GET("/reports/job-closings", func(c *gin.Context) { r.reportController.GetJobClosings(c) })
+				adminGroup.GET("/reports/funnel", func(c *gin.Context) { r.reportController.GetFunnel(c) })
+				adminGroup.GET("/reports/interview-feedback", func(c *gin.Context) { r.feedbackController.ListFeedbackSummaries(c) })
+
+				// Domain events delivered to webhooks, and their replay
+				adminGroup.GET("/events", func(c *gin.Context) { r.eventController.ListEvents(c) })
+				adminGroup.POST("/events/replay", func(c *gin.Context) { r.eventController.ReplayEvents(c) })
+				adminGroup.POST("/events/:id/replay", func(c *gin.Context) { r.eventController.ReplayEvent(c) })
+			}
+
 			// Application management routes
 			applicationRoutes := protected.Group("/applications")
 			{
@@ -121,17 +1057,32 @@ func (r *Router) SetupRoutes() *gin.Engine {
 				applicantRoutes.Use(middleware.RequireRole("applicant"))
 				{
 					applicantRoutes.GET("/me", func(c *gin.Context) { r.applicationController.GetMyApplications(c) })
+					applicantRoutes.POST("/:id/interview-feedback", func(c *gin.Context) { r.feedbackController.SubmitFeedback(c) })
+					applicantRoutes.POST("/:id/withdraw", func(c *gin.Context) { r.applicationController.WithdrawApplication(c) })
 				}
 
+				// Applicants, owning companies and auditors can view a single application
+				applicationRoutes.GET("/:id", func(c *gin.Context) { r.applicationController.GetApplication(c) })
+
 				// Company routes
 				companyRoutes := applicationRoutes.Group("/:id")
 				companyRoutes.Use(middleware.RequireRole("company"))
 				{
 					companyRoutes.PUT("/status", func(c *gin.Context) { r.applicationController.UpdateApplicationStatus(c) })
+					companyRoutes.GET("/allowed-transitions", func(c *gin.Context) { r.applicationController.GetAllowedTransitions(c) })
+					// Every change to the application, from its event stream
+					companyRoutes.GET("/events", func(c *gin.Context) { r.applicationController.GetApplicationEvents(c) })
+
+					// Internal notes of the hiring team, @email mentions notify teammates
+					companyRoutes.GET("/notes", func(c *gin.Context) { r.noteController.ListNotes(c) })
+					companyRoutes.POST("/notes", func(c *gin.Context) { r.noteController.AddNote(c) })
+
+					// Scheduling the interview lets the applicant give feedback once it's over
+					companyRoutes.PUT("/interview", func(c *gin.Context) { r.feedbackController.ScheduleInterview(c) })
 				}
 			}
 		}
 	}
 
 	return router
-}
\ No newline at end of file
+}
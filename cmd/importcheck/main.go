@@ -0,0 +1,147 @@
+// Command importcheck enforces the boundaries between the packages of the
+// module: each package may only import the packages its layer depends on, so
+// the domain stays free of infrastructure, repositories don't reach into use
+// cases and handlers only talk to use cases. Run it from the module root:
+//
+//	go run ./cmd/importcheck
+//
+// It lists every import crossing a boundary and exits with status 1 if there
+// is any.
+package main
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// module is the import path of the module, as declared in go.mod
+const module = "job-portal-backend"
+
+// rule lists the packages of the module a package may import. Entries match
+// the package and the packages below it.
+type rule struct {
+	pkg     string
+	imports []string
+}
+
+// rules are matched against a package by the longest pkg prefix. Packages
+// without a rule may import anything, they are the composition roots.
+var rules = []rule{
+	{pkg: "domain"},
+	{pkg: "config"},
+	// Shared libraries know nothing of the job portal
+	{pkg: "pkg"},
+	{pkg: "utils", imports: []string{"domain"}},
+	{pkg: "repository", imports: []string{"domain"}},
+	{pkg: "usecase", imports: []string{"domain", "repository", "pkg", "utils"}},
+	{pkg: "api/dto"},
+	{pkg: "api/response", imports: []string{"domain", "pkg/errors"}},
+	{pkg: "api/controller", imports: []string{"api/response", "domain", "pkg", "usecase", "utils"}},
+	// Token revocation is checked on every request, straight from the repository
+	{pkg: "api/middleware", imports: []string{"api/response", "domain", "pkg", "repository", "usecase", "utils"}},
+}
+
+// violation is an import crossing a boundary
+type violation struct {
+	file, pkg, imported string
+}
+
+func main() {
+	violations, err := check(".")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	for _, v := range violations {
+		fmt.Printf("%s: package %s must not import %s\n", v.file, v.pkg, v.imported)
+	}
+	if len(violations) > 0 {
+		os.Exit(1)
+	}
+}
+
+// check parses the imports of every Go file below root
+func check(root string) ([]violation, error) {
+	fset := token.NewFileSet()
+	var violations []violation
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(d.Name(), ".") || d.Name() == "vendor" || d.Name() == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+		pkg := filepath.ToSlash(filepath.Dir(path))
+		for _, spec := range file.Imports {
+			imported, _ := strconv.Unquote(spec.Path.Value)
+			if !allowed(pkg, imported) {
+				violations = append(violations, violation{file: path, pkg: pkg, imported: imported})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(violations, func(i, j int) bool { return violations[i].file < violations[j].file })
+	return violations, nil
+}
+
+// allowed reports whether the package in directory pkg may import imported
+func allowed(pkg, imported string) bool {
+	if imported != module && !strings.HasPrefix(imported, module+"/") {
+		return true // Standard library and third party packages
+	}
+	target := strings.TrimPrefix(strings.TrimPrefix(imported, module), "/")
+
+	r, ok := ruleFor(pkg)
+	if !ok || within(target, r.pkg) {
+		return true
+	}
+	for _, dep := range r.imports {
+		if within(target, dep) {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleFor returns the rule with the longest prefix matching pkg
+func ruleFor(pkg string) (rule, bool) {
+	var (
+		best  rule
+		found bool
+	)
+	for _, r := range rules {
+		if within(pkg, r.pkg) && (!found || len(r.pkg) > len(best.pkg)) {
+			best, found = r, true
+		}
+	}
+	return best, found
+}
+
+// within reports whether pkg is prefix or a package below it
+func within(pkg, prefix string) bool {
+	return pkg == prefix || strings.HasPrefix(pkg, prefix+"/")
+}
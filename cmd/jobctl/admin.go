@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"job-portal-backend/domain"
+)
+
+func newAdminCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Manage admin accounts",
+	}
+
+	var req domain.CreateAdminRequest
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Create an admin account",
+		Long: "Create an admin account. The password is read from JOBCTL_ADMIN_PASSWORD\n" +
+			"when --password is not given, so it doesn't end up in the shell history.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if req.Password == "" {
+				req.Password = os.Getenv("JOBCTL_ADMIN_PASSWORD")
+			}
+			if err := validator.New().Struct(req); err != nil {
+				var errs validator.ValidationErrors
+				if errors.As(err, &errs) {
+					msgs := make([]string, len(errs))
+					for i, e := range errs {
+						msgs[i] = e.Translate(nil)
+					}
+					return fmt.Errorf("invalid admin account: %s", strings.Join(msgs, "; "))
+				}
+				return err
+			}
+
+			return withDatabase(func(ctx context.Context, db *mongo.Database) error {
+				user, err := newMaintenanceUsecase(db).CreateAdmin(ctx, &req)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Created admin %s (%s)\n", user.Email, user.ID.Hex())
+				return nil
+			})
+		},
+	}
+	create.Flags().StringVar(&req.Name, "name", "Admin", "display name")
+	create.Flags().StringVar(&req.Email, "email", "", "login email (required)")
+	create.Flags().StringVar(&req.Password, "password", "", "password, defaults to $JOBCTL_ADMIN_PASSWORD")
+
+	cmd.AddCommand(create)
+	return cmd
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func newApplicationsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "applications",
+		Short: "Manage applications",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "rebuild-projections",
+		Short: "Rebuild applications from their event streams",
+		Long: "Replay the event stream of every application into the applications\n" +
+			"collection, e.g. after its documents were restored from an older backup.\n" +
+			"Applications without events are left as they are.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withDatabase(func(ctx context.Context, db *mongo.Database) error {
+				count, err := newMaintenanceUsecase(db).RebuildApplicationProjections(ctx)
+				fmt.Printf("Rebuilt %d applications\n", count)
+				return err
+			})
+		},
+	})
+
+	return cmd
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"job-portal-backend/config"
+	"job-portal-backend/pkg/storage"
+	"job-portal-backend/repository"
+	"job-portal-backend/usecase"
+)
+
+func newRestoreCommand() *cobra.Command {
+	var dryRun, stored bool
+	cmd := &cobra.Command{
+		Use:   "restore <archive>",
+		Short: "Load a backup archive into an empty database",
+		Long: "Load a backup archive taken with POST /api/v1/admin/backups into the configured\n" +
+			"database. Every collection in the archive must be empty. Run reindex afterwards.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withDatabase(func(ctx context.Context, db *mongo.Database) error {
+				archives := backupStore(config.GetEnv())
+				archive, err := openArchive(ctx, archives, args[0], stored)
+				if err != nil {
+					return err
+				}
+				defer archive.Close()
+
+				backups := usecase.NewBackupUsecase(
+					repository.NewBackupRepository(db),
+					repository.NewDumpRepository(db),
+					archives,
+				)
+				collections, err := backups.Restore(ctx, archive, dryRun)
+				if err != nil {
+					return err
+				}
+
+				if dryRun {
+					fmt.Println("Dry run, the archive can be restored and nothing was loaded")
+				}
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "COLLECTION\tDOCUMENTS")
+				for _, c := range collections {
+					fmt.Fprintf(w, "%s\t%d\n", c.Name, c.Documents)
+				}
+				return w.Flush()
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only check the archive and that its collections are empty")
+	cmd.Flags().BoolVar(&stored, "stored", false, "read the archive with this key from the backup storage instead of a local file")
+	return cmd
+}
+
+// openArchive opens the archive file at name, or the stored archive with key name
+func openArchive(ctx context.Context, archives storage.Store, name string, stored bool) (io.ReadCloser, error) {
+	if !stored {
+		return os.Open(name)
+	}
+	rc, _, err := archives.Download(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("error opening stored archive %s: %w", name, err)
+	}
+	return rc, nil
+}
+
+// backupStore returns the store the API keeps backup archives in
+func backupStore(cfg *config.Config) storage.Store {
+	if cfg.BackupStorage == "s3" {
+		return storage.NewS3Storage(storage.S3Config{
+			Endpoint:        cfg.BackupS3Endpoint,
+			Region:          cfg.BackupS3Region,
+			Bucket:          cfg.BackupS3Bucket,
+			AccessKeyID:     cfg.BackupS3AccessKeyID,
+			SecretAccessKey: cfg.BackupS3SecretAccessKey,
+		}, 30*time.Minute)
+	}
+	return storage.NewLocalStorage(cfg.BackupDir, "")
+}
@@ -0,0 +1,91 @@
+// Command jobctl runs operational tasks against the job portal database:
+// creating admin accounts, rebuilding indexes, requeueing notifications,
+// purging expired data, restoring backups, seeding load test data,
+// rebuilding applications from their events and inspecting a user's data.
+// It reads the same configuration (.env or environment variables) as the API.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"job-portal-backend/config"
+	"job-portal-backend/repository"
+	"job-portal-backend/usecase"
+)
+
+// commandTimeout bounds a single command, purges of large collections included
+const commandTimeout = 10 * time.Minute
+
+func main() {
+	root := &cobra.Command{
+		Use:           "jobctl",
+		Short:         "Job portal administration tool",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	root.AddCommand(
+		newAdminCommand(),
+		newApplicationsCommand(),
+		newReindexCommand(),
+		newNotificationsCommand(),
+		newPurgeCommand(),
+		newRestoreCommand(),
+		newSeedCommand(),
+		newUserCommand(),
+	)
+
+	if err := root.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// withDatabase connects to the configured database and runs fn with a context
+// bounded by commandTimeout
+func withDatabase(fn func(ctx context.Context, db *mongo.Database) error) error {
+	if err := config.Load(); err != nil {
+		return fmt.Errorf("failed to load configuration: %v", err)
+	}
+
+	client, err := config.NewMongoClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to MongoDB: %v", err)
+	}
+	defer config.Disconnect(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	return fn(ctx, config.GetDatabase(client))
+}
+
+// newMaintenanceUsecase wires the use case behind most commands
+func newMaintenanceUsecase(db *mongo.Database) usecase.MaintenanceUsecase {
+	return usecase.NewMaintenanceUsecase(
+		repository.NewRetentionRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewApplicationRepository(db),
+		repository.NewApplicationEventRepository(db),
+		repository.NewJobRepository(db),
+		repository.NewApplicantProfileRepository(db),
+		repository.NewResumeRepository(db),
+		repository.NewAlertPreferencesRepository(db),
+		repository.NewNotificationPreferencesRepository(db),
+		repository.NewCompanyProfileRepository(db),
+		repository.NewPendingNotificationRepository(db),
+	)
+}
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
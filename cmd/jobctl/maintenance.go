@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"job-portal-backend/repository"
+)
+
+func newReindexCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reindex",
+		Short: "Create the indexes of every collection",
+		Long: "Create the indexes of every collection, as the API does on startup.\n" +
+			"Run it after restoring a backup or when an index was dropped. Failures are logged.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withDatabase(func(ctx context.Context, db *mongo.Database) error {
+				repository.EnsureAllIndexes(db)
+				fmt.Println("Indexes are up to date")
+				return nil
+			})
+		},
+	}
+}
+
+func newPurgeCommand() *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Delete data past its retention period",
+		Long: "Delete authentication events, security alerts, job tombstones and expired\n" +
+			"tokens past their retention period, without waiting for MongoDB's TTL monitor.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withDatabase(func(ctx context.Context, db *mongo.Database) error {
+				results, err := newMaintenanceUsecase(db).PurgeExpired(ctx, dryRun)
+
+				verb := "deleted"
+				if dryRun {
+					verb = "expired"
+				}
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintf(w, "COLLECTION\tCUTOFF\t%s\n", verb)
+				for _, r := range results {
+					fmt.Fprintf(w, "%s\t%s\t%d\n", r.Collection, r.Cutoff.Format("2006-01-02 15:04"), r.Expired)
+				}
+				w.Flush()
+
+				return err
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only count the expired documents")
+	return cmd
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func newNotificationsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notifications",
+		Short: "Manage notification delivery",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "requeue",
+		Short: "Retry the notifications that failed to send",
+		Long: "Put the notifications that couldn't be emailed back in line. The API's\n" +
+			"hourly digest run sends them, batched per user.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withDatabase(func(ctx context.Context, db *mongo.Database) error {
+				count, err := newMaintenanceUsecase(db).RequeueFailedNotifications(ctx)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Requeued %d notifications\n", count)
+				return nil
+			})
+		},
+	})
+
+	return cmd
+}
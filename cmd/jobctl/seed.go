@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"job-portal-backend/config"
+	"job-portal-backend/domain"
+	"job-portal-backend/repository"
+)
+
+// seedOptions sizes the data set created by the seed command
+type seedOptions struct {
+	Companies          int
+	JobsPerCompany     int
+	Applicants         int
+	ApplicationsPerJob int
+	Password           string
+	Seed               int64
+}
+
+var (
+	seedTitles     = []string{"Backend Engineer", "Frontend Developer", "Data Analyst", "Product Manager", "UX Designer", "Sales Executive", "Support Specialist", "DevOps Engineer", "Accountant", "Marketing Lead"}
+	seedLocations  = []string{"Addis Ababa", "Nairobi", "Lagos", "Kigali", "Accra", "Cairo", "Remote"}
+	seedCategories = []string{domain.CategoryEngineering, domain.CategoryDesign, domain.CategoryProduct, domain.CategoryMarketing, domain.CategorySales, domain.CategoryFinance, domain.CategoryCustomerSupport}
+	seedTypes      = []domain.EmploymentType{domain.FullTime, domain.PartTime, domain.Contract, domain.Internship}
+	seedSkills     = []string{"go", "mongodb", "javascript", "react", "python", "sql", "docker", "kubernetes", "figma", "excel", "communication", "negotiation"}
+	seedLevels     = []domain.ExperienceLevel{domain.EntryLevel, domain.JuniorLevel, domain.MidLevel, domain.SeniorLevel, domain.LeadLevel}
+)
+
+func newSeedCommand() *cobra.Command {
+	opts := seedOptions{}
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Fill an empty database with load test data",
+		Long: "Create companies, published jobs, applicants with a resume and applications\n" +
+			"for the load test scripts in loadtest/. The same flags always produce the same\n" +
+			"data set. Accounts are named loadtest-company-N@example.test and\n" +
+			"loadtest-applicant-N@example.test (N from 1) and share --password.\n" +
+			"Refuses to run in production or against an already seeded database.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Companies < 1 || opts.JobsPerCompany < 1 || opts.Applicants < 1 || opts.ApplicationsPerJob < 0 {
+				return errors.New("--companies, --jobs-per-company and --applicants must be positive")
+			}
+			if opts.ApplicationsPerJob > opts.Applicants {
+				return errors.New("--applications-per-job can't exceed --applicants, applicants apply once per job")
+			}
+
+			return withDatabase(func(ctx context.Context, db *mongo.Database) error {
+				if config.Env.IsProduction() {
+					return errors.New("refusing to seed a production database")
+				}
+				return seed(ctx, db, opts)
+			})
+		},
+	}
+	cmd.Flags().IntVar(&opts.Companies, "companies", 50, "company accounts to create")
+	cmd.Flags().IntVar(&opts.JobsPerCompany, "jobs-per-company", 40, "published jobs per company")
+	cmd.Flags().IntVar(&opts.Applicants, "applicants", 500, "applicant accounts to create")
+	cmd.Flags().IntVar(&opts.ApplicationsPerJob, "applications-per-job", 25, "applications per job")
+	cmd.Flags().StringVar(&opts.Password, "password", "LoadTest#2024", "password of every seeded account")
+	cmd.Flags().Int64Var(&opts.Seed, "seed", 1, "random seed, change it for a different data set")
+	return cmd
+}
+
+func seed(ctx context.Context, db *mongo.Database, opts seedOptions) error {
+	userRepo := repository.NewUserRepository(db)
+	jobRepo := repository.NewJobRepository(db)
+	appRepo := repository.NewApplicationRepository(db)
+	resumeRepo := repository.NewResumeRepository(db)
+	rng := rand.New(rand.NewSource(opts.Seed))
+
+	if _, err := userRepo.FindByEmail(ctx, seedEmail("company", 1)); err == nil {
+		return errors.New("the database is already seeded, drop it to seed again")
+	} else if !errors.Is(err, domain.ErrUserNotFound) {
+		return err
+	}
+
+	// Seeded jobs use the default categories
+	if err := repository.NewCategoryRepository(db).CreateIfEmpty(ctx, domain.DefaultCategories()); err != nil {
+		return fmt.Errorf("error creating categories: %v", err)
+	}
+
+	now := time.Now()
+
+	companyIDs := make([]string, opts.Companies)
+	for i := range companyIDs {
+		user, err := seedUser(ctx, userRepo, domain.Company, "company", i+1, opts.Password, now)
+		if err != nil {
+			return err
+		}
+		companyIDs[i] = user.ID.Hex()
+	}
+
+	applicants := make([]*domain.User, opts.Applicants)
+	resumeLinks := make([]string, opts.Applicants)
+	for i := range applicants {
+		user, err := seedUser(ctx, userRepo, domain.Applicant, "applicant", i+1, opts.Password, now)
+		if err != nil {
+			return err
+		}
+		resume := &domain.Resume{
+			UserID:      user.ID.Hex(),
+			Name:        "resume.pdf",
+			URL:         fmt.Sprintf("https://example.test/resumes/%s.pdf", user.ID.Hex()),
+			ContentType: "application/pdf",
+			Size:        64 << 10,
+		}
+		if err := resumeRepo.Create(ctx, resume); err != nil {
+			return fmt.Errorf("error creating resume: %v", err)
+		}
+		applicants[i] = user
+		resumeLinks[i] = resume.URL
+	}
+	fmt.Printf("Created %d companies and %d applicants\n", len(companyIDs), len(applicants))
+
+	jobs, applications := 0, 0
+	for _, companyID := range companyIDs {
+		for j := 0; j < opts.JobsPerCompany; j++ {
+			title := seedTitles[rng.Intn(len(seedTitles))]
+			min := float64(500 + rng.Intn(40)*100)
+			job := &domain.Job{
+				Title:           title,
+				Description:     fmt.Sprintf("We are hiring a %s to join our growing team. Load test job %d.", title, jobs+1),
+				Location:        seedLocations[rng.Intn(len(seedLocations))],
+				EmploymentType:  seedTypes[rng.Intn(len(seedTypes))],
+				Category:        seedCategories[rng.Intn(len(seedCategories))],
+				ExperienceLevel: seedLevels[rng.Intn(len(seedLevels))],
+				Remote:          rng.Intn(4) == 0,
+				Skills:          seedPickSkills(rng),
+				Salary:          &domain.SalaryRange{Min: min, Max: min * 1.5, Currency: "USD", Period: domain.SalaryPerMonth},
+				IsPublished:     true,
+				CreatedBy:       companyID,
+				// Seeded jobs must not notify anyone
+				FollowersNotified: true,
+			}
+			// Spread bumps over the last 30 days so listings have something to rank
+			bumped := now.Add(-time.Duration(rng.Int63n(int64(30 * 24 * time.Hour))))
+			job.HiringConfirmedAt = &bumped
+			job.BumpedAt = &bumped
+			if err := jobRepo.CreateJob(ctx, job); err != nil {
+				return fmt.Errorf("error creating job: %v", err)
+			}
+			jobs++
+
+			// Consecutive applicants from a random offset, so nobody applies twice to a job
+			offset := rng.Intn(len(applicants))
+			for k := 0; k < opts.ApplicationsPerJob; k++ {
+				a := (offset + k) % len(applicants)
+				application := &domain.Application{
+					ApplicantID: applicants[a].ID.Hex(),
+					JobID:       job.ID,
+					ResumeLink:  resumeLinks[a],
+				}
+				if err := appRepo.CreateApplication(ctx, application); err != nil {
+					return fmt.Errorf("error creating application: %v", err)
+				}
+				applications++
+			}
+		}
+		fmt.Printf("\rCreated %d jobs and %d applications", jobs, applications)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+func seedUser(ctx context.Context, userRepo repository.UserRepository, role domain.Role, kind string, n int, password string, now time.Time) (*domain.User, error) {
+	user := &domain.User{
+		Name:      fmt.Sprintf("Loadtest %s %d", kind, n),
+		Email:     seedEmail(kind, n),
+		Password:  password, // Hashed by the repository
+		Role:      role,
+		Status:    domain.UserActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := userRepo.CreateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("error creating %s: %v", user.Email, err)
+	}
+	return user, nil
+}
+
+// seedPickSkills returns one to four distinct skills
+func seedPickSkills(rng *rand.Rand) []string {
+	n := 1 + rng.Intn(4)
+	skills := make([]string, 0, n)
+	for _, i := range rng.Perm(len(seedSkills))[:n] {
+		skills = append(skills, seedSkills[i])
+	}
+	return skills
+}
+
+func seedEmail(kind string, n int) string {
+	return fmt.Sprintf("loadtest-%s-%d@example.test", kind, n)
+}
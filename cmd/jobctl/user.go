@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func newUserCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Look into user accounts",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "inspect <id-or-email>",
+		Short: "Print the data stored about a user as JSON",
+		Long: "Print the account, preferences and, depending on the role, the profile,\n" +
+			"resumes and latest applications or the company profile and latest jobs.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withDatabase(func(ctx context.Context, db *mongo.Database) error {
+				report, err := newMaintenanceUsecase(db).InspectUser(ctx, args[0])
+				if err != nil {
+					return err
+				}
+				return printJSON(report)
+			})
+		},
+	})
+
+	return cmd
+}
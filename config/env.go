@@ -1,9 +1,12 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"os"
-	
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -11,18 +14,141 @@ import (
 // Env holds the application configuration
 var Env *Config
 
+// developmentJWTSecret signs tokens in development when no JWT key is
+// configured. It is public, so any other environment refuses to start with it.
+const developmentJWTSecret = "default_jwt_secret_change_me_in_production"
+
 // Config represents the application configuration
 // @property {string} Port - The port the server will listen on
 // @property {string} JWTSecret - Secret key for JWT token generation and validation
+// @property {string} JWTIssuer - Issuer ("iss") set on and required from JWT tokens
+// @property {string} JWTAudience - Audience ("aud") set on and required from JWT tokens
+// @property {string} JWTKeys - File path or URL of a JWKS document with the HMAC keys tokens may be signed with
+// @property {string} JWTSigningKeyID - kid of the JWTKeys key new tokens are signed with
+// @property {string} JWTPrivateKey - PEM file of an RSA or Ed25519 key to sign tokens with (RS256/EdDSA) instead of HMAC
+// @property {int64} JWTClockSkewSeconds - Leeway applied to the exp, nbf and iat claims of JWT tokens
 // @property {string} MongoDBURI - MongoDB connection string
 // @property {string} DatabaseName - Name of the MongoDB database
 // @property {string} Environment - Application environment (development, production, test)
+// @property {string} FrontendURL - Base URL of the web client, used to build links sent by email
+// @property {string} SMTPHost - SMTP relay host; when empty emails are only logged
+// @property {string} UploadDir - Directory uploaded files are stored in by the local storage provider
+// @property {string} SpoolDir - Directory uploads are spooled to while the storage provider is unavailable
+// @property {string} BackupDir - Directory database backups are written to; keep it out of UploadDir, which is served publicly
+// @property {string} BackupStorage - Where backups are kept: "local" (BackupDir) or "s3", an S3 compatible object store
+// @property {string} BackupS3Endpoint - Base URL of the object store backups are kept in, e.g. https://s3.eu-west-1.amazonaws.com
+// @property {string} BackupS3Region - Region requests to the backup object store are signed for
+// @property {string} BackupS3Bucket - Bucket backups are kept in
+// @property {string} BackupS3AccessKeyID - Access key of the backup object store
+// @property {string} BackupS3SecretAccessKey - Secret of the backup object store access key
+// @property {string} PDFToPPMPath - Poppler's pdftoppm, used to render resume thumbnails (they are disabled when it isn't installed)
+// @property {string} StorageDriver - File storage provider: "local" or "gridfs"
+// @property {int64} StorageQuotaBytes - Maximum bytes stored in GridFS (0 disables the quota)
+// @property {string} APIBaseURL - Public base URL of this API, used for OAuth redirects
+// @property {string} GoogleClientID - OAuth client ID for Google login (disabled when empty)
+// @property {string} LinkedInClientID - OAuth client ID for LinkedIn login (disabled when empty)
+// @property {string} StatusTransitions - JSON object overriding the application status transition graph
+// @property {int64} CompressionLevel - gzip/deflate level for API responses (-1 default, 1-9, 0 disables compression)
+// @property {int64} CompressionMinBytes - Responses smaller than this are sent uncompressed
+// @property {string} ChaosRules - JSON array of fault injection rules (latency, errors) applied outside production
+// @property {string} SecurityAlertEmail - Address security alerts are emailed to (defaults to AdminEmail)
+// @property {string} SupportEmail - Address new support tickets and user replies are emailed to (defaults to AdminEmail)
+// @property {string} SecurityAlertWebhookURL - URL security alerts are posted to as JSON (disabled when empty)
+// @property {[]string} TrustedProxies - IPs or CIDRs of the reverse proxies whose X-Forwarded-For header gives the client IP (none when empty)
+// @property {string} GeoIPDatabase - Path of a MaxMind country database (.mmdb); geo-IP rules are disabled when empty
+// @property {[]string} GeoIPBlockedCountries - ISO country codes signups and job postings are refused from
+// @property {[]string} GeoIPFlaggedCountries - ISO country codes whose signups and job postings are flagged for review
+// @property {string} ExchangeRatesURL - JSON exchange rate feed salaries are converted into the requester's currency with (disabled when empty)
+// @property {string} GeocoderURL - Base URL of a Nominatim server job locations are geocoded with for distance search (disabled when empty)
+// @property {string} HolidaysURL - Base URL of a Nager.Date server interview slots are checked for public holidays with (disabled when empty)
+// @property {[]string} HolidayCountries - ISO country codes whose public holidays are avoided by companies without their own scheduling rules
+// @property {bool} InviteOnly - Requires an admin-issued invite code to sign up, e.g. during a soft launch
+// @property {bool} JobModeration - Holds published jobs for review until an admin approves them
+// @property {int64} MaxSessions - Maximum number of concurrent sessions per user (unlimited when 0)
+// @property {string} SessionLimitMode - What a sign in over MaxSessions does: "revoke_oldest" signs out the oldest session, "reject" refuses the sign in
+// @property {bool} ShareInterviewFeedback - Lets companies see the anonymized interview feedback they received
+// @property {[]string} EventWebhookURLs - Comma separated URLs user lifecycle events are delivered to (events are only recorded when empty)
+// @property {string} AdminEmail - Email of the admin account created at startup (no account is seeded when empty)
 type Config struct {
 	Port         string `json:"port"`
-	JWTSecret    string `json:"jwt_secret"`
+	JWTSecret    string `json:"-"`
+	JWTIssuer    string `json:"jwt_issuer"`
+	JWTAudience  string `json:"jwt_audience"`
 	MongoDBURI   string `json:"mongo_uri"`
 	DatabaseName string `json:"database_name"`
 	Environment  string `json:"environment"`
+	FrontendURL  string `json:"frontend_url"`
+	SMTPHost     string `json:"smtp_host"`
+	SMTPPort     string `json:"smtp_port"`
+	SMTPUsername string `json:"smtp_username"`
+	SMTPPassword string `json:"-"`
+	EmailFrom    string `json:"email_from"`
+	UploadDir    string `json:"upload_dir"`
+	SpoolDir     string `json:"spool_dir"`
+	BackupDir    string `json:"backup_dir"`
+	PDFToPPMPath string `json:"pdftoppm_path"`
+
+	BackupStorage           string `json:"backup_storage"`
+	BackupS3Endpoint        string `json:"backup_s3_endpoint"`
+	BackupS3Region          string `json:"backup_s3_region"`
+	BackupS3Bucket          string `json:"backup_s3_bucket"`
+	BackupS3AccessKeyID     string `json:"backup_s3_access_key_id"`
+	BackupS3SecretAccessKey string `json:"-"`
+
+	JWTClockSkewSeconds int64 `json:"jwt_clock_skew_seconds"`
+
+	JWTKeys         string `json:"jwt_keys"`
+	JWTSigningKeyID string `json:"jwt_signing_key_id"`
+	JWTPrivateKey   string `json:"jwt_private_key"`
+
+	StorageDriver     string `json:"storage_driver"`
+	StorageQuotaBytes int64  `json:"storage_quota_bytes"`
+
+	APIBaseURL           string `json:"api_base_url"`
+	GoogleClientID       string `json:"google_client_id"`
+	GoogleClientSecret   string `json:"-"`
+	LinkedInClientID     string `json:"linkedin_client_id"`
+	LinkedInClientSecret string `json:"-"`
+
+	StatusTransitions string `json:"status_transitions"`
+
+	CompressionLevel    int64 `json:"compression_level"`
+	CompressionMinBytes int64 `json:"compression_min_bytes"`
+
+	ChaosRules string `json:"chaos_rules"`
+
+	AdminName     string `json:"admin_name"`
+	AdminEmail    string `json:"admin_email"`
+	AdminPassword string `json:"-"`
+
+	SecurityAlertEmail      string `json:"security_alert_email"`
+	SecurityAlertWebhookURL string `json:"-"`
+
+	SupportEmail string `json:"support_email"`
+
+	TrustedProxies []string `json:"trusted_proxies"`
+
+	GeoIPDatabase         string   `json:"geoip_database"`
+	GeoIPBlockedCountries []string `json:"geoip_blocked_countries"`
+	GeoIPFlaggedCountries []string `json:"geoip_flagged_countries"`
+
+	GeocoderURL string `json:"geocoder_url"`
+
+	ExchangeRatesURL string `json:"exchange_rates_url"`
+
+	HolidaysURL      string   `json:"holidays_url"`
+	HolidayCountries []string `json:"holiday_countries"`
+
+	InviteOnly bool `json:"invite_only"`
+
+	JobModeration bool `json:"job_moderation"`
+
+	MaxSessions      int64  `json:"max_sessions"`
+	SessionLimitMode string `json:"session_limit_mode"`
+
+	ShareInterviewFeedback bool `json:"share_interview_feedback"`
+
+	EventWebhookURLs []string `json:"-"`
 }
 
 // Load loads the configuration from environment variables
@@ -32,10 +158,117 @@ func Load() error {
 
 	Env = &Config{
 		Port:         getEnv("PORT", "8080"),
-		JWTSecret:    getEnv("JWT_SECRET", "default_jwt_secret_change_me_in_production"),
+		JWTSecret:    os.Getenv("JWT_SECRET"),
+		JWTIssuer:    getEnv("JWT_ISSUER", "job-portal-backend"),
+		JWTAudience:  getEnv("JWT_AUDIENCE", "job-portal-api"),
 		MongoDBURI:   getEnv("MONGODB_URI", "mongodb://localhost:27017"),
 		DatabaseName: getEnv("DATABASE_NAME", "job_portal"),
 		Environment:  getEnv("ENV", "development"),
+		FrontendURL:  getEnv("FRONTEND_URL", "http://localhost:3000"),
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		EmailFrom:    getEnv("EMAIL_FROM", "no-reply@jobportal.local"),
+		UploadDir:    getEnv("UPLOAD_DIR", "uploads"),
+		SpoolDir:     getEnv("SPOOL_DIR", "spool"),
+		BackupDir:    getEnv("BACKUP_DIR", "backups"),
+		PDFToPPMPath: getEnv("PDFTOPPM_PATH", "pdftoppm"),
+
+		BackupStorage:           getEnv("BACKUP_STORAGE", "local"),
+		BackupS3Endpoint:        os.Getenv("BACKUP_S3_ENDPOINT"),
+		BackupS3Region:          getEnv("BACKUP_S3_REGION", "us-east-1"),
+		BackupS3Bucket:          os.Getenv("BACKUP_S3_BUCKET"),
+		BackupS3AccessKeyID:     os.Getenv("BACKUP_S3_ACCESS_KEY_ID"),
+		BackupS3SecretAccessKey: os.Getenv("BACKUP_S3_SECRET_ACCESS_KEY"),
+
+		JWTClockSkewSeconds: getEnvInt64("JWT_CLOCK_SKEW_SECONDS", 30),
+
+		JWTKeys:         os.Getenv("JWT_KEYS"),
+		JWTSigningKeyID: os.Getenv("JWT_SIGNING_KEY_ID"),
+		JWTPrivateKey:   os.Getenv("JWT_PRIVATE_KEY"),
+
+		StorageDriver:     getEnv("STORAGE_DRIVER", "local"),
+		StorageQuotaBytes: getEnvInt64("STORAGE_QUOTA_BYTES", 0),
+
+		APIBaseURL:           os.Getenv("API_BASE_URL"),
+		GoogleClientID:       os.Getenv("GOOGLE_CLIENT_ID"),
+		GoogleClientSecret:   os.Getenv("GOOGLE_CLIENT_SECRET"),
+		LinkedInClientID:     os.Getenv("LINKEDIN_CLIENT_ID"),
+		LinkedInClientSecret: os.Getenv("LINKEDIN_CLIENT_SECRET"),
+
+		StatusTransitions: os.Getenv("APPLICATION_STATUS_TRANSITIONS"),
+
+		CompressionLevel:    getEnvInt64("COMPRESSION_LEVEL", -1),
+		CompressionMinBytes: getEnvInt64("COMPRESSION_MIN_BYTES", 1024),
+
+		ChaosRules: os.Getenv("CHAOS_RULES"),
+
+		AdminName:     getEnv("ADMIN_NAME", "Administrator"),
+		AdminEmail:    os.Getenv("ADMIN_EMAIL"),
+		AdminPassword: os.Getenv("ADMIN_PASSWORD"),
+
+		SecurityAlertEmail:      os.Getenv("SECURITY_ALERT_EMAIL"),
+		SecurityAlertWebhookURL: os.Getenv("SECURITY_ALERT_WEBHOOK_URL"),
+
+		SupportEmail: os.Getenv("SUPPORT_EMAIL"),
+
+		TrustedProxies: getEnvValues("TRUSTED_PROXIES"),
+
+		GeoIPDatabase:         os.Getenv("GEOIP_DATABASE"),
+		GeoIPBlockedCountries: getEnvList("GEOIP_BLOCKED_COUNTRIES"),
+		GeoIPFlaggedCountries: getEnvList("GEOIP_FLAGGED_COUNTRIES"),
+
+		GeocoderURL: os.Getenv("GEOCODER_URL"),
+
+		ExchangeRatesURL: os.Getenv("EXCHANGE_RATES_URL"),
+
+		HolidaysURL:      os.Getenv("HOLIDAYS_URL"),
+		HolidayCountries: getEnvList("HOLIDAY_COUNTRIES"),
+
+		InviteOnly: getEnvBool("INVITE_ONLY", false),
+
+		JobModeration: getEnvBool("JOB_MODERATION", false),
+
+		MaxSessions:      getEnvInt64("MAX_SESSIONS", 0),
+		SessionLimitMode: getEnv("SESSION_LIMIT_MODE", "revoke_oldest"),
+
+		ShareInterviewFeedback: getEnvBool("SHARE_INTERVIEW_FEEDBACK", false),
+
+		EventWebhookURLs: getEnvValues("EVENT_WEBHOOK_URLS"),
+	}
+
+	// Without any key configured, development falls back to a well-known
+	// secret. With JWT_KEYS or JWT_PRIVATE_KEY set, JWT_SECRET is only used
+	// for legacy tokens when it is set explicitly.
+	if Env.JWTSecret == "" && Env.JWTKeys == "" && Env.JWTPrivateKey == "" && Env.IsDevelopment() {
+		log.Println("JWT_SECRET is not set, signing tokens with the development secret")
+		Env.JWTSecret = developmentJWTSecret
+	}
+	if Env.JWTSecret == developmentJWTSecret && !Env.IsDevelopment() {
+		return errors.New("JWT_SECRET must not be the development secret outside development")
+	}
+
+	switch Env.BackupStorage {
+	case "local":
+	case "s3":
+		if Env.BackupS3Endpoint == "" || Env.BackupS3Bucket == "" || Env.BackupS3AccessKeyID == "" || Env.BackupS3SecretAccessKey == "" {
+			return errors.New("BACKUP_STORAGE=s3 needs BACKUP_S3_ENDPOINT, BACKUP_S3_BUCKET, BACKUP_S3_ACCESS_KEY_ID and BACKUP_S3_SECRET_ACCESS_KEY")
+		}
+	default:
+		return fmt.Errorf("BACKUP_STORAGE must be local or s3, not %q", Env.BackupStorage)
+	}
+
+	if Env.SecurityAlertEmail == "" {
+		Env.SecurityAlertEmail = Env.AdminEmail
+	}
+
+	if Env.SupportEmail == "" {
+		Env.SupportEmail = Env.AdminEmail
+	}
+
+	if Env.APIBaseURL == "" {
+		Env.APIBaseURL = "http://localhost:" + Env.Port
 	}
 
 	return nil
@@ -54,6 +287,60 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// getEnvInt64 returns the integer value of the environment variable named by the key,
+// or the fallback when the variable is not set or is not a valid integer.
+func getEnvInt64(key string, fallback int64) int64 {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		log.Printf("Invalid value for %s, using default %d: %v", key, fallback, err)
+		return fallback
+	}
+	return parsed
+}
+
+// getEnvBool returns the boolean value of the environment variable named by the key,
+// or the fallback when the variable is not set or is not a valid boolean.
+func getEnvBool(key string, fallback bool) bool {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid value for %s, using default %t: %v", key, fallback, err)
+		return fallback
+	}
+	return parsed
+}
+
+// getEnvList returns the comma separated values of the environment variable
+// named by the key, trimmed and upper-cased, or nil when it is not set
+func getEnvList(key string) []string {
+	values := getEnvValues(key)
+	for i, value := range values {
+		values[i] = strings.ToUpper(value)
+	}
+	return values
+}
+
+// getEnvValues returns the comma separated values of the environment variable
+// named by the key, trimmed, or nil when it is not set
+func getEnvValues(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 // GetEnv returns the current configuration
 // This is a convenience function to avoid modifying the global Env variable directly
 func GetEnv() *Config {
@@ -78,4 +365,4 @@ func (c *Config) IsDevelopment() bool {
 // IsTest returns true if the environment is set to test
 func (c *Config) IsTest() bool {
 	return c.Environment == "test"
-}
\ No newline at end of file
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// AlertPreferences holds an applicant's job alert and recommendation settings.
+// Jobs posted by an excluded company, or whose title or description contains an
+// excluded keyword, are never matched.
+type AlertPreferences struct {
+	UserID            string    `bson:"user_id" json:"user_id"`
+	ExcludedCompanies []string  `bson:"excluded_companies" json:"excluded_companies"`
+	ExcludedKeywords  []string  `bson:"excluded_keywords" json:"excluded_keywords"`
+	UpdatedAt         time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Exclusions returns the job filter described by the preferences
+func (p *AlertPreferences) Exclusions() JobExclusions {
+	if p == nil {
+		return JobExclusions{}
+	}
+	return JobExclusions{Companies: p.ExcludedCompanies, Keywords: p.ExcludedKeywords}
+}
+
+// JobExclusions filters jobs out of matching queries (alerts, recommendations)
+type JobExclusions struct {
+	// Companies are the IDs of companies whose jobs are excluded
+	Companies []string
+	// Keywords are matched case-insensitively against the job title and description
+	Keywords []string
+}
+
+// UpdateAlertPreferencesRequest replaces the exclusion lists. Up to 100
+// companies and 50 keywords can be excluded.
+type UpdateAlertPreferencesRequest struct {
+	ExcludedCompanies []string `json:"excluded_companies" validate:"max=100,dive,required"`
+	ExcludedKeywords  []string `json:"excluded_keywords" validate:"max=50,dive,required,max=100"`
+}
+
+type AlertPreferencesResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrAnnouncementNotFound = errors.New("announcement not found")
+
+// AnnouncementKind tells clients how to style an announcement's banner
+type AnnouncementKind string
+
+const (
+	AnnouncementMaintenance AnnouncementKind = "maintenance"
+	AnnouncementFeature     AnnouncementKind = "feature"
+	AnnouncementInfo        AnnouncementKind = "info"
+)
+
+// Announcement is a portal-wide message, e.g. a maintenance window or a new
+// feature, shown as a banner between StartsAt and EndsAt to the users it
+// targets until they dismiss it
+type Announcement struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Kind    AnnouncementKind   `bson:"kind" json:"kind"`
+	Title   string             `bson:"title" json:"title"`
+	Message string             `bson:"message" json:"message"`
+	// Link points to details, e.g. a release note
+	Link string `bson:"link,omitempty" json:"link,omitempty"`
+	// Roles the announcement is shown to, empty for everyone including
+	// visitors who aren't signed in
+	Roles    []Role     `bson:"roles,omitempty" json:"roles,omitempty"`
+	StartsAt time.Time  `bson:"starts_at" json:"starts_at"`
+	EndsAt   *time.Time `bson:"ends_at,omitempty" json:"ends_at,omitempty"`
+	// CreatedBy is the ID of the admin who created the announcement
+	CreatedBy string    `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Targets reports whether the announcement is shown to users with the role,
+// an empty role being a visitor
+func (a *Announcement) Targets(role Role) bool {
+	if len(a.Roles) == 0 {
+		return true
+	}
+	for _, r := range a.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// AnnouncementDismissal records that a user closed an announcement's banner
+type AnnouncementDismissal struct {
+	AnnouncementID string    `bson:"announcement_id" json:"announcement_id"`
+	UserID         string    `bson:"user_id" json:"user_id"`
+	DismissedAt    time.Time `bson:"dismissed_at" json:"dismissed_at"`
+}
+
+type CreateAnnouncementRequest struct {
+	Kind    AnnouncementKind `json:"kind" validate:"required,oneof=maintenance feature info"`
+	Title   string           `json:"title" validate:"required,min=2,max=100"`
+	Message string           `json:"message" validate:"required,max=1000"`
+	Link    string           `json:"link,omitempty" validate:"omitempty,url,max=500"`
+	Roles   []Role           `json:"roles,omitempty" validate:"omitempty,dive,oneof=applicant company auditor admin"`
+	// StartsAt defaults to now
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+}
+
+type UpdateAnnouncementRequest struct {
+	Kind     *AnnouncementKind `json:"kind,omitempty" validate:"omitempty,oneof=maintenance feature info"`
+	Title    *string           `json:"title,omitempty" validate:"omitempty,min=2,max=100"`
+	Message  *string           `json:"message,omitempty" validate:"omitempty,max=1000"`
+	Link     *string           `json:"link,omitempty" validate:"omitempty,max=500"`
+	Roles    *[]Role           `json:"roles,omitempty" validate:"omitempty,dive,oneof=applicant company auditor admin"`
+	StartsAt *time.Time        `json:"starts_at,omitempty"`
+	EndsAt   *time.Time        `json:"ends_at,omitempty"`
+}
+
+type AnnouncementResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+	// URL is the canonical location of a newly created resource
+	URL string `json:"url,omitempty"`
+}
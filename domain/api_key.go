@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+)
+
+// APIKeyScopes lists every scope an API key can be granted
+var APIKeyScopes = []string{ScopeJobsRead, ScopeJobsWrite, ScopeApplicationsRead, ScopeApplicationsWrite}
+
+// DefaultAPIKeyRateLimit is the number of requests per minute allowed when none is requested
+const DefaultAPIKeyRateLimit = 60
+
+// APIKey lets a company call the API from its own systems. Only the SHA-256
+// hash of the key is stored; Prefix identifies the key in listings.
+type APIKey struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	CompanyID  string             `bson:"company_id" json:"company_id"`
+	Name       string             `bson:"name" json:"name"`
+	Prefix     string             `bson:"prefix" json:"prefix"`
+	KeyHash    string             `bson:"key_hash" json:"-"`
+	Scopes     []string           `bson:"scopes" json:"scopes"`
+	RateLimit  int                `bson:"rate_limit" json:"rate_limit"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	LastUsedAt *time.Time         `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time         `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" validate:"required,min=1,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=jobs:read jobs:write applications:read applications:write"`
+	// RateLimit is the number of requests per minute, DefaultAPIKeyRateLimit when omitted
+	RateLimit int `json:"rate_limit,omitempty" validate:"omitempty,min=1,max=1000"`
+}
+
+// CreatedAPIKey is returned once when a key is generated; the plain key can't be retrieved later
+type CreatedAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
+
+type APIKeyResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+	// URL is the canonical location of a newly created resource
+	URL string `json:"url,omitempty"`
+}
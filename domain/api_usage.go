@@ -0,0 +1,87 @@
+package domain
+
+import "time"
+
+// APIUsageRetention is how long hourly API usage counts are kept
+const APIUsageRetention = 90 * 24 * time.Hour
+
+// DefaultAPIUsageDays is the period of the usage dashboard when none is requested
+const DefaultAPIUsageDays = 7
+
+// APIUsage counts the requests made with an API key during an hour
+type APIUsage struct {
+	CompanyID string    `bson:"company_id" json:"-"`
+	KeyID     string    `bson:"key_id" json:"-"`
+	Hour      time.Time `bson:"hour" json:"hour"`
+	Requests  int64     `bson:"requests" json:"requests"`
+	// Errors counts the requests answered with a 4xx or 5xx status, rate
+	// limited ones aside
+	Errors int64 `bson:"errors" json:"errors"`
+	// RateLimited counts the requests rejected by the key's rate limit
+	RateLimited int64 `bson:"rate_limited" json:"rate_limited"`
+	// PeakWindowRequests is the most requests counted in one rate limit window
+	PeakWindowRequests int `bson:"peak_window_requests" json:"peak_window_requests"`
+}
+
+// Add counts a request answered with status, windowRequests being the
+// requests counted in the key's rate limit window so far
+func (u *APIUsage) Add(status, windowRequests int) {
+	u.Requests++
+	switch {
+	case status == 429:
+		u.RateLimited++
+	case status >= 400:
+		u.Errors++
+	}
+	if windowRequests > u.PeakWindowRequests {
+		u.PeakWindowRequests = windowRequests
+	}
+}
+
+// APIUsageTotals sums the usage of a period
+type APIUsageTotals struct {
+	Requests    int64 `json:"requests"`
+	Errors      int64 `json:"errors"`
+	RateLimited int64 `json:"rate_limited"`
+}
+
+// APIKeyUsage is the usage of one of the company's API keys
+type APIKeyUsage struct {
+	KeyID     string     `json:"key_id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	RateLimit int        `json:"rate_limit"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	APIUsageTotals
+	// PeakRateLimitUsage is the largest share of the rate limit used in one
+	// window over the period, from 0 to 1
+	PeakRateLimitUsage float64 `json:"peak_rate_limit_usage"`
+	// Hours lists the hours the key was used, oldest first
+	Hours []*APIUsage `json:"hours"`
+}
+
+// WebhookDeliveryStats counts the webhook deliveries of the events about the company
+type WebhookDeliveryStats struct {
+	Delivered int64 `json:"delivered"`
+	Failed    int64 `json:"failed"`
+	Pending   int64 `json:"pending"`
+	// SuccessRate is the share of delivered events among those that were
+	// delivered or failed, nil when there are none
+	SuccessRate *float64 `json:"success_rate"`
+}
+
+// APIUsageReport is the company's API and webhook usage dashboard
+type APIUsageReport struct {
+	Since    time.Time            `json:"since"`
+	Until    time.Time            `json:"until"`
+	Totals   APIUsageTotals       `json:"totals"`
+	Keys     []*APIKeyUsage       `json:"keys"`
+	Webhooks WebhookDeliveryStats `json:"webhooks"`
+}
+
+type APIUsageResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
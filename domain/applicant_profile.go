@@ -0,0 +1,71 @@
+package domain
+
+import "time"
+
+// ApplicantProfile is an applicant's structured CV, shown to companies next to
+// their applications. Months are formatted YYYY-MM.
+type ApplicantProfile struct {
+	UserID     string           `bson:"user_id" json:"user_id"`
+	Headline   string           `bson:"headline" json:"headline"`
+	Summary    string           `bson:"summary" json:"summary"`
+	Skills     []string         `bson:"skills" json:"skills"`
+	Experience []WorkExperience `bson:"experience" json:"experience"`
+	Education  []Education      `bson:"education" json:"education"`
+	Links      []ProfileLink    `bson:"links" json:"links"`
+	UpdatedAt  time.Time        `bson:"updated_at" json:"updated_at"`
+}
+
+// Blinded returns a copy of the profile for companies screening blind. Links
+// identify the applicant and education dates hint at their age, both are left out.
+func (p *ApplicantProfile) Blinded() *ApplicantProfile {
+	blinded := *p
+	blinded.Links = []ProfileLink{}
+	blinded.Education = make([]Education, len(p.Education))
+	for i, edu := range p.Education {
+		edu.StartMonth, edu.EndMonth = "", ""
+		blinded.Education[i] = edu
+	}
+	return &blinded
+}
+
+// WorkExperience is a position in the applicant's work history. EndMonth is
+// empty for the current position.
+type WorkExperience struct {
+	Title       string `bson:"title" json:"title" validate:"required,max=100"`
+	Company     string `bson:"company" json:"company" validate:"required,max=100"`
+	StartMonth  string `bson:"start_month" json:"start_month" validate:"required,datetime=2006-01"`
+	EndMonth    string `bson:"end_month,omitempty" json:"end_month,omitempty" validate:"omitempty,datetime=2006-01"`
+	Description string `bson:"description,omitempty" json:"description,omitempty" validate:"max=2000"`
+}
+
+// Education is a degree or course the applicant followed
+type Education struct {
+	School     string `bson:"school" json:"school" validate:"required,max=100"`
+	Degree     string `bson:"degree,omitempty" json:"degree,omitempty" validate:"max=100"`
+	Field      string `bson:"field,omitempty" json:"field,omitempty" validate:"max=100"`
+	StartMonth string `bson:"start_month,omitempty" json:"start_month,omitempty" validate:"omitempty,datetime=2006-01"`
+	EndMonth   string `bson:"end_month,omitempty" json:"end_month,omitempty" validate:"omitempty,datetime=2006-01"`
+}
+
+// ProfileLink points to the applicant's portfolio, GitHub, LinkedIn, ...
+type ProfileLink struct {
+	Label string `bson:"label" json:"label" validate:"required,max=50"`
+	URL   string `bson:"url" json:"url" validate:"required,url,max=500"`
+}
+
+// UpdateApplicantProfileRequest replaces the stored profile
+type UpdateApplicantProfileRequest struct {
+	Headline   string           `json:"headline" validate:"max=150"`
+	Summary    string           `json:"summary" validate:"max=3000"`
+	Skills     []string         `json:"skills" validate:"max=50,dive,required,max=50"`
+	Experience []WorkExperience `json:"experience" validate:"max=30,dive"`
+	Education  []Education      `json:"education" validate:"max=20,dive"`
+	Links      []ProfileLink    `json:"links" validate:"max=10,dive"`
+}
+
+type ApplicantProfileResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
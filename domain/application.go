@@ -1,40 +1,116 @@
 package domain
 
 import (
+	"errors"
 	"mime/multipart"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var ErrApplicationNotFound = errors.New("application not found")
+
 type ApplicationStatus string
 
 const (
-	StatusApplied    ApplicationStatus = "Applied"
-	StatusReviewed   ApplicationStatus = "Reviewed"
-	StatusInterview  ApplicationStatus = "Interview"
-	StatusRejected   ApplicationStatus = "Rejected"
-	StatusHired      ApplicationStatus = "Hired"
+	StatusApplied   ApplicationStatus = "Applied"
+	StatusReviewed  ApplicationStatus = "Reviewed"
+	StatusInterview ApplicationStatus = "Interview"
+	StatusRejected  ApplicationStatus = "Rejected"
+	StatusHired     ApplicationStatus = "Hired"
 )
 
+// ApplicationStatuses lists every status in pipeline order
+var ApplicationStatuses = []ApplicationStatus{
+	StatusApplied,
+	StatusReviewed,
+	StatusInterview,
+	StatusRejected,
+	StatusHired,
+}
+
 type Application struct {
 	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	ApplicantID string             `bson:"applicant_id" json:"applicant_id"`
 	JobID       primitive.ObjectID `bson:"job_id" json:"job_id"`
 	ResumeLink  string             `bson:"resume_link" json:"resume_link"`
-	CoverLetter string             `bson:"cover_letter,omitempty" json:"cover_letter,omitempty"`
-	Status      ApplicationStatus  `bson:"status" json:"status"`
-	AppliedAt   time.Time          `bson:"applied_at" json:"applied_at"`
+	// ResumePending is set while the resume is spooled waiting for the storage provider
+	ResumePending bool `bson:"resume_pending,omitempty" json:"resume_pending,omitempty"`
+	// ResumeThumbnailURL is an image of the resume's first page, generated in
+	// the background for PDF resumes so list views can preview it
+	ResumeThumbnailURL string `bson:"resume_thumbnail_url,omitempty" json:"resume_thumbnail_url,omitempty"`
+	// ThumbnailProcessedAt is set once the thumbnail was generated, or the
+	// resume turned out not to have one
+	ThumbnailProcessedAt *time.Time        `bson:"thumbnail_processed_at,omitempty" json:"-"`
+	CoverLetter          string            `bson:"cover_letter,omitempty" json:"cover_letter,omitempty"`
+	Status               ApplicationStatus `bson:"status" json:"status"`
+	AppliedAt            time.Time         `bson:"applied_at" json:"applied_at"`
+	// Country is resolved from the applicant's IP, for fraud analysis
+	Country string `bson:"country,omitempty" json:"-"`
+	// AnonymizedAt is set when the applicant deleted their account. The applicant
+	// ID, resume and cover letter are erased; the status is kept for company stats.
+	AnonymizedAt *time.Time `bson:"anonymized_at,omitempty" json:"anonymized_at,omitempty"`
+	// InterviewedAt is when the application reached the Interview stage
+	InterviewedAt *time.Time `bson:"interviewed_at,omitempty" json:"interviewed_at,omitempty"`
+	// InterviewScheduledAt is when the company scheduled the interview. Once it
+	// passed the applicant is asked for feedback on the process.
+	InterviewScheduledAt *time.Time `bson:"interview_scheduled_at,omitempty" json:"interview_scheduled_at,omitempty"`
+	FeedbackRequestedAt  *time.Time `bson:"feedback_requested_at,omitempty" json:"-"`
+	// RejectionReason is set when the application is rejected
+	RejectionReason RejectionReason `bson:"rejection_reason,omitempty" json:"rejection_reason,omitempty"`
+	// StatusChangedAt is when the application entered its current status
+	StatusChangedAt *time.Time `bson:"status_changed_at,omitempty" json:"status_changed_at,omitempty"`
+	// SLAWarnedStage is the stage the job owner was last warned about, so each stage is warned about once
+	SLAWarnedStage ApplicationStatus `bson:"sla_warned_stage,omitempty" json:"-"`
+	// StatusHistory lists every status the application entered, starting with Applied
+	StatusHistory []StatusChange `bson:"status_history,omitempty" json:"status_history,omitempty"`
+	// WithdrawnAt is set when the applicant withdrew the application
+	WithdrawnAt *time.Time `bson:"withdrawn_at,omitempty" json:"withdrawn_at,omitempty"`
+	// EventVersion is the version of the last event of the application's
+	// stream this document was projected from, see ApplicationEvent
+	EventVersion int `bson:"event_version,omitempty" json:"-"`
+}
+
+// ResumeThumbnailWidth is the width in pixels of resume thumbnails
+const ResumeThumbnailWidth = 320
+
+// StatusSince returns when the application entered its current status.
+// Applications that never changed status count from when they were submitted.
+func (a *Application) StatusSince() time.Time {
+	if a.StatusChangedAt != nil {
+		return *a.StatusChangedAt
+	}
+	return a.AppliedAt
+}
+
+// Blinded returns a copy of the application without the resume, which carries
+// the applicant's name and photo, for companies screening blind
+func (a *Application) Blinded() *Application {
+	blinded := *a
+	blinded.ResumeLink = ""
+	blinded.ResumeThumbnailURL = ""
+	return &blinded
 }
 
 type ApplyRequest struct {
 	JobID       string                `form:"job_id" validate:"required"`
 	CoverLetter string                `form:"cover_letter,omitempty" validate:"max=2000"`
-	ResumeFile  *multipart.FileHeader `form:"resume" validate:"required"`
+	ResumeFile  *multipart.FileHeader `form:"resume" validate:"required_without=ResumeID,excluded_with=ResumeID"`
+	// ResumeID picks a resume from the applicant's library instead of uploading one
+	ResumeID string `form:"resume_id,omitempty" validate:"omitempty,len=24,hexadecimal"`
 }
 
 type UpdateApplicationStatusRequest struct {
 	Status ApplicationStatus `json:"status" validate:"required,oneof=Applied Reviewed Interview Rejected Hired"`
+	// RejectionReason is only recorded with the Rejected status
+	RejectionReason RejectionReason `json:"rejection_reason,omitempty" validate:"omitempty,oneof=qualifications experience position_filled candidate_withdrew other"`
+}
+
+// AllowedTransitions lists the statuses an application can move to from its current status
+type AllowedTransitions struct {
+	ApplicationID      string              `json:"application_id"`
+	CurrentStatus      ApplicationStatus   `json:"current_status"`
+	AllowedTransitions []ApplicationStatus `json:"allowed_transitions"`
 }
 
 type ApplicationResponse struct {
@@ -42,6 +118,10 @@ type ApplicationResponse struct {
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
 	Errors  []string    `json:"errors,omitempty"`
+	// URL is the canonical location of a newly created resource
+	URL string `json:"url,omitempty"`
+	// Warnings flag a scheduled interview falling outside the company's working time
+	Warnings []SchedulingWarning `json:"warnings,omitempty"`
 }
 
 type ApplicationListResponse struct {
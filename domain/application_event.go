@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrApplicationVersionConflict is returned when an event is appended to an
+// application stream that got another event since it was read
+var ErrApplicationVersionConflict = errors.New("application was changed concurrently")
+
+// ApplicationEventType is what happened to an application
+type ApplicationEventType string
+
+const (
+	ApplicationApplied       ApplicationEventType = "applied"
+	ApplicationStatusChanged ApplicationEventType = "status_changed"
+	ApplicationNoteAdded     ApplicationEventType = "note_added"
+	// ApplicationWithdrawn is the applicant pulling out, which rejects the
+	// application with RejectionCandidateWithdrew
+	ApplicationWithdrawn ApplicationEventType = "withdrawn"
+)
+
+// ApplicationEvent is an entry of an application's append-only event stream.
+// The application's state is derived from its events, see ReplayApplication.
+// Events carry no documents or contact details, they stay on the application.
+type ApplicationEvent struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ApplicationID primitive.ObjectID `bson:"application_id" json:"application_id"`
+	// Version numbers the events of an application from 1, without gaps
+	Version int                  `bson:"version" json:"version"`
+	Type    ApplicationEventType `bson:"type" json:"type"`
+	// ActorID is the user who caused the event, erased when the applicant deletes their account
+	ActorID string    `bson:"actor_id,omitempty" json:"actor_id,omitempty"`
+	At      time.Time `bson:"at" json:"at"`
+
+	// ApplicantID and JobID are set on Applied events
+	ApplicantID string             `bson:"applicant_id,omitempty" json:"-"`
+	JobID       primitive.ObjectID `bson:"job_id,omitempty" json:"job_id,omitempty"`
+	// Status and RejectionReason are set on StatusChanged and Withdrawn events
+	Status          ApplicationStatus `bson:"status,omitempty" json:"status,omitempty"`
+	RejectionReason RejectionReason   `bson:"rejection_reason,omitempty" json:"rejection_reason,omitempty"`
+	// NoteID is set on NoteAdded events
+	NoteID string `bson:"note_id,omitempty" json:"note_id,omitempty"`
+	// Backfilled is set on the events rebuilt from the status history of
+	// applications submitted before events were recorded
+	Backfilled bool `bson:"backfilled,omitempty" json:"backfilled,omitempty"`
+}
+
+// ApplicationAggregate is the state of an application derived from its events
+type ApplicationAggregate struct {
+	ID              primitive.ObjectID
+	ApplicantID     string
+	JobID           primitive.ObjectID
+	Status          ApplicationStatus
+	RejectionReason RejectionReason
+	AppliedAt       time.Time
+	StatusChangedAt *time.Time
+	InterviewedAt   *time.Time
+	WithdrawnAt     *time.Time
+	StatusHistory   []StatusChange
+	Notes           int
+	// Version is the version of the last event applied
+	Version int
+}
+
+// ReplayApplication derives an application's state from its events, oldest first
+func ReplayApplication(events []*ApplicationEvent) *ApplicationAggregate {
+	aggregate := &ApplicationAggregate{}
+	for _, event := range events {
+		aggregate.Apply(event)
+	}
+	return aggregate
+}
+
+// Apply updates the state with an event
+func (a *ApplicationAggregate) Apply(event *ApplicationEvent) {
+	a.ID = event.ApplicationID
+	a.Version = event.Version
+
+	switch event.Type {
+	case ApplicationApplied:
+		a.ApplicantID = event.ApplicantID
+		a.JobID = event.JobID
+		a.Status = StatusApplied
+		a.AppliedAt = event.At
+		a.StatusHistory = []StatusChange{{Status: StatusApplied, At: event.At}}
+	case ApplicationStatusChanged, ApplicationWithdrawn:
+		at := event.At
+		a.Status = event.Status
+		a.RejectionReason = event.RejectionReason
+		a.StatusChangedAt = &at
+		a.StatusHistory = append(a.StatusHistory, StatusChange{Status: event.Status, At: at})
+		if event.Status == StatusInterview {
+			a.InterviewedAt = &at
+		}
+		if event.Type == ApplicationWithdrawn {
+			a.WithdrawnAt = &at
+		}
+	case ApplicationNoteAdded:
+		a.Notes++
+	}
+}
+
+// Record numbers a new event after the last one applied, applies it and
+// returns it so it can be appended to the stream
+func (a *ApplicationAggregate) Record(event *ApplicationEvent) *ApplicationEvent {
+	event.ApplicationID = a.ID
+	event.Version = a.Version + 1
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+	a.Apply(event)
+	return event
+}
+
+// BackfillApplicationEvents rebuilds the events of an application submitted
+// before events were recorded from its status history
+func BackfillApplicationEvents(app *Application) []*ApplicationEvent {
+	timeline := app.Timeline()
+	events := make([]*ApplicationEvent, 0, len(timeline))
+	for i, change := range timeline {
+		event := &ApplicationEvent{
+			ApplicationID: app.ID,
+			Version:       i + 1,
+			Type:          ApplicationStatusChanged,
+			At:            change.At,
+			Status:        change.Status,
+			Backfilled:    true,
+		}
+		switch {
+		case i == 0:
+			event.Type = ApplicationApplied
+			event.ApplicantID = app.ApplicantID
+			event.ActorID = app.ApplicantID
+			event.JobID = app.JobID
+			event.Status = ""
+		case i == len(timeline)-1 && change.Status == StatusRejected:
+			event.RejectionReason = app.RejectionReason
+			if app.WithdrawnAt != nil {
+				event.Type = ApplicationWithdrawn
+			}
+		}
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaxNoteMentions bounds the teammates a single note can mention
+const MaxNoteMentions = 10
+
+// ApplicationNote is an internal note the hiring team leaves on an
+// application. Notes are never shown to the applicant.
+type ApplicationNote struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ApplicationID string             `bson:"application_id" json:"application_id"`
+	JobID         string             `bson:"job_id" json:"job_id"`
+	CompanyID     string             `bson:"company_id" json:"company_id"`
+	AuthorID      string             `bson:"author_id" json:"author_id"`
+	Body          string             `bson:"body" json:"body"`
+	// Mentions are the IDs of the teammates mentioned in the body
+	Mentions  []string  `bson:"mentions,omitempty" json:"mentions,omitempty"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// mentionPattern matches "@" followed by a teammate's email address, e.g.
+// "@jane@acme.com". The mention must not be glued to a preceding word.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.+-])@([\w.%+-]+@[\w-]+(?:\.[\w-]+)+)`)
+
+// ParseMentions returns the lower-cased email addresses mentioned in a note
+// body, each once, in the order they first appear
+func ParseMentions(body string) []string {
+	var emails []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		email := strings.ToLower(match[1])
+		if !seen[email] {
+			seen[email] = true
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+type CreateApplicationNoteRequest struct {
+	// Body may mention teammates as @email, they are notified of the note
+	Body string `json:"body" validate:"required,min=1,max=2000"`
+}
+
+type ApplicationNoteResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+	// URL is the canonical location of a newly created resource
+	URL string `json:"url,omitempty"`
+}
+
+// ActivityKind identifies what an activity feed entry is about
+type ActivityKind string
+
+const (
+	// ActivityMention tells a team member they were mentioned in an application note
+	ActivityMention ActivityKind = "mention"
+)
+
+// Activity is an entry of a user's activity feed
+type Activity struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID string             `bson:"user_id" json:"-"`
+	Kind   ActivityKind       `bson:"kind" json:"kind"`
+	// ActorID is the user who caused the activity
+	ActorID       string     `bson:"actor_id" json:"actor_id"`
+	ActorName     string     `bson:"actor_name" json:"actor_name"`
+	ApplicationID string     `bson:"application_id,omitempty" json:"application_id,omitempty"`
+	JobID         string     `bson:"job_id,omitempty" json:"job_id,omitempty"`
+	JobTitle      string     `bson:"job_title,omitempty" json:"job_title,omitempty"`
+	NoteID        string     `bson:"note_id,omitempty" json:"note_id,omitempty"`
+	Excerpt       string     `bson:"excerpt,omitempty" json:"excerpt,omitempty"`
+	ReadAt        *time.Time `bson:"read_at,omitempty" json:"read_at,omitempty"`
+	CreatedAt     time.Time  `bson:"created_at" json:"created_at"`
+}
+
+// activityExcerptLength is how much of a note is copied into activity entries
+const activityExcerptLength = 140
+
+// NoteExcerpt shortens a note body for activity entries and notifications
+func NoteExcerpt(body string) string {
+	runes := []rune(strings.TrimSpace(body))
+	if len(runes) <= activityExcerptLength {
+		return string(runes)
+	}
+	return string(runes[:activityExcerptLength]) + "…"
+}
+
+type ActivityListResponse struct {
+	Success    bool        `json:"success"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data,omitempty"`
+	Unread     int64       `json:"unread"`
+	PageNumber int         `json:"page_number"`
+	PageSize   int         `json:"page_size"`
+	TotalItems int64       `json:"total_items"`
+	TotalPages int         `json:"total_pages"`
+	Errors     []string    `json:"errors,omitempty"`
+}
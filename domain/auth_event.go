@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AuthEventType string
+
+const (
+	AuthEventSignup         AuthEventType = "signup"
+	AuthEventLogin          AuthEventType = "login"
+	AuthEventLoginFailed    AuthEventType = "login_failed"
+	AuthEventPasswordChange AuthEventType = "password_change"
+	AuthEventTokenRefresh   AuthEventType = "token_refresh"
+	AuthEventEmailChange    AuthEventType = "email_change"
+)
+
+// AuthEventRetention is how long authentication events are kept
+const AuthEventRetention = 180 * 24 * time.Hour
+
+// AuthEvent records an authentication related action on an account. Failed
+// logins for unknown emails have no UserID and keep the attempted Email instead.
+type AuthEvent struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID string             `bson:"user_id,omitempty" json:"-"`
+	Email  string             `bson:"email,omitempty" json:"-"`
+	Type   AuthEventType      `bson:"type" json:"type"`
+	// Method is how the user authenticated: password, 2fa or oauth:<provider>
+	Method    string    `bson:"method,omitempty" json:"method,omitempty"`
+	Reason    string    `bson:"reason,omitempty" json:"reason,omitempty"`
+	IP        string    `bson:"ip" json:"ip"`
+	Country   string    `bson:"country,omitempty" json:"country,omitempty"`
+	UserAgent string    `bson:"user_agent" json:"user_agent"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// ClientInfo identifies the client that made a request
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+	// Country is resolved from the IP when a geo-IP database is configured
+	Country string
+	// GeoFlagged is set when the country is flagged for the requested action
+	GeoFlagged bool
+}
+
+type clientInfoKey struct{}
+
+// WithClientInfo returns a context carrying the client info of the request
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, info)
+}
+
+// ClientInfoFromContext returns the client info stored by WithClientInfo, if any
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return info
+}
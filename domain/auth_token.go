@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid or expired token")
+)
+
+type TokenPurpose string
+
+const (
+	PurposePasswordReset TokenPurpose = "password_reset"
+	PurposeMagicLink     TokenPurpose = "magic_link"
+	PurposeEmailChange   TokenPurpose = "email_change"
+)
+
+// AuthToken is a single-use token sent to a user out of band (e.g. by email).
+// Only the SHA-256 hash of the token is stored.
+type AuthToken struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    string             `bson:"user_id" json:"user_id"`
+	TokenHash string             `bson:"token_hash" json:"-"`
+	Purpose   TokenPurpose       `bson:"purpose" json:"purpose"`
+	ExpiresAt time.Time          `bson:"expires_at" json:"expires_at"`
+	UsedAt    *time.Time         `bson:"used_at,omitempty" json:"used_at,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	// NewEmail is the address an email change token confirms
+	NewEmail string `bson:"new_email,omitempty" json:"-"`
+}
+
+// RevokedToken blacklists either a single token (by JTI) or, when RevokedBefore
+// is set, every token issued to the user before that instant.
+type RevokedToken struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	JTI           string             `bson:"jti,omitempty" json:"jti,omitempty"`
+	UserID        string             `bson:"user_id" json:"user_id"`
+	RevokedBefore *time.Time         `bson:"revoked_before,omitempty" json:"revoked_before,omitempty"`
+	ExpiresAt     time.Time          `bson:"expires_at" json:"expires_at"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type MagicLinkRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=8,containsany=!@#$%^&*,containsany=0123456789,containsany=ABCDEFGHIJKLMNOPQRSTUVWXYZ,containsany=abcdefghijklmnopqrstuvwxyz"`
+}
+
+// EmailChangeRequest starts an email change. Accounts with a password must
+// confirm it.
+type EmailChangeRequest struct {
+	NewEmail string `json:"new_email" validate:"required,email"`
+	Password string `json:"password,omitempty"`
+}
+
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrBackupNotFound = errors.New("backup not found")
+	// ErrBackupInProgress is returned when starting a backup while another one is running
+	ErrBackupInProgress = errors.New("backup already in progress")
+	// ErrBackupNotVerifiable is returned when verifying a backup that didn't
+	// complete or is already being verified
+	ErrBackupNotVerifiable = errors.New("backup can't be verified")
+)
+
+type BackupStatus string
+
+const (
+	BackupRunning   BackupStatus = "running"
+	BackupCompleted BackupStatus = "completed"
+	BackupFailed    BackupStatus = "failed"
+)
+
+type BackupVerificationStatus string
+
+const (
+	VerificationRunning BackupVerificationStatus = "running"
+	VerificationPassed  BackupVerificationStatus = "passed"
+	VerificationFailed  BackupVerificationStatus = "failed"
+)
+
+// BackupStaleAfter is how long a backup or verification may go without
+// progress before it is considered interrupted, e.g. by an API restart
+const BackupStaleAfter = 30 * time.Minute
+
+// BackupCollection summarizes one collection of a backup archive
+type BackupCollection struct {
+	Name      string `bson:"name" json:"name"`
+	Documents int64  `bson:"documents" json:"documents"`
+	SHA256    string `bson:"sha256" json:"sha256"`
+}
+
+// BackupVerification is the outcome of reading a backup archive back and
+// checking it against the collections recorded when it was taken
+type BackupVerification struct {
+	Status      BackupVerificationStatus `bson:"status" json:"status"`
+	RequestedBy string                   `bson:"requested_by" json:"requested_by"`
+	StartedAt   time.Time                `bson:"started_at" json:"started_at"`
+	CompletedAt *time.Time               `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+	// Mismatches lists the collections whose documents differ from the backup record
+	Mismatches []string `bson:"mismatches,omitempty" json:"mismatches,omitempty"`
+	Error      string   `bson:"error,omitempty" json:"error,omitempty"`
+}
+
+// Backup is a logical backup of every collection, taken by an admin. The
+// record doubles as the audit trail of who took and verified backups.
+type Backup struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Status      BackupStatus       `bson:"status" json:"status"`
+	RequestedBy string             `bson:"requested_by" json:"requested_by"`
+	// Key is the archive's name in the backup storage
+	Key         string              `bson:"key" json:"key"`
+	SizeBytes   int64               `bson:"size_bytes" json:"size_bytes"`
+	Collections []*BackupCollection `bson:"collections,omitempty" json:"collections,omitempty"`
+	// Consistent is set when the collections were read from a single snapshot
+	Consistent   bool                `bson:"consistent" json:"consistent"`
+	Error        string              `bson:"error,omitempty" json:"error,omitempty"`
+	Verification *BackupVerification `bson:"verification,omitempty" json:"verification,omitempty"`
+	CreatedAt    time.Time           `bson:"created_at" json:"created_at"`
+	HeartbeatAt  time.Time           `bson:"heartbeat_at" json:"-"`
+	CompletedAt  *time.Time          `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+}
+
+// Verifying reports whether a verification of the backup is in progress
+func (b *Backup) Verifying(now time.Time) bool {
+	v := b.Verification
+	return v != nil && v.Status == VerificationRunning && now.Sub(v.StartedAt) < BackupStaleAfter
+}
+
+type BackupResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
+
+type BackupListResponse struct {
+	Success    bool        `json:"success"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data,omitempty"`
+	PageNumber int         `json:"page_number"`
+	PageSize   int         `json:"page_size"`
+	TotalItems int64       `json:"total_items"`
+	TotalPages int         `json:"total_pages"`
+	Errors     []string    `json:"errors,omitempty"`
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryExists   = errors.New("category already exists")
+)
+
+// Category is an entry of the admin-managed job category taxonomy. Jobs
+// reference categories by Slug, which can't change once created.
+type Category struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Slug        string             `bson:"slug" json:"slug"`
+	Name        string             `bson:"name" json:"name"`
+	Description string             `bson:"description,omitempty" json:"description,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	// Jobs is the number of published jobs in the category, set when browsing
+	Jobs int64 `bson:"-" json:"jobs"`
+}
+
+// DefaultCategories are created when the taxonomy is empty, they are the
+// categories jobs could use before the taxonomy was introduced
+func DefaultCategories() []*Category {
+	return []*Category{
+		{Slug: CategoryEngineering, Name: "Engineering"},
+		{Slug: CategoryDesign, Name: "Design"},
+		{Slug: CategoryProduct, Name: "Product"},
+		{Slug: CategoryMarketing, Name: "Marketing"},
+		{Slug: CategorySales, Name: "Sales"},
+		{Slug: CategoryFinance, Name: "Finance"},
+		{Slug: CategoryOperations, Name: "Operations"},
+		{Slug: CategoryCustomerSupport, Name: "Customer support"},
+		{Slug: CategoryOther, Name: "Other"},
+	}
+}
+
+type CreateCategoryRequest struct {
+	// Slug is lower case letters and digits separated by - or _, e.g. "data_science"
+	Slug        string `json:"slug" validate:"required,min=2,max=50"`
+	Name        string `json:"name" validate:"required,min=2,max=100"`
+	Description string `json:"description,omitempty" validate:"omitempty,max=500"`
+}
+
+type UpdateCategoryRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=500"`
+}
+
+type CategoryResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+	// URL is the canonical location of a newly created resource
+	URL string `json:"url,omitempty"`
+}
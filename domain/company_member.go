@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrCompanyMemberNotFound = errors.New("company member not found")
+	// ErrCompanyMemberExists is returned when adding a user who already works for a company
+	ErrCompanyMemberExists = errors.New("user is already a member of a company")
+	ErrInvitationNotFound  = errors.New("invitation not found")
+)
+
+// CompanyInvitationTTL is how long an invitation to join a company can be accepted
+const CompanyInvitationTTL = 7 * 24 * time.Hour
+
+// MemberRole is a user's role inside a company account
+type MemberRole string
+
+const (
+	// MemberOwner is the company account itself. It isn't stored as a membership.
+	MemberOwner MemberRole = "owner"
+	// MemberAdmin manages the team on top of what recruiters can do
+	MemberAdmin MemberRole = "admin"
+	// MemberRecruiter posts and manages the company's jobs and their applications
+	MemberRecruiter MemberRole = "recruiter"
+)
+
+// ManagesTeam reports whether the role can invite and remove members
+func (r MemberRole) ManagesTeam() bool {
+	return r == MemberOwner || r == MemberAdmin
+}
+
+// CompanyMember links a user to the company account they work for. The
+// company account is the company role user that owns jobs and settings.
+type CompanyMember struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	CompanyID string             `bson:"company_id" json:"company_id"`
+	UserID    string             `bson:"user_id" json:"user_id"`
+	Role      MemberRole         `bson:"role" json:"role"`
+	// Source tells how the membership was created, e.g. "sso" or "invitation"
+	Source    string    `bson:"source" json:"source"`
+	InvitedBy string    `bson:"invited_by,omitempty" json:"invited_by,omitempty"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// TeamMember is an entry of a company's team listing
+type TeamMember struct {
+	UserID string     `json:"user_id"`
+	Name   string     `json:"name"`
+	Email  string     `json:"email"`
+	Role   MemberRole `json:"role"`
+	// JoinedAt is unset for the owner
+	JoinedAt *time.Time `json:"joined_at,omitempty"`
+}
+
+// CompanyInvitation invites someone by email to join a company's team. Only
+// the SHA-256 hash of the invitation token is stored.
+type CompanyInvitation struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	CompanyID  string             `bson:"company_id" json:"company_id"`
+	Email      string             `bson:"email" json:"email"`
+	Role       MemberRole         `bson:"role" json:"role"`
+	TokenHash  string             `bson:"token_hash" json:"-"`
+	InvitedBy  string             `bson:"invited_by" json:"invited_by"`
+	ExpiresAt  time.Time          `bson:"expires_at" json:"expires_at"`
+	AcceptedAt *time.Time         `bson:"accepted_at,omitempty" json:"accepted_at,omitempty"`
+	AcceptedBy string             `bson:"accepted_by,omitempty" json:"accepted_by,omitempty"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+}
+
+type InviteMemberRequest struct {
+	Email string     `json:"email" validate:"required,email"`
+	Role  MemberRole `json:"role" validate:"required,oneof=admin recruiter"`
+}
+
+type AcceptInvitationRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type CompanyTeamResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+	// URL is the canonical location of a newly created resource
+	URL string `json:"url,omitempty"`
+}
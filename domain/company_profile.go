@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrCompanyProfileNotFound = errors.New("company profile not found")
+
+// Company sizes, by number of employees. The validate tag below lists the same values.
+const (
+	CompanySizeMicro      = "1-10"
+	CompanySizeSmall      = "11-50"
+	CompanySizeMedium     = "51-200"
+	CompanySizeLarge      = "201-1000"
+	CompanySizeEnterprise = "1000+"
+)
+
+// CompanyProfile is the public description of a company account, shown on its jobs
+type CompanyProfile struct {
+	CompanyID string    `bson:"company_id" json:"company_id"`
+	LogoURL   string    `bson:"logo_url,omitempty" json:"logo_url,omitempty"`
+	About     string    `bson:"about,omitempty" json:"about,omitempty"`
+	Industry  string    `bson:"industry,omitempty" json:"industry,omitempty"`
+	Size      string    `bson:"size,omitempty" json:"size,omitempty"`
+	Website   string    `bson:"website,omitempty" json:"website,omitempty"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// CompanyInfo is the company data embedded in job responses
+type CompanyInfo struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	LogoURL  string `json:"logo_url,omitempty"`
+	About    string `json:"about,omitempty"`
+	Industry string `json:"industry,omitempty"`
+	Size     string `json:"size,omitempty"`
+	Website  string `json:"website,omitempty"`
+}
+
+// CompanyPage is a company's public employer page: its profile and a page of its published jobs
+type CompanyPage struct {
+	Company    *CompanyInfo `json:"company"`
+	Jobs       []*Job       `json:"jobs"`
+	PageNumber int          `json:"page_number"`
+	PageSize   int          `json:"page_size"`
+	TotalJobs  int64        `json:"total_jobs"`
+	TotalPages int          `json:"total_pages"`
+}
+
+// CompanyInfo combines the company account and its profile, which may be nil
+func (u *User) CompanyInfo(profile *CompanyProfile) *CompanyInfo {
+	info := &CompanyInfo{ID: u.ID.Hex(), Name: u.Name}
+	if profile != nil {
+		info.LogoURL = profile.LogoURL
+		info.About = profile.About
+		info.Industry = profile.Industry
+		info.Size = profile.Size
+		info.Website = profile.Website
+	}
+	return info
+}
+
+// SaveCompanyProfileRequest replaces the stored profile
+type SaveCompanyProfileRequest struct {
+	LogoURL  string `json:"logo_url,omitempty" validate:"omitempty,url,startswith=https://,max=500"`
+	About    string `json:"about,omitempty" validate:"max=3000"`
+	Industry string `json:"industry,omitempty" validate:"max=100"`
+	Size     string `json:"size,omitempty" validate:"omitempty,oneof=1-10 11-50 51-200 201-1000 1000+"`
+	Website  string `json:"website,omitempty" validate:"omitempty,url,max=500"`
+}
+
+type CompanyProfileResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
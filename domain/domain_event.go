@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrDomainEventNotFound = errors.New("event not found")
+
+// DomainEventType names a business event downstream systems (CRM, analytics)
+// subscribe to
+type DomainEventType string
+
+const (
+	EventUserSignup    DomainEventType = "user.signup"
+	EventUserVerified  DomainEventType = "user.verified"
+	EventUserSuspended DomainEventType = "user.suspended"
+)
+
+// DomainEventVersions is the current schema version of each event's payload.
+// A version is bumped on breaking payload changes; adding fields isn't one.
+var DomainEventVersions = map[DomainEventType]int{
+	EventUserSignup:    1,
+	EventUserVerified:  1,
+	EventUserSuspended: 1,
+}
+
+// MaxEventAttempts bounds the deliveries of an event before it is marked failed
+const MaxEventAttempts = 10
+
+// UserEventData is the payload of the user.* events
+type UserEventData struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+	Name   string `json:"name"`
+	Role   Role   `json:"role"`
+	// Method is how the user signed up or verified their email, e.g. "password" or "oauth:google"
+	Method string `json:"method,omitempty"`
+	// SuspendedBy is the admin who suspended the user
+	SuspendedBy string `json:"suspended_by,omitempty"`
+}
+
+// EventData returns the payload of the user's user.* events
+func (u *User) EventData(method string) *UserEventData {
+	return &UserEventData{
+		UserID: u.ID.Hex(),
+		Email:  u.Email,
+		Name:   u.Name,
+		Role:   u.Role,
+		Method: method,
+	}
+}
+
+// DomainEvent is an event in the outbox. It is written when the event happens
+// and delivered to every subscribed webhook by a background worker, at least
+// once: receivers dedupe by ID. Delivered events are kept so they can be replayed.
+type DomainEvent struct {
+	ID         primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	Type       DomainEventType        `bson:"type" json:"type"`
+	Version    int                    `bson:"version" json:"version"`
+	OccurredAt time.Time              `bson:"occurred_at" json:"occurred_at"`
+	Data       map[string]interface{} `bson:"data" json:"data"`
+	// DeliveredTo lists the webhooks that accepted the event
+	DeliveredTo   []string   `bson:"delivered_to,omitempty" json:"delivered_to,omitempty"`
+	DeliveredAt   *time.Time `bson:"delivered_at,omitempty" json:"delivered_at,omitempty"`
+	Attempts      int        `bson:"attempts" json:"attempts"`
+	NextAttemptAt time.Time  `bson:"next_attempt_at" json:"next_attempt_at"`
+	LastError     string     `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	// FailedAt is set once MaxEventAttempts deliveries failed
+	FailedAt *time.Time `bson:"failed_at,omitempty" json:"failed_at,omitempty"`
+}
+
+// Envelope is the JSON body posted to webhooks
+func (e *DomainEvent) Envelope() *DomainEventEnvelope {
+	return &DomainEventEnvelope{
+		ID:         e.ID.Hex(),
+		Type:       e.Type,
+		Version:    e.Version,
+		OccurredAt: e.OccurredAt,
+		Data:       e.Data,
+	}
+}
+
+// DomainEventEnvelope is the stable schema of delivered events: Type and
+// Version tell receivers how to read Data
+type DomainEventEnvelope struct {
+	ID         string                 `json:"id"`
+	Type       DomainEventType        `json:"type"`
+	Version    int                    `json:"version"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+// DomainEventStatus filters events by delivery state
+type DomainEventStatus string
+
+const (
+	EventStatusPending   DomainEventStatus = "pending"
+	EventStatusDelivered DomainEventStatus = "delivered"
+	EventStatusFailed    DomainEventStatus = "failed"
+)
+
+type DomainEventFilter struct {
+	Type   DomainEventType
+	Status DomainEventStatus
+	Since  *time.Time
+	Until  *time.Time
+	// UserID keeps the events about the user
+	UserID string
+}
+
+// ReplayEventsRequest queues the events that occurred in a period for
+// delivery again, optionally only those of some types
+type ReplayEventsRequest struct {
+	Since time.Time         `json:"since" validate:"required"`
+	Until *time.Time        `json:"until,omitempty"`
+	Types []DomainEventType `json:"types,omitempty" validate:"omitempty,dive,oneof=user.signup user.verified user.suspended"`
+}
+
+type DomainEventResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
+
+type DomainEventListResponse struct {
+	Success    bool        `json:"success"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data,omitempty"`
+	PageNumber int         `json:"page_number"`
+	PageSize   int         `json:"page_size"`
+	TotalItems int64       `json:"total_items"`
+	TotalPages int         `json:"total_pages"`
+	Errors     []string    `json:"errors,omitempty"`
+}
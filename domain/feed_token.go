@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrFeedTokenNotFound = errors.New("feed token not found")
+
+// MaxFeedTokens bounds the active feed tokens of a company, and those of the admins
+const MaxFeedTokens = 10
+
+// FeedToken lets an external job board fetch the XML job feed. A company's
+// tokens list its own published jobs; tokens created by admins have no
+// company and list every published job. Only the SHA-256 hash of the token is
+// stored; Prefix identifies it in listings.
+type FeedToken struct {
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	// CompanyID is empty for the tokens listing every company's jobs
+	CompanyID string `bson:"company_id" json:"company_id,omitempty"`
+	// Name says which job board uses the token, e.g. "Indeed"
+	Name       string     `bson:"name" json:"name"`
+	Prefix     string     `bson:"prefix" json:"prefix"`
+	TokenHash  string     `bson:"token_hash" json:"-"`
+	CreatedBy  string     `bson:"created_by" json:"created_by"`
+	CreatedAt  time.Time  `bson:"created_at" json:"created_at"`
+	LastUsedAt *time.Time `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+}
+
+type CreateFeedTokenRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+}
+
+// CreatedFeedToken is returned once when a token is generated with the feed
+// URL to give the job board; the plain token can't be retrieved later
+type CreatedFeedToken struct {
+	*FeedToken
+	Token   string `json:"token"`
+	FeedURL string `json:"feed_url"`
+}
+
+type FeedTokenResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+	// URL is the canonical location of a newly created resource
+	URL string `json:"url,omitempty"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrFollowNotFound = errors.New("follow not found")
+
+// Follow records an applicant following a company. Followers are notified
+// when the company publishes a new job.
+type Follow struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    string             `bson:"user_id" json:"user_id"`
+	CompanyID string             `bson:"company_id" json:"company_id"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// FollowedCompany is an entry of the applicant's following list
+type FollowedCompany struct {
+	Company    *CompanyInfo `json:"company"`
+	FollowedAt time.Time    `json:"followed_at"`
+}
+
+type FollowResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
+
+type FollowListResponse struct {
+	Success    bool        `json:"success"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data,omitempty"`
+	PageNumber int         `json:"page_number"`
+	PageSize   int         `json:"page_size"`
+	TotalItems int64       `json:"total_items"`
+	TotalPages int         `json:"total_pages"`
+	Errors     []string    `json:"errors,omitempty"`
+}
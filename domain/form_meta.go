@@ -0,0 +1,19 @@
+package domain
+
+// FormField describes an input of a client form and the validation rules the
+// server applies to it, so clients don't have to hard-code them
+type FormField struct {
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	Required  bool     `json:"required"`
+	MinLength *int     `json:"min_length,omitempty"`
+	MaxLength *int     `json:"max_length,omitempty"`
+	Options   []string `json:"options,omitempty"`
+}
+
+// JobFormMeta describes the job create/update form
+type JobFormMeta struct {
+	Fields []FormField `json:"fields"`
+	// Locations lists the locations of published jobs, for autocompletion
+	Locations []string `json:"locations"`
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// StatusChange is an entry of an application's status history
+type StatusChange struct {
+	Status ApplicationStatus `bson:"status" json:"status"`
+	At     time.Time         `bson:"at" json:"at"`
+}
+
+// Timeline returns the application's status history. For applications from
+// before the history was recorded it is rebuilt from the submission, interview
+// and last status change dates.
+func (a *Application) Timeline() []StatusChange {
+	if len(a.StatusHistory) > 0 {
+		return a.StatusHistory
+	}
+
+	timeline := []StatusChange{{Status: StatusApplied, At: a.AppliedAt}}
+	if a.InterviewedAt != nil && a.Status != StatusInterview {
+		timeline = append(timeline, StatusChange{Status: StatusInterview, At: *a.InterviewedAt})
+	}
+	if a.Status != StatusApplied {
+		timeline = append(timeline, StatusChange{Status: a.Status, At: a.StatusSince()})
+	}
+	return timeline
+}
+
+// FunnelApplication is an application covered by a funnel report, with its job
+type FunnelApplication struct {
+	Application `bson:",inline"`
+	JobTitle    string `bson:"job_title"`
+	CompanyID   string `bson:"company_id"`
+}
+
+// DurationStats summarises a set of durations, in days
+type DurationStats struct {
+	Count   int64   `json:"count"`
+	AvgDays float64 `json:"avg_days"`
+	P50Days float64 `json:"p50_days"`
+	P75Days float64 `json:"p75_days"`
+	P90Days float64 `json:"p90_days"`
+}
+
+// NewDurationStats computes the average and nearest-rank percentiles of
+// durations. It returns nil when there are none.
+func NewDurationStats(durations []time.Duration) *DurationStats {
+	if len(durations) == 0 {
+		return nil
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+		if rank < 0 {
+			rank = 0
+		}
+		return days(sorted[rank])
+	}
+
+	return &DurationStats{
+		Count:   int64(len(sorted)),
+		AvgDays: days(total / time.Duration(len(sorted))),
+		P50Days: percentile(50),
+		P75Days: percentile(75),
+		P90Days: percentile(90),
+	}
+}
+
+// days converts a duration to days, rounded to the hour
+func days(d time.Duration) float64 {
+	return math.Round(d.Hours()) / 24
+}
+
+// StageFunnel sums up one stage of the hiring funnel. TimeInStage covers the
+// applications that moved on from the stage.
+type StageFunnel struct {
+	Stage       ApplicationStatus `json:"stage"`
+	Reached     int64             `json:"reached"`
+	TimeInStage *DurationStats    `json:"time_in_stage,omitempty"`
+}
+
+// JobFunnel is the hiring funnel of one job, of a company's jobs (without job
+// ID and title) or the totals of a report. TimeToHire runs from the
+// application to the move to Hired.
+type JobFunnel struct {
+	JobID      string         `json:"job_id,omitempty"`
+	JobTitle   string         `json:"job_title,omitempty"`
+	CompanyID  string         `json:"company_id,omitempty"`
+	Stages     []*StageFunnel `json:"stages"`
+	TimeToHire *DurationStats `json:"time_to_hire,omitempty"`
+}
+
+// FunnelReport is the hiring funnel of the applications received in a period
+type FunnelReport struct {
+	From time.Time    `json:"from"`
+	To   time.Time    `json:"to"`
+	Jobs []*JobFunnel `json:"jobs"`
+	// Companies breaks platform wide reports down per company
+	Companies []*JobFunnel `json:"companies,omitempty"`
+	Totals    *JobFunnel   `json:"totals"`
+}
@@ -0,0 +1,71 @@
+package domain
+
+import "time"
+
+// RejectionReason categorises why an application was rejected, for hiring
+// outcome reports. The validate tag on UpdateApplicationStatusRequest lists the same values.
+type RejectionReason string
+
+const (
+	RejectionQualifications    RejectionReason = "qualifications"
+	RejectionExperience        RejectionReason = "experience"
+	RejectionPositionFilled    RejectionReason = "position_filled"
+	RejectionCandidateWithdrew RejectionReason = "candidate_withdrew"
+	RejectionOther             RejectionReason = "other"
+	// RejectionUnspecified counts rejections recorded without a reason
+	RejectionUnspecified RejectionReason = "unspecified"
+)
+
+// RejectionReasons lists every reason in report column order
+var RejectionReasons = []RejectionReason{
+	RejectionQualifications,
+	RejectionExperience,
+	RejectionPositionFilled,
+	RejectionCandidateWithdrew,
+	RejectionOther,
+	RejectionUnspecified,
+}
+
+const (
+	// DefaultHiringReportPeriod is the period reported on when no dates are given
+	DefaultHiringReportPeriod = 90 * 24 * time.Hour
+	// MaxHiringReportPeriod bounds the period a hiring outcome report covers
+	MaxHiringReportPeriod = 366 * 24 * time.Hour
+)
+
+// HiringReportFilter selects the applications a hiring outcome report covers
+type HiringReportFilter struct {
+	// CompanyID restricts the report to one company's jobs, all jobs when empty
+	CompanyID string
+	// From and To bound the application date, To is exclusive
+	From time.Time
+	To   time.Time
+}
+
+// JobHiringOutcome sums up the applications to one job. Interviews counts the
+// applications that reached the Interview stage, whatever happened next.
+type JobHiringOutcome struct {
+	JobID            string                    `json:"job_id"`
+	JobTitle         string                    `json:"job_title"`
+	CompanyID        string                    `json:"company_id"`
+	Applications     int64                     `json:"applications"`
+	Interviews       int64                     `json:"interviews"`
+	Hires            int64                     `json:"hires"`
+	Rejections       int64                     `json:"rejections"`
+	RejectionReasons map[RejectionReason]int64 `json:"rejection_reasons"`
+}
+
+// HiringOutcomeReport is the hiring outcome report for a period
+type HiringOutcomeReport struct {
+	From   time.Time           `json:"from"`
+	To     time.Time           `json:"to"`
+	Jobs   []*JobHiringOutcome `json:"jobs"`
+	Totals *JobHiringOutcome   `json:"totals"`
+}
+
+type HiringReportResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrInterviewFeedbackExists is returned when feedback on an interview was already submitted
+var ErrInterviewFeedbackExists = errors.New("interview feedback already submitted")
+
+// InterviewFeedbackDelay is how long after the scheduled interview the
+// applicant is asked for feedback
+const InterviewFeedbackDelay = 2 * time.Hour
+
+// MinFeedbackResponses is how many responses a company needs before it sees
+// its own summary, so a single answer can't be traced back to an applicant
+const MinFeedbackResponses = 5
+
+// InterviewRatings rate the interview process from 1 (poor) to 5 (excellent).
+// They are about how the applicant was treated, not the hiring decision.
+type InterviewRatings struct {
+	// Communication rates how clearly and promptly the company communicated
+	Communication int `bson:"communication" json:"communication" validate:"required,min=1,max=5"`
+	// Scheduling rates punctuality and how easy the interview was to arrange
+	Scheduling int `bson:"scheduling" json:"scheduling" validate:"required,min=1,max=5"`
+	// Respect rates how respectfully and professionally the applicant was treated
+	Respect int `bson:"respect" json:"respect" validate:"required,min=1,max=5"`
+	// Clarity rates how well the role and the next steps were explained
+	Clarity int `bson:"clarity" json:"clarity" validate:"required,min=1,max=5"`
+	Overall int `bson:"overall" json:"overall" validate:"required,min=1,max=5"`
+}
+
+// InterviewFeedback is an applicant's anonymous feedback on an interview. It
+// doesn't record who gave it; the application ID only prevents duplicates and
+// is never returned. The submission date is kept to the day.
+type InterviewFeedback struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	CompanyID     string             `bson:"company_id" json:"company_id"`
+	JobID         string             `bson:"job_id" json:"job_id"`
+	ApplicationID string             `bson:"application_id" json:"-"`
+	Ratings       InterviewRatings   `bson:"ratings" json:"ratings"`
+	Comment       string             `bson:"comment,omitempty" json:"comment,omitempty"`
+	SubmittedOn   time.Time          `bson:"submitted_on" json:"submitted_on"`
+}
+
+// InterviewRatingAverages averages the ratings of a company's interviews
+type InterviewRatingAverages struct {
+	Communication float64 `bson:"communication" json:"communication"`
+	Scheduling    float64 `bson:"scheduling" json:"scheduling"`
+	Respect       float64 `bson:"respect" json:"respect"`
+	Clarity       float64 `bson:"clarity" json:"clarity"`
+	Overall       float64 `bson:"overall" json:"overall"`
+}
+
+// InterviewFeedbackSummary aggregates the feedback a company received
+type InterviewFeedbackSummary struct {
+	CompanyID   string                  `bson:"_id" json:"company_id"`
+	CompanyName string                  `bson:"-" json:"company_name,omitempty"`
+	Responses   int64                   `bson:"responses" json:"responses"`
+	Averages    InterviewRatingAverages `bson:"averages" json:"averages"`
+}
+
+// InterviewFeedbackComment is a free text comment, shown to admins only
+type InterviewFeedbackComment struct {
+	Comment     string    `bson:"comment" json:"comment"`
+	Overall     int       `bson:"overall" json:"overall"`
+	SubmittedOn time.Time `bson:"submitted_on" json:"submitted_on"`
+}
+
+// InterviewFeedbackReport is the admin view of one company's feedback
+type InterviewFeedbackReport struct {
+	Summary  *InterviewFeedbackSummary   `json:"summary"`
+	Comments []*InterviewFeedbackComment `json:"comments"`
+}
+
+type ScheduleInterviewRequest struct {
+	ScheduledAt time.Time `json:"scheduled_at" validate:"required"`
+}
+
+type SubmitInterviewFeedbackRequest struct {
+	Ratings InterviewRatings `json:"ratings" validate:"required"`
+	Comment string           `json:"comment,omitempty" validate:"max=1000"`
+}
+
+type InterviewFeedbackResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+	// URL is the canonical location of a newly created resource
+	URL string `json:"url,omitempty"`
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInviteCodeNotFound = errors.New("invite code not found")
+	ErrInviteCodeExists   = errors.New("invite code already exists")
+	// ErrInviteCodeUnavailable is returned when a code is unknown, disabled,
+	// expired, used up or restricted to another role
+	ErrInviteCodeUnavailable = errors.New("invite code unavailable")
+)
+
+// InviteCode lets people sign up while the portal is invite-only, e.g. the
+// pilot companies and applicants of a soft launch
+type InviteCode struct {
+	ID   primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Code string             `bson:"code" json:"code"`
+	// Role restricts the code to accounts of one role, empty for any role
+	Role Role `bson:"role,omitempty" json:"role,omitempty"`
+	// MaxUses is how many accounts can sign up with the code
+	MaxUses   int        `bson:"max_uses" json:"max_uses"`
+	Uses      int        `bson:"uses" json:"uses"`
+	ExpiresAt *time.Time `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+	// Note says who the code is for, e.g. "Acme pilot"
+	Note       string     `bson:"note,omitempty" json:"note,omitempty"`
+	DisabledAt *time.Time `bson:"disabled_at,omitempty" json:"disabled_at,omitempty"`
+	// CreatedBy is the ID of the admin who created the code
+	CreatedBy string    `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// CreateInviteCodeRequest creates an invite code. The code is generated when
+// none is given.
+type CreateInviteCodeRequest struct {
+	Code      string     `json:"code,omitempty" validate:"omitempty,alphanum,min=6,max=32"`
+	Role      Role       `json:"role,omitempty" validate:"omitempty,oneof=applicant company"`
+	MaxUses   int        `json:"max_uses" validate:"required,min=1,max=10000"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Note      string     `json:"note,omitempty" validate:"max=200"`
+}
+
+type InviteCodeResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+	// URL is the canonical location of a newly created resource
+	URL string `json:"url,omitempty"`
+}
@@ -1,34 +1,611 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var ErrJobNotFound = errors.New("job not found")
+
+type EmploymentType string
+
+const (
+	FullTime   EmploymentType = "full_time"
+	PartTime   EmploymentType = "part_time"
+	Contract   EmploymentType = "contract"
+	Internship EmploymentType = "internship"
+	Temporary  EmploymentType = "temporary"
+)
+
+// ExperienceLevel is the seniority a job is aimed at
+type ExperienceLevel string
+
+const (
+	EntryLevel  ExperienceLevel = "entry"
+	JuniorLevel ExperienceLevel = "junior"
+	MidLevel    ExperienceLevel = "mid"
+	SeniorLevel ExperienceLevel = "senior"
+	LeadLevel   ExperienceLevel = "lead"
+)
+
+// Job categories created with the taxonomy, see DefaultCategories. Admins
+// manage the taxonomy, so jobs may use other categories too.
+const (
+	CategoryEngineering     = "engineering"
+	CategoryDesign          = "design"
+	CategoryProduct         = "product"
+	CategoryMarketing       = "marketing"
+	CategorySales           = "sales"
+	CategoryFinance         = "finance"
+	CategoryOperations      = "operations"
+	CategoryCustomerSupport = "customer_support"
+	CategoryOther           = "other"
+)
+
+// SalaryPeriod is the time a salary amount is paid for
+type SalaryPeriod string
+
+const (
+	SalaryPerHour  SalaryPeriod = "hour"
+	SalaryPerDay   SalaryPeriod = "day"
+	SalaryPerWeek  SalaryPeriod = "week"
+	SalaryPerMonth SalaryPeriod = "month"
+	SalaryPerYear  SalaryPeriod = "year"
+)
+
+// SalaryRange is the pay offered for a job. Currency is an ISO 4217 code.
+type SalaryRange struct {
+	Min      float64      `bson:"min" json:"min" validate:"gte=0"`
+	Max      float64      `bson:"max" json:"max" validate:"gtefield=Min"`
+	Currency string       `bson:"currency" json:"currency" validate:"required,iso4217"`
+	Period   SalaryPeriod `bson:"period" json:"period" validate:"required,oneof=hour day week month year"`
+	// Converted is the range in the currency preferred by the requester, set
+	// when it differs from Currency and exchange rates are available
+	Converted *ConvertedSalary `bson:"-" json:"converted,omitempty" validate:"-"`
+}
+
+// ConvertedSalary is a salary range converted for display, rounded to whole units
+type ConvertedSalary struct {
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+	Currency string  `json:"currency"`
+	// Rate is what one unit of the job's currency is worth in Currency
+	Rate float64 `json:"rate"`
+}
+
+// GeoPoint is a GeoJSON point. Coordinates are [longitude, latitude].
+type GeoPoint struct {
+	Type        string    `bson:"type" json:"type"`
+	Coordinates []float64 `bson:"coordinates" json:"coordinates"`
+}
+
+// NewGeoPoint returns the point at the latitude and longitude
+func NewGeoPoint(lat, lng float64) *GeoPoint {
+	return &GeoPoint{Type: "Point", Coordinates: []float64{lng, lat}}
+}
+
+// EarthRadiusKm is the radius distances on the globe are computed with
+const EarthRadiusKm = 6378.1
+
+// Job distance search radius, in kilometers
+const (
+	DefaultJobSearchRadiusKm = 25
+	MaxJobSearchRadiusKm     = 500
+)
+
+// GeoCircle is the area within RadiusKm of a point
+type GeoCircle struct {
+	Lat      float64
+	Lng      float64
+	RadiusKm float64
+}
+
 type Job struct {
 	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	Title       string             `bson:"title" json:"title" validate:"required,min=1,max=100"`
 	Description string             `bson:"description" json:"description" validate:"required,min=20,max=2000"`
 	Location    string             `bson:"location,omitempty" json:"location,omitempty"`
-	IsPublished bool               `bson:"is_published" json:"is_published"`
-	CreatedBy   string             `bson:"created_by" json:"created_by"`
-	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	// Coordinates are geocoded from Location in the background. GeocodedLocation
+	// is the location they were looked up for, it is unset until the lookup
+	// ran. Locations that can't be geocoded leave the job without coordinates.
+	Coordinates      *GeoPoint `bson:"coordinates,omitempty" json:"coordinates,omitempty"`
+	GeocodedLocation string    `bson:"geocoded_location,omitempty" json:"-"`
+	// EmploymentType and Category are optional for jobs posted before they were
+	// introduced. Category is the slug of a taxonomy Category.
+	EmploymentType EmploymentType `bson:"employment_type,omitempty" json:"employment_type,omitempty"`
+	Category       string         `bson:"category,omitempty" json:"category,omitempty"`
+	// ExperienceLevel is optional, Remote is false for jobs posted before it was introduced
+	ExperienceLevel ExperienceLevel `bson:"experience_level,omitempty" json:"experience_level,omitempty"`
+	Remote          bool            `bson:"remote" json:"remote"`
+	Salary          *SalaryRange    `bson:"salary,omitempty" json:"salary,omitempty"`
+	// Skills are stored normalized, see NormalizeSkills
+	Skills      []string `bson:"skills,omitempty" json:"skills,omitempty"`
+	IsPublished bool     `bson:"is_published" json:"is_published"`
+	// Deadline is when the job stops taking applications. Once it passes the
+	// job is unpublished, and it can only be published again with a later deadline.
+	Deadline *time.Time `bson:"deadline,omitempty" json:"deadline,omitempty"`
+	// DeadlinePassed is computed from Deadline when the job is returned
+	DeadlinePassed bool `bson:"-" json:"deadline_passed"`
+	// PublishAt is when an unpublished job is published automatically.
+	// ExpiresAt is when the posting is taken down, expired jobs are left out
+	// of the listings even before they are unpublished.
+	PublishAt *time.Time `bson:"publish_at,omitempty" json:"publish_at,omitempty"`
+	ExpiresAt *time.Time `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+	// GoesLiveInSeconds and ExpiresInSeconds count down to PublishAt and
+	// ExpiresAt, they are computed when the job is returned
+	GoesLiveInSeconds *int64 `bson:"-" json:"goes_live_in_seconds,omitempty"`
+	ExpiresInSeconds  *int64 `bson:"-" json:"expires_in_seconds,omitempty"`
+	// HiringConfirmedAt is when the company last confirmed it is still hiring
+	HiringConfirmedAt    *time.Time `bson:"hiring_confirmed_at,omitempty" json:"hiring_confirmed_at,omitempty"`
+	HiringReminderSentAt *time.Time `bson:"hiring_reminder_sent_at,omitempty" json:"-"`
+	// IsActivelyHiring is computed from HiringConfirmedAt when the job is returned
+	IsActivelyHiring bool `bson:"-" json:"is_actively_hiring"`
+	// FeaturedUntil is when the job stops being featured: featured jobs come
+	// first in the job listings. IsFeatured is computed from it when the job
+	// is returned.
+	FeaturedUntil *time.Time `bson:"featured_until,omitempty" json:"featured_until,omitempty"`
+	// FeaturedSince is when the running feature started, extensions keep it
+	FeaturedSince *time.Time `bson:"featured_since,omitempty" json:"featured_since,omitempty"`
+	IsFeatured    bool       `bson:"-" json:"is_featured"`
+	// IsSaved is set on the jobs the requesting applicant bookmarked
+	IsSaved bool `bson:"-" json:"is_saved"`
+	// ApplicationCount is set on the jobs of the company's own job list
+	ApplicationCount *int64 `bson:"-" json:"application_count,omitempty"`
+	// CreatedBy is the company account that owns the job
+	CreatedBy string `bson:"created_by" json:"created_by"`
+	// PostedBy is the team member who posted the job, unset when the company account did
+	PostedBy  string    `bson:"posted_by,omitempty" json:"posted_by,omitempty"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+	// GeoFlagged is set when the job was posted from a flagged country
+	GeoFlagged bool `bson:"geo_flagged,omitempty" json:"-"`
+	// BlindScreening hides applicants' identity from the company until their
+	// application reaches the Interview stage, to reduce bias
+	BlindScreening bool `bson:"blind_screening,omitempty" json:"blind_screening"`
+	// Closing is set once the company closed the job, closed jobs stay unpublished
+	Closing *JobClosing `bson:"closing,omitempty" json:"closing,omitempty"`
+	// MaxApplications caps the applications the job takes, 0 takes any
+	// number. ApplicationsReceived counts them; the application reaching the
+	// cap unpublishes the job, at ApplicationsCappedAt.
+	MaxApplications      int        `bson:"max_applications,omitempty" json:"max_applications,omitempty"`
+	ApplicationsReceived int64      `bson:"applications_received,omitempty" json:"-"`
+	ApplicationsCappedAt *time.Time `bson:"applications_capped_at,omitempty" json:"applications_capped_at,omitempty"`
+	// ApplicationsClosed is computed from the cap when the job is returned
+	ApplicationsClosed bool `bson:"-" json:"applications_closed"`
+	// ApplyURL sends applicants to the company's own applicant tracking
+	// system instead of applying on the portal, see JobApplyClick
+	ApplyURL string `bson:"apply_url,omitempty" json:"apply_url,omitempty"`
+	// Moderation is set once the job was submitted for review
+	Moderation *JobModeration `bson:"moderation,omitempty" json:"moderation,omitempty"`
+	// BumpedAt is when the job was last posted or reposted, listings rank by it
+	BumpedAt *time.Time `bson:"bumped_at,omitempty" json:"bumped_at,omitempty"`
+	// FollowersNotified is set once the company's followers were told about
+	// the job. Jobs posted before follows existed don't have the field and are
+	// never announced.
+	FollowersNotified bool `bson:"followers_notified" json:"-"`
+	// DeletedAt is set when the company deleted the job. Deleted jobs are
+	// archived: left out of every query until the company restores them.
+	DeletedAt *time.Time `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+}
+
+// IsClosed reports whether the company closed the job
+func (j *Job) IsClosed() bool {
+	return j.Closing != nil
+}
+
+// PastDeadline reports whether the job's application deadline has passed
+func (j *Job) PastDeadline(now time.Time) bool {
+	return j.Deadline != nil && !now.Before(*j.Deadline)
+}
+
+// Expired reports whether the job's posting has expired
+func (j *Job) Expired(now time.Time) bool {
+	return j.ExpiresAt != nil && !now.Before(*j.ExpiresAt)
+}
+
+// Scheduled reports whether the job waits to be published automatically
+func (j *Job) Scheduled(now time.Time) bool {
+	return !j.IsPublished && j.PublishAt != nil && now.Before(*j.PublishAt)
+}
+
+// Clone returns a new draft with the job's content. Its dates, schedule,
+// publication state and closing are left out, so it starts like a new posting.
+func (j *Job) Clone() *Job {
+	clone := &Job{
+		Title:           j.Title,
+		Description:     j.Description,
+		Location:        j.Location,
+		EmploymentType:  j.EmploymentType,
+		Category:        j.Category,
+		ExperienceLevel: j.ExperienceLevel,
+		Remote:          j.Remote,
+		BlindScreening:  j.BlindScreening,
+		ApplyURL:        j.ApplyURL,
+		MaxApplications: j.MaxApplications,
+		CreatedBy:       j.CreatedBy,
+		// The location was already geocoded
+		GeocodedLocation: j.GeocodedLocation,
+	}
+	if j.Coordinates != nil {
+		clone.Coordinates = NewGeoPoint(j.Coordinates.Coordinates[1], j.Coordinates.Coordinates[0])
+	}
+	if j.Salary != nil {
+		salary := *j.Salary
+		clone.Salary = &salary
+	}
+	if j.Skills != nil {
+		clone.Skills = append([]string(nil), j.Skills...)
+	}
+	return clone
+}
+
+// MinPublishedDescriptionLength is the shortest description a job can be published with
+const MinPublishedDescriptionLength = 100
+
+// PublishProblems lists what keeps the job from being published: listings
+// need a complete description, an employment type and a location unless the
+// job is remote. Drafts can be saved without them. A job can't be published
+// again once its application cap is reached.
+func (j *Job) PublishProblems() []string {
+	var problems []string
+	if len(strings.TrimSpace(j.Description)) < MinPublishedDescriptionLength {
+		problems = append(problems, fmt.Sprintf("The description must be at least %d characters long to publish the job", MinPublishedDescriptionLength))
+	}
+	if j.EmploymentType == "" {
+		problems = append(problems, "Set an employment type to publish the job")
+	}
+	if !j.Remote && strings.TrimSpace(j.Location) == "" {
+		problems = append(problems, "Set a location or mark the job as remote to publish it")
+	}
+	if j.ApplicationsFull() {
+		problems = append(problems, "The job received as many applications as it takes, raise or remove the limit to publish it")
+	}
+	return problems
+}
+
+// BlindsApplication reports whether the company must not see who submitted an
+// application with the given status
+func (j *Job) BlindsApplication(status ApplicationStatus) bool {
+	return j.BlindScreening && !ReachedInterview(status)
+}
+
+// ActivelyHiringWindow is how long a hiring confirmation lasts. Once it lapses
+// the job loses its actively hiring flag and is listed after fresh postings.
+const ActivelyHiringWindow = 14 * 24 * time.Hour
+
+// HiringReminderLead is how long before the signal lapses the company is asked to confirm
+const HiringReminderLead = 3 * 24 * time.Hour
+
+// HiringConfirmed returns when the company last confirmed it is hiring. Jobs
+// that were never confirmed count from their posting date.
+func (j *Job) HiringConfirmed() time.Time {
+	if j.HiringConfirmedAt != nil {
+		return *j.HiringConfirmedAt
+	}
+	return j.CreatedAt
+}
+
+// SetComputedFields computes the fields derived from the job's dates as of now
+func (j *Job) SetComputedFields(now time.Time) {
+	j.IsActivelyHiring = now.Sub(j.HiringConfirmed()) < ActivelyHiringWindow
+	j.DeadlinePassed = j.PastDeadline(now)
+	j.IsFeatured = j.Featured(now)
+	j.ApplicationsClosed = j.ApplicationsFull()
+	j.GoesLiveInSeconds, j.ExpiresInSeconds = nil, nil
+	if j.Scheduled(now) {
+		in := int64(j.PublishAt.Sub(now).Seconds())
+		j.GoesLiveInSeconds = &in
+	}
+	if j.ExpiresAt != nil && !j.Expired(now) {
+		in := int64(j.ExpiresAt.Sub(now).Seconds())
+		j.ExpiresInSeconds = &in
+	}
+}
+
+// Job audit actions
+const (
+	JobActionRepost      = "repost"
+	JobActionClose       = "close"
+	JobActionEdit        = "edit"
+	JobActionTrivialEdit = "trivial_edit"
+	JobActionPublish     = "publish"
+	JobActionUnpublish   = "unpublish"
+	JobActionClone       = "clone"
+	JobActionDelete      = "delete"
+	JobActionRestore     = "restore"
+	JobActionSubmit      = "submit_for_review"
+	JobActionApprove     = "approve"
+	JobActionReject      = "reject"
+	JobActionFeature     = "feature"
+	JobActionUnfeature   = "unfeature"
+)
+
+// JobAuditEntry records a significant action taken on a job posting
+type JobAuditEntry struct {
+	ID        primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	JobID     string                 `bson:"job_id" json:"job_id"`
+	Action    string                 `bson:"action" json:"action"`
+	ActorID   string                 `bson:"actor_id" json:"actor_id"`
+	Details   map[string]interface{} `bson:"details,omitempty" json:"details,omitempty"`
+	CreatedAt time.Time              `bson:"created_at" json:"created_at"`
 }
 
 type CreateJobRequest struct {
-	Title       string `json:"title" validate:"required,min=1,max=100"`
-	Description string `json:"description" validate:"required,min=20,max=2000"`
-	Location    string `json:"location,omitempty"`
-	IsPublished bool   `json:"is_published,omitempty"`
+	Title           string          `json:"title" validate:"required,min=1,max=100"`
+	Description     string          `json:"description" validate:"required,min=20,max=2000"`
+	Location        string          `json:"location,omitempty" validate:"omitempty,max=100"`
+	EmploymentType  EmploymentType  `json:"employment_type,omitempty" validate:"omitempty,oneof=full_time part_time contract internship temporary"`
+	Category        string          `json:"category,omitempty" validate:"omitempty,max=50"`
+	ExperienceLevel ExperienceLevel `json:"experience_level,omitempty" validate:"omitempty,oneof=entry junior mid senior lead"`
+	Remote          bool            `json:"remote,omitempty"`
+	Salary          *SalaryRange    `json:"salary,omitempty"`
+	Skills          []string        `json:"skills,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
+	IsPublished     bool            `json:"is_published,omitempty"`
+	BlindScreening  bool            `json:"blind_screening,omitempty"`
+	// ApplyURL redirects applicants to an external applicant tracking system
+	ApplyURL string `json:"apply_url,omitempty" validate:"omitempty,http_url,max=500"`
+	// MaxApplications caps the applications the job takes
+	MaxApplications int `json:"max_applications,omitempty" validate:"omitempty,min=1,max=100000"`
+	// Deadline must be in the future
+	Deadline *time.Time `json:"deadline,omitempty"`
+	// PublishAt schedules the job to be published later, it takes precedence
+	// over IsPublished. ExpiresAt must come after it, and after now.
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
 
 type UpdateJobRequest struct {
-	Title       *string `json:"title,omitempty" validate:"omitempty,min=1,max=100"`
-	Description *string `json:"description,omitempty" validate:"omitempty,min=20,max=2000"`
-	Location    *string `json:"location,omitempty"`
-	IsPublished *bool   `json:"is_published,omitempty"`
+	Title           *string          `json:"title,omitempty" validate:"omitempty,min=1,max=100"`
+	Description     *string          `json:"description,omitempty" validate:"omitempty,min=20,max=2000"`
+	Location        *string          `json:"location,omitempty" validate:"omitempty,max=100"`
+	EmploymentType  *EmploymentType  `json:"employment_type,omitempty" validate:"omitempty,oneof=full_time part_time contract internship temporary"`
+	Category        *string          `json:"category,omitempty" validate:"omitempty,max=50"`
+	ExperienceLevel *ExperienceLevel `json:"experience_level,omitempty" validate:"omitempty,oneof=entry junior mid senior lead"`
+	Remote          *bool            `json:"remote,omitempty"`
+	// Salary replaces the whole salary range
+	Salary *SalaryRange `json:"salary,omitempty"`
+	// Skills replaces the job's skills, an empty list removes them
+	Skills         []string `json:"skills,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
+	IsPublished    *bool    `json:"is_published,omitempty"`
+	BlindScreening *bool    `json:"blind_screening,omitempty"`
+	// ApplyURL replaces the external apply link, an empty one takes
+	// applications on the portal again
+	ApplyURL *string `json:"apply_url,omitempty" validate:"omitempty,http_url,max=500"`
+	// MaxApplications replaces the application cap, 0 removes it
+	MaxApplications *int `json:"max_applications,omitempty" validate:"omitempty,min=0,max=100000"`
+	// Deadline moves the application deadline, it must be in the future.
+	// ClearDeadline removes it, so the job takes applications until closed.
+	Deadline      *time.Time `json:"deadline,omitempty"`
+	ClearDeadline bool       `json:"clear_deadline,omitempty"`
+	// PublishAt and ExpiresAt reschedule the job like on creation, the clear
+	// flags remove them
+	PublishAt      *time.Time `json:"publish_at,omitempty"`
+	ClearPublishAt bool       `json:"clear_publish_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	ClearExpiresAt bool       `json:"clear_expires_at,omitempty"`
+}
+
+// WithUpdate returns a copy of the job with the request applied, to check the
+// result before saving it
+func (j *Job) WithUpdate(req *UpdateJobRequest) *Job {
+	updated := *j
+	if req.Title != nil {
+		updated.Title = *req.Title
+	}
+	if req.Description != nil {
+		updated.Description = *req.Description
+	}
+	if req.Location != nil {
+		updated.Location = *req.Location
+	}
+	if req.EmploymentType != nil {
+		updated.EmploymentType = *req.EmploymentType
+	}
+	if req.Category != nil {
+		updated.Category = *req.Category
+	}
+	if req.ExperienceLevel != nil {
+		updated.ExperienceLevel = *req.ExperienceLevel
+	}
+	if req.Remote != nil {
+		updated.Remote = *req.Remote
+	}
+	if req.Salary != nil {
+		updated.Salary = req.Salary
+	}
+	if req.Skills != nil {
+		updated.Skills = NormalizeSkills(req.Skills)
+	}
+	if req.IsPublished != nil {
+		updated.IsPublished = *req.IsPublished
+	}
+	if req.BlindScreening != nil {
+		updated.BlindScreening = *req.BlindScreening
+	}
+	if req.ApplyURL != nil {
+		updated.ApplyURL = *req.ApplyURL
+	}
+	if req.MaxApplications != nil {
+		updated.MaxApplications = *req.MaxApplications
+	}
+	if req.Deadline != nil || req.ClearDeadline {
+		updated.Deadline = req.Deadline
+	}
+	if req.PublishAt != nil || req.ClearPublishAt {
+		updated.PublishAt = req.PublishAt
+	}
+	if req.ExpiresAt != nil || req.ClearExpiresAt {
+		updated.ExpiresAt = req.ExpiresAt
+	}
+	return &updated
+}
+
+// MaxSkillFilters bounds the skills a job listing can be filtered on at once
+const MaxSkillFilters = 10
+
+// NormalizeSkills trims and lower-cases skills and drops empty and repeated
+// ones, so "Go" and "go " count as the same skill in filters and facets
+func NormalizeSkills(skills []string) []string {
+	normalized := make([]string, 0, len(skills))
+	seen := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		skill = strings.ToLower(strings.TrimSpace(skill))
+		if skill == "" || seen[skill] {
+			continue
+		}
+		seen[skill] = true
+		normalized = append(normalized, skill)
+	}
+	return normalized
+}
+
+// SkillFacet counts the published jobs requiring a skill
+type SkillFacet struct {
+	Skill string `bson:"_id" json:"skill"`
+	Jobs  int64  `bson:"jobs" json:"jobs"`
+}
+
+// Search box suggestions need at least MinSuggestQueryLength characters and
+// list up to MaxSuggestions values of each kind, DefaultSuggestions by default
+const (
+	MinSuggestQueryLength = 2
+	DefaultSuggestions    = 5
+	MaxSuggestions        = 10
+)
+
+// Similar jobs list up to MaxSimilarJobs jobs, DefaultSimilarJobs by default
+const (
+	DefaultSimilarJobs = 5
+	MaxSimilarJobs     = 20
+)
+
+// Weights of what a job has in common with another when ranking similar
+// jobs. Each shared skill counts, the category and location count once.
+const (
+	SimilarSkillWeight    = 2
+	SimilarCategoryWeight = 3
+	SimilarLocationWeight = 1
+)
+
+// CompanySuggestion is a company whose name matches the search box. ID is
+// the value of the job listing's company filter.
+type CompanySuggestion struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// JobSuggestions are the published job titles and locations, and the company
+// names, starting with what was typed in the search box
+type JobSuggestions struct {
+	Titles    []string             `json:"titles"`
+	Locations []string             `json:"locations"`
+	Companies []*CompanySuggestion `json:"companies"`
+}
+
+// JobFacetLimit bounds the values listed per job facet
+const JobFacetLimit = 20
+
+// FacetCount counts the jobs having a value
+type FacetCount struct {
+	Value string `bson:"_id" json:"value"`
+	// Label is the display name of category and company values
+	Label string `bson:"-" json:"label,omitempty"`
+	Jobs  int64  `bson:"jobs" json:"jobs"`
+}
+
+// JobFacets counts the jobs matching a job listing filter per location,
+// category, employment type and company, most common values first. Company
+// values are company IDs, the value of the listing's company filter.
+type JobFacets struct {
+	Total           int64         `bson:"-" json:"total"`
+	Locations       []*FacetCount `bson:"locations" json:"locations"`
+	Categories      []*FacetCount `bson:"categories" json:"categories"`
+	EmploymentTypes []*FacetCount `bson:"employment_types" json:"employment_types"`
+	Companies       []*FacetCount `bson:"companies" json:"companies"`
+}
+
+// JobFilter narrows down the published jobs returned by the job listing
+type JobFilter struct {
+	// Query is matched against the title, description and skills, the best
+	// matches are listed first
+	Query       string
+	Location    string
+	CompanyName string
+	// Category, EmploymentType and ExperienceLevel are ignored when empty
+	Category        string
+	EmploymentType  EmploymentType
+	ExperienceLevel ExperienceLevel
+	// Remote keeps remote jobs when true and on-site jobs when false
+	Remote *bool
+	// Skills keeps jobs requiring every one of them
+	Skills []string
+	// SalaryMin keeps jobs whose range reaches at least this amount
+	SalaryMin *float64
+	// SalaryMax keeps jobs whose range starts at or below this amount
+	SalaryMax *float64
+	// Currency is the requester's preferred currency, salaries are shown and
+	// the salary bounds are given in it. Empty defaults to the currency of
+	// the requester's country.
+	Currency string
+	// SalaryRates converts the salary bounds into each job currency they are
+	// compared in, set by the use case. Without it the bounds are compared
+	// with every salary as is.
+	SalaryRates map[string]float64
+	// Near keeps jobs located in the circle, jobs without coordinates never match
+	Near *GeoCircle
+}
+
+// JobListStatus selects the jobs of a company's job list
+type JobListStatus string
+
+const (
+	// JobStatusDraft lists the company's unpublished jobs, including the
+	// scheduled and closed ones
+	JobStatusDraft JobListStatus = "draft"
+	// JobStatusPublished lists the company's published jobs
+	JobStatusPublished JobListStatus = "published"
+	// JobStatusPendingReview lists the company's jobs waiting for an admin's review
+	JobStatusPendingReview JobListStatus = "pending_review"
+	// JobStatusArchived lists the company's deleted jobs, which can be restored
+	JobStatusArchived JobListStatus = "archived"
+)
+
+// CompanyJobFilter narrows down the jobs of a company's job list
+type CompanyJobFilter struct {
+	// Status is empty for the company's jobs that aren't archived
+	Status JobListStatus
+	// Title keeps the jobs whose title contains it, ignoring case
+	Title string
+	// CreatedFrom and CreatedTo bound when the jobs were created, CreatedTo
+	// excluded. Either can be zero.
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+// JobTombstoneRetention is how long deleted job IDs are kept for delta sync.
+// Clients whose checkpoint is older have to download the job list again.
+const JobTombstoneRetention = 30 * 24 * time.Hour
+
+// JobTombstone records a deleted job so sync clients can drop it
+type JobTombstone struct {
+	JobID     string    `bson:"job_id" json:"job_id"`
+	DeletedAt time.Time `bson:"deleted_at" json:"deleted_at"`
+}
+
+// JobChanges lists the jobs that changed since a sync checkpoint. Jobs that were
+// unpublished count as deleted, since clients only see published jobs.
+type JobChanges struct {
+	Created []string `json:"created"`
+	Updated []string `json:"updated"`
+	Deleted []string `json:"deleted"`
+	// Checkpoint is the value to pass as ?since= on the next sync
+	Checkpoint time.Time `json:"checkpoint"`
+	// FullResync is set when the checkpoint is too old to compute a delta
+	FullResync bool `json:"full_resync"`
 }
 
 type JobResponse struct {
@@ -36,6 +613,8 @@ type JobResponse struct {
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
 	Errors  []string    `json:"errors,omitempty"`
+	// URL is the canonical location of a newly created resource
+	URL string `json:"url,omitempty"`
 }
 
 type PaginationMeta struct {
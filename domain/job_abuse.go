@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrJobAbuseFlagNotFound = errors.New("job abuse flag not found")
+
+// Listings are ranked by a job's bump time, which only moves when the job is
+// posted or reposted. Edits and publish toggles never move a job up, and a job
+// can be reposted once per JobBumpCooldown.
+const JobBumpCooldown = 7 * 24 * time.Hour
+
+// JobActivityWindow is the sliding window the edit limits below are counted over
+const JobActivityWindow = 24 * time.Hour
+
+const (
+	// MaxJobEditsPerWindow bounds the edits to a single job
+	MaxJobEditsPerWindow = 20
+	// MaxTrivialJobEditsPerWindow bounds the edits that change nothing
+	MaxTrivialJobEditsPerWindow = 3
+	// MaxJobPublishTogglesPerWindow bounds how often a job is published or unpublished
+	MaxJobPublishTogglesPerWindow = 4
+)
+
+// JobAbuseReason says which limit a company ran into
+type JobAbuseReason string
+
+const (
+	AbuseExcessiveEdits  JobAbuseReason = "excessive_edits"
+	AbuseTrivialEdits    JobAbuseReason = "trivial_edits"
+	AbusePublishToggling JobAbuseReason = "publish_toggling"
+	AbuseRepostTooSoon   JobAbuseReason = "repost_too_soon"
+)
+
+// JobAbuseFlag is raised for admins when a job is throttled. Repeated
+// attempts update the open flag instead of raising new ones.
+type JobAbuseFlag struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	JobID       string             `bson:"job_id" json:"job_id"`
+	CompanyID   string             `bson:"company_id" json:"company_id"`
+	Reason      JobAbuseReason     `bson:"reason" json:"reason"`
+	Attempts    int                `bson:"attempts" json:"attempts"`
+	FirstSeenAt time.Time          `bson:"first_seen_at" json:"first_seen_at"`
+	LastSeenAt  time.Time          `bson:"last_seen_at" json:"last_seen_at"`
+	ResolvedAt  *time.Time         `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+	ResolvedBy  string             `bson:"resolved_by,omitempty" json:"resolved_by,omitempty"`
+}
+
+// Bumped returns the time the job is ranked by. Jobs posted before the
+// field was introduced rank by their posting date.
+func (j *Job) Bumped() time.Time {
+	if j.BumpedAt != nil {
+		return *j.BumpedAt
+	}
+	return j.CreatedAt
+}
+
+// ChangedBy reports whether applying the request would change any of the job's fields
+func (j *Job) ChangedBy(req *UpdateJobRequest) bool {
+	return (req.Title != nil && *req.Title != j.Title) ||
+		(req.Description != nil && *req.Description != j.Description) ||
+		(req.Location != nil && *req.Location != j.Location) ||
+		(req.EmploymentType != nil && *req.EmploymentType != j.EmploymentType) ||
+		(req.Category != nil && *req.Category != j.Category) ||
+		(req.ExperienceLevel != nil && *req.ExperienceLevel != j.ExperienceLevel) ||
+		(req.Remote != nil && *req.Remote != j.Remote) ||
+		(req.Skills != nil && !sameSkills(NormalizeSkills(req.Skills), j.Skills)) ||
+		(req.Salary != nil && (j.Salary == nil || *req.Salary != *j.Salary)) ||
+		(req.IsPublished != nil && *req.IsPublished != j.IsPublished) ||
+		(req.BlindScreening != nil && *req.BlindScreening != j.BlindScreening) ||
+		(req.ApplyURL != nil && *req.ApplyURL != j.ApplyURL) ||
+		(req.MaxApplications != nil && *req.MaxApplications != j.MaxApplications) ||
+		(req.Deadline != nil && (j.Deadline == nil || !req.Deadline.Equal(*j.Deadline))) ||
+		(req.ClearDeadline && j.Deadline != nil) ||
+		!sameTime(req.PublishAt, req.ClearPublishAt, j.PublishAt) ||
+		!sameTime(req.ExpiresAt, req.ClearExpiresAt, j.ExpiresAt)
+}
+
+// sameTime reports whether setting or clearing an optional time leaves current unchanged
+func sameTime(set *time.Time, clear bool, current *time.Time) bool {
+	switch {
+	case set != nil:
+		return current != nil && set.Equal(*current)
+	case clear:
+		return current == nil
+	default:
+		return true
+	}
+}
+
+func sameSkills(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+type JobAbuseFlagListResponse struct {
+	Success    bool        `json:"success"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data,omitempty"`
+	PageNumber int         `json:"page_number"`
+	PageSize   int         `json:"page_size"`
+	TotalItems int64       `json:"total_items"`
+	TotalPages int         `json:"total_pages"`
+	Errors     []string    `json:"errors,omitempty"`
+}
+
+type JobAbuseFlagResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// JobApplyClickRetention is how long clicks on external apply links are kept,
+// the click stats cover this period
+const JobApplyClickRetention = 90 * 24 * time.Hour
+
+// JobApplyClickDays is the number of days the daily click counts cover
+const JobApplyClickDays = 30
+
+// JobApplyClick records a visitor sent to a job's external apply link. It
+// doesn't say who clicked.
+type JobApplyClick struct {
+	ID    primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	JobID primitive.ObjectID `bson:"job_id" json:"job_id"`
+	// SignedIn is set when the visitor was signed in
+	SignedIn bool `bson:"signed_in" json:"signed_in"`
+	// Country is resolved from the IP when a geo-IP database is configured
+	Country string    `bson:"country,omitempty" json:"country,omitempty"`
+	At      time.Time `bson:"at" json:"at"`
+}
+
+// JobApplyClickStats sums up the clicks on a job's external apply link
+// over the last JobApplyClickRetention
+type JobApplyClickStats struct {
+	JobID          string     `json:"job_id"`
+	ApplyURL       string     `json:"apply_url,omitempty"`
+	Clicks         int64      `json:"clicks"`
+	SignedInClicks int64      `json:"signed_in_clicks"`
+	LastClickAt    *time.Time `json:"last_click_at,omitempty"`
+	// Daily counts the clicks of the last JobApplyClickDays days (UTC), oldest first
+	Daily []*JobApplyClickDay `json:"daily"`
+}
+
+// JobApplyClickDay counts the clicks of a day
+type JobApplyClickDay struct {
+	Day    string `bson:"_id" json:"day"`
+	Clicks int64  `bson:"clicks" json:"clicks"`
+}
+
+type JobApplyClickResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "errors"
+
+// ErrJobFull is returned when a job already received as many applications as
+// its cap allows
+var ErrJobFull = errors.New("job reached its maximum number of applications")
+
+// MaxApplicationsCap bounds the number of applications a job can be capped at
+const MaxApplicationsCap = 100000
+
+// ApplicationsFull reports whether the job received as many applications as it takes
+func (j *Job) ApplicationsFull() bool {
+	return j.MaxApplications > 0 && j.ApplicationsReceived >= int64(j.MaxApplications)
+}
+
+// ApplicationsCapped reports whether the job was unpublished by its cap and
+// is still full. Such jobs keep their page, marked closed to applications.
+func (j *Job) ApplicationsCapped() bool {
+	return j.ApplicationsCappedAt != nil && j.ApplicationsFull()
+}
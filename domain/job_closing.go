@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+// JobCloseReason tells why a company closed a job. The validate tag on
+// CloseJobRequest lists the same values.
+type JobCloseReason string
+
+const (
+	CloseFilledInternally JobCloseReason = "filled_internally"
+	CloseHiredViaPortal   JobCloseReason = "hired_via_portal"
+	CloseCancelled        JobCloseReason = "cancelled"
+)
+
+// JobCloseReasons lists every reason in report order
+var JobCloseReasons = []JobCloseReason{CloseFilledInternally, CloseHiredViaPortal, CloseCancelled}
+
+// JobClosing is recorded on a job when the company closes it, with the state
+// of its pipeline at that moment
+type JobClosing struct {
+	Reason   JobCloseReason  `bson:"reason" json:"reason"`
+	Note     string          `bson:"note,omitempty" json:"note,omitempty"`
+	ClosedBy string          `bson:"closed_by" json:"closed_by"`
+	ClosedAt time.Time       `bson:"closed_at" json:"closed_at"`
+	Stats    JobClosingStats `bson:"stats" json:"stats"`
+}
+
+// JobClosingStats sums up a job's applications when it is closed
+type JobClosingStats struct {
+	TotalApplicants int64 `bson:"total_applicants" json:"total_applicants"`
+	Hired           int64 `bson:"hired" json:"hired"`
+	// DaysOpen counts from the posting date to the closing
+	DaysOpen float64 `bson:"days_open" json:"days_open"`
+	// TimeToHireDays is the average time from application to hire, nil
+	// without hires
+	TimeToHireDays *float64 `bson:"time_to_hire_days,omitempty" json:"time_to_hire_days,omitempty"`
+}
+
+type CloseJobRequest struct {
+	Reason JobCloseReason `json:"reason" validate:"required,oneof=filled_internally hired_via_portal cancelled"`
+	Note   string         `json:"note,omitempty" validate:"max=500"`
+}
+
+// JobClosingSummary aggregates the jobs closed for one reason
+type JobClosingSummary struct {
+	Reason            JobCloseReason `json:"reason"`
+	Jobs              int64          `json:"jobs"`
+	AvgApplicants     float64        `json:"avg_applicants"`
+	AvgDaysOpen       float64        `json:"avg_days_open"`
+	Hires             int64          `json:"hires"`
+	AvgTimeToHireDays *float64       `json:"avg_time_to_hire_days,omitempty"`
+	// HiringJobs counts the jobs AvgTimeToHireDays is computed over
+	HiringJobs int64 `json:"-"`
+}
+
+// JobClosingReport sums up the jobs closed in a period, per reason. The
+// period uses HiringReportFilter and bounds the closing date.
+type JobClosingReport struct {
+	From    time.Time            `json:"from"`
+	To      time.Time            `json:"to"`
+	Reasons []*JobClosingSummary `json:"reasons"`
+	Totals  *JobClosingSummary   `json:"totals"`
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Up to MaxComparedJobs jobs are compared side by side, at least MinComparedJobs
+const (
+	MinComparedJobs = 2
+	MaxComparedJobs = 5
+)
+
+// ResponseTimeWindow is how far back applications count towards a company's
+// response time. Companies with fewer than MinResponseTimeSamples responses in
+// the window have no response time, a handful of applications says little.
+const (
+	ResponseTimeWindow     = 180 * 24 * time.Hour
+	MinResponseTimeSamples = 5
+)
+
+// salaryPeriodsPerYear converts salaries to yearly amounts, assuming full time
+// (40 hours a week, 5 days a week)
+var salaryPeriodsPerYear = map[SalaryPeriod]float64{
+	SalaryPerHour:  2080,
+	SalaryPerDay:   260,
+	SalaryPerWeek:  52,
+	SalaryPerMonth: 12,
+	SalaryPerYear:  1,
+}
+
+// ComparedSalary is a salary range per year, rounded to whole units
+type ComparedSalary struct {
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+	Currency string  `json:"currency"`
+}
+
+// YearlySalary returns the salary per year, in the converted currency when the
+// salary was converted. It returns nil for jobs without a salary.
+func YearlySalary(salary *SalaryRange) *ComparedSalary {
+	if salary == nil {
+		return nil
+	}
+
+	min, max, code := salary.Min, salary.Max, salary.Currency
+	if salary.Converted != nil {
+		min, max, code = salary.Converted.Min, salary.Converted.Max, salary.Converted.Currency
+	}
+	factor, ok := salaryPeriodsPerYear[salary.Period]
+	if !ok {
+		factor = 1
+	}
+	return &ComparedSalary{
+		Min:      math.Round(min * factor),
+		Max:      math.Round(max * factor),
+		Currency: code,
+	}
+}
+
+// ComparedJob is one column of a job comparison
+type ComparedJob struct {
+	ID              string          `json:"id"`
+	Title           string          `json:"title"`
+	Company         *CompanyInfo    `json:"company,omitempty"`
+	Location        string          `json:"location,omitempty"`
+	Remote          bool            `json:"remote"`
+	EmploymentType  EmploymentType  `json:"employment_type,omitempty"`
+	ExperienceLevel ExperienceLevel `json:"experience_level,omitempty"`
+	Salary          *ComparedSalary `json:"salary,omitempty"`
+	Skills          []string        `json:"skills"`
+	Deadline        *time.Time      `json:"deadline,omitempty"`
+	// CompanyRating is the company's average overall interview rating (1 to
+	// 5), unset until MinFeedbackResponses applicants rated it
+	CompanyRating *float64 `json:"company_rating,omitempty"`
+	// ResponseTimeDays is how long the company takes on average to move an
+	// application out of Applied, see ResponseTimeWindow
+	ResponseTimeDays *float64 `json:"response_time_days,omitempty"`
+}
+
+// JobComparison compares jobs side by side, in the order they were requested
+type JobComparison struct {
+	Jobs []*ComparedJob `json:"jobs"`
+	// SharedSkills are the skills every compared job requires
+	SharedSkills []string `json:"shared_skills"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// A job is featured for at most MaxFeatureDays at a time, and a company has
+// at most MaxFeaturedJobs featured jobs at once
+const (
+	MaxFeatureDays  = 30
+	MaxFeaturedJobs = 3
+)
+
+// ErrFeaturedJobsLimit is returned when featuring a job would give its company
+// more than MaxFeaturedJobs featured jobs
+var ErrFeaturedJobsLimit = errors.New("company reached its maximum number of featured jobs")
+
+// FeatureJobRequest features a job for a number of days from now, replacing
+// the end of a running feature. A feature can't be extended past
+// MaxFeatureDays from its start.
+type FeatureJobRequest struct {
+	Days int `json:"days" validate:"required,min=1,max=30"`
+}
+
+// Featured reports whether the job is featured at now
+func (j *Job) Featured(now time.Time) bool {
+	return j.FeaturedUntil != nil && now.Before(*j.FeaturedUntil)
+}
+
+// FeatureStart returns when the feature of a job featured at now starts: the
+// start of the running feature, or now. Features older than FeaturedSince are
+// taken to have started MaxFeatureDays before they end.
+func (j *Job) FeatureStart(now time.Time) time.Time {
+	switch {
+	case !j.Featured(now):
+		return now
+	case j.FeaturedSince != nil:
+		return *j.FeaturedSince
+	default:
+		return j.FeaturedUntil.AddDate(0, 0, -MaxFeatureDays)
+	}
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// JobFeedSize is how many of the latest jobs the job feeds list
+const JobFeedSize = 50
+
+// MaxBoardFeedJobs bounds the jobs listed in the XML feed fetched by job boards
+const MaxBoardFeedJobs = 5000
+
+// JobFeedMaxAge is how long clients and proxies may cache a job feed
+const JobFeedMaxAge = 10 * time.Minute
+
+const (
+	// MaxSitemapURLs is the most URLs a sitemap may list, per the sitemaps protocol
+	MaxSitemapURLs = 50000
+	// SitemapRefreshInterval is how often the sitemap is regenerated, it is served from memory in between
+	SitemapRefreshInterval = 30 * time.Minute
+	// maxJobSlugLength bounds the title part of job slugs
+	maxJobSlugLength = 60
+)
+
+// FeedJob is a job listed in a feed, with the company that posted it
+type FeedJob struct {
+	*Job
+	// Company is nil when the company's account no longer exists
+	Company *CompanyInfo
+}
+
+// PostedAt returns when the job was last posted or reposted
+func (j *FeedJob) PostedAt() time.Time {
+	if j.BumpedAt != nil {
+		return *j.BumpedAt
+	}
+	return j.CreatedAt
+}
+
+// CompanyName returns the name of the company, empty when unknown
+func (j *FeedJob) CompanyName() string {
+	if j.Company == nil {
+		return ""
+	}
+	return j.Company.Name
+}
+
+// SitemapJob is the part of a listed job its sitemap entry is built from
+type SitemapJob struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	Title     string             `bson:"title"`
+	UpdatedAt time.Time          `bson:"updated_at"`
+	BumpedAt  *time.Time         `bson:"bumped_at,omitempty"`
+}
+
+// LastModified returns when the job was last edited or reposted
+func (j *SitemapJob) LastModified() time.Time {
+	if j.BumpedAt != nil && j.BumpedAt.After(j.UpdatedAt) {
+		return *j.BumpedAt
+	}
+	return j.UpdatedAt
+}
+
+// SitemapURL is an entry of the sitemap
+type SitemapURL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+// JobSlug returns the readable path segment of a job's page: its title in
+// lower case words followed by its ID, e.g. "senior-go-engineer-64b0c9...".
+// The ID at the end is what identifies the job, titles may change.
+func JobSlug(id primitive.ObjectID, title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+		if b.Len() >= maxJobSlugLength {
+			break
+		}
+	}
+
+	if b.Len() == 0 {
+		return id.Hex()
+	}
+	return b.String() + "-" + id.Hex()
+}
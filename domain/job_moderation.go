@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// JobModerationStatus is where a job stands in the admins' review
+type JobModerationStatus string
+
+const (
+	JobPendingReview JobModerationStatus = "pending_review"
+	JobApproved      JobModerationStatus = "approved"
+	// JobRejected jobs go back to the company as drafts, with the reason
+	JobRejected JobModerationStatus = "rejected"
+)
+
+// JobModeration is recorded on a job submitted for review. In review mode a
+// job goes live only once an admin approved it; after that it can be
+// unpublished and published again without another review.
+type JobModeration struct {
+	Status      JobModerationStatus `bson:"status" json:"status"`
+	SubmittedAt time.Time           `bson:"submitted_at" json:"submitted_at"`
+	ReviewedBy  string              `bson:"reviewed_by,omitempty" json:"-"`
+	ReviewedAt  *time.Time          `bson:"reviewed_at,omitempty" json:"reviewed_at,omitempty"`
+	// Reason is why the job was rejected
+	Reason string `bson:"reason,omitempty" json:"reason,omitempty"`
+}
+
+type RejectJobRequest struct {
+	Reason string `json:"reason" validate:"required,min=10,max=1000"`
+}
+
+// PendingReview reports whether the job waits for an admin's review
+func (j *Job) PendingReview() bool {
+	return j.Moderation != nil && j.Moderation.Status == JobPendingReview
+}
+
+// Approved reports whether an admin approved the job
+func (j *Job) Approved() bool {
+	return j.Moderation != nil && j.Moderation.Status == JobApproved
+}
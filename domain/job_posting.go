@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// schemaOrgContext is the JSON-LD context of schema.org types
+const schemaOrgContext = "https://schema.org"
+
+// JobPosting is the schema.org JobPosting of a published job, the structured
+// data search engines such as Google for Jobs index.
+// See https://developers.google.com/search/docs/appearance/structured-data/job-posting
+type JobPosting struct {
+	Context            string                `json:"@context"`
+	Type               string                `json:"@type"`
+	Title              string                `json:"title"`
+	Description        string                `json:"description"`
+	URL                string                `json:"url,omitempty"`
+	Identifier         *SchemaPropertyValue  `json:"identifier,omitempty"`
+	DatePosted         string                `json:"datePosted"`
+	ValidThrough       string                `json:"validThrough,omitempty"`
+	EmploymentType     string                `json:"employmentType,omitempty"`
+	HiringOrganization *SchemaOrganization   `json:"hiringOrganization,omitempty"`
+	JobLocation        *SchemaPlace          `json:"jobLocation,omitempty"`
+	JobLocationType    string                `json:"jobLocationType,omitempty"`
+	BaseSalary         *SchemaMonetaryAmount `json:"baseSalary,omitempty"`
+	Skills             string                `json:"skills,omitempty"`
+	DirectApply        bool                  `json:"directApply"`
+}
+
+// SchemaPropertyValue is a schema.org PropertyValue
+type SchemaPropertyValue struct {
+	Type  string `json:"@type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// SchemaOrganization is a schema.org Organization
+type SchemaOrganization struct {
+	Type   string `json:"@type"`
+	Name   string `json:"name"`
+	SameAs string `json:"sameAs,omitempty"`
+	Logo   string `json:"logo,omitempty"`
+}
+
+// SchemaPlace is a schema.org Place
+type SchemaPlace struct {
+	Type    string              `json:"@type"`
+	Address SchemaPostalAddress `json:"address"`
+}
+
+// SchemaPostalAddress is a schema.org PostalAddress. Job locations are free
+// text, so the whole location is given as the locality.
+type SchemaPostalAddress struct {
+	Type            string `json:"@type"`
+	AddressLocality string `json:"addressLocality"`
+}
+
+// SchemaMonetaryAmount is a schema.org MonetaryAmount
+type SchemaMonetaryAmount struct {
+	Type     string                  `json:"@type"`
+	Currency string                  `json:"currency"`
+	Value    SchemaQuantitativeValue `json:"value"`
+}
+
+// SchemaQuantitativeValue is a schema.org QuantitativeValue
+type SchemaQuantitativeValue struct {
+	Type     string  `json:"@type"`
+	MinValue float64 `json:"minValue"`
+	MaxValue float64 `json:"maxValue"`
+	UnitText string  `json:"unitText"`
+}
+
+// schemaEmploymentTypes maps employment types to the values Google accepts
+var schemaEmploymentTypes = map[EmploymentType]string{
+	FullTime:   "FULL_TIME",
+	PartTime:   "PART_TIME",
+	Contract:   "CONTRACTOR",
+	Internship: "INTERN",
+	Temporary:  "TEMPORARY",
+}
+
+// schemaSalaryUnits maps salary periods to QuantitativeValue unit texts
+var schemaSalaryUnits = map[SalaryPeriod]string{
+	SalaryPerHour:  "HOUR",
+	SalaryPerDay:   "DAY",
+	SalaryPerWeek:  "WEEK",
+	SalaryPerMonth: "MONTH",
+	SalaryPerYear:  "YEAR",
+}
+
+// NewJobPosting describes a job as a schema.org JobPosting. url is the job's
+// page, the posting is valid until the job's deadline or expiry, whichever
+// comes first.
+func NewJobPosting(job *FeedJob, url string) *JobPosting {
+	posting := &JobPosting{
+		Context:        schemaOrgContext,
+		Type:           "JobPosting",
+		Title:          job.Title,
+		Description:    job.Description,
+		URL:            url,
+		DatePosted:     job.PostedAt().UTC().Format(time.RFC3339),
+		EmploymentType: schemaEmploymentTypes[job.EmploymentType],
+		// Jobs with an external apply link are applied to on the company's site
+		DirectApply: job.ApplyURL == "",
+	}
+
+	if job.Company != nil {
+		posting.HiringOrganization = &SchemaOrganization{
+			Type:   "Organization",
+			Name:   job.Company.Name,
+			SameAs: job.Company.Website,
+			Logo:   job.Company.LogoURL,
+		}
+		posting.Identifier = &SchemaPropertyValue{Type: "PropertyValue", Name: job.Company.Name, Value: job.ID.Hex()}
+	}
+
+	if validThrough := job.ValidThrough(); validThrough != nil {
+		posting.ValidThrough = validThrough.UTC().Format(time.RFC3339)
+	}
+
+	if job.Remote {
+		posting.JobLocationType = "TELECOMMUTE"
+	}
+	if job.Location != "" {
+		posting.JobLocation = &SchemaPlace{
+			Type:    "Place",
+			Address: SchemaPostalAddress{Type: "PostalAddress", AddressLocality: job.Location},
+		}
+	}
+
+	if job.Salary != nil {
+		posting.BaseSalary = &SchemaMonetaryAmount{
+			Type:     "MonetaryAmount",
+			Currency: job.Salary.Currency,
+			Value: SchemaQuantitativeValue{
+				Type:     "QuantitativeValue",
+				MinValue: job.Salary.Min,
+				MaxValue: job.Salary.Max,
+				UnitText: schemaSalaryUnits[job.Salary.Period],
+			},
+		}
+	}
+
+	posting.Skills = strings.Join(job.Skills, ", ")
+
+	return posting
+}
+
+// ValidThrough returns when the job stops being listed: its deadline or
+// expiry, whichever comes first, nil when it has neither
+func (j *Job) ValidThrough() *time.Time {
+	switch {
+	case j.Deadline == nil:
+		return j.ExpiresAt
+	case j.ExpiresAt == nil || j.Deadline.Before(*j.ExpiresAt):
+		return j.Deadline
+	default:
+		return j.ExpiresAt
+	}
+}
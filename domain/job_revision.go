@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// JobRevision records an edit of a job: who made it, when, and the value of
+// every changed field before and after, so accidental edits can be reverted
+type JobRevision struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	JobID    string             `bson:"job_id" json:"job_id"`
+	EditedBy string             `bson:"edited_by" json:"edited_by"`
+	EditedAt time.Time          `bson:"edited_at" json:"edited_at"`
+	// Changes is empty when the edit didn't change anything
+	Changes []JobFieldChange `bson:"changes" json:"changes"`
+}
+
+// JobFieldChange is a field of a job changed by an edit. Before and After
+// hold the field's JSON value, as in job responses.
+type JobFieldChange struct {
+	Field  string          `bson:"field" json:"field"`
+	Before json.RawMessage `bson:"before" json:"before"`
+	After  json.RawMessage `bson:"after" json:"after"`
+}
+
+// jobField is a job field and its value
+type jobField struct {
+	name  string
+	value interface{}
+}
+
+// editableFields returns the fields of the job an update can change, by
+// their JSON name
+func (j *Job) editableFields() []jobField {
+	return []jobField{
+		{"title", j.Title},
+		{"description", j.Description},
+		{"location", j.Location},
+		{"employment_type", j.EmploymentType},
+		{"category", j.Category},
+		{"experience_level", j.ExperienceLevel},
+		{"remote", j.Remote},
+		{"salary", j.Salary},
+		{"skills", j.Skills},
+		{"is_published", j.IsPublished},
+		{"blind_screening", j.BlindScreening},
+		{"apply_url", j.ApplyURL},
+		{"max_applications", j.MaxApplications},
+		{"deadline", j.Deadline},
+		{"publish_at", j.PublishAt},
+		{"expires_at", j.ExpiresAt},
+	}
+}
+
+// Changes lists the editable fields whose value differs in updated
+func (j *Job) Changes(updated *Job) []JobFieldChange {
+	changes := []JobFieldChange{}
+	after := updated.editableFields()
+	for i, field := range j.editableFields() {
+		before, _ := json.Marshal(field.value)
+		now, _ := json.Marshal(after[i].value)
+		if !bytes.Equal(before, now) {
+			changes = append(changes, JobFieldChange{Field: field.name, Before: before, After: now})
+		}
+	}
+	return changes
+}
+
+type JobRevisionListResponse struct {
+	Success    bool        `json:"success"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data,omitempty"`
+	PageNumber int         `json:"page_number"`
+	PageSize   int         `json:"page_size"`
+	TotalItems int64       `json:"total_items"`
+	TotalPages int         `json:"total_pages"`
+	Errors     []string    `json:"errors,omitempty"`
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrJobTemplateNotFound = errors.New("job template not found")
+
+// MaxJobTemplates bounds the templates a company can keep
+const MaxJobTemplates = 50
+
+// JobTemplate is a reusable posting a company creates jobs from. It holds a
+// job's content, without its dates or publication state.
+type JobTemplate struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	CompanyID       string             `bson:"company_id" json:"company_id"`
+	Name            string             `bson:"name" json:"name"`
+	Title           string             `bson:"title" json:"title"`
+	Description     string             `bson:"description" json:"description"`
+	Location        string             `bson:"location,omitempty" json:"location,omitempty"`
+	EmploymentType  EmploymentType     `bson:"employment_type,omitempty" json:"employment_type,omitempty"`
+	Category        string             `bson:"category,omitempty" json:"category,omitempty"`
+	ExperienceLevel ExperienceLevel    `bson:"experience_level,omitempty" json:"experience_level,omitempty"`
+	Remote          bool               `bson:"remote" json:"remote"`
+	Salary          *SalaryRange       `bson:"salary,omitempty" json:"salary,omitempty"`
+	Skills          []string           `bson:"skills,omitempty" json:"skills,omitempty"`
+	BlindScreening  bool               `bson:"blind_screening,omitempty" json:"blind_screening"`
+	// CreatedBy is the user who saved the template
+	CreatedBy string    `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// NewJobTemplate returns a template with the content of the job
+func NewJobTemplate(name string, job *Job) *JobTemplate {
+	content := job.Clone()
+	return &JobTemplate{
+		CompanyID:       job.CreatedBy,
+		Name:            name,
+		Title:           content.Title,
+		Description:     content.Description,
+		Location:        content.Location,
+		EmploymentType:  content.EmploymentType,
+		Category:        content.Category,
+		ExperienceLevel: content.ExperienceLevel,
+		Remote:          content.Remote,
+		Salary:          content.Salary,
+		Skills:          content.Skills,
+		BlindScreening:  content.BlindScreening,
+	}
+}
+
+// Draft returns a new unpublished job with the template's content
+func (t *JobTemplate) Draft() *Job {
+	return (&Job{
+		Title:           t.Title,
+		Description:     t.Description,
+		Location:        t.Location,
+		EmploymentType:  t.EmploymentType,
+		Category:        t.Category,
+		ExperienceLevel: t.ExperienceLevel,
+		Remote:          t.Remote,
+		Salary:          t.Salary,
+		Skills:          t.Skills,
+		BlindScreening:  t.BlindScreening,
+		CreatedBy:       t.CompanyID,
+	}).Clone()
+}
+
+// CreateJobTemplateRequest saves either an existing posting, by JobID, or
+// the content given with the request as a template
+type CreateJobTemplateRequest struct {
+	Name            string          `json:"name" validate:"required,min=1,max=100"`
+	JobID           string          `json:"job_id,omitempty"`
+	Title           string          `json:"title,omitempty" validate:"required_without=JobID,max=100"`
+	Description     string          `json:"description,omitempty" validate:"required_without=JobID,max=2000"`
+	Location        string          `json:"location,omitempty" validate:"omitempty,max=100"`
+	EmploymentType  EmploymentType  `json:"employment_type,omitempty" validate:"omitempty,oneof=full_time part_time contract internship temporary"`
+	Category        string          `json:"category,omitempty" validate:"omitempty,max=50"`
+	ExperienceLevel ExperienceLevel `json:"experience_level,omitempty" validate:"omitempty,oneof=entry junior mid senior lead"`
+	Remote          bool            `json:"remote,omitempty"`
+	Salary          *SalaryRange    `json:"salary,omitempty"`
+	Skills          []string        `json:"skills,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
+	BlindScreening  bool            `json:"blind_screening,omitempty"`
+}
+
+type JobTemplateResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+	// URL is the canonical location of a newly created resource
+	URL string `json:"url,omitempty"`
+}
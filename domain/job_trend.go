@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The views and applications of the last TrendingWindow rank trending jobs.
+// An application weighs as much as TrendingApplicationWeight views.
+const (
+	TrendingWindow            = 7 * 24 * time.Hour
+	TrendingApplicationWeight = 10
+)
+
+// TrendingRefreshInterval is how often the rankings are recomputed. They
+// cover the TrendingCandidates most viewed and most applied jobs.
+const (
+	TrendingRefreshInterval = 15 * time.Minute
+	TrendingCandidates      = 200
+)
+
+// Trending lists up to MaxTrendingJobs jobs, DefaultTrendingJobs by default
+const (
+	DefaultTrendingJobs = 10
+	MaxTrendingJobs     = 50
+)
+
+// JobViewRetention is how long daily job view counts are kept
+const JobViewRetention = 30 * 24 * time.Hour
+
+// Trending job rankings
+const (
+	// TrendingByScore ranks by views and applications
+	TrendingByScore = "trending"
+	// TrendingByApplications ranks by applications only (most applied)
+	TrendingByApplications = "applications"
+)
+
+// JobViews counts the views of a job's page during a day (UTC)
+type JobViews struct {
+	JobID primitive.ObjectID `bson:"job_id" json:"job_id"`
+	Day   time.Time          `bson:"day" json:"day"`
+	Views int64              `bson:"views" json:"views"`
+}
+
+// JobActivity counts a job's recent views or applications
+type JobActivity struct {
+	JobID primitive.ObjectID `bson:"_id"`
+	Count int64              `bson:"count"`
+}
+
+// TrendingJob is a job with the activity it was ranked by
+type TrendingJob struct {
+	*Job
+	RecentViews        int64 `json:"recent_views"`
+	RecentApplications int64 `json:"recent_applications"`
+}
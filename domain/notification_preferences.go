@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NotificationKind identifies an optional email that users can turn off.
+// Account and security emails (password resets, sign-in alerts) are always sent.
+type NotificationKind string
+
+const (
+	// NotificationStatusChange tells an applicant their application moved to another stage
+	NotificationStatusChange NotificationKind = "status_change"
+	// NotificationNewApplicant tells a company someone applied to one of its jobs
+	NotificationNewApplicant NotificationKind = "new_applicant"
+	// NotificationFollowedCompanyJob tells a follower the company published a
+	// job. Unfollowing the company is how it is turned off.
+	NotificationFollowedCompanyJob NotificationKind = "followed_company_job"
+	// NotificationMention tells a team member they were mentioned in an application note
+	NotificationMention NotificationKind = "mention"
+	// NotificationInterviewFeedback asks an applicant for feedback on an interview
+	NotificationInterviewFeedback NotificationKind = "interview_feedback"
+	// NotificationSavedSearch tells an applicant new jobs match a saved search.
+	// Turning the search's alerts off is how it is turned off.
+	NotificationSavedSearch NotificationKind = "saved_search"
+	// NotificationHiringReminder asks a company to confirm it is still hiring
+	// for a job before the job loses its actively hiring signal
+	NotificationHiringReminder NotificationKind = "hiring_reminder"
+	// NotificationSkillsGap sends a rejected applicant the skills they lacked for
+	// the job and open jobs matching their profile. It is off by default.
+	NotificationSkillsGap NotificationKind = "skills_gap"
+)
+
+// DigestFrequency is how often optional notifications are delivered. With
+// DigestNone they are sent one by one as they happen, otherwise they are
+// batched into a single digest email.
+type DigestFrequency string
+
+const (
+	DigestNone   DigestFrequency = "none"
+	DigestDaily  DigestFrequency = "daily"
+	DigestWeekly DigestFrequency = "weekly"
+)
+
+// Interval is how long the digest waits between emails
+func (f DigestFrequency) Interval() time.Duration {
+	switch f {
+	case DigestDaily:
+		return 24 * time.Hour
+	case DigestWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// NotificationPreferences holds which optional emails a user receives
+type NotificationPreferences struct {
+	UserID              string          `bson:"user_id" json:"user_id"`
+	EmailOnStatusChange bool            `bson:"email_on_status_change" json:"email_on_status_change"`
+	NewApplicantAlerts  bool            `bson:"new_applicant_alerts" json:"new_applicant_alerts"`
+	SkillsGapReports    bool            `bson:"skills_gap_reports" json:"skills_gap_reports"`
+	DigestFrequency     DigestFrequency `bson:"digest_frequency" json:"digest_frequency"`
+	LastDigestAt        *time.Time      `bson:"last_digest_at,omitempty" json:"last_digest_at,omitempty"`
+	UpdatedAt           time.Time       `bson:"updated_at" json:"updated_at"`
+}
+
+// DefaultNotificationPreferences are used until the user saves their own:
+// every notification but the skills gap reports is on and sent as it happens
+func DefaultNotificationPreferences(userID string) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:              userID,
+		EmailOnStatusChange: true,
+		NewApplicantAlerts:  true,
+		DigestFrequency:     DigestNone,
+	}
+}
+
+// Allows reports whether the user wants notifications of the given kind
+func (p *NotificationPreferences) Allows(kind NotificationKind) bool {
+	switch kind {
+	case NotificationStatusChange:
+		return p.EmailOnStatusChange
+	case NotificationNewApplicant:
+		return p.NewApplicantAlerts
+	case NotificationSkillsGap:
+		return p.SkillsGapReports
+	default:
+		return true
+	}
+}
+
+// Digested reports whether notifications are batched instead of sent right away
+func (p *NotificationPreferences) Digested() bool {
+	return p.DigestFrequency.Interval() > 0
+}
+
+// DigestDue reports whether the next digest should go out
+func (p *NotificationPreferences) DigestDue(now time.Time) bool {
+	if !p.Digested() {
+		return true
+	}
+	return p.LastDigestAt == nil || !now.Before(p.LastDigestAt.Add(p.DigestFrequency.Interval()))
+}
+
+// Apply copies the fields set in the request
+func (p *NotificationPreferences) Apply(req *UpdateNotificationPreferencesRequest) {
+	if req.EmailOnStatusChange != nil {
+		p.EmailOnStatusChange = *req.EmailOnStatusChange
+	}
+	if req.NewApplicantAlerts != nil {
+		p.NewApplicantAlerts = *req.NewApplicantAlerts
+	}
+	if req.SkillsGapReports != nil {
+		p.SkillsGapReports = *req.SkillsGapReports
+	}
+	if req.DigestFrequency != "" {
+		p.DigestFrequency = req.DigestFrequency
+	}
+}
+
+// PendingNotification is a notification waiting for the user's next digest, or
+// one whose delivery failed
+type PendingNotification struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    string             `bson:"user_id" json:"user_id"`
+	Kind      NotificationKind   `bson:"kind" json:"kind"`
+	Subject   string             `bson:"subject" json:"subject"`
+	Body      string             `bson:"body" json:"body"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	// Failed is set on notifications that couldn't be emailed. They are kept
+	// out of digests until an operator requeues them.
+	Failed bool `bson:"failed,omitempty" json:"failed,omitempty"`
+}
+
+// UpdateNotificationPreferencesRequest changes the fields that are set and
+// leaves the others as they are
+type UpdateNotificationPreferencesRequest struct {
+	EmailOnStatusChange *bool           `json:"email_on_status_change"`
+	NewApplicantAlerts  *bool           `json:"new_applicant_alerts"`
+	SkillsGapReports    *bool           `json:"skills_gap_reports"`
+	DigestFrequency     DigestFrequency `json:"digest_frequency" validate:"omitempty,oneof=none daily weekly"`
+}
+
+type NotificationPreferencesResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
@@ -0,0 +1,81 @@
+package domain
+
+// ContactVisibility controls when companies see an applicant's email and phone
+type ContactVisibility string
+
+const (
+	// ContactAlways shares the contact details with every company applied to
+	ContactAlways ContactVisibility = "always"
+	// ContactFromInterview shares them once the application reaches the Interview stage
+	ContactFromInterview ContactVisibility = "interview"
+)
+
+// PrivacySettings are an applicant's profile visibility choices. The zero value
+// keeps the behaviour from before the settings existed: contact details shown.
+type PrivacySettings struct {
+	ContactVisibility ContactVisibility `bson:"contact_visibility,omitempty" json:"contact_visibility"`
+}
+
+// WithDefaults fills in the settings left empty
+func (p PrivacySettings) WithDefaults() PrivacySettings {
+	if p.ContactVisibility == "" {
+		p.ContactVisibility = ContactAlways
+	}
+	return p
+}
+
+// ContactVisibleAt reports whether a company sees the contact details on an
+// application with the given status
+func (p PrivacySettings) ContactVisibleAt(status ApplicationStatus) bool {
+	if p.ContactVisibility != ContactFromInterview {
+		return true
+	}
+	return ReachedInterview(status)
+}
+
+// ReachedInterview reports whether an application with the given status made
+// it to the Interview stage, from which the applicant's identity is shared
+func ReachedInterview(status ApplicationStatus) bool {
+	return status == StatusInterview || status == StatusHired
+}
+
+// BlindApplicantName replaces the applicant's name on blind screening jobs
+const BlindApplicantName = "Anonymous candidate"
+
+type UpdatePrivacyRequest struct {
+	ContactVisibility *ContactVisibility `json:"contact_visibility,omitempty" validate:"omitempty,oneof=always interview"`
+}
+
+// ApplicantSummary is the applicant data shown to a company on an application
+type ApplicantSummary struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+	Phone string `json:"phone,omitempty"`
+	// AvatarURL is left out on blind screening jobs
+	AvatarURL string `json:"avatar_url,omitempty"`
+	// ContactHidden tells the company the contact details are withheld until the interview stage
+	ContactHidden bool `json:"contact_hidden,omitempty"`
+	// Blinded tells the company the job uses blind screening and the applicant's
+	// identity is withheld until the interview stage
+	Blinded bool `json:"blinded,omitempty"`
+}
+
+// SummaryForCompany maps an applicant to the data a company may see on an
+// application with the given status. Every response exposing applicant data
+// to companies must go through it so the privacy settings apply. blind is set
+// for jobs using blind screening.
+func (u *User) SummaryForCompany(status ApplicationStatus, blind bool) *ApplicantSummary {
+	if blind && !ReachedInterview(status) {
+		return &ApplicantSummary{ID: u.ID.Hex(), Name: BlindApplicantName, ContactHidden: true, Blinded: true}
+	}
+
+	summary := &ApplicantSummary{ID: u.ID.Hex(), Name: u.Name, AvatarURL: u.AvatarURL}
+	if u.Privacy.ContactVisibleAt(status) {
+		summary.Email = u.Email
+		summary.Phone = u.Phone
+	} else {
+		summary.ContactHidden = true
+	}
+	return summary
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrResumeNotFound = errors.New("resume not found")
+)
+
+const (
+	// MaxResumes is the number of resumes an applicant can keep in their library
+	MaxResumes = 10
+	// MaxResumeBytes is the largest resume file accepted
+	MaxResumeBytes = 10 << 20
+)
+
+// Resume is a file in an applicant's resume library. Applicants pick one by
+// ID when applying instead of uploading the file again.
+type Resume struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID      string             `bson:"user_id" json:"user_id"`
+	Name        string             `bson:"name" json:"name"`
+	URL         string             `bson:"url" json:"url"`
+	ContentType string             `bson:"content_type" json:"content_type"`
+	Size        int64              `bson:"size" json:"size"`
+	UploadedAt  time.Time          `bson:"uploaded_at" json:"uploaded_at"`
+}
+
+type ResumeResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+	// URL is the canonical location of a newly created resource
+	URL string `json:"url,omitempty"`
+}
@@ -0,0 +1,67 @@
+package domain
+
+import "time"
+
+// RetentionRule says how long documents of a collection are kept, counted from
+// a date field. MongoDB's TTL monitor enforces the rules in the background;
+// they are listed here so operators can purge on demand, e.g. after restoring
+// a backup or when the monitor fell behind.
+type RetentionRule struct {
+	Collection string
+	Field      string
+	// MaxAge is how long after Field a document expires, zero for fields
+	// that hold the expiry time itself
+	MaxAge time.Duration
+}
+
+// RetentionRules lists the collections whose documents expire
+var RetentionRules = []RetentionRule{
+	{Collection: "auth_events", Field: "created_at", MaxAge: AuthEventRetention},
+	{Collection: "security_alerts", Field: "created_at", MaxAge: AuthEventRetention},
+	{Collection: "job_tombstones", Field: "deleted_at", MaxAge: JobTombstoneRetention},
+	{Collection: "auth_tokens", Field: "expires_at"},
+	{Collection: "revoked_tokens", Field: "expires_at"},
+}
+
+// PurgeResult is the outcome of applying a retention rule
+type PurgeResult struct {
+	Collection string    `json:"collection"`
+	Cutoff     time.Time `json:"cutoff"`
+	// Expired counts the documents past the cutoff, they were deleted unless
+	// the purge was a dry run
+	Expired int64 `json:"expired"`
+}
+
+// PurgeResponse reports a retention purge. When DryRun is set nothing was
+// deleted and each result counts the documents that would have been.
+type PurgeResponse struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	DryRun  bool           `json:"dry_run"`
+	Data    []*PurgeResult `json:"data,omitempty"`
+	Errors  []string       `json:"errors,omitempty"`
+}
+
+// UserDataReport gathers what the portal stores about a user, for operators
+// answering support or data access requests
+type UserDataReport struct {
+	User                    *User                    `json:"user"`
+	NotificationPreferences *NotificationPreferences `json:"notification_preferences,omitempty"`
+	// Applicant data
+	Profile          *ApplicantProfile `json:"profile,omitempty"`
+	Resumes          []*Resume         `json:"resumes,omitempty"`
+	AlertPreferences *AlertPreferences `json:"alert_preferences,omitempty"`
+	Applications     []*Application    `json:"applications,omitempty"`
+	ApplicationCount int64             `json:"application_count,omitempty"`
+	// Company data
+	CompanyProfile *CompanyProfile `json:"company_profile,omitempty"`
+	Jobs           []*Job          `json:"jobs,omitempty"`
+	JobCount       int64           `json:"job_count,omitempty"`
+}
+
+// CreateAdminRequest creates an admin account from the command line
+type CreateAdminRequest struct {
+	Name     string `validate:"required,min=2,max=100"`
+	Email    string `validate:"required,email"`
+	Password string `validate:"required,min=8,containsany=!@#$%^&*,containsany=0123456789,containsany=ABCDEFGHIJKLMNOPQRSTUVWXYZ,containsany=abcdefghijklmnopqrstuvwxyz"`
+}
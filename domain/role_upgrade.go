@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrRoleUpgradeNotFound = errors.New("role upgrade request not found")
+	// ErrRoleUpgradeReviewed is returned when reviewing a request that was already approved or rejected
+	ErrRoleUpgradeReviewed = errors.New("role upgrade request already reviewed")
+	// ErrRoleUpgradePending is returned when the user already has a request waiting for review
+	ErrRoleUpgradePending = errors.New("role upgrade request already pending")
+)
+
+type RoleUpgradeStatus string
+
+const (
+	RoleUpgradePending  RoleUpgradeStatus = "pending"
+	RoleUpgradeApproved RoleUpgradeStatus = "approved"
+	RoleUpgradeRejected RoleUpgradeStatus = "rejected"
+)
+
+// RoleUpgrade is an applicant's request to become a company account. It is
+// reviewed by an admin; once approved the user's tokens are refreshed with
+// POST /api/v1/auth/refresh to pick up the new role.
+type RoleUpgrade struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID      string             `bson:"user_id" json:"user_id"`
+	FromRole    Role               `bson:"from_role" json:"from_role"`
+	ToRole      Role               `bson:"to_role" json:"to_role"`
+	CompanyName string             `bson:"company_name" json:"company_name"`
+	Reason      string             `bson:"reason" json:"reason"`
+	Status      RoleUpgradeStatus  `bson:"status" json:"status"`
+	ReviewerID  string             `bson:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+	ReviewNote  string             `bson:"review_note,omitempty" json:"review_note,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	ReviewedAt  *time.Time         `bson:"reviewed_at,omitempty" json:"reviewed_at,omitempty"`
+}
+
+type CreateRoleUpgradeRequest struct {
+	CompanyName string `json:"company_name" validate:"required,min=2,max=100"`
+	Reason      string `json:"reason" validate:"required,min=10,max=1000"`
+}
+
+type ReviewRoleUpgradeRequest struct {
+	Note string `json:"note,omitempty" validate:"max=500"`
+}
+
+type RoleUpgradeResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+	// URL is the canonical location of a newly created resource
+	URL string `json:"url,omitempty"`
+}
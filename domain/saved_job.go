@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrSavedJobNotFound = errors.New("saved job not found")
+
+// SavedJob records an applicant bookmarking a job
+type SavedJob struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    string             `bson:"user_id" json:"user_id"`
+	JobID     string             `bson:"job_id" json:"job_id"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// SavedJobEntry is an entry of the applicant's saved jobs list
+type SavedJobEntry struct {
+	Job     *Job      `json:"job"`
+	SavedAt time.Time `json:"saved_at"`
+}
+
+type SavedJobResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
+
+type SavedJobListResponse struct {
+	Success    bool        `json:"success"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data,omitempty"`
+	PageNumber int         `json:"page_number"`
+	PageSize   int         `json:"page_size"`
+	TotalItems int64       `json:"total_items"`
+	TotalPages int         `json:"total_pages"`
+	Errors     []string    `json:"errors,omitempty"`
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrSavedSearchNotFound = errors.New("saved search not found")
+
+const (
+	// MaxSavedSearches bounds the searches an applicant can save
+	MaxSavedSearches = 20
+	// SavedSearchAlertInterval is how often a saved search is run for alerts
+	SavedSearchAlertInterval = time.Hour
+	// SavedSearchAlertJobs bounds the new jobs listed in one alert
+	SavedSearchAlertJobs = 5
+)
+
+// SavedSearch is a job search an applicant saved. With alerts on it is run
+// against the jobs posted since it last ran, and matches are notified.
+type SavedSearch struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID string             `bson:"user_id" json:"user_id"`
+	Name   string             `bson:"name" json:"name"`
+
+	Query           string          `bson:"query,omitempty" json:"query,omitempty"`
+	Location        string          `bson:"location,omitempty" json:"location,omitempty"`
+	Category        string          `bson:"category,omitempty" json:"category,omitempty"`
+	EmploymentType  EmploymentType  `bson:"employment_type,omitempty" json:"employment_type,omitempty"`
+	ExperienceLevel ExperienceLevel `bson:"experience_level,omitempty" json:"experience_level,omitempty"`
+	Remote          *bool           `bson:"remote,omitempty" json:"remote,omitempty"`
+	Skills          []string        `bson:"skills,omitempty" json:"skills,omitempty"`
+	// SalaryMin and SalaryMax are amounts in Currency
+	SalaryMin *float64 `bson:"salary_min,omitempty" json:"salary_min,omitempty"`
+	SalaryMax *float64 `bson:"salary_max,omitempty" json:"salary_max,omitempty"`
+	Currency  string   `bson:"currency,omitempty" json:"currency,omitempty"`
+
+	Alerts bool `bson:"alerts" json:"alerts"`
+	// LastCheckedAt is when the search last ran for alerts, jobs posted
+	// since then are new to it
+	LastCheckedAt time.Time `bson:"last_checked_at" json:"last_checked_at"`
+	CreatedAt     time.Time `bson:"created_at" json:"created_at"`
+}
+
+// Filter returns the job listing filter the search runs
+func (s *SavedSearch) Filter() JobFilter {
+	return JobFilter{
+		Query:           s.Query,
+		Location:        s.Location,
+		Category:        s.Category,
+		EmploymentType:  s.EmploymentType,
+		ExperienceLevel: s.ExperienceLevel,
+		Remote:          s.Remote,
+		Skills:          s.Skills,
+		SalaryMin:       s.SalaryMin,
+		SalaryMax:       s.SalaryMax,
+		Currency:        s.Currency,
+	}
+}
+
+// SaveSearchRequest saves a job search. Alerts are on unless turned off.
+type SaveSearchRequest struct {
+	Name            string          `json:"name" validate:"required,max=100"`
+	Query           string          `json:"query" validate:"max=200"`
+	Location        string          `json:"location" validate:"max=100"`
+	Category        string          `json:"category" validate:"max=100"`
+	EmploymentType  EmploymentType  `json:"employment_type" validate:"omitempty,oneof=full_time part_time contract internship temporary"`
+	ExperienceLevel ExperienceLevel `json:"experience_level" validate:"omitempty,oneof=entry junior mid senior lead"`
+	Remote          *bool           `json:"remote"`
+	Skills          []string        `json:"skills" validate:"max=20,dive,required,max=50"`
+	SalaryMin       *float64        `json:"salary_min" validate:"omitempty,gte=0"`
+	SalaryMax       *float64        `json:"salary_max" validate:"omitempty,gte=0"`
+	// Currency is required with salary bounds
+	Currency string `json:"currency" validate:"required_with=SalaryMin SalaryMax,omitempty,iso4217"`
+	Alerts   *bool  `json:"alerts"`
+}
+
+type SavedSearchResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+	// URL is the canonical location of a newly created resource
+	URL string `json:"url,omitempty"`
+}
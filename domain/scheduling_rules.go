@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrSchedulingRulesNotFound = errors.New("scheduling rules not found")
+
+// SchedulingWarningCode tells why an interview slot is unusual. Warnings don't
+// prevent scheduling, the company may have agreed on the slot with the applicant.
+type SchedulingWarningCode string
+
+const (
+	WarningPublicHoliday      SchedulingWarningCode = "public_holiday"
+	WarningNonWorkingDay      SchedulingWarningCode = "non_working_day"
+	WarningOutsideWorkingHour SchedulingWarningCode = "outside_working_hours"
+)
+
+// SchedulingWarning flags an interview slot falling outside the company's
+// working time
+type SchedulingWarning struct {
+	Code    SchedulingWarningCode `json:"code"`
+	Message string                `json:"message"`
+}
+
+// SchedulingRules are a company's working time, which interview slots are
+// checked against
+type SchedulingRules struct {
+	CompanyID string `bson:"company_id" json:"company_id"`
+	// TimeZone is the IANA time zone of the working hours and holidays
+	TimeZone string `bson:"time_zone" json:"time_zone"`
+	// WorkingDays lists the days interviews are held, 0 is Sunday
+	WorkingDays []time.Weekday `bson:"working_days" json:"working_days"`
+	// WorkdayStart and WorkdayEnd bound the interview times, as HH:MM
+	WorkdayStart string `bson:"workday_start" json:"workday_start"`
+	WorkdayEnd   string `bson:"workday_end" json:"workday_end"`
+	// HolidayCountries lists the ISO 3166-1 alpha-2 countries whose public
+	// holidays interviews avoid
+	HolidayCountries []string  `bson:"holiday_countries" json:"holiday_countries"`
+	UpdatedAt        time.Time `bson:"updated_at" json:"updated_at,omitempty"`
+}
+
+// DefaultSchedulingRules are the rules of companies that didn't save their
+// own: 09:00 to 17:00 UTC, Monday to Friday, avoiding the holidays of countries
+func DefaultSchedulingRules(companyID string, countries []string) *SchedulingRules {
+	if countries == nil {
+		countries = []string{}
+	}
+	return &SchedulingRules{
+		CompanyID:        companyID,
+		TimeZone:         "UTC",
+		WorkingDays:      []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		WorkdayStart:     "09:00",
+		WorkdayEnd:       "17:00",
+		HolidayCountries: countries,
+	}
+}
+
+// Location returns the rules' time zone, UTC when it can't be loaded
+func (r *SchedulingRules) Location() *time.Location {
+	loc, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// WorkingTimeWarnings checks that at falls on a working day, within the working hours
+func (r *SchedulingRules) WorkingTimeWarnings(at time.Time) []SchedulingWarning {
+	local := at.In(r.Location())
+
+	var warnings []SchedulingWarning
+	working := false
+	for _, day := range r.WorkingDays {
+		if day == local.Weekday() {
+			working = true
+			break
+		}
+	}
+	if !working {
+		warnings = append(warnings, SchedulingWarning{
+			Code:    WarningNonWorkingDay,
+			Message: fmt.Sprintf("%s is not a working day", local.Weekday()),
+		})
+	}
+
+	clock := local.Format("15:04")
+	if clock < r.WorkdayStart || clock >= r.WorkdayEnd {
+		warnings = append(warnings, SchedulingWarning{
+			Code:    WarningOutsideWorkingHour,
+			Message: fmt.Sprintf("%s is outside working hours (%s to %s %s)", clock, r.WorkdayStart, r.WorkdayEnd, r.TimeZone),
+		})
+	}
+	return warnings
+}
+
+// SaveSchedulingRulesRequest replaces the company's scheduling rules
+type SaveSchedulingRulesRequest struct {
+	TimeZone         string         `json:"time_zone" validate:"required,timezone"`
+	WorkingDays      []time.Weekday `json:"working_days" validate:"required,min=1,max=7,unique,dive,min=0,max=6"`
+	WorkdayStart     string         `json:"workday_start" validate:"required,datetime=15:04"`
+	WorkdayEnd       string         `json:"workday_end" validate:"required,datetime=15:04"`
+	HolidayCountries []string       `json:"holiday_countries" validate:"max=10,unique,dive,iso3166_1_alpha2"`
+}
+
+// SchedulingCheck is the outcome of checking an interview slot
+type SchedulingCheck struct {
+	ScheduledAt time.Time           `json:"scheduled_at"`
+	Warnings    []SchedulingWarning `json:"warnings"`
+}
+
+type SchedulingRulesResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
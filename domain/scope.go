@@ -0,0 +1,24 @@
+package domain
+
+// Scopes grant access to groups of routes. Access tokens carry the scopes of the
+// user's role and API keys the scopes chosen when the key was created.
+const (
+	ScopeJobsRead          = "jobs:read"
+	ScopeJobsWrite         = "jobs:write"
+	ScopeApplicationsRead  = "applications:read"
+	ScopeApplicationsWrite = "applications:write"
+	ScopeUsersManage       = "users:manage"
+)
+
+// roleScopes lists the scopes granted to each role's access tokens
+var roleScopes = map[Role][]string{
+	Applicant: {ScopeJobsRead, ScopeApplicationsRead, ScopeApplicationsWrite},
+	Company:   {ScopeJobsRead, ScopeJobsWrite, ScopeApplicationsRead, ScopeApplicationsWrite},
+	Auditor:   {ScopeJobsRead, ScopeApplicationsRead},
+	Admin:     {ScopeJobsRead, ScopeApplicationsRead, ScopeUsersManage},
+}
+
+// ScopesForRole returns the scopes granted to the role's access tokens
+func ScopesForRole(role Role) []string {
+	return append([]string(nil), roleScopes[role]...)
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SecurityAlertKind string
+
+const (
+	// AlertFailureBurst is raised when one IP fails many logins
+	AlertFailureBurst SecurityAlertKind = "failure_burst"
+	// AlertCredentialStuffing is raised when one IP fails logins on many different accounts
+	AlertCredentialStuffing SecurityAlertKind = "credential_stuffing"
+	// AlertAccountTargeted is raised when one account fails many logins
+	AlertAccountTargeted SecurityAlertKind = "account_targeted"
+)
+
+// Anomaly detection thresholds. Failed logins are counted over
+// SecurityDetectionWindow; an alert for the same kind and subject isn't raised
+// again within the window.
+const (
+	SecurityDetectionWindow    = 10 * time.Minute
+	FailureBurstThreshold      = 30
+	CredentialStuffingAccounts = 10
+	AccountTargetedThreshold   = 10
+)
+
+// SecurityAlert records an anomaly detected in the authentication failures
+type SecurityAlert struct {
+	ID   primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Kind SecurityAlertKind  `bson:"kind" json:"kind"`
+	// Subject is the IP address or the account (user ID, or email when unknown) the alert is about
+	Subject     string    `bson:"subject" json:"subject"`
+	Failures    int64     `bson:"failures" json:"failures"`
+	Accounts    int64     `bson:"accounts,omitempty" json:"accounts,omitempty"`
+	IPs         int64     `bson:"ips,omitempty" json:"ips,omitempty"`
+	WindowStart time.Time `bson:"window_start" json:"window_start"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+}
+
+// IPFailures aggregates the failed logins coming from one IP address
+type IPFailures struct {
+	IP       string    `bson:"_id" json:"ip"`
+	Failures int64     `bson:"failures" json:"failures"`
+	Accounts int64     `bson:"accounts" json:"accounts"`
+	LastSeen time.Time `bson:"last_seen" json:"last_seen"`
+}
+
+// AccountFailures aggregates the failed logins on one account. Attempts on
+// unknown emails have no UserID and are grouped by Email.
+type AccountFailures struct {
+	UserID   string    `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	Email    string    `bson:"email,omitempty" json:"email,omitempty"`
+	Failures int64     `bson:"failures" json:"failures"`
+	IPs      int64     `bson:"ips" json:"ips"`
+	LastSeen time.Time `bson:"last_seen" json:"last_seen"`
+}
+
+// Account returns the user ID, or the attempted email for unknown accounts
+func (a *AccountFailures) Account() string {
+	if a.UserID != "" {
+		return a.UserID
+	}
+	return a.Email
+}
+
+// SecurityDashboard summarizes authentication activity since a point in time
+type SecurityDashboard struct {
+	Since          time.Time               `json:"since"`
+	EventCounts    map[AuthEventType]int64 `json:"event_counts"`
+	FailureReasons map[string]int64        `json:"failure_reasons"`
+	TopIPs         []*IPFailures           `json:"top_ips"`
+	TopAccounts    []*AccountFailures      `json:"top_accounts"`
+	Alerts         []*SecurityAlert        `json:"alerts"`
+}
+
+type SecurityDashboardResponse struct {
+	Success bool               `json:"success"`
+	Message string             `json:"message"`
+	Data    *SecurityDashboard `json:"data,omitempty"`
+	Errors  []string           `json:"errors,omitempty"`
+}
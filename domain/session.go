@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SessionLimitMode decides what happens when a user signs in with every
+// allowed session already in use
+type SessionLimitMode string
+
+const (
+	// SessionLimitReject refuses the new sign in
+	SessionLimitReject SessionLimitMode = "reject"
+	// SessionLimitRevokeOldest signs out the oldest session to make room
+	SessionLimitRevokeOldest SessionLimitMode = "revoke_oldest"
+)
+
+// Session is an access token issued by a sign in, along with the device
+// it was issued to
+type Session struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID string             `bson:"user_id" json:"user_id"`
+	// TokenID is the JTI of the access token
+	TokenID   string    `bson:"token_id" json:"-"`
+	IP        string    `bson:"ip,omitempty" json:"ip,omitempty"`
+	UserAgent string    `bson:"user_agent,omitempty" json:"user_agent,omitempty"`
+	Country   string    `bson:"country,omitempty" json:"country,omitempty"`
+	ExpiresAt time.Time `bson:"expires_at" json:"expires_at"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
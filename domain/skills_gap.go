@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SkillsGapReport compares a rejected applicant's profile skills with the
+// skills the job required, and points them to open jobs matching what they know
+type SkillsGapReport struct {
+	JobTitle string
+	// Matched are the job's skills the applicant has, Missing the others
+	Matched []string
+	Missing []string
+	// MatchScore is the percentage of the job's skills the applicant has
+	MatchScore      int
+	RecommendedJobs []*Job
+}
+
+// NewSkillsGapReport compares the job's skills with the applicant's. Both are
+// normalized, see NormalizeSkills. It returns nil when the job lists no skills.
+func NewSkillsGapReport(job *Job, applicantSkills []string) *SkillsGapReport {
+	required := NormalizeSkills(job.Skills)
+	if len(required) == 0 {
+		return nil
+	}
+
+	has := make(map[string]bool, len(applicantSkills))
+	for _, skill := range NormalizeSkills(applicantSkills) {
+		has[skill] = true
+	}
+
+	report := &SkillsGapReport{JobTitle: job.Title, Matched: []string{}, Missing: []string{}}
+	for _, skill := range required {
+		if has[skill] {
+			report.Matched = append(report.Matched, skill)
+		} else {
+			report.Missing = append(report.Missing, skill)
+		}
+	}
+	report.MatchScore = len(report.Matched) * 100 / len(required)
+	return report
+}
+
+// Body renders the report as the text of the notification, jobs link to frontendURL
+func (r *SkillsGapReport) Body(frontendURL string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Your profile matched %d%% of the skills \"%s\" asked for.\n", r.MatchScore, r.JobTitle)
+	if len(r.Matched) > 0 {
+		fmt.Fprintf(&b, "\nSkills you have: %s\n", strings.Join(r.Matched, ", "))
+	}
+	if len(r.Missing) > 0 {
+		fmt.Fprintf(&b, "Skills to work on: %s\n", strings.Join(r.Missing, ", "))
+	}
+
+	if len(r.RecommendedJobs) > 0 {
+		b.WriteString("\nOpen jobs matching your skills:\n")
+		for _, job := range r.RecommendedJobs {
+			fmt.Fprintf(&b, "- %s: %s/jobs/%s\n", job.Title, frontendURL, job.ID.Hex())
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
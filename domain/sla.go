@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrSLAPolicyNotFound = errors.New("sla policy not found")
+
+// SLATarget is the longest an application may stay in a stage, e.g. Applied
+// for 5 days means every application gets a first review within 5 days
+type SLATarget struct {
+	Stage ApplicationStatus `bson:"stage" json:"stage" validate:"required,oneof=Applied Reviewed Interview"`
+	Days  int               `bson:"days" json:"days" validate:"required,min=1,max=90"`
+}
+
+// SLAPolicy holds a company's response time targets. With NotifyBeforeHours
+// set, the job owner is emailed about applications that are about to breach.
+type SLAPolicy struct {
+	CompanyID         string      `bson:"company_id" json:"company_id"`
+	Targets           []SLATarget `bson:"targets" json:"targets"`
+	NotifyBeforeHours int         `bson:"notify_before_hours,omitempty" json:"notify_before_hours,omitempty"`
+	UpdatedAt         time.Time   `bson:"updated_at" json:"updated_at"`
+}
+
+// SLAAtRiskWindow is how long before the due date a timer counts as at risk,
+// when the policy has no notification lead of its own
+const SLAAtRiskWindow = 24 * time.Hour
+
+// SLATimer is the countdown of an application against its current stage's target
+type SLATimer struct {
+	Stage    ApplicationStatus `json:"stage"`
+	Since    time.Time         `json:"since"`
+	DueAt    time.Time         `json:"due_at"`
+	Breached bool              `json:"breached"`
+	// AtRisk is set when the due date is near but not yet passed
+	AtRisk bool `json:"at_risk"`
+}
+
+// Target returns the target set for a stage
+func (p *SLAPolicy) Target(stage ApplicationStatus) (SLATarget, bool) {
+	if p == nil {
+		return SLATarget{}, false
+	}
+	for _, target := range p.Targets {
+		if target.Stage == stage {
+			return target, true
+		}
+	}
+	return SLATarget{}, false
+}
+
+// Stages lists the stages that have a target
+func (p *SLAPolicy) Stages() []ApplicationStatus {
+	stages := make([]ApplicationStatus, 0, len(p.Targets))
+	for _, target := range p.Targets {
+		stages = append(stages, target.Stage)
+	}
+	return stages
+}
+
+// atRiskWindow is how long before the due date timers count as at risk
+func (p *SLAPolicy) atRiskWindow() time.Duration {
+	if p.NotifyBeforeHours > 0 {
+		return time.Duration(p.NotifyBeforeHours) * time.Hour
+	}
+	return SLAAtRiskWindow
+}
+
+// Timer computes the application's timer as of now. It is nil when the
+// application's stage has no target.
+func (p *SLAPolicy) Timer(app *Application, now time.Time) *SLATimer {
+	target, ok := p.Target(app.Status)
+	if !ok {
+		return nil
+	}
+
+	since := app.StatusSince()
+	due := since.Add(time.Duration(target.Days) * 24 * time.Hour)
+	return &SLATimer{
+		Stage:    app.Status,
+		Since:    since,
+		DueAt:    due,
+		Breached: !now.Before(due),
+		AtRisk:   now.Before(due) && !now.Before(due.Add(-p.atRiskWindow())),
+	}
+}
+
+// OpenApplication is an application still in the pipeline, with the job it was sent to
+type OpenApplication struct {
+	Application `bson:",inline"`
+	JobTitle    string `bson:"job_title"`
+}
+
+// SLAApplication is an open application listed on the company dashboard
+type SLAApplication struct {
+	ApplicationID string    `json:"application_id"`
+	JobID         string    `json:"job_id"`
+	JobTitle      string    `json:"job_title"`
+	Timer         *SLATimer `json:"timer"`
+}
+
+// CompanyDashboard sums up a company's open applications against its SLA
+type CompanyDashboard struct {
+	OpenApplications map[ApplicationStatus]int64 `json:"open_applications"`
+	Breached         int64                       `json:"breached"`
+	AtRisk           int64                       `json:"at_risk"`
+	// Attention lists the breached and at risk applications, most overdue first
+	Attention []*SLAApplication `json:"attention"`
+}
+
+type SaveSLAPolicyRequest struct {
+	Targets           []SLATarget `json:"targets" validate:"max=3,unique=Stage,dive"`
+	NotifyBeforeHours int         `json:"notify_before_hours,omitempty" validate:"min=0,max=168"`
+}
+
+type SLAResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
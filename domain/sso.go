@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrSSOConfigNotFound = errors.New("sso configuration not found")
+	// ErrSSODomainNotAllowed is returned when the identity provider vouches for
+	// an email outside the company's domains
+	ErrSSODomainNotAllowed = errors.New("email domain not allowed for this company")
+)
+
+// SSOConfig is the OpenID Connect identity provider a company's recruiters
+// sign in with. There is at most one per company.
+type SSOConfig struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	CompanyID string             `bson:"company_id" json:"company_id"`
+	// Issuer is the IdP's issuer URL, its discovery document is read from
+	// <issuer>/.well-known/openid-configuration
+	Issuer       string `bson:"issuer" json:"issuer"`
+	ClientID     string `bson:"client_id" json:"client_id"`
+	ClientSecret string `bson:"client_secret" json:"-"`
+	// AllowedDomains restricts which email addresses are provisioned. Only
+	// the VerifiedDomains among them are trusted, see DomainVerificationRecord.
+	AllowedDomains  []string `bson:"allowed_domains" json:"allowed_domains"`
+	VerifiedDomains []string `bson:"verified_domains,omitempty" json:"verified_domains"`
+	// VerificationToken is published in a DNS TXT record of each domain to
+	// prove the company controls it
+	VerificationToken string    `bson:"verification_token" json:"-"`
+	Enabled           bool      `bson:"enabled" json:"enabled"`
+	CreatedAt         time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt         time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// AllowsEmail reports whether email belongs to one of the verified domains.
+// Any IdP can vouch for any address, so only domains the company proved it
+// controls are trusted.
+func (c *SSOConfig) AllowsEmail(email string) bool {
+	_, host, ok := strings.Cut(strings.ToLower(email), "@")
+	if !ok {
+		return false
+	}
+	return hasDomain(c.VerifiedDomains, host)
+}
+
+// DomainVerificationRecord is the TXT record each allowed domain must publish
+// to be verified
+func (c *SSOConfig) DomainVerificationRecord() string {
+	return "job-portal-verification=" + c.VerificationToken
+}
+
+// UnverifiedDomains returns the allowed domains that are not verified yet
+func (c *SSOConfig) UnverifiedDomains() []string {
+	unverified := []string{}
+	for _, d := range c.AllowedDomains {
+		if !hasDomain(c.VerifiedDomains, d) {
+			unverified = append(unverified, d)
+		}
+	}
+	return unverified
+}
+
+func hasDomain(domains []string, domain string) bool {
+	for _, d := range domains {
+		if strings.EqualFold(d, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProviderName identifies the company's IdP in OAuthAccount.Provider and auth events
+func (c *SSOConfig) ProviderName() string {
+	return "sso:" + c.CompanyID
+}
+
+// SSOConfigView is returned to the company with the URLs its IdP must be set up with
+type SSOConfigView struct {
+	*SSOConfig
+	// LoginURL starts the sign in, recruiters can bookmark it
+	LoginURL    string `json:"login_url"`
+	RedirectURI string `json:"redirect_uri"`
+	// VerificationRecord is the TXT record to add to the UnverifiedDomains
+	VerificationRecord string   `json:"verification_record"`
+	UnverifiedDomains  []string `json:"unverified_domains"`
+}
+
+// SaveSSOConfigRequest creates or replaces a company's SSO configuration. The
+// client secret may be left out when updating to keep the stored one.
+type SaveSSOConfigRequest struct {
+	Issuer         string   `json:"issuer" validate:"required,url,startswith=https://"`
+	ClientID       string   `json:"client_id" validate:"required,max=256"`
+	ClientSecret   string   `json:"client_secret,omitempty" validate:"max=512"`
+	AllowedDomains []string `json:"allowed_domains" validate:"required,min=1,max=20,dive,fqdn"`
+	Enabled        bool     `json:"enabled"`
+}
+
+// SSOCallbackRequest carries the parameters the company's IdP redirects back with
+type SSOCallbackRequest struct {
+	CompanyID string
+	Code      string
+}
+
+type SSOResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+// StatusSchemaVersion is bumped on breaking changes to StatusPage, so status
+// page frontends can rely on its shape
+const StatusSchemaVersion = 1
+
+// StatusLevel is the health of the API or one of its dependencies
+type StatusLevel string
+
+const (
+	StatusOperational StatusLevel = "operational"
+	StatusDegraded    StatusLevel = "degraded"
+	StatusDown        StatusLevel = "down"
+)
+
+// Worse returns the worse of two levels
+func (l StatusLevel) Worse(other StatusLevel) StatusLevel {
+	rank := map[StatusLevel]int{StatusOperational: 0, StatusDegraded: 1, StatusDown: 2}
+	if rank[other] > rank[l] {
+		return other
+	}
+	return l
+}
+
+// DegradedErrorRate is the share of failed requests, in percent, over the
+// last hour above which the API is reported degraded
+const DegradedErrorRate = 5.0
+
+// DependencyStatus is the result of the last health check of a dependency.
+// Failure details are logged, not exposed.
+type DependencyStatus struct {
+	Name      string      `json:"name"`
+	Status    StatusLevel `json:"status"`
+	LatencyMs int64       `json:"latency_ms"`
+	CheckedAt time.Time   `json:"checked_at"`
+}
+
+// StatusWindow summarizes availability over a recent period
+type StatusWindow struct {
+	// Window is the period covered, e.g. "1h"
+	Window string `json:"window"`
+	// UptimePercent is the share of health checks that found every dependency
+	// reachable, nil until a check ran in the window
+	UptimePercent *float64 `json:"uptime_percent"`
+	Requests      int64    `json:"requests"`
+	// ErrorRatePercent is the share of requests answered with a server error
+	ErrorRatePercent float64 `json:"error_rate_percent"`
+}
+
+// StatusPage is the data a public status page renders
+type StatusPage struct {
+	Version      int                 `json:"version"`
+	Status       StatusLevel         `json:"status"`
+	GeneratedAt  time.Time           `json:"generated_at"`
+	Windows      []StatusWindow      `json:"windows"`
+	Dependencies []*DependencyStatus `json:"dependencies"`
+}
+
+type StatusResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
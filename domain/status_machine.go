@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrStatusTransitionsNotFound = errors.New("status transitions override not found")
+
+// StatusTransitions maps each status to the statuses it may move to
+type StatusTransitions map[ApplicationStatus][]ApplicationStatus
+
+// DefaultStatusTransitions is the pipeline shipped with the portal
+var DefaultStatusTransitions = StatusTransitions{
+	StatusApplied:   {StatusReviewed, StatusInterview, StatusRejected, StatusHired},
+	StatusReviewed:  {StatusInterview, StatusRejected, StatusHired},
+	StatusInterview: {StatusHired, StatusRejected},
+	// Hired and Rejected are final states, no further transitions allowed
+	StatusHired:    {},
+	StatusRejected: {},
+}
+
+// StatusTransitionsOverride is the transition graph an admin set for the
+// deployment. It replaces the configured graph until it is reset.
+type StatusTransitionsOverride struct {
+	Transitions StatusTransitions `bson:"transitions" json:"transitions"`
+	UpdatedBy   string            `bson:"updated_by" json:"updated_by"`
+	UpdatedAt   time.Time         `bson:"updated_at" json:"updated_at"`
+}
+
+// UpdateStatusTransitionsRequest replaces the transition graph of the deployment
+type UpdateStatusTransitionsRequest struct {
+	Transitions StatusTransitions `json:"transitions" validate:"required"`
+}
+
+// StatusMachine validates application status changes against a transition graph
+type StatusMachine struct {
+	transitions StatusTransitions
+	source      string
+}
+
+// StatusGraph describes the active transition graph
+type StatusGraph struct {
+	Source      string                                    `json:"source"`
+	Statuses    []ApplicationStatus                       `json:"statuses"`
+	Transitions map[ApplicationStatus][]ApplicationStatus `json:"transitions"`
+	Terminal    []ApplicationStatus                       `json:"terminal"`
+}
+
+// NewStatusMachine builds a state machine from a transition graph. The graph may only
+// reference known statuses and must be acyclic, so every application eventually
+// reaches a final state. source describes where the graph came from (e.g. "default").
+func NewStatusMachine(transitions StatusTransitions, source string) (*StatusMachine, error) {
+	known := make(map[ApplicationStatus]bool, len(ApplicationStatuses))
+	for _, status := range ApplicationStatuses {
+		known[status] = true
+	}
+
+	normalized := make(StatusTransitions, len(ApplicationStatuses))
+	for _, status := range ApplicationStatuses {
+		normalized[status] = []ApplicationStatus{}
+	}
+
+	for from, targets := range transitions {
+		if !known[from] {
+			return nil, fmt.Errorf("unknown status %q in transition graph", from)
+		}
+		for _, to := range targets {
+			if !known[to] {
+				return nil, fmt.Errorf("unknown status %q in transitions from %q", to, from)
+			}
+			if to == from {
+				return nil, fmt.Errorf("status %q cannot transition to itself", from)
+			}
+			normalized[from] = append(normalized[from], to)
+		}
+	}
+
+	if cycle := findCycle(normalized); cycle != nil {
+		return nil, fmt.Errorf("transition graph contains a cycle: %v", cycle)
+	}
+
+	return &StatusMachine{
+		transitions: normalized,
+		source:      source,
+	}, nil
+}
+
+// findCycle returns the statuses forming a cycle, or nil if the graph is acyclic
+func findCycle(transitions StatusTransitions) []ApplicationStatus {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	state := make(map[ApplicationStatus]int, len(transitions))
+	var path []ApplicationStatus
+
+	var visit func(status ApplicationStatus) []ApplicationStatus
+	visit = func(status ApplicationStatus) []ApplicationStatus {
+		state[status] = visiting
+		path = append(path, status)
+
+		for _, next := range transitions[status] {
+			switch state[next] {
+			case visiting:
+				// Return the part of the path that loops back to next
+				for i, s := range path {
+					if s == next {
+						return append(append([]ApplicationStatus{}, path[i:]...), next)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[status] = done
+		return nil
+	}
+
+	// Walk statuses in a fixed order so errors are deterministic
+	for _, status := range ApplicationStatuses {
+		if state[status] == unvisited {
+			if cycle := visit(status); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
+
+// CanTransition reports whether an application may move from one status to another
+func (m *StatusMachine) CanTransition(from, to ApplicationStatus) bool {
+	for _, status := range m.transitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedTransitions returns the statuses reachable in one step, in pipeline order
+func (m *StatusMachine) AllowedTransitions(from ApplicationStatus) []ApplicationStatus {
+	allowed := []ApplicationStatus{}
+	for _, status := range ApplicationStatuses {
+		if m.CanTransition(from, status) {
+			allowed = append(allowed, status)
+		}
+	}
+	return allowed
+}
+
+// Graph returns a description of the active transition graph
+func (m *StatusMachine) Graph() StatusGraph {
+	graph := StatusGraph{
+		Source:      m.source,
+		Statuses:    ApplicationStatuses,
+		Transitions: make(map[ApplicationStatus][]ApplicationStatus, len(m.transitions)),
+		Terminal:    []ApplicationStatus{},
+	}
+
+	for _, status := range ApplicationStatuses {
+		allowed := m.AllowedTransitions(status)
+		graph.Transitions[status] = allowed
+		if len(allowed) == 0 {
+			graph.Terminal = append(graph.Terminal, status)
+		}
+	}
+
+	return graph
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrSupportTicketNotFound = errors.New("support ticket not found")
+	// ErrSupportTicketClosed is returned when replying to a closed ticket
+	ErrSupportTicketClosed = errors.New("support ticket is closed")
+)
+
+// MaxSupportAttachmentBytes bounds the size of the file attached to a ticket
+const MaxSupportAttachmentBytes = 5 << 20
+
+// SupportAttachmentTypes lists the content types a ticket attachment can have
+var SupportAttachmentTypes = map[string]string{
+	"image/png":       ".png",
+	"image/jpeg":      ".jpg",
+	"image/gif":       ".gif",
+	"application/pdf": ".pdf",
+	"text/plain":      ".txt",
+}
+
+type SupportTicketCategory string
+
+const (
+	TicketCategoryAccount      SupportTicketCategory = "account"
+	TicketCategoryJobs         SupportTicketCategory = "jobs"
+	TicketCategoryApplications SupportTicketCategory = "applications"
+	TicketCategoryBug          SupportTicketCategory = "bug"
+	TicketCategoryAbuse        SupportTicketCategory = "abuse"
+	TicketCategoryOther        SupportTicketCategory = "other"
+)
+
+// SupportTicketStatus is open until support replied, and answered after.
+// A reply from the user opens the ticket again.
+type SupportTicketStatus string
+
+const (
+	TicketStatusOpen     SupportTicketStatus = "open"
+	TicketStatusAnswered SupportTicketStatus = "answered"
+	TicketStatusClosed   SupportTicketStatus = "closed"
+)
+
+// SupportTicket is a problem reported by a user to the portal's support team
+type SupportTicket struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID string             `bson:"user_id" json:"user_id"`
+	// Name, Email and Role are those of the user when the ticket was opened
+	Name          string                `bson:"name" json:"name"`
+	Email         string                `bson:"email" json:"email"`
+	Role          Role                  `bson:"role" json:"role"`
+	Category      SupportTicketCategory `bson:"category" json:"category"`
+	Message       string                `bson:"message" json:"message"`
+	AttachmentURL string                `bson:"attachment_url,omitempty" json:"attachment_url,omitempty"`
+	Status        SupportTicketStatus   `bson:"status" json:"status"`
+	// Replies lists the answers of the support team and the user, oldest first
+	Replies   []SupportTicketReply `bson:"replies" json:"replies"`
+	CreatedAt time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time            `bson:"updated_at" json:"updated_at"`
+	ClosedAt  *time.Time           `bson:"closed_at,omitempty" json:"closed_at,omitempty"`
+}
+
+// SupportTicketReply is a message added to a ticket
+type SupportTicketReply struct {
+	AuthorID string `bson:"author_id" json:"author_id"`
+	// FromSupport is set on the replies of admins
+	FromSupport bool      `bson:"from_support" json:"from_support"`
+	Message     string    `bson:"message" json:"message"`
+	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
+}
+
+// CreateSupportTicketRequest is sent as a multipart form, the optional
+// attachment goes in the attachment field
+type CreateSupportTicketRequest struct {
+	Category SupportTicketCategory `form:"category" json:"category" validate:"required,oneof=account jobs applications bug abuse other"`
+	Message  string                `form:"message" json:"message" validate:"required,min=10,max=5000"`
+}
+
+// ReplySupportTicketRequest answers a ticket. Close marks it resolved.
+type ReplySupportTicketRequest struct {
+	Message string `json:"message" validate:"required,min=1,max=5000"`
+	Close   bool   `json:"close,omitempty"`
+}
+
+// SupportTicketFilter narrows down the admin ticket queue
+type SupportTicketFilter struct {
+	Status   SupportTicketStatus
+	Category SupportTicketCategory
+}
+
+type SupportTicketResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+	// URL is the canonical location of a newly created resource
+	URL string `json:"url,omitempty"`
+}
+
+type SupportTicketListResponse struct {
+	Success    bool        `json:"success"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data,omitempty"`
+	PageNumber int         `json:"page_number"`
+	PageSize   int         `json:"page_size"`
+	TotalItems int64       `json:"total_items"`
+	TotalPages int         `json:"total_pages"`
+	Errors     []string    `json:"errors,omitempty"`
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// UIPreferenceKey names a view setting the web client saves for the user, so
+// every device they sign in on opens the same filters and sort order
+type UIPreferenceKey string
+
+const (
+	// PrefApplicationsView holds the filters and sort of the user's applications page
+	PrefApplicationsView UIPreferenceKey = "applications_view"
+	// PrefJobSearch holds the last used job search filters and sort
+	PrefJobSearch UIPreferenceKey = "job_search"
+)
+
+// NewUIPreferenceValue returns a pointer to the type the key's value decodes
+// into, or false for keys the server doesn't know
+func NewUIPreferenceValue(key UIPreferenceKey) (interface{}, bool) {
+	switch key {
+	case PrefApplicationsView:
+		return &ApplicationsViewPreference{}, true
+	case PrefJobSearch:
+		return &JobSearchPreference{}, true
+	default:
+		return nil, false
+	}
+}
+
+// ApplicationsViewPreference is the applicant's view of their applications
+type ApplicationsViewPreference struct {
+	Statuses []ApplicationStatus `json:"statuses,omitempty" validate:"omitempty,max=5,dive,oneof=Applied Reviewed Interview Rejected Hired"`
+	JobID    string              `json:"job_id,omitempty" validate:"omitempty,len=24,hexadecimal"`
+	Sort     string              `json:"sort,omitempty" validate:"omitempty,oneof=newest oldest status"`
+	PageSize int                 `json:"page_size,omitempty" validate:"omitempty,min=1,max=50"`
+}
+
+// JobSearchPreference mirrors the filters of the job listing
+type JobSearchPreference struct {
+	Query           string          `json:"q,omitempty" validate:"omitempty,max=100"`
+	Title           string          `json:"title,omitempty" validate:"omitempty,max=100"`
+	Location        string          `json:"location,omitempty" validate:"omitempty,max=100"`
+	CompanyName     string          `json:"company_name,omitempty" validate:"omitempty,max=100"`
+	Category        string          `json:"category,omitempty" validate:"omitempty,max=50"`
+	EmploymentType  EmploymentType  `json:"employment_type,omitempty" validate:"omitempty,oneof=full_time part_time contract internship temporary"`
+	ExperienceLevel ExperienceLevel `json:"experience_level,omitempty" validate:"omitempty,oneof=entry junior mid senior lead"`
+	Remote          *bool           `json:"remote,omitempty"`
+	Skills          []string        `json:"skills,omitempty" validate:"omitempty,max=10,dive,min=1,max=50"`
+	SalaryMin       *float64        `json:"salary_min,omitempty" validate:"omitempty,gte=0"`
+	SalaryMax       *float64        `json:"salary_max,omitempty" validate:"omitempty,gte=0"`
+	Currency        string          `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	Lat             *float64        `json:"lat,omitempty" validate:"omitempty,gte=-90,lte=90"`
+	Lng             *float64        `json:"lng,omitempty" validate:"omitempty,gte=-180,lte=180"`
+	RadiusKm        *float64        `json:"radius_km,omitempty" validate:"omitempty,gt=0,lte=500"`
+	Sort            string          `json:"sort,omitempty" validate:"omitempty,oneof=relevance newest salary"`
+	PageSize        int             `json:"page_size,omitempty" validate:"omitempty,min=1,max=50"`
+}
+
+// UIPreferences holds the saved view settings of a user. Values are kept
+// JSON encoded by key, as the client sent them once validated.
+type UIPreferences struct {
+	UserID    string            `bson:"user_id" json:"-"`
+	Values    map[string]string `bson:"values" json:"-"`
+	UpdatedAt time.Time         `bson:"updated_at" json:"-"`
+}
+
+// UIPreferencesView is how saved view settings are returned to the client
+type UIPreferencesView struct {
+	Values    map[string]json.RawMessage `json:"values"`
+	UpdatedAt *time.Time                 `json:"updated_at,omitempty"`
+}
+
+// View decodes the saved values for the response
+func (p *UIPreferences) View() *UIPreferencesView {
+	view := &UIPreferencesView{Values: make(map[string]json.RawMessage, len(p.Values))}
+	for key, value := range p.Values {
+		view.Values[key] = json.RawMessage(value)
+	}
+	if !p.UpdatedAt.IsZero() {
+		updatedAt := p.UpdatedAt
+		view.UpdatedAt = &updatedAt
+	}
+	return view
+}
+
+// UpdateUIPreferencesRequest saves the keys it contains and leaves the others
+// as they are. A null value removes the key.
+type UpdateUIPreferencesRequest map[UIPreferenceKey]json.RawMessage
+
+type UIPreferencesResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
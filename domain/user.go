@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -10,9 +11,11 @@ import (
 // Common errors
 var (
 	ErrEmailAlreadyExists = errors.New("email already exists")
-	ErrUserNotFound      = errors.New("user not found")
-	ErrInvalidID         = errors.New("invalid id")
-	ErrInvalidPassword   = errors.New("invalid password")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidID          = errors.New("invalid id")
+	ErrInvalidPassword    = errors.New("invalid password")
+	// ErrForbidden is matched by the errors returned when a user may not act on a resource
+	ErrForbidden = errors.New("forbidden")
 )
 
 type Role string
@@ -20,16 +23,93 @@ type Role string
 const (
 	Applicant Role = "applicant"
 	Company   Role = "company"
+	// Auditor is a read-only role used for compliance reviews. Auditor
+	// accounts cannot self-register, an admin opens them, and are rejected
+	// by mutating endpoints.
+	Auditor Role = "auditor"
+	// Admin manages user accounts. Admin accounts are seeded from the
+	// configuration and cannot self-register.
+	Admin Role = "admin"
 )
 
+// UserStatus tells whether an account may sign in
+type UserStatus string
+
+const (
+	UserActive    UserStatus = "active"
+	UserSuspended UserStatus = "suspended"
+	// UserDeleted accounts were closed by their owner and had their personal data erased
+	UserDeleted UserStatus = "deleted"
+)
+
+// DeletedUserName replaces the name of deleted accounts
+const DeletedUserName = "Deleted user"
+
 type User struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Name      string            `bson:"name" json:"name" validate:"required,alpha,min=2,max=100"`
-	Email     string            `bson:"email" json:"email" validate:"required,email"`
-	Password  string            `bson:"password" json:"-" validate:"required,min=8,containsany=!@#$%^&*,containsany=0123456789,containsany=ABCDEFGHIJKLMNOPQRSTUVWXYZ,containsany=abcdefghijklmnopqrstuvwxyz"`
-	Role      Role              `bson:"role" json:"role" validate:"required,oneof=applicant company"`
-	CreatedAt time.Time         `bson:"created_at" json:"created_at"`
-	UpdatedAt time.Time         `bson:"updated_at" json:"updated_at"`
+	ID       primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name     string             `bson:"name" json:"name" validate:"required,alpha,min=2,max=100"`
+	Email    string             `bson:"email" json:"email" validate:"required,email"`
+	Password string             `bson:"password" json:"-" validate:"required,min=8,containsany=!@#$%^&*,containsany=0123456789,containsany=ABCDEFGHIJKLMNOPQRSTUVWXYZ,containsany=abcdefghijklmnopqrstuvwxyz"`
+	Role     Role               `bson:"role" json:"role" validate:"required,oneof=applicant company"`
+	// Status is empty for accounts created before suspension was introduced, which are active
+	Status      UserStatus `bson:"status,omitempty" json:"status,omitempty"`
+	SuspendedAt *time.Time `bson:"suspended_at,omitempty" json:"suspended_at,omitempty"`
+	DeletedAt   *time.Time `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	// EmailVerifiedAt is when the user proved they own their email address: by
+	// signing in with a magic link, confirming an email change or a social
+	// login with a verified email
+	EmailVerifiedAt *time.Time `bson:"email_verified_at,omitempty" json:"email_verified_at,omitempty"`
+	// OAuthAccounts are the social login identities linked to this user
+	OAuthAccounts []OAuthAccount `bson:"oauth_accounts,omitempty" json:"-"`
+	// ProvisionedBy names the company IdP that created the account on first
+	// SSO sign in (see SSOConfig.ProviderName). Such accounts only sign in
+	// through that IdP.
+	ProvisionedBy string `bson:"provisioned_by,omitempty" json:"-"`
+	// TwoFactorEnabled is set once the user confirmed an authenticator app
+	TwoFactorEnabled       bool   `bson:"two_factor_enabled" json:"two_factor_enabled"`
+	TwoFactorSecret        string `bson:"two_factor_secret,omitempty" json:"-"`
+	TwoFactorPendingSecret string `bson:"two_factor_pending_secret,omitempty" json:"-"`
+	// TwoFactorLastStep is the TOTP time step of the last accepted code, a
+	// code is only accepted once
+	TwoFactorLastStep int64 `bson:"two_factor_last_step,omitempty" json:"-"`
+	// TwoFactorAttempts counts the codes entered since TwoFactorAttemptsSince,
+	// see MaxTwoFactorAttempts
+	TwoFactorAttempts      int        `bson:"two_factor_attempts,omitempty" json:"-"`
+	TwoFactorAttemptsSince *time.Time `bson:"two_factor_attempts_since,omitempty" json:"-"`
+	CreatedAt              time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt              time.Time  `bson:"updated_at" json:"updated_at"`
+	// SignupCountry is resolved from the signup IP, GeoFlagged is set when that
+	// country is flagged. Both are kept for fraud analysis.
+	SignupCountry string `bson:"signup_country,omitempty" json:"signup_country,omitempty"`
+	GeoFlagged    bool   `bson:"geo_flagged,omitempty" json:"-"`
+	// InviteCode is the code the user signed up with while the portal was invite-only
+	InviteCode string `bson:"invite_code,omitempty" json:"invite_code,omitempty"`
+	// Phone is optional; companies see it and Email according to Privacy
+	Phone   string          `bson:"phone,omitempty" json:"phone,omitempty"`
+	Privacy PrivacySettings `bson:"privacy,omitempty" json:"-"`
+	// AvatarURL is the profile picture, a square AvatarSize pixels wide
+	AvatarURL string `bson:"avatar_url,omitempty" json:"avatar_url,omitempty"`
+}
+
+const (
+	// MaxAvatarBytes bounds the size of uploaded profile pictures
+	MaxAvatarBytes = 5 << 20
+	// AvatarSize is the width and height profile pictures are resized to
+	AvatarSize = 256
+)
+
+const (
+	// MaxTwoFactorAttempts bounds the codes a user can enter per
+	// TwoFactorAttemptWindow, so codes can't be guessed
+	MaxTwoFactorAttempts   = 5
+	TwoFactorAttemptWindow = 15 * time.Minute
+)
+
+// OAuthAccount links a user to an identity at an external OAuth provider
+type OAuthAccount struct {
+	Provider string    `bson:"provider" json:"provider"`
+	Subject  string    `bson:"subject" json:"subject"`
+	LinkedAt time.Time `bson:"linked_at" json:"linked_at"`
 }
 
 // Sanitize removes sensitive data before sending the user object in responses
@@ -37,11 +117,83 @@ func (u *User) Sanitize() {
 	u.Password = ""
 }
 
+// IsSuspended reports whether an admin suspended the account
+func (u *User) IsSuspended() bool {
+	return u.Status == UserSuspended
+}
+
+// IsDeleted reports whether the owner closed the account
+func (u *User) IsDeleted() bool {
+	return u.Status == UserDeleted
+}
+
+// ProvisionedBySSO reports whether a company's IdP created the account.
+// Accounts provisioned before ProvisionedBy was recorded are recognized by
+// having no password and only SSO identities.
+func (u *User) ProvisionedBySSO() bool {
+	if u.ProvisionedBy != "" {
+		return true
+	}
+	if u.Password != "" || len(u.OAuthAccounts) == 0 {
+		return false
+	}
+	for _, account := range u.OAuthAccounts {
+		if !strings.HasPrefix(account.Provider, "sso:") {
+			return false
+		}
+	}
+	return true
+}
+
+// UserFilter narrows down the users returned by the admin user listing
+type UserFilter struct {
+	// Query matches the name or email (case insensitive)
+	Query  string
+	Role   Role
+	Status UserStatus
+}
+
+type UserResponse struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
+
+type UserListResponse struct {
+	Success    bool        `json:"success"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data,omitempty"`
+	PageNumber int         `json:"page_number"`
+	PageSize   int         `json:"page_size"`
+	TotalItems int64       `json:"total_items"`
+	TotalPages int         `json:"total_pages"`
+	Errors     []string    `json:"errors,omitempty"`
+}
+
+// CreateAuditorRequest is an admin opening a read-only auditor account. The
+// admin passes the initial password on to the auditor.
+type CreateAuditorRequest struct {
+	Name     string `json:"name" validate:"required,min=2,max=100"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8,containsany=!@#$%^&*,containsany=0123456789,containsany=ABCDEFGHIJKLMNOPQRSTUVWXYZ,containsany=abcdefghijklmnopqrstuvwxyz"`
+}
+
 type SignUpRequest struct {
 	Name     string `json:"name" validate:"required,alpha,min=2,max=100"`
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8,containsany=!@#$%^&*,containsany=0123456789,containsany=ABCDEFGHIJKLMNOPQRSTUVWXYZ,containsany=abcdefghijklmnopqrstuvwxyz"`
 	Role     Role   `json:"role" validate:"required,oneof=applicant company"`
+	Phone    string `json:"phone,omitempty" validate:"omitempty,e164"`
+	// InviteCode is required while signups are invite-only
+	InviteCode string `json:"invite_code,omitempty" validate:"omitempty,alphanum,max=32"`
+}
+
+// UpdateUserRequest changes the user's own account details. Omitted fields are
+// left unchanged, an empty phone removes it.
+type UpdateUserRequest struct {
+	Name  *string `json:"name,omitempty" validate:"omitempty,alpha,min=2,max=100"`
+	Phone *string `json:"phone,omitempty" validate:"omitempty,len=0|e164"`
 }
 
 type LoginRequest struct {
@@ -49,9 +201,41 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// OAuthCallbackRequest carries the parameters the provider redirects back with
+type OAuthCallbackRequest struct {
+	Provider string
+	Code     string
+	// Role and InviteCode are used only when the callback creates a new account
+	Role       Role
+	InviteCode string
+}
+
+type TwoFactorConfirmRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// TwoFactorEnrollment is returned when a user starts enrolling an authenticator app.
+// OTPAuthURL is meant to be rendered as a QR code by the client.
+type TwoFactorEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURL string `json:"otpauth_url"`
+}
+
 type AuthResponse struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
 	Token   string `json:"token,omitempty"`
 	User    *User  `json:"user,omitempty"`
-}
\ No newline at end of file
+	// RequiresTwoFactor is set when the password was correct but a TOTP code is still
+	// needed; the code must be sent with ChallengeToken to /auth/login/2fa
+	RequiresTwoFactor bool                 `json:"requires_2fa,omitempty"`
+	ChallengeToken    string               `json:"challenge_token,omitempty"`
+	TwoFactor         *TwoFactorEnrollment `json:"two_factor,omitempty"`
+	// URL is the canonical location of a newly created account
+	URL string `json:"url,omitempty"`
+}
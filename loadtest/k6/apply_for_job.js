@@ -0,0 +1,51 @@
+// Applicants applying with a resume from their library. Every application is
+// new until an applicant ran out of jobs, repeated ones are answered with 409
+// and don't count as failures. Reseed the database between runs.
+//   k6 run loadtest/k6/apply_for_job.js
+import http from 'k6/http';
+import { check } from 'k6';
+import { API, APPLICANTS, thresholds, login, auth, pick } from './common.js';
+
+export const options = {
+  scenarios: {
+    apply: {
+      executor: 'constant-arrival-rate',
+      rate: parseInt(__ENV.RATE || '50', 10),
+      timeUnit: '1s',
+      duration: __ENV.DURATION || '2m',
+      preAllocatedVUs: 50,
+      maxVUs: 200,
+    },
+  },
+  thresholds,
+};
+
+http.setResponseCallback(http.expectedStatuses(201, 409));
+
+// Logs in a sample of the seeded applicants and collects the jobs to apply to
+export function setup() {
+  const jobs = [];
+  for (let page = 1; page <= 10; page++) {
+    const res = http.get(`${API}/jobs?limit=50&page=${page}`, { tags: { name: 'GET /jobs' } });
+    jobs.push(...(res.json('data') || []).map((job) => job.id));
+  }
+
+  const applicants = [];
+  for (let n = 1; n <= Math.min(APPLICANTS, 50); n++) {
+    const token = login('applicant', n);
+    const res = http.get(`${API}/users/me/resumes`, auth(token, 'GET /users/me/resumes'));
+    const resumes = res.json('data') || [];
+    if (resumes.length > 0) {
+      applicants.push({ token, resume: resumes[0].id });
+    }
+  }
+  return { jobs, applicants };
+}
+
+export default function (data) {
+  const applicant = pick(data.applicants);
+  const job = pick(data.jobs);
+  const res = http.post(`${API}/jobs/${job}/applications`, { job_id: job, resume_id: applicant.resume },
+    auth(applicant.token, 'POST /jobs/:id/applications'));
+  check(res, { 'applied or already applied': (r) => r.status === 201 || r.status === 409 });
+}
@@ -0,0 +1,40 @@
+// Shared settings of the load test scripts. Run `jobctl seed` first, with the
+// same volumes as below, against the database of the API under test.
+import http from 'k6/http';
+import { check, fail } from 'k6';
+
+export const BASE_URL = __ENV.BASE_URL || 'http://localhost:8080';
+export const API = `${BASE_URL}/api/v1`;
+export const PASSWORD = __ENV.SEED_PASSWORD || 'LoadTest#2024';
+export const COMPANIES = parseInt(__ENV.SEED_COMPANIES || '50', 10);
+export const APPLICANTS = parseInt(__ENV.SEED_APPLICANTS || '500', 10);
+
+// Default latency budgets, a run fails when they are exceeded
+export const thresholds = {
+  http_req_failed: ['rate<0.01'],
+  http_req_duration: ['p(95)<300', 'p(99)<800'],
+};
+
+export function email(kind, n) {
+  return `loadtest-${kind}-${n}@example.test`;
+}
+
+// login returns a bearer token for a seeded account
+export function login(kind, n) {
+  const res = http.post(`${API}/auth/login`, JSON.stringify({ email: email(kind, n), password: PASSWORD }), {
+    headers: { 'Content-Type': 'application/json' },
+    tags: { name: 'login' },
+  });
+  if (!check(res, { 'logged in': (r) => r.status === 200 && r.json('token') })) {
+    fail(`login of ${email(kind, n)} failed with status ${res.status}`);
+  }
+  return res.json('token');
+}
+
+export function auth(token, name) {
+  return { headers: { Authorization: `Bearer ${token}` }, tags: { name } };
+}
+
+export function pick(list) {
+  return list[Math.floor(Math.random() * list.length)];
+}
@@ -0,0 +1,41 @@
+// A company reviewing the applications of its jobs.
+//   k6 run loadtest/k6/job_applications.js
+import http from 'k6/http';
+import { check } from 'k6';
+import { API, COMPANIES, thresholds, login, auth, pick } from './common.js';
+
+export const options = {
+  scenarios: {
+    review: {
+      executor: 'constant-arrival-rate',
+      rate: parseInt(__ENV.RATE || '100', 10),
+      timeUnit: '1s',
+      duration: __ENV.DURATION || '2m',
+      preAllocatedVUs: 50,
+      maxVUs: 200,
+    },
+  },
+  thresholds,
+};
+
+// Logs in a sample of the seeded companies and collects the IDs of their jobs
+export function setup() {
+  const companies = [];
+  for (let n = 1; n <= Math.min(COMPANIES, 20); n++) {
+    const token = login('company', n);
+    const res = http.get(`${API}/users/me/jobs?limit=50`, auth(token, 'GET /users/me/jobs'));
+    const jobs = (res.json('data') || []).map((job) => job.id);
+    if (jobs.length > 0) {
+      companies.push({ token, jobs });
+    }
+  }
+  return { companies };
+}
+
+export default function (data) {
+  const company = pick(data.companies);
+  const page = 1 + Math.floor(Math.random() * 2);
+  const res = http.get(`${API}/jobs/${pick(company.jobs)}/applications?page=${page}&limit=20`,
+    auth(company.token, 'GET /jobs/:id/applications'));
+  check(res, { 'status is 200': (r) => r.status === 200 });
+}
@@ -0,0 +1,41 @@
+// Public job listing with the filters clients combine most often.
+//   k6 run loadtest/k6/list_jobs.js
+import http from 'k6/http';
+import { check } from 'k6';
+import { API, thresholds, pick } from './common.js';
+
+export const options = {
+  scenarios: {
+    listing: {
+      executor: 'constant-arrival-rate',
+      rate: parseInt(__ENV.RATE || '200', 10),
+      timeUnit: '1s',
+      duration: __ENV.DURATION || '2m',
+      preAllocatedVUs: 50,
+      maxVUs: 200,
+    },
+  },
+  thresholds,
+};
+
+// Matches the titles, locations and salaries generated by jobctl seed
+const queries = [
+  '',
+  'page=5',
+  'title=engineer',
+  'location=nairobi',
+  'title=designer&location=remote',
+  'remote=true&experience_level=senior',
+  'employment_type=full_time&location=lagos',
+  'skills=go,mongodb',
+  'category=engineering&remote=true',
+  'salary_min=2000',
+  'salary_min=1500&salary_max=3000',
+  'title=analyst&salary_max=2500&page=2',
+];
+
+export default function () {
+  const query = pick(queries);
+  const res = http.get(`${API}/jobs?limit=20&${query}`, { tags: { name: 'GET /jobs' } });
+  check(res, { 'status is 200': (r) => r.status === 200 });
+}
@@ -0,0 +1,9 @@
+#!/bin/sh
+# Constant rate attack on the public job listing, for a quick check without k6.
+#   RATE=200 DURATION=2m loadtest/vegeta/run.sh
+set -eu
+
+dir=$(dirname "$0")
+vegeta attack -targets="$dir/list_jobs.txt" -rate="${RATE:-200}/s" -duration="${DURATION:-2m}" |
+  tee "${OUTPUT:-results.bin}" |
+  vegeta report
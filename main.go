@@ -42,6 +42,11 @@ func main() {
 	// Initialize router with database connection
 	appRouter := router.NewRouter(db)
 
+	// Start background workers; they are stopped on shutdown
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	appRouter.StartBackgroundJobs(workerCtx)
+
 	// Create HTTP server
 	srv := &http.Server{
 		Addr:    ":" + cfg.Port,
@@ -64,6 +69,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
+	stopWorkers()
 
 	// Create a deadline to wait for
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
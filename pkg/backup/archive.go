@@ -0,0 +1,161 @@
+// Package backup reads and writes logical database backups. An archive is a
+// gzip compressed stream of JSON lines, one per document:
+//
+//	{"collection":"jobs","document":{...canonical extended JSON...}}
+//
+// Every collection is summarized by its document count and a SHA-256 of its
+// documents, so an archive can be checked against the summary recorded when
+// it was written.
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// maxLineBytes bounds a single archive line; MongoDB documents are at most 16MB
+// of BSON, which can grow when rendered as extended JSON
+const maxLineBytes = 64 << 20
+
+// CollectionStats summarizes the documents of one collection in an archive
+type CollectionStats struct {
+	Name      string
+	Documents int64
+	SHA256    string
+}
+
+type line struct {
+	Collection string          `json:"collection"`
+	Document   json.RawMessage `json:"document"`
+}
+
+// tally accumulates the stats of each collection in the order they appear
+type tally struct {
+	order  []string
+	counts map[string]int64
+	hashes map[string]hash.Hash
+}
+
+func newTally() *tally {
+	return &tally{counts: map[string]int64{}, hashes: map[string]hash.Hash{}}
+}
+
+func (t *tally) add(collection string, document []byte) {
+	h, ok := t.hashes[collection]
+	if !ok {
+		h = sha256.New()
+		t.hashes[collection] = h
+		t.order = append(t.order, collection)
+	}
+	h.Write(document)
+	t.counts[collection]++
+}
+
+func (t *tally) stats() []CollectionStats {
+	stats := make([]CollectionStats, 0, len(t.order))
+	for _, name := range t.order {
+		stats = append(stats, CollectionStats{
+			Name:      name,
+			Documents: t.counts[name],
+			SHA256:    hex.EncodeToString(t.hashes[name].Sum(nil)),
+		})
+	}
+	return stats
+}
+
+// Writer writes documents to an archive
+type Writer struct {
+	gz    *gzip.Writer
+	tally *tally
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{gz: gzip.NewWriter(w), tally: newTally()}
+}
+
+// Write appends a document of the given collection to the archive
+func (w *Writer) Write(collection string, document bson.Raw) error {
+	doc, err := bson.MarshalExtJSON(document, true, false)
+	if err != nil {
+		return fmt.Errorf("encoding %s document: %w", collection, err)
+	}
+
+	// The line is assembled by hand so the document is stored byte for byte as hashed
+	name, err := json.Marshal(collection)
+	if err != nil {
+		return err
+	}
+	encoded := make([]byte, 0, len(name)+len(doc)+32)
+	encoded = append(encoded, `{"collection":`...)
+	encoded = append(encoded, name...)
+	encoded = append(encoded, `,"document":`...)
+	encoded = append(encoded, doc...)
+	encoded = append(encoded, "}\n"...)
+	if _, err := w.gz.Write(encoded); err != nil {
+		return err
+	}
+
+	w.tally.add(collection, doc)
+	return nil
+}
+
+// Close flushes the archive. It doesn't close the underlying writer.
+func (w *Writer) Close() error {
+	return w.gz.Close()
+}
+
+// Stats returns the collections written so far
+func (w *Writer) Stats() []CollectionStats {
+	return w.tally.stats()
+}
+
+// Read decodes every document of an archive, calling fn for each one when it
+// isn't nil, and returns the stats of the collections it contains. A document
+// that can't be decoded fails the read, so a successful read means the archive
+// can be restored.
+func Read(r io.Reader, fn func(collection string, document bson.D) error) ([]CollectionStats, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("opening archive: %w", err)
+	}
+	defer gz.Close()
+
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+
+	t := newTally()
+	for n := 1; scanner.Scan(); n++ {
+		var l line
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if l.Collection == "" {
+			return nil, fmt.Errorf("line %d: missing collection", n)
+		}
+
+		var doc bson.D
+		if err := bson.UnmarshalExtJSON(l.Document, true, &doc); err != nil {
+			return nil, fmt.Errorf("line %d: decoding %s document: %w", n, l.Collection, err)
+		}
+		if fn != nil {
+			if err := fn(l.Collection, doc); err != nil {
+				return nil, err
+			}
+		}
+
+		t.add(l.Collection, l.Document)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading archive: %w", err)
+	}
+
+	return t.stats(), nil
+}
@@ -1,42 +1,48 @@
 package constants
 
 const (
-    // Context keys
-    ContextUserIDKey   = "userID"
-    ContextUserRoleKey = "userRole"
+	// Context keys
+	ContextUserIDKey   = "userID"
+	ContextUserRoleKey = "userRole"
+	ContextTokenIDKey  = "tokenID"
+	ContextTokenExpKey = "tokenExpiresAt"
+	ContextAPIKeyIDKey = "apiKeyID"
+	ContextScopesKey   = "scopes"
 
-    // Pagination defaults
-    DefaultPageSize = 10
-    DefaultPage     = 1
-    MaxPageSize     = 100
+	// Pagination defaults
+	DefaultPageSize = 10
+	DefaultPage     = 1
+	MaxPageSize     = 100
 
-    // File upload
-    MaxFileSize      = 5 << 20 // 5MB
-    AllowedFileTypes = "application/pdf"
+	// File upload
+	MaxFileSize      = 5 << 20 // 5MB
+	AllowedFileTypes = "application/pdf"
 )
 
 // User roles
 const (
-    RoleApplicant = "applicant"
-    RoleCompany   = "company"
+	RoleApplicant = "applicant"
+	RoleCompany   = "company"
+	RoleAuditor   = "auditor"
+	RoleAdmin     = "admin"
 )
 
 // Application statuses
 const (
-    StatusApplied    = "Applied"
-    StatusReviewed   = "Reviewed"
-    StatusInterview  = "Interview"
-    StatusRejected   = "Rejected"
-    StatusHired      = "Hired"
+	StatusApplied   = "Applied"
+	StatusReviewed  = "Reviewed"
+	StatusInterview = "Interview"
+	StatusRejected  = "Rejected"
+	StatusHired     = "Hired"
 )
 
 // Error messages
 const (
-    ErrInvalidCredentials = "invalid email or password"
-    ErrEmailAlreadyExists  = "email already exists"
-    ErrUnauthorized       = "unauthorized"
-    ErrForbidden          = "forbidden"
-    ErrNotFound           = "resource not found"
-    ErrInvalidFileType    = "invalid file type"
-    ErrFileTooLarge       = "file too large"
+	ErrInvalidCredentials = "invalid email or password"
+	ErrEmailAlreadyExists = "email already exists"
+	ErrUnauthorized       = "unauthorized"
+	ErrForbidden          = "forbidden"
+	ErrNotFound           = "resource not found"
+	ErrInvalidFileType    = "invalid file type"
+	ErrFileTooLarge       = "file too large"
 )
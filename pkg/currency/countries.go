@@ -0,0 +1,50 @@
+package currency
+
+import "strings"
+
+// countryCurrencies maps ISO 3166-1 alpha-2 country codes to the ISO 4217
+// code of their currency
+var countryCurrencies = map[string]string{
+	// Euro area
+	"AT": "EUR", "BE": "EUR", "HR": "EUR", "CY": "EUR", "EE": "EUR", "FI": "EUR",
+	"FR": "EUR", "DE": "EUR", "GR": "EUR", "IE": "EUR", "IT": "EUR", "LV": "EUR",
+	"LT": "EUR", "LU": "EUR", "MT": "EUR", "NL": "EUR", "PT": "EUR", "SK": "EUR",
+	"SI": "EUR", "ES": "EUR", "AD": "EUR", "MC": "EUR", "SM": "EUR", "VA": "EUR",
+	"ME": "EUR", "XK": "EUR",
+
+	// Rest of Europe
+	"GB": "GBP", "CH": "CHF", "LI": "CHF", "NO": "NOK", "SE": "SEK", "DK": "DKK",
+	"IS": "ISK", "PL": "PLN", "CZ": "CZK", "HU": "HUF", "RO": "RON", "BG": "BGN",
+	"RS": "RSD", "BA": "BAM", "MK": "MKD", "AL": "ALL", "MD": "MDL", "UA": "UAH",
+	"BY": "BYN", "RU": "RUB", "TR": "TRY", "GE": "GEL", "AM": "AMD", "AZ": "AZN",
+
+	// Americas
+	"US": "USD", "CA": "CAD", "MX": "MXN", "BR": "BRL", "AR": "ARS", "CL": "CLP",
+	"CO": "COP", "PE": "PEN", "UY": "UYU", "PY": "PYG", "BO": "BOB", "VE": "VES",
+	"EC": "USD", "SV": "USD", "PA": "PAB", "CR": "CRC", "GT": "GTQ", "HN": "HNL",
+	"NI": "NIO", "DO": "DOP", "JM": "JMD", "TT": "TTD", "PR": "USD", "CU": "CUP",
+
+	// Asia and Oceania
+	"CN": "CNY", "HK": "HKD", "MO": "MOP", "TW": "TWD", "JP": "JPY", "KR": "KRW",
+	"IN": "INR", "PK": "PKR", "BD": "BDT", "LK": "LKR", "NP": "NPR", "ID": "IDR",
+	"MY": "MYR", "SG": "SGD", "TH": "THB", "VN": "VND", "PH": "PHP", "KH": "KHR",
+	"MM": "MMK", "MN": "MNT", "KZ": "KZT", "UZ": "UZS", "AU": "AUD", "NZ": "NZD",
+	"FJ": "FJD", "PG": "PGK",
+
+	// Middle East
+	"AE": "AED", "SA": "SAR", "QA": "QAR", "KW": "KWD", "BH": "BHD", "OM": "OMR",
+	"IL": "ILS", "JO": "JOD", "LB": "LBP", "IQ": "IQD", "IR": "IRR",
+
+	// Africa
+	"ZA": "ZAR", "NG": "NGN", "KE": "KES", "GH": "GHS", "EG": "EGP", "MA": "MAD",
+	"DZ": "DZD", "TN": "TND", "ET": "ETB", "TZ": "TZS", "UG": "UGX", "RW": "RWF",
+	"ZM": "ZMW", "BW": "BWP", "NA": "NAD", "MU": "MUR", "AO": "AOA", "MZ": "MZN",
+	"SN": "XOF", "CI": "XOF", "ML": "XOF", "BF": "XOF", "NE": "XOF", "TG": "XOF",
+	"BJ": "XOF", "CM": "XAF", "GA": "XAF", "CG": "XAF", "TD": "XAF", "CF": "XAF",
+}
+
+// ForCountry returns the currency of an ISO 3166-1 alpha-2 country code, or
+// "" when the country is unknown
+func ForCountry(country string) string {
+	return countryCurrencies[strings.ToUpper(country)]
+}
@@ -0,0 +1,178 @@
+package currency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ErrUnavailable is returned when no exchange rates can be provided
+var ErrUnavailable = errors.New("exchange rates unavailable")
+
+var validate = validator.New()
+
+// Valid reports whether code is an ISO 4217 currency code, e.g. "EUR"
+func Valid(code string) bool {
+	return validate.Var(code, "iso4217") == nil
+}
+
+// Rates are exchange rates against a base currency: one unit of Base is
+// worth Values[code] units of code
+type Rates struct {
+	Base      string
+	Values    map[string]float64
+	FetchedAt time.Time
+}
+
+// Factor returns what one unit of from is worth in to
+func (r *Rates) Factor(from, to string) (float64, bool) {
+	if from == to {
+		return 1, true
+	}
+	fromRate, ok := r.Values[from]
+	if !ok || fromRate <= 0 {
+		return 0, false
+	}
+	toRate, ok := r.Values[to]
+	if !ok || toRate <= 0 {
+		return 0, false
+	}
+	return toRate / fromRate, true
+}
+
+// Provider supplies exchange rates
+type Provider interface {
+	// Rates returns the current rates, or ErrUnavailable
+	Rates(ctx context.Context) (*Rates, error)
+}
+
+type httpProvider struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPProvider creates a Provider fetching the rates from url, which
+// returns a JSON object with the base currency in "base" or "base_code" and
+// the rates in "rates", like https://open.er-api.com/v6/latest/USD or
+// https://api.frankfurter.app/latest
+func NewHTTPProvider(url string, timeout time.Duration) Provider {
+	return &httpProvider{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *httpProvider) Rates(ctx context.Context) (*Rates, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exchange rate provider returned %s", resp.Status)
+	}
+
+	var body struct {
+		Base     string             `json:"base"`
+		BaseCode string             `json:"base_code"`
+		Rates    map[string]float64 `json:"rates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding exchange rates: %w", err)
+	}
+
+	base := strings.ToUpper(body.Base)
+	if base == "" {
+		base = strings.ToUpper(body.BaseCode)
+	}
+	if base == "" || len(body.Rates) == 0 {
+		return nil, errors.New("exchange rate provider returned no rates")
+	}
+
+	rates := &Rates{Base: base, Values: make(map[string]float64, len(body.Rates)+1), FetchedAt: time.Now()}
+	for code, value := range body.Rates {
+		rates.Values[strings.ToUpper(code)] = value
+	}
+	// Some providers leave the base out of its own rates
+	rates.Values[base] = 1
+	return rates, nil
+}
+
+type cachedProvider struct {
+	provider Provider
+	ttl      time.Duration
+
+	mu        sync.Mutex
+	rates     *Rates
+	checkedAt time.Time
+	failedAt  time.Time
+}
+
+// retryInterval spaces the attempts to fetch rates while none could be fetched
+const retryInterval = time.Minute
+
+// NewCachedProvider creates a Provider fetching the rates from provider at
+// most once per ttl. When a refresh fails the previous rates are served
+// until the next attempt.
+func NewCachedProvider(provider Provider, ttl time.Duration) Provider {
+	return &cachedProvider{
+		provider: provider,
+		ttl:      ttl,
+	}
+}
+
+func (p *cachedProvider) Rates(ctx context.Context) (*Rates, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.rates != nil && time.Since(p.checkedAt) < p.ttl {
+		return p.rates, nil
+	}
+	// Don't hold every request up while the provider is down
+	if p.rates == nil && time.Since(p.failedAt) < retryInterval {
+		return nil, ErrUnavailable
+	}
+
+	rates, err := p.provider.Rates(ctx)
+	if err != nil {
+		if p.rates == nil {
+			p.failedAt = time.Now()
+			return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+		}
+		log.Printf("Failed to refresh exchange rates, keeping the rates of %s: %v", p.rates.FetchedAt.Format(time.RFC3339), err)
+		// Retry once the ttl passed again instead of on every request
+		p.checkedAt = time.Now()
+		return p.rates, nil
+	}
+
+	p.rates = rates
+	p.checkedAt = time.Now()
+	return rates, nil
+}
+
+type noProvider struct{}
+
+// NewNoopProvider creates a Provider for when no exchange rate source is
+// configured; salaries are shown and filtered in their own currency
+func NewNoopProvider() Provider {
+	return noProvider{}
+}
+
+func (noProvider) Rates(context.Context) (*Rates, error) {
+	return nil, ErrUnavailable
+}
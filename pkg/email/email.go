@@ -0,0 +1,69 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/smtp"
+	"strings"
+)
+
+// Message represents an outgoing email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers email messages
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SMTPConfig holds the settings needed to talk to an SMTP relay
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type smtpSender struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPSender creates a Sender that delivers mail through an SMTP relay
+func NewSMTPSender(cfg SMTPConfig) Sender {
+	return &smtpSender{cfg: cfg}
+}
+
+func (s *smtpSender) Send(ctx context.Context, msg Message) error {
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", s.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
+	b.WriteString(msg.Body)
+
+	return smtp.SendMail(s.cfg.Host+":"+s.cfg.Port, auth, s.cfg.From, []string{msg.To}, []byte(b.String()))
+}
+
+type logSender struct{}
+
+// NewLogSender creates a Sender that only logs messages.
+// It is used in development when no SMTP relay is configured.
+func NewLogSender() Sender {
+	return &logSender{}
+}
+
+func (s *logSender) Send(ctx context.Context, msg Message) error {
+	log.Printf("Email to %s: %s\n%s\n", msg.To, msg.Subject, msg.Body)
+	return nil
+}
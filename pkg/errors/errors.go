@@ -1,6 +1,7 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 	"net/http"
 )
@@ -48,6 +49,20 @@ func NewNotFoundError(message string) *AppError {
 	}
 }
 
+func NewConflictError(message string) *AppError {
+	return &AppError{
+		Code:    http.StatusConflict,
+		Message: message,
+	}
+}
+
+func NewTooManyRequestsError(message string) *AppError {
+	return &AppError{
+		Code:    http.StatusTooManyRequests,
+		Message: message,
+	}
+}
+
 func NewInternalServerError(err error) *AppError {
 	return &AppError{
 		Code:    http.StatusInternalServerError,
@@ -56,6 +71,32 @@ func NewInternalServerError(err error) *AppError {
 	}
 }
 
+// WithDetails attaches details (e.g. a list of reasons) to the error
+func (e *AppError) WithDetails(details interface{}) *AppError {
+	e.Details = details
+	return e
+}
+
+// Wrap records the underlying error, so callers can match it with errors.Is
+func (e *AppError) Wrap(err error) *AppError {
+	e.Err = err
+	return e
+}
+
+// Unwrap returns the underlying error
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// As returns the AppError wrapped in err, if any
+func As(err error) (*AppError, bool) {
+	var appErr *AppError
+	if stderrors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
 // ErrorResponse represents the standard error response structure
 type ErrorResponse struct {
 	Success bool        `json:"success"`
@@ -0,0 +1,36 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+var errRecordNotFound = stderrors.New("record not found")
+
+func TestWrappedErrorMatchesThroughLayers(t *testing.T) {
+	// As a use case returns it, wrapped again by a caller adding context
+	err := fmt.Errorf("error loading record: %w", NewNotFoundError("Record not found").Wrap(errRecordNotFound))
+
+	if !stderrors.Is(err, errRecordNotFound) {
+		t.Fatalf("errors.Is(%v, errRecordNotFound) = false, want true", err)
+	}
+	appErr, ok := As(err)
+	if !ok {
+		t.Fatalf("As(%v) found no AppError", err)
+	}
+	if appErr.Code != http.StatusNotFound || appErr.Message != "Record not found" {
+		t.Fatalf("As(%v) = %d %q, want %d %q", err, appErr.Code, appErr.Message, http.StatusNotFound, "Record not found")
+	}
+}
+
+func TestUnwrapWithoutCause(t *testing.T) {
+	err := NewConflictError("Already exists")
+	if err.Unwrap() != nil {
+		t.Fatalf("Unwrap() = %v, want nil", err.Unwrap())
+	}
+	if stderrors.Is(err, errRecordNotFound) {
+		t.Fatalf("errors.Is(%v, errRecordNotFound) = true, want false", err)
+	}
+}
@@ -0,0 +1,115 @@
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrNotFound = errors.New("location not found")
+
+// Point is a position in WGS 84 degrees
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// Geocoder resolves free-form locations ("Berlin, Germany") to coordinates
+type Geocoder interface {
+	// Geocode returns the best match for the location, or ErrNotFound
+	Geocode(ctx context.Context, location string) (Point, error)
+}
+
+type nominatimGeocoder struct {
+	baseURL   string
+	userAgent string
+	client    *http.Client
+
+	// Public Nominatim servers allow one request per second
+	mu          sync.Mutex
+	interval    time.Duration
+	lastRequest time.Time
+}
+
+// NewNominatimGeocoder creates a Geocoder calling the search API of the
+// Nominatim server at baseURL, e.g. https://nominatim.openstreetmap.org.
+// Requests are spaced by at least interval and identify the caller with userAgent.
+func NewNominatimGeocoder(baseURL, userAgent string, interval, timeout time.Duration) Geocoder {
+	return &nominatimGeocoder{
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		userAgent: userAgent,
+		client:    &http.Client{Timeout: timeout},
+		interval:  interval,
+	}
+}
+
+func (g *nominatimGeocoder) Geocode(ctx context.Context, location string) (Point, error) {
+	if err := g.wait(ctx); err != nil {
+		return Point{}, err
+	}
+
+	query := url.Values{"q": {location}, "format": {"jsonv2"}, "limit": {"1"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseURL+"/search?"+query.Encode(), nil)
+	if err != nil {
+		return Point{}, err
+	}
+	req.Header.Set("User-Agent", g.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return Point{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Point{}, fmt.Errorf("geocoder responded with status %d", resp.StatusCode)
+	}
+
+	// Nominatim returns coordinates as strings
+	var results []struct {
+		Lat string `json:"lat"`
+		Lon string `json:"lon"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return Point{}, fmt.Errorf("error decoding geocoder response: %w", err)
+	}
+	if len(results) == 0 {
+		return Point{}, ErrNotFound
+	}
+
+	lat, err := strconv.ParseFloat(results[0].Lat, 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid latitude in geocoder response: %w", err)
+	}
+	lng, err := strconv.ParseFloat(results[0].Lon, 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid longitude in geocoder response: %w", err)
+	}
+	return Point{Lat: lat, Lng: lng}, nil
+}
+
+// wait blocks until the next request may be sent
+func (g *nominatimGeocoder) wait(ctx context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if delay := time.Until(g.lastRequest.Add(g.interval)); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	g.lastRequest = time.Now()
+	return nil
+}
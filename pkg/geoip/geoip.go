@@ -0,0 +1,56 @@
+package geoip
+
+import (
+	"net"
+	"strings"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// Locator resolves IP addresses to ISO 3166-1 alpha-2 country codes
+type Locator interface {
+	// Country returns the country of ip, or "" when it is unknown
+	Country(ip string) string
+}
+
+type mmdbLocator struct {
+	db *maxminddb.Reader
+}
+
+// OpenMMDB creates a Locator reading a local MaxMind DB file, e.g. GeoLite2-Country.mmdb.
+// The whole file is memory mapped, lookups don't do any I/O.
+func OpenMMDB(path string) (Locator, error) {
+	db, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &mmdbLocator{db: db}, nil
+}
+
+func (l *mmdbLocator) Country(ip string) string {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return ""
+	}
+
+	var record struct {
+		Country struct {
+			ISOCode string `maxminddb:"iso_code"`
+		} `maxminddb:"country"`
+	}
+	if err := l.db.Lookup(addr, &record); err != nil {
+		return ""
+	}
+	return strings.ToUpper(record.Country.ISOCode)
+}
+
+type noLocator struct{}
+
+// NewNoopLocator creates a Locator for when no database is configured; every country is unknown
+func NewNoopLocator() Locator {
+	return noLocator{}
+}
+
+func (noLocator) Country(string) string {
+	return ""
+}
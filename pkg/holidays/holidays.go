@@ -0,0 +1,141 @@
+package holidays
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnknownCountry is returned for countries the calendar has no holidays for
+var ErrUnknownCountry = errors.New("unknown holiday country")
+
+// Holiday is a nationwide public holiday
+type Holiday struct {
+	// Date is the day of the holiday, as YYYY-MM-DD
+	Date    string `json:"date"`
+	Name    string `json:"name"`
+	Country string `json:"country"`
+}
+
+// Calendar lists public holidays
+type Calendar interface {
+	// Holidays returns the holidays of an ISO 3166-1 alpha-2 country in a year
+	Holidays(ctx context.Context, country string, year int) ([]Holiday, error)
+}
+
+type nagerCalendar struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewNagerCalendar creates a Calendar calling the public holiday API of the
+// Nager.Date server at baseURL, e.g. https://date.nager.at. Regional holidays
+// are left out.
+func NewNagerCalendar(baseURL string, timeout time.Duration) Calendar {
+	return &nagerCalendar{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *nagerCalendar) Holidays(ctx context.Context, country string, year int) ([]Holiday, error) {
+	url := fmt.Sprintf("%s/api/v3/PublicHolidays/%d/%s", c.baseURL, year, strings.ToUpper(country))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusNoContent:
+		return nil, ErrUnknownCountry
+	default:
+		return nil, fmt.Errorf("holiday calendar returned %s", resp.Status)
+	}
+
+	var results []struct {
+		Date        string `json:"date"`
+		Name        string `json:"name"`
+		CountryCode string `json:"countryCode"`
+		Global      bool   `json:"global"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("decoding holidays: %w", err)
+	}
+
+	holidays := make([]Holiday, 0, len(results))
+	for _, r := range results {
+		if !r.Global {
+			continue
+		}
+		holidays = append(holidays, Holiday{Date: r.Date, Name: r.Name, Country: r.CountryCode})
+	}
+	return holidays, nil
+}
+
+type cachedCalendar struct {
+	calendar Calendar
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedHolidays
+}
+
+type cachedHolidays struct {
+	holidays  []Holiday
+	fetchedAt time.Time
+}
+
+// NewCachedCalendar creates a Calendar asking calendar for the holidays of a
+// country and year at most once per ttl
+func NewCachedCalendar(calendar Calendar, ttl time.Duration) Calendar {
+	return &cachedCalendar{
+		calendar: calendar,
+		ttl:      ttl,
+		entries:  map[string]cachedHolidays{},
+	}
+}
+
+func (c *cachedCalendar) Holidays(ctx context.Context, country string, year int) ([]Holiday, error) {
+	key := fmt.Sprintf("%s/%d", strings.ToUpper(country), year)
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < c.ttl {
+		return entry.holidays, nil
+	}
+
+	holidays, err := c.calendar.Holidays(ctx, country, year)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = cachedHolidays{holidays: holidays, fetchedAt: time.Now()}
+	c.mu.Unlock()
+	return holidays, nil
+}
+
+type noCalendar struct{}
+
+// NewNoopCalendar creates a Calendar for when no holiday source is configured; there are no holidays
+func NewNoopCalendar() Calendar {
+	return noCalendar{}
+}
+
+func (noCalendar) Holidays(context.Context, string, int) ([]Holiday, error) {
+	return nil, nil
+}
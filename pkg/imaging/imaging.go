@@ -0,0 +1,86 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format, use JPEG, PNG or WebP")
+	ErrTooLarge          = errors.New("image dimensions are too large")
+)
+
+// maxPixels bounds the decoded size of an image, so a small file can't expand
+// into gigabytes of memory
+const maxPixels = 40_000_000
+
+// allowedTypes are the sniffed content types images may be uploaded as
+var allowedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+// Square crops the centre of an image to a square and scales it to size×size
+// pixels. PNG images stay PNG to keep their transparency, other formats are
+// encoded as JPEG. It returns the encoded image and its content type.
+func Square(r io.Reader, size int) ([]byte, string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Trust the bytes, not the client's Content-Type header
+	contentType := http.DetectContentType(data)
+	if !allowedTypes[contentType] {
+		return nil, "", ErrUnsupportedFormat
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", ErrUnsupportedFormat
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, "", ErrTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("error decoding image: %v", err)
+	}
+
+	bounds := src.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(
+		bounds.Min.X+(bounds.Dx()-side)/2,
+		bounds.Min.Y+(bounds.Dy()-side)/2,
+	))
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, draw.Src, nil)
+
+	var out bytes.Buffer
+	if contentType == "image/png" {
+		err = png.Encode(&out, dst)
+	} else {
+		contentType = "image/jpeg"
+		err = jpeg.Encode(&out, dst, &jpeg.Options{Quality: 85})
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("error encoding image: %v", err)
+	}
+
+	return out.Bytes(), contentType, nil
+}
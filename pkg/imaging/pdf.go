@@ -0,0 +1,65 @@
+package imaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var ErrNotPDF = errors.New("file is not a PDF document")
+
+// PDFRenderer renders the first page of PDF documents as PNG images
+type PDFRenderer interface {
+	// FirstPage renders the document's first page width pixels wide
+	FirstPage(ctx context.Context, pdf []byte, width int) ([]byte, error)
+}
+
+type popplerRenderer struct {
+	path string
+}
+
+// NewPopplerRenderer creates a PDFRenderer running poppler's pdftoppm, found
+// at command or on the PATH. It fails when pdftoppm isn't installed.
+func NewPopplerRenderer(command string) (PDFRenderer, error) {
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return nil, err
+	}
+	return &popplerRenderer{path: path}, nil
+}
+
+func (r *popplerRenderer) FirstPage(ctx context.Context, pdf []byte, width int) ([]byte, error) {
+	// Trust the bytes, not the file name
+	if http.DetectContentType(pdf) != "application/pdf" {
+		return nil, ErrNotPDF
+	}
+
+	dir, err := os.MkdirTemp("", "pdf-render")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "document.pdf")
+	if err := os.WriteFile(input, pdf, 0600); err != nil {
+		return nil, err
+	}
+
+	// -scale-to-y -1 keeps the page's aspect ratio
+	output := filepath.Join(dir, "page")
+	cmd := exec.CommandContext(ctx, r.path,
+		"-f", "1", "-l", "1", "-singlefile", "-png",
+		"-scale-to-x", strconv.Itoa(width), "-scale-to-y", "-1",
+		input, output)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("error rendering PDF: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	return os.ReadFile(output + ".png")
+}
@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// Retention is how far back the recorder keeps counts
+const Retention = 24 * time.Hour
+
+// bucketCount is the number of one minute buckets covering Retention
+const bucketCount = int(Retention / time.Minute)
+
+// Recorder counts requests, server errors and health checks in one minute
+// buckets over the last Retention. Counts are in memory and per process, so
+// they restart from zero with the process.
+type Recorder struct {
+	mu      sync.Mutex
+	buckets [bucketCount]bucket
+}
+
+type bucket struct {
+	minute   int64
+	requests int64
+	errors   int64
+	checks   int64
+	checksUp int64
+}
+
+// Snapshot sums the counts of a window
+type Snapshot struct {
+	Requests int64
+	// Errors counts the requests answered with a 5xx status
+	Errors   int64
+	Checks   int64
+	ChecksUp int64
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// RecordRequest counts a request answered with the given HTTP status
+func (r *Recorder) RecordRequest(status int) {
+	r.record(time.Now(), func(b *bucket) {
+		b.requests++
+		if status >= 500 {
+			b.errors++
+		}
+	})
+}
+
+// RecordCheck counts a health check, up when every dependency was reachable
+func (r *Recorder) RecordCheck(up bool) {
+	r.record(time.Now(), func(b *bucket) {
+		b.checks++
+		if up {
+			b.checksUp++
+		}
+	})
+}
+
+func (r *Recorder) record(now time.Time, fn func(b *bucket)) {
+	minute := now.Unix() / 60
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := &r.buckets[minute%int64(bucketCount)]
+	if b.minute != minute {
+		*b = bucket{minute: minute}
+	}
+	fn(b)
+}
+
+// Window sums the counts of the last window, at most Retention
+func (r *Recorder) Window(window time.Duration) Snapshot {
+	now := time.Now().Unix() / 60
+	oldest := now - int64(window/time.Minute) + 1
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var s Snapshot
+	for _, b := range r.buckets {
+		if b.minute < oldest || b.minute > now {
+			continue
+		}
+		s.Requests += b.requests
+		s.Errors += b.errors
+		s.Checks += b.checks
+		s.ChecksUp += b.checksUp
+	}
+	return s
+}
@@ -0,0 +1,206 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/endpoints"
+)
+
+var (
+	ErrUnknownProvider = errors.New("unknown oauth provider")
+	// ErrNonPublicAddress is returned when a provider's URL resolves to a
+	// loopback, private or link-local address
+	ErrNonPublicAddress = errors.New("provider address is not public")
+)
+
+// Profile is the identity returned by a provider's userinfo endpoint
+type Profile struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+// Provider is an OAuth2/OpenID Connect identity provider
+type Provider struct {
+	Name        string
+	config      *oauth2.Config
+	userInfoURL string
+	// client makes the requests to the provider, http.DefaultClient when nil
+	client *http.Client
+}
+
+// ProviderConfig holds the client credentials registered with a provider
+type ProviderConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+}
+
+// NewGoogleProvider creates a provider for "Sign in with Google"
+func NewGoogleProvider(cfg ProviderConfig) *Provider {
+	return &Provider{
+		Name: "google",
+		config: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     endpoints.Google,
+			Scopes:       []string{"openid", "email", "profile"},
+		},
+		userInfoURL: "https://openidconnect.googleapis.com/v1/userinfo",
+	}
+}
+
+// NewLinkedInProvider creates a provider for "Sign in with LinkedIn" (OpenID Connect)
+func NewLinkedInProvider(cfg ProviderConfig) *Provider {
+	return &Provider{
+		Name: "linkedin",
+		config: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     endpoints.LinkedIn,
+			Scopes:       []string{"openid", "email", "profile"},
+		},
+		userInfoURL: "https://api.linkedin.com/v2/userinfo",
+	}
+}
+
+// AuthCodeURL returns the provider consent page URL the user is redirected to
+func (p *Provider) AuthCodeURL(state string) string {
+	return p.config.AuthCodeURL(state)
+}
+
+// Exchange trades the authorization code for a token and fetches the user's profile
+func (p *Provider) Exchange(ctx context.Context, code string) (*Profile, error) {
+	if p.client != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, p.client)
+	}
+	token, err := p.config.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("error exchanging authorization code: %v", err)
+	}
+
+	resp, err := p.config.Client(ctx, token).Get(p.userInfoURL)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching user info: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching user info: unexpected status %d", resp.StatusCode)
+	}
+
+	var profile Profile
+	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+		return nil, fmt.Errorf("error decoding user info: %v", err)
+	}
+
+	if profile.Subject == "" {
+		return nil, errors.New("provider returned a profile without a subject")
+	}
+
+	return &profile, nil
+}
+
+// discovery is the part of an OpenID Connect discovery document the provider needs
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+// publicClient makes the requests to OpenID Connect providers. Their URLs are
+// configured by companies, so it only connects to public addresses: an issuer
+// or endpoint can't be pointed at the internal network.
+var publicClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: refuseNonPublic,
+		}).DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+	},
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, not reachable from the internet
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// refuseNonPublic is checked on every connection, once the address is
+// resolved, so a hostname can't resolve to an internal address either
+func refuseNonPublic(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() || sharedAddressSpace.Contains(ip) {
+		return ErrNonPublicAddress
+	}
+	return nil
+}
+
+// NewOIDCProvider creates a provider for any OpenID Connect identity provider,
+// reading its endpoints from the issuer's discovery document
+func NewOIDCProvider(ctx context.Context, name, issuer string, cfg ProviderConfig) (*Provider, error) {
+	issuer = strings.TrimRight(issuer, "/")
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := publicClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching discovery document: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching discovery document: unexpected status %d", resp.StatusCode)
+	}
+
+	var doc discovery
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("error decoding discovery document: %v", err)
+	}
+
+	// The document must describe the issuer it was fetched from
+	if strings.TrimRight(doc.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("discovery document issuer %q does not match %q", doc.Issuer, issuer)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.UserInfoEndpoint == "" {
+		return nil, errors.New("discovery document is missing the authorization, token or userinfo endpoint")
+	}
+
+	return &Provider{
+		Name: name,
+		config: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  doc.AuthorizationEndpoint,
+				TokenURL: doc.TokenEndpoint,
+			},
+			Scopes: []string{"openid", "email", "profile"},
+		},
+		userInfoURL: doc.UserInfoEndpoint,
+		client:      publicClient,
+	}, nil
+}
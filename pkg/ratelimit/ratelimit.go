@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter is an in-memory fixed window rate limiter keyed by an arbitrary string
+// (API key ID, IP address, ...). Limits are per process.
+type Limiter struct {
+	window time.Duration
+
+	mu       sync.Mutex
+	counters map[string]*counter
+	lastGC   time.Time
+}
+
+type counter struct {
+	start time.Time
+	count int
+}
+
+// Result describes the state of a key's window after a call to Allow
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+func NewLimiter(window time.Duration) *Limiter {
+	return &Limiter{
+		window:   window,
+		counters: make(map[string]*counter),
+		lastGC:   time.Now(),
+	}
+}
+
+// Allow records a request for key and reports whether it is within limit
+func (l *Limiter) Allow(key string, limit int) Result {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.collectGarbage(now)
+
+	c, ok := l.counters[key]
+	if !ok || now.Sub(c.start) >= l.window {
+		c = &counter{start: now}
+		l.counters[key] = c
+	}
+
+	result := Result{Limit: limit, Reset: c.start.Add(l.window)}
+	if c.count >= limit {
+		return result
+	}
+
+	c.count++
+	result.Allowed = true
+	result.Remaining = limit - c.count
+	return result
+}
+
+// collectGarbage drops expired windows so idle keys don't accumulate
+func (l *Limiter) collectGarbage(now time.Time) {
+	if now.Sub(l.lastGC) < l.window {
+		return
+	}
+	for key, c := range l.counters {
+		if now.Sub(c.start) >= l.window {
+			delete(l.counters, key)
+		}
+	}
+	l.lastGC = now
+}
@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// gridFSMetadata is stored in the metadata field of each GridFS file
+type gridFSMetadata struct {
+	ContentType string `bson:"content_type"`
+}
+
+// GridFSStorage stores files in MongoDB GridFS so small deployments don't need
+// an external storage provider. Files are served by the API at baseURL + "/" + key.
+type GridFSStorage struct {
+	bucket     *gridfs.Bucket
+	baseURL    string
+	quotaBytes int64
+}
+
+// NewGridFSStorage creates a GridFS backed Storage in the "files" bucket.
+// A quotaBytes value of zero disables the quota.
+func NewGridFSStorage(db *mongo.Database, baseURL string, quotaBytes int64) (*GridFSStorage, error) {
+	bucket, err := gridfs.NewBucket(db, options.GridFSBucket().SetName("files"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &GridFSStorage{
+		bucket:     bucket,
+		baseURL:    baseURL,
+		quotaBytes: quotaBytes,
+	}, nil
+}
+
+func (s *GridFSStorage) Upload(ctx context.Context, key, contentType string, r io.Reader) (string, error) {
+	if s.quotaBytes > 0 {
+		used, err := s.UsedBytes(ctx)
+		if err != nil {
+			return "", err
+		}
+		if used >= s.quotaBytes {
+			return "", ErrQuotaExceeded
+		}
+	}
+
+	opts := options.GridFSUpload().SetMetadata(gridFSMetadata{ContentType: contentType})
+	stream, err := s.bucket.OpenUploadStream(key, opts)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.Copy(stream, r); err != nil {
+		_ = stream.Abort()
+		return "", err
+	}
+
+	if err := stream.Close(); err != nil {
+		return "", err
+	}
+
+	return s.baseURL + "/" + key, nil
+}
+
+// Download opens a stream over the stored file. The caller must close it.
+func (s *GridFSStorage) Download(ctx context.Context, key string) (io.ReadCloser, *FileInfo, error) {
+	stream, err := s.bucket.OpenDownloadStreamByName(key)
+	if err != nil {
+		if errors.Is(err, gridfs.ErrFileNotFound) {
+			return nil, nil, ErrFileNotFound
+		}
+		return nil, nil, err
+	}
+
+	file := stream.GetFile()
+
+	var meta gridFSMetadata
+	if file.Metadata != nil {
+		_ = bson.Unmarshal(file.Metadata, &meta)
+	}
+
+	return stream, &FileInfo{
+		Key:         key,
+		ContentType: meta.ContentType,
+		Size:        file.Length,
+		UploadedAt:  file.UploadDate,
+	}, nil
+}
+
+func (s *GridFSStorage) Delete(ctx context.Context, url string) error {
+	key, ok := keyFromURL(s.baseURL, url)
+	if !ok {
+		return nil
+	}
+
+	// A file uploaded twice under the same key has one revision per upload
+	cursor, err := s.bucket.Find(bson.M{"filename": key})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var files []struct {
+		ID interface{} `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &files); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if err := s.bucket.Delete(file.ID); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// UsedBytes returns the total size of all files stored in the bucket
+func (s *GridFSStorage) UsedBytes(ctx context.Context) (int64, error) {
+	cursor, err := s.bucket.GetFilesCollection().Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": nil, "total": bson.M{"$sum": "$length"}}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Total int64 `bson:"total"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return 0, err
+	}
+
+	if len(result) == 0 {
+		return 0, nil
+	}
+
+	return result[0].Total, nil
+}
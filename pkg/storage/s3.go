@@ -0,0 +1,201 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// emptyPayloadHash is the SHA-256 of an empty request body
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3Config locates a bucket of an S3 compatible object store (AWS S3, MinIO, R2, ...)
+type S3Config struct {
+	// Endpoint is the base URL of the service, e.g. https://s3.eu-west-1.amazonaws.com
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3Storage stores files as objects of an S3 bucket, addressed path style
+// (Endpoint/Bucket/key) so it works with S3 compatible servers. Requests are
+// signed with AWS Signature Version 4. Upload returns the key, objects are
+// read back through Download.
+type S3Storage struct {
+	cfg    S3Config
+	client *http.Client
+}
+
+// NewS3Storage creates an S3 backed Store
+func NewS3Storage(cfg S3Config, timeout time.Duration) *S3Storage {
+	cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+	return &S3Storage{
+		cfg:    cfg,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *S3Storage) Upload(ctx context.Context, key, contentType string, r io.Reader) (string, error) {
+	// S3 needs the length and, for signing, the checksum of the body before
+	// it is sent, so the stream is written to a temporary file first
+	f, err := os.CreateTemp("", "s3-upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, hash), r)
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, f, hex.EncodeToString(hash.Sum(nil)))
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", s3Error(resp)
+	}
+	return key, nil
+}
+
+// Download opens the object. The caller must close it.
+func (s *S3Storage) Download(ctx context.Context, key string) (io.ReadCloser, *FileInfo, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil, emptyPayloadHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, nil, ErrFileNotFound
+		}
+		return nil, nil, s3Error(resp)
+	}
+
+	info := &FileInfo{Key: key, ContentType: resp.Header.Get("Content-Type"), Size: resp.ContentLength}
+	if modified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		info.UploadedAt = modified
+	}
+	return resp.Body, info, nil
+}
+
+// Delete removes the object stored under url, the key returned by Upload
+func (s *S3Storage) Delete(ctx context.Context, url string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, url, nil, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Deleting a missing object succeeds as well
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return s3Error(resp)
+	}
+	return nil
+}
+
+// newRequest builds a signed request on the object stored under key
+func (s *S3Storage) newRequest(ctx context.Context, method, key string, body io.Reader, payloadHash string) (*http.Request, error) {
+	if key == "" || strings.Contains(key, "/") {
+		return nil, fmt.Errorf("invalid object key %q", key)
+	}
+	path := "/" + uriEncode(s.cfg.Bucket) + "/" + uriEncode(key)
+	req, err := http.NewRequestWithContext(ctx, method, s.cfg.Endpoint+path, body)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req, path, payloadHash, time.Now().UTC())
+	return req, nil
+}
+
+// sign adds the AWS Signature Version 4 headers to req
+func (s *S3Storage) sign(req *http.Request, path, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		"",
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), date)
+	for _, part := range []string{s.cfg.Region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// uriEncode escapes every byte but the unreserved characters, as signing expects
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Error describes a failed request, with the start of the XML error body
+func s3Error(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("object store returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,228 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// pendingScheme prefixes the placeholder URL returned for spooled uploads
+const pendingScheme = "pending://"
+
+// IsPending reports whether url is a placeholder for an upload that is still spooled
+func IsPending(url string) bool {
+	return strings.HasPrefix(url, pendingScheme)
+}
+
+// spoolMeta is stored next to each spooled file
+type spoolMeta struct {
+	Key         string    `json:"key"`
+	ContentType string    `json:"content_type"`
+	SpooledAt   time.Time `json:"spooled_at"`
+	Attempts    int       `json:"attempts"`
+}
+
+// SpoolingStorage wraps a primary Storage and, when the primary is unavailable,
+// writes the file to a local spool directory instead of failing. Spooled files
+// are uploaded by RetryPending once the provider recovers.
+type SpoolingStorage struct {
+	primary    Storage
+	dir        string
+	onUploaded func(ctx context.Context, pendingURL, url string) error
+}
+
+// NewSpoolingStorage creates a SpoolingStorage. onUploaded is called after a spooled
+// file has been uploaded so that references to the placeholder URL can be replaced.
+func NewSpoolingStorage(primary Storage, dir string, onUploaded func(ctx context.Context, pendingURL, url string) error) *SpoolingStorage {
+	return &SpoolingStorage{
+		primary:    primary,
+		dir:        dir,
+		onUploaded: onUploaded,
+	}
+}
+
+func (s *SpoolingStorage) Upload(ctx context.Context, key, contentType string, r io.Reader) (string, error) {
+	// Buffer the file so it can be spooled if the primary upload fails midway
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	url, err := s.primary.Upload(ctx, key, contentType, bytes.NewReader(data))
+	if err == nil {
+		return url, nil
+	}
+	if !unavailable(err) {
+		// Retrying wouldn't help, e.g. the quota is used up or the file was refused
+		return "", err
+	}
+
+	log.Printf("Storage provider unavailable, spooling %s: %v\n", key, err)
+	if spoolErr := s.spool(key, contentType, data); spoolErr != nil {
+		// Nothing more we can do; report the original provider error
+		return "", err
+	}
+
+	return pendingScheme + key, nil
+}
+
+// Delete removes the file stored at url, from the spool while its upload is
+// still pending and from the primary provider once it went through
+func (s *SpoolingStorage) Delete(ctx context.Context, url string) error {
+	if IsPending(url) {
+		name := filepath.Base(strings.TrimPrefix(url, pendingScheme))
+		for _, path := range []string{filepath.Join(s.dir, name+".meta"), filepath.Join(s.dir, name)} {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	deleter, ok := s.primary.(Deleter)
+	if !ok {
+		return nil
+	}
+	return deleter.Delete(ctx, url)
+}
+
+// unavailable reports whether err means the provider couldn't be reached,
+// as opposed to it refusing the file
+func unavailable(err error) bool {
+	if errors.Is(err, ErrQuotaExceeded) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var pathErr *os.PathError
+	return mongo.IsNetworkError(err) || mongo.IsTimeout(err) ||
+		errors.Is(err, mongo.ErrClientDisconnected) || errors.As(err, &pathErr)
+}
+
+func (s *SpoolingStorage) spool(key, contentType string, data []byte) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return err
+	}
+
+	name := filepath.Base(key)
+	if err := os.WriteFile(filepath.Join(s.dir, name), data, 0600); err != nil {
+		return err
+	}
+
+	return s.writeMeta(name, &spoolMeta{
+		Key:         key,
+		ContentType: contentType,
+		SpooledAt:   time.Now(),
+	})
+}
+
+func (s *SpoolingStorage) writeMeta(name string, meta *spoolMeta) error {
+	b, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.dir, name+".meta"), b, 0600)
+}
+
+// Pending returns the number of spooled files waiting to be uploaded
+func (s *SpoolingStorage) Pending() (int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	pending := 0
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".meta") {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// RetryPending tries to upload every spooled file to the primary provider
+func (s *SpoolingStorage) RetryPending(ctx context.Context) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Error reading spool directory: %v\n", err)
+		}
+		return
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".meta") {
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		name := strings.TrimSuffix(entry.Name(), ".meta")
+		if err := s.retry(ctx, name); err != nil {
+			log.Printf("Error uploading spooled file %s: %v\n", name, err)
+		}
+	}
+}
+
+func (s *SpoolingStorage) retry(ctx context.Context, name string) error {
+	metaPath := filepath.Join(s.dir, name+".meta")
+	dataPath := filepath.Join(s.dir, name)
+
+	b, err := os.ReadFile(metaPath)
+	if err != nil {
+		return err
+	}
+
+	var meta spoolMeta
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return err
+	}
+
+	f, err := os.Open(dataPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	url, err := s.primary.Upload(ctx, meta.Key, meta.ContentType, f)
+	if err != nil {
+		meta.Attempts++
+		_ = s.writeMeta(name, &meta)
+		return err
+	}
+
+	if s.onUploaded != nil {
+		if err := s.onUploaded(ctx, pendingScheme+meta.Key, url); err != nil {
+			return err
+		}
+	}
+
+	_ = os.Remove(dataPath)
+	return os.Remove(metaPath)
+}
+
+// Run retries spooled uploads on the given interval until ctx is cancelled
+func (s *SpoolingStorage) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RetryPending(ctx)
+		}
+	}
+}
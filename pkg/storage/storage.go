@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	ErrFileNotFound  = errors.New("file not found")
+	ErrQuotaExceeded = errors.New("storage quota exceeded")
+)
+
+// Storage uploads files to a storage provider and returns a URL the file can be fetched from
+type Storage interface {
+	Upload(ctx context.Context, key, contentType string, r io.Reader) (string, error)
+}
+
+// FileInfo describes a stored file
+type FileInfo struct {
+	Key         string
+	ContentType string
+	Size        int64
+	UploadedAt  time.Time
+}
+
+// Downloader is implemented by providers that serve files through the API
+// instead of a public URL
+type Downloader interface {
+	Download(ctx context.Context, key string) (io.ReadCloser, *FileInfo, error)
+}
+
+// Deleter is implemented by providers stored files can be removed from
+type Deleter interface {
+	// Delete removes the file stored at url. Files that are already gone or
+	// that are stored elsewhere are ignored.
+	Delete(ctx context.Context, url string) error
+}
+
+// Store is a Storage files can also be read back from and deleted
+type Store interface {
+	Storage
+	Downloader
+	Deleter
+}
+
+// keyFromURL returns the key of the file a provider serving files at
+// baseURL + "/" + key stores at url
+func keyFromURL(baseURL, url string) (string, bool) {
+	key, ok := strings.CutPrefix(url, baseURL+"/")
+	if !ok || key == "" || strings.Contains(key, "/") {
+		return "", false
+	}
+	return key, true
+}
+
+type localStorage struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalStorage creates a Storage that writes files to a directory on disk.
+// Files are addressed as baseURL + "/" + key.
+func NewLocalStorage(dir, baseURL string) Store {
+	return &localStorage{
+		dir:     dir,
+		baseURL: baseURL,
+	}
+}
+
+func (s *localStorage) Upload(ctx context.Context, key, contentType string, r io.Reader) (string, error) {
+	// Create the uploads folder if it doesn't exist
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return "", err
+	}
+
+	dst, err := os.Create(filepath.Join(s.dir, filepath.Base(key)))
+	if err != nil {
+		return "", err
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, r); err != nil {
+		return "", err
+	}
+
+	return s.baseURL + "/" + filepath.Base(key), nil
+}
+
+// Download opens the stored file. The caller must close it.
+func (s *localStorage) Download(ctx context.Context, key string) (io.ReadCloser, *FileInfo, error) {
+	f, err := os.Open(filepath.Join(s.dir, filepath.Base(key)))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, ErrFileNotFound
+		}
+		return nil, nil, err
+	}
+
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	return f, &FileInfo{
+		Key:        key,
+		Size:       stat.Size(),
+		UploadedAt: stat.ModTime(),
+	}, nil
+}
+
+func (s *localStorage) Delete(ctx context.Context, url string) error {
+	key, ok := keyFromURL(s.baseURL, url)
+	if !ok {
+		return nil
+	}
+
+	err := os.Remove(filepath.Join(s.dir, key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Sender posts JSON payloads to webhook URLs
+type Sender interface {
+	Send(ctx context.Context, url string, payload interface{}) error
+}
+
+type httpSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender creates a Sender that gives receivers timeout to answer
+func NewHTTPSender(timeout time.Duration) Sender {
+	return &httpSender{client: &http.Client{Timeout: timeout}}
+}
+
+func (s *httpSender) Send(ctx context.Context, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered with status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type ActivityRepository interface {
+	CreateMany(ctx context.Context, activities []*domain.Activity) error
+	// ListByUser returns the user's activity feed, most recent first
+	ListByUser(ctx context.Context, userID string, page, limit int) ([]*domain.Activity, int64, error)
+	CountUnread(ctx context.Context, userID string) (int64, error)
+	// MarkAllRead marks every unread entry of the user's feed as read
+	MarkAllRead(ctx context.Context, userID string) error
+	DeleteByUser(ctx context.Context, userID string) error
+}
+
+type activityRepository struct {
+	collection *mongo.Collection
+}
+
+func NewActivityRepository(db *mongo.Database) ActivityRepository {
+	collection := db.Collection("activities")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	)
+
+	return &activityRepository{
+		collection: collection,
+	}
+}
+
+func (r *activityRepository) CreateMany(ctx context.Context, activities []*domain.Activity) error {
+	if len(activities) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	docs := make([]interface{}, len(activities))
+	for i, activity := range activities {
+		activity.CreatedAt = now
+		docs[i] = activity
+	}
+
+	_, err := r.collection.InsertMany(ctx, docs)
+	return err
+}
+
+func (r *activityRepository) ListByUser(ctx context.Context, userID string, page, limit int) ([]*domain.Activity, int64, error) {
+	filter := bson.M{"user_id": userID}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find()
+	opts.SetSkip(int64((page - 1) * limit))
+	opts.SetLimit(int64(limit))
+	opts.SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	activities := []*domain.Activity{}
+	if err := cursor.All(ctx, &activities); err != nil {
+		return nil, 0, err
+	}
+	return activities, total, nil
+}
+
+func (r *activityRepository) CountUnread(ctx context.Context, userID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"user_id": userID, "read_at": nil})
+}
+
+func (r *activityRepository) MarkAllRead(ctx context.Context, userID string) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"user_id": userID, "read_at": nil},
+		bson.M{"$set": bson.M{"read_at": time.Now()}},
+	)
+	return err
+}
+
+func (r *activityRepository) DeleteByUser(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
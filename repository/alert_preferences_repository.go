@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type AlertPreferencesRepository interface {
+	// GetByUserID returns the user's preferences, or empty preferences when none were saved
+	GetByUserID(ctx context.Context, userID string) (*domain.AlertPreferences, error)
+	Upsert(ctx context.Context, prefs *domain.AlertPreferences) error
+	DeleteByUserID(ctx context.Context, userID string) error
+}
+
+type alertPreferencesRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAlertPreferencesRepository(db *mongo.Database) AlertPreferencesRepository {
+	collection := db.Collection("alert_preferences")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	)
+
+	return &alertPreferencesRepository{
+		collection: collection,
+	}
+}
+
+func (r *alertPreferencesRepository) GetByUserID(ctx context.Context, userID string) (*domain.AlertPreferences, error) {
+	var prefs domain.AlertPreferences
+	err := r.collection.FindOne(ctx, bson.M{"user_id": userID}).Decode(&prefs)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return &domain.AlertPreferences{
+				UserID:            userID,
+				ExcludedCompanies: []string{},
+				ExcludedKeywords:  []string{},
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &prefs, nil
+}
+
+func (r *alertPreferencesRepository) Upsert(ctx context.Context, prefs *domain.AlertPreferences) error {
+	prefs.UpdatedAt = time.Now()
+
+	_, err := r.collection.ReplaceOne(
+		ctx,
+		bson.M{"user_id": prefs.UserID},
+		prefs,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *alertPreferencesRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID})
+	return err
+}
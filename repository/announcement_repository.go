@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type AnnouncementRepository interface {
+	Create(ctx context.Context, announcement *domain.Announcement) error
+	GetByID(ctx context.Context, id string) (*domain.Announcement, error)
+	// List returns every announcement, the latest starting first
+	List(ctx context.Context) ([]*domain.Announcement, error)
+	// ListActive returns the announcements shown at the time, whatever their
+	// target roles, the latest starting first
+	ListActive(ctx context.Context, at time.Time) ([]*domain.Announcement, error)
+	Update(ctx context.Context, id string, update *domain.UpdateAnnouncementRequest) error
+	// Delete removes the announcement and its dismissals
+	Delete(ctx context.Context, id string) error
+	// Dismiss records the dismissal, dismissing twice keeps the first one
+	Dismiss(ctx context.Context, dismissal *domain.AnnouncementDismissal) error
+	// DismissedBy returns which of the announcements the user dismissed
+	DismissedBy(ctx context.Context, userID string, announcementIDs []string) (map[string]bool, error)
+}
+
+type announcementRepository struct {
+	collection *mongo.Collection
+	dismissals *mongo.Collection
+}
+
+func NewAnnouncementRepository(db *mongo.Database) AnnouncementRepository {
+	collection := db.Collection("announcements")
+	dismissals := db.Collection("announcement_dismissals")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "starts_at", Value: -1}}},
+	)
+	ensureIndexes(dismissals,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "announcement_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{Keys: bson.D{{Key: "announcement_id", Value: 1}}},
+	)
+
+	return &announcementRepository{
+		collection: collection,
+		dismissals: dismissals,
+	}
+}
+
+func (r *announcementRepository) Create(ctx context.Context, announcement *domain.Announcement) error {
+	announcement.ID = primitive.NewObjectID()
+	announcement.CreatedAt = time.Now()
+	announcement.UpdatedAt = announcement.CreatedAt
+
+	_, err := r.collection.InsertOne(ctx, announcement)
+	return err
+}
+
+func (r *announcementRepository) GetByID(ctx context.Context, id string) (*domain.Announcement, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidID
+	}
+
+	var announcement domain.Announcement
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&announcement)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrAnnouncementNotFound
+		}
+		return nil, err
+	}
+
+	return &announcement, nil
+}
+
+func (r *announcementRepository) List(ctx context.Context) ([]*domain.Announcement, error) {
+	return r.find(ctx, bson.M{})
+}
+
+func (r *announcementRepository) ListActive(ctx context.Context, at time.Time) ([]*domain.Announcement, error) {
+	return r.find(ctx, bson.M{
+		"starts_at": bson.M{"$lte": at},
+		"$or": []bson.M{
+			{"ends_at": nil},
+			{"ends_at": bson.M{"$gt": at}},
+		},
+	})
+}
+
+func (r *announcementRepository) find(ctx context.Context, filter bson.M) ([]*domain.Announcement, error) {
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "starts_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	announcements := []*domain.Announcement{}
+	if err := cursor.All(ctx, &announcements); err != nil {
+		return nil, err
+	}
+	return announcements, nil
+}
+
+func (r *announcementRepository) Update(ctx context.Context, id string, update *domain.UpdateAnnouncementRequest) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	set := bson.M{"updated_at": time.Now()}
+	if update.Kind != nil {
+		set["kind"] = *update.Kind
+	}
+	if update.Title != nil {
+		set["title"] = *update.Title
+	}
+	if update.Message != nil {
+		set["message"] = *update.Message
+	}
+	if update.Link != nil {
+		set["link"] = *update.Link
+	}
+	if update.Roles != nil {
+		set["roles"] = *update.Roles
+	}
+	if update.StartsAt != nil {
+		set["starts_at"] = *update.StartsAt
+	}
+	if update.EndsAt != nil {
+		set["ends_at"] = *update.EndsAt
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrAnnouncementNotFound
+	}
+	return nil
+}
+
+func (r *announcementRepository) Delete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrAnnouncementNotFound
+	}
+
+	_, err = r.dismissals.DeleteMany(ctx, bson.M{"announcement_id": id})
+	return err
+}
+
+func (r *announcementRepository) Dismiss(ctx context.Context, dismissal *domain.AnnouncementDismissal) error {
+	filter := bson.M{"announcement_id": dismissal.AnnouncementID, "user_id": dismissal.UserID}
+	_, err := r.dismissals.UpdateOne(ctx, filter, bson.M{"$setOnInsert": dismissal}, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		// A concurrent request dismissed it first
+		return nil
+	}
+	return err
+}
+
+func (r *announcementRepository) DismissedBy(ctx context.Context, userID string, announcementIDs []string) (map[string]bool, error) {
+	dismissed := make(map[string]bool)
+	if len(announcementIDs) == 0 {
+		return dismissed, nil
+	}
+
+	cursor, err := r.dismissals.Find(ctx, bson.M{
+		"user_id":         userID,
+		"announcement_id": bson.M{"$in": announcementIDs},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var dismissals []domain.AnnouncementDismissal
+	if err := cursor.All(ctx, &dismissals); err != nil {
+		return nil, err
+	}
+	for _, dismissal := range dismissals {
+		dismissed[dismissal.AnnouncementID] = true
+	}
+	return dismissed, nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type APIKeyRepository interface {
+	CreateKey(ctx context.Context, key *domain.APIKey) error
+	// FindActiveByHash returns the unrevoked key with the given hash
+	FindActiveByHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+	ListByCompany(ctx context.Context, companyID string) ([]*domain.APIKey, error)
+	CountActiveByCompany(ctx context.Context, companyID string) (int64, error)
+	RevokeKey(ctx context.Context, id, companyID string) error
+	TouchLastUsed(ctx context.Context, id primitive.ObjectID, usedAt time.Time) error
+	RevokeAllByCompany(ctx context.Context, companyID string) error
+}
+
+type apiKeyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAPIKeyRepository(db *mongo.Database) APIKeyRepository {
+	collection := db.Collection("api_keys")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "key_hash", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{Keys: bson.D{{Key: "company_id", Value: 1}}},
+	)
+
+	return &apiKeyRepository{
+		collection: collection,
+	}
+}
+
+func (r *apiKeyRepository) CreateKey(ctx context.Context, key *domain.APIKey) error {
+	key.ID = primitive.NewObjectID()
+	key.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, key)
+	return err
+}
+
+func (r *apiKeyRepository) FindActiveByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	err := r.collection.FindOne(ctx, bson.M{"key_hash": keyHash, "revoked_at": nil}).Decode(&key)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func (r *apiKeyRepository) ListByCompany(ctx context.Context, companyID string) ([]*domain.APIKey, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"company_id": companyID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	keys := []*domain.APIKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (r *apiKeyRepository) CountActiveByCompany(ctx context.Context, companyID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"company_id": companyID, "revoked_at": nil})
+}
+
+func (r *apiKeyRepository) RevokeKey(ctx context.Context, id, companyID string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID, "company_id": companyID, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id primitive.ObjectID, usedAt time.Time) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_used_at": usedAt}})
+	return err
+}
+
+func (r *apiKeyRepository) RevokeAllByCompany(ctx context.Context, companyID string) error {
+	_, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{"company_id": companyID, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	return err
+}
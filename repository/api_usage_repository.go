@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type APIUsageRepository interface {
+	// Add adds the counts to those stored for the same key and hour
+	Add(ctx context.Context, usage []*domain.APIUsage) error
+	// ListByCompany returns the usage of the company's keys since the hour, oldest first
+	ListByCompany(ctx context.Context, companyID string, since time.Time) ([]*domain.APIUsage, error)
+}
+
+type apiUsageRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAPIUsageRepository(db *mongo.Database) APIUsageRepository {
+	collection := db.Collection("api_usage")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "key_id", Value: 1}, {Key: "hour", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{Keys: bson.D{{Key: "company_id", Value: 1}, {Key: "hour", Value: 1}}},
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "hour", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(domain.APIUsageRetention.Seconds())),
+		},
+	)
+
+	return &apiUsageRepository{
+		collection: collection,
+	}
+}
+
+func (r *apiUsageRepository) Add(ctx context.Context, usage []*domain.APIUsage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, len(usage))
+	for i, u := range usage {
+		models[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"key_id": u.KeyID, "hour": u.Hour}).
+			SetUpdate(bson.M{
+				"$setOnInsert": bson.M{"company_id": u.CompanyID},
+				"$inc": bson.M{
+					"requests":     u.Requests,
+					"errors":       u.Errors,
+					"rate_limited": u.RateLimited,
+				},
+				"$max": bson.M{"peak_window_requests": u.PeakWindowRequests},
+			}).
+			SetUpsert(true)
+	}
+
+	_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (r *apiUsageRepository) ListByCompany(ctx context.Context, companyID string, since time.Time) ([]*domain.APIUsage, error) {
+	filter := bson.M{"company_id": companyID, "hour": bson.M{"$gte": since}}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "hour", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	usage := []*domain.APIUsage{}
+	if err := cursor.All(ctx, &usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type ApplicantProfileRepository interface {
+	// GetByUserID returns the applicant's profile, or an empty profile when none was saved
+	GetByUserID(ctx context.Context, userID string) (*domain.ApplicantProfile, error)
+	// GetByUserIDs returns the saved profiles of the given applicants, keyed by user ID
+	GetByUserIDs(ctx context.Context, userIDs []string) (map[string]*domain.ApplicantProfile, error)
+	Upsert(ctx context.Context, profile *domain.ApplicantProfile) error
+	DeleteByUserID(ctx context.Context, userID string) error
+}
+
+type applicantProfileRepository struct {
+	collection *mongo.Collection
+}
+
+func NewApplicantProfileRepository(db *mongo.Database) ApplicantProfileRepository {
+	collection := db.Collection("applicant_profiles")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	)
+
+	return &applicantProfileRepository{
+		collection: collection,
+	}
+}
+
+func (r *applicantProfileRepository) GetByUserID(ctx context.Context, userID string) (*domain.ApplicantProfile, error) {
+	var profile domain.ApplicantProfile
+	err := r.collection.FindOne(ctx, bson.M{"user_id": userID}).Decode(&profile)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return &domain.ApplicantProfile{
+				UserID:     userID,
+				Skills:     []string{},
+				Experience: []domain.WorkExperience{},
+				Education:  []domain.Education{},
+				Links:      []domain.ProfileLink{},
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+func (r *applicantProfileRepository) GetByUserIDs(ctx context.Context, userIDs []string) (map[string]*domain.ApplicantProfile, error) {
+	profiles := make(map[string]*domain.ApplicantProfile, len(userIDs))
+	if len(userIDs) == 0 {
+		return profiles, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": bson.M{"$in": userIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var profile domain.ApplicantProfile
+		if err := cursor.Decode(&profile); err != nil {
+			return nil, err
+		}
+		profiles[profile.UserID] = &profile
+	}
+
+	return profiles, cursor.Err()
+}
+
+func (r *applicantProfileRepository) Upsert(ctx context.Context, profile *domain.ApplicantProfile) error {
+	profile.UpdatedAt = time.Now()
+
+	_, err := r.collection.ReplaceOne(
+		ctx,
+		bson.M{"user_id": profile.UserID},
+		profile,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *applicantProfileRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID})
+	return err
+}
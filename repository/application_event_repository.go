@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+// ApplicationEventRepository is the append-only event store of applications
+type ApplicationEventRepository interface {
+	// Append stores events numbered after the last event of their application,
+	// returning domain.ErrApplicationVersionConflict when one of the versions is taken
+	Append(ctx context.Context, events ...*domain.ApplicationEvent) error
+	// ListByApplication returns the application's events, oldest first
+	ListByApplication(ctx context.Context, applicationID primitive.ObjectID) ([]*domain.ApplicationEvent, error)
+	// ListApplicationIDs returns the IDs of the applications with events
+	ListApplicationIDs(ctx context.Context) ([]primitive.ObjectID, error)
+	// AnonymizeByApplicant erases the applicant from the events of their applications
+	AnonymizeByApplicant(ctx context.Context, applicantID string) error
+}
+
+type applicationEventRepository struct {
+	collection *mongo.Collection
+}
+
+func NewApplicationEventRepository(db *mongo.Database) ApplicationEventRepository {
+	collection := db.Collection("application_events")
+
+	ensureIndexes(collection,
+		// The unique version rejects events appended concurrently to the same stream
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "application_id", Value: 1}, {Key: "version", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{Keys: bson.D{{Key: "applicant_id", Value: 1}}},
+	)
+
+	return &applicationEventRepository{
+		collection: collection,
+	}
+}
+
+func (r *applicationEventRepository) Append(ctx context.Context, events ...*domain.ApplicationEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(events))
+	for i, event := range events {
+		if event.ID.IsZero() {
+			event.ID = primitive.NewObjectID()
+		}
+		docs[i] = event
+	}
+
+	// Ordered, so nothing after a conflicting version is stored
+	_, err := r.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrApplicationVersionConflict
+	}
+	return err
+}
+
+func (r *applicationEventRepository) ListByApplication(ctx context.Context, applicationID primitive.ObjectID) ([]*domain.ApplicationEvent, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"application_id": applicationID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []*domain.ApplicationEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *applicationEventRepository) ListApplicationIDs(ctx context.Context) ([]primitive.ObjectID, error) {
+	values, err := r.collection.Distinct(ctx, "application_id", bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(values))
+	for _, value := range values {
+		if id, ok := value.(primitive.ObjectID); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *applicationEventRepository) AnonymizeByApplicant(ctx context.Context, applicantID string) error {
+	// The applicant is named on the Applied event of each of their applications
+	applicationIDs, err := r.collection.Distinct(ctx, "application_id", bson.M{"applicant_id": applicantID})
+	if err != nil {
+		return err
+	}
+	if len(applicationIDs) == 0 {
+		return nil
+	}
+
+	_, err = r.collection.UpdateMany(ctx,
+		bson.M{"application_id": bson.M{"$in": applicationIDs}, "actor_id": applicantID},
+		bson.M{"$unset": bson.M{"actor_id": ""}},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateMany(ctx,
+		bson.M{"applicant_id": applicantID},
+		bson.M{"$unset": bson.M{"applicant_id": ""}},
+	)
+	return err
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type ApplicationNoteRepository interface {
+	Create(ctx context.Context, note *domain.ApplicationNote) error
+	// ListByApplication returns the application's notes, oldest first
+	ListByApplication(ctx context.Context, applicationID string) ([]*domain.ApplicationNote, error)
+}
+
+type applicationNoteRepository struct {
+	collection *mongo.Collection
+}
+
+func NewApplicationNoteRepository(db *mongo.Database) ApplicationNoteRepository {
+	collection := db.Collection("application_notes")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "application_id", Value: 1}, {Key: "created_at", Value: 1}}},
+	)
+
+	return &applicationNoteRepository{
+		collection: collection,
+	}
+}
+
+func (r *applicationNoteRepository) Create(ctx context.Context, note *domain.ApplicationNote) error {
+	note.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, note)
+	if err != nil {
+		return err
+	}
+
+	note.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *applicationNoteRepository) ListByApplication(ctx context.Context, applicationID string) ([]*domain.ApplicationNote, error) {
+	cursor, err := r.collection.Find(ctx,
+		bson.M{"application_id": applicationID},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	notes := []*domain.ApplicationNote{}
+	if err := cursor.All(ctx, &notes); err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
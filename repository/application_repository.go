@@ -2,7 +2,6 @@ package repository
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -18,8 +17,51 @@ type ApplicationRepository interface {
 	GetApplicationByID(ctx context.Context, id string) (*domain.Application, error)
 	GetApplicationsByApplicant(ctx context.Context, applicantID string, page, limit int) ([]*domain.Application, int64, error)
 	GetApplicationByApplicantAndJob(ctx context.Context, applicantID, jobID string) (*domain.Application, error)
-	UpdateApplicationStatus(ctx context.Context, id string, status domain.ApplicationStatus) error
+	// Project stores the state derived from the application's events, unless
+	// the stored state was projected from a later event
+	Project(ctx context.Context, aggregate *domain.ApplicationAggregate) error
 	GetJobApplications(ctx context.Context, jobID string, page, limit int) ([]*domain.Application, int64, error)
+	// CountByJobs returns the number of applications to each of the jobs, jobs without any are left out
+	CountByJobs(ctx context.Context, jobIDs []primitive.ObjectID) (map[primitive.ObjectID]int64, error)
+	ReplaceResumeLink(ctx context.Context, oldLink, newLink string) error
+	// ListByResumeFile returns the applications whose resume or resume
+	// thumbnail is stored at url
+	ListByResumeFile(ctx context.Context, url string) ([]*domain.Application, error)
+	// ListResumeFilesByApplicant returns the URLs of the resumes and resume
+	// thumbnails stored for the applicant's applications
+	ListResumeFilesByApplicant(ctx context.Context, applicantID string) ([]string, error)
+	// ListAwaitingThumbnail returns applications whose uploaded resume wasn't
+	// processed for a thumbnail yet, oldest first
+	ListAwaitingThumbnail(ctx context.Context, limit int) ([]*domain.Application, error)
+	// SetResumeThumbnail marks every application with the resume as processed,
+	// setting thumbnailURL unless it is empty
+	SetResumeThumbnail(ctx context.Context, resumeLink, thumbnailURL string) error
+	// AnonymizeByApplicant detaches an applicant's applications from them and
+	// erases their documents, keeping the job and status for company statistics
+	AnonymizeByApplicant(ctx context.Context, applicantID string) error
+	// ListOpenForCompany returns the applications to the company's jobs that are in one of statuses
+	ListOpenForCompany(ctx context.Context, companyID string, statuses []domain.ApplicationStatus) ([]*domain.OpenApplication, error)
+	// ScheduleInterview sets the interview time, rescheduling re-arms the feedback request
+	ScheduleInterview(ctx context.Context, id string, at time.Time) error
+	// ListAwaitingFeedbackRequest returns applications whose interview was scheduled
+	// before interviewedBefore and whose applicant wasn't asked for feedback yet
+	ListAwaitingFeedbackRequest(ctx context.Context, interviewedBefore time.Time, limit int) ([]*domain.Application, error)
+	MarkFeedbackRequested(ctx context.Context, id primitive.ObjectID) error
+	// MarkSLAWarned records that the job owner was warned about the applications in their current stage
+	MarkSLAWarned(ctx context.Context, ids []primitive.ObjectID, stage domain.ApplicationStatus) error
+	// HiringOutcomes sums up the applications matching filter per job
+	// JobClosingStats sums up a job's applications, time to hire is measured
+	// from the application to the move to Hired
+	JobClosingStats(ctx context.Context, jobID primitive.ObjectID) (*domain.JobClosingStats, error)
+	// FunnelApplications returns the applications received in the filter's period with their job
+	FunnelApplications(ctx context.Context, filter domain.HiringReportFilter) ([]*domain.FunnelApplication, error)
+	HiringOutcomes(ctx context.Context, filter domain.HiringReportFilter) ([]*domain.JobHiringOutcome, error)
+	// ResponseTimes returns the average number of days the companies took to
+	// move applications received since since out of Applied, per company. Companies
+	// with fewer than minSamples responses are left out.
+	ResponseTimes(ctx context.Context, companyIDs []string, since time.Time, minSamples int) (map[string]float64, error)
+	// MostApplied returns the jobs that received the most applications since since, most first
+	MostApplied(ctx context.Context, since time.Time, limit int) ([]*domain.JobActivity, error)
 }
 
 type applicationRepository struct {
@@ -27,8 +69,16 @@ type applicationRepository struct {
 }
 
 func NewApplicationRepository(db *mongo.Database) ApplicationRepository {
+	collection := db.Collection("applications")
+
+	// Downloads of stored resumes are authorized by the applications they belong to
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "resume_link", Value: 1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "resume_thumbnail_url", Value: 1}}, Options: options.Index().SetSparse(true)},
+	)
+
 	return &applicationRepository{
-		collection: db.Collection("applications"),
+		collection: collection,
 	}
 }
 
@@ -36,6 +86,7 @@ func (r *applicationRepository) CreateApplication(ctx context.Context, applicati
 	application.ID = primitive.NewObjectID()
 	application.AppliedAt = time.Now()
 	application.Status = domain.StatusApplied
+	application.StatusHistory = []domain.StatusChange{{Status: domain.StatusApplied, At: application.AppliedAt}}
 
 	_, err := r.collection.InsertOne(ctx, application)
 	return err
@@ -44,14 +95,14 @@ func (r *applicationRepository) CreateApplication(ctx context.Context, applicati
 func (r *applicationRepository) GetApplicationByID(ctx context.Context, id string) (*domain.Application, error) {
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, errors.New("invalid application ID")
+		return nil, domain.ErrInvalidID
 	}
 
 	var application domain.Application
 	err = r.collection.FindOne(ctx, bson.M{"_id": objID, "deleted_at": nil}).Decode(&application)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("application not found")
+			return nil, domain.ErrApplicationNotFound
 		}
 		return nil, err
 	}
@@ -104,7 +155,7 @@ func (r *applicationRepository) GetApplicationsByApplicant(ctx context.Context,
 func (r *applicationRepository) GetApplicationByApplicantAndJob(ctx context.Context, applicantID, jobID string) (*domain.Application, error) {
 	jobObjID, err := primitive.ObjectIDFromHex(jobID)
 	if err != nil {
-		return nil, errors.New("invalid job ID")
+		return nil, domain.ErrInvalidID
 	}
 
 	var application domain.Application
@@ -124,26 +175,72 @@ func (r *applicationRepository) GetApplicationByApplicantAndJob(ctx context.Cont
 	return &application, nil
 }
 
-func (r *applicationRepository) UpdateApplicationStatus(ctx context.Context, id string, status domain.ApplicationStatus) error {
-	objID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return errors.New("invalid application ID")
+func (r *applicationRepository) Project(ctx context.Context, aggregate *domain.ApplicationAggregate) error {
+	set := bson.M{
+		"status":         aggregate.Status,
+		"status_history": aggregate.StatusHistory,
+		"event_version":  aggregate.Version,
+		"updated_at":     time.Now(),
+	}
+	unset := bson.M{}
+	fields := map[string]*time.Time{
+		"status_changed_at": aggregate.StatusChangedAt,
+		"interviewed_at":    aggregate.InterviewedAt,
+		"withdrawn_at":      aggregate.WithdrawnAt,
+	}
+	for field, value := range fields {
+		if value != nil {
+			set[field] = value
+		} else {
+			unset[field] = ""
+		}
+	}
+	if aggregate.RejectionReason != "" {
+		set["rejection_reason"] = aggregate.RejectionReason
+	} else {
+		unset["rejection_reason"] = ""
 	}
 
-	_, err = r.collection.UpdateOne(
-		ctx,
-		bson.M{"_id": objID},
-		bson.M{
-			"$set": bson.M{
-				"status":     status,
-				"updated_at": time.Now(),
-			},
+	// Projections of concurrent requests may arrive out of order, the latest version wins
+	filter := bson.M{
+		"_id": aggregate.ID,
+		"$or": bson.A{
+			bson.M{"event_version": bson.M{"$lte": aggregate.Version}},
+			bson.M{"event_version": bson.M{"$exists": false}},
 		},
-	)
-
+	}
+	_, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": set, "$unset": unset})
 	return err
 }
 
+func (r *applicationRepository) CountByJobs(ctx context.Context, jobIDs []primitive.ObjectID) (map[primitive.ObjectID]int64, error) {
+	counts := map[primitive.ObjectID]int64{}
+	if len(jobIDs) == 0 {
+		return counts, nil
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"job_id": bson.M{"$in": jobIDs}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$job_id", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		JobID primitive.ObjectID `bson:"_id"`
+		Count int64              `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		counts[group.JobID] = group.Count
+	}
+	return counts, nil
+}
+
 func (r *applicationRepository) GetJobApplications(ctx context.Context, jobID string, page, limit int) ([]*domain.Application, int64, error) {
 	// Set default values if not provided
 	if page < 1 {
@@ -156,7 +253,7 @@ func (r *applicationRepository) GetJobApplications(ctx context.Context, jobID st
 
 	jobObjID, err := primitive.ObjectIDFromHex(jobID)
 	if err != nil {
-		return nil, 0, errors.New("invalid job ID")
+		return nil, 0, domain.ErrInvalidID
 	}
 
 	// Get total count for pagination
@@ -189,4 +286,462 @@ func (r *applicationRepository) GetJobApplications(ctx context.Context, jobID st
 	}
 
 	return applications, total, nil
-}
\ No newline at end of file
+}
+
+func (r *applicationRepository) ListByResumeFile(ctx context.Context, url string) ([]*domain.Application, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"deleted_at": nil,
+		"$or": bson.A{
+			bson.M{"resume_link": url},
+			bson.M{"resume_thumbnail_url": url},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	applications := []*domain.Application{}
+	if err := cursor.All(ctx, &applications); err != nil {
+		return nil, err
+	}
+
+	return applications, nil
+}
+
+func (r *applicationRepository) ListResumeFilesByApplicant(ctx context.Context, applicantID string) ([]string, error) {
+	urls := []string{}
+	for _, field := range []string{"resume_link", "resume_thumbnail_url"} {
+		values, err := r.collection.Distinct(ctx, field, bson.M{"applicant_id": applicantID})
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range values {
+			if url, ok := v.(string); ok && url != "" {
+				urls = append(urls, url)
+			}
+		}
+	}
+	return urls, nil
+}
+
+func (r *applicationRepository) ReplaceResumeLink(ctx context.Context, oldLink, newLink string) error {
+	_, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{"resume_link": oldLink},
+		bson.M{
+			"$set": bson.M{
+				"resume_link": newLink,
+				"updated_at":  time.Now(),
+			},
+			"$unset": bson.M{"resume_pending": ""},
+		},
+	)
+
+	return err
+}
+
+func (r *applicationRepository) ListAwaitingThumbnail(ctx context.Context, limit int) ([]*domain.Application, error) {
+	filter := bson.M{
+		"resume_link":            bson.M{"$nin": bson.A{"", nil}},
+		"resume_pending":         bson.M{"$ne": true},
+		"thumbnail_processed_at": nil,
+	}
+
+	opts := options.Find()
+	opts.SetLimit(int64(limit))
+	opts.SetSort(bson.D{{Key: "applied_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var applications []*domain.Application
+	if err := cursor.All(ctx, &applications); err != nil {
+		return nil, err
+	}
+	return applications, nil
+}
+
+func (r *applicationRepository) SetResumeThumbnail(ctx context.Context, resumeLink, thumbnailURL string) error {
+	set := bson.M{"thumbnail_processed_at": time.Now()}
+	if thumbnailURL != "" {
+		set["resume_thumbnail_url"] = thumbnailURL
+	}
+
+	_, err := r.collection.UpdateMany(ctx, bson.M{"resume_link": resumeLink}, bson.M{"$set": set})
+	return err
+}
+
+func (r *applicationRepository) AnonymizeByApplicant(ctx context.Context, applicantID string) error {
+	_, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{"applicant_id": applicantID},
+		bson.M{
+			"$set": bson.M{
+				"applicant_id":  "",
+				"resume_link":   "",
+				"anonymized_at": time.Now(),
+			},
+			"$unset": bson.M{
+				"cover_letter":           "",
+				"resume_pending":         "",
+				"resume_thumbnail_url":   "",
+				"thumbnail_processed_at": "",
+				"country":                "",
+			},
+		},
+	)
+	return err
+}
+
+func (r *applicationRepository) ListOpenForCompany(ctx context.Context, companyID string, statuses []domain.ApplicationStatus) ([]*domain.OpenApplication, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"status":     bson.M{"$in": statuses},
+			"deleted_at": nil,
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "jobs",
+			"localField":   "job_id",
+			"foreignField": "_id",
+			"as":           "job",
+		}}},
+		{{Key: "$unwind", Value: "$job"}},
+		{{Key: "$match", Value: bson.M{"job.created_by": companyID, "job.deleted_at": nil}}},
+		{{Key: "$set", Value: bson.M{"job_title": "$job.title"}}},
+		{{Key: "$unset", Value: "job"}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	applications := []*domain.OpenApplication{}
+	if err := cursor.All(ctx, &applications); err != nil {
+		return nil, err
+	}
+	return applications, nil
+}
+
+func (r *applicationRepository) ScheduleInterview(ctx context.Context, id string, at time.Time) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": objID},
+		bson.M{
+			"$set":   bson.M{"interview_scheduled_at": at, "updated_at": time.Now()},
+			"$unset": bson.M{"feedback_requested_at": ""},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrApplicationNotFound
+	}
+	return nil
+}
+
+func (r *applicationRepository) ListAwaitingFeedbackRequest(ctx context.Context, interviewedBefore time.Time, limit int) ([]*domain.Application, error) {
+	filter := bson.M{
+		"interview_scheduled_at": bson.M{"$lte": interviewedBefore},
+		"feedback_requested_at":  nil,
+		"anonymized_at":          nil,
+		"deleted_at":             nil,
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	applications := []*domain.Application{}
+	if err := cursor.All(ctx, &applications); err != nil {
+		return nil, err
+	}
+	return applications, nil
+}
+
+func (r *applicationRepository) MarkFeedbackRequested(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"feedback_requested_at": time.Now()}})
+	return err
+}
+
+func (r *applicationRepository) MarkSLAWarned(ctx context.Context, ids []primitive.ObjectID, stage domain.ApplicationStatus) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// Applications that moved on in the meantime will be warned about their new stage
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": ids}, "status": stage},
+		bson.M{"$set": bson.M{"sla_warned_stage": stage}},
+	)
+	return err
+}
+
+func (r *applicationRepository) HiringOutcomes(ctx context.Context, filter domain.HiringReportFilter) ([]*domain.JobHiringOutcome, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"applied_at": bson.M{"$gte": filter.From, "$lt": filter.To},
+			"deleted_at": nil,
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "jobs",
+			"localField":   "job_id",
+			"foreignField": "_id",
+			"as":           "job",
+		}}},
+		{{Key: "$unwind", Value: "$job"}},
+		// Applications to archived jobs are left out, like the jobs
+		{{Key: "$match", Value: bson.M{"job.deleted_at": nil}}},
+	}
+	if filter.CompanyID != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"job.created_by": filter.CompanyID}}})
+	}
+	pipeline = append(pipeline,
+		// One row per job, status, reason and whether the Interview stage was reached
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"job_id": "$job_id",
+				"status": "$status",
+				"reason": "$rejection_reason",
+				"interviewed": bson.M{"$or": bson.A{
+					bson.M{"$ne": bson.A{bson.M{"$ifNull": bson.A{"$interviewed_at", nil}}, nil}},
+					bson.M{"$in": bson.A{"$status", bson.A{domain.StatusInterview, domain.StatusHired}}},
+				}},
+			},
+			"title":      bson.M{"$first": "$job.title"},
+			"company_id": bson.M{"$first": "$job.created_by"},
+			"count":      bson.M{"$sum": 1},
+		}}},
+	)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Key struct {
+			JobID       primitive.ObjectID       `bson:"job_id"`
+			Status      domain.ApplicationStatus `bson:"status"`
+			Reason      domain.RejectionReason   `bson:"reason"`
+			Interviewed bool                     `bson:"interviewed"`
+		} `bson:"_id"`
+		Title     string `bson:"title"`
+		CompanyID string `bson:"company_id"`
+		Count     int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+
+	byJob := map[primitive.ObjectID]*domain.JobHiringOutcome{}
+	outcomes := []*domain.JobHiringOutcome{}
+	for _, group := range groups {
+		outcome, ok := byJob[group.Key.JobID]
+		if !ok {
+			outcome = &domain.JobHiringOutcome{
+				JobID:            group.Key.JobID.Hex(),
+				JobTitle:         group.Title,
+				CompanyID:        group.CompanyID,
+				RejectionReasons: map[domain.RejectionReason]int64{},
+			}
+			byJob[group.Key.JobID] = outcome
+			outcomes = append(outcomes, outcome)
+		}
+
+		outcome.Applications += group.Count
+		if group.Key.Interviewed {
+			outcome.Interviews += group.Count
+		}
+		switch group.Key.Status {
+		case domain.StatusHired:
+			outcome.Hires += group.Count
+		case domain.StatusRejected:
+			reason := group.Key.Reason
+			if reason == "" {
+				reason = domain.RejectionUnspecified
+			}
+			outcome.Rejections += group.Count
+			outcome.RejectionReasons[reason] += group.Count
+		}
+	}
+
+	return outcomes, nil
+}
+
+func (r *applicationRepository) JobClosingStats(ctx context.Context, jobID primitive.ObjectID) (*domain.JobClosingStats, error) {
+	hired := bson.M{"$eq": bson.A{"$status", domain.StatusHired}}
+
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"job_id": jobID}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   nil,
+			"total": bson.M{"$sum": 1},
+			"hired": bson.M{"$sum": bson.M{"$cond": bson.A{hired, 1, 0}}},
+			// Hires made before status changes were timestamped are left out of the average
+			"time_to_hire_ms": bson.M{"$avg": bson.M{"$cond": bson.A{
+				bson.M{"$and": bson.A{hired, bson.M{"$gt": bson.A{"$status_changed_at", nil}}}},
+				bson.M{"$subtract": bson.A{"$status_changed_at", "$applied_at"}},
+				nil,
+			}}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Total        int64    `bson:"total"`
+		Hired        int64    `bson:"hired"`
+		TimeToHireMs *float64 `bson:"time_to_hire_ms"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+
+	stats := &domain.JobClosingStats{}
+	if len(groups) > 0 {
+		stats.TotalApplicants = groups[0].Total
+		stats.Hired = groups[0].Hired
+		if groups[0].TimeToHireMs != nil {
+			days := *groups[0].TimeToHireMs / float64(24*time.Hour/time.Millisecond)
+			stats.TimeToHireDays = &days
+		}
+	}
+
+	return stats, nil
+}
+
+func (r *applicationRepository) FunnelApplications(ctx context.Context, filter domain.HiringReportFilter) ([]*domain.FunnelApplication, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"applied_at": bson.M{"$gte": filter.From, "$lt": filter.To},
+			"deleted_at": nil,
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "jobs",
+			"localField":   "job_id",
+			"foreignField": "_id",
+			"as":           "job",
+		}}},
+		{{Key: "$unwind", Value: "$job"}},
+		// Applications to archived jobs are left out, like the jobs
+		{{Key: "$match", Value: bson.M{"job.deleted_at": nil}}},
+	}
+	if filter.CompanyID != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"job.created_by": filter.CompanyID}}})
+	}
+	// Only the dates are needed, leave resumes and cover letters in the database
+	pipeline = append(pipeline, bson.D{{Key: "$project", Value: bson.M{
+		"job_id":            1,
+		"status":            1,
+		"applied_at":        1,
+		"interviewed_at":    1,
+		"status_changed_at": 1,
+		"status_history":    1,
+		"job_title":         "$job.title",
+		"company_id":        "$job.created_by",
+	}}})
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	applications := []*domain.FunnelApplication{}
+	if err := cursor.All(ctx, &applications); err != nil {
+		return nil, err
+	}
+	return applications, nil
+}
+
+func (r *applicationRepository) ResponseTimes(ctx context.Context, companyIDs []string, since time.Time, minSamples int) (map[string]float64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"applied_at": bson.M{"$gte": since},
+			"status":     bson.M{"$ne": domain.StatusApplied},
+			"deleted_at": nil,
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "jobs",
+			"localField":   "job_id",
+			"foreignField": "_id",
+			"as":           "job",
+		}}},
+		{{Key: "$unwind", Value: "$job"}},
+		{{Key: "$match", Value: bson.M{"job.created_by": bson.M{"$in": companyIDs}}}},
+		// The first entry of the history after Applied is the response. Applications
+		// from before the history was recorded fall back to their last status change.
+		{{Key: "$project", Value: bson.M{
+			"company_id": "$job.created_by",
+			"applied_at": 1,
+			"responded_at": bson.M{"$ifNull": bson.A{
+				bson.M{"$arrayElemAt": bson.A{"$status_history.at", 1}},
+				"$status_changed_at",
+			}},
+		}}},
+		{{Key: "$match", Value: bson.M{"responded_at": bson.M{"$ne": nil}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":              "$company_id",
+			"responses":        bson.M{"$sum": 1},
+			"response_time_ms": bson.M{"$avg": bson.M{"$subtract": bson.A{"$responded_at", "$applied_at"}}},
+		}}},
+		{{Key: "$match", Value: bson.M{"responses": bson.M{"$gte": minSamples}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		CompanyID      string  `bson:"_id"`
+		ResponseTimeMs float64 `bson:"response_time_ms"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+
+	days := make(map[string]float64, len(groups))
+	for _, group := range groups {
+		days[group.CompanyID] = group.ResponseTimeMs / float64(24*time.Hour/time.Millisecond)
+	}
+	return days, nil
+}
+
+func (r *applicationRepository) MostApplied(ctx context.Context, since time.Time, limit int) ([]*domain.JobActivity, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"applied_at": bson.M{"$gte": since}, "deleted_at": nil}}},
+		{{Key: "$group", Value: bson.M{"_id": "$job_id", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	activity := []*domain.JobActivity{}
+	if err := cursor.All(ctx, &activity); err != nil {
+		return nil, err
+	}
+	return activity, nil
+}
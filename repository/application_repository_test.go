@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"job-portal-backend/domain"
+)
+
+func newMockApplicationRepository(mt *mtest.T) ApplicationRepository {
+	acknowledgeIndexes(mt, 1)
+	repo := NewApplicationRepository(mt.DB)
+	mt.ClearEvents()
+	return repo
+}
+
+func TestGetApplicationByIDNotFound(t *testing.T) {
+	mockTest(t, func(mt *mtest.T) {
+		repo := newMockApplicationRepository(mt)
+		mt.AddMockResponses(emptyCursor("test.applications"))
+
+		_, err := repo.GetApplicationByID(context.Background(), primitive.NewObjectID().Hex())
+		if !errors.Is(err, domain.ErrApplicationNotFound) {
+			mt.Fatalf("GetApplicationByID() error = %v, want %v", err, domain.ErrApplicationNotFound)
+		}
+	})
+}
+
+func TestGetApplicationByIDInvalidID(t *testing.T) {
+	mockTest(t, func(mt *mtest.T) {
+		repo := newMockApplicationRepository(mt)
+
+		_, err := repo.GetApplicationByID(context.Background(), "not-an-id")
+		if !errors.Is(err, domain.ErrInvalidID) {
+			mt.Fatalf("GetApplicationByID() error = %v, want %v", err, domain.ErrInvalidID)
+		}
+	})
+}
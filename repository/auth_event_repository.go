@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type AuthEventRepository interface {
+	Record(ctx context.Context, event *domain.AuthEvent) error
+	ListByUser(ctx context.Context, userID string, page, limit int) ([]*domain.AuthEvent, int64, error)
+	// CountByType counts the events of each type since a point in time
+	CountByType(ctx context.Context, since time.Time) (map[domain.AuthEventType]int64, error)
+	// CountFailureReasons counts the failed logins since a point in time by reason
+	CountFailureReasons(ctx context.Context, since time.Time) (map[string]int64, error)
+	// FailuresByIP returns the IPs with at least minFailures failed logins since a point in time, most failures first
+	FailuresByIP(ctx context.Context, since time.Time, minFailures int64, limit int) ([]*domain.IPFailures, error)
+	// FailuresByAccount returns the accounts with at least minFailures failed logins since a point in time, most failures first
+	FailuresByAccount(ctx context.Context, since time.Time, minFailures int64, limit int) ([]*domain.AccountFailures, error)
+}
+
+type authEventRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAuthEventRepository(db *mongo.Database) AuthEventRepository {
+	collection := db.Collection("auth_events")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "type", Value: 1}, {Key: "created_at", Value: -1}}},
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "created_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(domain.AuthEventRetention.Seconds())),
+		},
+	)
+
+	return &authEventRepository{
+		collection: collection,
+	}
+}
+
+func (r *authEventRepository) Record(ctx context.Context, event *domain.AuthEvent) error {
+	event.ID = primitive.NewObjectID()
+	event.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, event)
+	return err
+}
+
+func (r *authEventRepository) ListByUser(ctx context.Context, userID string, page, limit int) ([]*domain.AuthEvent, int64, error) {
+	filter := bson.M{"user_id": userID}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find()
+	opts.SetSkip(int64((page - 1) * limit))
+	opts.SetLimit(int64(limit))
+	opts.SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []*domain.AuthEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
+func (r *authEventRepository) CountByType(ctx context.Context, since time.Time) (map[domain.AuthEventType]int64, error) {
+	counts, err := r.countBy(ctx, bson.M{"created_at": bson.M{"$gte": since}}, "$type")
+	if err != nil {
+		return nil, err
+	}
+
+	byType := make(map[domain.AuthEventType]int64, len(counts))
+	for key, count := range counts {
+		byType[domain.AuthEventType(key)] = count
+	}
+	return byType, nil
+}
+
+func (r *authEventRepository) CountFailureReasons(ctx context.Context, since time.Time) (map[string]int64, error) {
+	return r.countBy(ctx, failuresSince(since), "$reason")
+}
+
+// countBy counts the events matching filter grouped by a field expression
+func (r *authEventRepository) countBy(ctx context.Context, filter bson.M, field string) (map[string]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": field, "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Key   string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(groups))
+	for _, group := range groups {
+		counts[group.Key] = group.Count
+	}
+	return counts, nil
+}
+
+func (r *authEventRepository) FailuresByIP(ctx context.Context, since time.Time, minFailures int64, limit int) ([]*domain.IPFailures, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: failuresSince(since)}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      "$ip",
+			"failures": bson.M{"$sum": 1},
+			// Unknown emails count as accounts too, that's what credential stuffing tries
+			"accounts":  bson.M{"$addToSet": bson.M{"$ifNull": bson.A{"$user_id", "$email"}}},
+			"last_seen": bson.M{"$max": "$created_at"},
+		}}},
+		{{Key: "$match", Value: bson.M{"failures": bson.M{"$gte": minFailures}}}},
+		{{Key: "$set", Value: bson.M{"accounts": bson.M{"$size": "$accounts"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "failures", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	ips := []*domain.IPFailures{}
+	if err := cursor.All(ctx, &ips); err != nil {
+		return nil, err
+	}
+	return ips, nil
+}
+
+func (r *authEventRepository) FailuresByAccount(ctx context.Context, since time.Time, minFailures int64, limit int) ([]*domain.AccountFailures, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: failuresSince(since)}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       bson.M{"user_id": "$user_id", "email": "$email"},
+			"failures":  bson.M{"$sum": 1},
+			"ips":       bson.M{"$addToSet": "$ip"},
+			"last_seen": bson.M{"$max": "$created_at"},
+		}}},
+		{{Key: "$match", Value: bson.M{"failures": bson.M{"$gte": minFailures}}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":       0,
+			"user_id":   "$_id.user_id",
+			"email":     "$_id.email",
+			"failures":  1,
+			"ips":       bson.M{"$size": "$ips"},
+			"last_seen": 1,
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "failures", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	accounts := []*domain.AccountFailures{}
+	if err := cursor.All(ctx, &accounts); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// failuresSince matches the failed logins since a point in time
+func failuresSince(since time.Time) bson.M {
+	return bson.M{"type": domain.AuthEventLoginFailed, "created_at": bson.M{"$gte": since}}
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type AuthTokenRepository interface {
+	CreateToken(ctx context.Context, token *domain.AuthToken) error
+	// ConsumeToken atomically marks an unused, unexpired token as used and returns it
+	ConsumeToken(ctx context.Context, tokenHash string, purpose domain.TokenPurpose) (*domain.AuthToken, error)
+	DeleteUserTokens(ctx context.Context, userID string, purpose domain.TokenPurpose) error
+}
+
+type authTokenRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAuthTokenRepository(db *mongo.Database) AuthTokenRepository {
+	collection := db.Collection("auth_tokens")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "token_hash", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		// Expired tokens are removed by MongoDB's TTL monitor
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	)
+
+	return &authTokenRepository{
+		collection: collection,
+	}
+}
+
+func (r *authTokenRepository) CreateToken(ctx context.Context, token *domain.AuthToken) error {
+	token.ID = primitive.NewObjectID()
+	token.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, token)
+	return err
+}
+
+func (r *authTokenRepository) ConsumeToken(ctx context.Context, tokenHash string, purpose domain.TokenPurpose) (*domain.AuthToken, error) {
+	now := time.Now()
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var token domain.AuthToken
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{
+			"token_hash": tokenHash,
+			"purpose":    purpose,
+			"used_at":    nil,
+			"expires_at": bson.M{"$gt": now},
+		},
+		bson.M{"$set": bson.M{"used_at": now}},
+		opts,
+	).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *authTokenRepository) DeleteUserTokens(ctx context.Context, userID string, purpose domain.TokenPurpose) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{
+		"user_id": userID,
+		"purpose": purpose,
+	})
+	return err
+}
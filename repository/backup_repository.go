@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+// backupCollection holds the backup records. It is left out of the backups themselves.
+const backupCollection = "backups"
+
+type BackupRepository interface {
+	// Create records a running backup. It fails with ErrBackupInProgress while another one runs.
+	Create(ctx context.Context, backup *domain.Backup) error
+	GetByID(ctx context.Context, id string) (*domain.Backup, error)
+	// List returns backups newest first
+	List(ctx context.Context, page, limit int) ([]*domain.Backup, int64, error)
+	// FailStale marks running backups without progress since before as failed
+	FailStale(ctx context.Context, before time.Time) error
+	Heartbeat(ctx context.Context, id primitive.ObjectID) error
+	Complete(ctx context.Context, id primitive.ObjectID, sizeBytes int64, collections []*domain.BackupCollection, consistent bool) error
+	Fail(ctx context.Context, id primitive.ObjectID, reason string) error
+	// StartVerification records a verification of a completed backup that isn't
+	// already being verified, or fails with ErrBackupNotVerifiable
+	StartVerification(ctx context.Context, id primitive.ObjectID, verification *domain.BackupVerification) error
+	FinishVerification(ctx context.Context, id primitive.ObjectID, verification *domain.BackupVerification) error
+}
+
+type backupRepository struct {
+	collection *mongo.Collection
+}
+
+func NewBackupRepository(db *mongo.Database) BackupRepository {
+	collection := db.Collection(backupCollection)
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "created_at", Value: -1}}},
+		// At most one backup runs at a time
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "status", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": domain.BackupRunning}).
+				SetName("status_running_unique"),
+		},
+	)
+
+	return &backupRepository{
+		collection: collection,
+	}
+}
+
+func (r *backupRepository) Create(ctx context.Context, backup *domain.Backup) error {
+	now := time.Now()
+	backup.ID = primitive.NewObjectID()
+	backup.Status = domain.BackupRunning
+	backup.CreatedAt = now
+	backup.HeartbeatAt = now
+
+	_, err := r.collection.InsertOne(ctx, backup)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrBackupInProgress
+	}
+	return err
+}
+
+func (r *backupRepository) GetByID(ctx context.Context, id string) (*domain.Backup, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidID
+	}
+
+	var backup domain.Backup
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&backup); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrBackupNotFound
+		}
+		return nil, err
+	}
+
+	return &backup, nil
+}
+
+func (r *backupRepository) List(ctx context.Context, page, limit int) ([]*domain.Backup, int64, error) {
+	total, err := r.collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find()
+	opts.SetSkip(int64((page - 1) * limit))
+	opts.SetLimit(int64(limit))
+	opts.SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	backups := []*domain.Backup{}
+	if err := cursor.All(ctx, &backups); err != nil {
+		return nil, 0, err
+	}
+	return backups, total, nil
+}
+
+func (r *backupRepository) FailStale(ctx context.Context, before time.Time) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"status": domain.BackupRunning, "heartbeat_at": bson.M{"$lt": before}},
+		bson.M{"$set": bson.M{"status": domain.BackupFailed, "error": "interrupted", "completed_at": time.Now()}},
+	)
+	return err
+}
+
+func (r *backupRepository) Heartbeat(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": domain.BackupRunning},
+		bson.M{"$set": bson.M{"heartbeat_at": time.Now()}},
+	)
+	return err
+}
+
+func (r *backupRepository) Complete(ctx context.Context, id primitive.ObjectID, sizeBytes int64, collections []*domain.BackupCollection, consistent bool) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": domain.BackupRunning},
+		bson.M{"$set": bson.M{
+			"status":       domain.BackupCompleted,
+			"size_bytes":   sizeBytes,
+			"collections":  collections,
+			"consistent":   consistent,
+			"completed_at": time.Now(),
+		}},
+	)
+	return err
+}
+
+func (r *backupRepository) Fail(ctx context.Context, id primitive.ObjectID, reason string) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": domain.BackupRunning},
+		bson.M{"$set": bson.M{"status": domain.BackupFailed, "error": reason, "completed_at": time.Now()}},
+	)
+	return err
+}
+
+func (r *backupRepository) StartVerification(ctx context.Context, id primitive.ObjectID, verification *domain.BackupVerification) error {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{
+			"_id":    id,
+			"status": domain.BackupCompleted,
+			// Verifications that stopped making progress may be restarted
+			"$or": bson.A{
+				bson.M{"verification.status": bson.M{"$ne": domain.VerificationRunning}},
+				bson.M{"verification.started_at": bson.M{"$lt": verification.StartedAt.Add(-domain.BackupStaleAfter)}},
+			},
+		},
+		bson.M{"$set": bson.M{"verification": verification}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrBackupNotVerifiable
+	}
+	return nil
+}
+
+func (r *backupRepository) FinishVerification(ctx context.Context, id primitive.ObjectID, verification *domain.BackupVerification) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"verification": verification}},
+	)
+	return err
+}
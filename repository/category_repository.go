@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type CategoryRepository interface {
+	Create(ctx context.Context, category *domain.Category) error
+	GetByID(ctx context.Context, id string) (*domain.Category, error)
+	GetBySlug(ctx context.Context, slug string) (*domain.Category, error)
+	// List returns every category ordered by name
+	List(ctx context.Context) ([]*domain.Category, error)
+	Update(ctx context.Context, id string, update *domain.UpdateCategoryRequest) error
+	Delete(ctx context.Context, id string) error
+	// CreateIfEmpty creates the categories when the collection has none
+	CreateIfEmpty(ctx context.Context, categories []*domain.Category) error
+}
+
+type categoryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCategoryRepository(db *mongo.Database) CategoryRepository {
+	collection := db.Collection("categories")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "slug", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	)
+
+	return &categoryRepository{
+		collection: collection,
+	}
+}
+
+func (r *categoryRepository) Create(ctx context.Context, category *domain.Category) error {
+	category.ID = primitive.NewObjectID()
+	category.CreatedAt = time.Now()
+	category.UpdatedAt = category.CreatedAt
+
+	_, err := r.collection.InsertOne(ctx, category)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrCategoryExists
+	}
+	return err
+}
+
+func (r *categoryRepository) GetByID(ctx context.Context, id string) (*domain.Category, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidID
+	}
+	return r.findOne(ctx, bson.M{"_id": objID})
+}
+
+func (r *categoryRepository) GetBySlug(ctx context.Context, slug string) (*domain.Category, error) {
+	return r.findOne(ctx, bson.M{"slug": slug})
+}
+
+func (r *categoryRepository) findOne(ctx context.Context, filter bson.M) (*domain.Category, error) {
+	var category domain.Category
+	err := r.collection.FindOne(ctx, filter).Decode(&category)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrCategoryNotFound
+		}
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (r *categoryRepository) List(ctx context.Context) ([]*domain.Category, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	categories := []*domain.Category{}
+	if err := cursor.All(ctx, &categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (r *categoryRepository) Update(ctx context.Context, id string, update *domain.UpdateCategoryRequest) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	set := bson.M{"updated_at": time.Now()}
+	if update.Name != nil {
+		set["name"] = *update.Name
+	}
+	if update.Description != nil {
+		set["description"] = *update.Description
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrCategoryNotFound
+	}
+	return nil
+}
+
+func (r *categoryRepository) Delete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrCategoryNotFound
+	}
+	return nil
+}
+
+func (r *categoryRepository) CreateIfEmpty(ctx context.Context, categories []*domain.Category) error {
+	count, err := r.collection.EstimatedDocumentCount(ctx)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	for _, category := range categories {
+		// Another instance may be seeding at the same time
+		if err := r.Create(ctx, category); err != nil && err != domain.ErrCategoryExists {
+			return err
+		}
+	}
+	return nil
+}
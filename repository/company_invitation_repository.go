@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"job-portal-backend/domain"
+)
+
+type CompanyInvitationRepository interface {
+	// Create stores an invitation, replacing the company's open invitations for the same email
+	Create(ctx context.Context, invitation *domain.CompanyInvitation) error
+	// GetOpenByTokenHash returns an unexpired invitation that hasn't been accepted
+	GetOpenByTokenHash(ctx context.Context, tokenHash string) (*domain.CompanyInvitation, error)
+	MarkAccepted(ctx context.Context, id primitive.ObjectID, userID string) error
+	DeleteByCompany(ctx context.Context, companyID string) error
+}
+
+type companyInvitationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCompanyInvitationRepository(db *mongo.Database) CompanyInvitationRepository {
+	collection := db.Collection("company_invitations")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "token_hash", Value: 1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "company_id", Value: 1}, {Key: "email", Value: 1}}},
+	)
+
+	return &companyInvitationRepository{
+		collection: collection,
+	}
+}
+
+func (r *companyInvitationRepository) Create(ctx context.Context, invitation *domain.CompanyInvitation) error {
+	// Only the latest invitation link works
+	_, err := r.collection.DeleteMany(ctx, bson.M{
+		"company_id":  invitation.CompanyID,
+		"email":       invitation.Email,
+		"accepted_at": nil,
+	})
+	if err != nil {
+		return err
+	}
+
+	invitation.ID = primitive.NewObjectID()
+	invitation.CreatedAt = time.Now()
+
+	_, err = r.collection.InsertOne(ctx, invitation)
+	return err
+}
+
+func (r *companyInvitationRepository) GetOpenByTokenHash(ctx context.Context, tokenHash string) (*domain.CompanyInvitation, error) {
+	var invitation domain.CompanyInvitation
+	err := r.collection.FindOne(ctx, bson.M{
+		"token_hash":  tokenHash,
+		"accepted_at": nil,
+		"expires_at":  bson.M{"$gt": time.Now()},
+	}).Decode(&invitation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *companyInvitationRepository) MarkAccepted(ctx context.Context, id primitive.ObjectID, userID string) error {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "accepted_at": nil},
+		bson.M{"$set": bson.M{"accepted_at": time.Now(), "accepted_by": userID}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrInvitationNotFound
+	}
+	return nil
+}
+
+func (r *companyInvitationRepository) DeleteByCompany(ctx context.Context, companyID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"company_id": companyID})
+	return err
+}
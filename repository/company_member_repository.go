@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type CompanyMemberRepository interface {
+	// Add makes the user a member of the company, existing memberships are left untouched.
+	// It fails with ErrCompanyMemberExists when the user works for another company.
+	Add(ctx context.Context, member *domain.CompanyMember) error
+	// FindByUser returns the membership of a user, or nil when the user isn't a member of any company
+	FindByUser(ctx context.Context, userID string) (*domain.CompanyMember, error)
+	// ListByCompany returns the company's members, earliest first
+	ListByCompany(ctx context.Context, companyID string) ([]*domain.CompanyMember, error)
+	Remove(ctx context.Context, companyID, userID string) error
+	DeleteByUser(ctx context.Context, userID string) error
+	DeleteByCompany(ctx context.Context, companyID string) error
+}
+
+type companyMemberRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCompanyMemberRepository(db *mongo.Database) CompanyMemberRepository {
+	collection := db.Collection("company_members")
+
+	ensureIndexes(collection,
+		// A user works for a single company
+		mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		mongo.IndexModel{Keys: bson.D{{Key: "company_id", Value: 1}, {Key: "created_at", Value: 1}}},
+	)
+
+	return &companyMemberRepository{
+		collection: collection,
+	}
+}
+
+func (r *companyMemberRepository) Add(ctx context.Context, member *domain.CompanyMember) error {
+	member.ID = primitive.NewObjectID()
+	member.CreatedAt = time.Now()
+
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"user_id": member.UserID, "company_id": member.CompanyID},
+		bson.M{"$setOnInsert": member},
+		options.Update().SetUpsert(true),
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrCompanyMemberExists
+	}
+	return err
+}
+
+func (r *companyMemberRepository) FindByUser(ctx context.Context, userID string) (*domain.CompanyMember, error) {
+	var member domain.CompanyMember
+	if err := r.collection.FindOne(ctx, bson.M{"user_id": userID}).Decode(&member); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *companyMemberRepository) ListByCompany(ctx context.Context, companyID string) ([]*domain.CompanyMember, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"company_id": companyID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	members := []*domain.CompanyMember{}
+	if err := cursor.All(ctx, &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *companyMemberRepository) Remove(ctx context.Context, companyID, userID string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"company_id": companyID, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrCompanyMemberNotFound
+	}
+	return nil
+}
+
+func (r *companyMemberRepository) DeleteByUser(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
+
+func (r *companyMemberRepository) DeleteByCompany(ctx context.Context, companyID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"company_id": companyID})
+	return err
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type CompanyProfileRepository interface {
+	GetByCompanyID(ctx context.Context, companyID string) (*domain.CompanyProfile, error)
+	Upsert(ctx context.Context, profile *domain.CompanyProfile) error
+	DeleteByCompanyID(ctx context.Context, companyID string) error
+}
+
+type companyProfileRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCompanyProfileRepository(db *mongo.Database) CompanyProfileRepository {
+	collection := db.Collection("company_profiles")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "company_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	)
+
+	return &companyProfileRepository{
+		collection: collection,
+	}
+}
+
+func (r *companyProfileRepository) GetByCompanyID(ctx context.Context, companyID string) (*domain.CompanyProfile, error) {
+	var profile domain.CompanyProfile
+	err := r.collection.FindOne(ctx, bson.M{"company_id": companyID}).Decode(&profile)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrCompanyProfileNotFound
+		}
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+func (r *companyProfileRepository) Upsert(ctx context.Context, profile *domain.CompanyProfile) error {
+	profile.UpdatedAt = time.Now()
+
+	_, err := r.collection.ReplaceOne(
+		ctx,
+		bson.M{"company_id": profile.CompanyID},
+		profile,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *companyProfileRepository) DeleteByCompanyID(ctx context.Context, companyID string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"company_id": companyID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrCompanyProfileNotFound
+	}
+
+	return nil
+}
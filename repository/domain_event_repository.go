@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+// DomainEventRepository is the outbox of domain events
+type DomainEventRepository interface {
+	Create(ctx context.Context, event *domain.DomainEvent) error
+	// ListDue returns the pending events whose next delivery attempt is due, oldest first
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.DomainEvent, error)
+	// SaveDelivery stores the outcome of a delivery attempt
+	SaveDelivery(ctx context.Context, event *domain.DomainEvent) error
+	// List returns events matching the filter, most recent first
+	List(ctx context.Context, filter domain.DomainEventFilter, page, limit int) ([]*domain.DomainEvent, int64, error)
+	// CountByStatus counts the events matching the filter by delivery state, its Status is ignored
+	CountByStatus(ctx context.Context, filter domain.DomainEventFilter) (map[domain.DomainEventStatus]int64, error)
+	// Requeue resets the delivery state of the events matching the filter so
+	// they are delivered again, returning how many were queued
+	Requeue(ctx context.Context, filter domain.DomainEventFilter, types []domain.DomainEventType) (int64, error)
+	RequeueByID(ctx context.Context, id string) error
+}
+
+type domainEventRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDomainEventRepository(db *mongo.Database) DomainEventRepository {
+	collection := db.Collection("events")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "delivered_at", Value: 1}, {Key: "failed_at", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "type", Value: 1}, {Key: "occurred_at", Value: -1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "occurred_at", Value: -1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "data.user_id", Value: 1}, {Key: "occurred_at", Value: -1}}},
+	)
+
+	return &domainEventRepository{
+		collection: collection,
+	}
+}
+
+func (r *domainEventRepository) Create(ctx context.Context, event *domain.DomainEvent) error {
+	result, err := r.collection.InsertOne(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	event.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *domainEventRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.DomainEvent, error) {
+	filter := bson.M{
+		"delivered_at":    nil,
+		"failed_at":       nil,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+
+	opts := options.Find()
+	opts.SetLimit(int64(limit))
+	opts.SetSort(bson.D{{Key: "occurred_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []*domain.DomainEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *domainEventRepository) SaveDelivery(ctx context.Context, event *domain.DomainEvent) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": event.ID}, bson.M{"$set": bson.M{
+		"delivered_to":    event.DeliveredTo,
+		"delivered_at":    event.DeliveredAt,
+		"attempts":        event.Attempts,
+		"next_attempt_at": event.NextAttemptAt,
+		"last_error":      event.LastError,
+		"failed_at":       event.FailedAt,
+	}})
+	return err
+}
+
+func (r *domainEventRepository) List(ctx context.Context, filter domain.DomainEventFilter, page, limit int) ([]*domain.DomainEvent, int64, error) {
+	query := eventQuery(filter, nil)
+	switch filter.Status {
+	case domain.EventStatusPending:
+		query["delivered_at"] = nil
+		query["failed_at"] = nil
+	case domain.EventStatusDelivered:
+		query["delivered_at"] = bson.M{"$ne": nil}
+	case domain.EventStatusFailed:
+		query["failed_at"] = bson.M{"$ne": nil}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find()
+	opts.SetSkip(int64((page - 1) * limit))
+	opts.SetLimit(int64(limit))
+	opts.SetSort(bson.D{{Key: "occurred_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []*domain.DomainEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+func (r *domainEventRepository) CountByStatus(ctx context.Context, filter domain.DomainEventFilter) (map[domain.DomainEventStatus]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: eventQuery(filter, nil)}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"$switch": bson.M{
+				"branches": bson.A{
+					bson.M{"case": bson.M{"$gt": bson.A{"$delivered_at", nil}}, "then": domain.EventStatusDelivered},
+					bson.M{"case": bson.M{"$gt": bson.A{"$failed_at", nil}}, "then": domain.EventStatusFailed},
+				},
+				"default": domain.EventStatusPending,
+			}},
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Status domain.DomainEventStatus `bson:"_id"`
+		Count  int64                    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[domain.DomainEventStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *domainEventRepository) Requeue(ctx context.Context, filter domain.DomainEventFilter, types []domain.DomainEventType) (int64, error) {
+	result, err := r.collection.UpdateMany(ctx, eventQuery(filter, types), requeueUpdate())
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *domainEventRepository) RequeueByID(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, requeueUpdate())
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrDomainEventNotFound
+	}
+	return nil
+}
+
+// eventQuery matches events by type and occurrence time
+func eventQuery(filter domain.DomainEventFilter, types []domain.DomainEventType) bson.M {
+	query := bson.M{}
+	if filter.Type != "" {
+		query["type"] = filter.Type
+	}
+	if len(types) > 0 {
+		query["type"] = bson.M{"$in": types}
+	}
+	if filter.UserID != "" {
+		query["data.user_id"] = filter.UserID
+	}
+
+	occurred := bson.M{}
+	if filter.Since != nil {
+		occurred["$gte"] = *filter.Since
+	}
+	if filter.Until != nil {
+		occurred["$lt"] = *filter.Until
+	}
+	if len(occurred) > 0 {
+		query["occurred_at"] = occurred
+	}
+	return query
+}
+
+// requeueUpdate resets an event's delivery state, it is delivered to every webhook again
+func requeueUpdate() bson.M {
+	return bson.M{
+		"$set":   bson.M{"attempts": 0, "next_attempt_at": time.Now()},
+		"$unset": bson.M{"delivered_to": "", "delivered_at": "", "last_error": "", "failed_at": ""},
+	}
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DumpRepository reads and loads whole collections for backups and restores
+type DumpRepository interface {
+	// Dump calls fn with every document of every collection, collection by
+	// collection in name order and by _id within a collection. The documents
+	// come from a single snapshot when the deployment supports snapshot reads
+	// and the dump ends before MongoDB drops the snapshot's history, which is
+	// reported by consistent. Longer dumps go on from a new snapshot.
+	Dump(ctx context.Context, fn func(collection string, document bson.Raw) error) (consistent bool, err error)
+	// CountDocuments returns the number of documents in a collection
+	CountDocuments(ctx context.Context, collection string) (int64, error)
+	// Load inserts documents into a collection
+	Load(ctx context.Context, collection string, documents []interface{}) error
+}
+
+type dumpRepository struct {
+	db *mongo.Database
+}
+
+func NewDumpRepository(db *mongo.Database) DumpRepository {
+	return &dumpRepository{
+		db: db,
+	}
+}
+
+// snapshotWindow is how long a dump reads from one snapshot. MongoDB keeps
+// the history of a snapshot for 300 seconds by default and fails later reads
+// with SnapshotTooOld, so a longer dump goes on from a new snapshot.
+const snapshotWindow = 4 * time.Minute
+
+// snapshotTooOldCode is returned by reads at a snapshot older than the history MongoDB keeps
+const snapshotTooOldCode = 239
+
+// errSnapshotWindowEnded stops a dump reading from a snapshot past snapshotWindow
+var errSnapshotWindowEnded = errors.New("snapshot window ended")
+
+// dumpPosition is how far a dump got: the index of the collection it is
+// reading and the _id of the last document it emitted from it
+type dumpPosition struct {
+	collection int
+	lastID     interface{}
+}
+
+func (r *dumpRepository) Dump(ctx context.Context, fn func(collection string, document bson.Raw) error) (bool, error) {
+	names, err := r.db.ListCollectionNames(ctx, bson.M{"type": "collection"})
+	if err != nil {
+		return false, err
+	}
+
+	collections := names[:0]
+	for _, name := range names {
+		if name == backupCollection || strings.HasPrefix(name, "system.") {
+			continue
+		}
+		collections = append(collections, name)
+	}
+	sort.Strings(collections)
+
+	pos := &dumpPosition{}
+	emitted := 0
+	count := func(collection string, document bson.Raw) error {
+		emitted++
+		return fn(collection, document)
+	}
+
+	// The dump is consistent when it was read from a single snapshot
+	for snapshots := 1; ; snapshots++ {
+		before := emitted
+		err := r.dumpSnapshot(ctx, collections, pos, count)
+
+		var cmdErr mongo.CommandError
+		switch {
+		case err == nil:
+			return snapshots == 1, nil
+		case errors.Is(err, errSnapshotWindowEnded), errors.As(err, &cmdErr) && cmdErr.Code == snapshotTooOldCode && emitted > before:
+			log.Printf("Dump is taking longer than a snapshot is kept, going on from a new snapshot")
+		case errors.As(err, &cmdErr) && emitted == 0:
+			// Standalone servers don't support snapshot reads
+			log.Printf("Snapshot reads are not supported by the MongoDB deployment, dumping without one: %v", err)
+			return false, r.dump(ctx, collections, pos, time.Time{}, count)
+		default:
+			return false, err
+		}
+	}
+}
+
+// dumpSnapshot goes on with the dump from pos, reading from a new snapshot
+// until the dump is done or snapshotWindow passed
+func (r *dumpRepository) dumpSnapshot(ctx context.Context, collections []string, pos *dumpPosition, fn func(collection string, document bson.Raw) error) error {
+	session, err := r.db.Client().StartSession(options.Session().SetSnapshot(true))
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	deadline := time.Now().Add(snapshotWindow)
+	return mongo.WithSession(ctx, session, func(sessionCtx mongo.SessionContext) error {
+		return r.dump(sessionCtx, collections, pos, deadline, fn)
+	})
+}
+
+// dump reads the collections from pos on, in _id order, until they are all
+// read or deadline passed. A zero deadline reads everything. _id values of a
+// collection must be of one type, documents are resumed after the last _id.
+func (r *dumpRepository) dump(ctx context.Context, collections []string, pos *dumpPosition, deadline time.Time, fn func(collection string, document bson.Raw) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	for ; pos.collection < len(collections); pos.collection, pos.lastID = pos.collection+1, nil {
+		name := collections[pos.collection]
+		filter := bson.M{}
+		if pos.lastID != nil {
+			filter["_id"] = bson.M{"$gt": pos.lastID}
+		}
+
+		cursor, err := r.db.Collection(name).Find(ctx, filter, opts)
+		if err != nil {
+			return err
+		}
+
+		for cursor.Next(ctx) {
+			if err := fn(name, cursor.Current); err != nil {
+				cursor.Close(ctx)
+				return err
+			}
+			// Copied, it outlives the cursor's current batch
+			id := cursor.Current.Lookup("_id")
+			pos.lastID = bson.RawValue{Type: id.Type, Value: append([]byte(nil), id.Value...)}
+			if !deadline.IsZero() && time.Now().After(deadline) {
+				cursor.Close(ctx)
+				return errSnapshotWindowEnded
+			}
+		}
+		err = cursor.Err()
+		cursor.Close(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *dumpRepository) CountDocuments(ctx context.Context, collection string) (int64, error) {
+	return r.db.Collection(collection).CountDocuments(ctx, bson.M{})
+}
+
+func (r *dumpRepository) Load(ctx context.Context, collection string, documents []interface{}) error {
+	if len(documents) == 0 {
+		return nil
+	}
+	_, err := r.db.Collection(collection).InsertMany(ctx, documents)
+	return err
+}
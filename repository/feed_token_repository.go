@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+// FeedTokenRepository stores the tokens of the job board feed. An empty
+// companyID selects the admins' tokens, which list every company's jobs.
+type FeedTokenRepository interface {
+	Create(ctx context.Context, token *domain.FeedToken) error
+	// FindActiveByHash returns the unrevoked token with the given hash
+	FindActiveByHash(ctx context.Context, tokenHash string) (*domain.FeedToken, error)
+	// ListByCompany returns the company's tokens, most recent first
+	ListByCompany(ctx context.Context, companyID string) ([]*domain.FeedToken, error)
+	CountActiveByCompany(ctx context.Context, companyID string) (int64, error)
+	Revoke(ctx context.Context, id, companyID string) error
+	TouchLastUsed(ctx context.Context, id primitive.ObjectID, usedAt time.Time) error
+}
+
+type feedTokenRepository struct {
+	collection *mongo.Collection
+}
+
+func NewFeedTokenRepository(db *mongo.Database) FeedTokenRepository {
+	collection := db.Collection("feed_tokens")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "token_hash", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{Keys: bson.D{{Key: "company_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	)
+
+	return &feedTokenRepository{
+		collection: collection,
+	}
+}
+
+func (r *feedTokenRepository) Create(ctx context.Context, token *domain.FeedToken) error {
+	token.ID = primitive.NewObjectID()
+	token.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, token)
+	return err
+}
+
+func (r *feedTokenRepository) FindActiveByHash(ctx context.Context, tokenHash string) (*domain.FeedToken, error) {
+	var token domain.FeedToken
+	err := r.collection.FindOne(ctx, bson.M{"token_hash": tokenHash, "revoked_at": nil}).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrFeedTokenNotFound
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *feedTokenRepository) ListByCompany(ctx context.Context, companyID string) ([]*domain.FeedToken, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"company_id": companyID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	tokens := []*domain.FeedToken{}
+	if err := cursor.All(ctx, &tokens); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+func (r *feedTokenRepository) CountActiveByCompany(ctx context.Context, companyID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"company_id": companyID, "revoked_at": nil})
+}
+
+func (r *feedTokenRepository) Revoke(ctx context.Context, id, companyID string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID, "company_id": companyID, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrFeedTokenNotFound
+	}
+
+	return nil
+}
+
+func (r *feedTokenRepository) TouchLastUsed(ctx context.Context, id primitive.ObjectID, usedAt time.Time) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_used_at": usedAt}})
+	return err
+}
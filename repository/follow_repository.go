@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type FollowRepository interface {
+	// Follow records the follow, following a company twice keeps the first follow
+	Follow(ctx context.Context, userID, companyID string) (*domain.Follow, error)
+	Unfollow(ctx context.Context, userID, companyID string) error
+	// ListByUser returns the companies the user follows, most recent first
+	ListByUser(ctx context.Context, userID string, page, limit int) ([]*domain.Follow, int64, error)
+	// ListFollowerIDs returns the IDs of the users following the company
+	ListFollowerIDs(ctx context.Context, companyID string) ([]string, error)
+	DeleteByUser(ctx context.Context, userID string) error
+	DeleteByCompany(ctx context.Context, companyID string) error
+}
+
+type followRepository struct {
+	collection *mongo.Collection
+}
+
+func NewFollowRepository(db *mongo.Database) FollowRepository {
+	collection := db.Collection("follows")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "company_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{Keys: bson.D{{Key: "company_id", Value: 1}}},
+	)
+
+	return &followRepository{
+		collection: collection,
+	}
+}
+
+func (r *followRepository) Follow(ctx context.Context, userID, companyID string) (*domain.Follow, error) {
+	var follow domain.Follow
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"user_id": userID, "company_id": companyID},
+		bson.M{"$setOnInsert": bson.M{"created_at": time.Now()}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&follow)
+	if err != nil {
+		return nil, err
+	}
+	return &follow, nil
+}
+
+func (r *followRepository) Unfollow(ctx context.Context, userID, companyID string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID, "company_id": companyID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrFollowNotFound
+	}
+	return nil
+}
+
+func (r *followRepository) ListByUser(ctx context.Context, userID string, page, limit int) ([]*domain.Follow, int64, error) {
+	filter := bson.M{"user_id": userID}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find()
+	opts.SetSkip(int64((page - 1) * limit))
+	opts.SetLimit(int64(limit))
+	opts.SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	follows := []*domain.Follow{}
+	if err := cursor.All(ctx, &follows); err != nil {
+		return nil, 0, err
+	}
+	return follows, total, nil
+}
+
+func (r *followRepository) ListFollowerIDs(ctx context.Context, companyID string) ([]string, error) {
+	values, err := r.collection.Distinct(ctx, "user_id", bson.M{"company_id": companyID})
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(values))
+	for _, v := range values {
+		if id, ok := v.(string); ok {
+			userIDs = append(userIDs, id)
+		}
+	}
+	return userIDs, nil
+}
+
+func (r *followRepository) DeleteByUser(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
+
+func (r *followRepository) DeleteByCompany(ctx context.Context, companyID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"company_id": companyID})
+	return err
+}
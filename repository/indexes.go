@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ensureIndexes creates the given indexes on a collection. Failures are logged
+// rather than returned so that a missing index never prevents the API from starting.
+func ensureIndexes(collection *mongo.Collection, models ...mongo.IndexModel) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := collection.Indexes().CreateMany(ctx, models); err != nil {
+		log.Printf("Failed to create indexes on %s: %v\n", collection.Name(), err)
+	}
+}
+
+// EnsureAllIndexes creates the indexes of every collection. The API creates
+// them as it starts; this lets operators rebuild them without a restart, e.g.
+// after restoring a backup into an empty database.
+func EnsureAllIndexes(db *mongo.Database) {
+	NewUserRepository(db)
+	NewJobRepository(db)
+	NewApplicationRepository(db)
+	NewApplicationEventRepository(db)
+	NewAuthTokenRepository(db)
+	NewRevokedTokenRepository(db)
+	NewSessionRepository(db)
+	NewAPIKeyRepository(db)
+	NewFeedTokenRepository(db)
+	NewAPIUsageRepository(db)
+	NewAuthEventRepository(db)
+	NewSecurityAlertRepository(db)
+	NewRoleUpgradeRepository(db)
+	NewSSOConfigRepository(db)
+	NewCompanyMemberRepository(db)
+	NewCompanyInvitationRepository(db)
+	NewCategoryRepository(db)
+	NewAnnouncementRepository(db)
+	NewInviteCodeRepository(db)
+	NewSupportTicketRepository(db)
+	NewAlertPreferencesRepository(db)
+	NewApplicantProfileRepository(db)
+	NewCompanyProfileRepository(db)
+	NewSLAPolicyRepository(db)
+	NewSchedulingRulesRepository(db)
+	NewResumeRepository(db)
+	NewNotificationPreferencesRepository(db)
+	NewUIPreferencesRepository(db)
+	NewApplicationNoteRepository(db)
+	NewActivityRepository(db)
+	NewInterviewFeedbackRepository(db)
+	NewJobTemplateRepository(db)
+	NewJobRevisionRepository(db)
+	NewDomainEventRepository(db)
+	NewPendingNotificationRepository(db)
+	NewJobAbuseFlagRepository(db)
+	NewFollowRepository(db)
+	NewSavedSearchRepository(db)
+	NewSavedJobRepository(db)
+	NewJobViewRepository(db)
+	NewJobApplyClickRepository(db)
+	NewBackupRepository(db)
+}
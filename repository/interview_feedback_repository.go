@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type InterviewFeedbackRepository interface {
+	// Create saves the feedback, returning domain.ErrInterviewFeedbackExists when
+	// the application's interview already has feedback
+	Create(ctx context.Context, feedback *domain.InterviewFeedback) error
+	// Summaries aggregates feedback per company, most responses first. An empty
+	// companyID summarizes every company.
+	Summaries(ctx context.Context, companyID string) ([]*domain.InterviewFeedbackSummary, error)
+	// Comments returns the company's most recent free text comments
+	Comments(ctx context.Context, companyID string, limit int) ([]*domain.InterviewFeedbackComment, error)
+}
+
+type interviewFeedbackRepository struct {
+	collection *mongo.Collection
+}
+
+func NewInterviewFeedbackRepository(db *mongo.Database) InterviewFeedbackRepository {
+	collection := db.Collection("interview_feedback")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "application_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		mongo.IndexModel{Keys: bson.D{{Key: "company_id", Value: 1}, {Key: "submitted_on", Value: -1}}},
+	)
+
+	return &interviewFeedbackRepository{
+		collection: collection,
+	}
+}
+
+func (r *interviewFeedbackRepository) Create(ctx context.Context, feedback *domain.InterviewFeedback) error {
+	feedback.ID = primitive.NewObjectID()
+	feedback.SubmittedOn = time.Now().UTC().Truncate(24 * time.Hour)
+
+	_, err := r.collection.InsertOne(ctx, feedback)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrInterviewFeedbackExists
+	}
+	return err
+}
+
+func (r *interviewFeedbackRepository) Summaries(ctx context.Context, companyID string) ([]*domain.InterviewFeedbackSummary, error) {
+	match := bson.M{}
+	if companyID != "" {
+		match["company_id"] = companyID
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           "$company_id",
+			"responses":     bson.M{"$sum": 1},
+			"communication": bson.M{"$avg": "$ratings.communication"},
+			"scheduling":    bson.M{"$avg": "$ratings.scheduling"},
+			"respect":       bson.M{"$avg": "$ratings.respect"},
+			"clarity":       bson.M{"$avg": "$ratings.clarity"},
+			"overall":       bson.M{"$avg": "$ratings.overall"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"responses": 1,
+			"averages": bson.M{
+				"communication": bson.M{"$round": bson.A{"$communication", 2}},
+				"scheduling":    bson.M{"$round": bson.A{"$scheduling", 2}},
+				"respect":       bson.M{"$round": bson.A{"$respect", 2}},
+				"clarity":       bson.M{"$round": bson.A{"$clarity", 2}},
+				"overall":       bson.M{"$round": bson.A{"$overall", 2}},
+			},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "responses", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	summaries := []*domain.InterviewFeedbackSummary{}
+	if err := cursor.All(ctx, &summaries); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+func (r *interviewFeedbackRepository) Comments(ctx context.Context, companyID string, limit int) ([]*domain.InterviewFeedbackComment, error) {
+	filter := bson.M{"company_id": companyID, "comment": bson.M{"$gt": ""}}
+
+	opts := options.Find()
+	opts.SetLimit(int64(limit))
+	opts.SetSort(bson.D{{Key: "submitted_on", Value: -1}})
+	opts.SetProjection(bson.M{"comment": 1, "overall": "$ratings.overall", "submitted_on": 1})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	comments := []*domain.InterviewFeedbackComment{}
+	if err := cursor.All(ctx, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
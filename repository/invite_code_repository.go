@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type InviteCodeRepository interface {
+	// Create stores the code, returning domain.ErrInviteCodeExists when it is taken
+	Create(ctx context.Context, code *domain.InviteCode) error
+	// List returns every code, most recent first
+	List(ctx context.Context) ([]*domain.InviteCode, error)
+	// Redeem uses up one signup of the code for an account of the role, or
+	// returns domain.ErrInviteCodeUnavailable
+	Redeem(ctx context.Context, code string, role domain.Role, now time.Time) (*domain.InviteCode, error)
+	// Release gives back a signup redeemed for an account that wasn't created
+	Release(ctx context.Context, code string) error
+	Disable(ctx context.Context, id string) error
+}
+
+type inviteCodeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewInviteCodeRepository(db *mongo.Database) InviteCodeRepository {
+	collection := db.Collection("invite_codes")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "code", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	)
+
+	return &inviteCodeRepository{
+		collection: collection,
+	}
+}
+
+func (r *inviteCodeRepository) Create(ctx context.Context, code *domain.InviteCode) error {
+	code.ID = primitive.NewObjectID()
+	code.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, code)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrInviteCodeExists
+	}
+	return err
+}
+
+func (r *inviteCodeRepository) List(ctx context.Context) ([]*domain.InviteCode, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	codes := []*domain.InviteCode{}
+	if err := cursor.All(ctx, &codes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (r *inviteCodeRepository) Redeem(ctx context.Context, code string, role domain.Role, now time.Time) (*domain.InviteCode, error) {
+	// The checks and the increment are one update, so concurrent signups can't exceed max_uses
+	filter := bson.M{
+		"code":        code,
+		"disabled_at": nil,
+		"role":        bson.M{"$in": bson.A{nil, "", role}},
+		"expires_at":  bson.M{"$not": bson.M{"$lte": now}},
+		"$expr":       bson.M{"$lt": bson.A{"$uses", "$max_uses"}},
+	}
+
+	var redeemed domain.InviteCode
+	err := r.collection.FindOneAndUpdate(ctx, filter,
+		bson.M{"$inc": bson.M{"uses": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&redeemed)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrInviteCodeUnavailable
+		}
+		return nil, err
+	}
+	return &redeemed, nil
+}
+
+func (r *inviteCodeRepository) Release(ctx context.Context, code string) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"code": code, "uses": bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{"uses": -1}},
+	)
+	return err
+}
+
+func (r *inviteCodeRepository) Disable(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": objectID, "disabled_at": nil},
+		bson.M{"$set": bson.M{"disabled_at": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrInviteCodeNotFound
+	}
+	return nil
+}
@@ -2,6 +2,10 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -15,26 +19,187 @@ import (
 type JobRepository interface {
 	CreateJob(ctx context.Context, job *domain.Job) error
 	GetJobByID(ctx context.Context, id string) (*domain.Job, error)
-	ListJobs(ctx context.Context, title, location, companyName string, page, limit int) ([]*domain.Job, int64, error)
-	GetJobsByCompanyID(ctx context.Context, companyID string, page, limit int) ([]*domain.Job, int64, error)
+	ListJobs(ctx context.Context, filter domain.JobFilter, page, limit int) ([]*domain.Job, int64, error)
+	// GetJobsByCompanyID returns the company's jobs matching filter, the archived ones only with the archived status
+	GetJobsByCompanyID(ctx context.Context, companyID string, filter domain.CompanyJobFilter, page, limit int) ([]*domain.Job, int64, error)
+	// ListPublishedByCompany returns the company's published jobs, ranked like the job listings
+	ListPublishedByCompany(ctx context.Context, companyID string, page, limit int) ([]*domain.Job, int64, error)
+	// UpdateJob changes the fields set in the request and leaves the others as they are
 	UpdateJob(ctx context.Context, id string, update *domain.UpdateJobRequest) error
+	// DeleteJob archives the job: it is unpublished and left out of every query
 	DeleteJob(ctx context.Context, id string) error
+	// GetArchivedJob returns a job deleted by its company
+	GetArchivedJob(ctx context.Context, id string) (*domain.Job, error)
+	// RestoreJob brings an archived job back as an unpublished draft
+	RestoreJob(ctx context.Context, id string) error
 	JobBelongsToUser(ctx context.Context, jobID, userID string) (bool, error)
+	// GetJobChanges returns the jobs modified after since and the IDs of jobs deleted after since
+	GetJobChanges(ctx context.Context, since time.Time) ([]*domain.Job, []string, error)
+	// ListLocations returns the distinct locations of published jobs
+	ListLocations(ctx context.Context) ([]string, error)
+	// CountPublishedByCategory returns the number of published jobs per category slug
+	CountPublishedByCategory(ctx context.Context) (map[string]int64, error)
+	// HasJobsInCategory reports whether any job, published or not, uses the category
+	HasJobsInCategory(ctx context.Context, slug string) (bool, error)
+	// SkillFacets returns the skills most required by published jobs, most common first
+	SkillFacets(ctx context.Context, limit int) ([]*domain.SkillFacet, error)
+	// JobFacets counts the jobs matching the listing filter per location,
+	// category, employment type and company
+	JobFacets(ctx context.Context, filter domain.JobFilter, limit int) (*domain.JobFacets, error)
+	// SuggestValues returns the distinct values of field (title or location)
+	// among published jobs starting with prefix, ignoring case, the most used first
+	SuggestValues(ctx context.Context, field, prefix string, limit int) ([]string, error)
+	// ListRecommendedJobs returns the latest published jobs not matching exclusions
+	ListRecommendedJobs(ctx context.Context, exclusions domain.JobExclusions, page, limit int) ([]*domain.Job, int64, error)
+	// ListSimilarJobs returns the listed jobs sharing skills, the category or
+	// the location with the job, most in common first, leaving out those matching exclusions
+	ListSimilarJobs(ctx context.Context, job *domain.Job, exclusions domain.JobExclusions, limit int) ([]*domain.Job, error)
+	// ListListedByIDs returns the jobs among ids that are listed (published, not
+	// expired), in no particular order
+	ListListedByIDs(ctx context.Context, ids []primitive.ObjectID) ([]*domain.Job, error)
+	// ListLatest returns the listed jobs matching filter, the most recently
+	// posted or reposted first
+	ListLatest(ctx context.Context, filter domain.JobFilter, limit int) ([]*domain.Job, error)
+	// ListSitemapJobs returns the listed jobs, the most recently posted first
+	ListSitemapJobs(ctx context.Context, limit int) ([]*domain.SitemapJob, error)
+	// ListPostedSince returns the newest published jobs matching filter and
+	// not exclusions that were posted, reposted or went live after since, with their count
+	ListPostedSince(ctx context.Context, filter domain.JobFilter, exclusions domain.JobExclusions, since time.Time, limit int) ([]*domain.Job, int64, error)
+	// UnpublishByCompany hides every job posted by the company
+	UnpublishByCompany(ctx context.Context, companyID string) error
+	// UnpublishPastDeadline hides the published jobs whose deadline passed by now
+	// and returns how many there were
+	UnpublishPastDeadline(ctx context.Context, now time.Time) (int64, error)
+	// PublishScheduled publishes the jobs whose publish time has come, unless
+	// they were closed, expired, passed their deadline or weren't approved
+	// meanwhile, and returns how many there were
+	PublishScheduled(ctx context.Context, now time.Time) (int64, error)
+	// UnpublishExpired hides the published jobs that expired by now and returns how many there were
+	UnpublishExpired(ctx context.Context, now time.Time) (int64, error)
+	ConfirmHiring(ctx context.Context, id string) error
+	// RepostJob moves the job's posting and bump dates to now and renews the hiring confirmation
+	RepostJob(ctx context.Context, id string) error
+	// SetPublished publishes or unpublishes the job, dropping its publish schedule
+	SetPublished(ctx context.Context, id string, published bool) error
+	AddAuditEntry(ctx context.Context, entry *domain.JobAuditEntry) error
+	// CountAuditEntries counts the job's audit entries with one of the actions since a point in time
+	CountAuditEntries(ctx context.Context, jobID string, actions []string, since time.Time) (int64, error)
+	// ListJobsNeedingHiringReminder returns published jobs whose hiring confirmation
+	// is older than confirmedBefore and whose company wasn't reminded yet
+	ListJobsNeedingHiringReminder(ctx context.Context, confirmedBefore time.Time, limit int) ([]*domain.Job, error)
+	MarkHiringReminderSent(ctx context.Context, id primitive.ObjectID) error
+	// ListJobsAwaitingFollowerNotice returns published jobs whose company's followers weren't told about yet
+	ListJobsAwaitingFollowerNotice(ctx context.Context, limit int) ([]*domain.Job, error)
+	MarkFollowersNotified(ctx context.Context, id primitive.ObjectID) error
+	// ListAwaitingGeocoding returns jobs with a location that wasn't geocoded yet
+	ListAwaitingGeocoding(ctx context.Context, limit int) ([]*domain.Job, error)
+	// SetCoordinates records the coordinates geocoded for the job's location,
+	// nil when it couldn't be found. Nothing is changed when the job's
+	// location is no longer the one that was geocoded.
+	SetCoordinates(ctx context.Context, id primitive.ObjectID, location string, coordinates *domain.GeoPoint) error
+	// CloseJob records the closing and unpublishes the job
+	CloseJob(ctx context.Context, id string, closing *domain.JobClosing) error
+	// ClosingSummaries aggregates the jobs closed in the filter's period per reason
+	ClosingSummaries(ctx context.Context, filter domain.HiringReportFilter) ([]*domain.JobClosingSummary, error)
+	// SetModeration records the job's review, nil withdraws it
+	SetModeration(ctx context.Context, id string, moderation *domain.JobModeration) error
+	// ListPendingReview returns the open jobs waiting for review, the longest waiting first
+	ListPendingReview(ctx context.Context, page, limit int) ([]*domain.Job, int64, error)
+	// ReviewJob records the outcome of the review of a job waiting for it,
+	// publishing the job with publish. It returns domain.ErrJobNotFound when
+	// the job isn't waiting for review.
+	ReviewJob(ctx context.Context, id string, moderation *domain.JobModeration, publish bool) error
+	// FeatureJob features the job of companyID from since until until. It
+	// fails with domain.ErrFeaturedJobsLimit, leaving the job as it was, when
+	// the company would have more than maxFeatured jobs featured at once.
+	FeatureJob(ctx context.Context, id, companyID string, since, until time.Time, maxFeatured int) error
+	// UnfeatureJob stops featuring the job
+	UnfeatureJob(ctx context.Context, id string) error
+	// UnfeatureExpired clears the features that ran out by now and returns how many there were
+	UnfeatureExpired(ctx context.Context, now time.Time) (int64, error)
+	// ReserveApplication counts an application to the job and returns the
+	// job, or domain.ErrJobFull when its cap is reached. The check and the
+	// count are atomic, so concurrent applications can't exceed the cap.
+	ReserveApplication(ctx context.Context, id primitive.ObjectID) (*domain.Job, error)
+	// ReleaseApplication gives back an application counted by ReserveApplication
+	ReleaseApplication(ctx context.Context, id primitive.ObjectID) error
+	// RaiseApplicationsReceived raises the job's application count to count
+	// when it is lower, for jobs that took applications before they were
+	// counted. Applications reserved meanwhile are kept.
+	RaiseApplicationsReceived(ctx context.Context, id primitive.ObjectID, count int64) error
+	// CapApplications unpublishes the job if it reached its cap. Both are read
+	// in the update, so a cap raised or an application released meanwhile
+	// keeps the job open.
+	CapApplications(ctx context.Context, id primitive.ObjectID, at time.Time) error
 }
 
 type jobRepository struct {
 	collection *mongo.Collection
+	tombstones *mongo.Collection
+	audit      *mongo.Collection
 }
 
 func NewJobRepository(db *mongo.Database) JobRepository {
+	collection := db.Collection("jobs")
+	tombstones := db.Collection("job_tombstones")
+	audit := db.Collection("job_audit")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "updated_at", Value: 1}}},
+		// Company job lists and archives
+		mongo.IndexModel{Keys: bson.D{{Key: "created_by", Value: 1}, {Key: "deleted_at", Value: 1}}},
+		// Category filters and counts
+		mongo.IndexModel{Keys: bson.D{{Key: "category", Value: 1}, {Key: "is_published", Value: 1}}},
+		// Skill filters and facets
+		mongo.IndexModel{Keys: bson.D{{Key: "skills", Value: 1}}},
+		// Search box suggestions
+		mongo.IndexModel{Keys: bson.D{{Key: "is_published", Value: 1}, {Key: "title", Value: 1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "is_published", Value: 1}, {Key: "location", Value: 1}}},
+		// Distance filters, and locations waiting to be geocoded
+		mongo.IndexModel{Keys: bson.D{{Key: "coordinates", Value: "2dsphere"}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "geocoded_location", Value: 1}}},
+		// Full-text search, a match in the title counts most
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}, {Key: "skills", Value: "text"}},
+			Options: options.Index().SetName("job_text").SetWeights(bson.D{
+				{Key: "title", Value: 10},
+				{Key: "skills", Value: 5},
+				{Key: "description", Value: 1},
+			}),
+		},
+		// Unpublishing jobs past their deadline or expired, publishing scheduled ones
+		mongo.IndexModel{Keys: bson.D{{Key: "is_published", Value: 1}, {Key: "deadline", Value: 1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "is_published", Value: 1}, {Key: "expires_at", Value: 1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "is_published", Value: 1}, {Key: "publish_at", Value: 1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "featured_until", Value: 1}}},
+		// Moderation queue
+		mongo.IndexModel{Keys: bson.D{{Key: "moderation.status", Value: 1}, {Key: "moderation.submitted_at", Value: 1}}},
+	)
+	ensureIndexes(tombstones,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "deleted_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(domain.JobTombstoneRetention.Seconds())),
+		},
+	)
+
+	ensureIndexes(audit,
+		mongo.IndexModel{Keys: bson.D{{Key: "job_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	)
+
 	return &jobRepository{
-		collection: db.Collection("jobs"),
+		collection: collection,
+		tombstones: tombstones,
+		audit:      audit,
 	}
 }
 
 func (r *jobRepository) CreateJob(ctx context.Context, job *domain.Job) error {
 	job.CreatedAt = time.Now()
 	job.UpdatedAt = time.Now()
+	if job.BumpedAt == nil {
+		bumped := job.CreatedAt
+		job.BumpedAt = &bumped
+	}
 
 	result, err := r.collection.InsertOne(ctx, job)
 	if err != nil {
@@ -48,93 +213,195 @@ func (r *jobRepository) CreateJob(ctx context.Context, job *domain.Job) error {
 	return nil
 }
 
-func (r *jobRepository) ListJobs(ctx context.Context, title, location, companyName string, page, limit int) ([]*domain.Job, int64, error) {
-	// Build filter based on provided parameters
-	filter := bson.M{"is_published": true} // Only show published jobs by default
+func (r *jobRepository) ListJobs(ctx context.Context, filter domain.JobFilter, page, limit int) ([]*domain.Job, int64, error) {
+	query := listingQuery(filter)
+
+	// Set default values if not provided
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	// Get total count for pagination
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Featured jobs first, then best matches when searching, then actively
+	// hiring jobs, most recent first
+	jobs, err := r.findRanked(ctx, query, page, limit, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, total, nil
+}
+
+// salaryBounds matches the salary ranges overlapping min and max, multiplied by rate
+func salaryBounds(min, max *float64, rate float64) bson.M {
+	bounds := bson.M{}
+	if min != nil {
+		bounds["salary.max"] = bson.M{"$gte": *min * rate}
+	}
+	if max != nil {
+		bounds["salary.min"] = bson.M{"$lte": *max * rate}
+	}
+	return bounds
+}
+
+// listingQuery builds the query of the published jobs matching a job listing filter
+func listingQuery(filter domain.JobFilter) bson.M {
+	// Build query based on provided filters
+	query := notDeleted(bson.M{"is_published": true}) // Only show published jobs by default
+	// Expired postings are left out before the scheduler unpublishes them
+	query["expires_at"] = bson.M{"$not": bson.M{"$lte": time.Now()}}
 
-	if title != "" {
-		filter["title"] = bson.M{"$regex": primitive.Regex{Pattern: title, Options: "i"}}
+	if filter.Query != "" {
+		query["$text"] = bson.M{"$search": filter.Query}
 	}
 
-	if location != "" {
-		filter["location"] = bson.M{"$regex": primitive.Regex{Pattern: location, Options: "i"}}
+	if filter.Location != "" {
+		query["location"] = bson.M{"$regex": primitive.Regex{Pattern: filter.Location, Options: "i"}}
 	}
 
-	if companyName != "" {
+	if filter.CompanyName != "" {
 		// This would require a join with the users collection in a real implementation
 		// For now, we'll just filter by created_by if it matches the company name
-		filter["created_by"] = companyName
+		query["created_by"] = filter.CompanyName
 	}
-	// Set default values if not provided
-	if page < 1 {
-		page = 1
+
+	if filter.Category != "" {
+		query["category"] = filter.Category
 	}
-	if limit < 1 {
-		limit = 10
+	if filter.EmploymentType != "" {
+		query["employment_type"] = filter.EmploymentType
+	}
+	if filter.ExperienceLevel != "" {
+		query["experience_level"] = filter.ExperienceLevel
+	}
+	if filter.Remote != nil {
+		query["remote"] = *filter.Remote
 	}
 
-	// Build the query
-	query := bson.M{"deleted_at": nil}
-
-	// Apply filters
-	if title, ok := filter["title"].(string); ok && title != "" {
-		query["title"] = bson.M{"$regex": primitive.Regex{Pattern: title, Options: "i"}}
+	if len(filter.Skills) > 0 {
+		query["skills"] = bson.M{"$all": filter.Skills}
 	}
 
-	if location, ok := filter["location"].(string); ok && location != "" {
-		query["location"] = bson.M{"$regex": primitive.Regex{Pattern: location, Options: "i"}}
+	// Salary filters match ranges overlapping the requested one, jobs without a salary never match
+	if len(filter.SalaryRates) > 0 && (filter.SalaryMin != nil || filter.SalaryMax != nil) {
+		// The bounds are converted into each currency, salaries in other currencies never match
+		codes := make([]string, 0, len(filter.SalaryRates))
+		for code := range filter.SalaryRates {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+
+		matches := make(bson.A, 0, len(codes))
+		for _, code := range codes {
+			match := salaryBounds(filter.SalaryMin, filter.SalaryMax, filter.SalaryRates[code])
+			match["salary.currency"] = code
+			matches = append(matches, match)
+		}
+		query["$or"] = matches
+	} else {
+		for field, bound := range salaryBounds(filter.SalaryMin, filter.SalaryMax, 1) {
+			query[field] = bound
+		}
 	}
 
-	if companyName, ok := filter["company_name"].(string); ok && companyName != "" {
-		// This would require a join with users collection in a real implementation
-		// For now, we'll just add it to the query
-		query["created_by_name"] = bson.M{"$regex": primitive.Regex{Pattern: companyName, Options: "i"}}
+	// $centerSphere takes the radius in radians
+	if filter.Near != nil {
+		query["coordinates"] = bson.M{"$geoWithin": bson.M{"$centerSphere": bson.A{
+			bson.A{filter.Near.Lng, filter.Near.Lat},
+			filter.Near.RadiusKm / domain.EarthRadiusKm,
+		}}}
 	}
 
-	// Get total count for pagination
-	total, err := r.collection.CountDocuments(ctx, filter)
-	if err != nil {
-		return nil, 0, err
+	return query
+}
+
+// findRanked returns a page of jobs matching filter. Jobs whose hiring
+// confirmation lapsed are down-ranked below actively hiring ones. When filter
+// is a text search the most relevant jobs come first, after the featured jobs
+// with featuredFirst.
+func (r *jobRepository) findRanked(ctx context.Context, filter bson.M, page, limit int, featuredFirst bool) ([]*domain.Job, error) {
+	now := time.Now()
+	cutoff := now.Add(-domain.ActivelyHiringWindow)
+
+	fields := bson.M{
+		"actively_hiring": bson.M{"$gte": bson.A{
+			bson.M{"$ifNull": bson.A{"$hiring_confirmed_at", "$created_at"}},
+			cutoff,
+		}},
+		// Edits don't move a job up, only posting and reposting do
+		"ranked_at": bson.M{"$ifNull": bson.A{"$bumped_at", "$created_at"}},
+	}
+	sort := bson.D{{Key: "actively_hiring", Value: -1}, {Key: "ranked_at", Value: -1}, {Key: "_id", Value: -1}}
+	if _, ok := filter["$text"]; ok {
+		fields["text_score"] = bson.M{"$meta": "textScore"}
+		sort = append(bson.D{{Key: "text_score", Value: -1}}, sort...)
+	}
+	// Features run out by themselves, the scheduler only clears them up
+	if featuredFirst {
+		fields["featured"] = bson.M{"$gt": bson.A{"$featured_until", now}}
+		sort = append(bson.D{{Key: "featured", Value: -1}}, sort...)
 	}
 
-	// Set up pagination options
-	opts := options.Find()
-	opts.SetSkip(int64((page - 1) * limit))
-	opts.SetLimit(int64(limit))
-	opts.SetSort(bson.D{{Key: "created_at", Value: -1}}) // Sort by most recent first
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: fields}},
+		{{Key: "$sort", Value: sort}},
+		{{Key: "$skip", Value: int64((page - 1) * limit)}},
+		{{Key: "$limit", Value: int64(limit)}},
+		{{Key: "$project", Value: bson.M{"actively_hiring": 0, "ranked_at": 0, "text_score": 0, "featured": 0}}},
+	}
 
-	// Execute query with filter and options
-	cursor, err := r.collection.Find(ctx, filter, opts)
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	// Decode results
-	var jobs []*domain.Job
+	jobs := []*domain.Job{}
 	if err := cursor.All(ctx, &jobs); err != nil {
-		return nil, 0, err
-	}
-
-	// If no jobs found, return empty slice instead of nil
-	if jobs == nil {
-		jobs = []*domain.Job{}
+		return nil, err
 	}
 
-	return jobs, total, nil
+	return jobs, nil
 }
 
 func (r *jobRepository) GetJobByID(ctx context.Context, id string) (*domain.Job, error) {
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
+		return nil, domain.ErrInvalidID
+	}
+
+	var job domain.Job
+	err = r.collection.FindOne(ctx, notDeleted(bson.M{"_id": objID})).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrJobNotFound
+		}
 		return nil, err
 	}
 
+	return &job, nil
+}
+
+func (r *jobRepository) GetArchivedJob(ctx context.Context, id string) (*domain.Job, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidID
+	}
+
 	var job domain.Job
-	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&job)
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID, "deleted_at": bson.M{"$ne": nil}}).Decode(&job)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, nil
+			return nil, domain.ErrJobNotFound
 		}
 		return nil, err
 	}
@@ -142,7 +409,7 @@ func (r *jobRepository) GetJobByID(ctx context.Context, id string) (*domain.Job,
 	return &job, nil
 }
 
-func (r *jobRepository) GetJobsByCompanyID(ctx context.Context, companyID string, page, limit int) ([]*domain.Job, int64, error) {
+func (r *jobRepository) GetJobsByCompanyID(ctx context.Context, companyID string, companyFilter domain.CompanyJobFilter, page, limit int) ([]*domain.Job, int64, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -154,6 +421,36 @@ func (r *jobRepository) GetJobsByCompanyID(ctx context.Context, companyID string
 
 	// Create filter for company ID
 	filter := bson.M{"created_by": companyID}
+	sortBy := "created_at"
+	switch companyFilter.Status {
+	case domain.JobStatusArchived:
+		filter["deleted_at"] = bson.M{"$ne": nil}
+		sortBy = "deleted_at"
+	case domain.JobStatusDraft:
+		filter["is_published"] = false
+		notDeleted(filter)
+	case domain.JobStatusPublished:
+		filter["is_published"] = true
+		notDeleted(filter)
+	case domain.JobStatusPendingReview:
+		filter["moderation.status"] = domain.JobPendingReview
+		notDeleted(filter)
+	default:
+		notDeleted(filter)
+	}
+	if title := strings.TrimSpace(companyFilter.Title); title != "" {
+		filter["title"] = bson.M{"$regex": primitive.Regex{Pattern: regexp.QuoteMeta(title), Options: "i"}}
+	}
+	if !companyFilter.CreatedFrom.IsZero() || !companyFilter.CreatedTo.IsZero() {
+		created := bson.M{}
+		if !companyFilter.CreatedFrom.IsZero() {
+			created["$gte"] = companyFilter.CreatedFrom
+		}
+		if !companyFilter.CreatedTo.IsZero() {
+			created["$lt"] = companyFilter.CreatedTo
+		}
+		filter["created_at"] = created
+	}
 
 	// Count total matching documents
 	total, err := r.collection.CountDocuments(ctx, filter)
@@ -165,7 +462,7 @@ func (r *jobRepository) GetJobsByCompanyID(ctx context.Context, companyID string
 	opts := options.Find()
 	opts.SetSkip(int64(skip))
 	opts.SetLimit(int64(limit))
-	opts.SetSort(bson.D{{Key: "created_at", Value: -1}}) // Sort by most recent first
+	opts.SetSort(bson.D{{Key: sortBy, Value: -1}}) // Sort by most recent first
 
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -181,52 +478,190 @@ func (r *jobRepository) GetJobsByCompanyID(ctx context.Context, companyID string
 	return jobs, total, nil
 }
 
+func (r *jobRepository) ListPublishedByCompany(ctx context.Context, companyID string, page, limit int) ([]*domain.Job, int64, error) {
+	filter := notDeleted(bson.M{"created_by": companyID, "is_published": true})
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	jobs, err := r.findRanked(ctx, filter, page, limit, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, total, nil
+}
+
 func (r *jobRepository) UpdateJob(ctx context.Context, id string, update *domain.UpdateJobRequest) error {
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return err
+		return domain.ErrInvalidID
 	}
 
+	// Only the fields present in the request are changed
 	updateFields := bson.M{
 		"$set": bson.M{
-			"title":       update.Title,
-			"description": update.Description,
-			"location":    update.Location,
-			"updated_at":  time.Now(),
+			"updated_at": time.Now(),
 		},
 	}
+	if update.Title != nil {
+		updateFields["$set"].(bson.M)["title"] = *update.Title
+	}
+	if update.Description != nil {
+		updateFields["$set"].(bson.M)["description"] = *update.Description
+	}
+	if update.Location != nil {
+		updateFields["$set"].(bson.M)["location"] = *update.Location
+	}
+	if update.EmploymentType != nil {
+		updateFields["$set"].(bson.M)["employment_type"] = *update.EmploymentType
+	}
+	if update.Category != nil {
+		updateFields["$set"].(bson.M)["category"] = *update.Category
+	}
+	if update.ExperienceLevel != nil {
+		updateFields["$set"].(bson.M)["experience_level"] = *update.ExperienceLevel
+	}
+	if update.Remote != nil {
+		updateFields["$set"].(bson.M)["remote"] = *update.Remote
+	}
+	if update.Skills != nil {
+		updateFields["$set"].(bson.M)["skills"] = update.Skills
+	}
+	if update.Salary != nil {
+		updateFields["$set"].(bson.M)["salary"] = update.Salary
+	}
+	if update.BlindScreening != nil {
+		updateFields["$set"].(bson.M)["blind_screening"] = *update.BlindScreening
+	}
+	if update.IsPublished != nil {
+		updateFields["$set"].(bson.M)["is_published"] = *update.IsPublished
+	}
+
+	// Optional dates are removed when their clear flag is set
+	unset := bson.M{}
+	// A new cap reopens a job closed by the previous one
+	if update.MaxApplications != nil {
+		if *update.MaxApplications > 0 {
+			updateFields["$set"].(bson.M)["max_applications"] = *update.MaxApplications
+		} else {
+			unset["max_applications"] = ""
+		}
+		unset["applications_capped_at"] = ""
+	}
+	if update.ApplyURL != nil {
+		if *update.ApplyURL != "" {
+			updateFields["$set"].(bson.M)["apply_url"] = *update.ApplyURL
+		} else {
+			unset["apply_url"] = ""
+		}
+	}
+	if update.Deadline != nil {
+		updateFields["$set"].(bson.M)["deadline"] = *update.Deadline
+	} else if update.ClearDeadline {
+		unset["deadline"] = ""
+	}
+	if update.PublishAt != nil {
+		updateFields["$set"].(bson.M)["publish_at"] = *update.PublishAt
+	} else if update.ClearPublishAt {
+		unset["publish_at"] = ""
+	}
+	if update.ExpiresAt != nil {
+		updateFields["$set"].(bson.M)["expires_at"] = *update.ExpiresAt
+	} else if update.ClearExpiresAt {
+		unset["expires_at"] = ""
+	}
+	// A new location is geocoded again
+	if update.Location != nil {
+		unset["coordinates"] = ""
+		unset["geocoded_location"] = ""
+	}
+	if len(unset) > 0 {
+		updateFields["$unset"] = unset
+	}
 
-	_, err = r.collection.UpdateOne(
+	result, err := r.collection.UpdateOne(
 		ctx,
-		bson.M{"_id": objID},
+		notDeleted(bson.M{"_id": objID}),
 		updateFields,
 	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrJobNotFound
+	}
 
-	return err
+	return nil
 }
 
 func (r *jobRepository) DeleteJob(ctx context.Context, id string) error {
 	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	// The publish schedule is dropped so the scheduler doesn't publish the archived job
+	now := time.Now()
+	result, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": objID}), bson.M{
+		"$set":   bson.M{"deleted_at": now, "is_published": false, "updated_at": now},
+		"$unset": bson.M{"publish_at": ""},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrJobNotFound
+	}
+
+	// Remember the deletion so delta sync clients can drop the job
+	_, err = r.tombstones.InsertOne(ctx, domain.JobTombstone{JobID: id, DeletedAt: now})
+	return err
+}
+
+func (r *jobRepository) RestoreJob(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID, "deleted_at": bson.M{"$ne": nil}}, bson.M{
+		"$set":   bson.M{"updated_at": time.Now()},
+		"$unset": bson.M{"deleted_at": ""},
+	})
 	if err != nil {
 		return err
 	}
+	if result.MatchedCount == 0 {
+		return domain.ErrJobNotFound
+	}
 
-	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objID})
+	// Sync clients see the job as changed again, not deleted
+	_, err = r.tombstones.DeleteMany(ctx, bson.M{"job_id": id})
 	return err
 }
 
+// notDeleted restricts a job query to the jobs that aren't archived. Every
+// query on jobs goes through it, except the archive listing and restore.
+func notDeleted(filter bson.M) bson.M {
+	filter["deleted_at"] = nil
+	return filter
+}
+
 func (r *jobRepository) JobBelongsToUser(ctx context.Context, jobID, userID string) (bool, error) {
 	objID, err := primitive.ObjectIDFromHex(jobID)
 	if err != nil {
-		return false, err
+		return false, domain.ErrInvalidID
 	}
 
 	count, err := r.collection.CountDocuments(
 		ctx,
-		bson.M{
+		notDeleted(bson.M{
 			"_id":       objID,
 			"created_by": userID,
-		},
+		}),
 	)
 
 	if err != nil {
@@ -234,4 +669,902 @@ func (r *jobRepository) JobBelongsToUser(ctx context.Context, jobID, userID stri
 	}
 
 	return count > 0, nil
-}
\ No newline at end of file
+}
+
+func (r *jobRepository) GetJobChanges(ctx context.Context, since time.Time) ([]*domain.Job, []string, error) {
+	// Only the fields needed to classify the change are loaded
+	opts := options.Find().SetProjection(bson.M{
+		"_id":          1,
+		"is_published": 1,
+		"created_at":   1,
+		"updated_at":   1,
+	})
+
+	cursor, err := r.collection.Find(ctx, notDeleted(bson.M{"updated_at": bson.M{"$gt": since}}), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []*domain.Job{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, nil, err
+	}
+
+	tombstoneCursor, err := r.tombstones.Find(ctx, bson.M{"deleted_at": bson.M{"$gt": since}})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tombstoneCursor.Close(ctx)
+
+	var tombstones []domain.JobTombstone
+	if err := tombstoneCursor.All(ctx, &tombstones); err != nil {
+		return nil, nil, err
+	}
+
+	deleted := make([]string, 0, len(tombstones))
+	for _, t := range tombstones {
+		deleted = append(deleted, t.JobID)
+	}
+
+	return jobs, deleted, nil
+}
+
+func (r *jobRepository) ListLocations(ctx context.Context) ([]string, error) {
+	values, err := r.collection.Distinct(ctx, "location", notDeleted(bson.M{"is_published": true, "location": bson.M{"$nin": bson.A{nil, ""}}}))
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]string, 0, len(values))
+	for _, v := range values {
+		if location, ok := v.(string); ok {
+			locations = append(locations, location)
+		}
+	}
+	sort.Strings(locations)
+
+	return locations, nil
+}
+
+func (r *jobRepository) CountPublishedByCategory(ctx context.Context) (map[string]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: notDeleted(bson.M{"is_published": true, "category": bson.M{"$nin": bson.A{nil, ""}}})}},
+		{{Key: "$group", Value: bson.M{"_id": "$category", "jobs": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Category string `bson:"_id"`
+		Jobs     int64  `bson:"jobs"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Category] = row.Jobs
+	}
+	return counts, nil
+}
+
+func (r *jobRepository) HasJobsInCategory(ctx context.Context, slug string) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, notDeleted(bson.M{"category": slug}), options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *jobRepository) SkillFacets(ctx context.Context, limit int) ([]*domain.SkillFacet, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: notDeleted(bson.M{"is_published": true, "skills.0": bson.M{"$exists": true}})}},
+		{{Key: "$unwind", Value: "$skills"}},
+		{{Key: "$group", Value: bson.M{"_id": "$skills", "jobs": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "jobs", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	facets := []*domain.SkillFacet{}
+	if err := cursor.All(ctx, &facets); err != nil {
+		return nil, err
+	}
+	return facets, nil
+}
+
+func (r *jobRepository) JobFacets(ctx context.Context, filter domain.JobFilter, limit int) (*domain.JobFacets, error) {
+	// count groups the jobs having a value in field, most common first
+	count := func(field string) bson.A {
+		return bson.A{
+			bson.M{"$match": bson.M{field: bson.M{"$nin": bson.A{nil, ""}}}},
+			bson.M{"$group": bson.M{"_id": "$" + field, "jobs": bson.M{"$sum": 1}}},
+			bson.M{"$sort": bson.D{{Key: "jobs", Value: -1}, {Key: "_id", Value: 1}}},
+			bson.M{"$limit": limit},
+		}
+	}
+
+	// A single pass over the matching jobs computes every facet
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: listingQuery(filter)}},
+		{{Key: "$facet", Value: bson.M{
+			"total":            bson.A{bson.M{"$count": "jobs"}},
+			"locations":        count("location"),
+			"categories":       count("category"),
+			"employment_types": count("employment_type"),
+			"companies":        count("created_by"),
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Total []struct {
+			Jobs int64 `bson:"jobs"`
+		} `bson:"total"`
+		domain.JobFacets `bson:",inline"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	facets := &domain.JobFacets{}
+	if len(rows) > 0 {
+		facets = &rows[0].JobFacets
+		if len(rows[0].Total) > 0 {
+			facets.Total = rows[0].Total[0].Jobs
+		}
+	}
+	return facets, nil
+}
+
+func (r *jobRepository) SuggestValues(ctx context.Context, field, prefix string, limit int) ([]string, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: notDeleted(bson.M{
+			"is_published": true,
+			field:          bson.M{"$regex": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(prefix), Options: "i"}},
+		})}},
+		{{Key: "$group", Value: bson.M{"_id": "$" + field, "jobs": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "jobs", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Value string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(rows))
+	for _, row := range rows {
+		values = append(values, row.Value)
+	}
+	return values, nil
+}
+
+func (r *jobRepository) ListRecommendedJobs(ctx context.Context, exclusions domain.JobExclusions, page, limit int) ([]*domain.Job, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	filter := notDeleted(bson.M{"is_published": true})
+	applyExclusions(filter, exclusions)
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	jobs, err := r.findRanked(ctx, filter, page, limit, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, total, nil
+}
+
+func (r *jobRepository) ListSimilarJobs(ctx context.Context, job *domain.Job, exclusions domain.JobExclusions, limit int) ([]*domain.Job, error) {
+	skills := job.Skills
+	if skills == nil {
+		skills = []string{}
+	}
+
+	// Only jobs with something in common are candidates
+	overlap := bson.A{}
+	if len(skills) > 0 {
+		overlap = append(overlap, bson.M{"skills": bson.M{"$in": skills}})
+	}
+	if job.Category != "" {
+		overlap = append(overlap, bson.M{"category": job.Category})
+	}
+	if job.Location != "" {
+		overlap = append(overlap, bson.M{"location": bson.M{"$regex": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(job.Location) + "$", Options: "i"}}})
+	}
+	if len(overlap) == 0 {
+		return []*domain.Job{}, nil
+	}
+
+	query := listingQuery(domain.JobFilter{})
+	query["_id"] = bson.M{"$ne": job.ID}
+	query["$or"] = overlap
+	applyExclusions(query, exclusions)
+
+	// matches is 1 when the field of the candidate equals the job's
+	matches := func(field interface{}, value string) bson.M {
+		return bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{field, value}}, 1, 0}}
+	}
+	score := bson.M{"$add": bson.A{
+		bson.M{"$multiply": bson.A{
+			bson.M{"$size": bson.M{"$setIntersection": bson.A{bson.M{"$ifNull": bson.A{"$skills", bson.A{}}}, skills}}},
+			domain.SimilarSkillWeight,
+		}},
+		bson.M{"$multiply": bson.A{matches("$category", job.Category), domain.SimilarCategoryWeight}},
+		bson.M{"$multiply": bson.A{
+			matches(bson.M{"$toLower": bson.M{"$ifNull": bson.A{"$location", ""}}}, strings.ToLower(job.Location)),
+			domain.SimilarLocationWeight,
+		}},
+	}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: query}},
+		{{Key: "$addFields", Value: bson.M{
+			"similarity": score,
+			"ranked_at":  bson.M{"$ifNull": bson.A{"$bumped_at", "$created_at"}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "similarity", Value: -1}, {Key: "ranked_at", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$limit", Value: int64(limit)}},
+		{{Key: "$project", Value: bson.M{"similarity": 0, "ranked_at": 0}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []*domain.Job{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (r *jobRepository) ListListedByIDs(ctx context.Context, ids []primitive.ObjectID) ([]*domain.Job, error) {
+	if len(ids) == 0 {
+		return []*domain.Job{}, nil
+	}
+
+	query := listingQuery(domain.JobFilter{})
+	query["_id"] = bson.M{"$in": ids}
+
+	cursor, err := r.collection.Find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []*domain.Job{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (r *jobRepository) ListLatest(ctx context.Context, filter domain.JobFilter, limit int) ([]*domain.Job, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: listingQuery(filter)}},
+		{{Key: "$addFields", Value: bson.M{"posted_at": bson.M{"$ifNull": bson.A{"$bumped_at", "$created_at"}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "posted_at", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$limit", Value: int64(limit)}},
+		{{Key: "$project", Value: bson.M{"posted_at": 0}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []*domain.Job{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (r *jobRepository) ListSitemapJobs(ctx context.Context, limit int) ([]*domain.SitemapJob, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"title": 1, "updated_at": 1, "bumped_at": 1}).
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, listingQuery(domain.JobFilter{}), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []*domain.SitemapJob{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (r *jobRepository) ListPostedSince(ctx context.Context, filter domain.JobFilter, exclusions domain.JobExclusions, since time.Time, limit int) ([]*domain.Job, int64, error) {
+	query := listingQuery(filter)
+	applyExclusions(query, exclusions)
+	// Going live bumps scheduled jobs, like reposting. $and keeps the salary $or of the listing query.
+	query["$and"] = bson.A{bson.M{"$or": bson.A{
+		bson.M{"created_at": bson.M{"$gt": since}},
+		bson.M{"bumped_at": bson.M{"$gt": since}},
+	}}}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*domain.Job{}, 0, nil
+	}
+
+	jobs, err := r.findRanked(ctx, query, 1, limit, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, total, nil
+}
+
+// applyExclusions adds an applicant's blacklist to a job query. Every job
+// matching query (alerts, recommendations) must go through it.
+func applyExclusions(filter bson.M, exclusions domain.JobExclusions) {
+	if len(exclusions.Companies) > 0 {
+		filter["created_by"] = bson.M{"$nin": exclusions.Companies}
+	}
+
+	if len(exclusions.Keywords) > 0 {
+		excluded := make(bson.A, 0, len(exclusions.Keywords)*2)
+		for _, keyword := range exclusions.Keywords {
+			pattern := primitive.Regex{Pattern: regexp.QuoteMeta(keyword), Options: "i"}
+			excluded = append(excluded,
+				bson.M{"title": bson.M{"$regex": pattern}},
+				bson.M{"description": bson.M{"$regex": pattern}},
+			)
+		}
+		filter["$nor"] = excluded
+	}
+}
+
+func (r *jobRepository) UnpublishByCompany(ctx context.Context, companyID string) error {
+	_, err := r.collection.UpdateMany(
+		ctx,
+		notDeleted(bson.M{"created_by": companyID, "is_published": true}),
+		bson.M{"$set": bson.M{"is_published": false, "updated_at": time.Now()}},
+	)
+	return err
+}
+
+func (r *jobRepository) UnpublishPastDeadline(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.collection.UpdateMany(
+		ctx,
+		notDeleted(bson.M{"is_published": true, "deadline": bson.M{"$lte": now}}),
+		bson.M{"$set": bson.M{"is_published": false, "updated_at": now}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *jobRepository) PublishScheduled(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.collection.UpdateMany(
+		ctx,
+		notDeleted(bson.M{
+			"is_published": false,
+			"publish_at":   bson.M{"$lte": now},
+			"closing":      nil,
+			"expires_at":   bson.M{"$not": bson.M{"$lte": now}},
+			"deadline":     bson.M{"$not": bson.M{"$lte": now}},
+			// Jobs waiting for review or rejected by it only go live once approved
+			"moderation.status": bson.M{"$nin": bson.A{domain.JobPendingReview, domain.JobRejected}},
+		}),
+		bson.M{
+			// Going live counts as posting the job, so it ranks as a fresh posting
+			"$set":   bson.M{"is_published": true, "bumped_at": now, "updated_at": now},
+			"$unset": bson.M{"publish_at": ""},
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *jobRepository) UnpublishExpired(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.collection.UpdateMany(
+		ctx,
+		notDeleted(bson.M{"is_published": true, "expires_at": bson.M{"$lte": now}}),
+		bson.M{"$set": bson.M{"is_published": false, "updated_at": now}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *jobRepository) ConfirmHiring(ctx context.Context, id string) error {
+	return r.renewHiring(ctx, id, bson.M{})
+}
+
+func (r *jobRepository) RepostJob(ctx context.Context, id string) error {
+	now := time.Now()
+	return r.renewHiring(ctx, id, bson.M{"created_at": now, "bumped_at": now})
+}
+
+func (r *jobRepository) SetPublished(ctx context.Context, id string, published bool) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": objID}), bson.M{
+		"$set":   bson.M{"is_published": published, "updated_at": time.Now()},
+		"$unset": bson.M{"publish_at": ""},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrJobNotFound
+	}
+
+	return nil
+}
+
+// renewHiring sets the hiring confirmation to now, along with fields, and
+// re-arms the reminder for the next time the confirmation runs out
+func (r *jobRepository) renewHiring(ctx context.Context, id string, fields bson.M) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	now := time.Now()
+	fields["hiring_confirmed_at"] = now
+	fields["updated_at"] = now
+
+	result, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": objID}), bson.M{
+		"$set":   fields,
+		"$unset": bson.M{"hiring_reminder_sent_at": ""},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrJobNotFound
+	}
+
+	return nil
+}
+
+func (r *jobRepository) AddAuditEntry(ctx context.Context, entry *domain.JobAuditEntry) error {
+	entry.ID = primitive.NewObjectID()
+	entry.CreatedAt = time.Now()
+
+	_, err := r.audit.InsertOne(ctx, entry)
+	return err
+}
+
+func (r *jobRepository) CountAuditEntries(ctx context.Context, jobID string, actions []string, since time.Time) (int64, error) {
+	return r.audit.CountDocuments(ctx, bson.M{
+		"job_id":     jobID,
+		"action":     bson.M{"$in": actions},
+		"created_at": bson.M{"$gte": since},
+	})
+}
+
+func (r *jobRepository) ListJobsNeedingHiringReminder(ctx context.Context, confirmedBefore time.Time, limit int) ([]*domain.Job, error) {
+	filter := notDeleted(bson.M{
+		"is_published":            true,
+		"hiring_reminder_sent_at": nil,
+		"$or": bson.A{
+			bson.M{"hiring_confirmed_at": bson.M{"$lt": confirmedBefore}},
+			// Jobs posted before the hiring signal existed count from their posting date
+			bson.M{"hiring_confirmed_at": nil, "created_at": bson.M{"$lt": confirmedBefore}},
+		},
+	})
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []*domain.Job{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (r *jobRepository) MarkHiringReminderSent(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"hiring_reminder_sent_at": time.Now()}})
+	return err
+}
+
+func (r *jobRepository) ListJobsAwaitingFollowerNotice(ctx context.Context, limit int) ([]*domain.Job, error) {
+	// Jobs without the field predate follows and are skipped
+	filter := notDeleted(bson.M{"is_published": true, "followers_notified": false})
+
+	opts := options.Find().SetLimit(int64(limit)).SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []*domain.Job{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (r *jobRepository) MarkFollowersNotified(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"followers_notified": true}})
+	return err
+}
+
+func (r *jobRepository) ListAwaitingGeocoding(ctx context.Context, limit int) ([]*domain.Job, error) {
+	filter := notDeleted(bson.M{"geocoded_location": nil, "location": bson.M{"$nin": bson.A{nil, ""}}})
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []*domain.Job{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (r *jobRepository) SetCoordinates(ctx context.Context, id primitive.ObjectID, location string, coordinates *domain.GeoPoint) error {
+	update := bson.M{"$set": bson.M{"geocoded_location": location}}
+	if coordinates != nil {
+		update["$set"].(bson.M)["coordinates"] = coordinates
+	} else {
+		update["$unset"] = bson.M{"coordinates": ""}
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "location": location}, update)
+	return err
+}
+
+func (r *jobRepository) CloseJob(ctx context.Context, id string, closing *domain.JobClosing) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		notDeleted(bson.M{"_id": objID, "closing": nil}),
+		bson.M{"$set": bson.M{"closing": closing, "is_published": false, "updated_at": closing.ClosedAt}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrJobNotFound
+	}
+
+	return nil
+}
+
+func (r *jobRepository) ClosingSummaries(ctx context.Context, filter domain.HiringReportFilter) ([]*domain.JobClosingSummary, error) {
+	match := notDeleted(bson.M{"closing.closed_at": bson.M{"$gte": filter.From, "$lt": filter.To}})
+	if filter.CompanyID != "" {
+		match["created_by"] = filter.CompanyID
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":                   "$closing.reason",
+			"jobs":                  bson.M{"$sum": 1},
+			"avg_applicants":        bson.M{"$avg": "$closing.stats.total_applicants"},
+			"avg_days_open":         bson.M{"$avg": "$closing.stats.days_open"},
+			"hires":                 bson.M{"$sum": "$closing.stats.hired"},
+			"avg_time_to_hire_days": bson.M{"$avg": "$closing.stats.time_to_hire_days"},
+			"hiring_jobs": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$closing.stats.time_to_hire_days", nil}}, 1, 0,
+			}}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Reason            domain.JobCloseReason `bson:"_id"`
+		Jobs              int64                 `bson:"jobs"`
+		AvgApplicants     float64               `bson:"avg_applicants"`
+		AvgDaysOpen       float64               `bson:"avg_days_open"`
+		Hires             int64                 `bson:"hires"`
+		AvgTimeToHireDays *float64              `bson:"avg_time_to_hire_days"`
+		HiringJobs        int64                 `bson:"hiring_jobs"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*domain.JobClosingSummary, 0, len(groups))
+	for _, group := range groups {
+		summaries = append(summaries, &domain.JobClosingSummary{
+			Reason:            group.Reason,
+			Jobs:              group.Jobs,
+			AvgApplicants:     group.AvgApplicants,
+			AvgDaysOpen:       group.AvgDaysOpen,
+			Hires:             group.Hires,
+			AvgTimeToHireDays: group.AvgTimeToHireDays,
+			HiringJobs:        group.HiringJobs,
+		})
+	}
+
+	return summaries, nil
+}
+
+func (r *jobRepository) SetModeration(ctx context.Context, id string, moderation *domain.JobModeration) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	update := bson.M{"$set": bson.M{"moderation": moderation, "updated_at": time.Now()}}
+	if moderation == nil {
+		update = bson.M{"$unset": bson.M{"moderation": ""}, "$set": bson.M{"updated_at": time.Now()}}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": objID}), update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrJobNotFound
+	}
+
+	return nil
+}
+
+func (r *jobRepository) ListPendingReview(ctx context.Context, page, limit int) ([]*domain.Job, int64, error) {
+	filter := notDeleted(bson.M{"moderation.status": domain.JobPendingReview, "closing": nil})
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "moderation.submitted_at", Value: 1}}).
+		SetSkip(int64((page - 1) * limit)).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []*domain.Job{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, total, nil
+}
+
+func (r *jobRepository) ReviewJob(ctx context.Context, id string, moderation *domain.JobModeration, publish bool) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	now := time.Now()
+	fields := bson.M{"moderation": moderation, "updated_at": now}
+	update := bson.M{"$set": fields}
+	if publish {
+		// Going live counts as posting the job, like a scheduled job going live
+		fields["is_published"] = true
+		fields["bumped_at"] = now
+		update["$unset"] = bson.M{"publish_at": ""}
+	}
+
+	result, err := r.collection.UpdateOne(ctx,
+		notDeleted(bson.M{"_id": objID, "moderation.status": domain.JobPendingReview, "closing": nil}),
+		update,
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrJobNotFound
+	}
+
+	return nil
+}
+
+// FeatureJob features the job first and counts the company's featured jobs
+// after, so two requests racing for the last place can't both get it: each
+// sees the other's job and is put back.
+func (r *jobRepository) FeatureJob(ctx context.Context, id, companyID string, since, until time.Time, maxFeatured int) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	now := time.Now()
+	opts := options.FindOneAndUpdate().SetProjection(bson.M{"featured_since": 1, "featured_until": 1})
+	var previous domain.Job
+	err = r.collection.FindOneAndUpdate(ctx,
+		notDeleted(bson.M{"_id": objID, "created_by": companyID}),
+		bson.M{"$set": bson.M{"featured_since": since, "featured_until": until, "updated_at": now}},
+		opts,
+	).Decode(&previous)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.ErrJobNotFound
+		}
+		return err
+	}
+
+	featured, err := r.collection.CountDocuments(ctx, notDeleted(bson.M{
+		"created_by":     companyID,
+		"featured_until": bson.M{"$gt": now},
+	}))
+	if err == nil && featured <= int64(maxFeatured) {
+		return nil
+	}
+
+	// Put the job back, unless it was featured again meanwhile
+	restore := bson.M{"$unset": bson.M{"featured_since": "", "featured_until": ""}}
+	if previous.FeaturedUntil != nil {
+		set := bson.M{"featured_until": previous.FeaturedUntil}
+		unset := bson.M{}
+		if previous.FeaturedSince != nil {
+			set["featured_since"] = previous.FeaturedSince
+		} else {
+			unset["featured_since"] = ""
+		}
+		restore = bson.M{"$set": set}
+		if len(unset) > 0 {
+			restore["$unset"] = unset
+		}
+	}
+	if _, restoreErr := r.collection.UpdateOne(ctx, bson.M{"_id": objID, "featured_until": until}, restore); restoreErr != nil && err == nil {
+		err = restoreErr
+	}
+	if err != nil {
+		return err
+	}
+	return domain.ErrFeaturedJobsLimit
+}
+
+func (r *jobRepository) UnfeatureJob(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(ctx, notDeleted(bson.M{"_id": objID}), bson.M{
+		"$unset": bson.M{"featured_since": "", "featured_until": ""},
+		"$set":   bson.M{"updated_at": time.Now()},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrJobNotFound
+	}
+
+	return nil
+}
+
+func (r *jobRepository) UnfeatureExpired(ctx context.Context, now time.Time) (int64, error) {
+	// The update time is left alone, the job itself didn't change
+	result, err := r.collection.UpdateMany(ctx,
+		bson.M{"featured_until": bson.M{"$lte": now}},
+		bson.M{"$unset": bson.M{"featured_since": "", "featured_until": ""}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *jobRepository) ReserveApplication(ctx context.Context, id primitive.ObjectID) (*domain.Job, error) {
+	filter := bson.M{
+		"_id": id,
+		"$or": bson.A{
+			bson.M{"max_applications": nil},
+			bson.M{"$expr": bson.M{"$lt": bson.A{bson.M{"$ifNull": bson.A{"$applications_received", 0}}, "$max_applications"}}},
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var job domain.Job
+	err := r.collection.FindOneAndUpdate(ctx, filter, bson.M{"$inc": bson.M{"applications_received": 1}}, opts).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrJobFull
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *jobRepository) ReleaseApplication(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "applications_received": bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{"applications_received": -1}},
+	)
+	return err
+}
+
+func (r *jobRepository) RaiseApplicationsReceived(ctx context.Context, id primitive.ObjectID, count int64) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$max": bson.M{"applications_received": count}},
+	)
+	return err
+}
+
+func (r *jobRepository) CapApplications(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	_, err := r.collection.UpdateOne(ctx,
+		notDeleted(bson.M{
+			"_id":              id,
+			"is_published":     true,
+			"max_applications": bson.M{"$gt": 0},
+			"$expr":            bson.M{"$gte": bson.A{bson.M{"$ifNull": bson.A{"$applications_received", 0}}, "$max_applications"}},
+		}),
+		bson.M{"$set": bson.M{"is_published": false, "applications_capped_at": at, "updated_at": at}},
+	)
+	return err
+}
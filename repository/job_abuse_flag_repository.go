@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type JobAbuseFlagRepository interface {
+	// Raise opens a flag for the job and reason, or counts another attempt on the open one
+	Raise(ctx context.Context, jobID, companyID string, reason domain.JobAbuseReason) error
+	// List returns flags newest first, only the open ones unless all is set
+	List(ctx context.Context, all bool, page, limit int) ([]*domain.JobAbuseFlag, int64, error)
+	Resolve(ctx context.Context, id, adminID string) (*domain.JobAbuseFlag, error)
+}
+
+type jobAbuseFlagRepository struct {
+	collection *mongo.Collection
+}
+
+func NewJobAbuseFlagRepository(db *mongo.Database) JobAbuseFlagRepository {
+	collection := db.Collection("job_abuse_flags")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "job_id", Value: 1}, {Key: "reason", Value: 1}, {Key: "resolved_at", Value: 1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "last_seen_at", Value: -1}}},
+	)
+
+	return &jobAbuseFlagRepository{
+		collection: collection,
+	}
+}
+
+func (r *jobAbuseFlagRepository) Raise(ctx context.Context, jobID, companyID string, reason domain.JobAbuseReason) error {
+	now := time.Now()
+
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"job_id": jobID, "reason": reason, "resolved_at": nil},
+		bson.M{
+			"$inc":         bson.M{"attempts": 1},
+			"$set":         bson.M{"last_seen_at": now, "company_id": companyID},
+			"$setOnInsert": bson.M{"first_seen_at": now},
+		},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *jobAbuseFlagRepository) List(ctx context.Context, all bool, page, limit int) ([]*domain.JobAbuseFlag, int64, error) {
+	filter := bson.M{}
+	if !all {
+		filter["resolved_at"] = nil
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find()
+	opts.SetSkip(int64((page - 1) * limit))
+	opts.SetLimit(int64(limit))
+	opts.SetSort(bson.D{{Key: "last_seen_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	flags := []*domain.JobAbuseFlag{}
+	if err := cursor.All(ctx, &flags); err != nil {
+		return nil, 0, err
+	}
+	return flags, total, nil
+}
+
+func (r *jobAbuseFlagRepository) Resolve(ctx context.Context, id, adminID string) (*domain.JobAbuseFlag, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidID
+	}
+
+	var flag domain.JobAbuseFlag
+	err = r.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": objID, "resolved_at": nil},
+		bson.M{"$set": bson.M{"resolved_at": time.Now(), "resolved_by": adminID}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&flag)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrJobAbuseFlagNotFound
+		}
+		return nil, err
+	}
+	return &flag, nil
+}
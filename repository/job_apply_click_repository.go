@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type JobApplyClickRepository interface {
+	Create(ctx context.Context, click *domain.JobApplyClick) error
+	// Stats sums up the job's clicks, with daily counts of the clicks since dailySince
+	Stats(ctx context.Context, jobID primitive.ObjectID, dailySince time.Time) (*domain.JobApplyClickStats, error)
+}
+
+type jobApplyClickRepository struct {
+	collection *mongo.Collection
+}
+
+func NewJobApplyClickRepository(db *mongo.Database) JobApplyClickRepository {
+	collection := db.Collection("job_apply_clicks")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "job_id", Value: 1}, {Key: "at", Value: 1}}},
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(domain.JobApplyClickRetention.Seconds())),
+		},
+	)
+
+	return &jobApplyClickRepository{
+		collection: collection,
+	}
+}
+
+func (r *jobApplyClickRepository) Create(ctx context.Context, click *domain.JobApplyClick) error {
+	click.ID = primitive.NewObjectID()
+	if click.At.IsZero() {
+		click.At = time.Now()
+	}
+
+	_, err := r.collection.InsertOne(ctx, click)
+	return err
+}
+
+func (r *jobApplyClickRepository) Stats(ctx context.Context, jobID primitive.ObjectID, dailySince time.Time) (*domain.JobApplyClickStats, error) {
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"job_id": jobID}}},
+		{{Key: "$facet", Value: bson.M{
+			"totals": bson.A{
+				bson.M{"$group": bson.M{
+					"_id":              nil,
+					"clicks":           bson.M{"$sum": 1},
+					"signed_in_clicks": bson.M{"$sum": bson.M{"$cond": bson.A{"$signed_in", 1, 0}}},
+					"last_click_at":    bson.M{"$max": "$at"},
+				}},
+			},
+			"daily": bson.A{
+				bson.M{"$match": bson.M{"at": bson.M{"$gte": dailySince}}},
+				bson.M{"$group": bson.M{
+					"_id":    bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$at"}},
+					"clicks": bson.M{"$sum": 1},
+				}},
+				bson.M{"$sort": bson.M{"_id": 1}},
+			},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Totals []struct {
+			Clicks         int64     `bson:"clicks"`
+			SignedInClicks int64     `bson:"signed_in_clicks"`
+			LastClickAt    time.Time `bson:"last_click_at"`
+		} `bson:"totals"`
+		Daily []*domain.JobApplyClickDay `bson:"daily"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	stats := &domain.JobApplyClickStats{JobID: jobID.Hex(), Daily: []*domain.JobApplyClickDay{}}
+	if len(results) == 0 {
+		return stats, nil
+	}
+	if len(results[0].Totals) > 0 {
+		totals := results[0].Totals[0]
+		stats.Clicks = totals.Clicks
+		stats.SignedInClicks = totals.SignedInClicks
+		stats.LastClickAt = &totals.LastClickAt
+	}
+	if results[0].Daily != nil {
+		stats.Daily = results[0].Daily
+	}
+	return stats, nil
+}
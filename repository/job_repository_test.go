@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"job-portal-backend/domain"
+)
+
+// newMockJobRepository creates a job repository on the mock deployment, it
+// indexes the jobs, tombstones and audit collections
+func newMockJobRepository(mt *mtest.T) JobRepository {
+	acknowledgeIndexes(mt, 3)
+	repo := NewJobRepository(mt.DB)
+	mt.ClearEvents()
+	return repo
+}
+
+func TestGetJobByIDNotFound(t *testing.T) {
+	mockTest(t, func(mt *mtest.T) {
+		repo := newMockJobRepository(mt)
+		mt.AddMockResponses(emptyCursor("test.jobs"))
+
+		_, err := repo.GetJobByID(context.Background(), primitive.NewObjectID().Hex())
+		if !errors.Is(err, domain.ErrJobNotFound) {
+			mt.Fatalf("GetJobByID() error = %v, want %v", err, domain.ErrJobNotFound)
+		}
+	})
+}
+
+func TestGetJobByIDInvalidID(t *testing.T) {
+	mockTest(t, func(mt *mtest.T) {
+		repo := newMockJobRepository(mt)
+
+		_, err := repo.GetJobByID(context.Background(), "not-an-id")
+		if !errors.Is(err, domain.ErrInvalidID) {
+			mt.Fatalf("GetJobByID() error = %v, want %v", err, domain.ErrInvalidID)
+		}
+		if event := mt.GetStartedEvent(); event != nil {
+			mt.Fatalf("GetJobByID() sent %s, want no command for a malformed ID", event.CommandName)
+		}
+	})
+}
+
+// sentFilter returns the filter of the find command the repository sent
+func sentFilter(mt *mtest.T) bson.Raw {
+	mt.Helper()
+	event := mt.GetStartedEvent()
+	if event == nil || event.CommandName != "find" {
+		mt.Fatalf("sent %v, want a find command", event)
+	}
+	return event.Command.Lookup("filter").Document()
+}
+
+// checkPublicFilter fails unless filter only matches published, undeleted jobs
+func checkPublicFilter(mt *mtest.T, filter bson.Raw) {
+	mt.Helper()
+	if value, ok := filter.Lookup("is_published").BooleanOK(); !ok || !value {
+		mt.Errorf("filter %v doesn't require is_published: true", filter)
+	}
+	checkNotDeleted(mt, filter)
+}
+
+func checkNotDeleted(mt *mtest.T, filter bson.Raw) {
+	mt.Helper()
+	if value, err := filter.LookupErr("deleted_at"); err != nil || value.Type != bsontype.Null {
+		mt.Errorf("filter %v doesn't require deleted_at: null", filter)
+	}
+}
+
+func TestGetJobByIDSkipsDeletedJobs(t *testing.T) {
+	mockTest(t, func(mt *mtest.T) {
+		repo := newMockJobRepository(mt)
+		mt.AddMockResponses(emptyCursor("test.jobs"))
+
+		_, _ = repo.GetJobByID(context.Background(), primitive.NewObjectID().Hex())
+		checkNotDeleted(mt, sentFilter(mt))
+	})
+}
+
+func TestListListedByIDsOnlyPublicJobs(t *testing.T) {
+	mockTest(t, func(mt *mtest.T) {
+		repo := newMockJobRepository(mt)
+		mt.AddMockResponses(emptyCursor("test.jobs"))
+
+		if _, err := repo.ListListedByIDs(context.Background(), []primitive.ObjectID{primitive.NewObjectID()}); err != nil {
+			mt.Fatalf("ListListedByIDs() error = %v", err)
+		}
+		checkPublicFilter(mt, sentFilter(mt))
+	})
+}
+
+func TestListingQueryOnlyPublicJobs(t *testing.T) {
+	remote := true
+	filters := map[string]domain.JobFilter{
+		"no filter": {},
+		"filtered":  {Query: "engineer", Location: "Addis", Category: "engineering", Remote: &remote, Skills: []string{"go"}},
+	}
+	for name, filter := range filters {
+		t.Run(name, func(t *testing.T) {
+			query := listingQuery(filter)
+			if query["is_published"] != true {
+				t.Errorf("listingQuery() is_published = %v, want true", query["is_published"])
+			}
+			if deletedAt, ok := query["deleted_at"]; !ok || deletedAt != nil {
+				t.Errorf("listingQuery() deleted_at = %v, want nil", deletedAt)
+			}
+		})
+	}
+}
+
+// Reposting refreshes the posting date along with the bump date
+func TestRepostJobRefreshesCreatedAt(t *testing.T) {
+	mockTest(t, func(mt *mtest.T) {
+		repo := newMockJobRepository(mt)
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+
+		if err := repo.RepostJob(context.Background(), primitive.NewObjectID().Hex()); err != nil {
+			mt.Fatalf("RepostJob() error = %v", err)
+		}
+
+		event := mt.GetStartedEvent()
+		if event == nil || event.CommandName != "update" {
+			mt.Fatalf("sent %v, want an update command", event)
+		}
+		set := event.Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("u", "$set").Document()
+		for _, field := range []string{"created_at", "bumped_at"} {
+			if _, err := set.LookupErr(field); err != nil {
+				mt.Errorf("$set = %s, want %s", set, field)
+			}
+		}
+	})
+}
+
+// The cap is checked against the stored count and cap, not the caller's copy
+// of the job, which applications or edits made meanwhile have outdated
+func TestCapApplicationsChecksStoredCount(t *testing.T) {
+	mockTest(t, func(mt *mtest.T) {
+		repo := newMockJobRepository(mt)
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}))
+
+		if err := repo.CapApplications(context.Background(), primitive.NewObjectID(), time.Now()); err != nil {
+			mt.Fatalf("CapApplications() error = %v", err)
+		}
+
+		event := mt.GetStartedEvent()
+		if event == nil || event.CommandName != "update" {
+			mt.Fatalf("sent %v, want an update command", event)
+		}
+		filter := event.Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("q").Document()
+		if _, err := filter.LookupErr("$expr"); err != nil {
+			mt.Errorf("filter = %s, want the count compared with max_applications", filter)
+		}
+	})
+}
+
+func TestRaiseApplicationsReceivedNeverLowers(t *testing.T) {
+	mockTest(t, func(mt *mtest.T) {
+		repo := newMockJobRepository(mt)
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 0}))
+
+		if err := repo.RaiseApplicationsReceived(context.Background(), primitive.NewObjectID(), 3); err != nil {
+			mt.Fatalf("RaiseApplicationsReceived() error = %v", err)
+		}
+
+		event := mt.GetStartedEvent()
+		if event == nil || event.CommandName != "update" {
+			mt.Fatalf("sent %v, want an update command", event)
+		}
+		update := event.Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("u").Document()
+		if _, err := update.LookupErr("$max", "applications_received"); err != nil {
+			mt.Errorf("update = %s, want $max on applications_received", update)
+		}
+	})
+}
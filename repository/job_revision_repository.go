@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type JobRevisionRepository interface {
+	Create(ctx context.Context, revision *domain.JobRevision) error
+	// ListByJob returns the job's revisions, most recent first
+	ListByJob(ctx context.Context, jobID string, page, limit int) ([]*domain.JobRevision, int64, error)
+}
+
+type jobRevisionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewJobRevisionRepository(db *mongo.Database) JobRevisionRepository {
+	collection := db.Collection("job_revisions")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "job_id", Value: 1}, {Key: "edited_at", Value: -1}}},
+	)
+
+	return &jobRevisionRepository{
+		collection: collection,
+	}
+}
+
+func (r *jobRevisionRepository) Create(ctx context.Context, revision *domain.JobRevision) error {
+	result, err := r.collection.InsertOne(ctx, revision)
+	if err != nil {
+		return err
+	}
+
+	revision.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *jobRevisionRepository) ListByJob(ctx context.Context, jobID string, page, limit int) ([]*domain.JobRevision, int64, error) {
+	filter := bson.M{"job_id": jobID}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find()
+	opts.SetSkip(int64((page - 1) * limit))
+	opts.SetLimit(int64(limit))
+	opts.SetSort(bson.D{{Key: "edited_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	revisions := []*domain.JobRevision{}
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return nil, 0, err
+	}
+	return revisions, total, nil
+}
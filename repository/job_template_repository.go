@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type JobTemplateRepository interface {
+	Create(ctx context.Context, template *domain.JobTemplate) error
+	GetByID(ctx context.Context, id string) (*domain.JobTemplate, error)
+	// ListByCompany returns the company's templates sorted by name
+	ListByCompany(ctx context.Context, companyID string) ([]*domain.JobTemplate, error)
+	CountByCompany(ctx context.Context, companyID string) (int64, error)
+	Delete(ctx context.Context, id string) error
+	DeleteByCompany(ctx context.Context, companyID string) error
+}
+
+type jobTemplateRepository struct {
+	collection *mongo.Collection
+}
+
+func NewJobTemplateRepository(db *mongo.Database) JobTemplateRepository {
+	collection := db.Collection("job_templates")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "company_id", Value: 1}, {Key: "name", Value: 1}}},
+	)
+
+	return &jobTemplateRepository{
+		collection: collection,
+	}
+}
+
+func (r *jobTemplateRepository) Create(ctx context.Context, template *domain.JobTemplate) error {
+	template.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, template)
+	if err != nil {
+		return err
+	}
+
+	template.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *jobTemplateRepository) GetByID(ctx context.Context, id string) (*domain.JobTemplate, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidID
+	}
+
+	var template domain.JobTemplate
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&template); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrJobTemplateNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *jobTemplateRepository) ListByCompany(ctx context.Context, companyID string) ([]*domain.JobTemplate, error) {
+	cursor, err := r.collection.Find(ctx,
+		bson.M{"company_id": companyID},
+		options.Find().SetSort(bson.D{{Key: "name", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	templates := []*domain.JobTemplate{}
+	if err := cursor.All(ctx, &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (r *jobTemplateRepository) CountByCompany(ctx context.Context, companyID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"company_id": companyID})
+}
+
+func (r *jobTemplateRepository) Delete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrJobTemplateNotFound
+	}
+	return nil
+}
+
+func (r *jobTemplateRepository) DeleteByCompany(ctx context.Context, companyID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"company_id": companyID})
+	return err
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type JobViewRepository interface {
+	// Add adds the counts to those stored for the same job and day
+	Add(ctx context.Context, views []*domain.JobViews) error
+	// MostViewed returns the jobs viewed most since the day, most views first
+	MostViewed(ctx context.Context, since time.Time, limit int) ([]*domain.JobActivity, error)
+}
+
+type jobViewRepository struct {
+	collection *mongo.Collection
+}
+
+func NewJobViewRepository(db *mongo.Database) JobViewRepository {
+	collection := db.Collection("job_views")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "job_id", Value: 1}, {Key: "day", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "day", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(domain.JobViewRetention.Seconds())),
+		},
+	)
+
+	return &jobViewRepository{
+		collection: collection,
+	}
+}
+
+func (r *jobViewRepository) Add(ctx context.Context, views []*domain.JobViews) error {
+	if len(views) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, len(views))
+	for i, v := range views {
+		models[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"job_id": v.JobID, "day": v.Day}).
+			SetUpdate(bson.M{"$inc": bson.M{"views": v.Views}}).
+			SetUpsert(true)
+	}
+
+	_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (r *jobViewRepository) MostViewed(ctx context.Context, since time.Time, limit int) ([]*domain.JobActivity, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"day": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$job_id", "count": bson.M{"$sum": "$views"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	activity := []*domain.JobActivity{}
+	if err := cursor.All(ctx, &activity); err != nil {
+		return nil, err
+	}
+	return activity, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type NotificationPreferencesRepository interface {
+	// GetByUserID returns the user's preferences, or the defaults when none were saved
+	GetByUserID(ctx context.Context, userID string) (*domain.NotificationPreferences, error)
+	Upsert(ctx context.Context, prefs *domain.NotificationPreferences) error
+	// MarkDigestSent records when the user's last digest went out
+	MarkDigestSent(ctx context.Context, userID string, sentAt time.Time) error
+	DeleteByUserID(ctx context.Context, userID string) error
+}
+
+type notificationPreferencesRepository struct {
+	collection *mongo.Collection
+}
+
+func NewNotificationPreferencesRepository(db *mongo.Database) NotificationPreferencesRepository {
+	collection := db.Collection("notification_preferences")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	)
+
+	return &notificationPreferencesRepository{
+		collection: collection,
+	}
+}
+
+func (r *notificationPreferencesRepository) GetByUserID(ctx context.Context, userID string) (*domain.NotificationPreferences, error) {
+	var prefs domain.NotificationPreferences
+	err := r.collection.FindOne(ctx, bson.M{"user_id": userID}).Decode(&prefs)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return domain.DefaultNotificationPreferences(userID), nil
+		}
+		return nil, err
+	}
+
+	return &prefs, nil
+}
+
+func (r *notificationPreferencesRepository) Upsert(ctx context.Context, prefs *domain.NotificationPreferences) error {
+	prefs.UpdatedAt = time.Now()
+
+	_, err := r.collection.ReplaceOne(
+		ctx,
+		bson.M{"user_id": prefs.UserID},
+		prefs,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *notificationPreferencesRepository) MarkDigestSent(ctx context.Context, userID string, sentAt time.Time) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"user_id": userID},
+		bson.M{"$set": bson.M{"last_digest_at": sentAt}},
+	)
+	return err
+}
+
+func (r *notificationPreferencesRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID})
+	return err
+}
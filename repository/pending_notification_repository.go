@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+// PendingNotificationRepository stores the notifications held back for digests
+// and the ones that failed to send
+type PendingNotificationRepository interface {
+	Enqueue(ctx context.Context, notification *domain.PendingNotification) error
+	// ListUserIDs returns the users with notifications waiting, failed ones aside
+	ListUserIDs(ctx context.Context) ([]string, error)
+	// ListByUser returns the user's waiting notifications, oldest first, failed ones aside
+	ListByUser(ctx context.Context, userID string) ([]*domain.PendingNotification, error)
+	// RequeueFailed puts the failed notifications back in line for the next digest run
+	RequeueFailed(ctx context.Context) (int64, error)
+	DeleteByIDs(ctx context.Context, ids []primitive.ObjectID) error
+	DeleteByUser(ctx context.Context, userID string) error
+}
+
+type pendingNotificationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPendingNotificationRepository(db *mongo.Database) PendingNotificationRepository {
+	collection := db.Collection("pending_notifications")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: 1}}},
+	)
+
+	return &pendingNotificationRepository{
+		collection: collection,
+	}
+}
+
+func (r *pendingNotificationRepository) Enqueue(ctx context.Context, notification *domain.PendingNotification) error {
+	notification.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, notification)
+	if err != nil {
+		return err
+	}
+
+	notification.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *pendingNotificationRepository) ListUserIDs(ctx context.Context) ([]string, error) {
+	values, err := r.collection.Distinct(ctx, "user_id", bson.M{"failed": bson.M{"$ne": true}})
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(values))
+	for _, v := range values {
+		if id, ok := v.(string); ok {
+			userIDs = append(userIDs, id)
+		}
+	}
+	return userIDs, nil
+}
+
+func (r *pendingNotificationRepository) ListByUser(ctx context.Context, userID string) ([]*domain.PendingNotification, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID, "failed": bson.M{"$ne": true}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	notifications := []*domain.PendingNotification{}
+	if err := cursor.All(ctx, &notifications); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *pendingNotificationRepository) RequeueFailed(ctx context.Context) (int64, error) {
+	result, err := r.collection.UpdateMany(ctx,
+		bson.M{"failed": true},
+		bson.M{"$unset": bson.M{"failed": ""}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *pendingNotificationRepository) DeleteByIDs(ctx context.Context, ids []primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	return err
+}
+
+func (r *pendingNotificationRepository) DeleteByUser(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// The repository tests run against the driver's mock deployment: a test
+// queues the server's replies and checks the commands the repository sent.
+
+// mockTest runs fn on a mock deployment
+func mockTest(t *testing.T, fn func(mt *mtest.T)) {
+	t.Helper()
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+	mt.Run("mock", fn)
+}
+
+// acknowledgeIndexes answers the index creations of a repository constructor
+// creating indexes on the given number of collections
+func acknowledgeIndexes(mt *mtest.T, collections int) {
+	for i := 0; i < collections; i++ {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+	}
+}
+
+// emptyCursor is the reply to a find on ns that matched nothing
+func emptyCursor(ns string) bson.D {
+	return mtest.CreateCursorResponse(0, ns, mtest.FirstBatch)
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type ResumeRepository interface {
+	Create(ctx context.Context, resume *domain.Resume) error
+	// GetByID returns one of the user's resumes
+	GetByID(ctx context.Context, id, userID string) (*domain.Resume, error)
+	ListByUser(ctx context.Context, userID string) ([]*domain.Resume, error)
+	// ListByURL returns the resumes stored at url
+	ListByURL(ctx context.Context, url string) ([]*domain.Resume, error)
+	CountByUser(ctx context.Context, userID string) (int64, error)
+	Delete(ctx context.Context, id, userID string) error
+	DeleteByUser(ctx context.Context, userID string) error
+	// ReplaceURL patches resumes uploaded while the storage provider was down
+	ReplaceURL(ctx context.Context, oldURL, newURL string) error
+}
+
+type resumeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewResumeRepository(db *mongo.Database) ResumeRepository {
+	collection := db.Collection("resumes")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "uploaded_at", Value: -1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "url", Value: 1}}},
+	)
+
+	return &resumeRepository{
+		collection: collection,
+	}
+}
+
+func (r *resumeRepository) Create(ctx context.Context, resume *domain.Resume) error {
+	resume.ID = primitive.NewObjectID()
+	resume.UploadedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, resume)
+	return err
+}
+
+func (r *resumeRepository) GetByID(ctx context.Context, id, userID string) (*domain.Resume, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidID
+	}
+
+	var resume domain.Resume
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID, "user_id": userID}).Decode(&resume)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrResumeNotFound
+		}
+		return nil, err
+	}
+
+	return &resume, nil
+}
+
+func (r *resumeRepository) ListByUser(ctx context.Context, userID string) ([]*domain.Resume, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "uploaded_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	resumes := []*domain.Resume{}
+	if err := cursor.All(ctx, &resumes); err != nil {
+		return nil, err
+	}
+
+	return resumes, nil
+}
+
+func (r *resumeRepository) ListByURL(ctx context.Context, url string) ([]*domain.Resume, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"url": url})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	resumes := []*domain.Resume{}
+	if err := cursor.All(ctx, &resumes); err != nil {
+		return nil, err
+	}
+
+	return resumes, nil
+}
+
+func (r *resumeRepository) CountByUser(ctx context.Context, userID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"user_id": userID})
+}
+
+func (r *resumeRepository) Delete(ctx context.Context, id, userID string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID, "user_id": userID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrResumeNotFound
+	}
+
+	return nil
+}
+
+func (r *resumeRepository) DeleteByUser(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
+
+func (r *resumeRepository) ReplaceURL(ctx context.Context, oldURL, newURL string) error {
+	_, err := r.collection.UpdateMany(ctx, bson.M{"url": oldURL}, bson.M{"$set": bson.M{"url": newURL}})
+	return err
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"job-portal-backend/domain"
+)
+
+// RetentionRepository applies the retention rules of domain.RetentionRules
+type RetentionRepository interface {
+	// Purge deletes the rule's documents dated before cutoff and returns how many
+	// there were. With dryRun they are only counted.
+	Purge(ctx context.Context, rule domain.RetentionRule, cutoff time.Time, dryRun bool) (int64, error)
+}
+
+type retentionRepository struct {
+	db *mongo.Database
+}
+
+func NewRetentionRepository(db *mongo.Database) RetentionRepository {
+	return &retentionRepository{
+		db: db,
+	}
+}
+
+func (r *retentionRepository) Purge(ctx context.Context, rule domain.RetentionRule, cutoff time.Time, dryRun bool) (int64, error) {
+	collection := r.db.Collection(rule.Collection)
+	filter := bson.M{rule.Field: bson.M{"$lt": cutoff}}
+
+	if dryRun {
+		return collection.CountDocuments(ctx, filter)
+	}
+
+	result, err := collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
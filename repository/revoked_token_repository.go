@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type RevokedTokenRepository interface {
+	// RevokeToken blacklists a single token until it would have expired anyway
+	RevokeToken(ctx context.Context, jti, userID string, expiresAt time.Time) error
+	// RevokeAllForUser blacklists every token issued to the user up to now.
+	// JWTs carry their issue time in whole seconds, so tokens issued during
+	// the current second are revoked as well, a user signing in again within
+	// that second has to sign in once more.
+	RevokeAllForUser(ctx context.Context, userID string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error)
+}
+
+type revokedTokenRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRevokedTokenRepository(db *mongo.Database) RevokedTokenRepository {
+	collection := db.Collection("revoked_tokens")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "jti", Value: 1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "revoked_before", Value: -1}}},
+		// Entries are useless once the tokens they cover have expired
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	)
+
+	return &revokedTokenRepository{
+		collection: collection,
+	}
+}
+
+func (r *revokedTokenRepository) RevokeToken(ctx context.Context, jti, userID string, expiresAt time.Time) error {
+	_, err := r.collection.InsertOne(ctx, &domain.RevokedToken{
+		ID:        primitive.NewObjectID(),
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	})
+	return err
+}
+
+func (r *revokedTokenRepository) RevokeAllForUser(ctx context.Context, userID string, expiresAt time.Time) error {
+	now := time.Now()
+	revokedBefore := now.Truncate(time.Second)
+	_, err := r.collection.InsertOne(ctx, &domain.RevokedToken{
+		ID:            primitive.NewObjectID(),
+		UserID:        userID,
+		RevokedBefore: &revokedBefore,
+		ExpiresAt:     expiresAt,
+		CreatedAt:     now,
+	})
+	return err
+}
+
+func (r *revokedTokenRepository) IsRevoked(ctx context.Context, jti, userID string, issuedAt time.Time) (bool, error) {
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"user_id": userID, "revoked_before": bson.M{"$gte": issuedAt.Truncate(time.Second)}},
+		},
+	}
+	if jti != "" {
+		filter["$or"] = append(filter["$or"].(bson.A), bson.M{"jti": jti})
+	}
+
+	count, err := r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
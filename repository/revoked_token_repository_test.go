@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func newMockRevokedTokenRepository(mt *mtest.T) RevokedTokenRepository {
+	acknowledgeIndexes(mt, 1)
+	repo := NewRevokedTokenRepository(mt.DB)
+	mt.ClearEvents()
+	return repo
+}
+
+func TestRevokeAllForUserTruncatesToTheSecond(t *testing.T) {
+	mockTest(t, func(mt *mtest.T) {
+		repo := newMockRevokedTokenRepository(mt)
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		if err := repo.RevokeAllForUser(context.Background(), "user", time.Now().Add(time.Hour)); err != nil {
+			mt.Fatalf("RevokeAllForUser() error = %v", err)
+		}
+
+		event := mt.GetStartedEvent()
+		if event == nil || event.CommandName != "insert" {
+			mt.Fatalf("sent %v, want an insert command", event)
+		}
+		inserted := event.Command.Lookup("documents").Array().Index(0).Value().Document()
+		if ms := inserted.Lookup("revoked_before").DateTime(); ms%1000 != 0 {
+			mt.Fatalf("revoked_before = %d ms, want a whole second", ms)
+		}
+	})
+}
+
+// A token issued later within the second of a revocation carries the same
+// iat, the inclusive comparison revokes it too
+func TestIsRevokedIncludesTheRevocationSecond(t *testing.T) {
+	mockTest(t, func(mt *mtest.T) {
+		repo := newMockRevokedTokenRepository(mt)
+		mt.AddMockResponses(emptyCursor("test.revoked_tokens"))
+
+		issuedAt := time.Date(2024, 6, 1, 10, 0, 0, 900*int(time.Millisecond), time.UTC)
+		revoked, err := repo.IsRevoked(context.Background(), "", "user", issuedAt)
+		if err != nil {
+			mt.Fatalf("IsRevoked() error = %v", err)
+		}
+		if revoked {
+			mt.Fatalf("IsRevoked() = true, want false")
+		}
+
+		event := mt.GetStartedEvent()
+		if event == nil || event.CommandName != "aggregate" {
+			mt.Fatalf("sent %v, want an aggregate command", event)
+		}
+		match := event.Command.Lookup("pipeline").Array().Index(0).Value().Document().Lookup("$match").Document()
+		var filter struct {
+			Or []struct {
+				RevokedBefore struct {
+					Gte time.Time `bson:"$gte"`
+				} `bson:"revoked_before"`
+			} `bson:"$or"`
+		}
+		if err := bson.Unmarshal(match, &filter); err != nil {
+			mt.Fatalf("decoding filter: %v", err)
+		}
+		if len(filter.Or) != 1 || !filter.Or[0].RevokedBefore.Gte.Equal(issuedAt.Truncate(time.Second)) {
+			mt.Fatalf("filter = %s, want revoked_before $gte %v", match, issuedAt.Truncate(time.Second))
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type RoleUpgradeRepository interface {
+	Create(ctx context.Context, upgrade *domain.RoleUpgrade) error
+	GetByID(ctx context.Context, id string) (*domain.RoleUpgrade, error)
+	// FindLatestByUser returns the user's most recent request
+	FindLatestByUser(ctx context.Context, userID string) (*domain.RoleUpgrade, error)
+	// ListByStatus lists requests oldest first, so the review queue is worked in order. An empty status lists all.
+	ListByStatus(ctx context.Context, status domain.RoleUpgradeStatus, page, limit int) ([]*domain.RoleUpgrade, int64, error)
+	// Review moves a pending request to approved or rejected
+	Review(ctx context.Context, id string, status domain.RoleUpgradeStatus, reviewerID, note string) error
+}
+
+type roleUpgradeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRoleUpgradeRepository(db *mongo.Database) RoleUpgradeRepository {
+	collection := db.Collection("role_upgrade_requests")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}}},
+		// A user has at most one request waiting for review
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": domain.RoleUpgradePending}).
+				SetName("user_id_pending_unique"),
+		},
+	)
+
+	return &roleUpgradeRepository{
+		collection: collection,
+	}
+}
+
+func (r *roleUpgradeRepository) Create(ctx context.Context, upgrade *domain.RoleUpgrade) error {
+	upgrade.ID = primitive.NewObjectID()
+	upgrade.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, upgrade)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrRoleUpgradePending
+	}
+	return err
+}
+
+func (r *roleUpgradeRepository) GetByID(ctx context.Context, id string) (*domain.RoleUpgrade, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidID
+	}
+
+	var upgrade domain.RoleUpgrade
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&upgrade); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrRoleUpgradeNotFound
+		}
+		return nil, err
+	}
+
+	return &upgrade, nil
+}
+
+func (r *roleUpgradeRepository) FindLatestByUser(ctx context.Context, userID string) (*domain.RoleUpgrade, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	var upgrade domain.RoleUpgrade
+	if err := r.collection.FindOne(ctx, bson.M{"user_id": userID}, opts).Decode(&upgrade); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrRoleUpgradeNotFound
+		}
+		return nil, err
+	}
+
+	return &upgrade, nil
+}
+
+func (r *roleUpgradeRepository) ListByStatus(ctx context.Context, status domain.RoleUpgradeStatus, page, limit int) ([]*domain.RoleUpgrade, int64, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find()
+	opts.SetSkip(int64((page - 1) * limit))
+	opts.SetLimit(int64(limit))
+	opts.SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	upgrades := []*domain.RoleUpgrade{}
+	if err := cursor.All(ctx, &upgrades); err != nil {
+		return nil, 0, err
+	}
+
+	return upgrades, total, nil
+}
+
+func (r *roleUpgradeRepository) Review(ctx context.Context, id string, status domain.RoleUpgradeStatus, reviewerID, note string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": objID, "status": domain.RoleUpgradePending},
+		bson.M{"$set": bson.M{
+			"status":      status,
+			"reviewer_id": reviewerID,
+			"review_note": note,
+			"reviewed_at": time.Now(),
+		}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrRoleUpgradeReviewed
+	}
+
+	return nil
+}
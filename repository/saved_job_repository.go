@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type SavedJobRepository interface {
+	// Save bookmarks the job, saving a job twice keeps the first save
+	Save(ctx context.Context, userID, jobID string) (*domain.SavedJob, error)
+	Unsave(ctx context.Context, userID, jobID string) error
+	// ListByUser returns the jobs the user saved, most recent first
+	ListByUser(ctx context.Context, userID string, page, limit int) ([]*domain.SavedJob, int64, error)
+	// SavedJobIDs returns which of jobIDs the user saved
+	SavedJobIDs(ctx context.Context, userID string, jobIDs []string) (map[string]bool, error)
+	DeleteByUser(ctx context.Context, userID string) error
+}
+
+type savedJobRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSavedJobRepository(db *mongo.Database) SavedJobRepository {
+	collection := db.Collection("saved_jobs")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "job_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	)
+
+	return &savedJobRepository{
+		collection: collection,
+	}
+}
+
+func (r *savedJobRepository) Save(ctx context.Context, userID, jobID string) (*domain.SavedJob, error) {
+	var saved domain.SavedJob
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"user_id": userID, "job_id": jobID},
+		bson.M{"$setOnInsert": bson.M{"created_at": time.Now()}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&saved)
+	if err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (r *savedJobRepository) Unsave(ctx context.Context, userID, jobID string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID, "job_id": jobID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrSavedJobNotFound
+	}
+	return nil
+}
+
+func (r *savedJobRepository) ListByUser(ctx context.Context, userID string, page, limit int) ([]*domain.SavedJob, int64, error) {
+	filter := bson.M{"user_id": userID}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find()
+	opts.SetSkip(int64((page - 1) * limit))
+	opts.SetLimit(int64(limit))
+	opts.SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	saved := []*domain.SavedJob{}
+	if err := cursor.All(ctx, &saved); err != nil {
+		return nil, 0, err
+	}
+	return saved, total, nil
+}
+
+func (r *savedJobRepository) SavedJobIDs(ctx context.Context, userID string, jobIDs []string) (map[string]bool, error) {
+	saved := make(map[string]bool, len(jobIDs))
+	if len(jobIDs) == 0 {
+		return saved, nil
+	}
+
+	values, err := r.collection.Distinct(ctx, "job_id", bson.M{"user_id": userID, "job_id": bson.M{"$in": jobIDs}})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range values {
+		if id, ok := v.(string); ok {
+			saved[id] = true
+		}
+	}
+	return saved, nil
+}
+
+func (r *savedJobRepository) DeleteByUser(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type SavedSearchRepository interface {
+	Create(ctx context.Context, search *domain.SavedSearch) error
+	// ListByUser returns the user's saved searches, most recent first
+	ListByUser(ctx context.Context, userID string) ([]*domain.SavedSearch, error)
+	CountByUser(ctx context.Context, userID string) (int64, error)
+	// Delete removes one of the user's saved searches
+	Delete(ctx context.Context, userID, id string) error
+	// ListDueForAlerts returns searches with alerts on that last ran before checkedBefore, least recently run first
+	ListDueForAlerts(ctx context.Context, checkedBefore time.Time, limit int) ([]*domain.SavedSearch, error)
+	MarkChecked(ctx context.Context, id primitive.ObjectID, at time.Time) error
+	DeleteByUser(ctx context.Context, userID string) error
+}
+
+type savedSearchRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSavedSearchRepository(db *mongo.Database) SavedSearchRepository {
+	collection := db.Collection("saved_searches")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "alerts", Value: 1}, {Key: "last_checked_at", Value: 1}}},
+	)
+
+	return &savedSearchRepository{
+		collection: collection,
+	}
+}
+
+func (r *savedSearchRepository) Create(ctx context.Context, search *domain.SavedSearch) error {
+	now := time.Now()
+	search.CreatedAt = now
+	search.LastCheckedAt = now
+
+	result, err := r.collection.InsertOne(ctx, search)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		search.ID = oid
+	}
+	return nil
+}
+
+func (r *savedSearchRepository) ListByUser(ctx context.Context, userID string) ([]*domain.SavedSearch, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	searches := []*domain.SavedSearch{}
+	if err := cursor.All(ctx, &searches); err != nil {
+		return nil, err
+	}
+	return searches, nil
+}
+
+func (r *savedSearchRepository) CountByUser(ctx context.Context, userID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"user_id": userID})
+}
+
+func (r *savedSearchRepository) Delete(ctx context.Context, userID, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrSavedSearchNotFound
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrSavedSearchNotFound
+	}
+	return nil
+}
+
+func (r *savedSearchRepository) ListDueForAlerts(ctx context.Context, checkedBefore time.Time, limit int) ([]*domain.SavedSearch, error) {
+	filter := bson.M{"alerts": true, "last_checked_at": bson.M{"$lt": checkedBefore}}
+
+	opts := options.Find().SetLimit(int64(limit)).SetSort(bson.D{{Key: "last_checked_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	searches := []*domain.SavedSearch{}
+	if err := cursor.All(ctx, &searches); err != nil {
+		return nil, err
+	}
+	return searches, nil
+}
+
+func (r *savedSearchRepository) MarkChecked(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_checked_at": at}})
+	return err
+}
+
+func (r *savedSearchRepository) DeleteByUser(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type SchedulingRulesRepository interface {
+	GetByCompanyID(ctx context.Context, companyID string) (*domain.SchedulingRules, error)
+	Upsert(ctx context.Context, rules *domain.SchedulingRules) error
+}
+
+type schedulingRulesRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSchedulingRulesRepository(db *mongo.Database) SchedulingRulesRepository {
+	collection := db.Collection("scheduling_rules")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "company_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	)
+
+	return &schedulingRulesRepository{
+		collection: collection,
+	}
+}
+
+func (r *schedulingRulesRepository) GetByCompanyID(ctx context.Context, companyID string) (*domain.SchedulingRules, error) {
+	var rules domain.SchedulingRules
+	err := r.collection.FindOne(ctx, bson.M{"company_id": companyID}).Decode(&rules)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrSchedulingRulesNotFound
+		}
+		return nil, err
+	}
+
+	return &rules, nil
+}
+
+func (r *schedulingRulesRepository) Upsert(ctx context.Context, rules *domain.SchedulingRules) error {
+	rules.UpdatedAt = time.Now()
+
+	_, err := r.collection.ReplaceOne(
+		ctx,
+		bson.M{"company_id": rules.CompanyID},
+		rules,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type SecurityAlertRepository interface {
+	Create(ctx context.Context, alert *domain.SecurityAlert) error
+	// ListSince returns the alerts raised since a point in time, newest first
+	ListSince(ctx context.Context, since time.Time, limit int) ([]*domain.SecurityAlert, error)
+	// ExistsSince reports whether an alert of the kind was raised about subject since a point in time
+	ExistsSince(ctx context.Context, kind domain.SecurityAlertKind, subject string, since time.Time) (bool, error)
+}
+
+type securityAlertRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSecurityAlertRepository(db *mongo.Database) SecurityAlertRepository {
+	collection := db.Collection("security_alerts")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "kind", Value: 1}, {Key: "subject", Value: 1}, {Key: "created_at", Value: -1}}},
+		// Alerts are kept as long as the events they were raised from
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "created_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(domain.AuthEventRetention.Seconds())),
+		},
+	)
+
+	return &securityAlertRepository{
+		collection: collection,
+	}
+}
+
+func (r *securityAlertRepository) Create(ctx context.Context, alert *domain.SecurityAlert) error {
+	alert.ID = primitive.NewObjectID()
+	alert.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, alert)
+	return err
+}
+
+func (r *securityAlertRepository) ListSince(ctx context.Context, since time.Time, limit int) ([]*domain.SecurityAlert, error) {
+	opts := options.Find()
+	opts.SetLimit(int64(limit))
+	opts.SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"created_at": bson.M{"$gte": since}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	alerts := []*domain.SecurityAlert{}
+	if err := cursor.All(ctx, &alerts); err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+func (r *securityAlertRepository) ExistsSince(ctx context.Context, kind domain.SecurityAlertKind, subject string, since time.Time) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{
+		"kind":       kind,
+		"subject":    subject,
+		"created_at": bson.M{"$gte": since},
+	}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
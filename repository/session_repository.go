@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *domain.Session) error
+	// ListUnexpired returns the user's sessions whose token hasn't expired, oldest
+	// first. Sessions that were signed out are included, they are tracked by the
+	// revoked token store.
+	ListUnexpired(ctx context.Context, userID string, now time.Time) ([]*domain.Session, error)
+}
+
+type sessionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSessionRepository(db *mongo.Database) SessionRepository {
+	collection := db.Collection("sessions")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: 1}}},
+		// Sessions are over once their token has expired
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	)
+
+	return &sessionRepository{
+		collection: collection,
+	}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	session.ID = primitive.NewObjectID()
+	session.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, session)
+	return err
+}
+
+func (r *sessionRepository) ListUnexpired(ctx context.Context, userID string, now time.Time) ([]*domain.Session, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID, "expires_at": bson.M{"$gt": now}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	sessions := []*domain.Session{}
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type SLAPolicyRepository interface {
+	GetByCompanyID(ctx context.Context, companyID string) (*domain.SLAPolicy, error)
+	Upsert(ctx context.Context, policy *domain.SLAPolicy) error
+	// ListNotifying returns the policies that warn job owners before a breach
+	ListNotifying(ctx context.Context) ([]*domain.SLAPolicy, error)
+}
+
+type slaPolicyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSLAPolicyRepository(db *mongo.Database) SLAPolicyRepository {
+	collection := db.Collection("sla_policies")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "company_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	)
+
+	return &slaPolicyRepository{
+		collection: collection,
+	}
+}
+
+func (r *slaPolicyRepository) GetByCompanyID(ctx context.Context, companyID string) (*domain.SLAPolicy, error) {
+	var policy domain.SLAPolicy
+	err := r.collection.FindOne(ctx, bson.M{"company_id": companyID}).Decode(&policy)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrSLAPolicyNotFound
+		}
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (r *slaPolicyRepository) Upsert(ctx context.Context, policy *domain.SLAPolicy) error {
+	policy.UpdatedAt = time.Now()
+
+	_, err := r.collection.ReplaceOne(
+		ctx,
+		bson.M{"company_id": policy.CompanyID},
+		policy,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *slaPolicyRepository) ListNotifying(ctx context.Context) ([]*domain.SLAPolicy, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"notify_before_hours": bson.M{"$gt": 0}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	policies := []*domain.SLAPolicy{}
+	if err := cursor.All(ctx, &policies); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
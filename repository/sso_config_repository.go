@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type SSOConfigRepository interface {
+	GetByCompany(ctx context.Context, companyID string) (*domain.SSOConfig, error)
+	// Upsert creates or replaces the company's configuration
+	Upsert(ctx context.Context, config *domain.SSOConfig) error
+	DeleteByCompany(ctx context.Context, companyID string) error
+	// SetVerifiedDomains replaces the domains the company proved it controls
+	SetVerifiedDomains(ctx context.Context, companyID string, domains []string) error
+}
+
+type ssoConfigRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSSOConfigRepository(db *mongo.Database) SSOConfigRepository {
+	collection := db.Collection("sso_configs")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "company_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+	)
+
+	return &ssoConfigRepository{
+		collection: collection,
+	}
+}
+
+func (r *ssoConfigRepository) GetByCompany(ctx context.Context, companyID string) (*domain.SSOConfig, error) {
+	var config domain.SSOConfig
+	if err := r.collection.FindOne(ctx, bson.M{"company_id": companyID}).Decode(&config); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrSSOConfigNotFound
+		}
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+func (r *ssoConfigRepository) Upsert(ctx context.Context, config *domain.SSOConfig) error {
+	now := time.Now()
+	config.UpdatedAt = now
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err := r.collection.FindOneAndUpdate(ctx,
+		bson.M{"company_id": config.CompanyID},
+		bson.M{
+			"$set": bson.M{
+				"issuer":             config.Issuer,
+				"client_id":          config.ClientID,
+				"client_secret":      config.ClientSecret,
+				"allowed_domains":    config.AllowedDomains,
+				"verified_domains":   config.VerifiedDomains,
+				"verification_token": config.VerificationToken,
+				"enabled":            config.Enabled,
+				"updated_at":         now,
+			},
+			"$setOnInsert": bson.M{"_id": primitive.NewObjectID(), "created_at": now},
+		},
+		opts,
+	).Decode(config)
+
+	return err
+}
+
+func (r *ssoConfigRepository) DeleteByCompany(ctx context.Context, companyID string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"company_id": companyID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrSSOConfigNotFound
+	}
+
+	return nil
+}
+
+func (r *ssoConfigRepository) SetVerifiedDomains(ctx context.Context, companyID string, domains []string) error {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"company_id": companyID},
+		bson.M{"$set": bson.M{"verified_domains": domains, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrSSOConfigNotFound
+	}
+
+	return nil
+}
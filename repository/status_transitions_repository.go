@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+// statusTransitionsID is the _id of the single override document
+const statusTransitionsID = "application"
+
+type StatusTransitionsRepository interface {
+	Get(ctx context.Context) (*domain.StatusTransitionsOverride, error)
+	Save(ctx context.Context, override *domain.StatusTransitionsOverride) error
+	Delete(ctx context.Context) error
+}
+
+type statusTransitionsRepository struct {
+	collection *mongo.Collection
+}
+
+func NewStatusTransitionsRepository(db *mongo.Database) StatusTransitionsRepository {
+	return &statusTransitionsRepository{
+		collection: db.Collection("status_transitions"),
+	}
+}
+
+func (r *statusTransitionsRepository) Get(ctx context.Context) (*domain.StatusTransitionsOverride, error) {
+	var override domain.StatusTransitionsOverride
+	err := r.collection.FindOne(ctx, bson.M{"_id": statusTransitionsID}).Decode(&override)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrStatusTransitionsNotFound
+		}
+		return nil, err
+	}
+
+	return &override, nil
+}
+
+func (r *statusTransitionsRepository) Save(ctx context.Context, override *domain.StatusTransitionsOverride) error {
+	override.UpdatedAt = time.Now()
+
+	_, err := r.collection.ReplaceOne(
+		ctx,
+		bson.M{"_id": statusTransitionsID},
+		override,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *statusTransitionsRepository) Delete(ctx context.Context) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": statusTransitionsID})
+	return err
+}
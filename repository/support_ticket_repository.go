@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type SupportTicketRepository interface {
+	Create(ctx context.Context, ticket *domain.SupportTicket) error
+	GetByID(ctx context.Context, id string) (*domain.SupportTicket, error)
+	// GetByAttachmentURL returns the ticket the file at url is attached to
+	GetByAttachmentURL(ctx context.Context, url string) (*domain.SupportTicket, error)
+	// ListAttachmentURLsByUser returns the URLs of the files attached to the user's tickets
+	ListAttachmentURLsByUser(ctx context.Context, userID string) ([]string, error)
+	// ListByUser returns the user's tickets, most recently updated first
+	ListByUser(ctx context.Context, userID string, page, limit int) ([]*domain.SupportTicket, int64, error)
+	// List returns the tickets matching the filter, longest waiting first
+	List(ctx context.Context, filter domain.SupportTicketFilter, page, limit int) ([]*domain.SupportTicket, int64, error)
+	// AddReply appends the reply and moves the ticket to status. It returns
+	// domain.ErrSupportTicketClosed when the ticket is closed.
+	AddReply(ctx context.Context, id string, reply domain.SupportTicketReply, status domain.SupportTicketStatus) error
+	// DeleteByUser removes the user's tickets
+	DeleteByUser(ctx context.Context, userID string) error
+}
+
+type supportTicketRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSupportTicketRepository(db *mongo.Database) SupportTicketRepository {
+	collection := db.Collection("support_tickets")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "updated_at", Value: -1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "status", Value: 1}, {Key: "updated_at", Value: 1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "attachment_url", Value: 1}}, Options: options.Index().SetSparse(true)},
+	)
+
+	return &supportTicketRepository{
+		collection: collection,
+	}
+}
+
+func (r *supportTicketRepository) Create(ctx context.Context, ticket *domain.SupportTicket) error {
+	ticket.ID = primitive.NewObjectID()
+	ticket.CreatedAt = time.Now()
+	ticket.UpdatedAt = ticket.CreatedAt
+	if ticket.Replies == nil {
+		ticket.Replies = []domain.SupportTicketReply{}
+	}
+
+	_, err := r.collection.InsertOne(ctx, ticket)
+	return err
+}
+
+func (r *supportTicketRepository) GetByID(ctx context.Context, id string) (*domain.SupportTicket, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidID
+	}
+
+	var ticket domain.SupportTicket
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&ticket); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrSupportTicketNotFound
+		}
+		return nil, err
+	}
+
+	return &ticket, nil
+}
+
+func (r *supportTicketRepository) GetByAttachmentURL(ctx context.Context, url string) (*domain.SupportTicket, error) {
+	var ticket domain.SupportTicket
+	if err := r.collection.FindOne(ctx, bson.M{"attachment_url": url}).Decode(&ticket); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrSupportTicketNotFound
+		}
+		return nil, err
+	}
+
+	return &ticket, nil
+}
+
+func (r *supportTicketRepository) ListByUser(ctx context.Context, userID string, page, limit int) ([]*domain.SupportTicket, int64, error) {
+	return r.find(ctx, bson.M{"user_id": userID}, bson.D{{Key: "updated_at", Value: -1}}, page, limit)
+}
+
+func (r *supportTicketRepository) List(ctx context.Context, filter domain.SupportTicketFilter, page, limit int) ([]*domain.SupportTicket, int64, error) {
+	query := bson.M{}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if filter.Category != "" {
+		query["category"] = filter.Category
+	}
+
+	return r.find(ctx, query, bson.D{{Key: "updated_at", Value: 1}}, page, limit)
+}
+
+func (r *supportTicketRepository) find(ctx context.Context, filter bson.M, sort bson.D, page, limit int) ([]*domain.SupportTicket, int64, error) {
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find()
+	opts.SetSkip(int64((page - 1) * limit))
+	opts.SetLimit(int64(limit))
+	opts.SetSort(sort)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	tickets := []*domain.SupportTicket{}
+	if err := cursor.All(ctx, &tickets); err != nil {
+		return nil, 0, err
+	}
+
+	return tickets, total, nil
+}
+
+func (r *supportTicketRepository) AddReply(ctx context.Context, id string, reply domain.SupportTicketReply, status domain.SupportTicketStatus) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	set := bson.M{"status": status, "updated_at": reply.CreatedAt}
+	if status == domain.TicketStatusClosed {
+		set["closed_at"] = reply.CreatedAt
+	}
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": objID, "status": bson.M{"$ne": domain.TicketStatusClosed}},
+		bson.M{"$set": set, "$push": bson.M{"replies": reply}},
+	)
+	if err != nil {
+		return err
+	}
+
+	// The ticket was loaded before replying, so it was closed meanwhile
+	if result.MatchedCount == 0 {
+		return domain.ErrSupportTicketClosed
+	}
+
+	return nil
+}
+
+func (r *supportTicketRepository) ListAttachmentURLsByUser(ctx context.Context, userID string) ([]string, error) {
+	values, err := r.collection.Distinct(ctx, "attachment_url", bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, 0, len(values))
+	for _, v := range values {
+		if url, ok := v.(string); ok && url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls, nil
+}
+
+func (r *supportTicketRepository) DeleteByUser(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	return err
+}
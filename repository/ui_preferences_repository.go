@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"job-portal-backend/domain"
+)
+
+type UIPreferencesRepository interface {
+	// GetByUserID returns the user's saved view settings, empty when none were saved
+	GetByUserID(ctx context.Context, userID string) (*domain.UIPreferences, error)
+	// Update saves the given JSON encoded values and removes the unset keys,
+	// keeping the other keys as they are
+	Update(ctx context.Context, userID string, set map[string]string, unset []string) error
+	DeleteByUserID(ctx context.Context, userID string) error
+}
+
+type uiPreferencesRepository struct {
+	collection *mongo.Collection
+}
+
+func NewUIPreferencesRepository(db *mongo.Database) UIPreferencesRepository {
+	collection := db.Collection("ui_preferences")
+
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	)
+
+	return &uiPreferencesRepository{
+		collection: collection,
+	}
+}
+
+func (r *uiPreferencesRepository) GetByUserID(ctx context.Context, userID string) (*domain.UIPreferences, error) {
+	var prefs domain.UIPreferences
+	err := r.collection.FindOne(ctx, bson.M{"user_id": userID}).Decode(&prefs)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return &domain.UIPreferences{UserID: userID}, nil
+		}
+		return nil, err
+	}
+
+	return &prefs, nil
+}
+
+func (r *uiPreferencesRepository) Update(ctx context.Context, userID string, set map[string]string, unset []string) error {
+	// Keys are known preference names, they never contain dots or dollars
+	fields := bson.M{"updated_at": time.Now()}
+	for key, value := range set {
+		fields["values."+key] = value
+	}
+	update := bson.M{"$set": fields}
+	if len(unset) > 0 {
+		removed := bson.M{}
+		for _, key := range unset {
+			removed["values."+key] = ""
+		}
+		update["$unset"] = removed
+	}
+
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"user_id": userID},
+		update,
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *uiPreferencesRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID})
+	return err
+}
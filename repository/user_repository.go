@@ -2,19 +2,56 @@ package repository
 
 import (
 	"context"
+	"regexp"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"golang.org/x/crypto/bcrypt"
 
-	"Job-Portal-Backend/domain"
+	"job-portal-backend/domain"
 )
 
 type UserRepository interface {
 	CreateUser(ctx context.Context, user *domain.User) error
 	FindByEmail(ctx context.Context, email string) (*domain.User, error)
 	FindByID(ctx context.Context, id string) (*domain.User, error)
+	UpdatePassword(ctx context.Context, id string, password string) error
+	// UpdateEmail changes the user's email, ErrEmailAlreadyExists when another
+	// account uses it. The new email counts as verified, the change was confirmed from it.
+	UpdateEmail(ctx context.Context, id, email string) error
+	// MarkEmailVerified records that the user verified their email, reporting
+	// whether it wasn't verified before
+	MarkEmailVerified(ctx context.Context, id string) (bool, error)
+	FindByOAuthAccount(ctx context.Context, provider, subject string) (*domain.User, error)
+	AddOAuthAccount(ctx context.Context, id string, account domain.OAuthAccount) error
+	SetPendingTwoFactorSecret(ctx context.Context, id, secret string) error
+	EnableTwoFactor(ctx context.Context, id, secret string) error
+	// ReserveTwoFactorAttempt counts a TOTP code entered by the user. It
+	// returns false once max codes were entered within window.
+	ReserveTwoFactorAttempt(ctx context.Context, id string, max int, window time.Duration) (bool, error)
+	// UseTwoFactorStep records the time step of an accepted TOTP code and
+	// clears the attempts. It returns false when the step, or a later one,
+	// was already used.
+	UseTwoFactorStep(ctx context.Context, id string, step int64) (bool, error)
+	ListUsers(ctx context.Context, filter domain.UserFilter, page, limit int) ([]*domain.User, int64, error)
+	// SuggestCompanies returns the active company accounts whose name starts
+	// with prefix, ignoring case, ordered by name
+	SuggestCompanies(ctx context.Context, prefix string, limit int) ([]*domain.User, error)
+	SetStatus(ctx context.Context, id string, status domain.UserStatus) error
+	// SetRole changes the user's role and, unless name is empty, the display name that goes with it
+	SetRole(ctx context.Context, id string, role domain.Role, name string) error
+	// UpdateUser applies the fields set in update to the user
+	UpdateUser(ctx context.Context, id string, update *domain.UpdateUserRequest) error
+	UpdatePrivacy(ctx context.Context, id string, privacy domain.PrivacySettings) error
+	SetAvatar(ctx context.Context, id, avatarURL string) error
+	// AvatarInUse reports whether a user's profile picture is stored at avatarURL
+	AvatarInUse(ctx context.Context, avatarURL string) (bool, error)
+	// SoftDelete marks the user deleted and erases their personal data. The
+	// email is replaced so the address can be used to register again.
+	SoftDelete(ctx context.Context, id string) error
 }
 
 type userRepository struct {
@@ -22,18 +59,33 @@ type userRepository struct {
 }
 
 func NewUserRepository(db *mongo.Database) UserRepository {
+	collection := db.Collection("users")
+
+	// CreateUser and UpdateEmail rely on this index to reject addresses already in use
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "email", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		// Company name suggestions
+		mongo.IndexModel{Keys: bson.D{{Key: "role", Value: 1}, {Key: "name", Value: 1}}},
+		mongo.IndexModel{Keys: bson.D{{Key: "avatar_url", Value: 1}}, Options: options.Index().SetSparse(true)},
+	)
+
 	return &userRepository{
-		collection: db.Collection("users"),
+		collection: collection,
 	}
 }
 
 func (r *userRepository) CreateUser(ctx context.Context, user *domain.User) error {
-	// Hash the password before saving
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
-	if err != nil {
-		return err
+	// Hash the password before saving. Accounts created through social login have no password.
+	if user.Password != "" {
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return err
+		}
+		user.Password = string(hashedPassword)
 	}
-	user.Password = string(hashedPassword)
 
 	result, err := r.collection.InsertOne(ctx, user)
 	if err != nil {
@@ -82,4 +134,470 @@ func (r *userRepository) FindByID(ctx context.Context, id string) (*domain.User,
 	}
 
 	return &user, nil
-}
\ No newline at end of file
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, id string, password string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	// Hash the password before saving
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID},
+		bson.M{
+			"$set": bson.M{
+				"password":   string(hashedPassword),
+				"updated_at": time.Now(),
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) FindByOAuthAccount(ctx context.Context, provider, subject string) (*domain.User, error) {
+	var user domain.User
+	err := r.collection.FindOne(ctx, bson.M{
+		"oauth_accounts": bson.M{
+			"$elemMatch": bson.M{"provider": provider, "subject": subject},
+		},
+	}).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *userRepository) AddOAuthAccount(ctx context.Context, id string, account domain.OAuthAccount) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID},
+		bson.M{
+			"$push": bson.M{"oauth_accounts": account},
+			"$set":  bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) SetPendingTwoFactorSecret(ctx context.Context, id, secret string) error {
+	return r.updateFields(ctx, id, bson.M{"two_factor_pending_secret": secret})
+}
+
+func (r *userRepository) EnableTwoFactor(ctx context.Context, id, secret string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID},
+		bson.M{
+			"$set": bson.M{
+				"two_factor_enabled": true,
+				"two_factor_secret":  secret,
+				"updated_at":         time.Now(),
+			},
+			"$unset": bson.M{"two_factor_pending_secret": ""},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) ReserveTwoFactorAttempt(ctx context.Context, id string, max int, window time.Duration) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, domain.ErrInvalidID
+	}
+
+	// Start a new window once the previous one is over
+	now := time.Now()
+	_, err = r.collection.UpdateOne(ctx,
+		bson.M{"_id": objID, "$or": bson.A{
+			bson.M{"two_factor_attempts_since": nil},
+			bson.M{"two_factor_attempts_since": bson.M{"$lte": now.Add(-window)}},
+		}},
+		bson.M{"$set": bson.M{"two_factor_attempts": 0, "two_factor_attempts_since": now}},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": objID, "two_factor_attempts": bson.M{"$lt": max}},
+		bson.M{"$inc": bson.M{"two_factor_attempts": 1}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *userRepository) UseTwoFactorStep(ctx context.Context, id string, step int64) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": objID, "$or": bson.A{
+			bson.M{"two_factor_last_step": nil},
+			bson.M{"two_factor_last_step": bson.M{"$lt": step}},
+		}},
+		bson.M{
+			"$set":   bson.M{"two_factor_last_step": step},
+			"$unset": bson.M{"two_factor_attempts": "", "two_factor_attempts_since": ""},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// updateFields sets the given fields (and updated_at) on a user
+func (r *userRepository) updateFields(ctx context.Context, id string, fields bson.M) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	fields["updated_at"] = time.Now()
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": fields})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) SuggestCompanies(ctx context.Context, prefix string, limit int) ([]*domain.User, error) {
+	query := bson.M{
+		"role":   domain.Company,
+		"name":   bson.M{"$regex": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(prefix), Options: "i"}},
+		"status": bson.M{"$nin": bson.A{domain.UserSuspended, domain.UserDeleted}},
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "name", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"name": 1})
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	users := []*domain.User{}
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *userRepository) ListUsers(ctx context.Context, filter domain.UserFilter, page, limit int) ([]*domain.User, int64, error) {
+	query := bson.M{}
+
+	if filter.Query != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(filter.Query), Options: "i"}
+		query["$or"] = bson.A{
+			bson.M{"name": bson.M{"$regex": pattern}},
+			bson.M{"email": bson.M{"$regex": pattern}},
+		}
+	}
+
+	if filter.Role != "" {
+		query["role"] = filter.Role
+	}
+
+	switch filter.Status {
+	case domain.UserSuspended:
+		query["status"] = domain.UserSuspended
+	case domain.UserDeleted:
+		query["status"] = domain.UserDeleted
+	case domain.UserActive:
+		// Accounts without a status predate suspensions and are active
+		query["status"] = bson.M{"$nin": bson.A{domain.UserSuspended, domain.UserDeleted}}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find()
+	opts.SetSkip(int64((page - 1) * limit))
+	opts.SetLimit(int64(limit))
+	opts.SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	users := []*domain.User{}
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+func (r *userRepository) SetStatus(ctx context.Context, id string, status domain.UserStatus) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	update := bson.M{
+		"$set":   bson.M{"status": status, "updated_at": time.Now()},
+		"$unset": bson.M{"suspended_at": ""},
+	}
+	if status == domain.UserSuspended {
+		update = bson.M{
+			"$set": bson.M{"status": status, "suspended_at": time.Now(), "updated_at": time.Now()},
+		}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) SetRole(ctx context.Context, id string, role domain.Role, name string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	set := bson.M{"role": role, "updated_at": time.Now()}
+	if name != "" {
+		set["name"] = name
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) UpdateEmail(ctx context.Context, id, email string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{
+		"$set": bson.M{"email": email, "email_verified_at": time.Now(), "updated_at": time.Now()},
+	})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrEmailAlreadyExists
+		}
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) MarkEmailVerified(ctx context.Context, id string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": objID, "email_verified_at": nil},
+		bson.M{"$set": bson.M{"email_verified_at": time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *userRepository) UpdateUser(ctx context.Context, id string, update *domain.UpdateUserRequest) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	set := bson.M{"updated_at": time.Now()}
+	unset := bson.M{}
+	if update.Name != nil {
+		set["name"] = *update.Name
+	}
+	if update.Phone != nil {
+		if *update.Phone == "" {
+			unset["phone"] = ""
+		} else {
+			set["phone"] = *update.Phone
+		}
+	}
+
+	changes := bson.M{"$set": set}
+	if len(unset) > 0 {
+		changes["$unset"] = unset
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, changes)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) UpdatePrivacy(ctx context.Context, id string, privacy domain.PrivacySettings) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{
+		"$set": bson.M{"privacy": privacy, "updated_at": time.Now()},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) SetAvatar(ctx context.Context, id, avatarURL string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{
+		"$set": bson.M{"avatar_url": avatarURL, "updated_at": time.Now()},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) AvatarInUse(ctx context.Context, avatarURL string) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"avatar_url": avatarURL}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *userRepository) SoftDelete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidID
+	}
+
+	now := time.Now()
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{
+		"$set": bson.M{
+			"name":               domain.DeletedUserName,
+			"email":              "deleted-" + id + "@deleted.invalid",
+			"password":           "",
+			"status":             domain.UserDeleted,
+			"two_factor_enabled": false,
+			"deleted_at":         now,
+			"updated_at":         now,
+		},
+		"$unset": bson.M{
+			"oauth_accounts":            "",
+			"two_factor_secret":         "",
+			"two_factor_pending_secret": "",
+			"suspended_at":              "",
+			"signup_country":            "",
+			"phone":                     "",
+			"privacy":                   "",
+			"avatar_url":                "",
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
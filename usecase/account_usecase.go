@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/pkg/storage"
+	"job-portal-backend/repository"
+	"job-portal-backend/utils"
+)
+
+// TxFunc runs fn inside a database transaction. Repository calls that belong
+// to the transaction must use the context passed to fn.
+type TxFunc func(ctx context.Context, fn func(ctx context.Context) error) error
+
+// AccountUsecase handles operations that span a user's data across repositories
+type AccountUsecase interface {
+	// DeleteAccount closes the user's account: the user is soft-deleted and their
+	// personal data erased, their applications anonymized, their jobs unpublished
+	// and every token and API key they hold invalidated. Their avatar, resumes
+	// and support attachments are then removed from storage.
+	DeleteAccount(ctx context.Context, userID string) (*domain.UserResponse, error)
+}
+
+type accountUsecase struct {
+	userRepo           repository.UserRepository
+	appRepo            repository.ApplicationRepository
+	appEventRepo       repository.ApplicationEventRepository
+	jobRepo            repository.JobRepository
+	authTokenRepo      repository.AuthTokenRepository
+	revokedRepo        repository.RevokedTokenRepository
+	apiKeyRepo         repository.APIKeyRepository
+	alertPrefsRepo     repository.AlertPreferencesRepository
+	profileRepo        repository.ApplicantProfileRepository
+	resumeRepo         repository.ResumeRepository
+	companyProfileRepo repository.CompanyProfileRepository
+	notifyPrefsRepo    repository.NotificationPreferencesRepository
+	pendingRepo        repository.PendingNotificationRepository
+	followRepo         repository.FollowRepository
+	memberRepo         repository.CompanyMemberRepository
+	invitationRepo     repository.CompanyInvitationRepository
+	uiPrefsRepo        repository.UIPreferencesRepository
+	activityRepo       repository.ActivityRepository
+	templateRepo       repository.JobTemplateRepository
+	ticketRepo         repository.SupportTicketRepository
+	savedSearchRepo    repository.SavedSearchRepository
+	savedJobRepo       repository.SavedJobRepository
+	files              storage.Deleter
+	tokens             *utils.TokenService
+	withTx             TxFunc
+}
+
+func NewAccountUsecase(
+	userRepo repository.UserRepository,
+	appRepo repository.ApplicationRepository,
+	appEventRepo repository.ApplicationEventRepository,
+	jobRepo repository.JobRepository,
+	authTokenRepo repository.AuthTokenRepository,
+	revokedRepo repository.RevokedTokenRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	alertPrefsRepo repository.AlertPreferencesRepository,
+	profileRepo repository.ApplicantProfileRepository,
+	resumeRepo repository.ResumeRepository,
+	companyProfileRepo repository.CompanyProfileRepository,
+	notifyPrefsRepo repository.NotificationPreferencesRepository,
+	pendingRepo repository.PendingNotificationRepository,
+	followRepo repository.FollowRepository,
+	memberRepo repository.CompanyMemberRepository,
+	invitationRepo repository.CompanyInvitationRepository,
+	uiPrefsRepo repository.UIPreferencesRepository,
+	activityRepo repository.ActivityRepository,
+	templateRepo repository.JobTemplateRepository,
+	ticketRepo repository.SupportTicketRepository,
+	savedSearchRepo repository.SavedSearchRepository,
+	savedJobRepo repository.SavedJobRepository,
+	files storage.Deleter,
+	tokens *utils.TokenService,
+	withTx TxFunc,
+) AccountUsecase {
+	return &accountUsecase{
+		userRepo:           userRepo,
+		appRepo:            appRepo,
+		appEventRepo:       appEventRepo,
+		jobRepo:            jobRepo,
+		authTokenRepo:      authTokenRepo,
+		revokedRepo:        revokedRepo,
+		apiKeyRepo:         apiKeyRepo,
+		alertPrefsRepo:     alertPrefsRepo,
+		profileRepo:        profileRepo,
+		resumeRepo:         resumeRepo,
+		companyProfileRepo: companyProfileRepo,
+		notifyPrefsRepo:    notifyPrefsRepo,
+		pendingRepo:        pendingRepo,
+		followRepo:         followRepo,
+		memberRepo:         memberRepo,
+		invitationRepo:     invitationRepo,
+		uiPrefsRepo:        uiPrefsRepo,
+		activityRepo:       activityRepo,
+		templateRepo:       templateRepo,
+		ticketRepo:         ticketRepo,
+		savedSearchRepo:    savedSearchRepo,
+		savedJobRepo:       savedJobRepo,
+		files:              files,
+		tokens:             tokens,
+		withTx:             withTx,
+	}
+}
+
+func (uc *accountUsecase) DeleteAccount(ctx context.Context, userID string) (*domain.UserResponse, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewNotFoundError("User not found")
+		}
+		return nil, err
+	}
+
+	if user.IsDeleted() {
+		return nil, apperrors.NewConflictError("Account is already deleted")
+	}
+	// The admin account is seeded from the configuration and would be recreated
+	if user.Role == domain.Admin {
+		return nil, errForbidden("Admin accounts cannot be deleted")
+	}
+
+	// The records pointing at the files are erased below, so collect them first
+	files, err := uc.storedFiles(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.withTx(ctx, func(ctx context.Context) error {
+		switch user.Role {
+		case domain.Applicant:
+			if err := uc.appRepo.AnonymizeByApplicant(ctx, userID); err != nil {
+				return fmt.Errorf("error anonymizing applications: %w", err)
+			}
+			if err := uc.appEventRepo.AnonymizeByApplicant(ctx, userID); err != nil {
+				return fmt.Errorf("error anonymizing application events: %w", err)
+			}
+			if err := uc.alertPrefsRepo.DeleteByUserID(ctx, userID); err != nil {
+				return fmt.Errorf("error deleting alert preferences: %w", err)
+			}
+			if err := uc.profileRepo.DeleteByUserID(ctx, userID); err != nil {
+				return fmt.Errorf("error deleting profile: %w", err)
+			}
+			if err := uc.resumeRepo.DeleteByUser(ctx, userID); err != nil {
+				return fmt.Errorf("error deleting resumes: %w", err)
+			}
+			if err := uc.followRepo.DeleteByUser(ctx, userID); err != nil {
+				return fmt.Errorf("error deleting followed companies: %w", err)
+			}
+			if err := uc.uiPrefsRepo.DeleteByUserID(ctx, userID); err != nil {
+				return fmt.Errorf("error deleting saved views: %w", err)
+			}
+			if err := uc.savedSearchRepo.DeleteByUser(ctx, userID); err != nil {
+				return fmt.Errorf("error deleting saved searches: %w", err)
+			}
+			if err := uc.savedJobRepo.DeleteByUser(ctx, userID); err != nil {
+				return fmt.Errorf("error deleting saved jobs: %w", err)
+			}
+		case domain.Company:
+			if err := uc.jobRepo.UnpublishByCompany(ctx, userID); err != nil {
+				return fmt.Errorf("error unpublishing jobs: %w", err)
+			}
+			if err := uc.apiKeyRepo.RevokeAllByCompany(ctx, userID); err != nil {
+				return fmt.Errorf("error revoking api keys: %w", err)
+			}
+			if err := uc.companyProfileRepo.DeleteByCompanyID(ctx, userID); err != nil && !errors.Is(err, domain.ErrCompanyProfileNotFound) {
+				return fmt.Errorf("error deleting company profile: %w", err)
+			}
+			if err := uc.followRepo.DeleteByCompany(ctx, userID); err != nil {
+				return fmt.Errorf("error deleting followers: %w", err)
+			}
+			// The team goes with the company account, members keep their own accounts
+			if err := uc.memberRepo.DeleteByCompany(ctx, userID); err != nil {
+				return fmt.Errorf("error deleting team members: %w", err)
+			}
+			if err := uc.invitationRepo.DeleteByCompany(ctx, userID); err != nil {
+				return fmt.Errorf("error deleting team invitations: %w", err)
+			}
+			if err := uc.templateRepo.DeleteByCompany(ctx, userID); err != nil {
+				return fmt.Errorf("error deleting job templates: %w", err)
+			}
+			if err := uc.memberRepo.DeleteByUser(ctx, userID); err != nil {
+				return fmt.Errorf("error leaving team: %w", err)
+			}
+			if err := uc.activityRepo.DeleteByUser(ctx, userID); err != nil {
+				return fmt.Errorf("error deleting activity: %w", err)
+			}
+		}
+
+		if err := uc.notifyPrefsRepo.DeleteByUserID(ctx, userID); err != nil {
+			return fmt.Errorf("error deleting notification preferences: %w", err)
+		}
+		if err := uc.pendingRepo.DeleteByUser(ctx, userID); err != nil {
+			return fmt.Errorf("error deleting pending notifications: %w", err)
+		}
+		if err := uc.ticketRepo.DeleteByUser(ctx, userID); err != nil {
+			return fmt.Errorf("error deleting support tickets: %w", err)
+		}
+		// Links sent before the deletion must not sign the account back in
+		for _, purpose := range []domain.TokenPurpose{domain.PurposePasswordReset, domain.PurposeMagicLink, domain.PurposeEmailChange} {
+			if err := uc.authTokenRepo.DeleteUserTokens(ctx, userID, purpose); err != nil {
+				return fmt.Errorf("error deleting %s tokens: %w", purpose, err)
+			}
+		}
+		if err := uc.revokedRepo.RevokeAllForUser(ctx, userID, time.Now().Add(uc.tokens.TTL())); err != nil {
+			return fmt.Errorf("error revoking tokens: %w", err)
+		}
+
+		if err := uc.userRepo.SoftDelete(ctx, userID); err != nil {
+			return fmt.Errorf("error deleting user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The account is gone either way, a file left behind is only logged
+	for _, url := range files {
+		if err := uc.files.Delete(ctx, url); err != nil {
+			log.Printf("Error deleting file %s of deleted user %s: %v", url, userID, err)
+		}
+	}
+
+	return &domain.UserResponse{
+		Success: true,
+		Message: "Account deleted successfully",
+	}, nil
+}
+
+// storedFiles returns the URLs of the files uploaded by the user
+func (uc *accountUsecase) storedFiles(ctx context.Context, user *domain.User) ([]string, error) {
+	userID := user.ID.Hex()
+	var files []string
+	if user.AvatarURL != "" {
+		files = append(files, user.AvatarURL)
+	}
+
+	if user.Role == domain.Applicant {
+		resumes, err := uc.resumeRepo.ListByUser(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("error listing resumes: %w", err)
+		}
+		for _, resume := range resumes {
+			files = append(files, resume.URL)
+		}
+
+		// Applications sent with a library resume share its file
+		resumeFiles, err := uc.appRepo.ListResumeFilesByApplicant(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("error listing application resumes: %w", err)
+		}
+		for _, url := range resumeFiles {
+			if !hasString(files, url) {
+				files = append(files, url)
+			}
+		}
+	}
+
+	attachments, err := uc.ticketRepo.ListAttachmentURLsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing support attachments: %w", err)
+	}
+	return append(files, attachments...), nil
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/repository"
+)
+
+// ActivityUsecase serves users' activity feeds, e.g. the application notes
+// they were mentioned in
+type ActivityUsecase interface {
+	// ListActivity returns the user's feed, most recent first, with its unread count
+	ListActivity(ctx context.Context, userID string, page, limit int) (*domain.ActivityListResponse, error)
+	MarkAllRead(ctx context.Context, userID string) (*domain.ActivityListResponse, error)
+}
+
+type activityUsecase struct {
+	activityRepo repository.ActivityRepository
+}
+
+func NewActivityUsecase(activityRepo repository.ActivityRepository) ActivityUsecase {
+	return &activityUsecase{
+		activityRepo: activityRepo,
+	}
+}
+
+func (uc *activityUsecase) ListActivity(ctx context.Context, userID string, page, limit int) (*domain.ActivityListResponse, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	activities, total, err := uc.activityRepo.ListByUser(ctx, userID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing activity: %w", err)
+	}
+	unread, err := uc.activityRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error counting unread activity: %w", err)
+	}
+
+	// Calculate total pages
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return &domain.ActivityListResponse{
+		Success:    true,
+		Message:    "Successfully retrieved activity",
+		Data:       activities,
+		Unread:     unread,
+		PageNumber: page,
+		PageSize:   len(activities),
+		TotalItems: total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (uc *activityUsecase) MarkAllRead(ctx context.Context, userID string) (*domain.ActivityListResponse, error) {
+	if err := uc.activityRepo.MarkAllRead(ctx, userID); err != nil {
+		return nil, fmt.Errorf("error marking activity as read: %w", err)
+	}
+
+	return &domain.ActivityListResponse{
+		Success:    true,
+		Message:    "Activity marked as read",
+		PageNumber: 1,
+		TotalPages: 1,
+	}, nil
+}
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+	"job-portal-backend/utils"
+)
+
+type AdminUsecase interface {
+	ListUsers(ctx context.Context, filter domain.UserFilter, page, limit int) (*domain.UserListResponse, error)
+	GetUser(ctx context.Context, userID string) (*domain.UserResponse, error)
+	// CreateAuditor opens a read-only auditor account, the only way to get one
+	CreateAuditor(ctx context.Context, req *domain.CreateAuditorRequest) (*domain.UserResponse, error)
+	SuspendUser(ctx context.Context, adminID, userID string) (*domain.UserResponse, error)
+	ReactivateUser(ctx context.Context, userID string) (*domain.UserResponse, error)
+	EnsureAdmin(ctx context.Context, name, email, password string) error
+}
+
+type adminUsecase struct {
+	userRepo    repository.UserRepository
+	revokedRepo repository.RevokedTokenRepository
+	events      EventBus
+	tokens      *utils.TokenService
+}
+
+func NewAdminUsecase(userRepo repository.UserRepository, revokedRepo repository.RevokedTokenRepository, events EventBus, tokens *utils.TokenService) AdminUsecase {
+	return &adminUsecase{
+		userRepo:    userRepo,
+		revokedRepo: revokedRepo,
+		events:      events,
+		tokens:      tokens,
+	}
+}
+
+func (uc *adminUsecase) ListUsers(ctx context.Context, filter domain.UserFilter, page, limit int) (*domain.UserListResponse, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	users, total, err := uc.userRepo.ListUsers(ctx, filter, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing users: %w", err)
+	}
+
+	for _, user := range users {
+		user.Sanitize()
+	}
+
+	// Calculate total pages
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return &domain.UserListResponse{
+		Success:    true,
+		Message:    "Successfully retrieved users",
+		Data:       users,
+		PageNumber: page,
+		PageSize:   len(users),
+		TotalItems: total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (uc *adminUsecase) GetUser(ctx context.Context, userID string) (*domain.UserResponse, error) {
+	return uc.userResponse(ctx, userID, "Successfully retrieved user")
+}
+
+func (uc *adminUsecase) CreateAuditor(ctx context.Context, req *domain.CreateAuditorRequest) (*domain.UserResponse, error) {
+	now := time.Now()
+	user := &domain.User{
+		Name:      req.Name,
+		Email:     req.Email,
+		Password:  req.Password, // Will be hashed in repository
+		Role:      domain.Auditor,
+		Status:    domain.UserActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.userRepo.CreateUser(ctx, user); err != nil {
+		if errors.Is(err, domain.ErrEmailAlreadyExists) {
+			return nil, apperrors.NewConflictError("Email already registered")
+		}
+		return nil, err
+	}
+	user.Sanitize()
+
+	return &domain.UserResponse{
+		Success: true,
+		Message: "Auditor account created successfully",
+		Data:    user,
+	}, nil
+}
+
+func (uc *adminUsecase) SuspendUser(ctx context.Context, adminID, userID string) (*domain.UserResponse, error) {
+	if adminID == userID {
+		return nil, apperrors.NewBadRequestError("You cannot suspend your own account", nil)
+	}
+
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDeleted() {
+		return nil, apperrors.NewConflictError("User account is deleted")
+	}
+	if user.IsSuspended() {
+		return nil, apperrors.NewConflictError("User is already suspended")
+	}
+
+	if err := uc.userRepo.SetStatus(ctx, userID, domain.UserSuspended); err != nil {
+		return nil, err
+	}
+
+	// Sign the user out everywhere, suspension must take effect immediately
+	if err := uc.revokedRepo.RevokeAllForUser(ctx, userID, time.Now().Add(uc.tokens.TTL())); err != nil {
+		return nil, err
+	}
+
+	data := user.EventData("")
+	data.SuspendedBy = adminID
+	uc.events.Publish(ctx, domain.EventUserSuspended, data)
+
+	return uc.userResponse(ctx, userID, "User suspended successfully")
+}
+
+func (uc *adminUsecase) ReactivateUser(ctx context.Context, userID string) (*domain.UserResponse, error) {
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDeleted() {
+		return nil, apperrors.NewConflictError("User account is deleted")
+	}
+	if !user.IsSuspended() {
+		return nil, apperrors.NewConflictError("User is not suspended")
+	}
+
+	if err := uc.userRepo.SetStatus(ctx, userID, domain.UserActive); err != nil {
+		return nil, err
+	}
+
+	return uc.userResponse(ctx, userID, "User reactivated successfully")
+}
+
+// EnsureAdmin creates the admin account configured at startup unless it already exists
+func (uc *adminUsecase) EnsureAdmin(ctx context.Context, name, email, password string) error {
+	existing, err := uc.userRepo.FindByEmail(ctx, email)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return err
+	}
+
+	if existing != nil {
+		if existing.Role != domain.Admin {
+			return fmt.Errorf("admin email %s is already used by a %s account", email, existing.Role)
+		}
+		return nil
+	}
+
+	now := time.Now()
+	return uc.userRepo.CreateUser(ctx, &domain.User{
+		Name:      name,
+		Email:     email,
+		Password:  password, // Will be hashed in repository
+		Role:      domain.Admin,
+		Status:    domain.UserActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+func (uc *adminUsecase) getUser(ctx context.Context, userID string) (*domain.User, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewNotFoundError("User not found")
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// userResponse reloads the user so the response reflects the stored state
+func (uc *adminUsecase) userResponse(ctx context.Context, userID, message string) (*domain.UserResponse, error) {
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user.Sanitize()
+
+	return &domain.UserResponse{
+		Success: true,
+		Message: message,
+		Data:    user,
+	}, nil
+}
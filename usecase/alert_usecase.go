@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/repository"
+)
+
+// AlertUsecase manages applicants' job alert preferences and the job matching
+// that honours them
+type AlertUsecase interface {
+	GetPreferences(ctx context.Context, userID string) (*domain.AlertPreferencesResponse, error)
+	UpdatePreferences(ctx context.Context, userID string, req *domain.UpdateAlertPreferencesRequest) (*domain.AlertPreferencesResponse, error)
+	// RecommendJobs returns the latest published jobs, minus the applicant's exclusions
+	RecommendJobs(ctx context.Context, userID string, page, limit int) ([]*domain.Job, int64, error)
+}
+
+type alertUsecase struct {
+	prefsRepo repository.AlertPreferencesRepository
+	jobRepo   repository.JobRepository
+}
+
+func NewAlertUsecase(prefsRepo repository.AlertPreferencesRepository, jobRepo repository.JobRepository) AlertUsecase {
+	return &alertUsecase{
+		prefsRepo: prefsRepo,
+		jobRepo:   jobRepo,
+	}
+}
+
+func (uc *alertUsecase) GetPreferences(ctx context.Context, userID string) (*domain.AlertPreferencesResponse, error) {
+	prefs, err := uc.prefsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving alert preferences: %w", err)
+	}
+
+	return &domain.AlertPreferencesResponse{
+		Success: true,
+		Message: "Successfully retrieved alert preferences",
+		Data:    prefs,
+	}, nil
+}
+
+func (uc *alertUsecase) UpdatePreferences(ctx context.Context, userID string, req *domain.UpdateAlertPreferencesRequest) (*domain.AlertPreferencesResponse, error) {
+	prefs := &domain.AlertPreferences{
+		UserID:            userID,
+		ExcludedCompanies: normalizeList(req.ExcludedCompanies, false),
+		ExcludedKeywords:  normalizeList(req.ExcludedKeywords, true),
+	}
+
+	if err := uc.prefsRepo.Upsert(ctx, prefs); err != nil {
+		return nil, fmt.Errorf("error saving alert preferences: %w", err)
+	}
+
+	return &domain.AlertPreferencesResponse{
+		Success: true,
+		Message: "Alert preferences updated successfully",
+		Data:    prefs,
+	}, nil
+}
+
+func (uc *alertUsecase) RecommendJobs(ctx context.Context, userID string, page, limit int) ([]*domain.Job, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	prefs, err := uc.prefsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error retrieving alert preferences: %w", err)
+	}
+
+	jobs, total, err := uc.jobRepo.ListRecommendedJobs(ctx, prefs.Exclusions(), page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	setComputedFields(jobs...)
+
+	return jobs, total, nil
+}
+
+// normalizeList trims entries and drops blanks and duplicates. Keywords are
+// compared case-insensitively, so they are stored lower-cased.
+func normalizeList(values []string, lower bool) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if lower {
+			v = strings.ToLower(v)
+		}
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	return result
+}
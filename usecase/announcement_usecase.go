@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// AnnouncementUsecase manages the portal-wide announcements shown as banners
+type AnnouncementUsecase interface {
+	// GetAnnouncements returns the active announcements targeting the role the
+	// user hasn't dismissed. userID and role are empty for visitors.
+	GetAnnouncements(ctx context.Context, userID string, role domain.Role) (*domain.AnnouncementResponse, error)
+	// DismissAnnouncement hides the announcement from the user
+	DismissAnnouncement(ctx context.Context, id, userID string) (*domain.AnnouncementResponse, error)
+	// ListAnnouncements returns every announcement, past and scheduled ones included
+	ListAnnouncements(ctx context.Context) (*domain.AnnouncementResponse, error)
+	CreateAnnouncement(ctx context.Context, req *domain.CreateAnnouncementRequest, adminID string) (*domain.AnnouncementResponse, error)
+	UpdateAnnouncement(ctx context.Context, id string, req *domain.UpdateAnnouncementRequest) (*domain.AnnouncementResponse, error)
+	DeleteAnnouncement(ctx context.Context, id string) (*domain.AnnouncementResponse, error)
+}
+
+type announcementUsecase struct {
+	announcementRepo repository.AnnouncementRepository
+}
+
+func NewAnnouncementUsecase(announcementRepo repository.AnnouncementRepository) AnnouncementUsecase {
+	return &announcementUsecase{
+		announcementRepo: announcementRepo,
+	}
+}
+
+func (uc *announcementUsecase) GetAnnouncements(ctx context.Context, userID string, role domain.Role) (*domain.AnnouncementResponse, error) {
+	active, err := uc.announcementRepo.ListActive(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error listing announcements: %w", err)
+	}
+
+	targeted := []*domain.Announcement{}
+	ids := []string{}
+	for _, announcement := range active {
+		if announcement.Targets(role) {
+			targeted = append(targeted, announcement)
+			ids = append(ids, announcement.ID.Hex())
+		}
+	}
+
+	announcements := targeted
+	if userID != "" {
+		dismissed, err := uc.announcementRepo.DismissedBy(ctx, userID, ids)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving dismissed announcements: %w", err)
+		}
+
+		announcements = []*domain.Announcement{}
+		for _, announcement := range targeted {
+			if !dismissed[announcement.ID.Hex()] {
+				announcements = append(announcements, announcement)
+			}
+		}
+	}
+
+	return &domain.AnnouncementResponse{
+		Success: true,
+		Message: "Successfully retrieved announcements",
+		Data:    announcements,
+	}, nil
+}
+
+func (uc *announcementUsecase) DismissAnnouncement(ctx context.Context, id, userID string) (*domain.AnnouncementResponse, error) {
+	announcement, err := uc.announcementRepo.GetByID(ctx, id)
+	if err != nil {
+		if isNotFound(err, domain.ErrAnnouncementNotFound) {
+			return nil, apperrors.NewNotFoundError("Announcement not found")
+		}
+		return nil, fmt.Errorf("error retrieving announcement: %w", err)
+	}
+
+	dismissal := &domain.AnnouncementDismissal{
+		AnnouncementID: announcement.ID.Hex(),
+		UserID:         userID,
+		DismissedAt:    time.Now(),
+	}
+	if err := uc.announcementRepo.Dismiss(ctx, dismissal); err != nil {
+		return nil, fmt.Errorf("error dismissing announcement: %w", err)
+	}
+
+	return &domain.AnnouncementResponse{
+		Success: true,
+		Message: "Announcement dismissed",
+	}, nil
+}
+
+func (uc *announcementUsecase) ListAnnouncements(ctx context.Context) (*domain.AnnouncementResponse, error) {
+	announcements, err := uc.announcementRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing announcements: %w", err)
+	}
+
+	return &domain.AnnouncementResponse{
+		Success: true,
+		Message: "Successfully retrieved announcements",
+		Data:    announcements,
+	}, nil
+}
+
+func (uc *announcementUsecase) CreateAnnouncement(ctx context.Context, req *domain.CreateAnnouncementRequest, adminID string) (*domain.AnnouncementResponse, error) {
+	startsAt := time.Now()
+	if req.StartsAt != nil {
+		startsAt = *req.StartsAt
+	}
+	if err := checkAnnouncementWindow(startsAt, req.EndsAt); err != nil {
+		return nil, err
+	}
+
+	announcement := &domain.Announcement{
+		Kind:      req.Kind,
+		Title:     strings.TrimSpace(req.Title),
+		Message:   strings.TrimSpace(req.Message),
+		Link:      req.Link,
+		Roles:     req.Roles,
+		StartsAt:  startsAt,
+		EndsAt:    req.EndsAt,
+		CreatedBy: adminID,
+	}
+	if err := uc.announcementRepo.Create(ctx, announcement); err != nil {
+		return nil, fmt.Errorf("error creating announcement: %w", err)
+	}
+
+	return &domain.AnnouncementResponse{
+		Success: true,
+		Message: "Announcement created successfully",
+		Data:    announcement,
+	}, nil
+}
+
+func (uc *announcementUsecase) UpdateAnnouncement(ctx context.Context, id string, req *domain.UpdateAnnouncementRequest) (*domain.AnnouncementResponse, error) {
+	existing, err := uc.announcementRepo.GetByID(ctx, id)
+	if err != nil {
+		if isNotFound(err, domain.ErrAnnouncementNotFound) {
+			return nil, apperrors.NewNotFoundError("Announcement not found")
+		}
+		return nil, fmt.Errorf("error retrieving announcement: %w", err)
+	}
+
+	// The window is checked as it will be once updated
+	startsAt, endsAt := existing.StartsAt, existing.EndsAt
+	if req.StartsAt != nil {
+		startsAt = *req.StartsAt
+	}
+	if req.EndsAt != nil {
+		endsAt = req.EndsAt
+	}
+	if err := checkAnnouncementWindow(startsAt, endsAt); err != nil {
+		return nil, err
+	}
+
+	if err := uc.announcementRepo.Update(ctx, id, req); err != nil {
+		if isNotFound(err, domain.ErrAnnouncementNotFound) {
+			return nil, apperrors.NewNotFoundError("Announcement not found")
+		}
+		return nil, fmt.Errorf("error updating announcement: %w", err)
+	}
+
+	announcement, err := uc.announcementRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving announcement: %w", err)
+	}
+
+	return &domain.AnnouncementResponse{
+		Success: true,
+		Message: "Announcement updated successfully",
+		Data:    announcement,
+	}, nil
+}
+
+func (uc *announcementUsecase) DeleteAnnouncement(ctx context.Context, id string) (*domain.AnnouncementResponse, error) {
+	if err := uc.announcementRepo.Delete(ctx, id); err != nil {
+		if isNotFound(err, domain.ErrAnnouncementNotFound) {
+			return nil, apperrors.NewNotFoundError("Announcement not found")
+		}
+		return nil, fmt.Errorf("error deleting announcement: %w", err)
+	}
+
+	return &domain.AnnouncementResponse{
+		Success: true,
+		Message: "Announcement deleted successfully",
+	}, nil
+}
+
+// checkAnnouncementWindow fails with a bad request when the announcement would end before it starts
+func checkAnnouncementWindow(startsAt time.Time, endsAt *time.Time) error {
+	if endsAt != nil && !endsAt.After(startsAt) {
+		return apperrors.NewBadRequestError("Validation failed", []string{"ends_at must be after starts_at"})
+	}
+	return nil
+}
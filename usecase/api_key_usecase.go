@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+	"job-portal-backend/utils"
+)
+
+// maxAPIKeysPerCompany bounds the number of active keys a company can hold
+const maxAPIKeysPerCompany = 10
+
+// apiKeyTouchInterval throttles last_used_at writes, a busy key would otherwise
+// cause a database write on every request
+const apiKeyTouchInterval = time.Minute
+
+type APIKeyUsecase interface {
+	CreateKey(ctx context.Context, companyID string, req *domain.CreateAPIKeyRequest) (*domain.APIKeyResponse, error)
+	ListKeys(ctx context.Context, companyID string) (*domain.APIKeyResponse, error)
+	RevokeKey(ctx context.Context, companyID, keyID string) (*domain.APIKeyResponse, error)
+	// Authenticate resolves a raw key sent in the X-Api-Key header
+	Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, error)
+}
+
+type apiKeyUsecase struct {
+	keyRepo  repository.APIKeyRepository
+	userRepo repository.UserRepository
+}
+
+func NewAPIKeyUsecase(keyRepo repository.APIKeyRepository, userRepo repository.UserRepository) APIKeyUsecase {
+	return &apiKeyUsecase{
+		keyRepo:  keyRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (uc *apiKeyUsecase) CreateKey(ctx context.Context, companyID string, req *domain.CreateAPIKeyRequest) (*domain.APIKeyResponse, error) {
+	count, err := uc.keyRepo.CountActiveByCompany(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("error counting api keys: %w", err)
+	}
+	if count >= maxAPIKeysPerCompany {
+		return nil, apperrors.NewConflictError(fmt.Sprintf("A company can have at most %d active API keys", maxAPIKeysPerCompany))
+	}
+
+	prefix, err := randomHex(4)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := utils.GenerateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+	rawKey := "jp_" + prefix + "_" + secret
+
+	rateLimit := req.RateLimit
+	if rateLimit == 0 {
+		rateLimit = domain.DefaultAPIKeyRateLimit
+	}
+
+	key := &domain.APIKey{
+		CompanyID: companyID,
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   utils.HashToken(rawKey),
+		Scopes:    req.Scopes,
+		RateLimit: rateLimit,
+	}
+	if err := uc.keyRepo.CreateKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("error creating api key: %w", err)
+	}
+
+	return &domain.APIKeyResponse{
+		Success: true,
+		Message: "API key created successfully. Store the key now, it won't be shown again",
+		Data:    &domain.CreatedAPIKey{APIKey: key, Key: rawKey},
+	}, nil
+}
+
+func (uc *apiKeyUsecase) ListKeys(ctx context.Context, companyID string) (*domain.APIKeyResponse, error) {
+	keys, err := uc.keyRepo.ListByCompany(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing api keys: %w", err)
+	}
+
+	return &domain.APIKeyResponse{
+		Success: true,
+		Message: "Successfully retrieved API keys",
+		Data:    keys,
+	}, nil
+}
+
+func (uc *apiKeyUsecase) RevokeKey(ctx context.Context, companyID, keyID string) (*domain.APIKeyResponse, error) {
+	if err := uc.keyRepo.RevokeKey(ctx, keyID, companyID); err != nil {
+		if isNotFound(err, domain.ErrAPIKeyNotFound) {
+			return nil, apperrors.NewNotFoundError("API key not found")
+		}
+		return nil, fmt.Errorf("error revoking api key: %w", err)
+	}
+
+	return &domain.APIKeyResponse{
+		Success: true,
+		Message: "API key revoked successfully",
+	}, nil
+}
+
+func (uc *apiKeyUsecase) Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, error) {
+	key, err := uc.keyRepo.FindActiveByHash(ctx, utils.HashToken(rawKey))
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			return nil, apperrors.NewUnauthorizedError("Invalid API key")
+		}
+		return nil, err
+	}
+
+	// Keys stop working as soon as the owning company is removed or suspended
+	company, err := uc.userRepo.FindByID(ctx, key.CompanyID)
+	if err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewUnauthorizedError("Invalid API key")
+		}
+		return nil, err
+	}
+	if company.IsSuspended() {
+		return nil, errAccountSuspended()
+	}
+
+	now := time.Now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyTouchInterval {
+		// Usage tracking is best effort and must not fail the request
+		_ = uc.keyRepo.TouchLastUsed(ctx, key.ID, now)
+	}
+
+	return key, nil
+}
+
+// randomHex returns n random bytes encoded as hex
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// APIUsageUsecase tracks the requests made with company API keys and reports
+// them with the webhook deliveries of the company's events
+type APIUsageUsecase interface {
+	// RecordRequest counts a request made with the key and answered with
+	// status. windowRequests is the number of requests counted in the key's
+	// rate limit window. Counts are kept in memory until the next Flush.
+	RecordRequest(key *domain.APIKey, status, windowRequests int)
+	// Flush stores the counts recorded since the last flush
+	Flush(ctx context.Context) error
+	// GetUsage returns the company's usage dashboard over the last days
+	GetUsage(ctx context.Context, companyID string, days int) (*domain.APIUsageResponse, error)
+}
+
+type apiUsageUsecase struct {
+	usageRepo repository.APIUsageRepository
+	keyRepo   repository.APIKeyRepository
+	eventRepo repository.DomainEventRepository
+
+	mu      sync.Mutex
+	pending map[apiUsageBucket]*domain.APIUsage
+}
+
+// apiUsageBucket identifies the counts of a key during an hour
+type apiUsageBucket struct {
+	keyID string
+	hour  time.Time
+}
+
+func NewAPIUsageUsecase(usageRepo repository.APIUsageRepository, keyRepo repository.APIKeyRepository, eventRepo repository.DomainEventRepository) APIUsageUsecase {
+	return &apiUsageUsecase{
+		usageRepo: usageRepo,
+		keyRepo:   keyRepo,
+		eventRepo: eventRepo,
+		pending:   make(map[apiUsageBucket]*domain.APIUsage),
+	}
+}
+
+func (uc *apiUsageUsecase) RecordRequest(key *domain.APIKey, status, windowRequests int) {
+	bucket := apiUsageBucket{keyID: key.ID.Hex(), hour: time.Now().UTC().Truncate(time.Hour)}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	usage, ok := uc.pending[bucket]
+	if !ok {
+		usage = &domain.APIUsage{CompanyID: key.CompanyID, KeyID: bucket.keyID, Hour: bucket.hour}
+		uc.pending[bucket] = usage
+	}
+	usage.Add(status, windowRequests)
+}
+
+func (uc *apiUsageUsecase) Flush(ctx context.Context) error {
+	uc.mu.Lock()
+	pending := uc.pending
+	uc.pending = make(map[apiUsageBucket]*domain.APIUsage)
+	uc.mu.Unlock()
+
+	usage := make([]*domain.APIUsage, 0, len(pending))
+	for _, u := range pending {
+		usage = append(usage, u)
+	}
+	if err := uc.usageRepo.Add(ctx, usage); err != nil {
+		// Keep the counts for the next flush
+		uc.mu.Lock()
+		for bucket, u := range pending {
+			if current, ok := uc.pending[bucket]; ok {
+				u.Requests += current.Requests
+				u.Errors += current.Errors
+				u.RateLimited += current.RateLimited
+				if current.PeakWindowRequests > u.PeakWindowRequests {
+					u.PeakWindowRequests = current.PeakWindowRequests
+				}
+			}
+			uc.pending[bucket] = u
+		}
+		uc.mu.Unlock()
+		return fmt.Errorf("error storing API usage: %w", err)
+	}
+	return nil
+}
+
+func (uc *apiUsageUsecase) GetUsage(ctx context.Context, companyID string, days int) (*domain.APIUsageResponse, error) {
+	maxDays := int(domain.APIUsageRetention / (24 * time.Hour))
+	if days < 1 || days > maxDays {
+		return nil, apperrors.NewBadRequestError("Validation failed", []string{fmt.Sprintf("days must be between 1 and %d", maxDays)})
+	}
+
+	until := time.Now().UTC()
+	since := until.Truncate(time.Hour).Add(-time.Duration(days) * 24 * time.Hour)
+
+	keys, err := uc.keyRepo.ListByCompany(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing API keys: %w", err)
+	}
+	usage, err := uc.usageRepo.ListByCompany(ctx, companyID, since)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving API usage: %w", err)
+	}
+	webhooks, err := uc.webhookStats(ctx, companyID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.APIUsageReport{
+		Since:    since,
+		Until:    until,
+		Keys:     make([]*domain.APIKeyUsage, 0, len(keys)),
+		Webhooks: webhooks,
+	}
+	byKey := make(map[string]*domain.APIKeyUsage, len(keys))
+	for _, key := range keys {
+		keyUsage := &domain.APIKeyUsage{
+			KeyID:     key.ID.Hex(),
+			Name:      key.Name,
+			Prefix:    key.Prefix,
+			RateLimit: key.RateLimit,
+			RevokedAt: key.RevokedAt,
+			Hours:     []*domain.APIUsage{},
+		}
+		report.Keys = append(report.Keys, keyUsage)
+		byKey[keyUsage.KeyID] = keyUsage
+	}
+
+	for _, hour := range usage {
+		keyUsage, ok := byKey[hour.KeyID]
+		if !ok {
+			// The key was deleted with its company's data
+			continue
+		}
+		keyUsage.Hours = append(keyUsage.Hours, hour)
+		keyUsage.Requests += hour.Requests
+		keyUsage.Errors += hour.Errors
+		keyUsage.RateLimited += hour.RateLimited
+		if keyUsage.RateLimit > 0 {
+			if share := float64(hour.PeakWindowRequests) / float64(keyUsage.RateLimit); share > keyUsage.PeakRateLimitUsage {
+				keyUsage.PeakRateLimitUsage = share
+			}
+		}
+
+		report.Totals.Requests += hour.Requests
+		report.Totals.Errors += hour.Errors
+		report.Totals.RateLimited += hour.RateLimited
+	}
+
+	return &domain.APIUsageResponse{
+		Success: true,
+		Message: "Successfully retrieved API usage",
+		Data:    report,
+	}, nil
+}
+
+// webhookStats counts the webhook deliveries of the events about the company since the time
+func (uc *apiUsageUsecase) webhookStats(ctx context.Context, companyID string, since time.Time) (domain.WebhookDeliveryStats, error) {
+	counts, err := uc.eventRepo.CountByStatus(ctx, domain.DomainEventFilter{UserID: companyID, Since: &since})
+	if err != nil {
+		return domain.WebhookDeliveryStats{}, fmt.Errorf("error counting webhook deliveries: %w", err)
+	}
+
+	stats := domain.WebhookDeliveryStats{
+		Delivered: counts[domain.EventStatusDelivered],
+		Failed:    counts[domain.EventStatusFailed],
+		Pending:   counts[domain.EventStatusPending],
+	}
+	if done := stats.Delivered + stats.Failed; done > 0 {
+		rate := float64(stats.Delivered) / float64(done)
+		stats.SuccessRate = &rate
+	}
+	return stats, nil
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// ApplicationNoteUsecase manages the hiring team's internal notes on
+// applications. Teammates mentioned in a note are notified and find it in
+// their activity feed.
+type ApplicationNoteUsecase interface {
+	// AddNote leaves a note on an application of the user's company. Every
+	// mentioned email must belong to someone on the company's team.
+	AddNote(ctx context.Context, applicationID, userID string, req *domain.CreateApplicationNoteRequest) (*domain.ApplicationNoteResponse, error)
+	// ListNotes returns the application's notes, oldest first
+	ListNotes(ctx context.Context, applicationID, userID string) (*domain.ApplicationNoteResponse, error)
+}
+
+type applicationNoteUsecase struct {
+	noteRepo     repository.ApplicationNoteRepository
+	activityRepo repository.ActivityRepository
+	appRepo      repository.ApplicationRepository
+	stream       applicationStream
+	jobRepo      repository.JobRepository
+	userRepo     repository.UserRepository
+	memberRepo   repository.CompanyMemberRepository
+	notifier     NotificationUsecase
+	frontendURL  string
+}
+
+func NewApplicationNoteUsecase(noteRepo repository.ApplicationNoteRepository, activityRepo repository.ActivityRepository, appRepo repository.ApplicationRepository, eventRepo repository.ApplicationEventRepository, jobRepo repository.JobRepository, userRepo repository.UserRepository, memberRepo repository.CompanyMemberRepository, notifier NotificationUsecase, frontendURL string) ApplicationNoteUsecase {
+	return &applicationNoteUsecase{
+		noteRepo:     noteRepo,
+		activityRepo: activityRepo,
+		appRepo:      appRepo,
+		stream:       applicationStream{eventRepo: eventRepo, appRepo: appRepo},
+		jobRepo:      jobRepo,
+		userRepo:     userRepo,
+		memberRepo:   memberRepo,
+		notifier:     notifier,
+		frontendURL:  frontendURL,
+	}
+}
+
+func (uc *applicationNoteUsecase) AddNote(ctx context.Context, applicationID, userID string, req *domain.CreateApplicationNoteRequest) (*domain.ApplicationNoteResponse, error) {
+	application, job, companyID, err := getOwnedApplication(ctx, uc.appRepo, uc.jobRepo, uc.memberRepo, applicationID, userID,
+		"You don't have permission to access this application's notes")
+	if err != nil {
+		return nil, err
+	}
+
+	emails := domain.ParseMentions(req.Body)
+	if len(emails) > domain.MaxNoteMentions {
+		return nil, apperrors.NewBadRequestError("Validation failed",
+			[]string{fmt.Sprintf("A note can mention at most %d teammates", domain.MaxNoteMentions)})
+	}
+	mentioned, err := uc.resolveMentions(ctx, companyID, emails)
+	if err != nil {
+		return nil, err
+	}
+
+	note := &domain.ApplicationNote{
+		ApplicationID: applicationID,
+		JobID:         job.ID.Hex(),
+		CompanyID:     companyID,
+		AuthorID:      userID,
+		Body:          req.Body,
+	}
+	// Mentioning yourself doesn't notify anyone
+	for _, member := range mentioned {
+		if member.ID.Hex() != userID {
+			note.Mentions = append(note.Mentions, member.ID.Hex())
+		}
+	}
+	if err := uc.noteRepo.Create(ctx, note); err != nil {
+		return nil, fmt.Errorf("error saving note: %w", err)
+	}
+
+	// The note itself stays private to the team, the stream records that it was left
+	aggregate, err := uc.stream.load(ctx, application)
+	if err == nil {
+		added := aggregate.Record(&domain.ApplicationEvent{
+			Type:    domain.ApplicationNoteAdded,
+			ActorID: userID,
+			NoteID:  note.ID.Hex(),
+		})
+		err = uc.stream.commit(ctx, aggregate, added)
+	}
+	if err != nil {
+		log.Printf("Failed to record note %s on application %s: %v", note.ID.Hex(), applicationID, err)
+	}
+
+	uc.notifyMentions(ctx, note, job)
+
+	return &domain.ApplicationNoteResponse{
+		Success: true,
+		Message: "Note added successfully",
+		Data:    note,
+	}, nil
+}
+
+func (uc *applicationNoteUsecase) ListNotes(ctx context.Context, applicationID, userID string) (*domain.ApplicationNoteResponse, error) {
+	if _, _, _, err := getOwnedApplication(ctx, uc.appRepo, uc.jobRepo, uc.memberRepo, applicationID, userID,
+		"You don't have permission to access this application's notes"); err != nil {
+		return nil, err
+	}
+
+	notes, err := uc.noteRepo.ListByApplication(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing notes: %w", err)
+	}
+
+	return &domain.ApplicationNoteResponse{
+		Success: true,
+		Message: "Successfully retrieved notes",
+		Data:    notes,
+	}, nil
+}
+
+// resolveMentions maps mentioned emails to the company's team: the company
+// account and its members. Emails of anyone else are refused.
+func (uc *applicationNoteUsecase) resolveMentions(ctx context.Context, companyID string, emails []string) ([]*domain.User, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	teamIDs := []string{companyID}
+	members, err := uc.memberRepo.ListByCompany(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing team members: %w", err)
+	}
+	for _, member := range members {
+		teamIDs = append(teamIDs, member.UserID)
+	}
+
+	team := make(map[string]*domain.User, len(teamIDs))
+	for _, id := range teamIDs {
+		user, err := uc.userRepo.FindByID(ctx, id)
+		if err != nil {
+			continue // Skip members whose account no longer exists
+		}
+		if !user.IsDeleted() {
+			team[strings.ToLower(user.Email)] = user
+		}
+	}
+
+	var (
+		mentioned []*domain.User
+		unknown   []string
+	)
+	for _, email := range emails {
+		if user, ok := team[email]; ok {
+			mentioned = append(mentioned, user)
+		} else {
+			unknown = append(unknown, fmt.Sprintf("%s is not on your hiring team", email))
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, apperrors.NewBadRequestError("Validation failed", unknown)
+	}
+	return mentioned, nil
+}
+
+// notifyMentions adds the note to the mentioned teammates' activity feeds and
+// notifies them. Failures are logged, the note is saved either way.
+func (uc *applicationNoteUsecase) notifyMentions(ctx context.Context, note *domain.ApplicationNote, job *domain.Job) {
+	if len(note.Mentions) == 0 {
+		return
+	}
+
+	authorName := "A teammate"
+	if author, err := uc.userRepo.FindByID(ctx, note.AuthorID); err == nil {
+		authorName = author.Name
+	}
+	excerpt := domain.NoteExcerpt(note.Body)
+
+	activities := make([]*domain.Activity, len(note.Mentions))
+	for i, userID := range note.Mentions {
+		activities[i] = &domain.Activity{
+			UserID:        userID,
+			Kind:          domain.ActivityMention,
+			ActorID:       note.AuthorID,
+			ActorName:     authorName,
+			ApplicationID: note.ApplicationID,
+			JobID:         note.JobID,
+			JobTitle:      job.Title,
+			NoteID:        note.ID.Hex(),
+			Excerpt:       excerpt,
+		}
+	}
+	if err := uc.activityRepo.CreateMany(ctx, activities); err != nil {
+		log.Printf("Failed to record mentions of note %s: %v", note.ID.Hex(), err)
+	}
+
+	for _, userID := range note.Mentions {
+		err := uc.notifier.Notify(ctx, userID, domain.NotificationMention,
+			fmt.Sprintf("%s mentioned you on an application for %s", authorName, job.Title),
+			fmt.Sprintf("%s mentioned you in a note on an application for \"%s\":\n\n%s\n\nSee the application here: %s/applications/%s",
+				authorName, job.Title, excerpt, uc.frontendURL, note.ApplicationID))
+		if err != nil {
+			log.Printf("Failed to notify %s of a mention in note %s: %v", userID, note.ID.Hex(), err)
+		}
+	}
+}
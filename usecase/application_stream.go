@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// applicationStream derives applications from their event streams. Changes
+// are appended as events and then projected into the applications
+// collection, which the queries and reports read.
+type applicationStream struct {
+	eventRepo repository.ApplicationEventRepository
+	appRepo   repository.ApplicationRepository
+}
+
+// events returns the application's events, oldest first. The events of an
+// application submitted before events were recorded are backfilled from its
+// status history first.
+func (s applicationStream) events(ctx context.Context, app *domain.Application) ([]*domain.ApplicationEvent, error) {
+	events, err := s.eventRepo.ListByApplication(ctx, app.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting application events: %w", err)
+	}
+	if len(events) > 0 {
+		return events, nil
+	}
+
+	events = domain.BackfillApplicationEvents(app)
+	if err := s.eventRepo.Append(ctx, events...); err != nil {
+		if !errors.Is(err, domain.ErrApplicationVersionConflict) {
+			return nil, fmt.Errorf("error backfilling application events: %w", err)
+		}
+		// Backfilled by a concurrent request
+		if events, err = s.eventRepo.ListByApplication(ctx, app.ID); err != nil {
+			return nil, fmt.Errorf("error getting application events: %w", err)
+		}
+	}
+	return events, nil
+}
+
+// load replays the application's events
+func (s applicationStream) load(ctx context.Context, app *domain.Application) (*domain.ApplicationAggregate, error) {
+	events, err := s.events(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	return domain.ReplayApplication(events), nil
+}
+
+// commit appends the events recorded on the aggregate and projects its new state
+func (s applicationStream) commit(ctx context.Context, aggregate *domain.ApplicationAggregate, events ...*domain.ApplicationEvent) error {
+	if err := s.eventRepo.Append(ctx, events...); err != nil {
+		if errors.Is(err, domain.ErrApplicationVersionConflict) {
+			return apperrors.NewConflictError("The application was changed at the same time, please try again")
+		}
+		return fmt.Errorf("error appending application events: %w", err)
+	}
+	if err := s.appRepo.Project(ctx, aggregate); err != nil {
+		return fmt.Errorf("error projecting application: %w", err)
+	}
+	return nil
+}
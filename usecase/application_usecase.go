@@ -2,57 +2,131 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/pkg/storage"
 	"job-portal-backend/repository"
 )
 
 type ApplicationUseCase interface {
+	// ApplyForJob applies with the uploaded resume at resumeLink, or with the
+	// library resume req.ResumeID when resumeLink is empty
 	ApplyForJob(ctx context.Context, req *domain.ApplyRequest, applicantID string, resumeLink string) (*domain.ApplicationResponse, error)
+	GetApplication(ctx context.Context, applicationID, userID, role string) (*domain.ApplicationResponse, error)
 	GetMyApplications(ctx context.Context, applicantID string, page, limit int) (*domain.ApplicationListResponse, error)
 	GetJobApplications(ctx context.Context, jobID, companyID string, page, limit int) (*domain.ApplicationListResponse, error)
 	UpdateApplicationStatus(ctx context.Context, applicationID, companyID string, req *domain.UpdateApplicationStatusRequest) (*domain.ApplicationResponse, error)
+	GetAllowedTransitions(ctx context.Context, applicationID, companyID string) (*domain.ApplicationResponse, error)
+	GetStatusGraph(ctx context.Context) (*domain.ApplicationResponse, error)
+	// UpdateStatusTransitions stores an admin's transition graph, which replaces
+	// the configured one until it is reset
+	UpdateStatusTransitions(ctx context.Context, req *domain.UpdateStatusTransitionsRequest, adminID string) (*domain.ApplicationResponse, error)
+	// ResetStatusTransitions deletes the stored graph, going back to the configured one
+	ResetStatusTransitions(ctx context.Context) (*domain.ApplicationResponse, error)
+	// ReloadStatusTransitions picks up the graph stored by another instance
+	ReloadStatusTransitions(ctx context.Context) error
+	// WithdrawApplication lets the applicant pull out of an application that
+	// is still in progress. It is rejected with domain.RejectionCandidateWithdrew.
+	WithdrawApplication(ctx context.Context, applicationID, applicantID string) (*domain.ApplicationResponse, error)
+	// GetApplicationEvents returns the full history of an application of the
+	// user's company, oldest first
+	GetApplicationEvents(ctx context.Context, applicationID, userID string) (*domain.ApplicationResponse, error)
 }
 
 type applicationUseCase struct {
-	appRepo  repository.ApplicationRepository
-	jobRepo  repository.JobRepository
-	userRepo repository.UserRepository
+	appRepo        repository.ApplicationRepository
+	stream         applicationStream
+	jobRepo        repository.JobRepository
+	userRepo       repository.UserRepository
+	profileRepo    repository.ApplicantProfileRepository
+	alertPrefsRepo repository.AlertPreferencesRepository
+	slaRepo        repository.SLAPolicyRepository
+	resumeRepo     repository.ResumeRepository
+	memberRepo     repository.CompanyMemberRepository
+	notifier       NotificationUsecase
+	// configured is the transition graph of the configuration, used while
+	// no override is stored
+	configured      *domain.StatusMachine
+	statuses        atomic.Pointer[domain.StatusMachine]
+	transitionsRepo repository.StatusTransitionsRepository
+	frontendURL     string
 }
 
-func NewApplicationUseCase(appRepo repository.ApplicationRepository, jobRepo repository.JobRepository, userRepo repository.UserRepository) ApplicationUseCase {
-	return &applicationUseCase{
-		appRepo:  appRepo,
-		jobRepo:  jobRepo,
-		userRepo: userRepo,
+func NewApplicationUseCase(appRepo repository.ApplicationRepository, eventRepo repository.ApplicationEventRepository, jobRepo repository.JobRepository, userRepo repository.UserRepository, profileRepo repository.ApplicantProfileRepository, alertPrefsRepo repository.AlertPreferencesRepository, slaRepo repository.SLAPolicyRepository, resumeRepo repository.ResumeRepository, memberRepo repository.CompanyMemberRepository, notifier NotificationUsecase, statuses *domain.StatusMachine, transitionsRepo repository.StatusTransitionsRepository, frontendURL string) ApplicationUseCase {
+	uc := &applicationUseCase{
+		appRepo:         appRepo,
+		stream:          applicationStream{eventRepo: eventRepo, appRepo: appRepo},
+		jobRepo:         jobRepo,
+		userRepo:        userRepo,
+		profileRepo:     profileRepo,
+		alertPrefsRepo:  alertPrefsRepo,
+		slaRepo:         slaRepo,
+		resumeRepo:      resumeRepo,
+		memberRepo:      memberRepo,
+		notifier:        notifier,
+		configured:      statuses,
+		transitionsRepo: transitionsRepo,
+		frontendURL:     frontendURL,
 	}
+	uc.statuses.Store(statuses)
+	return uc
 }
 
 func (uc *applicationUseCase) ApplyForJob(ctx context.Context, req *domain.ApplyRequest, applicantID string, resumeLink string) (*domain.ApplicationResponse, error) {
-	// Check if job exists
+	// Check if job exists and still takes applications
 	job, err := uc.jobRepo.GetJobByID(ctx, req.JobID)
 	if err != nil {
-		if err.Error() == "job not found" {
-			return &domain.ApplicationResponse{
-				Success: false,
-				Message: "Job not found",
-			}, nil
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, apperrors.NewNotFoundError("Job not found")
 		}
-		return nil, fmt.Errorf("error checking job: %v", err)
+		return nil, fmt.Errorf("error checking job: %w", err)
+	}
+	// Checked first, jobs are unpublished once their deadline passes or
+	// their cap is reached
+	now := time.Now()
+	if job.PastDeadline(now) && !job.IsClosed() {
+		return nil, apperrors.NewConflictError("The application deadline for this job has passed")
+	}
+	if job.ApplicationsCapped() && !job.IsClosed() {
+		return nil, errJobFull()
+	}
+	// Unpublished and expired jobs are hidden from applicants, so they can't be applied to either
+	if (!job.IsPublished || job.Expired(now)) && !job.IsClosed() {
+		return nil, apperrors.NewNotFoundError("Job not found")
+	}
+	if job.IsClosed() {
+		return nil, errJobClosed()
+	}
+	if job.ApplyURL != "" {
+		return nil, apperrors.NewConflictError("This job takes applications on the company's website, follow its apply link")
 	}
 
 	// Check if user has already applied
 	existingApp, err := uc.appRepo.GetApplicationByApplicantAndJob(ctx, applicantID, req.JobID)
 	if err != nil {
-		return nil, fmt.Errorf("error checking existing application: %v", err)
+		return nil, fmt.Errorf("error checking existing application: %w", err)
 	}
 	if existingApp != nil {
-		return &domain.ApplicationResponse{
-			Success: false,
-			Message: "You have already applied for this job",
-		}, nil
+		return nil, apperrors.NewConflictError("You have already applied for this job")
+	}
+
+	// Resolve a resume picked from the applicant's library
+	if resumeLink == "" {
+		resume, err := uc.resumeRepo.GetByID(ctx, req.ResumeID, applicantID)
+		if err != nil {
+			if isNotFound(err, domain.ErrResumeNotFound) {
+				return nil, apperrors.NewNotFoundError("Resume not found")
+			}
+			return nil, fmt.Errorf("error getting resume: %w", err)
+		}
+		resumeLink = resume.URL
 	}
 
 	// Create new application
@@ -63,14 +137,56 @@ func (uc *applicationUseCase) ApplyForJob(ctx context.Context, req *domain.Apply
 		ResumeLink:  resumeLink,
 		CoverLetter: req.CoverLetter,
 		Status:      domain.StatusApplied,
+		// The resume is uploaded in the background when the storage provider was down
+		ResumePending: storage.IsPending(resumeLink),
+		Country:       domain.ClientInfoFromContext(ctx).Country,
 	}
 
+	// The slot is taken before the application is stored, so concurrent
+	// applicants can't go over the job's cap
+	reserved, err := uc.jobRepo.ReserveApplication(ctx, jobObjID)
+	if err != nil {
+		if errors.Is(err, domain.ErrJobFull) {
+			return nil, errJobFull()
+		}
+		return nil, fmt.Errorf("error reserving application: %w", err)
+	}
 	if err := uc.appRepo.CreateApplication(ctx, application); err != nil {
-		return nil, fmt.Errorf("error creating application: %v", err)
+		if releaseErr := uc.jobRepo.ReleaseApplication(ctx, jobObjID); releaseErr != nil {
+			log.Printf("Failed to release application slot of job %s: %v", req.JobID, releaseErr)
+		}
+		return nil, fmt.Errorf("error creating application: %w", err)
+	}
+	// The application taking the last slot closes the job
+	if reserved.ApplicationsFull() {
+		if err := uc.jobRepo.CapApplications(ctx, jobObjID, time.Now()); err != nil {
+			log.Printf("Failed to close job %s at its application cap: %v", req.JobID, err)
+		}
+	}
+	// Starts the application's stream. Should it fail, the stream is
+	// backfilled from the application when it is next loaded.
+	aggregate := &domain.ApplicationAggregate{ID: application.ID}
+	applied := aggregate.Record(&domain.ApplicationEvent{
+		Type:        domain.ApplicationApplied,
+		ActorID:     applicantID,
+		At:          application.AppliedAt,
+		ApplicantID: applicantID,
+		JobID:       application.JobID,
+	})
+	if err := uc.stream.commit(ctx, aggregate, applied); err != nil {
+		log.Printf("Failed to record application %s: %v", application.ID.Hex(), err)
+	} else {
+		application.EventVersion = aggregate.Version
 	}
 
-	// Get job details for response
-	job, _ = uc.jobRepo.GetJobByID(ctx, req.JobID)
+	// The applicant is not named, the job may screen applications blind
+	err = uc.notifier.Notify(ctx, job.CreatedBy, domain.NotificationNewApplicant,
+		fmt.Sprintf("New application for %s", job.Title),
+		fmt.Sprintf("Someone applied to your job posting \"%s\". Review the application here: %s/applications/%s",
+			job.Title, uc.frontendURL, application.ID.Hex()))
+	if err != nil {
+		log.Printf("Failed to notify company %s of application %s: %v", job.CreatedBy, application.ID.Hex(), err)
+	}
 
 	return &domain.ApplicationResponse{
 		Success: true,
@@ -79,6 +195,39 @@ func (uc *applicationUseCase) ApplyForJob(ctx context.Context, req *domain.Apply
 	}, nil
 }
 
+func (uc *applicationUseCase) GetApplication(ctx context.Context, applicationID, userID, role string) (*domain.ApplicationResponse, error) {
+	application, err := uc.getApplication(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch domain.Role(role) {
+	case domain.Applicant:
+		if application.ApplicantID != userID {
+			return nil, errForbidden("You don't have permission to view this application")
+		}
+	case domain.Company:
+		// Companies may only see applications to their own jobs
+		job, err := uc.getOwnedJob(ctx, application.JobID.Hex(), userID, "You don't have permission to view this application")
+		if err != nil {
+			return nil, err
+		}
+		if job.BlindsApplication(application.Status) {
+			application = application.Blinded()
+		}
+	case domain.Auditor:
+		// Auditors have read access to every application
+	default:
+		return nil, errForbidden("You don't have permission to view this application")
+	}
+
+	return &domain.ApplicationResponse{
+		Success: true,
+		Message: "Successfully retrieved application",
+		Data:    application,
+	}, nil
+}
+
 func (uc *applicationUseCase) GetMyApplications(ctx context.Context, applicantID string, page, limit int) (*domain.ApplicationListResponse, error) {
 	// Validate pagination parameters
 	if page < 1 {
@@ -91,7 +240,7 @@ func (uc *applicationUseCase) GetMyApplications(ctx context.Context, applicantID
 	// Get applications for the applicant
 	applications, total, err := uc.appRepo.GetApplicationsByApplicant(ctx, applicantID, page, limit)
 	if err != nil {
-		return nil, fmt.Errorf("error getting applications: %v", err)
+		return nil, fmt.Errorf("error getting applications: %w", err)
 	}
 
 	// Prepare response data
@@ -149,55 +298,73 @@ func (uc *applicationUseCase) GetJobApplications(ctx context.Context, jobID, com
 	}
 
 	// Check if job exists and is owned by the company
-	job, err := uc.jobRepo.GetJobByID(ctx, jobID)
+	job, err := uc.getOwnedJob(ctx, jobID, companyID, "You don't have permission to view applications for this job")
 	if err != nil {
-		if err.Error() == "job not found" {
-			return &domain.ApplicationListResponse{
-				Success: false,
-				Message: "Job not found",
-			}, nil
-		}
-		return nil, fmt.Errorf("error checking job: %v", err)
-	}
-
-	// Verify job ownership
-	if job.CreatedBy != companyID {
-		return &domain.ApplicationListResponse{
-			Success: false,
-			Message: "Forbidden",
-			Errors:  []string{"You don't have permission to view applications for this job"},
-		}, nil
+		return nil, err
 	}
 
 	// Get applications for the job
 	applications, total, err := uc.appRepo.GetJobApplications(ctx, jobID, page, limit)
 	if err != nil {
-		return nil, fmt.Errorf("error getting job applications: %v", err)
+		return nil, fmt.Errorf("error getting job applications: %w", err)
+	}
+
+	// Structured profiles are shown next to the resume
+	applicantIDs := make([]string, 0, len(applications))
+	for _, app := range applications {
+		applicantIDs = append(applicantIDs, app.ApplicantID)
+	}
+	profiles, err := uc.profileRepo.GetByUserIDs(ctx, applicantIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error getting applicant profiles: %w", err)
+	}
+
+	// Timers against the company's response time targets, if it set any
+	policy, err := uc.slaRepo.GetByCompanyID(ctx, job.CreatedBy)
+	if err != nil && !errors.Is(err, domain.ErrSLAPolicyNotFound) {
+		return nil, fmt.Errorf("error getting sla policy: %w", err)
 	}
+	now := time.Now()
 
 	// Prepare response data
 	var appResponses []map[string]interface{}
 	for _, app := range applications {
-		// Get applicant details
+		// Get applicant details, as far as their privacy settings and blind screening allow
+		blind := job.BlindsApplication(app.Status)
+		summary := &domain.ApplicantSummary{ID: app.ApplicantID}
 		applicant, err := uc.userRepo.FindByID(ctx, app.ApplicantID)
-		applicantName := ""
-		applicantEmail := ""
 		if err == nil && applicant != nil {
-			applicantName = applicant.Name
-			applicantEmail = applicant.Email
+			summary = applicant.SummaryForCompany(app.Status, blind)
+		}
+
+		profile := profiles[app.ApplicantID]
+		resumeLink, thumbnailURL := app.ResumeLink, app.ResumeThumbnailURL
+		if blind {
+			if profile != nil {
+				profile = profile.Blinded()
+			}
+			resumeLink, thumbnailURL = "", ""
 		}
 
 		appResponse := map[string]interface{}{
-			"id":             app.ID.Hex(),
-			"job_id":         jobID,
-			"job_title":      job.Title,
-			"applicant_id":   app.ApplicantID,
-			"applicant_name": applicantName,
-			"email":          applicantEmail,
-			"status":         app.Status,
-			"applied_at":     app.AppliedAt,
-			"resume_link":    app.ResumeLink,
-			"cover_letter":   app.CoverLetter,
+			"id":                   app.ID.Hex(),
+			"job_id":               jobID,
+			"job_title":            job.Title,
+			"applicant_id":         app.ApplicantID,
+			"applicant_name":       summary.Name,
+			"email":                summary.Email,
+			"phone":                summary.Phone,
+			"contact_hidden":       summary.ContactHidden,
+			"blinded":              summary.Blinded,
+			"status":               app.Status,
+			"applied_at":           app.AppliedAt,
+			"resume_link":          resumeLink,
+			"resume_thumbnail_url": thumbnailURL,
+			"cover_letter":         app.CoverLetter,
+			"profile":              profile,
+		}
+		if policy != nil {
+			appResponse["sla"] = policy.Timer(app, now)
 		}
 		appResponses = append(appResponses, appResponse)
 	}
@@ -222,68 +389,68 @@ func (uc *applicationUseCase) GetJobApplications(ctx context.Context, jobID, com
 func (uc *applicationUseCase) UpdateApplicationStatus(ctx context.Context, applicationID, companyID string, req *domain.UpdateApplicationStatusRequest) (*domain.ApplicationResponse, error) {
 	// Validate the request
 	if req.Status == "" {
-		return &domain.ApplicationResponse{
-			Success: false,
-			Message: "Validation failed",
-			Errors:  []string{"Status is required"},
-		}, nil
+		return nil, apperrors.NewBadRequestError("Validation failed", []string{"Status is required"})
+	}
+	if req.RejectionReason != "" && req.Status != domain.StatusRejected {
+		return nil, apperrors.NewBadRequestError("Validation failed", []string{"A rejection reason can only be given with the Rejected status"})
 	}
 
 	// Check if the application exists
-	application, err := uc.appRepo.GetApplicationByID(ctx, applicationID)
+	application, err := uc.getApplication(ctx, applicationID)
 	if err != nil {
-		if err.Error() == "invalid application ID" || err.Error() == "mongo: no documents in result" {
-			return &domain.ApplicationResponse{
-				Success: false,
-				Message: "Application not found",
-			}, nil
-		}
-		return nil, fmt.Errorf("error getting application: %v", err)
+		return nil, err
 	}
 
 	// Check if the job exists and is owned by the company
-	job, err := uc.jobRepo.GetJobByID(ctx, application.JobID.Hex())
+	job, err := uc.getOwnedJob(ctx, application.JobID.Hex(), companyID, "You don't have permission to update this application")
 	if err != nil {
-		if err.Error() == "job not found" {
-			return &domain.ApplicationResponse{
-				Success: false,
-				Message: "Job not found",
-			}, nil
-		}
-		return nil, fmt.Errorf("error checking job: %v", err)
+		return nil, err
 	}
 
-	// Verify job ownership
-	if job.CreatedBy != companyID {
-		return &domain.ApplicationResponse{
-			Success: false,
-			Message: "Forbidden",
-			Errors:  []string{"You don't have permission to update this application"},
-		}, nil
+	// The current status is the one derived from the application's events
+	aggregate, err := uc.stream.load(ctx, application)
+	if err != nil {
+		return nil, err
 	}
+	previous := aggregate.Status
 
 	// Validate status transition
-	if !isValidStatusTransition(application.Status, domain.ApplicationStatus(req.Status)) {
-		return &domain.ApplicationResponse{
-			Success: false,
-			Message: "Invalid status transition",
-			Errors:  []string{fmt.Sprintf("Cannot change status from %s to %s", application.Status, req.Status)},
-		}, nil
+	if !uc.statuses.Load().CanTransition(previous, req.Status) {
+		return nil, apperrors.NewConflictError("Invalid status transition").WithDetails(
+			[]string{fmt.Sprintf("Cannot change status from %s to %s", previous, req.Status)})
 	}
 
 	// Update the application status
-	err = uc.appRepo.UpdateApplicationStatus(ctx, applicationID, domain.ApplicationStatus(req.Status))
-	if err != nil {
-		return nil, fmt.Errorf("error updating application status: %v", err)
+	changed := aggregate.Record(&domain.ApplicationEvent{
+		Type:            domain.ApplicationStatusChanged,
+		ActorID:         companyID,
+		Status:          req.Status,
+		RejectionReason: req.RejectionReason,
+	})
+	if err := uc.stream.commit(ctx, aggregate, changed); err != nil {
+		return nil, err
 	}
 
-	// In a real application, you might want to send notifications here
-	// e.g., email to the applicant about the status update
+	err = uc.notifier.Notify(ctx, application.ApplicantID, domain.NotificationStatusChange,
+		fmt.Sprintf("Your application for %s was updated", job.Title),
+		fmt.Sprintf("Your application for \"%s\" moved from %s to %s. See the details here: %s/applications/%s",
+			job.Title, previous, req.Status, uc.frontendURL, applicationID))
+	if err != nil {
+		log.Printf("Failed to notify applicant of application %s: %v", applicationID, err)
+	}
+	if req.Status == domain.StatusRejected {
+		if err := uc.sendSkillsGapReport(ctx, application.ApplicantID, job); err != nil {
+			log.Printf("Failed to send skills gap report for application %s: %v", applicationID, err)
+		}
+	}
 
 	// Get updated application
 	updatedApp, err := uc.appRepo.GetApplicationByID(ctx, applicationID)
 	if err != nil {
-		return nil, fmt.Errorf("error getting updated application: %v", err)
+		return nil, fmt.Errorf("error getting updated application: %w", err)
+	}
+	if job.BlindsApplication(updatedApp.Status) {
+		updatedApp = updatedApp.Blinded()
 	}
 
 	return &domain.ApplicationResponse{
@@ -293,27 +460,263 @@ func (uc *applicationUseCase) UpdateApplicationStatus(ctx context.Context, appli
 	}, nil
 }
 
-// isValidStatusTransition checks if the status transition is valid
-func isValidStatusTransition(currentStatus, newStatus domain.ApplicationStatus) bool {
-	switch currentStatus {
-	case domain.StatusApplied:
-		// Can transition to any status
-		return newStatus == domain.StatusReviewed || 
-		       newStatus == domain.StatusInterview || 
-	       newStatus == domain.StatusRejected || 
-	       newStatus == domain.StatusHired
-	case domain.StatusReviewed:
-		// Can transition to interview, rejected, or hired
-		return newStatus == domain.StatusInterview || 
-	       newStatus == domain.StatusRejected || 
-	       newStatus == domain.StatusHired
-	case domain.StatusInterview:
-		// Can transition to hired or rejected
-		return newStatus == domain.StatusHired || newStatus == domain.StatusRejected
-	case domain.StatusHired, domain.StatusRejected:
-		// Final states, no further transitions allowed
-		return false
-	default:
-		return false
+// sendSkillsGapReport tells a rejected applicant which of the job's skills
+// their profile lacks, with the open jobs ranked closest to their skills by
+// the similar jobs scoring. Applicants opt in through their notification preferences.
+func (uc *applicationUseCase) sendSkillsGapReport(ctx context.Context, applicantID string, job *domain.Job) error {
+	// The report is off by default, most rejections need no work
+	wanted, err := uc.notifier.Allows(ctx, applicantID, domain.NotificationSkillsGap)
+	if err != nil || !wanted {
+		return err
+	}
+
+	profile, err := uc.profileRepo.GetByUserID(ctx, applicantID)
+	if err != nil {
+		return fmt.Errorf("error getting applicant profile: %w", err)
+	}
+	report := domain.NewSkillsGapReport(job, profile.Skills)
+	if report == nil {
+		return nil
+	}
+
+	// The recommendations honour the applicant's excluded companies and keywords
+	prefs, err := uc.alertPrefsRepo.GetByUserID(ctx, applicantID)
+	if err != nil {
+		return fmt.Errorf("error retrieving alert preferences: %w", err)
+	}
+
+	// The rejected job itself is left out of the recommendations
+	similarTo := &domain.Job{ID: job.ID, Skills: domain.NormalizeSkills(profile.Skills), Category: job.Category, Location: job.Location}
+	report.RecommendedJobs, err = uc.jobRepo.ListSimilarJobs(ctx, similarTo, prefs.Exclusions(), domain.DefaultSimilarJobs)
+	if err != nil {
+		return fmt.Errorf("error finding matching jobs: %w", err)
+	}
+
+	return uc.notifier.Notify(ctx, applicantID, domain.NotificationSkillsGap,
+		fmt.Sprintf("Your skills compared to %s", job.Title), report.Body(uc.frontendURL))
+}
+
+func (uc *applicationUseCase) GetAllowedTransitions(ctx context.Context, applicationID, companyID string) (*domain.ApplicationResponse, error) {
+	// Check if the application exists
+	application, err := uc.getApplication(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the job exists and is owned by the company
+	if _, err := uc.getOwnedJob(ctx, application.JobID.Hex(), companyID, "You don't have permission to view this application"); err != nil {
+		return nil, err
+	}
+
+	// The transitions are offered from the status the update validates against
+	aggregate, err := uc.stream.load(ctx, application)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ApplicationResponse{
+		Success: true,
+		Message: "Successfully retrieved allowed transitions",
+		Data: domain.AllowedTransitions{
+			ApplicationID:      application.ID.Hex(),
+			CurrentStatus:      aggregate.Status,
+			AllowedTransitions: uc.statuses.Load().AllowedTransitions(aggregate.Status),
+		},
+	}, nil
+}
+
+func (uc *applicationUseCase) GetStatusGraph(ctx context.Context) (*domain.ApplicationResponse, error) {
+	return &domain.ApplicationResponse{
+		Success: true,
+		Message: "Successfully retrieved status transition graph",
+		Data:    uc.statuses.Load().Graph(),
+	}, nil
+}
+
+func (uc *applicationUseCase) UpdateStatusTransitions(ctx context.Context, req *domain.UpdateStatusTransitionsRequest, adminID string) (*domain.ApplicationResponse, error) {
+	machine, err := domain.NewStatusMachine(req.Transitions, "database")
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("Invalid status transitions", []string{err.Error()})
+	}
+
+	override := &domain.StatusTransitionsOverride{
+		Transitions: req.Transitions,
+		UpdatedBy:   adminID,
+	}
+	if err := uc.transitionsRepo.Save(ctx, override); err != nil {
+		return nil, apperrors.NewInternalServerError(err)
+	}
+	uc.statuses.Store(machine)
+
+	return &domain.ApplicationResponse{
+		Success: true,
+		Message: "Status transitions updated successfully",
+		Data:    machine.Graph(),
+	}, nil
+}
+
+func (uc *applicationUseCase) ResetStatusTransitions(ctx context.Context) (*domain.ApplicationResponse, error) {
+	if err := uc.transitionsRepo.Delete(ctx); err != nil {
+		return nil, apperrors.NewInternalServerError(err)
+	}
+	uc.statuses.Store(uc.configured)
+
+	return &domain.ApplicationResponse{
+		Success: true,
+		Message: "Status transitions reset to the configured graph",
+		Data:    uc.configured.Graph(),
+	}, nil
+}
+
+func (uc *applicationUseCase) ReloadStatusTransitions(ctx context.Context) error {
+	override, err := uc.transitionsRepo.Get(ctx)
+	if errors.Is(err, domain.ErrStatusTransitionsNotFound) {
+		uc.statuses.Store(uc.configured)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// A stored graph was validated when it was saved. Should it no longer be
+	// valid, e.g. after a status was removed, the current graph is kept.
+	machine, err := domain.NewStatusMachine(override.Transitions, "database")
+	if err != nil {
+		return fmt.Errorf("stored status transitions: %w", err)
+	}
+	uc.statuses.Store(machine)
+	return nil
+}
+
+func (uc *applicationUseCase) WithdrawApplication(ctx context.Context, applicationID, applicantID string) (*domain.ApplicationResponse, error) {
+	application, err := uc.getApplication(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if application.ApplicantID != applicantID {
+		return nil, errForbidden("You don't have permission to withdraw this application")
+	}
+
+	aggregate, err := uc.stream.load(ctx, application)
+	if err != nil {
+		return nil, err
+	}
+	// Applications whose outcome was decided can't be withdrawn
+	if len(uc.statuses.Load().AllowedTransitions(aggregate.Status)) == 0 {
+		return nil, apperrors.NewConflictError("This application can no longer be withdrawn").WithDetails(
+			[]string{fmt.Sprintf("The application is %s", aggregate.Status)})
+	}
+	withdrawn := aggregate.Record(&domain.ApplicationEvent{
+		Type:            domain.ApplicationWithdrawn,
+		ActorID:         applicantID,
+		Status:          domain.StatusRejected,
+		RejectionReason: domain.RejectionCandidateWithdrew,
+	})
+	if err := uc.stream.commit(ctx, aggregate, withdrawn); err != nil {
+		return nil, err
+	}
+
+	job, err := uc.jobRepo.GetJobByID(ctx, application.JobID.Hex())
+	if err == nil {
+		err = uc.notifier.Notify(ctx, job.CreatedBy, domain.NotificationNewApplicant,
+			fmt.Sprintf("Application withdrawn for %s", job.Title),
+			fmt.Sprintf("An applicant withdrew their application to your job posting \"%s\". See the application here: %s/applications/%s",
+				job.Title, uc.frontendURL, applicationID))
+	}
+	if err != nil {
+		log.Printf("Failed to notify company of withdrawn application %s: %v", applicationID, err)
+	}
+
+	updatedApp, err := uc.appRepo.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting updated application: %w", err)
+	}
+
+	return &domain.ApplicationResponse{
+		Success: true,
+		Message: "Application withdrawn successfully",
+		Data:    updatedApp,
+	}, nil
+}
+
+func (uc *applicationUseCase) GetApplicationEvents(ctx context.Context, applicationID, userID string) (*domain.ApplicationResponse, error) {
+	application, _, _, err := getOwnedApplication(ctx, uc.appRepo, uc.jobRepo, uc.memberRepo, applicationID, userID,
+		"You don't have permission to view this application")
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := uc.stream.events(ctx, application)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ApplicationResponse{
+		Success: true,
+		Message: "Successfully retrieved application history",
+		Data:    events,
+	}, nil
+}
+
+// getApplication loads an application, reporting a missing one as a not found error
+func (uc *applicationUseCase) getApplication(ctx context.Context, applicationID string) (*domain.Application, error) {
+	application, err := uc.appRepo.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		if isNotFound(err, domain.ErrApplicationNotFound) {
+			return nil, apperrors.NewNotFoundError("Application not found")
+		}
+		return nil, fmt.Errorf("error getting application: %w", err)
 	}
-}
\ No newline at end of file
+	return application, nil
+}
+
+// getOwnedJob loads a job and verifies it belongs to the company userID acts for.
+// forbidden is the message returned when the company doesn't own the job.
+func (uc *applicationUseCase) getOwnedJob(ctx context.Context, jobID, userID, forbidden string) (*domain.Job, error) {
+	job, err := uc.jobRepo.GetJobByID(ctx, jobID)
+	if err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, apperrors.NewNotFoundError("Job not found")
+		}
+		return nil, fmt.Errorf("error checking job: %w", err)
+	}
+
+	companyID, err := actingCompany(ctx, uc.memberRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if job.CreatedBy != companyID {
+		return nil, errForbidden(forbidden)
+	}
+	return job, nil
+}
+
+// getOwnedApplication loads an application and its job, and checks the job
+// belongs to the company userID acts for, which it returns as well.
+// forbidden is the message returned when the company doesn't own the job.
+func getOwnedApplication(ctx context.Context, appRepo repository.ApplicationRepository, jobRepo repository.JobRepository, memberRepo repository.CompanyMemberRepository, applicationID, userID, forbidden string) (*domain.Application, *domain.Job, string, error) {
+	application, err := appRepo.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		if isNotFound(err, domain.ErrApplicationNotFound) {
+			return nil, nil, "", apperrors.NewNotFoundError("Application not found")
+		}
+		return nil, nil, "", fmt.Errorf("error getting application: %w", err)
+	}
+
+	job, err := jobRepo.GetJobByID(ctx, application.JobID.Hex())
+	if err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, nil, "", apperrors.NewNotFoundError("Job not found")
+		}
+		return nil, nil, "", fmt.Errorf("error checking job: %w", err)
+	}
+
+	companyID, err := actingCompany(ctx, memberRepo, userID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if job.CreatedBy != companyID {
+		return nil, nil, "", errForbidden(forbidden)
+	}
+	return application, job, companyID, nil
+}
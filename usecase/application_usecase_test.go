@@ -0,0 +1,314 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+)
+
+// applicationFixture is a company's job with applications from applicants
+// with profiles, in fake repositories
+type applicationFixture struct {
+	uc          ApplicationUseCase
+	job         *domain.Job
+	jobs        *fakeJobRepo
+	apps        *fakeApplicationRepo
+	events      *fakeApplicationEventRepo
+	resumes     *fakeResumeRepo
+	transitions *fakeStatusTransitionsRepo
+	alertPrefs  *fakeAlertPrefsRepo
+	notifier    *fakeNotifier
+	companyID   string
+}
+
+func newApplicationFixture(tb testing.TB, applicants int) *applicationFixture {
+	tb.Helper()
+	companyID := primitive.NewObjectID().Hex()
+	job := benchmarkJobs(1, companyID)[0]
+	jobs := newFakeJobRepo(job)
+
+	users := &fakeUserRepo{users: map[string]*domain.User{}}
+	profiles := &fakeProfileRepo{profiles: map[string]*domain.ApplicantProfile{}}
+	apps := &fakeApplicationRepo{}
+	for i := 0; i < applicants; i++ {
+		id := primitive.NewObjectID()
+		users.users[id.Hex()] = &domain.User{ID: id, Name: fmt.Sprintf("Applicant %d", i), Email: fmt.Sprintf("applicant%d@example.com", i), Role: domain.Applicant}
+		profiles.profiles[id.Hex()] = &domain.ApplicantProfile{UserID: id.Hex(), Headline: "Backend developer", Skills: []string{"go", "mongodb"}}
+		apps.applications = append(apps.applications, &domain.Application{
+			ID:          primitive.NewObjectID(),
+			ApplicantID: id.Hex(),
+			JobID:       job.ID,
+			ResumeLink:  "/uploads/" + id.Hex() + ".pdf",
+			Status:      domain.StatusApplied,
+			AppliedAt:   time.Now(),
+		})
+	}
+
+	statuses, err := domain.NewStatusMachine(domain.DefaultStatusTransitions, "default")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	resumes := &fakeResumeRepo{resumes: map[string]*domain.Resume{}}
+	transitions := &fakeStatusTransitionsRepo{}
+	alertPrefs := &fakeAlertPrefsRepo{prefs: map[string]*domain.AlertPreferences{}}
+	notifier := &fakeNotifier{prefs: map[string]*domain.NotificationPreferences{}}
+	events := &fakeApplicationEventRepo{events: map[primitive.ObjectID][]*domain.ApplicationEvent{}}
+	uc := NewApplicationUseCase(apps, events, jobs, users, profiles, alertPrefs, &fakeSLAPolicyRepo{}, resumes, &fakeMemberRepo{}, notifier, statuses, transitions, "http://localhost:3000")
+
+	return &applicationFixture{uc: uc, job: job, jobs: jobs, apps: apps, events: events, resumes: resumes, transitions: transitions, alertPrefs: alertPrefs, notifier: notifier, companyID: companyID}
+}
+
+// addResume puts a resume in the applicant's library
+func (f *applicationFixture) addResume(applicantID string) string {
+	id := primitive.NewObjectID()
+	f.resumes.resumes[id.Hex()] = &domain.Resume{ID: id, UserID: applicantID, Name: "resume.pdf", URL: "/uploads/" + id.Hex() + ".pdf"}
+	return id.Hex()
+}
+
+// BenchmarkGetJobApplications measures a company reviewing a page of
+// applications: ownership check, profiles, applicant summaries and SLA timers
+func BenchmarkGetJobApplications(b *testing.B) {
+	f := newApplicationFixture(b, 500)
+	ctx := context.Background()
+	jobID := f.job.ID.Hex()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		page := i%10 + 1
+		if _, err := f.uc.GetJobApplications(ctx, jobID, f.companyID, page, 50); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkApplyForJob measures applying with a library resume, each
+// iteration is a different applicant
+func BenchmarkApplyForJob(b *testing.B) {
+	f := newApplicationFixture(b, 0)
+	ctx := context.Background()
+
+	applicants := make([]string, b.N)
+	resumeIDs := make([]string, b.N)
+	for i := range applicants {
+		applicants[i] = primitive.NewObjectID().Hex()
+		resumeIDs[i] = f.addResume(applicants[i])
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Applications pile up, keep the duplicate check's scan out of the measure
+		if len(f.apps.applications) >= 1000 {
+			b.StopTimer()
+			f.apps.applications = f.apps.applications[:0]
+			b.StartTimer()
+		}
+		req := &domain.ApplyRequest{JobID: f.job.ID.Hex(), ResumeID: resumeIDs[i], CoverLetter: "I would love to join the team."}
+		if _, err := f.uc.ApplyForJob(ctx, req, applicants[i], ""); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestApplyForJobUnknownJob(t *testing.T) {
+	f := newApplicationFixture(t, 0)
+	applicantID := primitive.NewObjectID().Hex()
+
+	for _, jobID := range []string{primitive.NewObjectID().Hex(), "not-an-id"} {
+		req := &domain.ApplyRequest{JobID: jobID, ResumeID: f.addResume(applicantID)}
+		_, err := f.uc.ApplyForJob(context.Background(), req, applicantID, "")
+		appErr, ok := apperrors.As(err)
+		if !ok || appErr.Code != http.StatusNotFound {
+			t.Errorf("ApplyForJob(%q) error = %v, want a 404", jobID, err)
+		}
+	}
+}
+
+func TestGetJobApplicationsOtherCompany(t *testing.T) {
+	f := newApplicationFixture(t, 1)
+
+	_, err := f.uc.GetJobApplications(context.Background(), f.job.ID.Hex(), primitive.NewObjectID().Hex(), 1, 10)
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("GetJobApplications() error = %v, want %v", err, domain.ErrForbidden)
+	}
+	if appErr, ok := apperrors.As(err); !ok || appErr.Code != http.StatusForbidden {
+		t.Fatalf("GetJobApplications() error = %v, want a 403", err)
+	}
+}
+
+func TestGetAllowedTransitionsFollowsEvents(t *testing.T) {
+	f := newApplicationFixture(t, 1)
+	app := f.apps.applications[0]
+
+	// The projection still says applied, the event stream has moved on to interview
+	f.events.events[app.ID] = []*domain.ApplicationEvent{
+		{ApplicationID: app.ID, Version: 1, Type: domain.ApplicationApplied, ApplicantID: app.ApplicantID, JobID: app.JobID, At: app.AppliedAt},
+		{ApplicationID: app.ID, Version: 2, Type: domain.ApplicationStatusChanged, Status: domain.StatusInterview, At: time.Now()},
+	}
+
+	resp, err := f.uc.GetAllowedTransitions(context.Background(), app.ID.Hex(), f.companyID)
+	if err != nil {
+		t.Fatalf("GetAllowedTransitions() error = %v", err)
+	}
+	got := resp.Data.(domain.AllowedTransitions)
+	if got.CurrentStatus != domain.StatusInterview {
+		t.Errorf("CurrentStatus = %s, want %s", got.CurrentStatus, domain.StatusInterview)
+	}
+	statuses, err := domain.NewStatusMachine(domain.DefaultStatusTransitions, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := statuses.AllowedTransitions(domain.StatusInterview)
+	if fmt.Sprint(got.AllowedTransitions) != fmt.Sprint(want) {
+		t.Errorf("AllowedTransitions = %v, want %v", got.AllowedTransitions, want)
+	}
+}
+
+func TestApplyForJobUnpublishedJob(t *testing.T) {
+	f := newApplicationFixture(t, 0)
+	f.job.IsPublished = false
+	applicantID := primitive.NewObjectID().Hex()
+
+	req := &domain.ApplyRequest{JobID: f.job.ID.Hex(), ResumeID: f.addResume(applicantID)}
+	_, err := f.uc.ApplyForJob(context.Background(), req, applicantID, "")
+	if appErr, ok := apperrors.As(err); !ok || appErr.Code != http.StatusNotFound {
+		t.Fatalf("ApplyForJob() error = %v, want a 404", err)
+	}
+	if len(f.apps.applications) != 0 {
+		t.Fatalf("ApplyForJob() stored %d applications, want none", len(f.apps.applications))
+	}
+}
+
+func statusGraph(t *testing.T, resp *domain.ApplicationResponse) domain.StatusGraph {
+	t.Helper()
+	graph, ok := resp.Data.(domain.StatusGraph)
+	if !ok {
+		t.Fatalf("response data = %T, want domain.StatusGraph", resp.Data)
+	}
+	return graph
+}
+
+func TestUpdateStatusTransitionsStoresTheGraph(t *testing.T) {
+	f := newApplicationFixture(t, 0)
+	ctx := context.Background()
+
+	req := &domain.UpdateStatusTransitionsRequest{Transitions: domain.StatusTransitions{
+		domain.StatusApplied:  {domain.StatusReviewed, domain.StatusRejected},
+		domain.StatusReviewed: {domain.StatusHired, domain.StatusRejected},
+	}}
+	if _, err := f.uc.UpdateStatusTransitions(ctx, req, "admin"); err != nil {
+		t.Fatalf("UpdateStatusTransitions() error = %v", err)
+	}
+	if f.transitions.override == nil || f.transitions.override.UpdatedBy != "admin" {
+		t.Fatalf("stored override = %+v, want one updated by admin", f.transitions.override)
+	}
+
+	resp, err := f.uc.GetStatusGraph(ctx)
+	if err != nil {
+		t.Fatalf("GetStatusGraph() error = %v", err)
+	}
+	graph := statusGraph(t, resp)
+	if graph.Source != "database" || len(graph.Transitions[domain.StatusApplied]) != 2 {
+		t.Fatalf("graph = %+v, want the stored transitions", graph)
+	}
+
+	if _, err := f.uc.ResetStatusTransitions(ctx); err != nil {
+		t.Fatalf("ResetStatusTransitions() error = %v", err)
+	}
+	resp, _ = f.uc.GetStatusGraph(ctx)
+	if graph := statusGraph(t, resp); graph.Source != "default" || f.transitions.override != nil {
+		t.Fatalf("graph source after reset = %q, want default", graph.Source)
+	}
+}
+
+func TestUpdateStatusTransitionsRejectsCycles(t *testing.T) {
+	f := newApplicationFixture(t, 0)
+
+	req := &domain.UpdateStatusTransitionsRequest{Transitions: domain.StatusTransitions{
+		domain.StatusApplied:  {domain.StatusReviewed},
+		domain.StatusReviewed: {domain.StatusApplied},
+	}}
+	_, err := f.uc.UpdateStatusTransitions(context.Background(), req, "admin")
+	if appErr, ok := apperrors.As(err); !ok || appErr.Code != http.StatusBadRequest {
+		t.Fatalf("UpdateStatusTransitions() error = %v, want a 400", err)
+	}
+	if f.transitions.override != nil {
+		t.Fatalf("UpdateStatusTransitions() stored %+v, want nothing", f.transitions.override)
+	}
+}
+
+// Another instance's change is picked up on reload
+func TestReloadStatusTransitions(t *testing.T) {
+	f := newApplicationFixture(t, 0)
+	ctx := context.Background()
+	f.transitions.override = &domain.StatusTransitionsOverride{Transitions: domain.StatusTransitions{
+		domain.StatusApplied: {domain.StatusHired},
+	}}
+
+	if err := f.uc.ReloadStatusTransitions(ctx); err != nil {
+		t.Fatalf("ReloadStatusTransitions() error = %v", err)
+	}
+	resp, _ := f.uc.GetStatusGraph(ctx)
+	if graph := statusGraph(t, resp); graph.Source != "database" {
+		t.Fatalf("graph source = %q, want database", graph.Source)
+	}
+
+	f.transitions.override = nil
+	if err := f.uc.ReloadStatusTransitions(ctx); err != nil {
+		t.Fatalf("ReloadStatusTransitions() error = %v", err)
+	}
+	resp, _ = f.uc.GetStatusGraph(ctx)
+	if graph := statusGraph(t, resp); graph.Source != "default" {
+		t.Fatalf("graph source = %q, want default", graph.Source)
+	}
+}
+
+// Most applicants never opted in, their rejections mustn't cost a profile
+// lookup and a similar jobs query
+func TestSkillsGapReportNotWanted(t *testing.T) {
+	f := newApplicationFixture(t, 0)
+	uc := f.uc.(*applicationUseCase)
+
+	if err := uc.sendSkillsGapReport(context.Background(), "applicant", f.job); err != nil {
+		t.Fatalf("sendSkillsGapReport() error = %v", err)
+	}
+	if len(f.jobs.similarExclusions) != 0 || len(f.notifier.sent) != 0 {
+		t.Fatalf("looked up %d similar job lists and sent %v, want nothing", len(f.jobs.similarExclusions), f.notifier.sent)
+	}
+}
+
+func TestSkillsGapReportHonoursExclusions(t *testing.T) {
+	f := newApplicationFixture(t, 0)
+	uc := f.uc.(*applicationUseCase)
+	prefs := domain.DefaultNotificationPreferences("applicant")
+	prefs.SkillsGapReports = true
+	f.notifier.prefs["applicant"] = prefs
+	f.alertPrefs.prefs["applicant"] = &domain.AlertPreferences{
+		UserID:            "applicant",
+		ExcludedCompanies: []string{"blocked-company"},
+		ExcludedKeywords:  []string{"unpaid"},
+	}
+
+	if err := uc.sendSkillsGapReport(context.Background(), "applicant", f.job); err != nil {
+		t.Fatalf("sendSkillsGapReport() error = %v", err)
+	}
+	if len(f.jobs.similarExclusions) != 1 {
+		t.Fatalf("looked up %d similar job lists, want 1", len(f.jobs.similarExclusions))
+	}
+	exclusions := f.jobs.similarExclusions[0]
+	if len(exclusions.Companies) != 1 || exclusions.Companies[0] != "blocked-company" || len(exclusions.Keywords) != 1 || exclusions.Keywords[0] != "unpaid" {
+		t.Errorf("exclusions = %+v, want the applicant's", exclusions)
+	}
+	if len(f.notifier.sent) != 1 || f.notifier.sent[0] != domain.NotificationSkillsGap {
+		t.Errorf("sent %v, want a skills gap report", f.notifier.sent)
+	}
+}
@@ -0,0 +1,357 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/backup"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/pkg/storage"
+	"job-portal-backend/repository"
+)
+
+// restoreBatchSize is how many documents a restore inserts at once
+const restoreBatchSize = 500
+
+// backupHeartbeat is how often a running backup records that it is still making progress
+const backupHeartbeat = time.Minute
+
+// BackupUsecase takes logical backups of the database and checks they can be restored
+type BackupUsecase interface {
+	// StartBackup records a backup and takes it in the background. Progress is
+	// followed with GetBackup. Only one backup runs at a time.
+	StartBackup(ctx context.Context, adminID string) (*domain.BackupResponse, error)
+	// ListBackups returns backups newest first
+	ListBackups(ctx context.Context, page, limit int) (*domain.BackupListResponse, error)
+	GetBackup(ctx context.Context, id string) (*domain.BackupResponse, error)
+	// VerifyBackup reads the backup's archive back in the background and checks
+	// every collection against the counts and checksums recorded when it was taken
+	VerifyBackup(ctx context.Context, adminID, id string) (*domain.BackupResponse, error)
+	// Restore loads an archive into the database. Every collection it contains
+	// must be empty, so a restore never mixes backed up and live documents.
+	// A dry run reads and checks the whole archive without loading anything.
+	Restore(ctx context.Context, archive io.Reader, dryRun bool) ([]*domain.BackupCollection, error)
+}
+
+type backupUsecase struct {
+	backupRepo repository.BackupRepository
+	dumpRepo   repository.DumpRepository
+	archives   storage.Store
+}
+
+func NewBackupUsecase(backupRepo repository.BackupRepository, dumpRepo repository.DumpRepository, archives storage.Store) BackupUsecase {
+	return &backupUsecase{
+		backupRepo: backupRepo,
+		dumpRepo:   dumpRepo,
+		archives:   archives,
+	}
+}
+
+func (uc *backupUsecase) StartBackup(ctx context.Context, adminID string) (*domain.BackupResponse, error) {
+	now := time.Now()
+
+	// A backup interrupted by a restart would otherwise block every later one
+	if err := uc.backupRepo.FailStale(ctx, now.Add(-domain.BackupStaleAfter)); err != nil {
+		return nil, fmt.Errorf("error expiring interrupted backups: %w", err)
+	}
+
+	b := &domain.Backup{
+		RequestedBy: adminID,
+		Key:         fmt.Sprintf("backup-%s.jsonl.gz", now.UTC().Format("20060102T150405.000Z")),
+	}
+	if err := uc.backupRepo.Create(ctx, b); err != nil {
+		if errors.Is(err, domain.ErrBackupInProgress) {
+			return nil, apperrors.NewConflictError("A backup is already in progress")
+		}
+		return nil, fmt.Errorf("error recording backup: %w", err)
+	}
+
+	// The backup outlives the request that started it
+	go uc.run(context.Background(), b)
+
+	return &domain.BackupResponse{
+		Success: true,
+		Message: "Backup started",
+		Data:    b,
+	}, nil
+}
+
+func (uc *backupUsecase) ListBackups(ctx context.Context, page, limit int) (*domain.BackupListResponse, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	backups, total, err := uc.backupRepo.List(ctx, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing backups: %w", err)
+	}
+
+	// Calculate total pages
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return &domain.BackupListResponse{
+		Success:    true,
+		Message:    "Successfully retrieved backups",
+		Data:       backups,
+		PageNumber: page,
+		PageSize:   len(backups),
+		TotalItems: total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (uc *backupUsecase) GetBackup(ctx context.Context, id string) (*domain.BackupResponse, error) {
+	b, err := uc.getBackup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.BackupResponse{
+		Success: true,
+		Message: "Successfully retrieved backup",
+		Data:    b,
+	}, nil
+}
+
+func (uc *backupUsecase) VerifyBackup(ctx context.Context, adminID, id string) (*domain.BackupResponse, error) {
+	b, err := uc.getBackup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if b.Status != domain.BackupCompleted {
+		return nil, apperrors.NewConflictError("Only completed backups can be verified")
+	}
+	if b.Verifying(now) {
+		return nil, apperrors.NewConflictError("The backup is already being verified")
+	}
+
+	verification := &domain.BackupVerification{
+		Status:      domain.VerificationRunning,
+		RequestedBy: adminID,
+		StartedAt:   now,
+	}
+	if err := uc.backupRepo.StartVerification(ctx, b.ID, verification); err != nil {
+		if errors.Is(err, domain.ErrBackupNotVerifiable) {
+			return nil, apperrors.NewConflictError("The backup is already being verified")
+		}
+		return nil, fmt.Errorf("error recording verification: %w", err)
+	}
+	b.Verification = verification
+
+	go uc.verify(context.Background(), b)
+
+	return &domain.BackupResponse{
+		Success: true,
+		Message: "Backup verification started",
+		Data:    b,
+	}, nil
+}
+
+func (uc *backupUsecase) Restore(ctx context.Context, archive io.Reader, dryRun bool) ([]*domain.BackupCollection, error) {
+	var (
+		current string
+		batch   []interface{}
+	)
+	flush := func() error {
+		var err error
+		if !dryRun {
+			err = uc.dumpRepo.Load(ctx, current, batch)
+		}
+		batch = batch[:0]
+		return err
+	}
+
+	stats, err := backup.Read(archive, func(collection string, document bson.D) error {
+		if collection != current {
+			if err := flush(); err != nil {
+				return err
+			}
+			count, err := uc.dumpRepo.CountDocuments(ctx, collection)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return apperrors.NewConflictError(fmt.Sprintf("Collection %s is not empty", collection))
+			}
+			current = collection
+		}
+
+		batch = append(batch, document)
+		if len(batch) >= restoreBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return collectionsFromStats(stats), nil
+}
+
+// run takes the backup, streaming the archive to storage as it is written
+func (uc *backupUsecase) run(ctx context.Context, b *domain.Backup) {
+	pr, pw := io.Pipe()
+	counter := &countingReader{r: pr}
+
+	type dumpResult struct {
+		stats      []backup.CollectionStats
+		consistent bool
+		err        error
+	}
+	done := make(chan dumpResult, 1)
+
+	go func() {
+		w := backup.NewWriter(pw)
+		lastBeat := time.Now()
+		consistent, err := uc.dumpRepo.Dump(ctx, func(collection string, document bson.Raw) error {
+			if time.Since(lastBeat) >= backupHeartbeat {
+				lastBeat = time.Now()
+				if err := uc.backupRepo.Heartbeat(ctx, b.ID); err != nil {
+					log.Printf("Failed to record progress of backup %s: %v", b.ID.Hex(), err)
+				}
+			}
+			return w.Write(collection, document)
+		})
+		if err == nil {
+			err = w.Close()
+		}
+		pw.CloseWithError(err)
+		done <- dumpResult{stats: w.Stats(), consistent: consistent, err: err}
+	}()
+
+	_, err := uc.archives.Upload(ctx, b.Key, "application/gzip", counter)
+	// Unblocks the dump if the upload gave up before reading everything
+	pr.CloseWithError(errors.New("archive upload stopped"))
+	result := <-done
+
+	if result.err != nil {
+		err = result.err
+	}
+	if err != nil {
+		log.Printf("Backup %s failed: %v", b.ID.Hex(), err)
+		if ferr := uc.backupRepo.Fail(ctx, b.ID, err.Error()); ferr != nil {
+			log.Printf("Failed to record failure of backup %s: %v", b.ID.Hex(), ferr)
+		}
+		return
+	}
+
+	if err := uc.backupRepo.Complete(ctx, b.ID, counter.n, collectionsFromStats(result.stats), result.consistent); err != nil {
+		log.Printf("Failed to record completion of backup %s: %v", b.ID.Hex(), err)
+	}
+}
+
+// verify reads the archive back and compares it to the backup record
+func (uc *backupUsecase) verify(ctx context.Context, b *domain.Backup) {
+	v := b.Verification
+
+	stats, err := uc.readArchive(ctx, b.Key)
+	if err != nil {
+		v.Status = domain.VerificationFailed
+		v.Error = err.Error()
+	} else {
+		v.Mismatches = compareCollections(b.Collections, collectionsFromStats(stats))
+		v.Status = domain.VerificationPassed
+		if len(v.Mismatches) > 0 {
+			v.Status = domain.VerificationFailed
+		}
+	}
+
+	now := time.Now()
+	v.CompletedAt = &now
+	if err := uc.backupRepo.FinishVerification(ctx, b.ID, v); err != nil {
+		log.Printf("Failed to record verification of backup %s: %v", b.ID.Hex(), err)
+	}
+}
+
+func (uc *backupUsecase) readArchive(ctx context.Context, key string) ([]backup.CollectionStats, error) {
+	rc, _, err := uc.archives.Download(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("error opening archive: %w", err)
+	}
+	defer rc.Close()
+
+	return backup.Read(rc, nil)
+}
+
+func (uc *backupUsecase) getBackup(ctx context.Context, id string) (*domain.Backup, error) {
+	b, err := uc.backupRepo.GetByID(ctx, id)
+	if err != nil {
+		if isNotFound(err, domain.ErrBackupNotFound) {
+			return nil, apperrors.NewNotFoundError("Backup not found")
+		}
+		return nil, fmt.Errorf("error retrieving backup: %w", err)
+	}
+	return b, nil
+}
+
+// compareCollections describes how the collections read from an archive differ from the recorded ones
+func compareCollections(recorded, read []*domain.BackupCollection) []string {
+	byName := make(map[string]*domain.BackupCollection, len(read))
+	for _, c := range read {
+		byName[c.Name] = c
+	}
+
+	var mismatches []string
+	for _, want := range recorded {
+		got, ok := byName[want.Name]
+		delete(byName, want.Name)
+		switch {
+		case !ok:
+			mismatches = append(mismatches, fmt.Sprintf("%s: missing from the archive", want.Name))
+		case got.Documents != want.Documents:
+			mismatches = append(mismatches, fmt.Sprintf("%s: %d documents, expected %d", want.Name, got.Documents, want.Documents))
+		case got.SHA256 != want.SHA256:
+			mismatches = append(mismatches, fmt.Sprintf("%s: checksum differs", want.Name))
+		}
+	}
+	for _, c := range read {
+		if _, extra := byName[c.Name]; extra {
+			mismatches = append(mismatches, fmt.Sprintf("%s: not in the backup record", c.Name))
+		}
+	}
+	return mismatches
+}
+
+func collectionsFromStats(stats []backup.CollectionStats) []*domain.BackupCollection {
+	collections := make([]*domain.BackupCollection, 0, len(stats))
+	for _, s := range stats {
+		collections = append(collections, &domain.BackupCollection{
+			Name:      s.Name,
+			Documents: s.Documents,
+			SHA256:    s.SHA256,
+		})
+	}
+	return collections
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// categorySlug matches lower case words separated by - or _
+var categorySlug = regexp.MustCompile(`^[a-z0-9]+([_-][a-z0-9]+)*$`)
+
+// CategoryUsecase manages the job category taxonomy
+type CategoryUsecase interface {
+	// ListCategories returns every category with its number of published jobs
+	ListCategories(ctx context.Context) (*domain.CategoryResponse, error)
+	CreateCategory(ctx context.Context, req *domain.CreateCategoryRequest) (*domain.CategoryResponse, error)
+	UpdateCategory(ctx context.Context, id string, req *domain.UpdateCategoryRequest) (*domain.CategoryResponse, error)
+	// DeleteCategory removes a category no job uses
+	DeleteCategory(ctx context.Context, id string) (*domain.CategoryResponse, error)
+	// EnsureDefaults creates the default categories when the taxonomy is empty
+	EnsureDefaults(ctx context.Context) error
+}
+
+type categoryUsecase struct {
+	categoryRepo repository.CategoryRepository
+	jobRepo      repository.JobRepository
+}
+
+func NewCategoryUsecase(categoryRepo repository.CategoryRepository, jobRepo repository.JobRepository) CategoryUsecase {
+	return &categoryUsecase{
+		categoryRepo: categoryRepo,
+		jobRepo:      jobRepo,
+	}
+}
+
+func (uc *categoryUsecase) ListCategories(ctx context.Context) (*domain.CategoryResponse, error) {
+	categories, err := uc.categoryRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing categories: %w", err)
+	}
+
+	counts, err := uc.jobRepo.CountPublishedByCategory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error counting jobs: %w", err)
+	}
+	for _, category := range categories {
+		category.Jobs = counts[category.Slug]
+	}
+
+	return &domain.CategoryResponse{
+		Success: true,
+		Message: "Successfully retrieved categories",
+		Data:    categories,
+	}, nil
+}
+
+func (uc *categoryUsecase) CreateCategory(ctx context.Context, req *domain.CreateCategoryRequest) (*domain.CategoryResponse, error) {
+	slug := strings.ToLower(strings.TrimSpace(req.Slug))
+	if !categorySlug.MatchString(slug) {
+		return nil, apperrors.NewBadRequestError("Invalid category slug",
+			[]string{"slug must be lower case letters and digits separated by - or _"})
+	}
+
+	category := &domain.Category{
+		Slug:        slug,
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+	}
+	if err := uc.categoryRepo.Create(ctx, category); err != nil {
+		if errors.Is(err, domain.ErrCategoryExists) {
+			return nil, apperrors.NewConflictError("A category with this slug already exists")
+		}
+		return nil, fmt.Errorf("error creating category: %w", err)
+	}
+
+	return &domain.CategoryResponse{
+		Success: true,
+		Message: "Category created successfully",
+		Data:    category,
+	}, nil
+}
+
+func (uc *categoryUsecase) UpdateCategory(ctx context.Context, id string, req *domain.UpdateCategoryRequest) (*domain.CategoryResponse, error) {
+	if err := uc.categoryRepo.Update(ctx, id, req); err != nil {
+		if isNotFound(err, domain.ErrCategoryNotFound) {
+			return nil, apperrors.NewNotFoundError("Category not found")
+		}
+		return nil, fmt.Errorf("error updating category: %w", err)
+	}
+
+	category, err := uc.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving category: %w", err)
+	}
+
+	return &domain.CategoryResponse{
+		Success: true,
+		Message: "Category updated successfully",
+		Data:    category,
+	}, nil
+}
+
+func (uc *categoryUsecase) DeleteCategory(ctx context.Context, id string) (*domain.CategoryResponse, error) {
+	category, err := uc.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		if isNotFound(err, domain.ErrCategoryNotFound) {
+			return nil, apperrors.NewNotFoundError("Category not found")
+		}
+		return nil, fmt.Errorf("error retrieving category: %w", err)
+	}
+
+	used, err := uc.jobRepo.HasJobsInCategory(ctx, category.Slug)
+	if err != nil {
+		return nil, fmt.Errorf("error checking category jobs: %w", err)
+	}
+	if used {
+		return nil, apperrors.NewConflictError("The category is used by jobs, move them to another category first")
+	}
+
+	if err := uc.categoryRepo.Delete(ctx, id); err != nil {
+		if isNotFound(err, domain.ErrCategoryNotFound) {
+			return nil, apperrors.NewNotFoundError("Category not found")
+		}
+		return nil, fmt.Errorf("error deleting category: %w", err)
+	}
+
+	return &domain.CategoryResponse{
+		Success: true,
+		Message: "Category deleted successfully",
+	}, nil
+}
+
+func (uc *categoryUsecase) EnsureDefaults(ctx context.Context) error {
+	return uc.categoryRepo.CreateIfEmpty(ctx, domain.DefaultCategories())
+}
+
+// checkCategory fails with a bad request when the slug isn't in the taxonomy
+func checkCategory(ctx context.Context, categoryRepo repository.CategoryRepository, slug string) error {
+	if slug == "" {
+		return nil
+	}
+	if _, err := categoryRepo.GetBySlug(ctx, slug); err != nil {
+		if errors.Is(err, domain.ErrCategoryNotFound) {
+			return apperrors.NewBadRequestError("Unknown category", []string{fmt.Sprintf("category %q doesn't exist", slug)})
+		}
+		return fmt.Errorf("error checking category: %w", err)
+	}
+	return nil
+}
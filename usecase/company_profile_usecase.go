@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// CompanyProfileUsecase manages the public profile of company accounts
+type CompanyProfileUsecase interface {
+	GetProfile(ctx context.Context, companyID string) (*domain.CompanyProfileResponse, error)
+	// SaveProfile replaces the company's profile
+	SaveProfile(ctx context.Context, companyID string, req *domain.SaveCompanyProfileRequest) (*domain.CompanyProfileResponse, error)
+	DeleteProfile(ctx context.Context, companyID string) (*domain.CompanyProfileResponse, error)
+	// GetPublicPage returns the company's employer page, shown without signing in.
+	// Suspended and deleted companies have no page.
+	GetPublicPage(ctx context.Context, companyID string, page, limit int) (*domain.CompanyProfileResponse, error)
+}
+
+type companyProfileUsecase struct {
+	profileRepo repository.CompanyProfileRepository
+	userRepo    repository.UserRepository
+	jobRepo     repository.JobRepository
+}
+
+func NewCompanyProfileUsecase(profileRepo repository.CompanyProfileRepository, userRepo repository.UserRepository, jobRepo repository.JobRepository) CompanyProfileUsecase {
+	return &companyProfileUsecase{
+		profileRepo: profileRepo,
+		userRepo:    userRepo,
+		jobRepo:     jobRepo,
+	}
+}
+
+func (uc *companyProfileUsecase) GetProfile(ctx context.Context, companyID string) (*domain.CompanyProfileResponse, error) {
+	profile, err := uc.profileRepo.GetByCompanyID(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrCompanyProfileNotFound) {
+			return nil, apperrors.NewNotFoundError("Company profile not found")
+		}
+		return nil, fmt.Errorf("error retrieving company profile: %w", err)
+	}
+
+	return &domain.CompanyProfileResponse{
+		Success: true,
+		Message: "Successfully retrieved company profile",
+		Data:    profile,
+	}, nil
+}
+
+func (uc *companyProfileUsecase) SaveProfile(ctx context.Context, companyID string, req *domain.SaveCompanyProfileRequest) (*domain.CompanyProfileResponse, error) {
+	profile := &domain.CompanyProfile{
+		CompanyID: companyID,
+		LogoURL:   req.LogoURL,
+		About:     strings.TrimSpace(req.About),
+		Industry:  strings.TrimSpace(req.Industry),
+		Size:      req.Size,
+		Website:   req.Website,
+	}
+
+	if err := uc.profileRepo.Upsert(ctx, profile); err != nil {
+		return nil, fmt.Errorf("error saving company profile: %w", err)
+	}
+
+	return &domain.CompanyProfileResponse{
+		Success: true,
+		Message: "Company profile saved successfully",
+		Data:    profile,
+	}, nil
+}
+
+func (uc *companyProfileUsecase) DeleteProfile(ctx context.Context, companyID string) (*domain.CompanyProfileResponse, error) {
+	if err := uc.profileRepo.DeleteByCompanyID(ctx, companyID); err != nil {
+		if errors.Is(err, domain.ErrCompanyProfileNotFound) {
+			return nil, apperrors.NewNotFoundError("Company profile not found")
+		}
+		return nil, fmt.Errorf("error deleting company profile: %w", err)
+	}
+
+	return &domain.CompanyProfileResponse{
+		Success: true,
+		Message: "Company profile deleted successfully",
+	}, nil
+}
+
+func (uc *companyProfileUsecase) GetPublicPage(ctx context.Context, companyID string, page, limit int) (*domain.CompanyProfileResponse, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	company, err := uc.userRepo.FindByID(ctx, companyID)
+	if err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewNotFoundError("Company not found")
+		}
+		return nil, fmt.Errorf("error retrieving company: %w", err)
+	}
+	if company.Role != domain.Company || company.IsSuspended() || company.IsDeleted() {
+		return nil, apperrors.NewNotFoundError("Company not found")
+	}
+
+	// Companies that never filled in a profile still get a page
+	profile, err := uc.profileRepo.GetByCompanyID(ctx, companyID)
+	if err != nil && !errors.Is(err, domain.ErrCompanyProfileNotFound) {
+		return nil, fmt.Errorf("error retrieving company profile: %w", err)
+	}
+
+	jobs, total, err := uc.jobRepo.ListPublishedByCompany(ctx, companyID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving company jobs: %w", err)
+	}
+	setComputedFields(jobs...)
+
+	// Calculate total pages
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return &domain.CompanyProfileResponse{
+		Success: true,
+		Message: "Successfully retrieved company page",
+		Data: &domain.CompanyPage{
+			Company:    company.CompanyInfo(profile),
+			Jobs:       jobs,
+			PageNumber: page,
+			PageSize:   len(jobs),
+			TotalJobs:  total,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
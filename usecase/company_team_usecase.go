@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/email"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+	"job-portal-backend/utils"
+)
+
+// CompanyTeamUsecase manages the recruiters working for a company account.
+// Members act on behalf of the company: the jobs they post belong to it and
+// they manage every job of the company and its applications.
+type CompanyTeamUsecase interface {
+	// ListMembers returns the team of the company the user works for, owner first
+	ListMembers(ctx context.Context, userID string) (*domain.CompanyTeamResponse, error)
+	// InviteMember emails an invitation to join the team. Only the owner and admins can invite.
+	InviteMember(ctx context.Context, userID string, req *domain.InviteMemberRequest) (*domain.CompanyTeamResponse, error)
+	// AcceptInvitation adds the user to the team they were invited to
+	AcceptInvitation(ctx context.Context, userID string, req *domain.AcceptInvitationRequest) (*domain.CompanyTeamResponse, error)
+	// RemoveMember removes a member from the team. Admins can only remove recruiters.
+	RemoveMember(ctx context.Context, userID, memberID string) (*domain.CompanyTeamResponse, error)
+}
+
+type companyTeamUsecase struct {
+	memberRepo     repository.CompanyMemberRepository
+	invitationRepo repository.CompanyInvitationRepository
+	userRepo       repository.UserRepository
+	jobRepo        repository.JobRepository
+	mailer         email.Sender
+	frontendURL    string
+}
+
+func NewCompanyTeamUsecase(memberRepo repository.CompanyMemberRepository, invitationRepo repository.CompanyInvitationRepository, userRepo repository.UserRepository, jobRepo repository.JobRepository, mailer email.Sender, frontendURL string) CompanyTeamUsecase {
+	return &companyTeamUsecase{
+		memberRepo:     memberRepo,
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+		jobRepo:        jobRepo,
+		mailer:         mailer,
+		frontendURL:    frontendURL,
+	}
+}
+
+func (uc *companyTeamUsecase) ListMembers(ctx context.Context, userID string) (*domain.CompanyTeamResponse, error) {
+	companyID, _, err := teamRole(ctx, uc.memberRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := uc.userRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving company: %w", err)
+	}
+	team := []*domain.TeamMember{{
+		UserID: companyID,
+		Name:   owner.Name,
+		Email:  owner.Email,
+		Role:   domain.MemberOwner,
+	}}
+
+	members, err := uc.memberRepo.ListByCompany(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing members: %w", err)
+	}
+	for _, member := range members {
+		user, err := uc.userRepo.FindByID(ctx, member.UserID)
+		if err != nil {
+			continue // Skip members whose account no longer exists
+		}
+		joinedAt := member.CreatedAt
+		team = append(team, &domain.TeamMember{
+			UserID:   member.UserID,
+			Name:     user.Name,
+			Email:    user.Email,
+			Role:     member.Role,
+			JoinedAt: &joinedAt,
+		})
+	}
+
+	return &domain.CompanyTeamResponse{
+		Success: true,
+		Message: "Successfully retrieved team members",
+		Data:    team,
+	}, nil
+}
+
+func (uc *companyTeamUsecase) InviteMember(ctx context.Context, userID string, req *domain.InviteMemberRequest) (*domain.CompanyTeamResponse, error) {
+	companyID, role, err := teamRole(ctx, uc.memberRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !role.ManagesTeam() {
+		return nil, errForbidden("Only the company owner and admins can invite members")
+	}
+
+	company, err := uc.userRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving company: %w", err)
+	}
+	if strings.EqualFold(company.Email, req.Email) {
+		return nil, apperrors.NewConflictError("The company owner is already on the team")
+	}
+
+	rawToken, err := utils.GenerateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &domain.CompanyInvitation{
+		CompanyID: companyID,
+		Email:     strings.ToLower(req.Email),
+		Role:      req.Role,
+		TokenHash: utils.HashToken(rawToken),
+		InvitedBy: userID,
+		ExpiresAt: time.Now().Add(domain.CompanyInvitationTTL),
+	}
+	if err := uc.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("error creating invitation: %w", err)
+	}
+
+	acceptLink := fmt.Sprintf("%s/invitations/accept?token=%s", uc.frontendURL, rawToken)
+	err = uc.mailer.Send(ctx, email.Message{
+		To:      req.Email,
+		Subject: fmt.Sprintf("You're invited to join %s on the job portal", company.Name),
+		Body: fmt.Sprintf("Hi,\n\n%s invited you to join their hiring team as %s. Sign in with a company account using this email address and open the link below to accept. It expires in %s.\n\n%s\n\nIf you weren't expecting this, you can ignore this email.\n",
+			company.Name, req.Role, domain.CompanyInvitationTTL, acceptLink),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error sending invitation: %w", err)
+	}
+
+	return &domain.CompanyTeamResponse{
+		Success: true,
+		Message: "Invitation sent successfully",
+		Data:    invitation,
+	}, nil
+}
+
+func (uc *companyTeamUsecase) AcceptInvitation(ctx context.Context, userID string, req *domain.AcceptInvitationRequest) (*domain.CompanyTeamResponse, error) {
+	invitation, err := uc.invitationRepo.GetOpenByTokenHash(ctx, utils.HashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvitationNotFound) {
+			return nil, apperrors.NewBadRequestError("Invalid or expired invitation", nil)
+		}
+		return nil, fmt.Errorf("error retrieving invitation: %w", err)
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewNotFoundError("User not found")
+		}
+		return nil, err
+	}
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, errForbidden("This invitation was sent to another email address")
+	}
+	if userID == invitation.CompanyID {
+		return nil, apperrors.NewConflictError("You already own this company account")
+	}
+
+	// A company account with its own team or jobs would leave them behind
+	members, err := uc.memberRepo.ListByCompany(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error checking team: %w", err)
+	}
+	if len(members) > 0 {
+		return nil, apperrors.NewConflictError("Accounts with their own team can't join another company")
+	}
+	if _, total, err := uc.jobRepo.GetJobsByCompanyID(ctx, userID, domain.CompanyJobFilter{}, 1, 1); err != nil {
+		return nil, fmt.Errorf("error checking jobs: %w", err)
+	} else if total > 0 {
+		return nil, apperrors.NewConflictError("Accounts that posted jobs can't join another company")
+	}
+
+	member := &domain.CompanyMember{
+		CompanyID: invitation.CompanyID,
+		UserID:    userID,
+		Role:      invitation.Role,
+		Source:    "invitation",
+		InvitedBy: invitation.InvitedBy,
+	}
+	if err := uc.memberRepo.Add(ctx, member); err != nil {
+		if errors.Is(err, domain.ErrCompanyMemberExists) {
+			return nil, apperrors.NewConflictError("You are already a member of a company")
+		}
+		return nil, fmt.Errorf("error adding member: %w", err)
+	}
+	if err := uc.invitationRepo.MarkAccepted(ctx, invitation.ID, userID); err != nil && !errors.Is(err, domain.ErrInvitationNotFound) {
+		return nil, fmt.Errorf("error accepting invitation: %w", err)
+	}
+
+	return &domain.CompanyTeamResponse{
+		Success: true,
+		Message: "Invitation accepted successfully",
+		Data:    member,
+	}, nil
+}
+
+func (uc *companyTeamUsecase) RemoveMember(ctx context.Context, userID, memberID string) (*domain.CompanyTeamResponse, error) {
+	companyID, role, err := teamRole(ctx, uc.memberRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !role.ManagesTeam() {
+		return nil, errForbidden("Only the company owner and admins can remove members")
+	}
+
+	member, err := uc.memberRepo.FindByUser(ctx, memberID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving member: %w", err)
+	}
+	if member == nil || member.CompanyID != companyID {
+		return nil, apperrors.NewNotFoundError("Member not found")
+	}
+	if role == domain.MemberAdmin && member.Role != domain.MemberRecruiter {
+		return nil, errForbidden("Admins can only remove recruiters")
+	}
+
+	if err := uc.memberRepo.Remove(ctx, companyID, memberID); err != nil {
+		if errors.Is(err, domain.ErrCompanyMemberNotFound) {
+			return nil, apperrors.NewNotFoundError("Member not found")
+		}
+		return nil, fmt.Errorf("error removing member: %w", err)
+	}
+
+	return &domain.CompanyTeamResponse{
+		Success: true,
+		Message: "Member removed successfully",
+	}, nil
+}
+
+// teamRole returns the company account the user acts for and their role in
+// its team. Users who aren't a member of a company are their own company.
+func teamRole(ctx context.Context, memberRepo repository.CompanyMemberRepository, userID string) (string, domain.MemberRole, error) {
+	member, err := memberRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return "", "", fmt.Errorf("error retrieving company membership: %w", err)
+	}
+	if member == nil {
+		return userID, domain.MemberOwner, nil
+	}
+	return member.CompanyID, member.Role, nil
+}
+
+// actingCompany returns the company account the user acts for
+func actingCompany(ctx context.Context, memberRepo repository.CompanyMemberRepository, userID string) (string, error) {
+	companyID, _, err := teamRole(ctx, memberRepo, userID)
+	return companyID, err
+}
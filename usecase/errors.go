@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"errors"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+)
+
+// Business failures (missing records, ownership, duplicates, ...) are returned as
+// *apperrors.AppError so controllers can map them to the matching HTTP status.
+
+// isNotFound reports whether err means the looked up record doesn't exist.
+// Malformed IDs are treated the same as missing records.
+func isNotFound(err, notFound error) bool {
+	return errors.Is(err, notFound) || errors.Is(err, domain.ErrInvalidID)
+}
+
+// errForbidden returns a 403 error matching domain.ErrForbidden
+func errForbidden(message string) error {
+	return apperrors.NewForbiddenError(message).Wrap(domain.ErrForbidden)
+}
@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/pkg/webhook"
+	"job-portal-backend/repository"
+)
+
+// eventDeliveryBatch bounds how many events one delivery run sends
+const eventDeliveryBatch = 200
+
+// maxEventBackoff caps the wait between deliveries of a failing event
+const maxEventBackoff = time.Hour
+
+// EventBus publishes domain events to the downstream systems (CRM,
+// analytics) subscribed through webhooks. Events go through an outbox:
+// publishing stores the event and a background worker delivers it, retrying
+// with backoff, so a slow or down receiver never fails the request that
+// caused the event.
+type EventBus interface {
+	// Publish records an event, data is its payload at the type's current
+	// schema version. Failures are logged, not returned: the business
+	// operation already happened.
+	Publish(ctx context.Context, eventType domain.DomainEventType, data interface{})
+	// DispatchEvents delivers the events that are due to every webhook
+	DispatchEvents(ctx context.Context) error
+	ListEvents(ctx context.Context, filter domain.DomainEventFilter, page, limit int) (*domain.DomainEventListResponse, error)
+	// ReplayEvents queues the events of a period for delivery again
+	ReplayEvents(ctx context.Context, req *domain.ReplayEventsRequest) (*domain.DomainEventResponse, error)
+	ReplayEvent(ctx context.Context, eventID string) (*domain.DomainEventResponse, error)
+}
+
+type eventBus struct {
+	eventRepo   repository.DomainEventRepository
+	webhooks    webhook.Sender
+	webhookURLs []string
+}
+
+// NewEventBus creates an EventBus delivering to webhookURLs. Without any
+// URL events are still recorded so they can be replayed once one is set.
+func NewEventBus(eventRepo repository.DomainEventRepository, webhooks webhook.Sender, webhookURLs []string) EventBus {
+	return &eventBus{
+		eventRepo:   eventRepo,
+		webhooks:    webhooks,
+		webhookURLs: webhookURLs,
+	}
+}
+
+func (b *eventBus) Publish(ctx context.Context, eventType domain.DomainEventType, data interface{}) {
+	// Payloads are stored as documents so old versions stay readable after the
+	// Go type changes
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Failed to encode %s event: %v", eventType, err)
+		return
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(encoded, &payload); err != nil {
+		log.Printf("Failed to encode %s event: %v", eventType, err)
+		return
+	}
+
+	now := time.Now()
+	event := &domain.DomainEvent{
+		Type:          eventType,
+		Version:       domain.DomainEventVersions[eventType],
+		OccurredAt:    now,
+		Data:          payload,
+		NextAttemptAt: now,
+	}
+	if err := b.eventRepo.Create(ctx, event); err != nil {
+		log.Printf("Failed to record %s event: %v", eventType, err)
+	}
+}
+
+func (b *eventBus) DispatchEvents(ctx context.Context) error {
+	events, err := b.eventRepo.ListDue(ctx, time.Now(), eventDeliveryBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		b.deliver(ctx, event)
+		if err := b.eventRepo.SaveDelivery(ctx, event); err != nil {
+			return fmt.Errorf("error saving event delivery: %w", err)
+		}
+	}
+	return nil
+}
+
+// deliver sends the event to the webhooks that didn't accept it yet and
+// updates its delivery state
+func (b *eventBus) deliver(ctx context.Context, event *domain.DomainEvent) {
+	delivered := make(map[string]bool, len(event.DeliveredTo))
+	for _, url := range event.DeliveredTo {
+		delivered[url] = true
+	}
+
+	event.LastError = ""
+	envelope := event.Envelope()
+	for _, url := range b.webhookURLs {
+		if delivered[url] {
+			continue
+		}
+		if err := b.webhooks.Send(ctx, url, envelope); err != nil {
+			event.LastError = fmt.Sprintf("%s: %v", url, err)
+			continue
+		}
+		event.DeliveredTo = append(event.DeliveredTo, url)
+	}
+
+	now := time.Now()
+	if event.LastError == "" {
+		event.DeliveredAt = &now
+		return
+	}
+
+	event.Attempts++
+	if event.Attempts >= domain.MaxEventAttempts {
+		log.Printf("Giving up on %s event %s after %d attempts: %s", event.Type, event.ID.Hex(), event.Attempts, event.LastError)
+		event.FailedAt = &now
+		return
+	}
+	backoff := time.Duration(1<<uint(event.Attempts-1)) * time.Minute
+	if backoff > maxEventBackoff {
+		backoff = maxEventBackoff
+	}
+	event.NextAttemptAt = now.Add(backoff)
+}
+
+func (b *eventBus) ListEvents(ctx context.Context, filter domain.DomainEventFilter, page, limit int) (*domain.DomainEventListResponse, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	events, total, err := b.eventRepo.List(ctx, filter, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing events: %w", err)
+	}
+
+	// Calculate total pages
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return &domain.DomainEventListResponse{
+		Success:    true,
+		Message:    "Successfully retrieved events",
+		Data:       events,
+		PageNumber: page,
+		PageSize:   len(events),
+		TotalItems: total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (b *eventBus) ReplayEvents(ctx context.Context, req *domain.ReplayEventsRequest) (*domain.DomainEventResponse, error) {
+	if req.Until != nil && !req.Until.After(req.Since) {
+		return nil, apperrors.NewBadRequestError("Validation failed", []string{"until must be after since"})
+	}
+
+	since := req.Since
+	queued, err := b.eventRepo.Requeue(ctx, domain.DomainEventFilter{Since: &since, Until: req.Until}, req.Types)
+	if err != nil {
+		return nil, fmt.Errorf("error queuing events for replay: %w", err)
+	}
+
+	return &domain.DomainEventResponse{
+		Success: true,
+		Message: fmt.Sprintf("%d events queued for replay", queued),
+		Data:    map[string]int64{"queued": queued},
+	}, nil
+}
+
+func (b *eventBus) ReplayEvent(ctx context.Context, eventID string) (*domain.DomainEventResponse, error) {
+	if err := b.eventRepo.RequeueByID(ctx, eventID); err != nil {
+		if isNotFound(err, domain.ErrDomainEventNotFound) {
+			return nil, apperrors.NewNotFoundError("Event not found")
+		}
+		return nil, fmt.Errorf("error queuing event for replay: %w", err)
+	}
+
+	return &domain.DomainEventResponse{
+		Success: true,
+		Message: "Event queued for replay",
+	}, nil
+}
@@ -0,0 +1,406 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/email"
+	"job-portal-backend/repository"
+)
+
+// The fakes keep their data in memory. They embed the repository interface,
+// so calling a method a test doesn't set up panics instead of hitting MongoDB.
+
+type fakeJobRepo struct {
+	repository.JobRepository
+	mu   sync.Mutex
+	jobs map[string]*domain.Job
+	// listed is returned by ListJobs, in order
+	listed []*domain.Job
+	// similarExclusions records the exclusions ListSimilarJobs was called with
+	similarExclusions []domain.JobExclusions
+}
+
+func newFakeJobRepo(jobs ...*domain.Job) *fakeJobRepo {
+	r := &fakeJobRepo{jobs: map[string]*domain.Job{}}
+	for _, job := range jobs {
+		if job.ID.IsZero() {
+			job.ID = primitive.NewObjectID()
+		}
+		r.jobs[job.ID.Hex()] = job
+		r.listed = append(r.listed, job)
+	}
+	return r
+}
+
+func (r *fakeJobRepo) GetJobByID(ctx context.Context, id string) (*domain.Job, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, domain.ErrInvalidID
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, domain.ErrJobNotFound
+	}
+	clone := *job
+	return &clone, nil
+}
+
+func (r *fakeJobRepo) ListJobs(ctx context.Context, filter domain.JobFilter, page, limit int) ([]*domain.Job, int64, error) {
+	start := (page - 1) * limit
+	if start > len(r.listed) {
+		start = len(r.listed)
+	}
+	end := start + limit
+	if end > len(r.listed) {
+		end = len(r.listed)
+	}
+
+	jobs := make([]*domain.Job, 0, end-start)
+	for _, job := range r.listed[start:end] {
+		clone := *job
+		jobs = append(jobs, &clone)
+	}
+	return jobs, int64(len(r.listed)), nil
+}
+
+func (r *fakeJobRepo) ReserveApplication(ctx context.Context, id primitive.ObjectID) (*domain.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id.Hex()]
+	if !ok {
+		return nil, domain.ErrJobNotFound
+	}
+	if job.ApplicationsFull() {
+		return nil, domain.ErrJobFull
+	}
+	job.ApplicationsReceived++
+	clone := *job
+	return &clone, nil
+}
+
+func (r *fakeJobRepo) ReleaseApplication(ctx context.Context, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if job, ok := r.jobs[id.Hex()]; ok {
+		job.ApplicationsReceived--
+	}
+	return nil
+}
+
+func (r *fakeJobRepo) CapApplications(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if job, ok := r.jobs[id.Hex()]; ok && job.IsPublished && job.ApplicationsFull() {
+		job.IsPublished = false
+		job.ApplicationsCappedAt = &at
+	}
+	return nil
+}
+
+func (r *fakeJobRepo) ListJobsNeedingHiringReminder(ctx context.Context, confirmedBefore time.Time, limit int) ([]*domain.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	jobs := []*domain.Job{}
+	for _, job := range r.listed {
+		if len(jobs) < limit && job.HiringReminderSentAt == nil && job.HiringConfirmed().Before(confirmedBefore) {
+			clone := *job
+			jobs = append(jobs, &clone)
+		}
+	}
+	return jobs, nil
+}
+
+func (r *fakeJobRepo) MarkHiringReminderSent(ctx context.Context, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if job, ok := r.jobs[id.Hex()]; ok {
+		now := time.Now()
+		job.HiringReminderSentAt = &now
+	}
+	return nil
+}
+
+func (r *fakeJobRepo) FeatureJob(ctx context.Context, id, companyID string, since, until time.Time, maxFeatured int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok || job.CreatedBy != companyID {
+		return domain.ErrJobNotFound
+	}
+
+	now := time.Now()
+	featured := 0
+	for _, other := range r.jobs {
+		if other.CreatedBy == companyID && (other == job || other.Featured(now)) {
+			featured++
+		}
+	}
+	if featured > maxFeatured {
+		return domain.ErrFeaturedJobsLimit
+	}
+	job.FeaturedSince, job.FeaturedUntil = &since, &until
+	return nil
+}
+
+func (r *fakeJobRepo) ListSimilarJobs(ctx context.Context, job *domain.Job, exclusions domain.JobExclusions, limit int) ([]*domain.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.similarExclusions = append(r.similarExclusions, exclusions)
+	return []*domain.Job{}, nil
+}
+
+func (r *fakeJobRepo) AddAuditEntry(ctx context.Context, entry *domain.JobAuditEntry) error {
+	return nil
+}
+
+type fakeApplicationRepo struct {
+	repository.ApplicationRepository
+	mu           sync.Mutex
+	applications []*domain.Application
+}
+
+func (r *fakeApplicationRepo) CreateApplication(ctx context.Context, application *domain.Application) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	application.ID = primitive.NewObjectID()
+	application.AppliedAt = time.Now()
+	r.applications = append(r.applications, application)
+	return nil
+}
+
+func (r *fakeApplicationRepo) GetApplicationByApplicantAndJob(ctx context.Context, applicantID, jobID string) (*domain.Application, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, app := range r.applications {
+		if app.ApplicantID == applicantID && app.JobID.Hex() == jobID {
+			return app, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeApplicationRepo) GetJobApplications(ctx context.Context, jobID string, page, limit int) ([]*domain.Application, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var matching []*domain.Application
+	for _, app := range r.applications {
+		if app.JobID.Hex() == jobID {
+			matching = append(matching, app)
+		}
+	}
+
+	start := (page - 1) * limit
+	if start > len(matching) {
+		start = len(matching)
+	}
+	end := start + limit
+	if end > len(matching) {
+		end = len(matching)
+	}
+	return matching[start:end], int64(len(matching)), nil
+}
+
+func (r *fakeApplicationRepo) GetApplicationByID(ctx context.Context, id string) (*domain.Application, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, app := range r.applications {
+		if app.ID.Hex() == id {
+			return app, nil
+		}
+	}
+	return nil, domain.ErrApplicationNotFound
+}
+
+func (r *fakeApplicationRepo) Project(ctx context.Context, aggregate *domain.ApplicationAggregate) error {
+	return nil
+}
+
+type fakeApplicationEventRepo struct {
+	repository.ApplicationEventRepository
+	// events are the recorded streams, an application without one is backfilled
+	events map[primitive.ObjectID][]*domain.ApplicationEvent
+}
+
+func (r *fakeApplicationEventRepo) Append(ctx context.Context, events ...*domain.ApplicationEvent) error {
+	return nil
+}
+
+func (r *fakeApplicationEventRepo) ListByApplication(ctx context.Context, applicationID primitive.ObjectID) ([]*domain.ApplicationEvent, error) {
+	return r.events[applicationID], nil
+}
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	users map[string]*domain.User
+	// errs fails the lookups of some users, e.g. with a database error
+	errs map[string]error
+}
+
+func (r *fakeUserRepo) FindByID(ctx context.Context, id string) (*domain.User, error) {
+	if err := r.errs[id]; err != nil {
+		return nil, err
+	}
+	user, ok := r.users[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	return user, nil
+}
+
+type fakeProfileRepo struct {
+	repository.ApplicantProfileRepository
+	profiles map[string]*domain.ApplicantProfile
+}
+
+func (r *fakeProfileRepo) GetByUserID(ctx context.Context, userID string) (*domain.ApplicantProfile, error) {
+	if profile, ok := r.profiles[userID]; ok {
+		return profile, nil
+	}
+	return &domain.ApplicantProfile{UserID: userID}, nil
+}
+
+func (r *fakeProfileRepo) GetByUserIDs(ctx context.Context, userIDs []string) (map[string]*domain.ApplicantProfile, error) {
+	profiles := make(map[string]*domain.ApplicantProfile, len(userIDs))
+	for _, id := range userIDs {
+		if profile, ok := r.profiles[id]; ok {
+			profiles[id] = profile
+		}
+	}
+	return profiles, nil
+}
+
+type fakeAlertPrefsRepo struct {
+	repository.AlertPreferencesRepository
+	prefs map[string]*domain.AlertPreferences
+}
+
+func (r *fakeAlertPrefsRepo) GetByUserID(ctx context.Context, userID string) (*domain.AlertPreferences, error) {
+	if prefs, ok := r.prefs[userID]; ok {
+		return prefs, nil
+	}
+	return &domain.AlertPreferences{UserID: userID}, nil
+}
+
+type fakeSLAPolicyRepo struct {
+	repository.SLAPolicyRepository
+}
+
+func (r *fakeSLAPolicyRepo) GetByCompanyID(ctx context.Context, companyID string) (*domain.SLAPolicy, error) {
+	return nil, domain.ErrSLAPolicyNotFound
+}
+
+type fakeResumeRepo struct {
+	repository.ResumeRepository
+	resumes map[string]*domain.Resume
+}
+
+func (r *fakeResumeRepo) GetByID(ctx context.Context, id, userID string) (*domain.Resume, error) {
+	resume, ok := r.resumes[id]
+	if !ok || resume.UserID != userID {
+		return nil, domain.ErrResumeNotFound
+	}
+	return resume, nil
+}
+
+// fakeMemberRepo has no team members, every company user acts for themselves
+type fakeMemberRepo struct {
+	repository.CompanyMemberRepository
+}
+
+func (r *fakeMemberRepo) FindByUser(ctx context.Context, userID string) (*domain.CompanyMember, error) {
+	return nil, nil
+}
+
+type fakeStatusTransitionsRepo struct {
+	repository.StatusTransitionsRepository
+	override *domain.StatusTransitionsOverride
+}
+
+func (r *fakeStatusTransitionsRepo) Get(ctx context.Context) (*domain.StatusTransitionsOverride, error) {
+	if r.override == nil {
+		return nil, domain.ErrStatusTransitionsNotFound
+	}
+	return r.override, nil
+}
+
+func (r *fakeStatusTransitionsRepo) Save(ctx context.Context, override *domain.StatusTransitionsOverride) error {
+	override.UpdatedAt = time.Now()
+	r.override = override
+	return nil
+}
+
+func (r *fakeStatusTransitionsRepo) Delete(ctx context.Context) error {
+	r.override = nil
+	return nil
+}
+
+type fakeAuthTokenRepo struct {
+	repository.AuthTokenRepository
+	tokens []*domain.AuthToken
+}
+
+func (r *fakeAuthTokenRepo) ConsumeToken(ctx context.Context, tokenHash string, purpose domain.TokenPurpose) (*domain.AuthToken, error) {
+	for i, token := range r.tokens {
+		if token.TokenHash == tokenHash && token.Purpose == purpose && token.ExpiresAt.After(time.Now()) {
+			r.tokens = append(r.tokens[:i], r.tokens[i+1:]...)
+			return token, nil
+		}
+	}
+	return nil, domain.ErrInvalidToken
+}
+
+type fakeAuthEventRepo struct {
+	repository.AuthEventRepository
+	events []*domain.AuthEvent
+}
+
+func (r *fakeAuthEventRepo) Record(ctx context.Context, event *domain.AuthEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+type fakeMailer struct {
+	mu   sync.Mutex
+	sent []email.Message
+}
+
+func (m *fakeMailer) Send(ctx context.Context, msg email.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+type fakeNotifier struct {
+	NotificationUsecase
+	mu   sync.Mutex
+	sent []domain.NotificationKind
+	// failFor lists the users whose notifications can't be delivered
+	failFor map[string]bool
+	// prefs are the users' notification preferences, the defaults when missing
+	prefs map[string]*domain.NotificationPreferences
+}
+
+func (n *fakeNotifier) Allows(ctx context.Context, userID string, kind domain.NotificationKind) (bool, error) {
+	if prefs, ok := n.prefs[userID]; ok {
+		return prefs.Allows(kind), nil
+	}
+	return domain.DefaultNotificationPreferences(userID).Allows(kind), nil
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, userID string, kind domain.NotificationKind, subject, body string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.failFor[userID] {
+		return errors.New("mail relay unavailable")
+	}
+	n.sent = append(n.sent, kind)
+	return nil
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+	"job-portal-backend/utils"
+)
+
+// feedTokenTouchInterval throttles last_used_at writes, like apiKeyTouchInterval
+const feedTokenTouchInterval = time.Minute
+
+// FeedTokenUsecase manages the tokens external job boards fetch the XML job
+// feed with. With allCompanies the admins' tokens, which list every company's
+// jobs, are managed instead of those of the company userID acts for.
+type FeedTokenUsecase interface {
+	CreateToken(ctx context.Context, userID string, allCompanies bool, req *domain.CreateFeedTokenRequest) (*domain.FeedTokenResponse, error)
+	ListTokens(ctx context.Context, userID string, allCompanies bool) (*domain.FeedTokenResponse, error)
+	RevokeToken(ctx context.Context, userID string, allCompanies bool, tokenID string) (*domain.FeedTokenResponse, error)
+	// Authenticate resolves a raw token sent by a job board
+	Authenticate(ctx context.Context, rawToken string) (*domain.FeedToken, error)
+}
+
+type feedTokenUsecase struct {
+	tokenRepo  repository.FeedTokenRepository
+	userRepo   repository.UserRepository
+	memberRepo repository.CompanyMemberRepository
+	apiBaseURL string
+}
+
+func NewFeedTokenUsecase(tokenRepo repository.FeedTokenRepository, userRepo repository.UserRepository, memberRepo repository.CompanyMemberRepository, apiBaseURL string) FeedTokenUsecase {
+	return &feedTokenUsecase{
+		tokenRepo:  tokenRepo,
+		userRepo:   userRepo,
+		memberRepo: memberRepo,
+		apiBaseURL: strings.TrimRight(apiBaseURL, "/"),
+	}
+}
+
+func (uc *feedTokenUsecase) CreateToken(ctx context.Context, userID string, allCompanies bool, req *domain.CreateFeedTokenRequest) (*domain.FeedTokenResponse, error) {
+	companyID, err := uc.owner(ctx, userID, allCompanies)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := uc.tokenRepo.CountActiveByCompany(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("error counting feed tokens: %w", err)
+	}
+	if count >= domain.MaxFeedTokens {
+		return nil, apperrors.NewConflictError(fmt.Sprintf("At most %d feed tokens can be active", domain.MaxFeedTokens))
+	}
+
+	prefix, err := randomHex(4)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := utils.GenerateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+	rawToken := "jpf_" + prefix + "_" + secret
+
+	token := &domain.FeedToken{
+		CompanyID: companyID,
+		Name:      strings.TrimSpace(req.Name),
+		Prefix:    prefix,
+		TokenHash: utils.HashToken(rawToken),
+		CreatedBy: userID,
+	}
+	if err := uc.tokenRepo.Create(ctx, token); err != nil {
+		return nil, fmt.Errorf("error creating feed token: %w", err)
+	}
+
+	return &domain.FeedTokenResponse{
+		Success: true,
+		Message: "Feed token created successfully. Store the token now, it won't be shown again",
+		Data: &domain.CreatedFeedToken{
+			FeedToken: token,
+			Token:     rawToken,
+			FeedURL:   uc.apiBaseURL + "/feeds/indeed.xml?token=" + url.QueryEscape(rawToken),
+		},
+	}, nil
+}
+
+func (uc *feedTokenUsecase) ListTokens(ctx context.Context, userID string, allCompanies bool) (*domain.FeedTokenResponse, error) {
+	companyID, err := uc.owner(ctx, userID, allCompanies)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := uc.tokenRepo.ListByCompany(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing feed tokens: %w", err)
+	}
+
+	return &domain.FeedTokenResponse{
+		Success: true,
+		Message: "Successfully retrieved feed tokens",
+		Data:    tokens,
+	}, nil
+}
+
+func (uc *feedTokenUsecase) RevokeToken(ctx context.Context, userID string, allCompanies bool, tokenID string) (*domain.FeedTokenResponse, error) {
+	companyID, err := uc.owner(ctx, userID, allCompanies)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.tokenRepo.Revoke(ctx, tokenID, companyID); err != nil {
+		if isNotFound(err, domain.ErrFeedTokenNotFound) {
+			return nil, apperrors.NewNotFoundError("Feed token not found")
+		}
+		return nil, fmt.Errorf("error revoking feed token: %w", err)
+	}
+
+	return &domain.FeedTokenResponse{
+		Success: true,
+		Message: "Feed token revoked successfully",
+	}, nil
+}
+
+func (uc *feedTokenUsecase) Authenticate(ctx context.Context, rawToken string) (*domain.FeedToken, error) {
+	token, err := uc.tokenRepo.FindActiveByHash(ctx, utils.HashToken(rawToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrFeedTokenNotFound) {
+			return nil, apperrors.NewUnauthorizedError("Invalid feed token")
+		}
+		return nil, err
+	}
+
+	// A company's tokens stop working as soon as the company is removed or suspended
+	if token.CompanyID != "" {
+		company, err := uc.userRepo.FindByID(ctx, token.CompanyID)
+		if err != nil {
+			if isNotFound(err, domain.ErrUserNotFound) {
+				return nil, apperrors.NewUnauthorizedError("Invalid feed token")
+			}
+			return nil, err
+		}
+		if company.IsDeleted() {
+			return nil, apperrors.NewUnauthorizedError("Invalid feed token")
+		}
+		if company.IsSuspended() {
+			return nil, errAccountSuspended()
+		}
+	}
+
+	now := time.Now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= feedTokenTouchInterval {
+		// Usage tracking is best effort and must not fail the request
+		_ = uc.tokenRepo.TouchLastUsed(ctx, token.ID, now)
+	}
+
+	return token, nil
+}
+
+// owner returns the company whose tokens are managed, empty for the admins' tokens
+func (uc *feedTokenUsecase) owner(ctx context.Context, userID string, allCompanies bool) (string, error) {
+	if allCompanies {
+		return "", nil
+	}
+	return actingCompany(ctx, uc.memberRepo, userID)
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+type fakeFeedTokenRepo struct {
+	repository.FeedTokenRepository
+	err error
+}
+
+func (r *fakeFeedTokenRepo) FindActiveByHash(ctx context.Context, tokenHash string) (*domain.FeedToken, error) {
+	return nil, r.err
+}
+
+func TestAuthenticateUnknownFeedToken(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"sentinel", domain.ErrFeedTokenNotFound},
+		{"wrapped sentinel", fmt.Errorf("error finding feed token: %w", domain.ErrFeedTokenNotFound)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewFeedTokenUsecase(&fakeFeedTokenRepo{err: tt.err}, &fakeUserRepo{}, &fakeMemberRepo{}, "http://localhost:8080")
+
+			_, err := uc.Authenticate(context.Background(), "unknown")
+			if appErr, ok := apperrors.As(err); !ok || appErr.Code != http.StatusUnauthorized {
+				t.Fatalf("Authenticate() error = %v, want a 401", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// FileAccessUsecase decides who may download the files served by the API
+type FileAccessUsecase interface {
+	// AuthorizeDownload checks the user may download the file stored at url.
+	// Profile pictures are visible to every signed in user, resumes to their
+	// applicant and to the hiring team of the jobs they were sent to, support
+	// attachments to the ticket's author and the admins. Files the user may
+	// not see are reported as not found, so stored keys can't be probed.
+	AuthorizeDownload(ctx context.Context, url, userID, role string) error
+}
+
+type fileAccessUsecase struct {
+	userRepo   repository.UserRepository
+	resumeRepo repository.ResumeRepository
+	appRepo    repository.ApplicationRepository
+	jobRepo    repository.JobRepository
+	memberRepo repository.CompanyMemberRepository
+	ticketRepo repository.SupportTicketRepository
+}
+
+func NewFileAccessUsecase(userRepo repository.UserRepository, resumeRepo repository.ResumeRepository, appRepo repository.ApplicationRepository, jobRepo repository.JobRepository, memberRepo repository.CompanyMemberRepository, ticketRepo repository.SupportTicketRepository) FileAccessUsecase {
+	return &fileAccessUsecase{
+		userRepo:   userRepo,
+		resumeRepo: resumeRepo,
+		appRepo:    appRepo,
+		jobRepo:    jobRepo,
+		memberRepo: memberRepo,
+		ticketRepo: ticketRepo,
+	}
+}
+
+func (uc *fileAccessUsecase) AuthorizeDownload(ctx context.Context, url, userID, role string) error {
+	allowed, err := uc.allowed(ctx, url, userID, domain.Role(role))
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apperrors.NewNotFoundError("File not found")
+	}
+	return nil
+}
+
+// allowed looks the file up everywhere files are referenced
+func (uc *fileAccessUsecase) allowed(ctx context.Context, url, userID string, role domain.Role) (bool, error) {
+	avatar, err := uc.userRepo.AvatarInUse(ctx, url)
+	if err != nil {
+		return false, fmt.Errorf("error checking avatars: %w", err)
+	}
+	if avatar {
+		return true, nil
+	}
+
+	resumes, err := uc.resumeRepo.ListByURL(ctx, url)
+	if err != nil {
+		return false, fmt.Errorf("error checking resumes: %w", err)
+	}
+	for _, resume := range resumes {
+		if resume.UserID == userID {
+			return true, nil
+		}
+	}
+
+	applications, err := uc.appRepo.ListByResumeFile(ctx, url)
+	if err != nil {
+		return false, fmt.Errorf("error checking applications: %w", err)
+	}
+	for _, application := range applications {
+		allowed, err := uc.canSeeResume(ctx, application, userID, role)
+		if err != nil || allowed {
+			return allowed, err
+		}
+	}
+
+	ticket, err := uc.ticketRepo.GetByAttachmentURL(ctx, url)
+	if err != nil {
+		if errors.Is(err, domain.ErrSupportTicketNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error checking support tickets: %w", err)
+	}
+	return ticket.UserID == userID || role == domain.Admin, nil
+}
+
+// canSeeResume applies the rules of the application endpoints: the applicant,
+// auditors and the hiring team of the job, unless it screens the application blind
+func (uc *fileAccessUsecase) canSeeResume(ctx context.Context, application *domain.Application, userID string, role domain.Role) (bool, error) {
+	switch {
+	case application.ApplicantID == userID, role == domain.Auditor:
+		return true, nil
+	case role != domain.Company:
+		return false, nil
+	}
+
+	job, err := uc.jobRepo.GetJobByID(ctx, application.JobID.Hex())
+	if err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error getting job: %w", err)
+	}
+	companyID, err := actingCompany(ctx, uc.memberRepo, userID)
+	if err != nil {
+		return false, err
+	}
+	return job.CreatedBy == companyID && !job.BlindsApplication(application.Status), nil
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// followerNoticeBatch bounds the jobs announced to followers per run
+const followerNoticeBatch = 50
+
+// FollowUsecase manages the companies applicants follow and announces new jobs to followers
+type FollowUsecase interface {
+	Follow(ctx context.Context, userID, companyID string) (*domain.FollowResponse, error)
+	Unfollow(ctx context.Context, userID, companyID string) (*domain.FollowResponse, error)
+	// ListFollowing returns the companies the user follows, most recently followed first
+	ListFollowing(ctx context.Context, userID string, page, limit int) (*domain.FollowListResponse, error)
+	// NotifyFollowers tells followers about the jobs their companies published since the last run
+	NotifyFollowers(ctx context.Context) error
+}
+
+type followUsecase struct {
+	followRepo         repository.FollowRepository
+	userRepo           repository.UserRepository
+	jobRepo            repository.JobRepository
+	companyProfileRepo repository.CompanyProfileRepository
+	notifier           NotificationUsecase
+	frontendURL        string
+}
+
+func NewFollowUsecase(followRepo repository.FollowRepository, userRepo repository.UserRepository, jobRepo repository.JobRepository, companyProfileRepo repository.CompanyProfileRepository, notifier NotificationUsecase, frontendURL string) FollowUsecase {
+	return &followUsecase{
+		followRepo:         followRepo,
+		userRepo:           userRepo,
+		jobRepo:            jobRepo,
+		companyProfileRepo: companyProfileRepo,
+		notifier:           notifier,
+		frontendURL:        frontendURL,
+	}
+}
+
+func (uc *followUsecase) Follow(ctx context.Context, userID, companyID string) (*domain.FollowResponse, error) {
+	if _, err := uc.getCompany(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	follow, err := uc.followRepo.Follow(ctx, userID, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("error following company: %w", err)
+	}
+
+	return &domain.FollowResponse{
+		Success: true,
+		Message: "Company followed successfully",
+		Data:    follow,
+	}, nil
+}
+
+func (uc *followUsecase) Unfollow(ctx context.Context, userID, companyID string) (*domain.FollowResponse, error) {
+	if err := uc.followRepo.Unfollow(ctx, userID, companyID); err != nil {
+		if errors.Is(err, domain.ErrFollowNotFound) {
+			return nil, apperrors.NewNotFoundError("You don't follow this company")
+		}
+		return nil, fmt.Errorf("error unfollowing company: %w", err)
+	}
+
+	return &domain.FollowResponse{
+		Success: true,
+		Message: "Company unfollowed successfully",
+	}, nil
+}
+
+func (uc *followUsecase) ListFollowing(ctx context.Context, userID string, page, limit int) (*domain.FollowListResponse, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	follows, total, err := uc.followRepo.ListByUser(ctx, userID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing followed companies: %w", err)
+	}
+
+	companies := make([]*domain.FollowedCompany, 0, len(follows))
+	for _, follow := range follows {
+		company, err := uc.userRepo.FindByID(ctx, follow.CompanyID)
+		if err != nil {
+			continue // Skip companies that no longer exist
+		}
+		profile, err := uc.companyProfileRepo.GetByCompanyID(ctx, follow.CompanyID)
+		if err != nil && !errors.Is(err, domain.ErrCompanyProfileNotFound) {
+			return nil, fmt.Errorf("error retrieving company profile: %w", err)
+		}
+		companies = append(companies, &domain.FollowedCompany{
+			Company:    company.CompanyInfo(profile),
+			FollowedAt: follow.CreatedAt,
+		})
+	}
+
+	// Calculate total pages
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return &domain.FollowListResponse{
+		Success:    true,
+		Message:    "Successfully retrieved followed companies",
+		Data:       companies,
+		PageNumber: page,
+		PageSize:   len(companies),
+		TotalItems: total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (uc *followUsecase) NotifyFollowers(ctx context.Context) error {
+	jobs, err := uc.jobRepo.ListJobsAwaitingFollowerNotice(ctx, followerNoticeBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		followerIDs, err := uc.followRepo.ListFollowerIDs(ctx, job.CreatedBy)
+		if err != nil {
+			return err
+		}
+
+		if len(followerIDs) > 0 {
+			companyName := "A company you follow"
+			if company, err := uc.userRepo.FindByID(ctx, job.CreatedBy); err == nil {
+				companyName = company.Name
+			}
+
+			subject := fmt.Sprintf("%s posted a new job: %s", companyName, job.Title)
+			body := fmt.Sprintf("%s, a company you follow, is hiring for \"%s\". See the job here: %s/jobs/%s",
+				companyName, job.Title, uc.frontendURL, job.ID.Hex())
+			for _, followerID := range followerIDs {
+				// One undeliverable follower doesn't hold up the others
+				if err := uc.notifier.Notify(ctx, followerID, domain.NotificationFollowedCompanyJob, subject, body); err != nil {
+					log.Printf("Failed to notify follower %s of job %s: %v", followerID, job.ID.Hex(), err)
+				}
+			}
+		}
+
+		if err := uc.jobRepo.MarkFollowersNotified(ctx, job.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getCompany returns the company account, treating other roles and closed accounts as not found
+func (uc *followUsecase) getCompany(ctx context.Context, companyID string) (*domain.User, error) {
+	company, err := uc.userRepo.FindByID(ctx, companyID)
+	if err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewNotFoundError("Company not found")
+		}
+		return nil, fmt.Errorf("error retrieving company: %w", err)
+	}
+	if company.Role != domain.Company || company.IsSuspended() || company.IsDeleted() {
+		return nil, apperrors.NewNotFoundError("Company not found")
+	}
+	return company, nil
+}
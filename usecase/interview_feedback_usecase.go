@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// feedbackRequestBatch bounds how many feedback requests one run sends
+const feedbackRequestBatch = 200
+
+// feedbackCommentLimit bounds how many comments the admin report lists
+const feedbackCommentLimit = 50
+
+// InterviewFeedbackUsecase collects applicants' feedback on how their
+// interviews were run. Feedback is stored without the applicant's identity
+// and only ever shown aggregated per company.
+type InterviewFeedbackUsecase interface {
+	// ScheduleInterview sets when an application in the Interview stage is interviewed
+	ScheduleInterview(ctx context.Context, applicationID, userID string, req *domain.ScheduleInterviewRequest) (*domain.ApplicationResponse, error)
+	// SubmitFeedback records the applicant's feedback once the interview took place
+	SubmitFeedback(ctx context.Context, applicationID, applicantID string, req *domain.SubmitInterviewFeedbackRequest) (*domain.InterviewFeedbackResponse, error)
+	// RequestFeedback asks applicants whose interview is over for feedback
+	RequestFeedback(ctx context.Context) error
+	// CompanySummary returns the feedback summary of the user's company, when
+	// sharing is enabled and enough applicants answered to keep them anonymous
+	CompanySummary(ctx context.Context, userID string) (*domain.InterviewFeedbackResponse, error)
+	// AdminSummaries returns the feedback summary of every company
+	AdminSummaries(ctx context.Context) (*domain.InterviewFeedbackResponse, error)
+	// AdminCompanyReport returns a company's summary and recent comments
+	AdminCompanyReport(ctx context.Context, companyID string) (*domain.InterviewFeedbackResponse, error)
+}
+
+type interviewFeedbackUsecase struct {
+	feedbackRepo repository.InterviewFeedbackRepository
+	appRepo      repository.ApplicationRepository
+	jobRepo      repository.JobRepository
+	userRepo     repository.UserRepository
+	memberRepo   repository.CompanyMemberRepository
+	notifier     NotificationUsecase
+	scheduling   SchedulingUsecase
+	frontendURL  string
+	// shareWithCompanies lets companies see their own summary
+	shareWithCompanies bool
+}
+
+func NewInterviewFeedbackUsecase(feedbackRepo repository.InterviewFeedbackRepository, appRepo repository.ApplicationRepository, jobRepo repository.JobRepository, userRepo repository.UserRepository, memberRepo repository.CompanyMemberRepository, notifier NotificationUsecase, scheduling SchedulingUsecase, frontendURL string, shareWithCompanies bool) InterviewFeedbackUsecase {
+	return &interviewFeedbackUsecase{
+		feedbackRepo:       feedbackRepo,
+		appRepo:            appRepo,
+		jobRepo:            jobRepo,
+		userRepo:           userRepo,
+		memberRepo:         memberRepo,
+		notifier:           notifier,
+		scheduling:         scheduling,
+		frontendURL:        frontendURL,
+		shareWithCompanies: shareWithCompanies,
+	}
+}
+
+func (uc *interviewFeedbackUsecase) ScheduleInterview(ctx context.Context, applicationID, userID string, req *domain.ScheduleInterviewRequest) (*domain.ApplicationResponse, error) {
+	application, job, companyID, err := getOwnedApplication(ctx, uc.appRepo, uc.jobRepo, uc.memberRepo, applicationID, userID,
+		"You don't have permission to schedule interviews for this application")
+	if err != nil {
+		return nil, err
+	}
+
+	if application.Status != domain.StatusInterview {
+		return nil, apperrors.NewConflictError("Only applications in the Interview stage can have an interview scheduled")
+	}
+	if !req.ScheduledAt.After(time.Now()) {
+		return nil, apperrors.NewBadRequestError("Validation failed", []string{"The interview must be scheduled in the future"})
+	}
+
+	if err := uc.appRepo.ScheduleInterview(ctx, applicationID, req.ScheduledAt); err != nil {
+		return nil, fmt.Errorf("error scheduling interview: %w", err)
+	}
+	scheduledAt := req.ScheduledAt
+	application.InterviewScheduledAt = &scheduledAt
+
+	// Unusual slots are still scheduled, the company is only warned
+	warnings, err := uc.scheduling.SlotWarnings(ctx, companyID, scheduledAt)
+	if err != nil {
+		log.Printf("Failed to check interview slot for application %s: %v", applicationID, err)
+	}
+
+	if application.ApplicantID != "" {
+		err := uc.notifier.Notify(ctx, application.ApplicantID, domain.NotificationStatusChange,
+			fmt.Sprintf("Your interview for %s is scheduled", job.Title),
+			fmt.Sprintf("Your interview for \"%s\" is scheduled for %s.\n\nSee your application here: %s/applications/%s",
+				job.Title, scheduledAt.UTC().Format("Monday, January 2, 15:04 MST"), uc.frontendURL, applicationID))
+		if err != nil {
+			log.Printf("Failed to notify applicant of interview for application %s: %v", applicationID, err)
+		}
+	}
+
+	return &domain.ApplicationResponse{
+		Success:  true,
+		Message:  "Interview scheduled successfully",
+		Data:     application,
+		Warnings: warnings,
+	}, nil
+}
+
+func (uc *interviewFeedbackUsecase) SubmitFeedback(ctx context.Context, applicationID, applicantID string, req *domain.SubmitInterviewFeedbackRequest) (*domain.InterviewFeedbackResponse, error) {
+	application, err := uc.appRepo.GetApplicationByID(ctx, applicationID)
+	if err != nil {
+		if isNotFound(err, domain.ErrApplicationNotFound) {
+			return nil, apperrors.NewNotFoundError("Application not found")
+		}
+		return nil, fmt.Errorf("error getting application: %w", err)
+	}
+	if application.ApplicantID != applicantID {
+		return nil, errForbidden("You don't have permission to give feedback on this application")
+	}
+	if application.InterviewScheduledAt == nil || application.InterviewScheduledAt.After(time.Now()) {
+		return nil, apperrors.NewConflictError("Feedback can only be given once the interview took place")
+	}
+
+	job, err := uc.jobRepo.GetJobByID(ctx, application.JobID.Hex())
+	if err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, apperrors.NewNotFoundError("Job not found")
+		}
+		return nil, fmt.Errorf("error checking job: %w", err)
+	}
+
+	feedback := &domain.InterviewFeedback{
+		CompanyID:     job.CreatedBy,
+		JobID:         job.ID.Hex(),
+		ApplicationID: applicationID,
+		Ratings:       req.Ratings,
+		Comment:       req.Comment,
+	}
+	if err := uc.feedbackRepo.Create(ctx, feedback); err != nil {
+		if errors.Is(err, domain.ErrInterviewFeedbackExists) {
+			return nil, apperrors.NewConflictError("You already gave feedback on this interview")
+		}
+		return nil, fmt.Errorf("error saving interview feedback: %w", err)
+	}
+
+	return &domain.InterviewFeedbackResponse{
+		Success: true,
+		Message: "Thank you for your feedback",
+	}, nil
+}
+
+func (uc *interviewFeedbackUsecase) RequestFeedback(ctx context.Context) error {
+	applications, err := uc.appRepo.ListAwaitingFeedbackRequest(ctx, time.Now().Add(-domain.InterviewFeedbackDelay), feedbackRequestBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, application := range applications {
+		job, err := uc.jobRepo.GetJobByID(ctx, application.JobID.Hex())
+		if err != nil {
+			log.Printf("Skipping feedback request for application %s: %v", application.ID.Hex(), err)
+			continue
+		}
+
+		err = uc.notifier.Notify(ctx, application.ApplicantID, domain.NotificationInterviewFeedback,
+			fmt.Sprintf("How was your interview for %s?", job.Title),
+			fmt.Sprintf("Tell us how your interview for \"%s\" went. We ask about the process, not the outcome, and your answers are anonymous.\n\nGive your feedback here: %s/applications/%s/interview-feedback",
+				job.Title, uc.frontendURL, application.ID.Hex()))
+		if err != nil {
+			return fmt.Errorf("error requesting interview feedback: %w", err)
+		}
+
+		if err := uc.appRepo.MarkFeedbackRequested(ctx, application.ID); err != nil {
+			return fmt.Errorf("error marking feedback requested: %w", err)
+		}
+	}
+	return nil
+}
+
+func (uc *interviewFeedbackUsecase) CompanySummary(ctx context.Context, userID string) (*domain.InterviewFeedbackResponse, error) {
+	if !uc.shareWithCompanies {
+		return nil, errForbidden("Interview feedback isn't shared with companies")
+	}
+
+	companyID, err := actingCompany(ctx, uc.memberRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := uc.feedbackRepo.Summaries(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("error summarizing interview feedback: %w", err)
+	}
+	if len(summaries) == 0 || summaries[0].Responses < domain.MinFeedbackResponses {
+		return &domain.InterviewFeedbackResponse{
+			Success: true,
+			Message: fmt.Sprintf("Feedback is shown once at least %d applicants answered", domain.MinFeedbackResponses),
+		}, nil
+	}
+
+	return &domain.InterviewFeedbackResponse{
+		Success: true,
+		Message: "Successfully retrieved interview feedback",
+		Data:    summaries[0],
+	}, nil
+}
+
+func (uc *interviewFeedbackUsecase) AdminSummaries(ctx context.Context) (*domain.InterviewFeedbackResponse, error) {
+	summaries, err := uc.feedbackRepo.Summaries(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("error summarizing interview feedback: %w", err)
+	}
+	for _, summary := range summaries {
+		uc.setCompanyName(ctx, summary)
+	}
+
+	return &domain.InterviewFeedbackResponse{
+		Success: true,
+		Message: "Successfully retrieved interview feedback",
+		Data:    summaries,
+	}, nil
+}
+
+func (uc *interviewFeedbackUsecase) AdminCompanyReport(ctx context.Context, companyID string) (*domain.InterviewFeedbackResponse, error) {
+	summaries, err := uc.feedbackRepo.Summaries(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("error summarizing interview feedback: %w", err)
+	}
+	if len(summaries) == 0 {
+		return nil, apperrors.NewNotFoundError("No interview feedback for this company")
+	}
+	uc.setCompanyName(ctx, summaries[0])
+
+	comments, err := uc.feedbackRepo.Comments(ctx, companyID, feedbackCommentLimit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing interview feedback comments: %w", err)
+	}
+
+	return &domain.InterviewFeedbackResponse{
+		Success: true,
+		Message: "Successfully retrieved interview feedback",
+		Data: &domain.InterviewFeedbackReport{
+			Summary:  summaries[0],
+			Comments: comments,
+		},
+	}, nil
+}
+
+// setCompanyName fills in the summary's company name, left empty when the
+// company account no longer exists
+func (uc *interviewFeedbackUsecase) setCompanyName(ctx context.Context, summary *domain.InterviewFeedbackSummary) {
+	if company, err := uc.userRepo.FindByID(ctx, summary.CompanyID); err == nil {
+		summary.CompanyName = company.Name
+	}
+}
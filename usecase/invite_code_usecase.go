@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// inviteCodeAlphabet leaves out characters that are easily confused (0/O, 1/I/L)
+const inviteCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// inviteCodeLength is the length of generated invite codes
+const inviteCodeLength = 10
+
+// InviteCodeUsecase manages the invite codes required to sign up while the portal is invite-only
+type InviteCodeUsecase interface {
+	CreateCode(ctx context.Context, req *domain.CreateInviteCodeRequest, adminID string) (*domain.InviteCodeResponse, error)
+	// ListCodes returns every code with its usage, most recent first
+	ListCodes(ctx context.Context) (*domain.InviteCodeResponse, error)
+	// DisableCode stops the code from being used, accounts created with it are kept
+	DisableCode(ctx context.Context, id string) (*domain.InviteCodeResponse, error)
+}
+
+type inviteCodeUsecase struct {
+	inviteRepo repository.InviteCodeRepository
+}
+
+func NewInviteCodeUsecase(inviteRepo repository.InviteCodeRepository) InviteCodeUsecase {
+	return &inviteCodeUsecase{
+		inviteRepo: inviteRepo,
+	}
+}
+
+func (uc *inviteCodeUsecase) CreateCode(ctx context.Context, req *domain.CreateInviteCodeRequest, adminID string) (*domain.InviteCodeResponse, error) {
+	code := &domain.InviteCode{
+		Code:      strings.ToUpper(req.Code),
+		Role:      req.Role,
+		MaxUses:   req.MaxUses,
+		ExpiresAt: req.ExpiresAt,
+		Note:      strings.TrimSpace(req.Note),
+		CreatedBy: adminID,
+	}
+	if code.Code == "" {
+		generated, err := generateInviteCode()
+		if err != nil {
+			return nil, fmt.Errorf("error generating invite code: %w", err)
+		}
+		code.Code = generated
+	}
+
+	if err := uc.inviteRepo.Create(ctx, code); err != nil {
+		if errors.Is(err, domain.ErrInviteCodeExists) {
+			return nil, apperrors.NewConflictError("An invite code with this code already exists")
+		}
+		return nil, fmt.Errorf("error creating invite code: %w", err)
+	}
+
+	return &domain.InviteCodeResponse{
+		Success: true,
+		Message: "Invite code created successfully",
+		Data:    code,
+	}, nil
+}
+
+func (uc *inviteCodeUsecase) ListCodes(ctx context.Context) (*domain.InviteCodeResponse, error) {
+	codes, err := uc.inviteRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing invite codes: %w", err)
+	}
+
+	return &domain.InviteCodeResponse{
+		Success: true,
+		Message: "Successfully retrieved invite codes",
+		Data:    codes,
+	}, nil
+}
+
+func (uc *inviteCodeUsecase) DisableCode(ctx context.Context, id string) (*domain.InviteCodeResponse, error) {
+	if err := uc.inviteRepo.Disable(ctx, id); err != nil {
+		if isNotFound(err, domain.ErrInviteCodeNotFound) {
+			return nil, apperrors.NewNotFoundError("Invite code not found")
+		}
+		return nil, fmt.Errorf("error disabling invite code: %w", err)
+	}
+
+	return &domain.InviteCodeResponse{
+		Success: true,
+		Message: "Invite code disabled successfully",
+	}, nil
+}
+
+// generateInviteCode returns a random code that is easy to read out and type
+func generateInviteCode() (string, error) {
+	max := big.NewInt(int64(len(inviteCodeAlphabet)))
+	code := make([]byte, inviteCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = inviteCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// JobApplyClickUsecase sends applicants to the external apply links of jobs
+// and counts the clicks for their companies
+type JobApplyClickUsecase interface {
+	// RecordClick counts a click on the apply link of a listed job and returns the link
+	RecordClick(ctx context.Context, jobID string, signedIn bool) (string, error)
+	// GetClickStats sums up the clicks on the apply link of a job of the company userID acts for
+	GetClickStats(ctx context.Context, jobID, userID string) (*domain.JobApplyClickResponse, error)
+}
+
+type jobApplyClickUsecase struct {
+	jobRepo    repository.JobRepository
+	clickRepo  repository.JobApplyClickRepository
+	memberRepo repository.CompanyMemberRepository
+}
+
+func NewJobApplyClickUsecase(jobRepo repository.JobRepository, clickRepo repository.JobApplyClickRepository, memberRepo repository.CompanyMemberRepository) JobApplyClickUsecase {
+	return &jobApplyClickUsecase{
+		jobRepo:    jobRepo,
+		clickRepo:  clickRepo,
+		memberRepo: memberRepo,
+	}
+}
+
+func (uc *jobApplyClickUsecase) RecordClick(ctx context.Context, jobID string, signedIn bool) (string, error) {
+	job, err := uc.jobRepo.GetJobByID(ctx, jobID)
+	if err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return "", apperrors.NewNotFoundError("Job not found")
+		}
+		return "", err
+	}
+
+	// Only the jobs applicants can see take applications
+	now := time.Now()
+	if !job.IsPublished || job.Expired(now) || job.PastDeadline(now) || job.IsClosed() || job.ApplyURL == "" {
+		return "", apperrors.NewNotFoundError("Job not found")
+	}
+
+	click := &domain.JobApplyClick{
+		JobID:    job.ID,
+		SignedIn: signedIn,
+		Country:  domain.ClientInfoFromContext(ctx).Country,
+		At:       now,
+	}
+	// Counting is best effort, the applicant is sent on either way
+	if err := uc.clickRepo.Create(ctx, click); err != nil {
+		log.Printf("Failed to record apply click on job %s: %v", jobID, err)
+	}
+
+	return job.ApplyURL, nil
+}
+
+func (uc *jobApplyClickUsecase) GetClickStats(ctx context.Context, jobID, userID string) (*domain.JobApplyClickResponse, error) {
+	job, err := uc.jobRepo.GetJobByID(ctx, jobID)
+	if err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, apperrors.NewNotFoundError("Job not found")
+		}
+		return nil, err
+	}
+
+	companyID, err := actingCompany(ctx, uc.memberRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if job.CreatedBy != companyID {
+		return nil, errForbidden("You don't have permission to view this job's apply clicks")
+	}
+
+	dailySince := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(domain.JobApplyClickDays - 1))
+	stats, err := uc.clickRepo.Stats(ctx, job.ID, dailySince)
+	if err != nil {
+		return nil, err
+	}
+	stats.ApplyURL = job.ApplyURL
+
+	return &domain.JobApplyClickResponse{
+		Success: true,
+		Message: "Successfully retrieved apply clicks",
+		Data:    stats,
+	}, nil
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// JobComparisonUsecase compares published jobs side by side so applicants can
+// decide where to apply first
+type JobComparisonUsecase interface {
+	// CompareJobs compares the jobs, with salaries per year in the requested
+	// currency (or the currency of the requester's country) when rates are available
+	CompareJobs(ctx context.Context, jobIDs []string, requestedCurrency string) (*domain.JobComparison, error)
+}
+
+type jobComparisonUsecase struct {
+	jobs         JobUseCase
+	appRepo      repository.ApplicationRepository
+	feedbackRepo repository.InterviewFeedbackRepository
+}
+
+func NewJobComparisonUsecase(jobs JobUseCase, appRepo repository.ApplicationRepository, feedbackRepo repository.InterviewFeedbackRepository) JobComparisonUsecase {
+	return &jobComparisonUsecase{
+		jobs:         jobs,
+		appRepo:      appRepo,
+		feedbackRepo: feedbackRepo,
+	}
+}
+
+func (uc *jobComparisonUsecase) CompareJobs(ctx context.Context, jobIDs []string, requestedCurrency string) (*domain.JobComparison, error) {
+	jobIDs = normalizeList(jobIDs, true)
+	if len(jobIDs) < domain.MinComparedJobs || len(jobIDs) > domain.MaxComparedJobs {
+		return nil, apperrors.NewBadRequestError("Invalid comparison", []string{fmt.Sprintf("ids must list %d to %d different jobs", domain.MinComparedJobs, domain.MaxComparedJobs)})
+	}
+
+	jobs := make([]*domain.Job, 0, len(jobIDs))
+	companyIDs := []string{}
+	seenCompanies := map[string]bool{}
+	for _, id := range jobIDs {
+		job, err := uc.jobs.GetJobByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !job.IsPublished {
+			return nil, apperrors.NewNotFoundError("Job not found")
+		}
+		jobs = append(jobs, job)
+
+		if !seenCompanies[job.CreatedBy] {
+			seenCompanies[job.CreatedBy] = true
+			companyIDs = append(companyIDs, job.CreatedBy)
+		}
+	}
+	uc.jobs.ConvertSalaries(ctx, requestedCurrency, jobs...)
+
+	responseTimes, err := uc.appRepo.ResponseTimes(ctx, companyIDs, time.Now().Add(-domain.ResponseTimeWindow), domain.MinResponseTimeSamples)
+	if err != nil {
+		return nil, fmt.Errorf("error computing response times: %w", err)
+	}
+
+	companies := make(map[string]*domain.CompanyInfo, len(companyIDs))
+	ratings := make(map[string]float64, len(companyIDs))
+	for _, companyID := range companyIDs {
+		if companies[companyID], err = uc.jobs.GetCompanyInfo(ctx, companyID); err != nil {
+			return nil, fmt.Errorf("error retrieving company: %w", err)
+		}
+
+		summaries, err := uc.feedbackRepo.Summaries(ctx, companyID)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving interview feedback: %w", err)
+		}
+		// Like the company's own feedback view, ratings stay hidden until they can't point at anyone
+		if len(summaries) > 0 && summaries[0].Responses >= domain.MinFeedbackResponses {
+			ratings[companyID] = summaries[0].Averages.Overall
+		}
+	}
+
+	comparison := &domain.JobComparison{Jobs: make([]*domain.ComparedJob, 0, len(jobs))}
+	for _, job := range jobs {
+		compared := &domain.ComparedJob{
+			ID:              job.ID.Hex(),
+			Title:           job.Title,
+			Company:         companies[job.CreatedBy],
+			Location:        job.Location,
+			Remote:          job.Remote,
+			EmploymentType:  job.EmploymentType,
+			ExperienceLevel: job.ExperienceLevel,
+			Salary:          domain.YearlySalary(job.Salary),
+			Skills:          job.Skills,
+			Deadline:        job.Deadline,
+		}
+		if compared.Skills == nil {
+			compared.Skills = []string{}
+		}
+		if rating, ok := ratings[job.CreatedBy]; ok {
+			compared.CompanyRating = &rating
+		}
+		if days, ok := responseTimes[job.CreatedBy]; ok {
+			rounded := math.Round(days*10) / 10
+			compared.ResponseTimeDays = &rounded
+		}
+		comparison.Jobs = append(comparison.Jobs, compared)
+	}
+	comparison.SharedSkills = sharedSkills(jobs)
+
+	return comparison, nil
+}
+
+// sharedSkills returns the skills every job requires, in the order of the first job
+func sharedSkills(jobs []*domain.Job) []string {
+	counts := map[string]int{}
+	for _, job := range jobs {
+		for _, skill := range normalizeList(job.Skills, false) {
+			counts[skill]++
+		}
+	}
+
+	shared := []string{}
+	for _, skill := range jobs[0].Skills {
+		if counts[skill] == len(jobs) {
+			shared = append(shared, skill)
+			counts[skill] = 0
+		}
+	}
+	return shared
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// JobFeedUsecase lists published jobs for syndication: feeds that aggregators
+// and feed readers subscribe to
+type JobFeedUsecase interface {
+	// ListLatest returns the latest published jobs matching filter, the most
+	// recently posted first, with the companies that posted them
+	ListLatest(ctx context.Context, filter domain.JobFilter) ([]*domain.FeedJob, error)
+	// ListForBoard returns the published jobs of the company, or of every
+	// company when companyID is empty, for an external job board
+	ListForBoard(ctx context.Context, companyID string) ([]*domain.FeedJob, error)
+	// GetJobPosting describes a published job as a schema.org JobPosting
+	GetJobPosting(ctx context.Context, jobID string) (*domain.JobPosting, error)
+	// JobURL returns the address of the job's page on the web client
+	JobURL(id primitive.ObjectID, title string) string
+	// RefreshSitemap regenerates the sitemap, which is cached in between
+	RefreshSitemap(ctx context.Context) error
+	// Sitemap returns the URLs of the job pages for search engines, and when they were collected
+	Sitemap(ctx context.Context) ([]domain.SitemapURL, time.Time, error)
+}
+
+type jobFeedUsecase struct {
+	jobRepo     repository.JobRepository
+	jobs        JobUseCase
+	frontendURL string
+
+	sitemapMu sync.RWMutex
+	sitemap   []domain.SitemapURL
+	sitemapAt time.Time
+}
+
+func NewJobFeedUsecase(jobRepo repository.JobRepository, jobs JobUseCase, frontendURL string) JobFeedUsecase {
+	return &jobFeedUsecase{
+		jobRepo:     jobRepo,
+		jobs:        jobs,
+		frontendURL: strings.TrimRight(frontendURL, "/"),
+	}
+}
+
+func (uc *jobFeedUsecase) ListLatest(ctx context.Context, filter domain.JobFilter) ([]*domain.FeedJob, error) {
+	jobs, err := uc.jobRepo.ListLatest(ctx, filter, domain.JobFeedSize)
+	if err != nil {
+		return nil, fmt.Errorf("error listing latest jobs: %w", err)
+	}
+	setComputedFields(jobs...)
+
+	return uc.withCompanies(ctx, jobs)
+}
+
+func (uc *jobFeedUsecase) ListForBoard(ctx context.Context, companyID string) ([]*domain.FeedJob, error) {
+	// The listing filter matches the company name against the owning company's ID
+	jobs, err := uc.jobRepo.ListLatest(ctx, domain.JobFilter{CompanyName: companyID}, domain.MaxBoardFeedJobs)
+	if err != nil {
+		return nil, fmt.Errorf("error listing jobs: %w", err)
+	}
+	setComputedFields(jobs...)
+
+	return uc.withCompanies(ctx, jobs)
+}
+
+func (uc *jobFeedUsecase) GetJobPosting(ctx context.Context, jobID string) (*domain.JobPosting, error) {
+	job, err := uc.jobs.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	// Only listed jobs may be indexed, a posting past its validThrough is an error to Google
+	if !job.IsPublished || job.Expired(time.Now()) {
+		return nil, apperrors.NewNotFoundError("Job not found")
+	}
+
+	jobs, err := uc.withCompanies(ctx, []*domain.Job{job})
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewJobPosting(jobs[0], uc.JobURL(job.ID, job.Title)), nil
+}
+
+func (uc *jobFeedUsecase) JobURL(id primitive.ObjectID, title string) string {
+	return fmt.Sprintf("%s/jobs/%s", uc.frontendURL, domain.JobSlug(id, title))
+}
+
+// withCompanies looks up the company of each job, once per company
+func (uc *jobFeedUsecase) withCompanies(ctx context.Context, jobs []*domain.Job) ([]*domain.FeedJob, error) {
+	companies := map[string]*domain.CompanyInfo{}
+	feed := make([]*domain.FeedJob, 0, len(jobs))
+	for _, job := range jobs {
+		company, ok := companies[job.CreatedBy]
+		if !ok {
+			var err error
+			company, err = uc.jobs.GetCompanyInfo(ctx, job.CreatedBy)
+			if err != nil {
+				return nil, fmt.Errorf("error getting company: %w", err)
+			}
+			companies[job.CreatedBy] = company
+		}
+		feed = append(feed, &domain.FeedJob{Job: job, Company: company})
+	}
+	return feed, nil
+}
+
+func (uc *jobFeedUsecase) RefreshSitemap(ctx context.Context) error {
+	jobs, err := uc.jobRepo.ListSitemapJobs(ctx, domain.MaxSitemapURLs-1)
+	if err != nil {
+		return fmt.Errorf("error listing sitemap jobs: %w", err)
+	}
+
+	now := time.Now()
+	urls := make([]domain.SitemapURL, 0, len(jobs)+1)
+	// The job listing changes whenever a job is posted
+	urls = append(urls, domain.SitemapURL{Loc: uc.frontendURL + "/jobs", LastMod: now})
+	for _, job := range jobs {
+		urls = append(urls, domain.SitemapURL{
+			Loc:     uc.JobURL(job.ID, job.Title),
+			LastMod: job.LastModified(),
+		})
+	}
+
+	uc.sitemapMu.Lock()
+	defer uc.sitemapMu.Unlock()
+	uc.sitemap = urls
+	uc.sitemapAt = now
+	return nil
+}
+
+func (uc *jobFeedUsecase) Sitemap(ctx context.Context) ([]domain.SitemapURL, time.Time, error) {
+	uc.sitemapMu.RLock()
+	urls, generatedAt := uc.sitemap, uc.sitemapAt
+	uc.sitemapMu.RUnlock()
+	if urls != nil {
+		return urls, generatedAt, nil
+	}
+
+	// Not generated yet, the API just started
+	if err := uc.RefreshSitemap(ctx); err != nil {
+		return nil, time.Time{}, err
+	}
+	uc.sitemapMu.RLock()
+	defer uc.sitemapMu.RUnlock()
+	return uc.sitemap, uc.sitemapAt, nil
+}
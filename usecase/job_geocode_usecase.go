@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/geocode"
+	"job-portal-backend/repository"
+)
+
+// geocodeBatch bounds how many job locations one run looks up
+const geocodeBatch = 30
+
+// JobGeocodeUsecase looks up the coordinates of job locations, so jobs can be
+// searched by distance
+type JobGeocodeUsecase interface {
+	// GeocodeJobs geocodes the locations of the jobs that weren't geocoded
+	// since they were posted or their location changed
+	GeocodeJobs(ctx context.Context) error
+}
+
+type jobGeocodeUsecase struct {
+	jobRepo  repository.JobRepository
+	geocoder geocode.Geocoder
+}
+
+func NewJobGeocodeUsecase(jobRepo repository.JobRepository, geocoder geocode.Geocoder) JobGeocodeUsecase {
+	return &jobGeocodeUsecase{
+		jobRepo:  jobRepo,
+		geocoder: geocoder,
+	}
+}
+
+func (uc *jobGeocodeUsecase) GeocodeJobs(ctx context.Context) error {
+	jobs, err := uc.jobRepo.ListAwaitingGeocoding(ctx, geocodeBatch)
+	if err != nil {
+		return err
+	}
+
+	// Many jobs share a location, each is looked up once
+	found := make(map[string]*domain.GeoPoint)
+	for _, job := range jobs {
+		coordinates, ok := found[job.Location]
+		if !ok {
+			point, err := uc.geocoder.Geocode(ctx, job.Location)
+			switch {
+			case err == nil:
+				coordinates = domain.NewGeoPoint(point.Lat, point.Lng)
+			case errors.Is(err, geocode.ErrNotFound):
+				// The job is left out of distance searches
+			default:
+				// The geocoder may be reachable on the next run
+				log.Printf("Failed to geocode location of job %s: %v", job.ID.Hex(), err)
+				continue
+			}
+			found[job.Location] = coordinates
+		}
+
+		if err := uc.jobRepo.SetCoordinates(ctx, job.ID, job.Location, coordinates); err != nil {
+			return fmt.Errorf("error saving job coordinates: %w", err)
+		}
+	}
+	return nil
+}
@@ -3,141 +3,480 @@ package usecase
 import (
 	"context"
 	"errors"
-	
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
 
-	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"job-portal-backend/domain"
+	"job-portal-backend/pkg/currency"
+	"job-portal-backend/pkg/email"
+	apperrors "job-portal-backend/pkg/errors"
 	"job-portal-backend/repository"
+	"job-portal-backend/utils"
 )
 
 type JobUseCase interface {
+	// CreateJob posts a job on behalf of the company the user works for
 	CreateJob(ctx context.Context, req *domain.CreateJobRequest, userID string) (*domain.JobResponse, error)
+	// UpdateJob edits the job and records the edit in the job's history
 	UpdateJob(ctx context.Context, jobID string, req *domain.UpdateJobRequest, userID string) (*domain.JobResponse, error)
+	// GetJobHistory returns the job's edits, most recent first
+	GetJobHistory(ctx context.Context, jobID, userID string, page, limit int) (*domain.JobRevisionListResponse, error)
+	// DeleteJob archives the job, it can be restored with RestoreJob
 	DeleteJob(ctx context.Context, jobID, userID string) (*domain.JobResponse, error)
-	ListJobs(ctx context.Context, title, location, companyName string, page, limit int) ([]*domain.Job, int64, error)
-	GetJobsByCompanyID(ctx context.Context, companyID string, page, limit int) ([]*domain.Job, int64, error)
+	// RestoreJob brings an archived job back as an unpublished draft
+	RestoreJob(ctx context.Context, jobID, userID string) (*domain.JobResponse, error)
+	// ListJobs returns the published jobs matching the filter, with salaries
+	// converted into the requester's preferred currency
+	ListJobs(ctx context.Context, filter domain.JobFilter, page, limit int) ([]*domain.Job, int64, error)
+	GetJobsByCompanyID(ctx context.Context, companyID string, filter domain.CompanyJobFilter, page, limit int) ([]*domain.Job, int64, error)
 	GetJobByID(ctx context.Context, jobID string) (*domain.Job, error)
+	// ConvertSalaries sets the salaries of the jobs converted into the
+	// requested currency, or the currency of the requester's country
+	ConvertSalaries(ctx context.Context, requested string, jobs ...*domain.Job)
+	// ActingCompany returns the company account the user acts for: the company
+	// whose team they are on, or their own account
+	ActingCompany(ctx context.Context, userID string) (string, error)
+	// GetCompanyInfo returns the company shown on a job, or nil when the account no longer exists
+	GetCompanyInfo(ctx context.Context, companyID string) (*domain.CompanyInfo, error)
+	GetJobChanges(ctx context.Context, since time.Time) (*domain.JobChanges, error)
+	GetJobFormMeta(ctx context.Context) (*domain.JobFormMeta, error)
+	// ListSkillFacets returns the skills most required by published jobs
+	ListSkillFacets(ctx context.Context, limit int) ([]*domain.SkillFacet, error)
+	// GetJobFacets counts the jobs matching the listing filter per location,
+	// category, employment type and company, with category and company names
+	GetJobFacets(ctx context.Context, filter domain.JobFilter) (*domain.JobFacets, error)
+	// SuggestJobs returns the job titles, locations and company names starting
+	// with query, for search box autocomplete
+	SuggestJobs(ctx context.Context, query string, limit int) (*domain.JobSuggestions, error)
+	// GetSimilarJobs returns other published jobs ranked by the skills, category
+	// and location they share with the published job, for "you may also like"
+	GetSimilarJobs(ctx context.Context, jobID string, limit int) ([]*domain.Job, error)
+	// ConfirmHiring renews the job's actively hiring signal
+	ConfirmHiring(ctx context.Context, jobID, userID string) (*domain.JobResponse, error)
+	// RepostJob refreshes the job's posting date and records it in the job's audit trail.
+	// A job can be reposted once per domain.JobBumpCooldown.
+	RepostJob(ctx context.Context, jobID, userID string) (*domain.JobResponse, error)
+	// CloneJob copies the job into a new draft of the same company, for roles hired for again
+	CloneJob(ctx context.Context, jobID, userID string) (*domain.JobResponse, error)
+	// CreateTemplate saves a posting of the user's company, or the content of
+	// the request, as a template
+	CreateTemplate(ctx context.Context, req *domain.CreateJobTemplateRequest, userID string) (*domain.JobTemplateResponse, error)
+	ListTemplates(ctx context.Context, userID string) (*domain.JobTemplateResponse, error)
+	DeleteTemplate(ctx context.Context, templateID, userID string) (*domain.JobTemplateResponse, error)
+	// CreateJobFromTemplate creates a new draft with the template's content
+	CreateJobFromTemplate(ctx context.Context, templateID, userID string) (*domain.JobResponse, error)
+	// PublishJob publishes a draft or scheduled job once it passes the publish
+	// checks, see domain.Job.PublishProblems
+	PublishJob(ctx context.Context, jobID, userID string) (*domain.JobResponse, error)
+	// UnpublishJob takes the job down, or cancels its publish schedule
+	UnpublishJob(ctx context.Context, jobID, userID string) (*domain.JobResponse, error)
+	// CloseJob unpublishes the job for good, recording why along with the
+	// state of its pipeline
+	CloseJob(ctx context.Context, jobID string, req *domain.CloseJobRequest, userID string) (*domain.JobResponse, error)
+	// SendHiringReminders asks companies to confirm jobs whose hiring signal is about to lapse
+	SendHiringReminders(ctx context.Context) error
+	// UnpublishPastDeadline unpublishes the jobs whose application deadline passed
+	UnpublishPastDeadline(ctx context.Context) error
+	// RunSchedules publishes the jobs scheduled to go live, unpublishes the
+	// expired ones and clears the features that ran out
+	RunSchedules(ctx context.Context) error
+	// ListAbuseFlags returns the jobs throttled for gaming the listings, open flags only unless all is set
+	ListAbuseFlags(ctx context.Context, all bool, page, limit int) (*domain.JobAbuseFlagListResponse, error)
+	ResolveAbuseFlag(ctx context.Context, flagID, adminID string) (*domain.JobAbuseFlagResponse, error)
+	// ListPendingReview returns the jobs waiting for an admin's review, the longest waiting first
+	ListPendingReview(ctx context.Context, page, limit int) (*domain.JobListResponse, error)
+	// ApproveJob lets a job waiting for review go live, right away unless it is scheduled
+	ApproveJob(ctx context.Context, jobID, adminID string) (*domain.JobResponse, error)
+	// RejectJob returns a job waiting for review to its company as a draft, with the reason
+	RejectJob(ctx context.Context, jobID string, req *domain.RejectJobRequest, adminID string) (*domain.JobResponse, error)
+	// FeatureJob puts a listed job first in the job listings for a number of days
+	FeatureJob(ctx context.Context, jobID string, req *domain.FeatureJobRequest, userID string) (*domain.JobResponse, error)
+	// UnfeatureJob stops featuring the job before its feature runs out
+	UnfeatureJob(ctx context.Context, jobID, userID string) (*domain.JobResponse, error)
 }
 
+// hiringReminderBatch bounds the number of reminders sent per run
+const hiringReminderBatch = 100
+
 type jobUseCase struct {
-	repo repository.JobRepository
+	repo               repository.JobRepository
+	appRepo            repository.ApplicationRepository
+	userRepo           repository.UserRepository
+	companyProfileRepo repository.CompanyProfileRepository
+	flagRepo           repository.JobAbuseFlagRepository
+	memberRepo         repository.CompanyMemberRepository
+	categoryRepo       repository.CategoryRepository
+	templateRepo       repository.JobTemplateRepository
+	revisionRepo       repository.JobRevisionRepository
+	mailer             email.Sender
+	notifier           NotificationUsecase
+	rates              currency.Provider
+	frontendURL        string
+	// moderation holds jobs for review until an admin approves them
+	moderation bool
 }
 
-func NewJobUseCase(repo repository.JobRepository) JobUseCase {
+func NewJobUseCase(repo repository.JobRepository, appRepo repository.ApplicationRepository, userRepo repository.UserRepository, companyProfileRepo repository.CompanyProfileRepository, flagRepo repository.JobAbuseFlagRepository, memberRepo repository.CompanyMemberRepository, categoryRepo repository.CategoryRepository, templateRepo repository.JobTemplateRepository, revisionRepo repository.JobRevisionRepository, mailer email.Sender, notifier NotificationUsecase, rates currency.Provider, frontendURL string, moderation bool) JobUseCase {
 	return &jobUseCase{
-		repo: repo,
+		repo:               repo,
+		appRepo:            appRepo,
+		userRepo:           userRepo,
+		companyProfileRepo: companyProfileRepo,
+		flagRepo:           flagRepo,
+		memberRepo:         memberRepo,
+		categoryRepo:       categoryRepo,
+		templateRepo:       templateRepo,
+		revisionRepo:       revisionRepo,
+		mailer:             mailer,
+		notifier:           notifier,
+		rates:              rates,
+		frontendURL:        frontendURL,
+		moderation:         moderation,
 	}
 }
 
 func (uc *jobUseCase) CreateJob(ctx context.Context, req *domain.CreateJobRequest, userID string) (*domain.JobResponse, error) {
+	companyID, err := uc.ActingCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCategory(ctx, uc.categoryRepo, req.Category); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if req.Deadline != nil && !req.Deadline.After(now) {
+		return nil, errDeadlineNotFuture()
+	}
+	if err := checkSchedule(now, req.PublishAt, req.ExpiresAt, req.PublishAt, req.ExpiresAt); err != nil {
+		return nil, err
+	}
+	// Scheduled jobs stay unpublished until their publish time
+	if req.PublishAt != nil {
+		req.IsPublished = false
+	}
 	job := &domain.Job{
-		Title:       req.Title,
-		Description: req.Description,
-		Location:    req.Location,
-		CreatedBy:   userID,
+		Title:           req.Title,
+		Description:     req.Description,
+		Location:        req.Location,
+		EmploymentType:  req.EmploymentType,
+		Category:        req.Category,
+		ExperienceLevel: req.ExperienceLevel,
+		Remote:          req.Remote,
+		Salary:          req.Salary,
+		Skills:          domain.NormalizeSkills(req.Skills),
+		IsPublished:     req.IsPublished,
+		BlindScreening:  req.BlindScreening,
+		ApplyURL:        req.ApplyURL,
+		MaxApplications: req.MaxApplications,
+		Deadline:        req.Deadline,
+		PublishAt:       req.PublishAt,
+		ExpiresAt:       req.ExpiresAt,
+		CreatedBy:       companyID,
+		// Posting a job counts as confirming the company is hiring
+		HiringConfirmedAt: &now,
+		GeoFlagged:        domain.ClientInfoFromContext(ctx).GeoFlagged,
 	}
 
+	if companyID != userID {
+		job.PostedBy = userID
+	}
+	// Drafts can be incomplete, jobs going live can't
+	if job.IsPublished || job.PublishAt != nil {
+		if problems := job.PublishProblems(); len(problems) > 0 {
+			return nil, apperrors.NewBadRequestError("Validation failed", problems)
+		}
+	}
+	message := "Job created successfully"
+	if (job.IsPublished || job.PublishAt != nil) && uc.needsReview(job) {
+		job.IsPublished = false
+		job.Moderation = &domain.JobModeration{Status: domain.JobPendingReview, SubmittedAt: now}
+		message = "Job created and submitted for review"
+	}
 
-	err := uc.repo.CreateJob(ctx, job)
-	if err != nil {
-		return &domain.JobResponse{
-			Success: false,
-			Message: "Failed to create job",
-			Errors:  []string{err.Error()},
-		}, err
+	if err := uc.repo.CreateJob(ctx, job); err != nil {
+		return nil, err
 	}
+	setComputedFields(job)
 
 	return &domain.JobResponse{
 		Success: true,
-		Message: "Job created successfully",
+		Message: message,
 		Data:    job,
 	}, nil
 }
 
 func (uc *jobUseCase) UpdateJob(ctx context.Context, jobID string, req *domain.UpdateJobRequest, userID string) (*domain.JobResponse, error) {
 	// Check if job exists and belongs to user
-	belongs, err := uc.repo.JobBelongsToUser(ctx, jobID, userID)
+	job, err := uc.getOwnedJob(ctx, jobID, userID, "You don't have permission to update this job")
 	if err != nil {
-		return &domain.JobResponse{
-			Success: false,
-			Message: "Error checking job ownership",
-			Errors:  []string{err.Error()},
-		}, err
+		return nil, err
+	}
+	if job.IsClosed() && req.IsPublished != nil && *req.IsPublished {
+		return nil, errJobClosed()
+	}
+	if req.Category != nil && *req.Category != job.Category {
+		if err := checkCategory(ctx, uc.categoryRepo, *req.Category); err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+	if req.Deadline != nil && !req.Deadline.After(now) {
+		return nil, errDeadlineNotFuture()
+	}
+	// A job unpublished by its deadline must get a new one to be published again
+	if req.IsPublished != nil && *req.IsPublished && req.Deadline == nil && !req.ClearDeadline && job.PastDeadline(now) {
+		return nil, apperrors.NewBadRequestError("Validation failed", []string{"The application deadline has passed, set a later deadline or clear it to publish the job"})
+	}
+	if req.IsPublished != nil && *req.IsPublished && req.ExpiresAt == nil && !req.ClearExpiresAt && job.Expired(now) {
+		return nil, apperrors.NewBadRequestError("Validation failed", []string{"The posting has expired, set a later expiry or clear it to publish the job"})
+	}
+	publishAt, expiresAt := job.PublishAt, job.ExpiresAt
+	if req.PublishAt != nil || req.ClearPublishAt {
+		publishAt = req.PublishAt
+	}
+	if req.ExpiresAt != nil || req.ClearExpiresAt {
+		expiresAt = req.ExpiresAt
+	}
+	if err := checkSchedule(now, req.PublishAt, req.ExpiresAt, publishAt, expiresAt); err != nil {
+		return nil, err
+	}
+	// Scheduling takes the job down until its publish time, publishing it
+	// right away drops the schedule
+	if req.PublishAt != nil {
+		unpublished := false
+		req.IsPublished = &unpublished
+	} else if req.IsPublished != nil && *req.IsPublished && job.PublishAt != nil {
+		req.ClearPublishAt = true
+	}
+	// A new cap is checked against the applications the job already has
+	capChanged := req.MaxApplications != nil && *req.MaxApplications != job.MaxApplications
+	if capChanged {
+		counts, err := uc.appRepo.CountByJobs(ctx, []primitive.ObjectID{job.ID})
+		if err != nil {
+			return nil, fmt.Errorf("error counting applications: %w", err)
+		}
+		// Jobs that took applications before they were counted
+		if counts[job.ID] > job.ApplicationsReceived {
+			job.ApplicationsReceived = counts[job.ID]
+		}
+	}
+	// Drafts can be incomplete, but a job can't go live incomplete
+	goingLive := (req.IsPublished != nil && *req.IsPublished && !job.IsPublished) || req.PublishAt != nil
+	if goingLive {
+		if problems := job.WithUpdate(req).PublishProblems(); len(problems) > 0 {
+			return nil, apperrors.NewBadRequestError("Validation failed", problems)
+		}
+	}
+	// In review mode the job stays unpublished until it is approved, a
+	// schedule is kept for after the approval
+	submitted := goingLive && uc.needsReview(job)
+	if submitted && req.IsPublished != nil && *req.IsPublished {
+		unpublished := false
+		req.IsPublished = &unpublished
 	}
 
-	if !belongs {
-		return &domain.JobResponse{
-			Success: false,
-			Message: "Unauthorized: You don't have permission to update this job",
-		}, errors.New("unauthorized")
+	// Edits never move a job up the listings, but they are limited so they
+	// can't be used to churn it either
+	editAction := domain.JobActionEdit
+	if !job.ChangedBy(req) {
+		editAction = domain.JobActionTrivialEdit
+		if err := uc.throttle(ctx, job, domain.AbuseTrivialEdits, domain.MaxTrivialJobEditsPerWindow, domain.JobActionTrivialEdit); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.throttle(ctx, job, domain.AbuseExcessiveEdits, domain.MaxJobEditsPerWindow, domain.JobActionEdit, domain.JobActionTrivialEdit); err != nil {
+		return nil, err
+	}
+	toggled := req.IsPublished != nil && *req.IsPublished != job.IsPublished
+	if toggled {
+		if err := uc.throttle(ctx, job, domain.AbusePublishToggling, domain.MaxJobPublishTogglesPerWindow, domain.JobActionPublish, domain.JobActionUnpublish); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Skills != nil {
+		req.Skills = domain.NormalizeSkills(req.Skills)
+	}
+	// An unchanged location keeps its coordinates
+	if req.Location != nil && *req.Location == job.Location {
+		req.Location = nil
 	}
 
 	// Update the job
-	err = uc.repo.UpdateJob(ctx, jobID, req)
-	if err != nil {
-		return &domain.JobResponse{
-			Success: false,
-			Message: "Failed to update job",
-			Errors:  []string{err.Error()},
-		}, err
+	if err := uc.repo.UpdateJob(ctx, jobID, req); err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, apperrors.NewNotFoundError("Job not found")
+		}
+		return nil, err
+	}
+
+	if err := uc.repo.AddAuditEntry(ctx, &domain.JobAuditEntry{JobID: jobID, Action: editAction, ActorID: userID}); err != nil {
+		return nil, err
+	}
+	if capChanged {
+		// The count is only ever raised, applications reserved since it was
+		// taken stay counted
+		if err := uc.repo.RaiseApplicationsReceived(ctx, job.ID, job.ApplicationsReceived); err != nil {
+			return nil, fmt.Errorf("error counting applications: %w", err)
+		}
+		// A cap lowered below the applications received closes the job. The
+		// repository checks against the stored count, which applications
+		// coming in during the update have already moved.
+		if err := uc.repo.CapApplications(ctx, job.ID, now); err != nil {
+			return nil, err
+		}
+	}
+	if toggled {
+		action := domain.JobActionUnpublish
+		if *req.IsPublished {
+			action = domain.JobActionPublish
+		}
+		if err := uc.repo.AddAuditEntry(ctx, &domain.JobAuditEntry{JobID: jobID, Action: action, ActorID: userID}); err != nil {
+			return nil, err
+		}
+	}
+	// Jobs already waiting keep their place in the queue
+	if submitted && !job.PendingReview() {
+		if err := uc.submitForReview(ctx, jobID, userID); err != nil {
+			return nil, err
+		}
 	}
 
 	// Get the updated job
 	updatedJob, err := uc.repo.GetJobByID(ctx, jobID)
 	if err != nil {
-		return &domain.JobResponse{
-			Success: false,
-			Message: "Failed to fetch updated job",
-			Errors:  []string{err.Error()},
-		}, err
+		return nil, err
+	}
+	setComputedFields(updatedJob)
+
+	revision := &domain.JobRevision{
+		JobID:    jobID,
+		EditedBy: userID,
+		EditedAt: updatedJob.UpdatedAt,
+		Changes:  job.Changes(updatedJob),
+	}
+	if err := uc.revisionRepo.Create(ctx, revision); err != nil {
+		return nil, fmt.Errorf("error recording job revision: %w", err)
+	}
+
+	message := "Job updated successfully"
+	if submitted {
+		message = "Job updated and submitted for review"
 	}
 
 	return &domain.JobResponse{
 		Success: true,
-		Message: "Job updated successfully",
+		Message: message,
 		Data:    updatedJob,
 	}, nil
 }
 
+func (uc *jobUseCase) GetJobHistory(ctx context.Context, jobID, userID string, page, limit int) (*domain.JobRevisionListResponse, error) {
+	if _, err := uc.getOwnedJob(ctx, jobID, userID, "You don't have permission to view this job's history"); err != nil {
+		return nil, err
+	}
+
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	revisions, total, err := uc.revisionRepo.ListByJob(ctx, jobID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing job revisions: %w", err)
+	}
+
+	// Calculate total pages
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return &domain.JobRevisionListResponse{
+		Success:    true,
+		Message:    "Successfully retrieved job history",
+		Data:       revisions,
+		PageNumber: page,
+		PageSize:   len(revisions),
+		TotalItems: total,
+		TotalPages: totalPages,
+	}, nil
+}
+
 func (uc *jobUseCase) DeleteJob(ctx context.Context, jobID, userID string) (*domain.JobResponse, error) {
 	// First, get the job to check ownership
-	job, err := uc.repo.GetJobByID(ctx, jobID)
+	if _, err := uc.getOwnedJob(ctx, jobID, userID, "You don't have permission to delete this job"); err != nil {
+		return nil, err
+	}
+
+	// Archive the job
+	if err := uc.repo.DeleteJob(ctx, jobID); err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, apperrors.NewNotFoundError("Job not found")
+		}
+		return nil, err
+	}
+	if err := uc.repo.AddAuditEntry(ctx, &domain.JobAuditEntry{JobID: jobID, Action: domain.JobActionDelete, ActorID: userID}); err != nil {
+		return nil, err
+	}
+
+	return &domain.JobResponse{
+		Success: true,
+		Message: "Job deleted successfully, it can be restored from the archive",
+	}, nil
+}
+
+func (uc *jobUseCase) RestoreJob(ctx context.Context, jobID, userID string) (*domain.JobResponse, error) {
+	job, err := uc.repo.GetArchivedJob(ctx, jobID)
 	if err != nil {
-		return &domain.JobResponse{
-			Success: false,
-			Message: "Job not found",
-			Errors:  []string{err.Error()},
-		}, err
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, apperrors.NewNotFoundError("Archived job not found")
+		}
+		return nil, err
 	}
 
-	// Check if the user is the owner of the job
-	if job.CreatedBy != userID {
-		return &domain.JobResponse{
-			Success: false,
-			Message: "Unauthorized",
-			Errors:  []string{"You don't have permission to delete this job"},
-		}, errors.New("unauthorized access")
+	companyID, err := uc.ActingCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if job.CreatedBy != companyID {
+		return nil, errForbidden("You don't have permission to restore this job")
+	}
+
+	if err := uc.repo.RestoreJob(ctx, jobID); err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, apperrors.NewNotFoundError("Archived job not found")
+		}
+		return nil, err
+	}
+	if err := uc.repo.AddAuditEntry(ctx, &domain.JobAuditEntry{JobID: jobID, Action: domain.JobActionRestore, ActorID: userID}); err != nil {
+		return nil, err
 	}
 
-	// Delete the job
-	err = uc.repo.DeleteJob(ctx, jobID)
+	restored, err := uc.GetJobByID(ctx, jobID)
 	if err != nil {
-		return &domain.JobResponse{
-			Success: false,
-			Message: "Failed to delete job",
-			Errors:  []string{err.Error()},
-		}, err
+		return nil, err
 	}
 
 	return &domain.JobResponse{
 		Success: true,
-		Message: "Job deleted successfully",
+		Message: "Job restored as a draft",
+		Data:    restored,
 	}, nil
 }
 
 // ListJobs retrieves a paginated list of jobs with optional filters
-func (uc *jobUseCase) ListJobs(ctx context.Context, title, location, companyName string, page, limit int) ([]*domain.Job, int64, error) {
+func (uc *jobUseCase) ListJobs(ctx context.Context, filter domain.JobFilter, page, limit int) ([]*domain.Job, int64, error) {
 	// Set default values for pagination
 	if page < 1 {
 		page = 1
@@ -146,19 +485,111 @@ func (uc *jobUseCase) ListJobs(ctx context.Context, title, location, companyName
 		limit = 10
 	}
 
+	preferred, rates := uc.convertSalaryFilter(ctx, &filter)
+
 	// Call repository to get jobs with filters
-	jobs, total, err := uc.repo.ListJobs(ctx, title, location, companyName, page, limit)
+	jobs, total, err := uc.repo.ListJobs(ctx, filter, page, limit)
 	if err != nil {
 		return nil, 0, err
 	}
+	setComputedFields(jobs...)
+	convertSalaries(rates, preferred, jobs...)
 
 	return jobs, total, nil
 }
 
+// convertSalaryFilter sets the rates converting the filter's salary bounds,
+// given in the requester's preferred currency, into the job currencies. It
+// returns the preferred currency and the rates, nil when unavailable.
+func (uc *jobUseCase) convertSalaryFilter(ctx context.Context, filter *domain.JobFilter) (string, *currency.Rates) {
+	preferred := preferredCurrency(ctx, filter.Currency)
+	rates := uc.exchangeRates(ctx, preferred)
+	if filter.SalaryMin != nil || filter.SalaryMax != nil {
+		switch {
+		case rates != nil:
+			filter.SalaryRates = salaryFactors(rates, preferred)
+		case filter.Currency != "":
+			// Without rates, bounds in an explicit currency only compare with salaries in it
+			filter.SalaryRates = map[string]float64{filter.Currency: 1}
+		}
+	}
+	return preferred, rates
+}
+
+func (uc *jobUseCase) ConvertSalaries(ctx context.Context, requested string, jobs ...*domain.Job) {
+	preferred := preferredCurrency(ctx, requested)
+	convertSalaries(uc.exchangeRates(ctx, preferred), preferred, jobs...)
+}
+
+// exchangeRates returns the current exchange rates, or nil when no currency
+// is preferred or the rates are unavailable
+func (uc *jobUseCase) exchangeRates(ctx context.Context, preferred string) *currency.Rates {
+	if preferred == "" {
+		return nil
+	}
+
+	rates, err := uc.rates.Rates(ctx)
+	if err != nil {
+		// The bare error means no rates are configured or the provider is known to be down
+		if err != currency.ErrUnavailable {
+			log.Printf("Failed to retrieve exchange rates: %v", err)
+		}
+		return nil
+	}
+	if _, ok := rates.Values[preferred]; !ok {
+		return nil
+	}
+	return rates
+}
+
+// preferredCurrency returns the requested currency, or the currency of the
+// requester's country when none was requested
+func preferredCurrency(ctx context.Context, requested string) string {
+	if requested != "" {
+		return requested
+	}
+	return currency.ForCountry(domain.ClientInfoFromContext(ctx).Country)
+}
+
+// salaryFactors returns what one unit of preferred is worth in every currency with a rate
+func salaryFactors(rates *currency.Rates, preferred string) map[string]float64 {
+	factors := make(map[string]float64, len(rates.Values))
+	for code := range rates.Values {
+		if factor, ok := rates.Factor(preferred, code); ok {
+			factors[code] = factor
+		}
+	}
+	return factors
+}
+
+// convertSalaries sets the converted salary of the jobs paying in another
+// currency than preferred. It does nothing without rates.
+func convertSalaries(rates *currency.Rates, preferred string, jobs ...*domain.Job) {
+	if rates == nil {
+		return
+	}
+	for _, job := range jobs {
+		salary := job.Salary
+		if salary == nil || salary.Currency == preferred {
+			continue
+		}
+		factor, ok := rates.Factor(salary.Currency, preferred)
+		if !ok {
+			continue
+		}
+		salary.Converted = &domain.ConvertedSalary{
+			Min:      math.Round(salary.Min * factor),
+			Max:      math.Round(salary.Max * factor),
+			Currency: preferred,
+			Rate:     factor,
+		}
+	}
+}
+
 // GetJobsByCompanyID retrieves a paginated list of jobs by company ID
-func (uc *jobUseCase) GetJobsByCompanyID(ctx context.Context, companyID string, page, limit int) ([]*domain.Job, int64, error) {
+func (uc *jobUseCase) GetJobsByCompanyID(ctx context.Context, companyID string, filter domain.CompanyJobFilter, page, limit int) ([]*domain.Job, int64, error) {
 	if companyID == "" {
-		return nil, 0, errors.New("company ID is required")
+		return nil, 0, apperrors.NewBadRequestError("Company ID is required", nil)
 	}
 
 	if page < 1 {
@@ -168,10 +599,28 @@ func (uc *jobUseCase) GetJobsByCompanyID(ctx context.Context, companyID string,
 		limit = 10
 	}
 
-	jobs, total, err := uc.repo.GetJobsByCompanyID(ctx, companyID, page, limit)
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && !filter.CreatedFrom.Before(filter.CreatedTo) {
+		return nil, 0, apperrors.NewBadRequestError("The from date must not be after the to date", nil)
+	}
+
+	jobs, total, err := uc.repo.GetJobsByCompanyID(ctx, companyID, filter, page, limit)
 	if err != nil {
 		return nil, 0, err
 	}
+	setComputedFields(jobs...)
+
+	jobIDs := make([]primitive.ObjectID, len(jobs))
+	for i, job := range jobs {
+		jobIDs[i] = job.ID
+	}
+	counts, err := uc.appRepo.CountByJobs(ctx, jobIDs)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error counting applications: %w", err)
+	}
+	for _, job := range jobs {
+		count := counts[job.ID]
+		job.ApplicationCount = &count
+	}
 
 	return jobs, total, nil
 }
@@ -179,16 +628,1083 @@ func (uc *jobUseCase) GetJobsByCompanyID(ctx context.Context, companyID string,
 // GetJobByID retrieves a job by its ID
 func (uc *jobUseCase) GetJobByID(ctx context.Context, jobID string) (*domain.Job, error) {
 	if jobID == "" {
-		return nil, errors.New("job ID is required")
+		return nil, apperrors.NewBadRequestError("Job ID is required", nil)
 	}
 
 	job, err := uc.repo.GetJobByID(ctx, jobID)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("job not found")
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, apperrors.NewNotFoundError("Job not found")
+		}
+		return nil, err
+	}
+	setComputedFields(job)
+
+	return job, nil
+}
+
+func (uc *jobUseCase) GetCompanyInfo(ctx context.Context, companyID string) (*domain.CompanyInfo, error) {
+	company, err := uc.userRepo.FindByID(ctx, companyID)
+	if err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if company.IsDeleted() {
+		return nil, nil
+	}
+
+	// Companies that never filled in their profile are shown by name only
+	profile, err := uc.companyProfileRepo.GetByCompanyID(ctx, companyID)
+	if err != nil && !errors.Is(err, domain.ErrCompanyProfileNotFound) {
+		return nil, err
+	}
+
+	return company.CompanyInfo(profile), nil
+}
+
+// GetJobChanges returns the IDs of jobs created, updated or deleted since a sync checkpoint
+func (uc *jobUseCase) GetJobChanges(ctx context.Context, since time.Time) (*domain.JobChanges, error) {
+	// Taken before querying so changes made while the delta is computed show up in the next sync
+	checkpoint := time.Now()
+
+	changes := &domain.JobChanges{
+		Created:    []string{},
+		Updated:    []string{},
+		Deleted:    []string{},
+		Checkpoint: checkpoint,
+	}
+
+	// Deletions older than the tombstone retention are gone, the client has to start over
+	if since.Before(checkpoint.Add(-domain.JobTombstoneRetention)) {
+		changes.FullResync = true
+		return changes, nil
+	}
+
+	jobs, deleted, err := uc.repo.GetJobChanges(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, job := range jobs {
+		switch {
+		case !job.IsPublished:
+			changes.Deleted = append(changes.Deleted, job.ID.Hex())
+		case job.CreatedAt.After(since):
+			changes.Created = append(changes.Created, job.ID.Hex())
+		default:
+			changes.Updated = append(changes.Updated, job.ID.Hex())
 		}
+	}
+	changes.Deleted = append(changes.Deleted, deleted...)
+
+	return changes, nil
+}
+
+func (uc *jobUseCase) ActingCompany(ctx context.Context, userID string) (string, error) {
+	return actingCompany(ctx, uc.memberRepo, userID)
+}
+
+// getOwnedJob loads a job and verifies it belongs to the company userID acts for.
+// forbidden is the message returned when the user doesn't own the job.
+func (uc *jobUseCase) getOwnedJob(ctx context.Context, jobID, userID, forbidden string) (*domain.Job, error) {
+	job, err := uc.GetJobByID(ctx, jobID)
+	if err != nil {
 		return nil, err
 	}
 
+	companyID, err := uc.ActingCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if job.CreatedBy != companyID {
+		return nil, errForbidden(forbidden)
+	}
 	return job, nil
-}
\ No newline at end of file
+}
+
+// GetJobFormMeta describes the job form fields using the same rules the job
+// requests are validated with
+func (uc *jobUseCase) GetJobFormMeta(ctx context.Context) (*domain.JobFormMeta, error) {
+	locations, err := uc.repo.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := uc.categoryRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Categories come from the taxonomy rather than the validate tags
+	fields := utils.DescribeForm(domain.CreateJobRequest{})
+	for i := range fields {
+		if fields[i].Name == "category" {
+			fields[i].Type = "select"
+			fields[i].Options = make([]string, len(categories))
+			for j, category := range categories {
+				fields[i].Options[j] = category.Slug
+			}
+		}
+	}
+
+	return &domain.JobFormMeta{
+		Fields:    fields,
+		Locations: locations,
+	}, nil
+}
+
+func (uc *jobUseCase) ListSkillFacets(ctx context.Context, limit int) ([]*domain.SkillFacet, error) {
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	facets, err := uc.repo.SkillFacets(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error counting skills: %w", err)
+	}
+	return facets, nil
+}
+
+func (uc *jobUseCase) GetJobFacets(ctx context.Context, filter domain.JobFilter) (*domain.JobFacets, error) {
+	uc.convertSalaryFilter(ctx, &filter)
+
+	facets, err := uc.repo.JobFacets(ctx, filter, domain.JobFacetLimit)
+	if err != nil {
+		return nil, fmt.Errorf("error counting job facets: %w", err)
+	}
+
+	if len(facets.Categories) > 0 {
+		categories, err := uc.categoryRepo.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving categories: %w", err)
+		}
+		names := make(map[string]string, len(categories))
+		for _, category := range categories {
+			names[category.Slug] = category.Name
+		}
+		for _, facet := range facets.Categories {
+			facet.Label = names[facet.Value]
+		}
+	}
+
+	// Deleted companies keep their jobs counted, without a name
+	for _, facet := range facets.Companies {
+		company, err := uc.GetCompanyInfo(ctx, facet.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving company: %w", err)
+		}
+		if company != nil {
+			facet.Label = company.Name
+		}
+	}
+
+	// Facets without values are listed as empty, not null
+	for _, values := range []*[]*domain.FacetCount{&facets.Locations, &facets.Categories, &facets.EmploymentTypes, &facets.Companies} {
+		if *values == nil {
+			*values = []*domain.FacetCount{}
+		}
+	}
+
+	return facets, nil
+}
+
+func (uc *jobUseCase) SuggestJobs(ctx context.Context, query string, limit int) (*domain.JobSuggestions, error) {
+	query = strings.TrimSpace(query)
+	if utf8.RuneCountInString(query) < domain.MinSuggestQueryLength {
+		return nil, apperrors.NewBadRequestError("Invalid search", []string{fmt.Sprintf("q must be at least %d characters long", domain.MinSuggestQueryLength)})
+	}
+	if limit < 1 || limit > domain.MaxSuggestions {
+		limit = domain.DefaultSuggestions
+	}
+
+	suggestions := &domain.JobSuggestions{Companies: []*domain.CompanySuggestion{}}
+	var err error
+	if suggestions.Titles, err = uc.repo.SuggestValues(ctx, "title", query, limit); err != nil {
+		return nil, fmt.Errorf("error suggesting titles: %w", err)
+	}
+	if suggestions.Locations, err = uc.repo.SuggestValues(ctx, "location", query, limit); err != nil {
+		return nil, fmt.Errorf("error suggesting locations: %w", err)
+	}
+
+	companies, err := uc.userRepo.SuggestCompanies(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error suggesting companies: %w", err)
+	}
+	for _, company := range companies {
+		suggestions.Companies = append(suggestions.Companies, &domain.CompanySuggestion{ID: company.ID.Hex(), Name: company.Name})
+	}
+
+	return suggestions, nil
+}
+
+func (uc *jobUseCase) GetSimilarJobs(ctx context.Context, jobID string, limit int) ([]*domain.Job, error) {
+	if limit < 1 || limit > domain.MaxSimilarJobs {
+		limit = domain.DefaultSimilarJobs
+	}
+
+	job, err := uc.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if !job.IsPublished {
+		return nil, apperrors.NewNotFoundError("Job not found")
+	}
+
+	jobs, err := uc.repo.ListSimilarJobs(ctx, job, domain.JobExclusions{}, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing similar jobs: %w", err)
+	}
+	setComputedFields(jobs...)
+
+	return jobs, nil
+}
+
+func (uc *jobUseCase) ConfirmHiring(ctx context.Context, jobID, userID string) (*domain.JobResponse, error) {
+	job, err := uc.getOwnedJob(ctx, jobID, userID, "You don't have permission to update this job")
+	if err != nil {
+		return nil, err
+	}
+	if job.IsClosed() {
+		return nil, errJobClosed()
+	}
+
+	if err := uc.repo.ConfirmHiring(ctx, jobID); err != nil {
+		return nil, err
+	}
+
+	job, err = uc.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.JobResponse{
+		Success: true,
+		Message: "Hiring status confirmed",
+		Data:    job,
+	}, nil
+}
+
+func (uc *jobUseCase) RepostJob(ctx context.Context, jobID, userID string) (*domain.JobResponse, error) {
+	job, err := uc.getOwnedJob(ctx, jobID, userID, "You don't have permission to repost this job")
+	if err != nil {
+		return nil, err
+	}
+	if job.IsClosed() {
+		return nil, errJobClosed()
+	}
+	if next := job.Bumped().Add(domain.JobBumpCooldown); time.Now().Before(next) {
+		uc.raiseAbuseFlag(ctx, job, domain.AbuseRepostTooSoon)
+		return nil, apperrors.NewTooManyRequestsError("This job was posted or reposted recently").WithDetails(
+			[]string{fmt.Sprintf("The job can be reposted again after %s", next.Format(time.RFC3339))})
+	}
+
+	if err := uc.repo.RepostJob(ctx, jobID); err != nil {
+		return nil, err
+	}
+
+	err = uc.repo.AddAuditEntry(ctx, &domain.JobAuditEntry{
+		JobID:   jobID,
+		Action:  domain.JobActionRepost,
+		ActorID: userID,
+		Details: map[string]interface{}{"previous_created_at": job.CreatedAt, "previous_bumped_at": job.Bumped()},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reposted, err := uc.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.JobResponse{
+		Success: true,
+		Message: "Job reposted successfully",
+		Data:    reposted,
+	}, nil
+}
+
+func (uc *jobUseCase) CloneJob(ctx context.Context, jobID, userID string) (*domain.JobResponse, error) {
+	job, err := uc.getOwnedJob(ctx, jobID, userID, "You don't have permission to clone this job")
+	if err != nil {
+		return nil, err
+	}
+
+	clone := job.Clone()
+	if err := uc.createDraft(ctx, clone, userID); err != nil {
+		return nil, err
+	}
+	err = uc.repo.AddAuditEntry(ctx, &domain.JobAuditEntry{
+		JobID:   clone.ID.Hex(),
+		Action:  domain.JobActionClone,
+		ActorID: userID,
+		Details: map[string]interface{}{"source_job_id": jobID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	setComputedFields(clone)
+
+	return &domain.JobResponse{
+		Success: true,
+		Message: "Job cloned successfully",
+		Data:    clone,
+	}, nil
+}
+
+// createDraft saves a draft copied from a job or a template, posted by userID
+func (uc *jobUseCase) createDraft(ctx context.Context, draft *domain.Job, userID string) error {
+	// Categories removed from the taxonomy since are dropped
+	if draft.Category != "" {
+		if _, err := uc.categoryRepo.GetBySlug(ctx, draft.Category); err != nil {
+			if !errors.Is(err, domain.ErrCategoryNotFound) {
+				return fmt.Errorf("error checking category: %w", err)
+			}
+			draft.Category = ""
+		}
+	}
+	now := time.Now()
+	draft.HiringConfirmedAt = &now
+	draft.GeoFlagged = domain.ClientInfoFromContext(ctx).GeoFlagged
+	if draft.CreatedBy != userID {
+		draft.PostedBy = userID
+	}
+
+	return uc.repo.CreateJob(ctx, draft)
+}
+
+func (uc *jobUseCase) CreateTemplate(ctx context.Context, req *domain.CreateJobTemplateRequest, userID string) (*domain.JobTemplateResponse, error) {
+	companyID, err := uc.ActingCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := uc.templateRepo.CountByCompany(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("error counting job templates: %w", err)
+	}
+	if count >= domain.MaxJobTemplates {
+		return nil, apperrors.NewConflictError(fmt.Sprintf("A company can keep at most %d job templates", domain.MaxJobTemplates))
+	}
+
+	var template *domain.JobTemplate
+	if req.JobID != "" {
+		job, err := uc.getOwnedJob(ctx, req.JobID, userID, "You don't have permission to use this job as a template")
+		if err != nil {
+			return nil, err
+		}
+		template = domain.NewJobTemplate(req.Name, job)
+	} else {
+		if err := checkCategory(ctx, uc.categoryRepo, req.Category); err != nil {
+			return nil, err
+		}
+		template = domain.NewJobTemplate(req.Name, &domain.Job{
+			Title:           req.Title,
+			Description:     req.Description,
+			Location:        req.Location,
+			EmploymentType:  req.EmploymentType,
+			Category:        req.Category,
+			ExperienceLevel: req.ExperienceLevel,
+			Remote:          req.Remote,
+			Salary:          req.Salary,
+			Skills:          domain.NormalizeSkills(req.Skills),
+			BlindScreening:  req.BlindScreening,
+			CreatedBy:       companyID,
+		})
+	}
+	template.CreatedBy = userID
+
+	if err := uc.templateRepo.Create(ctx, template); err != nil {
+		return nil, fmt.Errorf("error saving job template: %w", err)
+	}
+
+	return &domain.JobTemplateResponse{
+		Success: true,
+		Message: "Job template saved successfully",
+		Data:    template,
+	}, nil
+}
+
+func (uc *jobUseCase) ListTemplates(ctx context.Context, userID string) (*domain.JobTemplateResponse, error) {
+	companyID, err := uc.ActingCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := uc.templateRepo.ListByCompany(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing job templates: %w", err)
+	}
+
+	return &domain.JobTemplateResponse{
+		Success: true,
+		Message: "Successfully retrieved job templates",
+		Data:    templates,
+	}, nil
+}
+
+func (uc *jobUseCase) DeleteTemplate(ctx context.Context, templateID, userID string) (*domain.JobTemplateResponse, error) {
+	if _, err := uc.getOwnedTemplate(ctx, templateID, userID, "You don't have permission to delete this job template"); err != nil {
+		return nil, err
+	}
+
+	if err := uc.templateRepo.Delete(ctx, templateID); err != nil {
+		if isNotFound(err, domain.ErrJobTemplateNotFound) {
+			return nil, apperrors.NewNotFoundError("Job template not found")
+		}
+		return nil, fmt.Errorf("error deleting job template: %w", err)
+	}
+
+	return &domain.JobTemplateResponse{
+		Success: true,
+		Message: "Job template deleted successfully",
+	}, nil
+}
+
+func (uc *jobUseCase) CreateJobFromTemplate(ctx context.Context, templateID, userID string) (*domain.JobResponse, error) {
+	template, err := uc.getOwnedTemplate(ctx, templateID, userID, "You don't have permission to use this job template")
+	if err != nil {
+		return nil, err
+	}
+
+	job := template.Draft()
+	if err := uc.createDraft(ctx, job, userID); err != nil {
+		return nil, err
+	}
+	setComputedFields(job)
+
+	return &domain.JobResponse{
+		Success: true,
+		Message: "Job created from template successfully",
+		Data:    job,
+	}, nil
+}
+
+// getOwnedTemplate loads a template and verifies it belongs to the company userID acts for
+func (uc *jobUseCase) getOwnedTemplate(ctx context.Context, templateID, userID, forbidden string) (*domain.JobTemplate, error) {
+	template, err := uc.templateRepo.GetByID(ctx, templateID)
+	if err != nil {
+		if isNotFound(err, domain.ErrJobTemplateNotFound) {
+			return nil, apperrors.NewNotFoundError("Job template not found")
+		}
+		return nil, fmt.Errorf("error getting job template: %w", err)
+	}
+
+	companyID, err := uc.ActingCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if template.CompanyID != companyID {
+		return nil, errForbidden(forbidden)
+	}
+	return template, nil
+}
+
+func (uc *jobUseCase) PublishJob(ctx context.Context, jobID, userID string) (*domain.JobResponse, error) {
+	job, err := uc.getOwnedJob(ctx, jobID, userID, "You don't have permission to publish this job")
+	if err != nil {
+		return nil, err
+	}
+	if job.IsClosed() {
+		return nil, errJobClosed()
+	}
+	if job.IsPublished {
+		return nil, apperrors.NewConflictError("This job is already published")
+	}
+
+	now := time.Now()
+	problems := job.PublishProblems()
+	if job.PastDeadline(now) {
+		problems = append(problems, "The application deadline has passed, set a later deadline or clear it to publish the job")
+	}
+	if job.Expired(now) {
+		problems = append(problems, "The posting has expired, set a later expiry or clear it to publish the job")
+	}
+	if len(problems) > 0 {
+		return nil, apperrors.NewBadRequestError("Validation failed", problems)
+	}
+
+	if uc.needsReview(job) {
+		if job.PendingReview() {
+			return nil, apperrors.NewConflictError("This job is already waiting for review")
+		}
+		if err := uc.submitForReview(ctx, jobID, userID); err != nil {
+			return nil, err
+		}
+
+		submitted, err := uc.GetJobByID(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &domain.JobResponse{
+			Success: true,
+			Message: "Job submitted for review",
+			Data:    submitted,
+		}, nil
+	}
+
+	if err := uc.throttle(ctx, job, domain.AbusePublishToggling, domain.MaxJobPublishTogglesPerWindow, domain.JobActionPublish, domain.JobActionUnpublish); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.SetPublished(ctx, jobID, true); err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, apperrors.NewNotFoundError("Job not found")
+		}
+		return nil, err
+	}
+	if err := uc.repo.AddAuditEntry(ctx, &domain.JobAuditEntry{JobID: jobID, Action: domain.JobActionPublish, ActorID: userID}); err != nil {
+		return nil, err
+	}
+
+	published, err := uc.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.JobResponse{
+		Success: true,
+		Message: "Job published successfully",
+		Data:    published,
+	}, nil
+}
+
+func (uc *jobUseCase) UnpublishJob(ctx context.Context, jobID, userID string) (*domain.JobResponse, error) {
+	job, err := uc.getOwnedJob(ctx, jobID, userID, "You don't have permission to unpublish this job")
+	if err != nil {
+		return nil, err
+	}
+	if !job.IsPublished && job.PublishAt == nil && !job.PendingReview() {
+		return nil, apperrors.NewConflictError("This job isn't published")
+	}
+
+	// Cancelling a schedule doesn't toggle anything, so it isn't throttled
+	if job.IsPublished {
+		if err := uc.throttle(ctx, job, domain.AbusePublishToggling, domain.MaxJobPublishTogglesPerWindow, domain.JobActionPublish, domain.JobActionUnpublish); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.repo.SetPublished(ctx, jobID, false); err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, apperrors.NewNotFoundError("Job not found")
+		}
+		return nil, err
+	}
+	if job.IsPublished {
+		if err := uc.repo.AddAuditEntry(ctx, &domain.JobAuditEntry{JobID: jobID, Action: domain.JobActionUnpublish, ActorID: userID}); err != nil {
+			return nil, err
+		}
+	}
+	// Unpublishing a job waiting for review withdraws it from the queue
+	if job.PendingReview() {
+		if err := uc.repo.SetModeration(ctx, jobID, nil); err != nil {
+			return nil, fmt.Errorf("error withdrawing job from review: %w", err)
+		}
+	}
+
+	unpublished, err := uc.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.JobResponse{
+		Success: true,
+		Message: "Job unpublished successfully",
+		Data:    unpublished,
+	}, nil
+}
+
+func (uc *jobUseCase) CloseJob(ctx context.Context, jobID string, req *domain.CloseJobRequest, userID string) (*domain.JobResponse, error) {
+	job, err := uc.getOwnedJob(ctx, jobID, userID, "You don't have permission to close this job")
+	if err != nil {
+		return nil, err
+	}
+	if job.IsClosed() {
+		return nil, errJobClosed()
+	}
+
+	stats, err := uc.appRepo.JobClosingStats(ctx, job.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error computing closing stats: %w", err)
+	}
+	if req.Reason == domain.CloseHiredViaPortal && stats.Hired == 0 {
+		return nil, apperrors.NewBadRequestError("Validation failed", []string{"No applicant to this job was hired, choose another reason"})
+	}
+
+	now := time.Now()
+	stats.DaysOpen = now.Sub(job.CreatedAt).Hours() / 24
+	closing := &domain.JobClosing{
+		Reason:   req.Reason,
+		Note:     req.Note,
+		ClosedBy: userID,
+		ClosedAt: now,
+		Stats:    *stats,
+	}
+	if err := uc.repo.CloseJob(ctx, jobID, closing); err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, errJobClosed()
+		}
+		return nil, err
+	}
+
+	err = uc.repo.AddAuditEntry(ctx, &domain.JobAuditEntry{
+		JobID:   jobID,
+		Action:  domain.JobActionClose,
+		ActorID: userID,
+		Details: map[string]interface{}{"reason": req.Reason},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	closed, err := uc.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.JobResponse{
+		Success: true,
+		Message: "Job closed successfully",
+		Data:    closed,
+	}, nil
+}
+
+func (uc *jobUseCase) SendHiringReminders(ctx context.Context) error {
+	confirmedBefore := time.Now().Add(-(domain.ActivelyHiringWindow - domain.HiringReminderLead))
+	jobs, err := uc.repo.ListJobsNeedingHiringReminder(ctx, confirmedBefore, hiringReminderBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if _, err := uc.userRepo.FindByID(ctx, job.CreatedBy); err != nil {
+			if !errors.Is(err, domain.ErrUserNotFound) {
+				// Left unmarked, the next run tries again
+				log.Printf("Skipping hiring reminder for job %s: %v", job.ID.Hex(), err)
+				continue
+			}
+			// The company is gone. Marked anyway, or the job would come first
+			// in every batch and crowd out jobs that can be reminded.
+			if err := uc.repo.MarkHiringReminderSent(ctx, job.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		err = uc.notifier.Notify(ctx, job.CreatedBy, domain.NotificationHiringReminder,
+			fmt.Sprintf("Are you still hiring for %s?", job.Title),
+			fmt.Sprintf("Your job posting \"%s\" will stop being shown as actively hiring on %s.\n"+
+				"If the position is still open, confirm it here so candidates keep seeing it first:\n\n%s/jobs/%s",
+				job.Title, job.HiringConfirmed().Add(domain.ActivelyHiringWindow).Format("January 2, 2006"),
+				uc.frontendURL, job.ID.Hex()))
+		if err != nil {
+			log.Printf("Failed to send hiring reminder for job %s: %v", job.ID.Hex(), err)
+			continue
+		}
+
+		if err := uc.repo.MarkHiringReminderSent(ctx, job.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (uc *jobUseCase) UnpublishPastDeadline(ctx context.Context) error {
+	count, err := uc.repo.UnpublishPastDeadline(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("error unpublishing jobs past their deadline: %w", err)
+	}
+	if count > 0 {
+		log.Printf("Unpublished %d jobs past their application deadline", count)
+	}
+	return nil
+}
+
+func (uc *jobUseCase) RunSchedules(ctx context.Context) error {
+	now := time.Now()
+
+	published, err := uc.repo.PublishScheduled(ctx, now)
+	if err != nil {
+		return fmt.Errorf("error publishing scheduled jobs: %w", err)
+	}
+	if published > 0 {
+		log.Printf("Published %d scheduled jobs", published)
+	}
+
+	expired, err := uc.repo.UnpublishExpired(ctx, now)
+	if err != nil {
+		return fmt.Errorf("error unpublishing expired jobs: %w", err)
+	}
+	if expired > 0 {
+		log.Printf("Unpublished %d expired jobs", expired)
+	}
+
+	unfeatured, err := uc.repo.UnfeatureExpired(ctx, now)
+	if err != nil {
+		return fmt.Errorf("error clearing expired job features: %w", err)
+	}
+	if unfeatured > 0 {
+		log.Printf("Cleared %d expired job features", unfeatured)
+	}
+	return nil
+}
+
+// setComputedFields fills in the computed fields of jobs returned to clients
+func setComputedFields(jobs ...*domain.Job) {
+	now := time.Now()
+	for _, job := range jobs {
+		job.SetComputedFields(now)
+	}
+}
+
+func (uc *jobUseCase) ListAbuseFlags(ctx context.Context, all bool, page, limit int) (*domain.JobAbuseFlagListResponse, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	flags, total, err := uc.flagRepo.List(ctx, all, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing job abuse flags: %w", err)
+	}
+
+	// Calculate total pages
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return &domain.JobAbuseFlagListResponse{
+		Success:    true,
+		Message:    "Successfully retrieved job abuse flags",
+		Data:       flags,
+		PageNumber: page,
+		PageSize:   len(flags),
+		TotalItems: total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (uc *jobUseCase) ResolveAbuseFlag(ctx context.Context, flagID, adminID string) (*domain.JobAbuseFlagResponse, error) {
+	flag, err := uc.flagRepo.Resolve(ctx, flagID, adminID)
+	if err != nil {
+		if isNotFound(err, domain.ErrJobAbuseFlagNotFound) {
+			return nil, apperrors.NewNotFoundError("Open job abuse flag not found")
+		}
+		return nil, fmt.Errorf("error resolving job abuse flag: %w", err)
+	}
+
+	return &domain.JobAbuseFlagResponse{
+		Success: true,
+		Message: "Job abuse flag resolved",
+		Data:    flag,
+	}, nil
+}
+
+func (uc *jobUseCase) ListPendingReview(ctx context.Context, page, limit int) (*domain.JobListResponse, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	jobs, total, err := uc.repo.ListPendingReview(ctx, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing jobs waiting for review: %w", err)
+	}
+	setComputedFields(jobs...)
+
+	// Calculate total pages
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return &domain.JobListResponse{
+		Success:    true,
+		Message:    "Successfully retrieved jobs waiting for review",
+		Data:       jobs,
+		PageNumber: page,
+		PageSize:   len(jobs),
+		TotalItems: total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (uc *jobUseCase) ApproveJob(ctx context.Context, jobID, adminID string) (*domain.JobResponse, error) {
+	job, err := uc.getPendingReview(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	moderation := *job.Moderation
+	moderation.Status = domain.JobApproved
+	moderation.ReviewedBy = adminID
+	moderation.ReviewedAt = &now
+	moderation.Reason = ""
+	// Scheduled jobs go live at their publish time. Jobs whose deadline or
+	// expiry passed while waiting stay drafts, the company can publish them
+	// again with new dates without another review.
+	publish := !job.Scheduled(now) && !job.PastDeadline(now) && !job.Expired(now)
+
+	if err := uc.repo.ReviewJob(ctx, jobID, &moderation, publish); err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, apperrors.NewNotFoundError("Job waiting for review not found")
+		}
+		return nil, err
+	}
+	if err := uc.repo.AddAuditEntry(ctx, &domain.JobAuditEntry{JobID: jobID, Action: domain.JobActionApprove, ActorID: adminID}); err != nil {
+		return nil, err
+	}
+
+	approved, err := uc.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.JobResponse{
+		Success: true,
+		Message: "Job approved",
+		Data:    approved,
+	}, nil
+}
+
+func (uc *jobUseCase) RejectJob(ctx context.Context, jobID string, req *domain.RejectJobRequest, adminID string) (*domain.JobResponse, error) {
+	job, err := uc.getPendingReview(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	moderation := *job.Moderation
+	moderation.Status = domain.JobRejected
+	moderation.ReviewedBy = adminID
+	moderation.ReviewedAt = &now
+	moderation.Reason = strings.TrimSpace(req.Reason)
+
+	if err := uc.repo.ReviewJob(ctx, jobID, &moderation, false); err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, apperrors.NewNotFoundError("Job waiting for review not found")
+		}
+		return nil, err
+	}
+	err = uc.repo.AddAuditEntry(ctx, &domain.JobAuditEntry{
+		JobID:   jobID,
+		Action:  domain.JobActionReject,
+		ActorID: adminID,
+		Details: map[string]interface{}{"reason": moderation.Reason},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The reason is on the job, the email only tells the company about it
+	company, err := uc.userRepo.FindByID(ctx, job.CreatedBy)
+	if err == nil {
+		err = uc.mailer.Send(ctx, email.Message{
+			To:      company.Email,
+			Subject: fmt.Sprintf("Your job posting %s was not approved", job.Title),
+			Body: fmt.Sprintf("Hi %s,\n\nYour job posting \"%s\" was not approved for the following reason:\n\n%s\n\n"+
+				"You can edit it and publish it again to submit it for another review:\n\n%s/jobs/%s\n",
+				company.Name, job.Title, moderation.Reason, uc.frontendURL, jobID),
+		})
+	}
+	if err != nil {
+		log.Printf("Failed to tell company %s about the rejection of job %s: %v", job.CreatedBy, jobID, err)
+	}
+
+	rejected, err := uc.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.JobResponse{
+		Success: true,
+		Message: "Job rejected",
+		Data:    rejected,
+	}, nil
+}
+
+func (uc *jobUseCase) FeatureJob(ctx context.Context, jobID string, req *domain.FeatureJobRequest, userID string) (*domain.JobResponse, error) {
+	job, err := uc.getOwnedJob(ctx, jobID, userID, "You don't have permission to feature this job")
+	if err != nil {
+		return nil, err
+	}
+
+	// Only jobs in the listings can be featured in them
+	now := time.Now()
+	if job.IsClosed() {
+		return nil, errJobClosed()
+	}
+	if !job.IsPublished || job.Expired(now) || job.PastDeadline(now) {
+		return nil, apperrors.NewConflictError("Only published jobs can be featured")
+	}
+
+	// Extending a running feature keeps its start, it can't be renewed forever
+	since := job.FeatureStart(now)
+	until := now.AddDate(0, 0, req.Days)
+	if latest := since.AddDate(0, 0, domain.MaxFeatureDays); until.After(latest) {
+		return nil, apperrors.NewConflictError(fmt.Sprintf("A job is featured for at most %d days in a row", domain.MaxFeatureDays)).WithDetails(
+			[]string{fmt.Sprintf("The running feature can be extended until %s at the latest", latest.Format(time.RFC3339))})
+	}
+
+	if err := uc.repo.FeatureJob(ctx, jobID, job.CreatedBy, since, until, domain.MaxFeaturedJobs); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrFeaturedJobsLimit):
+			return nil, apperrors.NewConflictError(fmt.Sprintf("At most %d jobs can be featured at once", domain.MaxFeaturedJobs))
+		case isNotFound(err, domain.ErrJobNotFound):
+			return nil, apperrors.NewNotFoundError("Job not found")
+		}
+		return nil, err
+	}
+	err = uc.repo.AddAuditEntry(ctx, &domain.JobAuditEntry{
+		JobID:   jobID,
+		Action:  domain.JobActionFeature,
+		ActorID: userID,
+		Details: map[string]interface{}{"featured_until": until},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.JobResponse{
+		Success: true,
+		Message: "Job featured successfully",
+		Data:    updated,
+	}, nil
+}
+
+func (uc *jobUseCase) UnfeatureJob(ctx context.Context, jobID, userID string) (*domain.JobResponse, error) {
+	job, err := uc.getOwnedJob(ctx, jobID, userID, "You don't have permission to unfeature this job")
+	if err != nil {
+		return nil, err
+	}
+	if !job.Featured(time.Now()) {
+		return nil, apperrors.NewConflictError("This job isn't featured")
+	}
+
+	if err := uc.repo.UnfeatureJob(ctx, jobID); err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, apperrors.NewNotFoundError("Job not found")
+		}
+		return nil, err
+	}
+	if err := uc.repo.AddAuditEntry(ctx, &domain.JobAuditEntry{JobID: jobID, Action: domain.JobActionUnfeature, ActorID: userID}); err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.JobResponse{
+		Success: true,
+		Message: "Job unfeatured successfully",
+		Data:    updated,
+	}, nil
+}
+
+// needsReview reports whether the job has to be approved before going live
+func (uc *jobUseCase) needsReview(job *domain.Job) bool {
+	return uc.moderation && !job.Approved()
+}
+
+// submitForReview queues the job for an admin's review
+func (uc *jobUseCase) submitForReview(ctx context.Context, jobID, userID string) error {
+	moderation := &domain.JobModeration{Status: domain.JobPendingReview, SubmittedAt: time.Now()}
+	if err := uc.repo.SetModeration(ctx, jobID, moderation); err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return apperrors.NewNotFoundError("Job not found")
+		}
+		return fmt.Errorf("error submitting job for review: %w", err)
+	}
+	return uc.repo.AddAuditEntry(ctx, &domain.JobAuditEntry{JobID: jobID, Action: domain.JobActionSubmit, ActorID: userID})
+}
+
+// getPendingReview returns a job waiting for review
+func (uc *jobUseCase) getPendingReview(ctx context.Context, jobID string) (*domain.Job, error) {
+	job, err := uc.repo.GetJobByID(ctx, jobID)
+	if err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, apperrors.NewNotFoundError("Job waiting for review not found")
+		}
+		return nil, err
+	}
+	if !job.PendingReview() || job.IsClosed() {
+		return nil, apperrors.NewNotFoundError("Job waiting for review not found")
+	}
+	return job, nil
+}
+
+// throttle rejects a change once the job's audit trail holds limit entries
+// with one of the actions in the last domain.JobActivityWindow, and flags the
+// job for admins
+func (uc *jobUseCase) throttle(ctx context.Context, job *domain.Job, reason domain.JobAbuseReason, limit int, actions ...string) error {
+	count, err := uc.repo.CountAuditEntries(ctx, job.ID.Hex(), actions, time.Now().Add(-domain.JobActivityWindow))
+	if err != nil {
+		return fmt.Errorf("error counting job changes: %w", err)
+	}
+	if count < int64(limit) {
+		return nil
+	}
+
+	uc.raiseAbuseFlag(ctx, job, reason)
+	return apperrors.NewTooManyRequestsError("This job was changed too often, try again later").WithDetails(
+		[]string{fmt.Sprintf("At most %d changes of this kind are allowed per day", limit)})
+}
+
+// raiseAbuseFlag records the throttled attempt for admins. Failing to record
+// it doesn't change the outcome for the company.
+func (uc *jobUseCase) raiseAbuseFlag(ctx context.Context, job *domain.Job, reason domain.JobAbuseReason) {
+	if err := uc.flagRepo.Raise(ctx, job.ID.Hex(), job.CreatedBy, reason); err != nil {
+		log.Printf("Failed to flag job %s for %s: %v", job.ID.Hex(), reason, err)
+	}
+}
+
+// errJobClosed is returned when a closed job is reopened, reposted or applied to
+func errJobClosed() error {
+	return apperrors.NewConflictError("This job is closed")
+}
+
+// errJobFull is returned when a job that reached its application cap is applied to
+func errJobFull() error {
+	return apperrors.NewConflictError("This job is no longer accepting applications")
+}
+
+// checkSchedule validates the publish and expiry times set by a request.
+// publishAt and expiresAt are the times the job ends up with.
+func checkSchedule(now time.Time, newPublishAt, newExpiresAt, publishAt, expiresAt *time.Time) error {
+	var errs []string
+	if newPublishAt != nil && !newPublishAt.After(now) {
+		errs = append(errs, "The publish time must be in the future")
+	}
+	if newExpiresAt != nil && !newExpiresAt.After(now) {
+		errs = append(errs, "The expiry time must be in the future")
+	}
+	if publishAt != nil && expiresAt != nil && !expiresAt.After(*publishAt) {
+		errs = append(errs, "The expiry time must come after the publish time")
+	}
+	if len(errs) > 0 {
+		return apperrors.NewBadRequestError("Validation failed", errs)
+	}
+	return nil
+}
+
+func errDeadlineNotFuture() error {
+	return apperrors.NewBadRequestError("Validation failed", []string{"The application deadline must be in the future"})
+}
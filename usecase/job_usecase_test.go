@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/currency"
+	apperrors "job-portal-backend/pkg/errors"
+)
+
+// benchmarkJobs returns n published jobs of one company, with the fields the
+// listing computes or converts on the way out
+func benchmarkJobs(n int, companyID string) []*domain.Job {
+	now := time.Now()
+	deadline := now.Add(30 * 24 * time.Hour)
+	jobs := make([]*domain.Job, n)
+	for i := range jobs {
+		jobs[i] = &domain.Job{
+			Title:          fmt.Sprintf("Backend Engineer %d", i),
+			Description:    "Build and run the services behind the job portal.",
+			Location:       "Addis Ababa",
+			EmploymentType: domain.FullTime,
+			Salary:         &domain.SalaryRange{Min: 30000, Max: 50000, Currency: "USD", Period: domain.SalaryPerYear},
+			Skills:         []string{"go", "mongodb", "docker"},
+			IsPublished:    true,
+			Deadline:       &deadline,
+			CreatedBy:      companyID,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+	}
+	return jobs
+}
+
+func newBenchmarkJobUseCase(repo *fakeJobRepo) JobUseCase {
+	return NewJobUseCase(repo, nil, nil, nil, nil, &fakeMemberRepo{}, nil, nil, nil, nil, &fakeNotifier{}, currency.NewNoopProvider(), "http://localhost:3000", false)
+}
+
+// BenchmarkListJobs measures the use case around the repository: pagination
+// defaults, computed fields and salary conversion. Database time is covered
+// by the load profile in loadtest/.
+func BenchmarkListJobs(b *testing.B) {
+	uc := newBenchmarkJobUseCase(newFakeJobRepo(benchmarkJobs(1000, "company")...))
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		page := i%50 + 1
+		if _, _, err := uc.ListJobs(ctx, domain.JobFilter{}, page, 20); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// A job whose company is gone is marked too, so it doesn't take a place in
+// every batch ahead of jobs that can be reminded. Jobs failing for a reason
+// that may pass are left for the next run, without stopping the batch.
+func TestSendHiringRemindersMarksSkippedJobs(t *testing.T) {
+	confirmed := time.Now().Add(-domain.ActivelyHiringWindow)
+	orphan := &domain.Job{Title: "Orphaned", IsPublished: true, CreatedBy: "deleted-company", HiringConfirmedAt: &confirmed}
+	unreachable := &domain.Job{Title: "Unreachable", IsPublished: true, CreatedBy: "flaky-company", HiringConfirmedAt: &confirmed}
+	bounced := &domain.Job{Title: "Bounced", IsPublished: true, CreatedBy: "bouncing-company", HiringConfirmedAt: &confirmed}
+	stale := &domain.Job{Title: "Stale", IsPublished: true, CreatedBy: "company", HiringConfirmedAt: &confirmed}
+	repo := newFakeJobRepo(orphan, unreachable, bounced, stale)
+	users := &fakeUserRepo{
+		users: map[string]*domain.User{
+			"company":          {Name: "Acme", Email: "jobs@acme.example", Role: domain.Company},
+			"bouncing-company": {Name: "Bounce", Email: "jobs@bounce.example", Role: domain.Company},
+		},
+		errs: map[string]error{"flaky-company": errors.New("connection reset")},
+	}
+	notifier := &fakeNotifier{failFor: map[string]bool{"bouncing-company": true}}
+	uc := NewJobUseCase(repo, nil, users, nil, nil, &fakeMemberRepo{}, nil, nil, nil, nil, notifier, currency.NewNoopProvider(), "http://localhost:3000", false)
+
+	if err := uc.SendHiringReminders(context.Background()); err != nil {
+		t.Fatalf("SendHiringReminders() error = %v", err)
+	}
+
+	if len(notifier.sent) != 1 || notifier.sent[0] != domain.NotificationHiringReminder {
+		t.Errorf("sent %v, want one hiring reminder", notifier.sent)
+	}
+	for _, job := range []*domain.Job{orphan, stale} {
+		if job.HiringReminderSentAt == nil {
+			t.Errorf("job %q not marked as reminded", job.Title)
+		}
+	}
+	for _, job := range []*domain.Job{unreachable, bounced} {
+		if job.HiringReminderSentAt != nil {
+			t.Errorf("job %q marked as reminded, want it retried", job.Title)
+		}
+	}
+}
+
+// featuredJob returns a published job of the company featured from since until until
+func featuredJob(companyID string, since, until time.Time) *domain.Job {
+	return &domain.Job{Title: "Featured", IsPublished: true, CreatedBy: companyID, FeaturedSince: &since, FeaturedUntil: &until}
+}
+
+func TestFeatureJobExtensionKeepsStart(t *testing.T) {
+	now := time.Now()
+	job := featuredJob("company", now.AddDate(0, 0, -20), now.AddDate(0, 0, 2))
+	uc := newBenchmarkJobUseCase(newFakeJobRepo(job))
+
+	_, err := uc.FeatureJob(context.Background(), job.ID.Hex(), &domain.FeatureJobRequest{Days: 11}, "company")
+	if appErr, ok := apperrors.As(err); !ok || appErr.Code != http.StatusConflict {
+		t.Fatalf("FeatureJob() past %d days from the start: error = %v, want a conflict", domain.MaxFeatureDays, err)
+	}
+
+	resp, err := uc.FeatureJob(context.Background(), job.ID.Hex(), &domain.FeatureJobRequest{Days: 9}, "company")
+	if err != nil {
+		t.Fatalf("FeatureJob() error = %v", err)
+	}
+	featured := resp.Data.(*domain.Job)
+	if !featured.FeaturedSince.Equal(*job.FeaturedSince) {
+		t.Errorf("featured_since = %v, want the running feature's start %v", featured.FeaturedSince, job.FeaturedSince)
+	}
+}
+
+func TestFeatureJobLimit(t *testing.T) {
+	now := time.Now()
+	jobs := []*domain.Job{{Title: "Unfeatured", IsPublished: true, CreatedBy: "company"}}
+	for i := 0; i < domain.MaxFeaturedJobs; i++ {
+		jobs = append(jobs, featuredJob("company", now, now.AddDate(0, 0, 7)))
+	}
+	uc := newBenchmarkJobUseCase(newFakeJobRepo(jobs...))
+
+	_, err := uc.FeatureJob(context.Background(), jobs[0].ID.Hex(), &domain.FeatureJobRequest{Days: 7}, "company")
+	if appErr, ok := apperrors.As(err); !ok || appErr.Code != http.StatusConflict {
+		t.Fatalf("FeatureJob() over the limit: error = %v, want a conflict", err)
+	}
+	if jobs[0].FeaturedUntil != nil {
+		t.Errorf("featured_until = %v, want the job left unfeatured", jobs[0].FeaturedUntil)
+	}
+}
@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// maxReportedItems bounds the applications and jobs listed in a user data report
+const maxReportedItems = 100
+
+// MaintenanceUsecase runs the operational tasks of the jobctl command line tool
+type MaintenanceUsecase interface {
+	// PurgeExpired applies domain.RetentionRules now instead of waiting for the TTL monitor
+	PurgeExpired(ctx context.Context, dryRun bool) ([]*domain.PurgeResult, error)
+	// InspectUser gathers the data stored about the user with the given ID or email
+	InspectUser(ctx context.Context, idOrEmail string) (*domain.UserDataReport, error)
+	// CreateAdmin creates an admin account. The email must not be in use.
+	CreateAdmin(ctx context.Context, req *domain.CreateAdminRequest) (*domain.User, error)
+	// RequeueFailedNotifications puts the notifications that couldn't be emailed
+	// back in line for the next digest run, and returns how many there were
+	RequeueFailedNotifications(ctx context.Context) (int64, error)
+	// RebuildApplicationProjections replays the event stream of every
+	// application into the applications collection, and returns how many
+	// applications were rebuilt
+	RebuildApplicationProjections(ctx context.Context) (int, error)
+}
+
+type maintenanceUsecase struct {
+	retentionRepo      repository.RetentionRepository
+	userRepo           repository.UserRepository
+	appRepo            repository.ApplicationRepository
+	appEventRepo       repository.ApplicationEventRepository
+	jobRepo            repository.JobRepository
+	profileRepo        repository.ApplicantProfileRepository
+	resumeRepo         repository.ResumeRepository
+	alertPrefsRepo     repository.AlertPreferencesRepository
+	notifyPrefsRepo    repository.NotificationPreferencesRepository
+	companyProfileRepo repository.CompanyProfileRepository
+	pendingRepo        repository.PendingNotificationRepository
+}
+
+func NewMaintenanceUsecase(
+	retentionRepo repository.RetentionRepository,
+	userRepo repository.UserRepository,
+	appRepo repository.ApplicationRepository,
+	appEventRepo repository.ApplicationEventRepository,
+	jobRepo repository.JobRepository,
+	profileRepo repository.ApplicantProfileRepository,
+	resumeRepo repository.ResumeRepository,
+	alertPrefsRepo repository.AlertPreferencesRepository,
+	notifyPrefsRepo repository.NotificationPreferencesRepository,
+	companyProfileRepo repository.CompanyProfileRepository,
+	pendingRepo repository.PendingNotificationRepository,
+) MaintenanceUsecase {
+	return &maintenanceUsecase{
+		retentionRepo:      retentionRepo,
+		userRepo:           userRepo,
+		appRepo:            appRepo,
+		appEventRepo:       appEventRepo,
+		jobRepo:            jobRepo,
+		profileRepo:        profileRepo,
+		resumeRepo:         resumeRepo,
+		alertPrefsRepo:     alertPrefsRepo,
+		notifyPrefsRepo:    notifyPrefsRepo,
+		companyProfileRepo: companyProfileRepo,
+		pendingRepo:        pendingRepo,
+	}
+}
+
+func (uc *maintenanceUsecase) PurgeExpired(ctx context.Context, dryRun bool) ([]*domain.PurgeResult, error) {
+	now := time.Now()
+
+	results := make([]*domain.PurgeResult, 0, len(domain.RetentionRules))
+	for _, rule := range domain.RetentionRules {
+		cutoff := now.Add(-rule.MaxAge)
+		expired, err := uc.retentionRepo.Purge(ctx, rule, cutoff, dryRun)
+		if err != nil {
+			return results, fmt.Errorf("error purging %s: %v", rule.Collection, err)
+		}
+		results = append(results, &domain.PurgeResult{Collection: rule.Collection, Cutoff: cutoff, Expired: expired})
+	}
+
+	return results, nil
+}
+
+func (uc *maintenanceUsecase) InspectUser(ctx context.Context, idOrEmail string) (*domain.UserDataReport, error) {
+	var user *domain.User
+	var err error
+	if primitive.IsValidObjectID(idOrEmail) {
+		user, err = uc.userRepo.FindByID(ctx, idOrEmail)
+	} else {
+		user, err = uc.userRepo.FindByEmail(ctx, idOrEmail)
+	}
+	if err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewNotFoundError("User not found")
+		}
+		return nil, err
+	}
+	user.Sanitize()
+	userID := user.ID.Hex()
+
+	report := &domain.UserDataReport{User: user}
+	if report.NotificationPreferences, err = uc.notifyPrefsRepo.GetByUserID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("error retrieving notification preferences: %w", err)
+	}
+
+	switch user.Role {
+	case domain.Applicant:
+		if report.Profile, err = uc.profileRepo.GetByUserID(ctx, userID); err != nil {
+			return nil, fmt.Errorf("error retrieving profile: %w", err)
+		}
+		if report.Resumes, err = uc.resumeRepo.ListByUser(ctx, userID); err != nil {
+			return nil, fmt.Errorf("error retrieving resumes: %w", err)
+		}
+		if report.AlertPreferences, err = uc.alertPrefsRepo.GetByUserID(ctx, userID); err != nil {
+			return nil, fmt.Errorf("error retrieving alert preferences: %w", err)
+		}
+		if report.Applications, report.ApplicationCount, err = uc.appRepo.GetApplicationsByApplicant(ctx, userID, 1, maxReportedItems); err != nil {
+			return nil, fmt.Errorf("error retrieving applications: %w", err)
+		}
+	case domain.Company:
+		report.CompanyProfile, err = uc.companyProfileRepo.GetByCompanyID(ctx, userID)
+		if err != nil && !errors.Is(err, domain.ErrCompanyProfileNotFound) {
+			return nil, fmt.Errorf("error retrieving company profile: %w", err)
+		}
+		if report.Jobs, report.JobCount, err = uc.jobRepo.GetJobsByCompanyID(ctx, userID, domain.CompanyJobFilter{}, 1, maxReportedItems); err != nil {
+			return nil, fmt.Errorf("error retrieving jobs: %w", err)
+		}
+	}
+
+	return report, nil
+}
+
+func (uc *maintenanceUsecase) CreateAdmin(ctx context.Context, req *domain.CreateAdminRequest) (*domain.User, error) {
+	existing, err := uc.userRepo.FindByEmail(ctx, req.Email)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperrors.NewConflictError(fmt.Sprintf("Email is already used by a %s account", existing.Role))
+	}
+
+	now := time.Now()
+	user := &domain.User{
+		Name:      req.Name,
+		Email:     req.Email,
+		Password:  req.Password, // Will be hashed in repository
+		Role:      domain.Admin,
+		Status:    domain.UserActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.userRepo.CreateUser(ctx, user); err != nil {
+		if errors.Is(err, domain.ErrEmailAlreadyExists) {
+			return nil, apperrors.NewConflictError("Email is already in use")
+		}
+		return nil, fmt.Errorf("error creating admin: %w", err)
+	}
+	user.Sanitize()
+
+	return user, nil
+}
+
+func (uc *maintenanceUsecase) RequeueFailedNotifications(ctx context.Context) (int64, error) {
+	count, err := uc.pendingRepo.RequeueFailed(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error requeueing failed notifications: %w", err)
+	}
+	return count, nil
+}
+
+func (uc *maintenanceUsecase) RebuildApplicationProjections(ctx context.Context) (int, error) {
+	ids, err := uc.appEventRepo.ListApplicationIDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error listing application streams: %w", err)
+	}
+
+	rebuilt := 0
+	for _, id := range ids {
+		events, err := uc.appEventRepo.ListByApplication(ctx, id)
+		if err != nil {
+			return rebuilt, fmt.Errorf("error getting events of application %s: %w", id.Hex(), err)
+		}
+		if err := uc.appRepo.Project(ctx, domain.ReplayApplication(events)); err != nil {
+			return rebuilt, fmt.Errorf("error projecting application %s: %w", id.Hex(), err)
+		}
+		rebuilt++
+	}
+	return rebuilt, nil
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/email"
+	"job-portal-backend/repository"
+)
+
+// NotificationUsecase manages users' notification preferences and delivers the
+// optional emails that honour them. Every optional notification should go
+// through Notify rather than the mailer, so the user's choices are respected.
+type NotificationUsecase interface {
+	GetPreferences(ctx context.Context, userID string) (*domain.NotificationPreferencesResponse, error)
+	// UpdatePreferences changes the fields set in the request
+	UpdatePreferences(ctx context.Context, userID string, req *domain.UpdateNotificationPreferencesRequest) (*domain.NotificationPreferencesResponse, error)
+	// Allows reports whether the user wants notifications of the given kind,
+	// so notifications that are costly to put together are only built when wanted
+	Allows(ctx context.Context, userID string, kind domain.NotificationKind) (bool, error)
+	// Notify emails the user, holds the notification for their digest, or
+	// drops it when they turned that kind of notification off
+	Notify(ctx context.Context, userID string, kind domain.NotificationKind, subject, body string) error
+	// SendDigests emails the notifications held back for users whose digest is due
+	SendDigests(ctx context.Context) error
+}
+
+type notificationUsecase struct {
+	prefsRepo   repository.NotificationPreferencesRepository
+	pendingRepo repository.PendingNotificationRepository
+	userRepo    repository.UserRepository
+	mailer      email.Sender
+	frontendURL string
+}
+
+func NewNotificationUsecase(prefsRepo repository.NotificationPreferencesRepository, pendingRepo repository.PendingNotificationRepository, userRepo repository.UserRepository, mailer email.Sender, frontendURL string) NotificationUsecase {
+	return &notificationUsecase{
+		prefsRepo:   prefsRepo,
+		pendingRepo: pendingRepo,
+		userRepo:    userRepo,
+		mailer:      mailer,
+		frontendURL: frontendURL,
+	}
+}
+
+func (uc *notificationUsecase) GetPreferences(ctx context.Context, userID string) (*domain.NotificationPreferencesResponse, error) {
+	prefs, err := uc.prefsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving notification preferences: %w", err)
+	}
+
+	return &domain.NotificationPreferencesResponse{
+		Success: true,
+		Message: "Successfully retrieved notification preferences",
+		Data:    prefs,
+	}, nil
+}
+
+func (uc *notificationUsecase) UpdatePreferences(ctx context.Context, userID string, req *domain.UpdateNotificationPreferencesRequest) (*domain.NotificationPreferencesResponse, error) {
+	prefs, err := uc.prefsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving notification preferences: %w", err)
+	}
+
+	prefs.Apply(req)
+	if err := uc.prefsRepo.Upsert(ctx, prefs); err != nil {
+		return nil, fmt.Errorf("error saving notification preferences: %w", err)
+	}
+
+	return &domain.NotificationPreferencesResponse{
+		Success: true,
+		Message: "Notification preferences updated successfully",
+		Data:    prefs,
+	}, nil
+}
+
+func (uc *notificationUsecase) Allows(ctx context.Context, userID string, kind domain.NotificationKind) (bool, error) {
+	prefs, err := uc.prefsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("error retrieving notification preferences: %w", err)
+	}
+	return prefs.Allows(kind), nil
+}
+
+func (uc *notificationUsecase) Notify(ctx context.Context, userID string, kind domain.NotificationKind, subject, body string) error {
+	prefs, err := uc.prefsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error retrieving notification preferences: %w", err)
+	}
+	if !prefs.Allows(kind) {
+		return nil
+	}
+
+	if prefs.Digested() {
+		return uc.pendingRepo.Enqueue(ctx, &domain.PendingNotification{
+			UserID:  userID,
+			Kind:    kind,
+			Subject: subject,
+			Body:    body,
+		})
+	}
+
+	user, ok := uc.recipient(ctx, userID)
+	if !ok {
+		return nil
+	}
+
+	err = uc.mailer.Send(ctx, email.Message{
+		To:      user.Email,
+		Subject: subject,
+		Body:    fmt.Sprintf("Hi %s,\n\n%s\n\n%s", user.Name, body, uc.footer()),
+	})
+	if err != nil {
+		// Kept so an operator can retry it once the mail relay is back
+		failed := &domain.PendingNotification{UserID: userID, Kind: kind, Subject: subject, Body: body, Failed: true}
+		if qerr := uc.pendingRepo.Enqueue(ctx, failed); qerr != nil {
+			log.Printf("Failed to keep undelivered notification for user %s: %v", userID, qerr)
+		}
+		return fmt.Errorf("error sending notification: %w", err)
+	}
+	return nil
+}
+
+func (uc *notificationUsecase) SendDigests(ctx context.Context) error {
+	userIDs, err := uc.pendingRepo.ListUserIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, userID := range userIDs {
+		prefs, err := uc.prefsRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("error retrieving notification preferences: %w", err)
+		}
+		if !prefs.DigestDue(now) {
+			continue
+		}
+
+		pending, err := uc.pendingRepo.ListByUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		// Kinds turned off since they were queued are dropped
+		var sections []string
+		ids := make([]primitive.ObjectID, 0, len(pending))
+		for _, n := range pending {
+			ids = append(ids, n.ID)
+			if prefs.Allows(n.Kind) {
+				sections = append(sections, fmt.Sprintf("%s\n%s", n.Subject, n.Body))
+			}
+		}
+
+		// Requeued notifications of users without a digest go out the same way
+		subject := fmt.Sprintf("You have %d job portal updates", len(sections))
+		if prefs.Digested() {
+			subject = fmt.Sprintf("Your %s job portal digest: %d updates", prefs.DigestFrequency, len(sections))
+		}
+
+		user, ok := uc.recipient(ctx, userID)
+		if ok && len(sections) > 0 {
+			err = uc.mailer.Send(ctx, email.Message{
+				To:      user.Email,
+				Subject: subject,
+				Body: fmt.Sprintf("Hi %s,\n\nHere are your latest job portal updates:\n\n%s\n\n%s",
+					user.Name, strings.Join(sections, "\n\n"), uc.footer()),
+			})
+			if err != nil {
+				return fmt.Errorf("error sending digest: %w", err)
+			}
+		}
+
+		if err := uc.pendingRepo.DeleteByIDs(ctx, ids); err != nil {
+			return err
+		}
+		if prefs.Digested() {
+			if err := uc.prefsRepo.MarkDigestSent(ctx, userID, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// recipient returns the user to notify. Deleted and suspended accounts get no notifications.
+func (uc *notificationUsecase) recipient(ctx context.Context, userID string) (*domain.User, bool) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		log.Printf("Skipping notification for user %s: %v", userID, err)
+		return nil, false
+	}
+	if user.IsDeleted() || user.IsSuspended() {
+		return nil, false
+	}
+	return user, true
+}
+
+func (uc *notificationUsecase) footer() string {
+	return fmt.Sprintf("You can change which emails you receive in your notification settings: %s/settings/notifications", uc.frontendURL)
+}
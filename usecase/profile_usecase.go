@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// ProfileUsecase manages applicants' structured profiles
+type ProfileUsecase interface {
+	GetProfile(ctx context.Context, userID string) (*domain.ApplicantProfileResponse, error)
+	// UpdateProfile replaces the applicant's profile
+	UpdateProfile(ctx context.Context, userID string, req *domain.UpdateApplicantProfileRequest) (*domain.ApplicantProfileResponse, error)
+}
+
+type profileUsecase struct {
+	profileRepo repository.ApplicantProfileRepository
+}
+
+func NewProfileUsecase(profileRepo repository.ApplicantProfileRepository) ProfileUsecase {
+	return &profileUsecase{
+		profileRepo: profileRepo,
+	}
+}
+
+func (uc *profileUsecase) GetProfile(ctx context.Context, userID string) (*domain.ApplicantProfileResponse, error) {
+	profile, err := uc.profileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving profile: %w", err)
+	}
+
+	return &domain.ApplicantProfileResponse{
+		Success: true,
+		Message: "Successfully retrieved profile",
+		Data:    profile,
+	}, nil
+}
+
+func (uc *profileUsecase) UpdateProfile(ctx context.Context, userID string, req *domain.UpdateApplicantProfileRequest) (*domain.ApplicantProfileResponse, error) {
+	// Months are YYYY-MM, so they compare as strings
+	var errs []string
+	for i, exp := range req.Experience {
+		if exp.EndMonth != "" && exp.EndMonth < exp.StartMonth {
+			errs = append(errs, fmt.Sprintf("Experience %d ends before it starts", i+1))
+		}
+	}
+	for i, edu := range req.Education {
+		if edu.StartMonth != "" && edu.EndMonth != "" && edu.EndMonth < edu.StartMonth {
+			errs = append(errs, fmt.Sprintf("Education %d ends before it starts", i+1))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, apperrors.NewBadRequestError("Validation failed", errs)
+	}
+
+	profile := &domain.ApplicantProfile{
+		UserID:     userID,
+		Headline:   strings.TrimSpace(req.Headline),
+		Summary:    strings.TrimSpace(req.Summary),
+		Skills:     normalizeList(req.Skills, false),
+		Experience: req.Experience,
+		Education:  req.Education,
+		Links:      req.Links,
+	}
+	if profile.Experience == nil {
+		profile.Experience = []domain.WorkExperience{}
+	}
+	if profile.Education == nil {
+		profile.Education = []domain.Education{}
+	}
+	if profile.Links == nil {
+		profile.Links = []domain.ProfileLink{}
+	}
+
+	if err := uc.profileRepo.Upsert(ctx, profile); err != nil {
+		return nil, fmt.Errorf("error saving profile: %w", err)
+	}
+
+	return &domain.ApplicantProfileResponse{
+		Success: true,
+		Message: "Profile updated successfully",
+		Data:    profile,
+	}, nil
+}
@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// ReportUsecase builds the hiring outcome reports companies and admins file
+// for equal-opportunity reporting
+type ReportUsecase interface {
+	// HiringOutcomes reports the outcome of the applications received in the
+	// filter's period, per job and in total
+	HiringOutcomes(ctx context.Context, filter domain.HiringReportFilter) (*domain.HiringOutcomeReport, error)
+	// JobClosings sums up the jobs closed in the filter's period per closing reason
+	JobClosings(ctx context.Context, filter domain.HiringReportFilter) (*domain.JobClosingReport, error)
+	// Funnel reports how far the applications received in the filter's period
+	// got, with the time spent in each stage and the time to hire
+	Funnel(ctx context.Context, filter domain.HiringReportFilter) (*domain.FunnelReport, error)
+}
+
+type reportUsecase struct {
+	appRepo repository.ApplicationRepository
+	jobRepo repository.JobRepository
+}
+
+func NewReportUsecase(appRepo repository.ApplicationRepository, jobRepo repository.JobRepository) ReportUsecase {
+	return &reportUsecase{
+		appRepo: appRepo,
+		jobRepo: jobRepo,
+	}
+}
+
+func (uc *reportUsecase) HiringOutcomes(ctx context.Context, filter domain.HiringReportFilter) (*domain.HiringOutcomeReport, error) {
+	if err := validateReportPeriod(filter); err != nil {
+		return nil, err
+	}
+
+	jobs, err := uc.appRepo.HiringOutcomes(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error computing hiring outcomes: %w", err)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].JobTitle != jobs[j].JobTitle {
+			return jobs[i].JobTitle < jobs[j].JobTitle
+		}
+		return jobs[i].JobID < jobs[j].JobID
+	})
+
+	totals := &domain.JobHiringOutcome{RejectionReasons: map[domain.RejectionReason]int64{}}
+	for _, job := range jobs {
+		totals.Applications += job.Applications
+		totals.Interviews += job.Interviews
+		totals.Hires += job.Hires
+		totals.Rejections += job.Rejections
+		for reason, count := range job.RejectionReasons {
+			totals.RejectionReasons[reason] += count
+		}
+	}
+
+	return &domain.HiringOutcomeReport{
+		From:   filter.From,
+		To:     filter.To,
+		Jobs:   jobs,
+		Totals: totals,
+	}, nil
+}
+
+func (uc *reportUsecase) JobClosings(ctx context.Context, filter domain.HiringReportFilter) (*domain.JobClosingReport, error) {
+	if err := validateReportPeriod(filter); err != nil {
+		return nil, err
+	}
+
+	summaries, err := uc.jobRepo.ClosingSummaries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error computing job closings: %w", err)
+	}
+	byReason := make(map[domain.JobCloseReason]*domain.JobClosingSummary, len(summaries))
+	for _, summary := range summaries {
+		byReason[summary.Reason] = summary
+	}
+
+	// Every reason is listed, in a fixed order, with the totals weighted by job count
+	report := &domain.JobClosingReport{
+		From:    filter.From,
+		To:      filter.To,
+		Reasons: make([]*domain.JobClosingSummary, 0, len(domain.JobCloseReasons)),
+		Totals:  &domain.JobClosingSummary{},
+	}
+	var applicants, daysOpen, timeToHire float64
+	for _, reason := range domain.JobCloseReasons {
+		summary, ok := byReason[reason]
+		if !ok {
+			summary = &domain.JobClosingSummary{Reason: reason}
+		}
+		report.Reasons = append(report.Reasons, summary)
+
+		report.Totals.Jobs += summary.Jobs
+		report.Totals.Hires += summary.Hires
+		applicants += summary.AvgApplicants * float64(summary.Jobs)
+		daysOpen += summary.AvgDaysOpen * float64(summary.Jobs)
+		if summary.AvgTimeToHireDays != nil {
+			timeToHire += *summary.AvgTimeToHireDays * float64(summary.HiringJobs)
+			report.Totals.HiringJobs += summary.HiringJobs
+		}
+	}
+	if report.Totals.Jobs > 0 {
+		report.Totals.AvgApplicants = applicants / float64(report.Totals.Jobs)
+		report.Totals.AvgDaysOpen = daysOpen / float64(report.Totals.Jobs)
+	}
+	if report.Totals.HiringJobs > 0 {
+		avg := timeToHire / float64(report.Totals.HiringJobs)
+		report.Totals.AvgTimeToHireDays = &avg
+	}
+
+	return report, nil
+}
+
+func (uc *reportUsecase) Funnel(ctx context.Context, filter domain.HiringReportFilter) (*domain.FunnelReport, error) {
+	if err := validateReportPeriod(filter); err != nil {
+		return nil, err
+	}
+
+	applications, err := uc.appRepo.FunnelApplications(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error listing applications: %w", err)
+	}
+
+	totals := newFunnelMetrics()
+	byJob := map[string]*funnelMetrics{}
+	byCompany := map[string]*funnelMetrics{}
+	var jobs, companies []*domain.JobFunnel
+	for _, app := range applications {
+		jobID := app.JobID.Hex()
+		metrics, ok := byJob[jobID]
+		if !ok {
+			metrics = newFunnelMetrics()
+			metrics.job = &domain.JobFunnel{JobID: jobID, JobTitle: app.JobTitle, CompanyID: app.CompanyID}
+			byJob[jobID] = metrics
+			jobs = append(jobs, metrics.job)
+		}
+
+		timeline := app.Timeline()
+		metrics.add(timeline)
+		totals.add(timeline)
+
+		if filter.CompanyID == "" {
+			company, ok := byCompany[app.CompanyID]
+			if !ok {
+				company = newFunnelMetrics()
+				company.job = &domain.JobFunnel{CompanyID: app.CompanyID}
+				byCompany[app.CompanyID] = company
+				companies = append(companies, company.job)
+			}
+			company.add(timeline)
+		}
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].JobTitle != jobs[j].JobTitle {
+			return jobs[i].JobTitle < jobs[j].JobTitle
+		}
+		return jobs[i].JobID < jobs[j].JobID
+	})
+	sort.Slice(companies, func(i, j int) bool { return companies[i].CompanyID < companies[j].CompanyID })
+	for _, metrics := range byJob {
+		metrics.finish()
+	}
+	for _, metrics := range byCompany {
+		metrics.finish()
+	}
+	totals.job = &domain.JobFunnel{JobTitle: "Total"}
+	totals.finish()
+	if jobs == nil {
+		jobs = []*domain.JobFunnel{}
+	}
+
+	return &domain.FunnelReport{
+		From:      filter.From,
+		To:        filter.To,
+		Jobs:      jobs,
+		Companies: companies,
+		Totals:    totals.job,
+	}, nil
+}
+
+// funnelMetrics collects the stage counts and durations of a job's applications
+type funnelMetrics struct {
+	job         *domain.JobFunnel
+	reached     map[domain.ApplicationStatus]int64
+	timeInStage map[domain.ApplicationStatus][]time.Duration
+	timeToHire  []time.Duration
+}
+
+func newFunnelMetrics() *funnelMetrics {
+	return &funnelMetrics{
+		reached:     map[domain.ApplicationStatus]int64{},
+		timeInStage: map[domain.ApplicationStatus][]time.Duration{},
+	}
+}
+
+// add counts an application's timeline. A stage entered twice, after the
+// pipeline was rewound, is counted once with the time spent in it added up.
+func (m *funnelMetrics) add(timeline []domain.StatusChange) {
+	reached := map[domain.ApplicationStatus]bool{}
+	spent := map[domain.ApplicationStatus]time.Duration{}
+	for i, change := range timeline {
+		reached[change.Status] = true
+		if i+1 < len(timeline) {
+			spent[change.Status] += timeline[i+1].At.Sub(change.At)
+		}
+		if change.Status == domain.StatusHired {
+			m.timeToHire = append(m.timeToHire, change.At.Sub(timeline[0].At))
+		}
+	}
+
+	for status := range reached {
+		m.reached[status]++
+	}
+	for status, d := range spent {
+		m.timeInStage[status] = append(m.timeInStage[status], d)
+	}
+}
+
+// finish fills the job's funnel in, every stage is listed in pipeline order
+func (m *funnelMetrics) finish() {
+	m.job.Stages = make([]*domain.StageFunnel, 0, len(domain.ApplicationStatuses))
+	for _, status := range domain.ApplicationStatuses {
+		m.job.Stages = append(m.job.Stages, &domain.StageFunnel{
+			Stage:       status,
+			Reached:     m.reached[status],
+			TimeInStage: domain.NewDurationStats(m.timeInStage[status]),
+		})
+	}
+	m.job.TimeToHire = domain.NewDurationStats(m.timeToHire)
+}
+
+// validateReportPeriod checks the period of a report is ordered and at most a year long
+func validateReportPeriod(filter domain.HiringReportFilter) error {
+	if !filter.To.After(filter.From) {
+		return apperrors.NewBadRequestError("Invalid period", []string{"from must be before to"})
+	}
+	if filter.To.Sub(filter.From) > domain.MaxHiringReportPeriod {
+		return apperrors.NewBadRequestError("Invalid period", []string{"The period cannot be longer than a year"})
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/imaging"
+	"job-portal-backend/pkg/storage"
+	"job-portal-backend/repository"
+)
+
+// thumbnailBatch bounds how many resumes one run processes
+const thumbnailBatch = 50
+
+// thumbnailRenderTimeout bounds rendering a single resume
+const thumbnailRenderTimeout = 30 * time.Second
+
+// ResumeThumbnailUsecase generates previews of the resumes applications were
+// submitted with, so company list views can show them without downloading
+// the file
+type ResumeThumbnailUsecase interface {
+	// GenerateThumbnails renders the first page of the PDF resumes that don't
+	// have a thumbnail yet. Other formats are skipped.
+	GenerateThumbnails(ctx context.Context) error
+}
+
+type resumeThumbnailUsecase struct {
+	appRepo  repository.ApplicationRepository
+	files    storage.Store
+	renderer imaging.PDFRenderer
+}
+
+// NewResumeThumbnailUsecase creates a ResumeThumbnailUsecase reading resumes
+// from files and storing their thumbnails next to them
+func NewResumeThumbnailUsecase(appRepo repository.ApplicationRepository, files storage.Store, renderer imaging.PDFRenderer) ResumeThumbnailUsecase {
+	return &resumeThumbnailUsecase{
+		appRepo:  appRepo,
+		files:    files,
+		renderer: renderer,
+	}
+}
+
+func (uc *resumeThumbnailUsecase) GenerateThumbnails(ctx context.Context) error {
+	applications, err := uc.appRepo.ListAwaitingThumbnail(ctx, thumbnailBatch)
+	if err != nil {
+		return err
+	}
+
+	// Library resumes are shared by applications, each is rendered once
+	done := make(map[string]bool)
+	for _, application := range applications {
+		link := application.ResumeLink
+		if done[link] {
+			continue
+		}
+		done[link] = true
+
+		thumbnailURL, err := uc.thumbnail(ctx, link)
+		if err != nil {
+			// The resume may be readable on the next run, e.g. once storage recovers
+			log.Printf("Failed to generate thumbnail of resume %s: %v", link, err)
+			continue
+		}
+		if err := uc.appRepo.SetResumeThumbnail(ctx, link, thumbnailURL); err != nil {
+			return fmt.Errorf("error saving resume thumbnail: %w", err)
+		}
+	}
+	return nil
+}
+
+// thumbnail renders and uploads the thumbnail of the resume at link. It
+// returns an empty URL without error when the resume can't have one.
+func (uc *resumeThumbnailUsecase) thumbnail(ctx context.Context, link string) (string, error) {
+	key := path.Base(link)
+	file, _, err := uc.files.Download(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrFileNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	data, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		return "", err
+	}
+
+	renderCtx, cancel := context.WithTimeout(ctx, thumbnailRenderTimeout)
+	image, err := uc.renderer.FirstPage(renderCtx, data, domain.ResumeThumbnailWidth)
+	cancel()
+	if err != nil {
+		if !errors.Is(err, imaging.ErrNotPDF) {
+			log.Printf("Resume %s can't be rendered: %v", link, err)
+		}
+		return "", nil
+	}
+
+	thumbnailKey := strings.TrimSuffix(key, path.Ext(key)) + "-thumbnail.png"
+	return uc.files.Upload(ctx, thumbnailKey, "image/png", bytes.NewReader(image))
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// ResumeUsecase manages applicants' resume libraries
+type ResumeUsecase interface {
+	ListResumes(ctx context.Context, userID string) (*domain.ResumeResponse, error)
+	// AddResume records a resume the controller uploaded to the storage provider
+	AddResume(ctx context.Context, userID string, resume *domain.Resume) (*domain.ResumeResponse, error)
+	// DeleteResume removes a resume from the library. Applications already sent
+	// with it keep their link to the file.
+	DeleteResume(ctx context.Context, userID, resumeID string) (*domain.ResumeResponse, error)
+}
+
+type resumeUsecase struct {
+	resumeRepo repository.ResumeRepository
+}
+
+func NewResumeUsecase(resumeRepo repository.ResumeRepository) ResumeUsecase {
+	return &resumeUsecase{
+		resumeRepo: resumeRepo,
+	}
+}
+
+func (uc *resumeUsecase) ListResumes(ctx context.Context, userID string) (*domain.ResumeResponse, error) {
+	resumes, err := uc.resumeRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing resumes: %w", err)
+	}
+
+	return &domain.ResumeResponse{
+		Success: true,
+		Message: "Successfully retrieved resumes",
+		Data:    resumes,
+	}, nil
+}
+
+func (uc *resumeUsecase) AddResume(ctx context.Context, userID string, resume *domain.Resume) (*domain.ResumeResponse, error) {
+	count, err := uc.resumeRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error counting resumes: %w", err)
+	}
+	if count >= domain.MaxResumes {
+		return nil, apperrors.NewConflictError(fmt.Sprintf("You can keep at most %d resumes, delete one first", domain.MaxResumes))
+	}
+
+	resume.UserID = userID
+	if err := uc.resumeRepo.Create(ctx, resume); err != nil {
+		return nil, fmt.Errorf("error saving resume: %w", err)
+	}
+
+	return &domain.ResumeResponse{
+		Success: true,
+		Message: "Resume uploaded successfully",
+		Data:    resume,
+	}, nil
+}
+
+func (uc *resumeUsecase) DeleteResume(ctx context.Context, userID, resumeID string) (*domain.ResumeResponse, error) {
+	if err := uc.resumeRepo.Delete(ctx, resumeID, userID); err != nil {
+		if isNotFound(err, domain.ErrResumeNotFound) {
+			return nil, apperrors.NewNotFoundError("Resume not found")
+		}
+		return nil, fmt.Errorf("error deleting resume: %w", err)
+	}
+
+	return &domain.ResumeResponse{
+		Success: true,
+		Message: "Resume deleted successfully",
+	}, nil
+}
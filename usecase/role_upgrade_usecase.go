@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/email"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// RoleUpgradeUsecase handles applicants asking to become company accounts
+type RoleUpgradeUsecase interface {
+	RequestUpgrade(ctx context.Context, userID string, req *domain.CreateRoleUpgradeRequest) (*domain.RoleUpgradeResponse, error)
+	// GetMyUpgrade returns the user's latest request, so clients know when to refresh their token
+	GetMyUpgrade(ctx context.Context, userID string) (*domain.RoleUpgradeResponse, error)
+	// ListUpgrades returns the admin review queue
+	ListUpgrades(ctx context.Context, status domain.RoleUpgradeStatus, page, limit int) (*domain.UserListResponse, error)
+	// ApproveUpgrade switches the user to the company role. Their current token keeps
+	// the old role until they call POST /api/v1/auth/refresh or log in again.
+	ApproveUpgrade(ctx context.Context, adminID, upgradeID string, req *domain.ReviewRoleUpgradeRequest) (*domain.RoleUpgradeResponse, error)
+	RejectUpgrade(ctx context.Context, adminID, upgradeID string, req *domain.ReviewRoleUpgradeRequest) (*domain.RoleUpgradeResponse, error)
+}
+
+type roleUpgradeUsecase struct {
+	upgradeRepo repository.RoleUpgradeRepository
+	userRepo    repository.UserRepository
+	mailer      email.Sender
+	withTx      TxFunc
+}
+
+func NewRoleUpgradeUsecase(upgradeRepo repository.RoleUpgradeRepository, userRepo repository.UserRepository, mailer email.Sender, withTx TxFunc) RoleUpgradeUsecase {
+	return &roleUpgradeUsecase{
+		upgradeRepo: upgradeRepo,
+		userRepo:    userRepo,
+		mailer:      mailer,
+		withTx:      withTx,
+	}
+}
+
+func (uc *roleUpgradeUsecase) RequestUpgrade(ctx context.Context, userID string, req *domain.CreateRoleUpgradeRequest) (*domain.RoleUpgradeResponse, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewNotFoundError("User not found")
+		}
+		return nil, err
+	}
+
+	if user.Role != domain.Applicant {
+		return nil, apperrors.NewBadRequestError("Only applicant accounts can request a company account", nil)
+	}
+
+	upgrade := &domain.RoleUpgrade{
+		UserID:      userID,
+		FromRole:    user.Role,
+		ToRole:      domain.Company,
+		CompanyName: req.CompanyName,
+		Reason:      req.Reason,
+		Status:      domain.RoleUpgradePending,
+	}
+	if err := uc.upgradeRepo.Create(ctx, upgrade); err != nil {
+		if errors.Is(err, domain.ErrRoleUpgradePending) {
+			return nil, apperrors.NewConflictError("You already have a role upgrade request waiting for review")
+		}
+		return nil, fmt.Errorf("error creating role upgrade request: %w", err)
+	}
+
+	return &domain.RoleUpgradeResponse{
+		Success: true,
+		Message: "Role upgrade requested, an administrator will review it",
+		Data:    upgrade,
+	}, nil
+}
+
+func (uc *roleUpgradeUsecase) GetMyUpgrade(ctx context.Context, userID string) (*domain.RoleUpgradeResponse, error) {
+	upgrade, err := uc.upgradeRepo.FindLatestByUser(ctx, userID)
+	if err != nil {
+		if isNotFound(err, domain.ErrRoleUpgradeNotFound) {
+			return nil, apperrors.NewNotFoundError("No role upgrade request found")
+		}
+		return nil, err
+	}
+
+	return &domain.RoleUpgradeResponse{
+		Success: true,
+		Message: "Successfully retrieved role upgrade request",
+		Data:    upgrade,
+	}, nil
+}
+
+func (uc *roleUpgradeUsecase) ListUpgrades(ctx context.Context, status domain.RoleUpgradeStatus, page, limit int) (*domain.UserListResponse, error) {
+	switch status {
+	case "", domain.RoleUpgradePending, domain.RoleUpgradeApproved, domain.RoleUpgradeRejected:
+	default:
+		return nil, apperrors.NewBadRequestError("Invalid status", []string{"status must be pending, approved or rejected"})
+	}
+
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	upgrades, total, err := uc.upgradeRepo.ListByStatus(ctx, status, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing role upgrade requests: %w", err)
+	}
+
+	// Calculate total pages
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return &domain.UserListResponse{
+		Success:    true,
+		Message:    "Successfully retrieved role upgrade requests",
+		Data:       upgrades,
+		PageNumber: page,
+		PageSize:   len(upgrades),
+		TotalItems: total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (uc *roleUpgradeUsecase) ApproveUpgrade(ctx context.Context, adminID, upgradeID string, req *domain.ReviewRoleUpgradeRequest) (*domain.RoleUpgradeResponse, error) {
+	upgrade, err := uc.getPending(ctx, upgradeID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, upgrade.UserID)
+	if err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewNotFoundError("User not found")
+		}
+		return nil, err
+	}
+	if user.IsDeleted() {
+		return nil, apperrors.NewConflictError("User account is deleted")
+	}
+	if user.Role != upgrade.FromRole {
+		return nil, apperrors.NewConflictError("User role changed since the request was made")
+	}
+
+	err = uc.withTx(ctx, func(ctx context.Context) error {
+		if err := uc.upgradeRepo.Review(ctx, upgradeID, domain.RoleUpgradeApproved, adminID, req.Note); err != nil {
+			return err
+		}
+		return uc.userRepo.SetRole(ctx, upgrade.UserID, upgrade.ToRole, upgrade.CompanyName)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrRoleUpgradeReviewed) {
+			return nil, apperrors.NewConflictError("Role upgrade request was already reviewed")
+		}
+		return nil, fmt.Errorf("error approving role upgrade: %w", err)
+	}
+
+	uc.notify(ctx, user, "Your company account is ready",
+		fmt.Sprintf("Hi %s,\n\nYour request to post jobs as %s was approved. Log in again to start posting jobs.\n", user.Name, upgrade.CompanyName))
+
+	return uc.reviewResponse(ctx, upgradeID, "Role upgrade approved")
+}
+
+func (uc *roleUpgradeUsecase) RejectUpgrade(ctx context.Context, adminID, upgradeID string, req *domain.ReviewRoleUpgradeRequest) (*domain.RoleUpgradeResponse, error) {
+	upgrade, err := uc.getPending(ctx, upgradeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.upgradeRepo.Review(ctx, upgradeID, domain.RoleUpgradeRejected, adminID, req.Note); err != nil {
+		if errors.Is(err, domain.ErrRoleUpgradeReviewed) {
+			return nil, apperrors.NewConflictError("Role upgrade request was already reviewed")
+		}
+		return nil, err
+	}
+
+	if user, err := uc.userRepo.FindByID(ctx, upgrade.UserID); err == nil {
+		body := fmt.Sprintf("Hi %s,\n\nYour request to post jobs as %s was not approved.\n", user.Name, upgrade.CompanyName)
+		if req.Note != "" {
+			body += "\nReviewer note: " + req.Note + "\n"
+		}
+		uc.notify(ctx, user, "Your company account request", body)
+	}
+
+	return uc.reviewResponse(ctx, upgradeID, "Role upgrade rejected")
+}
+
+// getPending loads a request and checks it is still waiting for review
+func (uc *roleUpgradeUsecase) getPending(ctx context.Context, upgradeID string) (*domain.RoleUpgrade, error) {
+	upgrade, err := uc.upgradeRepo.GetByID(ctx, upgradeID)
+	if err != nil {
+		if isNotFound(err, domain.ErrRoleUpgradeNotFound) {
+			return nil, apperrors.NewNotFoundError("Role upgrade request not found")
+		}
+		return nil, err
+	}
+
+	if upgrade.Status != domain.RoleUpgradePending {
+		return nil, apperrors.NewConflictError("Role upgrade request was already reviewed")
+	}
+	return upgrade, nil
+}
+
+func (uc *roleUpgradeUsecase) reviewResponse(ctx context.Context, upgradeID, message string) (*domain.RoleUpgradeResponse, error) {
+	upgrade, err := uc.upgradeRepo.GetByID(ctx, upgradeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.RoleUpgradeResponse{
+		Success: true,
+		Message: message,
+		Data:    upgrade,
+	}, nil
+}
+
+// notify emails the user about the review outcome. The decision is already
+// stored, so a failed email is only logged.
+func (uc *roleUpgradeUsecase) notify(ctx context.Context, user *domain.User, subject, body string) {
+	if err := uc.mailer.Send(ctx, email.Message{To: user.Email, Subject: subject, Body: body}); err != nil {
+		log.Printf("Failed to send role upgrade email to user %s: %v", user.ID.Hex(), err)
+	}
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// SavedJobUsecase manages the jobs applicants bookmark
+type SavedJobUsecase interface {
+	SaveJob(ctx context.Context, userID, jobID string) (*domain.SavedJobResponse, error)
+	UnsaveJob(ctx context.Context, userID, jobID string) (*domain.SavedJobResponse, error)
+	// ListSavedJobs returns the jobs the user saved, most recently saved first
+	ListSavedJobs(ctx context.Context, userID string, page, limit int) (*domain.SavedJobListResponse, error)
+	// MarkSaved sets IsSaved on the jobs the user saved
+	MarkSaved(ctx context.Context, userID string, jobs ...*domain.Job) error
+}
+
+type savedJobUsecase struct {
+	savedJobRepo repository.SavedJobRepository
+	jobRepo      repository.JobRepository
+}
+
+func NewSavedJobUsecase(savedJobRepo repository.SavedJobRepository, jobRepo repository.JobRepository) SavedJobUsecase {
+	return &savedJobUsecase{
+		savedJobRepo: savedJobRepo,
+		jobRepo:      jobRepo,
+	}
+}
+
+func (uc *savedJobUsecase) SaveJob(ctx context.Context, userID, jobID string) (*domain.SavedJobResponse, error) {
+	job, err := uc.jobRepo.GetJobByID(ctx, jobID)
+	if err != nil {
+		if isNotFound(err, domain.ErrJobNotFound) {
+			return nil, apperrors.NewNotFoundError("Job not found")
+		}
+		return nil, fmt.Errorf("error retrieving job: %w", err)
+	}
+	if !job.IsPublished {
+		return nil, apperrors.NewNotFoundError("Job not found")
+	}
+
+	saved, err := uc.savedJobRepo.Save(ctx, userID, job.ID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("error saving job: %w", err)
+	}
+
+	return &domain.SavedJobResponse{
+		Success: true,
+		Message: "Job saved successfully",
+		Data:    saved,
+	}, nil
+}
+
+func (uc *savedJobUsecase) UnsaveJob(ctx context.Context, userID, jobID string) (*domain.SavedJobResponse, error) {
+	if err := uc.savedJobRepo.Unsave(ctx, userID, jobID); err != nil {
+		if errors.Is(err, domain.ErrSavedJobNotFound) {
+			return nil, apperrors.NewNotFoundError("You haven't saved this job")
+		}
+		return nil, fmt.Errorf("error removing saved job: %w", err)
+	}
+
+	return &domain.SavedJobResponse{
+		Success: true,
+		Message: "Job removed from saved jobs",
+	}, nil
+}
+
+func (uc *savedJobUsecase) ListSavedJobs(ctx context.Context, userID string, page, limit int) (*domain.SavedJobListResponse, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	saved, total, err := uc.savedJobRepo.ListByUser(ctx, userID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing saved jobs: %w", err)
+	}
+
+	entries := make([]*domain.SavedJobEntry, 0, len(saved))
+	for _, s := range saved {
+		job, err := uc.jobRepo.GetJobByID(ctx, s.JobID)
+		if err != nil {
+			if isNotFound(err, domain.ErrJobNotFound) {
+				continue // Skip jobs that were deleted since
+			}
+			return nil, fmt.Errorf("error retrieving job: %w", err)
+		}
+		job.IsSaved = true
+		setComputedFields(job)
+		entries = append(entries, &domain.SavedJobEntry{
+			Job:     job,
+			SavedAt: s.CreatedAt,
+		})
+	}
+
+	// Calculate total pages
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return &domain.SavedJobListResponse{
+		Success:    true,
+		Message:    "Successfully retrieved saved jobs",
+		Data:       entries,
+		PageNumber: page,
+		PageSize:   len(entries),
+		TotalItems: total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (uc *savedJobUsecase) MarkSaved(ctx context.Context, userID string, jobs ...*domain.Job) error {
+	jobIDs := make([]string, len(jobs))
+	for i, job := range jobs {
+		jobIDs[i] = job.ID.Hex()
+	}
+
+	saved, err := uc.savedJobRepo.SavedJobIDs(ctx, userID, jobIDs)
+	if err != nil {
+		return fmt.Errorf("error retrieving saved jobs: %w", err)
+	}
+	for _, job := range jobs {
+		job.IsSaved = saved[job.ID.Hex()]
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/currency"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// savedSearchAlertBatch bounds the saved searches run per alert run
+const savedSearchAlertBatch = 100
+
+// SavedSearchUsecase manages applicants' saved job searches and their alerts
+type SavedSearchUsecase interface {
+	SaveSearch(ctx context.Context, userID string, req *domain.SaveSearchRequest) (*domain.SavedSearchResponse, error)
+	ListSearches(ctx context.Context, userID string) (*domain.SavedSearchResponse, error)
+	DeleteSearch(ctx context.Context, userID, id string) (*domain.SavedSearchResponse, error)
+	// SendAlerts runs the saved searches due for alerts against the jobs
+	// posted since their last run, and notifies their owners of matches
+	SendAlerts(ctx context.Context) error
+}
+
+type savedSearchUsecase struct {
+	searchRepo  repository.SavedSearchRepository
+	jobRepo     repository.JobRepository
+	prefsRepo   repository.AlertPreferencesRepository
+	notifier    NotificationUsecase
+	rates       currency.Provider
+	frontendURL string
+}
+
+func NewSavedSearchUsecase(searchRepo repository.SavedSearchRepository, jobRepo repository.JobRepository, prefsRepo repository.AlertPreferencesRepository, notifier NotificationUsecase, rates currency.Provider, frontendURL string) SavedSearchUsecase {
+	return &savedSearchUsecase{
+		searchRepo:  searchRepo,
+		jobRepo:     jobRepo,
+		prefsRepo:   prefsRepo,
+		notifier:    notifier,
+		rates:       rates,
+		frontendURL: frontendURL,
+	}
+}
+
+func (uc *savedSearchUsecase) SaveSearch(ctx context.Context, userID string, req *domain.SaveSearchRequest) (*domain.SavedSearchResponse, error) {
+	if req.SalaryMin != nil && req.SalaryMax != nil && *req.SalaryMax < *req.SalaryMin {
+		return nil, apperrors.NewBadRequestError("Validation failed", []string{"salary_max must be at least salary_min"})
+	}
+
+	count, err := uc.searchRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error counting saved searches: %w", err)
+	}
+	if count >= domain.MaxSavedSearches {
+		return nil, apperrors.NewConflictError(fmt.Sprintf("You can save up to %d searches, delete one first", domain.MaxSavedSearches))
+	}
+
+	search := &domain.SavedSearch{
+		UserID:          userID,
+		Name:            strings.TrimSpace(req.Name),
+		Query:           strings.TrimSpace(req.Query),
+		Location:        strings.TrimSpace(req.Location),
+		Category:        req.Category,
+		EmploymentType:  req.EmploymentType,
+		ExperienceLevel: req.ExperienceLevel,
+		Remote:          req.Remote,
+		Skills:          normalizeList(req.Skills, false),
+		SalaryMin:       req.SalaryMin,
+		SalaryMax:       req.SalaryMax,
+		Currency:        strings.ToUpper(req.Currency),
+		Alerts:          req.Alerts == nil || *req.Alerts,
+	}
+	if len(search.Skills) == 0 {
+		search.Skills = nil
+	}
+
+	if err := uc.searchRepo.Create(ctx, search); err != nil {
+		return nil, fmt.Errorf("error saving search: %w", err)
+	}
+
+	return &domain.SavedSearchResponse{
+		Success: true,
+		Message: "Search saved successfully",
+		Data:    search,
+	}, nil
+}
+
+func (uc *savedSearchUsecase) ListSearches(ctx context.Context, userID string) (*domain.SavedSearchResponse, error) {
+	searches, err := uc.searchRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing saved searches: %w", err)
+	}
+
+	return &domain.SavedSearchResponse{
+		Success: true,
+		Message: "Successfully retrieved saved searches",
+		Data:    searches,
+	}, nil
+}
+
+func (uc *savedSearchUsecase) DeleteSearch(ctx context.Context, userID, id string) (*domain.SavedSearchResponse, error) {
+	if err := uc.searchRepo.Delete(ctx, userID, id); err != nil {
+		if errors.Is(err, domain.ErrSavedSearchNotFound) {
+			return nil, apperrors.NewNotFoundError("Saved search not found")
+		}
+		return nil, fmt.Errorf("error deleting saved search: %w", err)
+	}
+
+	return &domain.SavedSearchResponse{
+		Success: true,
+		Message: "Saved search deleted successfully",
+	}, nil
+}
+
+func (uc *savedSearchUsecase) SendAlerts(ctx context.Context) error {
+	now := time.Now()
+	searches, err := uc.searchRepo.ListDueForAlerts(ctx, now.Add(-domain.SavedSearchAlertInterval), savedSearchAlertBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, search := range searches {
+		prefs, err := uc.prefsRepo.GetByUserID(ctx, search.UserID)
+		if err != nil {
+			return err
+		}
+
+		filter := search.Filter()
+		if filter.SalaryMin != nil || filter.SalaryMax != nil {
+			filter.SalaryRates = uc.salaryRates(ctx, search.Currency)
+		}
+
+		jobs, total, err := uc.jobRepo.ListPostedSince(ctx, filter, prefs.Exclusions(), search.LastCheckedAt, domain.SavedSearchAlertJobs)
+		if err != nil {
+			return err
+		}
+
+		if total > 0 {
+			// One undeliverable alert doesn't hold up the others
+			if err := uc.notifier.Notify(ctx, search.UserID, domain.NotificationSavedSearch,
+				uc.alertSubject(search, total), uc.alertBody(search, jobs, total)); err != nil {
+				log.Printf("Failed to send alert for saved search %s: %v", search.ID.Hex(), err)
+			}
+		}
+
+		if err := uc.searchRepo.MarkChecked(ctx, search.ID, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// salaryRates converts salary bounds in code into every currency with a
+// rate. Without rates the bounds only compare with salaries in code.
+func (uc *savedSearchUsecase) salaryRates(ctx context.Context, code string) map[string]float64 {
+	rates, err := uc.rates.Rates(ctx)
+	if err != nil {
+		if err != currency.ErrUnavailable {
+			log.Printf("Failed to retrieve exchange rates: %v", err)
+		}
+		return map[string]float64{code: 1}
+	}
+	if _, ok := rates.Values[code]; !ok {
+		return map[string]float64{code: 1}
+	}
+	return salaryFactors(rates, code)
+}
+
+func (uc *savedSearchUsecase) alertSubject(search *domain.SavedSearch, total int64) string {
+	if total == 1 {
+		return fmt.Sprintf("A new job matches \"%s\"", search.Name)
+	}
+	return fmt.Sprintf("%d new jobs match \"%s\"", total, search.Name)
+}
+
+func (uc *savedSearchUsecase) alertBody(search *domain.SavedSearch, jobs []*domain.Job, total int64) string {
+	var body strings.Builder
+	fmt.Fprintf(&body, "New jobs match your saved search \"%s\":\n\n", search.Name)
+	for _, job := range jobs {
+		fmt.Fprintf(&body, "- %s: %s/jobs/%s\n", job.Title, uc.frontendURL, job.ID.Hex())
+	}
+	if more := total - int64(len(jobs)); more > 0 {
+		fmt.Fprintf(&body, "\nand %d more.\n", more)
+	}
+	fmt.Fprintf(&body, "\nManage your saved searches here: %s/saved-searches", uc.frontendURL)
+	return body.String()
+}
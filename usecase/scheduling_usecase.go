@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/pkg/holidays"
+	"job-portal-backend/repository"
+)
+
+// SchedulingUsecase manages companies' working time and checks interview slots against it
+type SchedulingUsecase interface {
+	GetRules(ctx context.Context, userID string) (*domain.SchedulingRulesResponse, error)
+	// SaveRules replaces the rules of the user's company, for its owner and admins
+	SaveRules(ctx context.Context, userID string, req *domain.SaveSchedulingRulesRequest) (*domain.SchedulingRulesResponse, error)
+	// CheckSlot checks a proposed interview time against the rules of the user's company
+	CheckSlot(ctx context.Context, userID string, at time.Time) (*domain.SchedulingRulesResponse, error)
+	// SlotWarnings lists why an interview at the given time doesn't suit the company
+	SlotWarnings(ctx context.Context, companyID string, at time.Time) ([]domain.SchedulingWarning, error)
+}
+
+type schedulingUsecase struct {
+	rulesRepo  repository.SchedulingRulesRepository
+	memberRepo repository.CompanyMemberRepository
+	calendar   holidays.Calendar
+	// defaultCountries are the holiday countries of companies without rules
+	defaultCountries []string
+}
+
+func NewSchedulingUsecase(rulesRepo repository.SchedulingRulesRepository, memberRepo repository.CompanyMemberRepository, calendar holidays.Calendar, defaultCountries []string) SchedulingUsecase {
+	return &schedulingUsecase{
+		rulesRepo:        rulesRepo,
+		memberRepo:       memberRepo,
+		calendar:         calendar,
+		defaultCountries: defaultCountries,
+	}
+}
+
+func (uc *schedulingUsecase) GetRules(ctx context.Context, userID string) (*domain.SchedulingRulesResponse, error) {
+	companyID, err := actingCompany(ctx, uc.memberRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := uc.rules(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SchedulingRulesResponse{
+		Success: true,
+		Message: "Successfully retrieved scheduling rules",
+		Data:    rules,
+	}, nil
+}
+
+func (uc *schedulingUsecase) SaveRules(ctx context.Context, userID string, req *domain.SaveSchedulingRulesRequest) (*domain.SchedulingRulesResponse, error) {
+	companyID, role, err := teamRole(ctx, uc.memberRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !role.ManagesTeam() {
+		return nil, errForbidden("Only the company owner and admins can change the scheduling rules")
+	}
+	// HH:MM times compare as strings
+	if req.WorkdayEnd <= req.WorkdayStart {
+		return nil, apperrors.NewBadRequestError("Validation failed", []string{"The workday must end after it starts"})
+	}
+
+	rules := &domain.SchedulingRules{
+		CompanyID:        companyID,
+		TimeZone:         req.TimeZone,
+		WorkingDays:      req.WorkingDays,
+		WorkdayStart:     req.WorkdayStart,
+		WorkdayEnd:       req.WorkdayEnd,
+		HolidayCountries: req.HolidayCountries,
+	}
+	if rules.HolidayCountries == nil {
+		rules.HolidayCountries = []string{}
+	}
+
+	if err := uc.rulesRepo.Upsert(ctx, rules); err != nil {
+		return nil, fmt.Errorf("error saving scheduling rules: %w", err)
+	}
+
+	return &domain.SchedulingRulesResponse{
+		Success: true,
+		Message: "Scheduling rules saved successfully",
+		Data:    rules,
+	}, nil
+}
+
+func (uc *schedulingUsecase) CheckSlot(ctx context.Context, userID string, at time.Time) (*domain.SchedulingRulesResponse, error) {
+	companyID, err := actingCompany(ctx, uc.memberRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings, err := uc.SlotWarnings(ctx, companyID, at)
+	if err != nil {
+		return nil, err
+	}
+	if warnings == nil {
+		warnings = []domain.SchedulingWarning{}
+	}
+
+	return &domain.SchedulingRulesResponse{
+		Success: true,
+		Message: "Successfully checked interview time",
+		Data: &domain.SchedulingCheck{
+			ScheduledAt: at,
+			Warnings:    warnings,
+		},
+	}, nil
+}
+
+func (uc *schedulingUsecase) SlotWarnings(ctx context.Context, companyID string, at time.Time) ([]domain.SchedulingWarning, error) {
+	rules, err := uc.rules(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := rules.WorkingTimeWarnings(at)
+
+	local := at.In(rules.Location())
+	day := local.Format("2006-01-02")
+	for _, country := range rules.HolidayCountries {
+		// The check is advisory, an unavailable calendar only skips it
+		days, err := uc.calendar.Holidays(ctx, country, local.Year())
+		if err != nil {
+			log.Printf("Failed to get the public holidays of %s in %d: %v", country, local.Year(), err)
+			continue
+		}
+		for _, holiday := range days {
+			if holiday.Date == day {
+				warnings = append(warnings, domain.SchedulingWarning{
+					Code:    domain.WarningPublicHoliday,
+					Message: fmt.Sprintf("%s is %s, a public holiday in %s", day, holiday.Name, country),
+				})
+				break
+			}
+		}
+	}
+	return warnings, nil
+}
+
+// rules returns the company's scheduling rules, or the defaults when it saved none
+func (uc *schedulingUsecase) rules(ctx context.Context, companyID string) (*domain.SchedulingRules, error) {
+	rules, err := uc.rulesRepo.GetByCompanyID(ctx, companyID)
+	if err != nil {
+		if isNotFound(err, domain.ErrSchedulingRulesNotFound) {
+			return domain.DefaultSchedulingRules(companyID, uc.defaultCountries), nil
+		}
+		return nil, fmt.Errorf("error getting scheduling rules: %w", err)
+	}
+	return rules, nil
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/email"
+	"job-portal-backend/pkg/webhook"
+	"job-portal-backend/repository"
+)
+
+// securityDashboardTop bounds the number of IPs and accounts listed on the dashboard
+const securityDashboardTop = 10
+
+// maxDashboardHours is the longest period the dashboard covers
+const maxDashboardHours = 30 * 24
+
+type SecurityUsecase interface {
+	// GetDashboard summarizes the authentication activity of the last hours
+	GetDashboard(ctx context.Context, hours int) (*domain.SecurityDashboardResponse, error)
+	// DetectAnomalies looks for brute force patterns in recent failed logins and raises alerts
+	DetectAnomalies(ctx context.Context) error
+}
+
+type securityUsecase struct {
+	eventRepo  repository.AuthEventRepository
+	alertRepo  repository.SecurityAlertRepository
+	mailer     email.Sender
+	webhooks   webhook.Sender
+	alertEmail string
+	webhookURL string
+}
+
+// NewSecurityUsecase creates the security usecase. Alerts are emailed to
+// alertEmail and posted to webhookURL; either may be empty to disable it.
+func NewSecurityUsecase(eventRepo repository.AuthEventRepository, alertRepo repository.SecurityAlertRepository, mailer email.Sender, webhooks webhook.Sender, alertEmail, webhookURL string) SecurityUsecase {
+	return &securityUsecase{
+		eventRepo:  eventRepo,
+		alertRepo:  alertRepo,
+		mailer:     mailer,
+		webhooks:   webhooks,
+		alertEmail: alertEmail,
+		webhookURL: webhookURL,
+	}
+}
+
+func (uc *securityUsecase) GetDashboard(ctx context.Context, hours int) (*domain.SecurityDashboardResponse, error) {
+	if hours < 1 || hours > maxDashboardHours {
+		hours = 24
+	}
+	since := time.Now().Add(-time.Duration(hours) * time.Hour)
+
+	counts, err := uc.eventRepo.CountByType(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("error counting auth events: %w", err)
+	}
+	reasons, err := uc.eventRepo.CountFailureReasons(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("error counting failure reasons: %w", err)
+	}
+	ips, err := uc.eventRepo.FailuresByIP(ctx, since, 1, securityDashboardTop)
+	if err != nil {
+		return nil, fmt.Errorf("error aggregating failures by IP: %w", err)
+	}
+	accounts, err := uc.eventRepo.FailuresByAccount(ctx, since, 1, securityDashboardTop)
+	if err != nil {
+		return nil, fmt.Errorf("error aggregating failures by account: %w", err)
+	}
+	alerts, err := uc.alertRepo.ListSince(ctx, since, 50)
+	if err != nil {
+		return nil, fmt.Errorf("error listing security alerts: %w", err)
+	}
+
+	return &domain.SecurityDashboardResponse{
+		Success: true,
+		Message: "Successfully retrieved security dashboard",
+		Data: &domain.SecurityDashboard{
+			Since:          since,
+			EventCounts:    counts,
+			FailureReasons: reasons,
+			TopIPs:         ips,
+			TopAccounts:    accounts,
+			Alerts:         alerts,
+		},
+	}, nil
+}
+
+func (uc *securityUsecase) DetectAnomalies(ctx context.Context) error {
+	windowStart := time.Now().Add(-domain.SecurityDetectionWindow)
+
+	// The lowest per IP threshold, the others are checked on the aggregated counts
+	ips, err := uc.eventRepo.FailuresByIP(ctx, windowStart, domain.CredentialStuffingAccounts, 100)
+	if err != nil {
+		return err
+	}
+	for _, ip := range ips {
+		alert := &domain.SecurityAlert{Subject: ip.IP, Failures: ip.Failures, Accounts: ip.Accounts, WindowStart: windowStart}
+		switch {
+		case ip.Accounts >= domain.CredentialStuffingAccounts:
+			alert.Kind = domain.AlertCredentialStuffing
+		case ip.Failures >= domain.FailureBurstThreshold:
+			alert.Kind = domain.AlertFailureBurst
+		default:
+			continue
+		}
+		if err := uc.raise(ctx, alert); err != nil {
+			return err
+		}
+	}
+
+	accounts, err := uc.eventRepo.FailuresByAccount(ctx, windowStart, domain.AccountTargetedThreshold, 100)
+	if err != nil {
+		return err
+	}
+	for _, account := range accounts {
+		alert := &domain.SecurityAlert{
+			Kind:        domain.AlertAccountTargeted,
+			Subject:     account.Account(),
+			Failures:    account.Failures,
+			IPs:         account.IPs,
+			WindowStart: windowStart,
+		}
+		if err := uc.raise(ctx, alert); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// raise stores an alert and notifies the security contacts, unless the same
+// anomaly was already reported during the detection window
+func (uc *securityUsecase) raise(ctx context.Context, alert *domain.SecurityAlert) error {
+	reported, err := uc.alertRepo.ExistsSince(ctx, alert.Kind, alert.Subject, alert.WindowStart)
+	if err != nil {
+		return err
+	}
+	if reported {
+		return nil
+	}
+
+	if err := uc.alertRepo.Create(ctx, alert); err != nil {
+		return err
+	}
+	log.Printf("Security alert %s on %s: %d failed logins", alert.Kind, alert.Subject, alert.Failures)
+
+	// Notifications are best effort, the alert is on the dashboard either way
+	if uc.alertEmail != "" {
+		err := uc.mailer.Send(ctx, email.Message{
+			To:      uc.alertEmail,
+			Subject: fmt.Sprintf("Security alert: %s on %s", alert.Kind, alert.Subject),
+			Body: fmt.Sprintf("%d failed logins on %s since %s (%d accounts, %d IPs).\n\nSee the admin security dashboard for details.\n",
+				alert.Failures, alert.Subject, alert.WindowStart.Format(time.RFC3339), alert.Accounts, alert.IPs),
+		})
+		if err != nil {
+			log.Printf("Failed to email security alert: %v", err)
+		}
+	}
+	if uc.webhookURL != "" {
+		payload := map[string]interface{}{"event": "security.alert", "alert": alert}
+		if err := uc.webhooks.Send(ctx, uc.webhookURL, payload); err != nil {
+			log.Printf("Failed to post security alert webhook: %v", err)
+		}
+	}
+
+	return nil
+}
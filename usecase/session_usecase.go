@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/repository"
+	"job-portal-backend/utils"
+)
+
+// SessionUsecase issues the access tokens of sign ins, tracking each one as a
+// session of the device it was issued to and enforcing the concurrent session limit
+type SessionUsecase interface {
+	// StartSession issues an access token for the user. When replacedTokenID is
+	// set the session replaces that token (token refresh) and the limit is not checked.
+	StartSession(ctx context.Context, user *domain.User, replacedTokenID string) (string, error)
+}
+
+type sessionUsecase struct {
+	sessionRepo repository.SessionRepository
+	revokedRepo repository.RevokedTokenRepository
+	tokens      *utils.TokenService
+	// maxSessions is the number of concurrent sessions per user, 0 for no limit
+	maxSessions int
+	mode        domain.SessionLimitMode
+}
+
+func NewSessionUsecase(sessionRepo repository.SessionRepository, revokedRepo repository.RevokedTokenRepository, tokens *utils.TokenService, maxSessions int, mode domain.SessionLimitMode) SessionUsecase {
+	return &sessionUsecase{
+		sessionRepo: sessionRepo,
+		revokedRepo: revokedRepo,
+		tokens:      tokens,
+		maxSessions: maxSessions,
+		mode:        mode,
+	}
+}
+
+func (uc *sessionUsecase) StartSession(ctx context.Context, user *domain.User, replacedTokenID string) (string, error) {
+	userID := user.ID.Hex()
+	if replacedTokenID == "" && uc.maxSessions > 0 {
+		if err := uc.enforceLimit(ctx, userID); err != nil {
+			return "", err
+		}
+	}
+
+	token, claims, err := uc.tokens.IssueAccessToken(userID, string(user.Role), domain.ScopesForRole(user.Role))
+	if err != nil {
+		return "", err
+	}
+
+	client := domain.ClientInfoFromContext(ctx)
+	if err := uc.sessionRepo.Create(ctx, &domain.Session{
+		UserID:    userID,
+		TokenID:   claims.ID,
+		IP:        client.IP,
+		UserAgent: client.UserAgent,
+		Country:   client.Country,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}); err != nil {
+		return "", fmt.Errorf("error recording session: %w", err)
+	}
+
+	return token, nil
+}
+
+// enforceLimit makes room for one more session of the user, either by signing
+// out the oldest sessions or by refusing the sign in. Revoked tokens are
+// rejected by the auth middleware, so revoking is enough to end a session.
+func (uc *sessionUsecase) enforceLimit(ctx context.Context, userID string) error {
+	active, err := uc.activeSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(active) < uc.maxSessions {
+		return nil
+	}
+
+	if uc.mode == domain.SessionLimitReject {
+		return errForbidden(fmt.Sprintf("You are already signed in on %d devices, sign out on one of them first", len(active)))
+	}
+
+	for _, session := range active[:len(active)-uc.maxSessions+1] {
+		if err := uc.revokedRepo.RevokeToken(ctx, session.TokenID, userID, session.ExpiresAt); err != nil {
+			return fmt.Errorf("error revoking oldest session: %w", err)
+		}
+	}
+	return nil
+}
+
+// activeSessions returns the user's sessions that are neither expired nor
+// signed out (logout, password reset, ...), oldest first
+func (uc *sessionUsecase) activeSessions(ctx context.Context, userID string) ([]*domain.Session, error) {
+	sessions, err := uc.sessionRepo.ListUnexpired(ctx, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error listing sessions: %w", err)
+	}
+
+	active := make([]*domain.Session, 0, len(sessions))
+	for _, session := range sessions {
+		revoked, err := uc.revokedRepo.IsRevoked(ctx, session.TokenID, userID, session.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error checking session: %w", err)
+		}
+		if !revoked {
+			active = append(active, session)
+		}
+	}
+	return active, nil
+}
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/email"
+	"job-portal-backend/repository"
+)
+
+// maxDashboardAttention bounds the breached and at risk applications listed on the dashboard
+const maxDashboardAttention = 50
+
+// SLAUsecase manages companies' application response time targets
+type SLAUsecase interface {
+	GetPolicy(ctx context.Context, companyID string) (*domain.SLAResponse, error)
+	// SavePolicy replaces the company's targets. An empty list turns the SLA off.
+	SavePolicy(ctx context.Context, companyID string, req *domain.SaveSLAPolicyRequest) (*domain.SLAResponse, error)
+	// GetDashboard sums up the company's open applications against its targets
+	GetDashboard(ctx context.Context, companyID string) (*domain.SLAResponse, error)
+	// SendSLAWarnings emails job owners about applications that are about to breach
+	SendSLAWarnings(ctx context.Context) error
+}
+
+type slaUsecase struct {
+	policyRepo  repository.SLAPolicyRepository
+	appRepo     repository.ApplicationRepository
+	userRepo    repository.UserRepository
+	mailer      email.Sender
+	frontendURL string
+}
+
+func NewSLAUsecase(policyRepo repository.SLAPolicyRepository, appRepo repository.ApplicationRepository, userRepo repository.UserRepository, mailer email.Sender, frontendURL string) SLAUsecase {
+	return &slaUsecase{
+		policyRepo:  policyRepo,
+		appRepo:     appRepo,
+		userRepo:    userRepo,
+		mailer:      mailer,
+		frontendURL: frontendURL,
+	}
+}
+
+func (uc *slaUsecase) GetPolicy(ctx context.Context, companyID string) (*domain.SLAResponse, error) {
+	policy, err := uc.policy(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SLAResponse{
+		Success: true,
+		Message: "Successfully retrieved SLA targets",
+		Data:    policy,
+	}, nil
+}
+
+func (uc *slaUsecase) SavePolicy(ctx context.Context, companyID string, req *domain.SaveSLAPolicyRequest) (*domain.SLAResponse, error) {
+	policy := &domain.SLAPolicy{
+		CompanyID:         companyID,
+		Targets:           req.Targets,
+		NotifyBeforeHours: req.NotifyBeforeHours,
+	}
+	if policy.Targets == nil {
+		policy.Targets = []domain.SLATarget{}
+	}
+
+	if err := uc.policyRepo.Upsert(ctx, policy); err != nil {
+		return nil, fmt.Errorf("error saving sla policy: %w", err)
+	}
+
+	return &domain.SLAResponse{
+		Success: true,
+		Message: "SLA targets saved successfully",
+		Data:    policy,
+	}, nil
+}
+
+func (uc *slaUsecase) GetDashboard(ctx context.Context, companyID string) (*domain.SLAResponse, error) {
+	policy, err := uc.policy(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	open := []domain.ApplicationStatus{domain.StatusApplied, domain.StatusReviewed, domain.StatusInterview}
+	applications, err := uc.appRepo.ListOpenForCompany(ctx, companyID, open)
+	if err != nil {
+		return nil, fmt.Errorf("error listing open applications: %w", err)
+	}
+
+	now := time.Now()
+	dashboard := &domain.CompanyDashboard{
+		OpenApplications: make(map[domain.ApplicationStatus]int64, len(open)),
+		Attention:        []*domain.SLAApplication{},
+	}
+	for _, status := range open {
+		dashboard.OpenApplications[status] = 0
+	}
+	for _, app := range applications {
+		dashboard.OpenApplications[app.Status]++
+
+		timer := policy.Timer(&app.Application, now)
+		if timer == nil || !(timer.Breached || timer.AtRisk) {
+			continue
+		}
+		if timer.Breached {
+			dashboard.Breached++
+		} else {
+			dashboard.AtRisk++
+		}
+		dashboard.Attention = append(dashboard.Attention, &domain.SLAApplication{
+			ApplicationID: app.ID.Hex(),
+			JobID:         app.JobID.Hex(),
+			JobTitle:      app.JobTitle,
+			Timer:         timer,
+		})
+	}
+
+	sort.Slice(dashboard.Attention, func(i, j int) bool {
+		return dashboard.Attention[i].Timer.DueAt.Before(dashboard.Attention[j].Timer.DueAt)
+	})
+	if len(dashboard.Attention) > maxDashboardAttention {
+		dashboard.Attention = dashboard.Attention[:maxDashboardAttention]
+	}
+
+	return &domain.SLAResponse{
+		Success: true,
+		Message: "Successfully retrieved dashboard",
+		Data:    dashboard,
+	}, nil
+}
+
+func (uc *slaUsecase) SendSLAWarnings(ctx context.Context) error {
+	policies, err := uc.policyRepo.ListNotifying(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, policy := range policies {
+		if len(policy.Targets) == 0 {
+			continue
+		}
+
+		applications, err := uc.appRepo.ListOpenForCompany(ctx, policy.CompanyID, policy.Stages())
+		if err != nil {
+			return err
+		}
+
+		// Applications already warned about in their current stage are skipped
+		var lines []string
+		warned := map[domain.ApplicationStatus][]primitive.ObjectID{}
+		for _, app := range applications {
+			timer := policy.Timer(&app.Application, now)
+			if timer == nil || !timer.AtRisk || app.SLAWarnedStage == app.Status {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("- %s, %s since %s, due %s: %s/applications/%s",
+				app.JobTitle, app.Status, timer.Since.Format("January 2"), timer.DueAt.Format("January 2, 15:04 MST"),
+				uc.frontendURL, app.ID.Hex()))
+			warned[app.Status] = append(warned[app.Status], app.ID)
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		company, err := uc.userRepo.FindByID(ctx, policy.CompanyID)
+		if err != nil {
+			log.Printf("Skipping SLA warning for company %s: %v", policy.CompanyID, err)
+			continue
+		}
+
+		err = uc.mailer.Send(ctx, email.Message{
+			To:      company.Email,
+			Subject: fmt.Sprintf("%d applications are about to miss your response time targets", len(lines)),
+			Body: fmt.Sprintf("Hi %s,\n\nThese applications will breach your response time targets soon:\n\n%s\n",
+				company.Name, strings.Join(lines, "\n")),
+		})
+		if err != nil {
+			return fmt.Errorf("error sending sla warning: %w", err)
+		}
+
+		for stage, ids := range warned {
+			if err := uc.appRepo.MarkSLAWarned(ctx, ids, stage); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// policy returns the company's policy, an empty one when none was saved
+func (uc *slaUsecase) policy(ctx context.Context, companyID string) (*domain.SLAPolicy, error) {
+	policy, err := uc.policyRepo.GetByCompanyID(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSLAPolicyNotFound) {
+			return &domain.SLAPolicy{CompanyID: companyID, Targets: []domain.SLATarget{}}, nil
+		}
+		return nil, fmt.Errorf("error retrieving sla policy: %w", err)
+	}
+	return policy, nil
+}
@@ -0,0 +1,406 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/pkg/oauth"
+	"job-portal-backend/repository"
+	"job-portal-backend/utils"
+)
+
+// SSOUsecase handles company recruiters signing in through their corporate
+// OpenID Connect identity provider
+type SSOUsecase interface {
+	GetConfig(ctx context.Context, companyID string) (*domain.SSOResponse, error)
+	// SaveConfig creates or replaces the company's IdP configuration. The
+	// issuer's discovery document is fetched to catch typos before saving.
+	SaveConfig(ctx context.Context, companyID string, req *domain.SaveSSOConfigRequest) (*domain.SSOResponse, error)
+	DeleteConfig(ctx context.Context, companyID string) (*domain.SSOResponse, error)
+	// VerifyDomains checks the DNS TXT records of the allowed domains that
+	// are not verified yet. Only verified domains can sign in.
+	VerifyDomains(ctx context.Context, companyID string) (*domain.SSOResponse, error)
+	// LoginURL returns the company's IdP sign in page
+	LoginURL(ctx context.Context, companyID, state string) (string, error)
+	// Callback completes the sign in. Recruiters signing in for the first time
+	// get an account and a membership of the company (just-in-time provisioning).
+	Callback(ctx context.Context, req *domain.SSOCallbackRequest) (*domain.AuthResponse, error)
+}
+
+// TXTResolver looks up DNS TXT records, net.DefaultResolver implements it
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+type ssoUsecase struct {
+	configRepo repository.SSOConfigRepository
+	memberRepo repository.CompanyMemberRepository
+	userRepo   repository.UserRepository
+	eventRepo  repository.AuthEventRepository
+	events     EventBus
+	sessions   SessionUsecase
+	tokens     *utils.TokenService
+	resolver   TXTResolver
+	// apiBaseURL is used to build the callback URL registered with the IdP
+	apiBaseURL string
+}
+
+func NewSSOUsecase(configRepo repository.SSOConfigRepository, memberRepo repository.CompanyMemberRepository, userRepo repository.UserRepository, eventRepo repository.AuthEventRepository, events EventBus, sessions SessionUsecase, tokens *utils.TokenService, resolver TXTResolver, apiBaseURL string) SSOUsecase {
+	return &ssoUsecase{
+		configRepo: configRepo,
+		memberRepo: memberRepo,
+		userRepo:   userRepo,
+		eventRepo:  eventRepo,
+		events:     events,
+		sessions:   sessions,
+		tokens:     tokens,
+		resolver:   resolver,
+		apiBaseURL: apiBaseURL,
+	}
+}
+
+func (uc *ssoUsecase) GetConfig(ctx context.Context, companyID string) (*domain.SSOResponse, error) {
+	config, err := uc.configRepo.GetByCompany(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSSOConfigNotFound) {
+			return nil, apperrors.NewNotFoundError("SSO is not configured")
+		}
+		return nil, err
+	}
+
+	return &domain.SSOResponse{
+		Success: true,
+		Message: "SSO configuration retrieved successfully",
+		Data:    uc.configView(config),
+	}, nil
+}
+
+func (uc *ssoUsecase) SaveConfig(ctx context.Context, companyID string, req *domain.SaveSSOConfigRequest) (*domain.SSOResponse, error) {
+	if err := uc.requireCompanyAccount(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.configRepo.GetByCompany(ctx, companyID)
+	if err != nil && !errors.Is(err, domain.ErrSSOConfigNotFound) {
+		return nil, err
+	}
+
+	secret := req.ClientSecret
+	if secret == "" {
+		if existing == nil {
+			return nil, apperrors.NewBadRequestError("Client secret is required", nil)
+		}
+		secret = existing.ClientSecret
+	}
+
+	domains := make([]string, 0, len(req.AllowedDomains))
+	for _, d := range req.AllowedDomains {
+		domains = append(domains, strings.ToLower(d))
+	}
+
+	config := &domain.SSOConfig{
+		CompanyID:       companyID,
+		Issuer:          strings.TrimRight(req.Issuer, "/"),
+		ClientID:        req.ClientID,
+		ClientSecret:    secret,
+		AllowedDomains:  domains,
+		VerifiedDomains: []string{},
+		Enabled:         req.Enabled,
+	}
+	// Domains stay verified as long as they are allowed
+	if existing != nil {
+		config.VerificationToken = existing.VerificationToken
+		for _, d := range existing.VerifiedDomains {
+			if hasString(domains, d) {
+				config.VerifiedDomains = append(config.VerifiedDomains, d)
+			}
+		}
+	}
+	if config.VerificationToken == "" {
+		if config.VerificationToken, err = utils.GenerateSecureToken(); err != nil {
+			return nil, err
+		}
+	}
+
+	// The provider's error is logged, not returned: it would tell what the
+	// server can reach
+	if _, err := uc.provider(ctx, config); err != nil {
+		log.Printf("Failed to read the identity provider of company %s: %v", companyID, err)
+		return nil, apperrors.NewBadRequestError("Failed to read the identity provider configuration, check the issuer URL is public and serves an OpenID Connect discovery document", nil)
+	}
+
+	if err := uc.configRepo.Upsert(ctx, config); err != nil {
+		return nil, fmt.Errorf("error saving sso configuration: %w", err)
+	}
+
+	return &domain.SSOResponse{
+		Success: true,
+		Message: "SSO configuration saved successfully",
+		Data:    uc.configView(config),
+	}, nil
+}
+
+func (uc *ssoUsecase) DeleteConfig(ctx context.Context, companyID string) (*domain.SSOResponse, error) {
+	if err := uc.requireCompanyAccount(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.configRepo.DeleteByCompany(ctx, companyID); err != nil {
+		if errors.Is(err, domain.ErrSSOConfigNotFound) {
+			return nil, apperrors.NewNotFoundError("SSO is not configured")
+		}
+		return nil, err
+	}
+
+	return &domain.SSOResponse{
+		Success: true,
+		Message: "SSO configuration deleted successfully",
+	}, nil
+}
+
+func (uc *ssoUsecase) VerifyDomains(ctx context.Context, companyID string) (*domain.SSOResponse, error) {
+	if err := uc.requireCompanyAccount(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	config, err := uc.configRepo.GetByCompany(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSSOConfigNotFound) {
+			return nil, apperrors.NewNotFoundError("SSO is not configured")
+		}
+		return nil, err
+	}
+	if config.VerificationToken == "" {
+		return nil, apperrors.NewBadRequestError("Save the SSO configuration again to get a verification record", nil)
+	}
+
+	verified := append([]string{}, config.VerifiedDomains...)
+	for _, d := range config.UnverifiedDomains() {
+		records, err := uc.resolver.LookupTXT(ctx, d)
+		if err != nil {
+			// Not published yet, the domain stays unverified
+			continue
+		}
+		for _, record := range records {
+			if strings.TrimSpace(record) == config.DomainVerificationRecord() {
+				verified = append(verified, d)
+				break
+			}
+		}
+	}
+
+	if err := uc.configRepo.SetVerifiedDomains(ctx, companyID, verified); err != nil {
+		return nil, fmt.Errorf("error saving verified domains: %w", err)
+	}
+	config.VerifiedDomains = verified
+
+	view := uc.configView(config)
+	message := "All domains are verified"
+	if len(view.UnverifiedDomains) > 0 {
+		message = fmt.Sprintf("%d of %d domains are verified, publish the verification record on the others", len(verified), len(config.AllowedDomains))
+	}
+
+	return &domain.SSOResponse{
+		Success: true,
+		Message: message,
+		Data:    view,
+	}, nil
+}
+
+func (uc *ssoUsecase) LoginURL(ctx context.Context, companyID, state string) (string, error) {
+	config, err := uc.enabledConfig(ctx, companyID)
+	if err != nil {
+		return "", err
+	}
+
+	p, err := uc.provider(ctx, config)
+	if err != nil {
+		return "", apperrors.NewBadRequestError("The company's identity provider is unavailable", nil)
+	}
+
+	return p.AuthCodeURL(state), nil
+}
+
+func (uc *ssoUsecase) Callback(ctx context.Context, req *domain.SSOCallbackRequest) (*domain.AuthResponse, error) {
+	config, err := uc.enabledConfig(ctx, req.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := uc.provider(ctx, config)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("The company's identity provider is unavailable", nil)
+	}
+
+	profile, err := p.Exchange(ctx, req.Code)
+	if err != nil {
+		log.Printf("Failed SSO code exchange for company %s: %v", config.CompanyID, err)
+		return nil, apperrors.NewUnauthorizedError("SSO login failed")
+	}
+
+	if profile.Email == "" || !profile.EmailVerified {
+		return nil, apperrors.NewUnauthorizedError("Your identity provider returned no verified email address")
+	}
+	if !config.AllowsEmail(profile.Email) {
+		recordAuthEvent(ctx, uc.eventRepo, &domain.AuthEvent{Email: profile.Email, Type: domain.AuthEventLoginFailed, Method: "sso", Reason: "domain_not_allowed"})
+		return nil, errForbidden("Your email domain is not allowed to sign in to this company")
+	}
+
+	user, err := uc.provision(ctx, config, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsSuspended() {
+		recordAuthEvent(ctx, uc.eventRepo, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventLoginFailed, Method: "sso", Reason: "account_suspended"})
+		return nil, errAccountSuspended()
+	}
+
+	return signIn(ctx, uc.sessions, uc.tokens, uc.eventRepo, user, "sso")
+}
+
+// provision finds the user the IdP identity belongs to, creating the account
+// and its company membership on first sign in
+func (uc *ssoUsecase) provision(ctx context.Context, config *domain.SSOConfig, profile *oauth.Profile) (*domain.User, error) {
+	account := domain.OAuthAccount{
+		Provider: config.ProviderName(),
+		Subject:  profile.Subject,
+		LinkedAt: time.Now(),
+	}
+
+	user, err := uc.userRepo.FindByOAuthAccount(ctx, account.Provider, account.Subject)
+	switch {
+	case err == nil:
+	case errors.Is(err, domain.ErrUserNotFound):
+		user, err = uc.userRepo.FindByEmail(ctx, profile.Email)
+		switch {
+		case err == nil:
+			// Only link accounts that already belong to the company, never take over
+			// an applicant or another company's account with the same email
+			if user.ID.Hex() != config.CompanyID {
+				member, err := uc.memberRepo.FindByUser(ctx, user.ID.Hex())
+				if err != nil {
+					return nil, err
+				}
+				if member == nil || member.CompanyID != config.CompanyID {
+					return nil, apperrors.NewConflictError("An account with this email already exists and is not part of this company")
+				}
+			}
+			if err := uc.userRepo.AddOAuthAccount(ctx, user.ID.Hex(), account); err != nil {
+				return nil, err
+			}
+		case errors.Is(err, domain.ErrUserNotFound):
+			name := profile.Name
+			if name == "" {
+				name, _, _ = strings.Cut(profile.Email, "@")
+			}
+
+			now := time.Now()
+			client := domain.ClientInfoFromContext(ctx)
+			user = &domain.User{
+				Name:          name,
+				Email:         profile.Email,
+				Role:          domain.Company,
+				Status:        domain.UserActive,
+				OAuthAccounts: []domain.OAuthAccount{account},
+				ProvisionedBy: account.Provider,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+				SignupCountry: client.Country,
+				GeoFlagged:    client.GeoFlagged,
+			}
+			if err := uc.userRepo.CreateUser(ctx, user); err != nil {
+				return nil, err
+			}
+			recordAuthEvent(ctx, uc.eventRepo, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventSignup, Method: "sso"})
+			uc.events.Publish(ctx, domain.EventUserSignup, user.EventData("sso"))
+		default:
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	// The company account itself may sign in through its IdP, everybody else is a recruiter
+	if user.ID.Hex() != config.CompanyID {
+		if err := uc.memberRepo.Add(ctx, &domain.CompanyMember{
+			CompanyID: config.CompanyID,
+			UserID:    user.ID.Hex(),
+			Role:      domain.MemberRecruiter,
+			Source:    "sso",
+		}); err != nil {
+			return nil, fmt.Errorf("error adding company member: %w", err)
+		}
+	}
+
+	return user, nil
+}
+
+// requireCompanyAccount rejects recruiters, they act on behalf of a company but
+// only the company account itself manages SSO
+func (uc *ssoUsecase) requireCompanyAccount(ctx context.Context, userID string) error {
+	member, err := uc.memberRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if member != nil {
+		return errForbidden("Only the company account can configure SSO")
+	}
+	return nil
+}
+
+// enabledConfig returns the company's SSO configuration if SSO is turned on
+func (uc *ssoUsecase) enabledConfig(ctx context.Context, companyID string) (*domain.SSOConfig, error) {
+	config, err := uc.configRepo.GetByCompany(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSSOConfigNotFound) {
+			return nil, apperrors.NewNotFoundError("SSO is not available for this company")
+		}
+		return nil, err
+	}
+	if !config.Enabled {
+		return nil, apperrors.NewNotFoundError("SSO is not available for this company")
+	}
+
+	return config, nil
+}
+
+// provider builds the OIDC provider of a configuration from its discovery document
+func (uc *ssoUsecase) provider(ctx context.Context, config *domain.SSOConfig) (*oauth.Provider, error) {
+	return oauth.NewOIDCProvider(ctx, config.ProviderName(), config.Issuer, oauth.ProviderConfig{
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		RedirectURL:  uc.callbackURL(config.CompanyID),
+	})
+}
+
+// callbackURL is the redirect URI the company registers with its IdP
+func (uc *ssoUsecase) callbackURL(companyID string) string {
+	return uc.apiBaseURL + "/api/v1/auth/sso/" + companyID + "/callback"
+}
+
+// configView adds the URLs the company needs to set up its IdP to the configuration
+func (uc *ssoUsecase) configView(config *domain.SSOConfig) *domain.SSOConfigView {
+	return &domain.SSOConfigView{
+		SSOConfig:          config,
+		LoginURL:           uc.apiBaseURL + "/api/v1/auth/sso/" + config.CompanyID,
+		RedirectURI:        uc.callbackURL(config.CompanyID),
+		VerificationRecord: config.DomainVerificationRecord(),
+		UnverifiedDomains:  config.UnverifiedDomains(),
+	}
+}
+
+func hasString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/metrics"
+)
+
+// dependencyCheckTimeout bounds a single dependency check
+const dependencyCheckTimeout = 5 * time.Second
+
+// statusWindows are the periods the status page summarizes
+var statusWindows = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"1h", time.Hour},
+	{"24h", 24 * time.Hour},
+}
+
+// DependencyCheck probes a dependency of the API. Check returns the
+// dependency's level, an error means it is down.
+type DependencyCheck struct {
+	Name  string
+	Check func(ctx context.Context) (domain.StatusLevel, error)
+}
+
+// StatusUsecase serves the public status page from the request metrics and
+// the periodic dependency checks
+type StatusUsecase interface {
+	// CheckDependencies runs every dependency check and records whether they all passed
+	CheckDependencies(ctx context.Context) error
+	GetStatus(ctx context.Context) (*domain.StatusResponse, error)
+}
+
+type statusUsecase struct {
+	checks   []DependencyCheck
+	recorder *metrics.Recorder
+
+	mu     sync.RWMutex
+	latest []*domain.DependencyStatus
+}
+
+func NewStatusUsecase(recorder *metrics.Recorder, checks ...DependencyCheck) StatusUsecase {
+	return &statusUsecase{
+		checks:   checks,
+		recorder: recorder,
+	}
+}
+
+func (uc *statusUsecase) CheckDependencies(ctx context.Context) error {
+	results := make([]*domain.DependencyStatus, len(uc.checks))
+	up := true
+	for i, check := range uc.checks {
+		checkCtx, cancel := context.WithTimeout(ctx, dependencyCheckTimeout)
+		start := time.Now()
+		level, err := check.Check(checkCtx)
+		cancel()
+		if err != nil {
+			log.Printf("Dependency check %s failed: %v", check.Name, err)
+			level = domain.StatusDown
+		}
+		if level == domain.StatusDown {
+			up = false
+		}
+
+		results[i] = &domain.DependencyStatus{
+			Name:      check.Name,
+			Status:    level,
+			LatencyMs: time.Since(start).Milliseconds(),
+			CheckedAt: start,
+		}
+	}
+	uc.recorder.RecordCheck(up)
+
+	uc.mu.Lock()
+	uc.latest = results
+	uc.mu.Unlock()
+	return nil
+}
+
+func (uc *statusUsecase) GetStatus(ctx context.Context) (*domain.StatusResponse, error) {
+	uc.mu.RLock()
+	dependencies := uc.latest
+	uc.mu.RUnlock()
+	// Right after startup no check ran yet
+	if dependencies == nil {
+		if err := uc.CheckDependencies(ctx); err != nil {
+			return nil, err
+		}
+		uc.mu.RLock()
+		dependencies = uc.latest
+		uc.mu.RUnlock()
+	}
+
+	page := &domain.StatusPage{
+		Version:      domain.StatusSchemaVersion,
+		Status:       domain.StatusOperational,
+		GeneratedAt:  time.Now(),
+		Dependencies: dependencies,
+	}
+	for _, dependency := range dependencies {
+		page.Status = page.Status.Worse(dependency.Status)
+	}
+
+	for _, w := range statusWindows {
+		snapshot := uc.recorder.Window(w.duration)
+		window := domain.StatusWindow{
+			Window:   w.name,
+			Requests: snapshot.Requests,
+		}
+		if snapshot.Checks > 0 {
+			uptime := percent(snapshot.ChecksUp, snapshot.Checks)
+			window.UptimePercent = &uptime
+		}
+		if snapshot.Requests > 0 {
+			window.ErrorRatePercent = percent(snapshot.Errors, snapshot.Requests)
+		}
+		page.Windows = append(page.Windows, window)
+	}
+	if page.Windows[0].ErrorRatePercent > domain.DegradedErrorRate {
+		page.Status = page.Status.Worse(domain.StatusDegraded)
+	}
+
+	return &domain.StatusResponse{
+		Success: true,
+		Message: "Successfully retrieved status",
+		Data:    page,
+	}, nil
+}
+
+// percent returns part/total as a percentage rounded to two decimals
+func percent(part, total int64) float64 {
+	return math.Round(float64(part)/float64(total)*10000) / 100
+}
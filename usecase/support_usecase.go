@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/pkg/email"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// SupportUsecase handles the problems users report to the portal's support
+// team, and the team's answers
+type SupportUsecase interface {
+	// CreateTicket opens a ticket for the user, attachmentURL is empty without attachment
+	CreateTicket(ctx context.Context, userID string, req *domain.CreateSupportTicketRequest, attachmentURL string) (*domain.SupportTicketResponse, error)
+	// ListMyTickets returns the user's tickets with their replies
+	ListMyTickets(ctx context.Context, userID string, page, limit int) (*domain.SupportTicketListResponse, error)
+	// ReplyAsUser adds the user's reply to their ticket and opens it again
+	ReplyAsUser(ctx context.Context, userID, ticketID string, req *domain.ReplySupportTicketRequest) (*domain.SupportTicketResponse, error)
+	// ListTickets returns the support queue, longest waiting first
+	ListTickets(ctx context.Context, filter domain.SupportTicketFilter, page, limit int) (*domain.SupportTicketListResponse, error)
+	GetTicket(ctx context.Context, ticketID string) (*domain.SupportTicketResponse, error)
+	// ReplyAsSupport answers the ticket and emails the answer to the user
+	ReplyAsSupport(ctx context.Context, adminID, ticketID string, req *domain.ReplySupportTicketRequest) (*domain.SupportTicketResponse, error)
+}
+
+type supportUsecase struct {
+	ticketRepo   repository.SupportTicketRepository
+	userRepo     repository.UserRepository
+	mailer       email.Sender
+	supportEmail string
+	frontendURL  string
+}
+
+// NewSupportUsecase creates a SupportUsecase. New tickets and user replies are
+// emailed to supportEmail, when it is set.
+func NewSupportUsecase(ticketRepo repository.SupportTicketRepository, userRepo repository.UserRepository, mailer email.Sender, supportEmail, frontendURL string) SupportUsecase {
+	return &supportUsecase{
+		ticketRepo:   ticketRepo,
+		userRepo:     userRepo,
+		mailer:       mailer,
+		supportEmail: supportEmail,
+		frontendURL:  frontendURL,
+	}
+}
+
+func (uc *supportUsecase) CreateTicket(ctx context.Context, userID string, req *domain.CreateSupportTicketRequest, attachmentURL string) (*domain.SupportTicketResponse, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewNotFoundError("User not found")
+		}
+		return nil, err
+	}
+
+	ticket := &domain.SupportTicket{
+		UserID:        userID,
+		Name:          user.Name,
+		Email:         user.Email,
+		Role:          user.Role,
+		Category:      req.Category,
+		Message:       req.Message,
+		AttachmentURL: attachmentURL,
+		Status:        domain.TicketStatusOpen,
+	}
+	if err := uc.ticketRepo.Create(ctx, ticket); err != nil {
+		return nil, fmt.Errorf("error creating support ticket: %w", err)
+	}
+
+	body := fmt.Sprintf("%s (%s, %s) opened a %s ticket:\n\n%s\n", ticket.Name, ticket.Email, ticket.Role, ticket.Category, ticket.Message)
+	if attachmentURL != "" {
+		body += "\nAttachment: " + attachmentURL + "\n"
+	}
+	uc.notifySupport(ctx, ticket, "New support ticket", body)
+
+	return &domain.SupportTicketResponse{
+		Success: true,
+		Message: "Support ticket created, our team will get back to you by email",
+		Data:    ticket,
+	}, nil
+}
+
+func (uc *supportUsecase) ListMyTickets(ctx context.Context, userID string, page, limit int) (*domain.SupportTicketListResponse, error) {
+	page, limit = supportPage(page, limit)
+
+	tickets, total, err := uc.ticketRepo.ListByUser(ctx, userID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing support tickets: %w", err)
+	}
+
+	return supportListResponse(tickets, total, page, limit), nil
+}
+
+func (uc *supportUsecase) ReplyAsUser(ctx context.Context, userID, ticketID string, req *domain.ReplySupportTicketRequest) (*domain.SupportTicketResponse, error) {
+	ticket, err := uc.getTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	// Other users' tickets are reported as missing
+	if ticket.UserID != userID {
+		return nil, apperrors.NewNotFoundError("Support ticket not found")
+	}
+
+	reply := domain.SupportTicketReply{AuthorID: userID, Message: req.Message, CreatedAt: time.Now()}
+	if err := uc.addReply(ctx, ticket, reply, domain.TicketStatusOpen); err != nil {
+		return nil, err
+	}
+
+	uc.notifySupport(ctx, ticket, "Support ticket reply",
+		fmt.Sprintf("%s (%s) replied to their %s ticket:\n\n%s\n", ticket.Name, ticket.Email, ticket.Category, req.Message))
+
+	return uc.ticketResponse(ctx, ticketID, "Reply added")
+}
+
+func (uc *supportUsecase) ListTickets(ctx context.Context, filter domain.SupportTicketFilter, page, limit int) (*domain.SupportTicketListResponse, error) {
+	switch filter.Status {
+	case "", domain.TicketStatusOpen, domain.TicketStatusAnswered, domain.TicketStatusClosed:
+	default:
+		return nil, apperrors.NewBadRequestError("Invalid status", []string{"status must be open, answered or closed"})
+	}
+	page, limit = supportPage(page, limit)
+
+	tickets, total, err := uc.ticketRepo.List(ctx, filter, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing support tickets: %w", err)
+	}
+
+	return supportListResponse(tickets, total, page, limit), nil
+}
+
+func (uc *supportUsecase) GetTicket(ctx context.Context, ticketID string) (*domain.SupportTicketResponse, error) {
+	ticket, err := uc.getTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SupportTicketResponse{
+		Success: true,
+		Message: "Successfully retrieved support ticket",
+		Data:    ticket,
+	}, nil
+}
+
+func (uc *supportUsecase) ReplyAsSupport(ctx context.Context, adminID, ticketID string, req *domain.ReplySupportTicketRequest) (*domain.SupportTicketResponse, error) {
+	ticket, err := uc.getTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := domain.TicketStatusAnswered
+	if req.Close {
+		status = domain.TicketStatusClosed
+	}
+	reply := domain.SupportTicketReply{AuthorID: adminID, FromSupport: true, Message: req.Message, CreatedAt: time.Now()}
+	if err := uc.addReply(ctx, ticket, reply, status); err != nil {
+		return nil, err
+	}
+
+	// The user's current address is used, they may have changed it since
+	if user, err := uc.userRepo.FindByID(ctx, ticket.UserID); err == nil && !user.IsDeleted() {
+		body := fmt.Sprintf("Hi %s,\n\nOur support team answered your request:\n\n%s\n", user.Name, req.Message)
+		if req.Close {
+			body += "\nWe consider this request resolved. If it isn't, open a new request from the help page.\n"
+		} else {
+			body += fmt.Sprintf("\nReply from the help page: %s/support\n", uc.frontendURL)
+		}
+		if err := uc.mailer.Send(ctx, email.Message{To: user.Email, Subject: "Answer to your support request", Body: body}); err != nil {
+			log.Printf("Failed to send support reply email to user %s: %v", ticket.UserID, err)
+		}
+	}
+
+	return uc.ticketResponse(ctx, ticketID, "Reply sent")
+}
+
+// getTicket loads a ticket, mapping a missing one to a 404
+func (uc *supportUsecase) getTicket(ctx context.Context, ticketID string) (*domain.SupportTicket, error) {
+	ticket, err := uc.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		if isNotFound(err, domain.ErrSupportTicketNotFound) {
+			return nil, apperrors.NewNotFoundError("Support ticket not found")
+		}
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// addReply appends the reply to a ticket that isn't closed
+func (uc *supportUsecase) addReply(ctx context.Context, ticket *domain.SupportTicket, reply domain.SupportTicketReply, status domain.SupportTicketStatus) error {
+	errClosed := apperrors.NewConflictError("Support ticket is closed, open a new one")
+	if ticket.Status == domain.TicketStatusClosed {
+		return errClosed
+	}
+
+	if err := uc.ticketRepo.AddReply(ctx, ticket.ID.Hex(), reply, status); err != nil {
+		if errors.Is(err, domain.ErrSupportTicketClosed) {
+			return errClosed
+		}
+		return fmt.Errorf("error adding support ticket reply: %w", err)
+	}
+	return nil
+}
+
+func (uc *supportUsecase) ticketResponse(ctx context.Context, ticketID, message string) (*domain.SupportTicketResponse, error) {
+	ticket, err := uc.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.SupportTicketResponse{
+		Success: true,
+		Message: message,
+		Data:    ticket,
+	}, nil
+}
+
+// notifySupport emails the support team about the ticket. The ticket is
+// already stored and listed to admins, so a failed email is only logged.
+func (uc *supportUsecase) notifySupport(ctx context.Context, ticket *domain.SupportTicket, subject, body string) {
+	if uc.supportEmail == "" {
+		return
+	}
+
+	subject = fmt.Sprintf("%s #%s", subject, ticket.ID.Hex())
+	if err := uc.mailer.Send(ctx, email.Message{To: uc.supportEmail, Subject: subject, Body: body}); err != nil {
+		log.Printf("Failed to email support about ticket %s: %v", ticket.ID.Hex(), err)
+	}
+}
+
+// supportPage applies the default page and page size
+func supportPage(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+	return page, limit
+}
+
+func supportListResponse(tickets []*domain.SupportTicket, total int64, page, limit int) *domain.SupportTicketListResponse {
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return &domain.SupportTicketListResponse{
+		Success:    true,
+		Message:    "Successfully retrieved support tickets",
+		Data:       tickets,
+		PageNumber: page,
+		PageSize:   len(tickets),
+		TotalItems: total,
+		TotalPages: totalPages,
+	}
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/repository"
+)
+
+// TrendingUsecase ranks the jobs getting the most attention from their recent
+// views and applications
+type TrendingUsecase interface {
+	// RecordView counts a view of the job's page. Counts are kept in memory
+	// until the next Flush.
+	RecordView(jobID primitive.ObjectID)
+	// Flush stores the views recorded since the last flush
+	Flush(ctx context.Context) error
+	// Refresh recomputes the rankings, which are cached in between
+	Refresh(ctx context.Context) error
+	// ListTrending returns the listed jobs ranked by domain.TrendingByScore or
+	// domain.TrendingByApplications
+	ListTrending(ctx context.Context, by string, limit int) ([]*domain.TrendingJob, error)
+}
+
+type trendingUsecase struct {
+	viewRepo repository.JobViewRepository
+	appRepo  repository.ApplicationRepository
+	jobRepo  repository.JobRepository
+
+	mu      sync.Mutex
+	pending map[jobViewBucket]int64
+
+	rankingMu sync.RWMutex
+	// rankings holds the ranked activity per domain.TrendingBy* value
+	rankings map[string][]*jobTrend
+	rankedAt time.Time
+}
+
+// jobViewBucket identifies the views of a job during a day
+type jobViewBucket struct {
+	jobID primitive.ObjectID
+	day   time.Time
+}
+
+// jobTrend is the recent activity of a job
+type jobTrend struct {
+	jobID        primitive.ObjectID
+	views        int64
+	applications int64
+}
+
+func (t *jobTrend) score() int64 {
+	return t.views + t.applications*domain.TrendingApplicationWeight
+}
+
+func NewTrendingUsecase(viewRepo repository.JobViewRepository, appRepo repository.ApplicationRepository, jobRepo repository.JobRepository) TrendingUsecase {
+	return &trendingUsecase{
+		viewRepo: viewRepo,
+		appRepo:  appRepo,
+		jobRepo:  jobRepo,
+		pending:  make(map[jobViewBucket]int64),
+	}
+}
+
+func (uc *trendingUsecase) RecordView(jobID primitive.ObjectID) {
+	bucket := jobViewBucket{jobID: jobID, day: time.Now().UTC().Truncate(24 * time.Hour)}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.pending[bucket]++
+}
+
+func (uc *trendingUsecase) Flush(ctx context.Context) error {
+	uc.mu.Lock()
+	pending := uc.pending
+	uc.pending = make(map[jobViewBucket]int64)
+	uc.mu.Unlock()
+
+	views := make([]*domain.JobViews, 0, len(pending))
+	for bucket, count := range pending {
+		views = append(views, &domain.JobViews{JobID: bucket.jobID, Day: bucket.day, Views: count})
+	}
+	if err := uc.viewRepo.Add(ctx, views); err != nil {
+		// Keep the counts for the next flush
+		uc.mu.Lock()
+		for bucket, count := range pending {
+			uc.pending[bucket] += count
+		}
+		uc.mu.Unlock()
+		return fmt.Errorf("error storing job views: %w", err)
+	}
+	return nil
+}
+
+func (uc *trendingUsecase) Refresh(ctx context.Context) error {
+	since := time.Now().UTC().Add(-domain.TrendingWindow)
+
+	// Views are counted per day, the window starts with the day it falls on
+	viewed, err := uc.viewRepo.MostViewed(ctx, since.Truncate(24*time.Hour), domain.TrendingCandidates)
+	if err != nil {
+		return fmt.Errorf("error counting job views: %w", err)
+	}
+	applied, err := uc.appRepo.MostApplied(ctx, since, domain.TrendingCandidates)
+	if err != nil {
+		return fmt.Errorf("error counting applications: %w", err)
+	}
+
+	// Jobs outside the top of one list count as having no activity there
+	trends := map[primitive.ObjectID]*jobTrend{}
+	trendOf := func(jobID primitive.ObjectID) *jobTrend {
+		trend, ok := trends[jobID]
+		if !ok {
+			trend = &jobTrend{jobID: jobID}
+			trends[jobID] = trend
+		}
+		return trend
+	}
+	for _, activity := range viewed {
+		trendOf(activity.JobID).views = activity.Count
+	}
+	for _, activity := range applied {
+		trendOf(activity.JobID).applications = activity.Count
+	}
+
+	byScore := make([]*jobTrend, 0, len(trends))
+	for _, trend := range trends {
+		byScore = append(byScore, trend)
+	}
+	byApplications := append([]*jobTrend(nil), byScore...)
+
+	sort.Slice(byScore, func(i, j int) bool {
+		if byScore[i].score() != byScore[j].score() {
+			return byScore[i].score() > byScore[j].score()
+		}
+		return byScore[i].jobID.Hex() > byScore[j].jobID.Hex()
+	})
+	sort.Slice(byApplications, func(i, j int) bool {
+		a, b := byApplications[i], byApplications[j]
+		if a.applications != b.applications {
+			return a.applications > b.applications
+		}
+		if a.views != b.views {
+			return a.views > b.views
+		}
+		return a.jobID.Hex() > b.jobID.Hex()
+	})
+	// Most applied only lists jobs that were applied to
+	for len(byApplications) > 0 && byApplications[len(byApplications)-1].applications == 0 {
+		byApplications = byApplications[:len(byApplications)-1]
+	}
+
+	uc.rankingMu.Lock()
+	uc.rankings = map[string][]*jobTrend{
+		domain.TrendingByScore:        byScore,
+		domain.TrendingByApplications: byApplications,
+	}
+	uc.rankedAt = time.Now()
+	uc.rankingMu.Unlock()
+
+	return nil
+}
+
+func (uc *trendingUsecase) ListTrending(ctx context.Context, by string, limit int) ([]*domain.TrendingJob, error) {
+	if by == "" {
+		by = domain.TrendingByScore
+	}
+	if by != domain.TrendingByScore && by != domain.TrendingByApplications {
+		return nil, apperrors.NewBadRequestError("Invalid ranking", []string{"by must be trending or applications"})
+	}
+	if limit < 1 || limit > domain.MaxTrendingJobs {
+		limit = domain.DefaultTrendingJobs
+	}
+
+	ranking, err := uc.ranking(ctx, by)
+	if err != nil {
+		return nil, err
+	}
+
+	// Jobs taken down since the last refresh are skipped, so more are looked up than listed
+	candidates := ranking
+	if len(candidates) > limit*2 {
+		candidates = candidates[:limit*2]
+	}
+	ids := make([]primitive.ObjectID, len(candidates))
+	for i, trend := range candidates {
+		ids[i] = trend.jobID
+	}
+	jobs, err := uc.jobRepo.ListListedByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving trending jobs: %w", err)
+	}
+	setComputedFields(jobs...)
+
+	listed := make(map[primitive.ObjectID]*domain.Job, len(jobs))
+	for _, job := range jobs {
+		listed[job.ID] = job
+	}
+
+	trending := make([]*domain.TrendingJob, 0, limit)
+	for _, trend := range candidates {
+		job, ok := listed[trend.jobID]
+		if !ok {
+			continue
+		}
+		trending = append(trending, &domain.TrendingJob{
+			Job:                job,
+			RecentViews:        trend.views,
+			RecentApplications: trend.applications,
+		})
+		if len(trending) == limit {
+			break
+		}
+	}
+	return trending, nil
+}
+
+// ranking returns the cached ranking, computing it when nothing was cached yet
+func (uc *trendingUsecase) ranking(ctx context.Context, by string) ([]*jobTrend, error) {
+	uc.rankingMu.RLock()
+	ranked := !uc.rankedAt.IsZero()
+	uc.rankingMu.RUnlock()
+
+	if !ranked {
+		if err := uc.Refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.rankingMu.RLock()
+	defer uc.rankingMu.RUnlock()
+	return uc.rankings[by], nil
+}
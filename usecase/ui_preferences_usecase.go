@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"job-portal-backend/domain"
+	"job-portal-backend/repository"
+)
+
+// UIPreferencesUsecase stores the view settings the web client keeps in sync
+// across the user's devices
+type UIPreferencesUsecase interface {
+	GetPreferences(ctx context.Context, userID string) (*domain.UIPreferencesResponse, error)
+	// UpdatePreferences saves the given values, already decoded and validated
+	// for their key. A nil value removes the key.
+	UpdatePreferences(ctx context.Context, userID string, values map[domain.UIPreferenceKey]interface{}) (*domain.UIPreferencesResponse, error)
+}
+
+type uiPreferencesUsecase struct {
+	prefsRepo repository.UIPreferencesRepository
+}
+
+func NewUIPreferencesUsecase(prefsRepo repository.UIPreferencesRepository) UIPreferencesUsecase {
+	return &uiPreferencesUsecase{
+		prefsRepo: prefsRepo,
+	}
+}
+
+func (uc *uiPreferencesUsecase) GetPreferences(ctx context.Context, userID string) (*domain.UIPreferencesResponse, error) {
+	prefs, err := uc.prefsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving preferences: %w", err)
+	}
+
+	return &domain.UIPreferencesResponse{
+		Success: true,
+		Message: "Successfully retrieved preferences",
+		Data:    prefs.View(),
+	}, nil
+}
+
+func (uc *uiPreferencesUsecase) UpdatePreferences(ctx context.Context, userID string, values map[domain.UIPreferenceKey]interface{}) (*domain.UIPreferencesResponse, error) {
+	set := make(map[string]string)
+	var unset []string
+	for key, value := range values {
+		if value == nil {
+			unset = append(unset, string(key))
+			continue
+		}
+		// Re-encoding drops whatever the client sent beyond the known fields
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding preference %s: %w", key, err)
+		}
+		set[string(key)] = string(encoded)
+	}
+
+	if err := uc.prefsRepo.Update(ctx, userID, set, unset); err != nil {
+		return nil, fmt.Errorf("error saving preferences: %w", err)
+	}
+
+	prefs, err := uc.prefsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving preferences: %w", err)
+	}
+
+	return &domain.UIPreferencesResponse{
+		Success: true,
+		Message: "Preferences updated successfully",
+		Data:    prefs.View(),
+	}, nil
+}
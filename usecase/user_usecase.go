@@ -2,66 +2,148 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
 	"time"
 
 	"job-portal-backend/domain"
+	"job-portal-backend/pkg/email"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/pkg/oauth"
 	"job-portal-backend/repository"
 	"job-portal-backend/utils"
 )
 
+// passwordResetTTL is how long a password reset link stays valid
+const passwordResetTTL = time.Hour
+
+// emailChangeTTL is how long an email change confirmation link stays valid
+const emailChangeTTL = 24 * time.Hour
+
+// magicLinkTTL is how long a passwordless login link stays valid
+const magicLinkTTL = 15 * time.Minute
+
+// twoFactorChallengeTTL is how long a user has to enter the TOTP code after the password step
+const twoFactorChallengeTTL = 5 * time.Minute
+
+// totpIssuer is the account issuer shown in authenticator apps
+const totpIssuer = "Job Portal"
+
 type UserUsecase interface {
 	SignUp(ctx context.Context, req *domain.SignUpRequest) (*domain.AuthResponse, error)
 	Login(ctx context.Context, req *domain.LoginRequest) (*domain.AuthResponse, error)
 	GetProfile(ctx context.Context, userID string) (*domain.User, error)
+	UpdateProfile(ctx context.Context, userID string, req *domain.UpdateUserRequest) (*domain.UserResponse, error)
+	// RequestEmailChange emails a confirmation link to the new address, the
+	// email is only changed once the link is used
+	RequestEmailChange(ctx context.Context, userID string, req *domain.EmailChangeRequest) (*domain.UserResponse, error)
+	// ConfirmEmailChange swaps the email and signs out every session
+	ConfirmEmailChange(ctx context.Context, req *domain.ConfirmEmailChangeRequest) (*domain.AuthResponse, error)
+	ForgotPassword(ctx context.Context, req *domain.ForgotPasswordRequest) (*domain.AuthResponse, error)
+	ResetPassword(ctx context.Context, req *domain.ResetPasswordRequest) (*domain.AuthResponse, error)
+	// RequestMagicLink emails an applicant a single-use passwordless login link
+	RequestMagicLink(ctx context.Context, req *domain.MagicLinkRequest) (*domain.AuthResponse, error)
+	// VerifyMagicLink exchanges a login link token for an access token
+	VerifyMagicLink(ctx context.Context, rawToken string) (*domain.AuthResponse, error)
+	Logout(ctx context.Context, userID, tokenID string, expiresAt time.Time, allSessions bool) (*domain.AuthResponse, error)
+	OAuthLoginURL(provider, state string) (string, error)
+	OAuthCallback(ctx context.Context, req *domain.OAuthCallbackRequest) (*domain.AuthResponse, error)
+	EnrollTwoFactor(ctx context.Context, userID string) (*domain.AuthResponse, error)
+	ConfirmTwoFactor(ctx context.Context, userID string, req *domain.TwoFactorConfirmRequest) (*domain.AuthResponse, error)
+	VerifyTwoFactorLogin(ctx context.Context, req *domain.TwoFactorLoginRequest) (*domain.AuthResponse, error)
+	// RefreshToken exchanges a valid access token for a new one carrying the
+	// user's current role, and revokes the old token
+	RefreshToken(ctx context.Context, userID, tokenID string, expiresAt time.Time) (*domain.AuthResponse, error)
+	GetSecurityLog(ctx context.Context, userID string, page, limit int) (*domain.UserListResponse, error)
+	GetPrivacy(ctx context.Context, userID string) (*domain.UserResponse, error)
+	UpdatePrivacy(ctx context.Context, userID string, req *domain.UpdatePrivacyRequest) (*domain.UserResponse, error)
+	// UpdateAvatar points the user's profile picture at an uploaded image
+	UpdateAvatar(ctx context.Context, userID, avatarURL string) (*domain.UserResponse, error)
 }
 
 type userUsecase struct {
-	repo       repository.UserRepository
-	jwtSecret  string
-	tokenExp   time.Duration
+	repo        repository.UserRepository
+	tokenRepo   repository.AuthTokenRepository
+	revokedRepo repository.RevokedTokenRepository
+	eventRepo   repository.AuthEventRepository
+	events      EventBus
+	mailer      email.Sender
+	oauth       map[string]*oauth.Provider
+	tokens      *utils.TokenService
+	sessions    SessionUsecase
+	frontendURL string
+	invites     repository.InviteCodeRepository
+	// inviteOnly requires an invite code to create an account
+	inviteOnly bool
 }
 
-func NewUserUsecase(repo repository.UserRepository, jwtSecret string) UserUsecase {
+func NewUserUsecase(repo repository.UserRepository, tokenRepo repository.AuthTokenRepository, revokedRepo repository.RevokedTokenRepository, eventRepo repository.AuthEventRepository, events EventBus, mailer email.Sender, oauthProviders []*oauth.Provider, tokens *utils.TokenService, sessions SessionUsecase, frontendURL string, invites repository.InviteCodeRepository, inviteOnly bool) UserUsecase {
+	providers := make(map[string]*oauth.Provider, len(oauthProviders))
+	for _, p := range oauthProviders {
+		providers[p.Name] = p
+	}
+
 	return &userUsecase{
-		repo:       repo,
-		jwtSecret:  jwtSecret,
-		tokenExp:   24 * time.Hour, // Default token expiration
+		repo:        repo,
+		tokenRepo:   tokenRepo,
+		revokedRepo: revokedRepo,
+		eventRepo:   eventRepo,
+		events:      events,
+		mailer:      mailer,
+		oauth:       providers,
+		tokens:      tokens,
+		sessions:    sessions,
+		frontendURL: frontendURL,
+		invites:     invites,
+		inviteOnly:  inviteOnly,
 	}
 }
 
 func (uc *userUsecase) SignUp(ctx context.Context, req *domain.SignUpRequest) (*domain.AuthResponse, error) {
 	// Check if user already exists
 	existingUser, err := uc.repo.FindByEmail(ctx, req.Email)
-	if err != nil && err != domain.ErrUserNotFound {
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
 		return nil, err
 	}
 
 	if existingUser != nil {
-		return &domain.AuthResponse{
-			Success: false,
-			Message: "Email already registered",
-		}, nil
+		return nil, apperrors.NewConflictError("Email already registered")
+	}
+
+	inviteCode, err := uc.redeemInviteCode(ctx, req.InviteCode, req.Role)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create new user
 	now := time.Now()
+	client := domain.ClientInfoFromContext(ctx)
 	user := &domain.User{
-		Name:      req.Name,
-		Email:     req.Email,
-		Password:  req.Password, // Will be hashed in repository
-		Role:      req.Role,
-		CreatedAt: now,
-		UpdatedAt: now,
+		Name:          req.Name,
+		Email:         req.Email,
+		Password:      req.Password, // Will be hashed in repository
+		Role:          req.Role,
+		Phone:         req.Phone,
+		Status:        domain.UserActive,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		SignupCountry: client.Country,
+		GeoFlagged:    client.GeoFlagged,
+		InviteCode:    inviteCode,
 	}
 
-
 	// Save user to database
 	if err := uc.repo.CreateUser(ctx, user); err != nil {
+		uc.releaseInviteCode(ctx, inviteCode)
 		return nil, err
 	}
+	uc.recordEvent(ctx, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventSignup, Method: "password"})
+	uc.events.Publish(ctx, domain.EventUserSignup, user.EventData("password"))
 
 	// Generate JWT token
-	token, err := utils.GenerateJWT(user.ID.Hex(), string(user.Role), uc.jwtSecret)
+	token, err := uc.sessions.StartSession(ctx, user, "")
 	if err != nil {
 		return nil, err
 	}
@@ -81,28 +163,609 @@ func (uc *userUsecase) Login(ctx context.Context, req *domain.LoginRequest) (*do
 	// Find user by email
 	user, err := uc.repo.FindByEmail(ctx, req.Email)
 	if err != nil {
-		if err == domain.ErrUserNotFound {
-			return &domain.AuthResponse{
-				Success: false,
-				Message: "Invalid email or password",
-			}, nil
+		if errors.Is(err, domain.ErrUserNotFound) {
+			uc.recordEvent(ctx, &domain.AuthEvent{Email: req.Email, Type: domain.AuthEventLoginFailed, Method: "password", Reason: "unknown_email"})
+			return nil, apperrors.NewUnauthorizedError("Invalid email or password")
 		}
 		return nil, err
 	}
 
 	// Verify password
 	if err := utils.CheckPassword(req.Password, user.Password); err != nil {
+		uc.recordEvent(ctx, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventLoginFailed, Method: "password", Reason: "invalid_password"})
+		return nil, apperrors.NewUnauthorizedError("Invalid email or password")
+	}
+
+	if user.IsSuspended() {
+		uc.recordEvent(ctx, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventLoginFailed, Method: "password", Reason: "account_suspended"})
+		return nil, errAccountSuspended()
+	}
+
+	return signIn(ctx, uc.sessions, uc.tokens, uc.eventRepo, user, "password")
+}
+
+func (uc *userUsecase) GetProfile(ctx context.Context, userID string) (*domain.User, error) {
+	user, err := uc.repo.FindByID(ctx, userID)
+	if err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewNotFoundError("User not found")
+		}
+		return nil, err
+	}
+
+	// Sanitize user data before returning
+	user.Sanitize()
+
+	return user, nil
+}
+
+func (uc *userUsecase) UpdateProfile(ctx context.Context, userID string, req *domain.UpdateUserRequest) (*domain.UserResponse, error) {
+	if err := uc.repo.UpdateUser(ctx, userID, req); err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewNotFoundError("User not found")
+		}
+		return nil, err
+	}
+
+	user, err := uc.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UserResponse{
+		Success: true,
+		Message: "Profile updated successfully",
+		Data:    user,
+	}, nil
+}
+
+func (uc *userUsecase) RequestEmailChange(ctx context.Context, userID string, req *domain.EmailChangeRequest) (*domain.UserResponse, error) {
+	user, err := uc.repo.FindByID(ctx, userID)
+	if err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewNotFoundError("User not found")
+		}
+		return nil, err
+	}
+
+	// Whoever holds a stolen session must not be able to take the account over
+	if user.Password != "" {
+		if req.Password == "" {
+			return nil, apperrors.NewBadRequestError("Validation failed", []string{"Your current password is required"})
+		}
+		if err := utils.CheckPassword(req.Password, user.Password); err != nil {
+			return nil, apperrors.NewUnauthorizedError("Invalid password")
+		}
+	}
+
+	if req.NewEmail == user.Email {
+		return nil, apperrors.NewBadRequestError("Validation failed", []string{"The new email is your current email"})
+	}
+	if _, err := uc.repo.FindByEmail(ctx, req.NewEmail); err == nil {
+		return nil, apperrors.NewConflictError("Email already exists")
+	} else if !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+
+	// Only the latest link works
+	if err := uc.tokenRepo.DeleteUserTokens(ctx, userID, domain.PurposeEmailChange); err != nil {
+		return nil, err
+	}
+
+	rawToken, err := utils.GenerateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+
+	token := &domain.AuthToken{
+		UserID:    userID,
+		TokenHash: utils.HashToken(rawToken),
+		Purpose:   domain.PurposeEmailChange,
+		ExpiresAt: time.Now().Add(emailChangeTTL),
+		NewEmail:  req.NewEmail,
+	}
+	if err := uc.tokenRepo.CreateToken(ctx, token); err != nil {
+		return nil, err
+	}
+
+	confirmLink := fmt.Sprintf("%s/confirm-email?token=%s", uc.frontendURL, rawToken)
+	err = uc.mailer.Send(ctx, email.Message{
+		To:      req.NewEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to confirm this is your new email address. It expires in %s.\n\n%s\n\nIf you didn't request this, you can ignore this email.\n",
+			user.Name, emailChangeTTL, confirmLink),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error sending email change confirmation: %w", err)
+	}
+
+	return &domain.UserResponse{
+		Success: true,
+		Message: "A confirmation link has been sent to the new email address",
+	}, nil
+}
+
+func (uc *userUsecase) ConfirmEmailChange(ctx context.Context, req *domain.ConfirmEmailChangeRequest) (*domain.AuthResponse, error) {
+	token, err := uc.tokenRepo.ConsumeToken(ctx, utils.HashToken(req.Token), domain.PurposeEmailChange)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidToken) {
+			return nil, apperrors.NewBadRequestError("Invalid or expired confirmation token", nil)
+		}
+		return nil, err
+	}
+
+	user, err := uc.repo.FindByID(ctx, token.UserID)
+	if err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewBadRequestError("Invalid or expired confirmation token", nil)
+		}
+		return nil, err
+	}
+	if user.IsDeleted() {
+		return nil, apperrors.NewBadRequestError("Invalid or expired confirmation token", nil)
+	}
+	oldEmail := user.Email
+
+	// The unique email index settles a race with a signup or another change to the same address
+	if err := uc.repo.UpdateEmail(ctx, token.UserID, token.NewEmail); err != nil {
+		if errors.Is(err, domain.ErrEmailAlreadyExists) {
+			return nil, apperrors.NewConflictError("Email already exists")
+		}
+		return nil, err
+	}
+	uc.recordEvent(ctx, &domain.AuthEvent{UserID: token.UserID, Type: domain.AuthEventEmailChange})
+	user.Email = token.NewEmail
+	uc.events.Publish(ctx, domain.EventUserVerified, user.EventData("email_change"))
+
+	// Links sent to the old address must not work anymore, and sessions are signed out
+	if err := uc.tokenRepo.DeleteUserTokens(ctx, token.UserID, domain.PurposePasswordReset); err != nil {
+		return nil, err
+	}
+	if err := uc.tokenRepo.DeleteUserTokens(ctx, token.UserID, domain.PurposeMagicLink); err != nil {
+		return nil, err
+	}
+	if err := uc.revokedRepo.RevokeAllForUser(ctx, token.UserID, time.Now().Add(uc.tokens.TTL())); err != nil {
+		return nil, err
+	}
+
+	// Let the previous address know, in case the change wasn't made by its owner
+	err = uc.mailer.Send(ctx, email.Message{
+		To:      oldEmail,
+		Subject: "Your email address was changed",
+		Body: fmt.Sprintf("Hi %s,\n\nThe email address of your account was changed to %s.\n\nIf you didn't make this change, contact support right away.\n",
+			user.Name, token.NewEmail),
+	})
+	if err != nil {
+		log.Printf("Failed to notify %s of the email change: %v", token.UserID, err)
+	}
+
+	return &domain.AuthResponse{
+		Success: true,
+		Message: "Email address changed successfully, please log in again",
+	}, nil
+}
+
+func (uc *userUsecase) ForgotPassword(ctx context.Context, req *domain.ForgotPasswordRequest) (*domain.AuthResponse, error) {
+	// Always return the same response so the endpoint can't be used to discover accounts
+	response := &domain.AuthResponse{
+		Success: true,
+		Message: "If an account exists for this email, a password reset link has been sent",
+	}
+
+	user, err := uc.repo.FindByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return response, nil
+		}
+		return nil, err
+	}
+
+	// Invalidate any previously issued reset links
+	if err := uc.tokenRepo.DeleteUserTokens(ctx, user.ID.Hex(), domain.PurposePasswordReset); err != nil {
+		return nil, err
+	}
+
+	rawToken, err := utils.GenerateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+
+	token := &domain.AuthToken{
+		UserID:    user.ID.Hex(),
+		TokenHash: utils.HashToken(rawToken),
+		Purpose:   domain.PurposePasswordReset,
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+	}
+	if err := uc.tokenRepo.CreateToken(ctx, token); err != nil {
+		return nil, err
+	}
+
+	resetLink := fmt.Sprintf("%s/reset-password?token=%s", uc.frontendURL, rawToken)
+	err = uc.mailer.Send(ctx, email.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to reset your password. It expires in %s.\n\n%s\n\nIf you didn't request this, you can ignore this email.\n",
+			user.Name, passwordResetTTL, resetLink),
+	})
+	if err != nil {
+		// Failing here would tell the caller the account exists
+		log.Printf("Failed to send password reset email to %s: %v", user.ID.Hex(), err)
+	}
+
+	return response, nil
+}
+
+func (uc *userUsecase) ResetPassword(ctx context.Context, req *domain.ResetPasswordRequest) (*domain.AuthResponse, error) {
+	token, err := uc.tokenRepo.ConsumeToken(ctx, utils.HashToken(req.Token), domain.PurposePasswordReset)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidToken) {
+			return nil, apperrors.NewBadRequestError("Invalid or expired reset token", nil)
+		}
+		return nil, err
+	}
+
+	if err := uc.repo.UpdatePassword(ctx, token.UserID, req.NewPassword); err != nil {
+		return nil, err
+	}
+	uc.recordEvent(ctx, &domain.AuthEvent{UserID: token.UserID, Type: domain.AuthEventPasswordChange, Method: "password_reset"})
+
+	// Sign out every existing session, they may belong to whoever knew the old password
+	if err := uc.revokedRepo.RevokeAllForUser(ctx, token.UserID, time.Now().Add(uc.tokens.TTL())); err != nil {
+		return nil, err
+	}
+
+	return &domain.AuthResponse{
+		Success: true,
+		Message: "Password has been reset successfully",
+	}, nil
+}
+
+func (uc *userUsecase) RequestMagicLink(ctx context.Context, req *domain.MagicLinkRequest) (*domain.AuthResponse, error) {
+	// Always return the same response so the endpoint can't be used to discover accounts
+	response := &domain.AuthResponse{
+		Success: true,
+		Message: "If an applicant account exists for this email, a login link has been sent",
+	}
+
+	user, err := uc.repo.FindByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return response, nil
+		}
+		return nil, err
+	}
+
+	// Passwordless login is for applicants, and must not bypass two-factor authentication
+	if user.Role != domain.Applicant || user.TwoFactorEnabled || user.IsSuspended() {
+		return response, nil
+	}
+
+	// Only the latest link works
+	if err := uc.tokenRepo.DeleteUserTokens(ctx, user.ID.Hex(), domain.PurposeMagicLink); err != nil {
+		return nil, err
+	}
+
+	rawToken, err := utils.GenerateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+
+	token := &domain.AuthToken{
+		UserID:    user.ID.Hex(),
+		TokenHash: utils.HashToken(rawToken),
+		Purpose:   domain.PurposeMagicLink,
+		ExpiresAt: time.Now().Add(magicLinkTTL),
+	}
+	if err := uc.tokenRepo.CreateToken(ctx, token); err != nil {
+		return nil, err
+	}
+
+	// The link opens the web client, which calls the verify endpoint. Linking the
+	// API directly would let mail scanners that prefetch links burn the token.
+	loginLink := fmt.Sprintf("%s/magic-link?token=%s", uc.frontendURL, rawToken)
+	err = uc.mailer.Send(ctx, email.Message{
+		To:      user.Email,
+		Subject: "Your login link",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to log in. It can be used once and expires in %s.\n\n%s\n\nIf you didn't request this, you can ignore this email.\n",
+			user.Name, magicLinkTTL, loginLink),
+	})
+	if err != nil {
+		// Failing here would tell the caller the account exists
+		log.Printf("Failed to send login link email to %s: %v", user.ID.Hex(), err)
+	}
+
+	return response, nil
+}
+
+func (uc *userUsecase) VerifyMagicLink(ctx context.Context, rawToken string) (*domain.AuthResponse, error) {
+	token, err := uc.tokenRepo.ConsumeToken(ctx, utils.HashToken(rawToken), domain.PurposeMagicLink)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidToken) {
+			return nil, apperrors.NewUnauthorizedError("Invalid or expired login link")
+		}
+		return nil, err
+	}
+
+	user, err := uc.repo.FindByID(ctx, token.UserID)
+	if err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewUnauthorizedError("Invalid or expired login link")
+		}
+		return nil, err
+	}
+	if user.IsDeleted() {
+		return nil, apperrors.NewUnauthorizedError("Invalid or expired login link")
+	}
+
+	if user.IsSuspended() {
+		uc.recordEvent(ctx, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventLoginFailed, Method: "magic_link", Reason: "account_suspended"})
+		return nil, errAccountSuspended()
+	}
+
+	// Following the link proves the user owns the address
+	verified, err := uc.repo.MarkEmailVerified(ctx, user.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	if verified {
+		uc.events.Publish(ctx, domain.EventUserVerified, user.EventData("magic_link"))
+	}
+
+	return signIn(ctx, uc.sessions, uc.tokens, uc.eventRepo, user, "magic_link")
+}
+
+func (uc *userUsecase) Logout(ctx context.Context, userID, tokenID string, expiresAt time.Time, allSessions bool) (*domain.AuthResponse, error) {
+	if allSessions {
+		if err := uc.revokedRepo.RevokeAllForUser(ctx, userID, time.Now().Add(uc.tokens.TTL())); err != nil {
+			return nil, err
+		}
+
 		return &domain.AuthResponse{
-			Success: false,
-			Message: "Invalid email or password",
+			Success: true,
+			Message: "Logged out from all sessions",
 		}, nil
 	}
 
+	// Tokens issued before JTIs were introduced can only be revoked with allSessions
+	if tokenID == "" {
+		return nil, apperrors.NewBadRequestError("Token cannot be revoked individually, log out from all sessions instead", nil)
+	}
+
+	if err := uc.revokedRepo.RevokeToken(ctx, tokenID, userID, expiresAt); err != nil {
+		return nil, err
+	}
+
+	return &domain.AuthResponse{
+		Success: true,
+		Message: "Logged out successfully",
+	}, nil
+}
+
+func (uc *userUsecase) OAuthLoginURL(provider, state string) (string, error) {
+	p, ok := uc.oauth[provider]
+	if !ok {
+		return "", apperrors.NewNotFoundError("Unsupported login provider")
+	}
+
+	return p.AuthCodeURL(state), nil
+}
+
+func (uc *userUsecase) OAuthCallback(ctx context.Context, req *domain.OAuthCallbackRequest) (*domain.AuthResponse, error) {
+	p, ok := uc.oauth[req.Provider]
+	if !ok {
+		return nil, apperrors.NewNotFoundError("Unsupported login provider")
+	}
+
+	profile, err := p.Exchange(ctx, req.Code)
+	if err != nil {
+		return nil, apperrors.NewUnauthorizedError("Social login failed: " + err.Error())
+	}
+
+	// Returning user that already linked this identity
+	user, err := uc.repo.FindByOAuthAccount(ctx, p.Name, profile.Subject)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+
+	if user == nil {
+		if profile.Email == "" || !profile.EmailVerified {
+			return nil, apperrors.NewUnauthorizedError("Your " + p.Name + " account has no verified email address")
+		}
+
+		account := domain.OAuthAccount{
+			Provider: p.Name,
+			Subject:  profile.Subject,
+			LinkedAt: time.Now(),
+		}
+
+		// Link the identity to an existing account with the same verified email
+		user, err = uc.repo.FindByEmail(ctx, profile.Email)
+		switch {
+		case err == nil:
+			// Accounts created by a company's SSO only sign in through it, the
+			// company's IdP vouched for the email, not the user
+			if user.ProvisionedBySSO() {
+				uc.recordEvent(ctx, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventLoginFailed, Method: "oauth:" + p.Name, Reason: "sso_account"})
+				return nil, apperrors.NewConflictError("This account signs in through its company's single sign-on")
+			}
+			if err := uc.repo.AddOAuthAccount(ctx, user.ID.Hex(), account); err != nil {
+				return nil, err
+			}
+			verified, err := uc.repo.MarkEmailVerified(ctx, user.ID.Hex())
+			if err != nil {
+				return nil, err
+			}
+			if verified {
+				uc.events.Publish(ctx, domain.EventUserVerified, user.EventData("oauth:"+p.Name))
+			}
+		case errors.Is(err, domain.ErrUserNotFound):
+			role := req.Role
+			if role != domain.Applicant && role != domain.Company {
+				role = domain.Applicant
+			}
+
+			inviteCode, err := uc.redeemInviteCode(ctx, req.InviteCode, role)
+			if err != nil {
+				return nil, err
+			}
+
+			now := time.Now()
+			client := domain.ClientInfoFromContext(ctx)
+			user = &domain.User{
+				Name:            profile.Name,
+				Email:           profile.Email,
+				Role:            role,
+				Status:          domain.UserActive,
+				OAuthAccounts:   []domain.OAuthAccount{account},
+				EmailVerifiedAt: &now,
+				CreatedAt:       now,
+				UpdatedAt:       now,
+				SignupCountry:   client.Country,
+				GeoFlagged:      client.GeoFlagged,
+				InviteCode:      inviteCode,
+			}
+			if err := uc.repo.CreateUser(ctx, user); err != nil {
+				uc.releaseInviteCode(ctx, inviteCode)
+				return nil, err
+			}
+			uc.recordEvent(ctx, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventSignup, Method: "oauth:" + p.Name})
+			uc.events.Publish(ctx, domain.EventUserSignup, user.EventData("oauth:"+p.Name))
+			uc.events.Publish(ctx, domain.EventUserVerified, user.EventData("oauth:"+p.Name))
+		default:
+			return nil, err
+		}
+	}
+
+	if user.IsSuspended() {
+		uc.recordEvent(ctx, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventLoginFailed, Method: "oauth:" + p.Name, Reason: "account_suspended"})
+		return nil, errAccountSuspended()
+	}
+
+	return signIn(ctx, uc.sessions, uc.tokens, uc.eventRepo, user, "oauth:"+p.Name)
+}
+
+func (uc *userUsecase) EnrollTwoFactor(ctx context.Context, userID string) (*domain.AuthResponse, error) {
+	user, err := uc.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role != domain.Company {
+		return nil, errForbidden("Two-factor authentication is only available for company accounts")
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, apperrors.NewConflictError("Two-factor authentication is already enabled")
+	}
+
+	// The secret only becomes active once the user proves their app generates valid codes
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.SetPendingTwoFactorSecret(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+
+	return &domain.AuthResponse{
+		Success: true,
+		Message: "Scan the QR code with your authenticator app and confirm with a code",
+		TwoFactor: &domain.TwoFactorEnrollment{
+			Secret:     secret,
+			OTPAuthURL: utils.TOTPURI(totpIssuer, user.Email, secret),
+		},
+	}, nil
+}
+
+func (uc *userUsecase) ConfirmTwoFactor(ctx context.Context, userID string, req *domain.TwoFactorConfirmRequest) (*domain.AuthResponse, error) {
+	user, err := uc.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, apperrors.NewConflictError("Two-factor authentication is already enabled")
+	}
+
+	if user.TwoFactorPendingSecret == "" {
+		return nil, apperrors.NewBadRequestError("Two-factor enrollment has not been started", nil)
+	}
+
+	step, ok := utils.MatchTOTP(user.TwoFactorPendingSecret, req.Code, time.Now())
+	if !ok {
+		return nil, apperrors.NewBadRequestError("Invalid two-factor authentication code", nil)
+	}
+
+	if err := uc.repo.EnableTwoFactor(ctx, userID, user.TwoFactorPendingSecret); err != nil {
+		return nil, err
+	}
+	// The confirmation code can't be replayed to sign in
+	if _, err := uc.repo.UseTwoFactorStep(ctx, userID, step); err != nil {
+		return nil, err
+	}
+
+	return &domain.AuthResponse{
+		Success: true,
+		Message: "Two-factor authentication enabled",
+	}, nil
+}
+
+func (uc *userUsecase) VerifyTwoFactorLogin(ctx context.Context, req *domain.TwoFactorLoginRequest) (*domain.AuthResponse, error) {
+	claims, err := uc.tokens.ParseChallengeToken(req.ChallengeToken)
+	if err != nil {
+		return nil, apperrors.NewUnauthorizedError("Invalid or expired two-factor challenge")
+	}
+
+	user, err := uc.repo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewUnauthorizedError("Invalid or expired two-factor challenge")
+		}
+		return nil, err
+	}
+
+	// Attempts are counted before the code is checked, so concurrent guesses
+	// count too
+	allowed, err := uc.repo.ReserveTwoFactorAttempt(ctx, user.ID.Hex(), domain.MaxTwoFactorAttempts, domain.TwoFactorAttemptWindow)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		uc.recordEvent(ctx, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventLoginFailed, Method: "2fa", Reason: "too_many_attempts"})
+		return nil, apperrors.NewTooManyRequestsError("Too many two-factor authentication attempts, try again later")
+	}
+
+	step, ok := utils.MatchTOTP(user.TwoFactorSecret, req.Code, time.Now())
+	if !user.TwoFactorEnabled || !ok {
+		uc.recordEvent(ctx, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventLoginFailed, Method: "2fa", Reason: "invalid_code"})
+		return nil, apperrors.NewUnauthorizedError("Invalid two-factor authentication code")
+	}
+	// Each code signs in once, a code seen by someone else can't be replayed
+	fresh, err := uc.repo.UseTwoFactorStep(ctx, user.ID.Hex(), step)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		uc.recordEvent(ctx, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventLoginFailed, Method: "2fa", Reason: "reused_code"})
+		return nil, apperrors.NewUnauthorizedError("Invalid two-factor authentication code")
+	}
+
+	if user.IsDeleted() {
+		uc.recordEvent(ctx, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventLoginFailed, Method: "2fa", Reason: "account_deleted"})
+		return nil, errAccountDeleted()
+	}
+	if user.IsSuspended() {
+		uc.recordEvent(ctx, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventLoginFailed, Method: "2fa", Reason: "account_suspended"})
+		return nil, errAccountSuspended()
+	}
+
 	// Generate JWT token
-	token, err := utils.GenerateJWT(user.ID.Hex(), string(user.Role), uc.jwtSecret)
+	token, err := uc.sessions.StartSession(ctx, user, "")
 	if err != nil {
 		return nil, err
 	}
+	uc.recordEvent(ctx, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventLogin, Method: "2fa"})
 
 	// Sanitize user data before returning
 	user.Sanitize()
@@ -115,14 +778,229 @@ func (uc *userUsecase) Login(ctx context.Context, req *domain.LoginRequest) (*do
 	}, nil
 }
 
-func (uc *userUsecase) GetProfile(ctx context.Context, userID string) (*domain.User, error) {
+func (uc *userUsecase) RefreshToken(ctx context.Context, userID, tokenID string, expiresAt time.Time) (*domain.AuthResponse, error) {
 	user, err := uc.repo.FindByID(ctx, userID)
+	if err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewUnauthorizedError("User no longer exists")
+		}
+		return nil, err
+	}
+
+	if user.IsDeleted() {
+		return nil, errAccountDeleted()
+	}
+	if user.IsSuspended() {
+		return nil, errAccountSuspended()
+	}
+
+	// The role is read from the database so role changes show up in the new token
+	token, err := uc.sessions.StartSession(ctx, user, tokenID)
 	if err != nil {
 		return nil, err
 	}
 
+	// Tokens issued before JTIs were introduced can't be revoked individually and simply expire
+	if tokenID != "" {
+		if err := uc.revokedRepo.RevokeToken(ctx, tokenID, userID, expiresAt); err != nil {
+			return nil, err
+		}
+	}
+	uc.recordEvent(ctx, &domain.AuthEvent{UserID: userID, Type: domain.AuthEventTokenRefresh})
+
+	user.Sanitize()
+
+	return &domain.AuthResponse{
+		Success: true,
+		Message: "Token refreshed successfully",
+		Token:   token,
+		User:    user,
+	}, nil
+}
+
+func (uc *userUsecase) GetSecurityLog(ctx context.Context, userID string, page, limit int) (*domain.UserListResponse, error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	events, total, err := uc.eventRepo.ListByUser(ctx, userID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving security log: %w", err)
+	}
+
+	// Calculate total pages
+	totalPages := (int(total) + limit - 1) / limit
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return &domain.UserListResponse{
+		Success:    true,
+		Message:    "Successfully retrieved security log",
+		Data:       events,
+		PageNumber: page,
+		PageSize:   len(events),
+		TotalItems: total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (uc *userUsecase) GetPrivacy(ctx context.Context, userID string) (*domain.UserResponse, error) {
+	user, err := uc.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UserResponse{
+		Success: true,
+		Message: "Successfully retrieved privacy settings",
+		Data:    user.Privacy.WithDefaults(),
+	}, nil
+}
+
+func (uc *userUsecase) UpdatePrivacy(ctx context.Context, userID string, req *domain.UpdatePrivacyRequest) (*domain.UserResponse, error) {
+	user, err := uc.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	privacy := user.Privacy.WithDefaults()
+	if req.ContactVisibility != nil {
+		privacy.ContactVisibility = *req.ContactVisibility
+	}
+
+	if err := uc.repo.UpdatePrivacy(ctx, userID, privacy); err != nil {
+		return nil, err
+	}
+
+	return &domain.UserResponse{
+		Success: true,
+		Message: "Privacy settings updated successfully",
+		Data:    privacy,
+	}, nil
+}
+
+func (uc *userUsecase) UpdateAvatar(ctx context.Context, userID, avatarURL string) (*domain.UserResponse, error) {
+	if err := uc.repo.SetAvatar(ctx, userID, avatarURL); err != nil {
+		if isNotFound(err, domain.ErrUserNotFound) {
+			return nil, apperrors.NewNotFoundError("User not found")
+		}
+		return nil, err
+	}
+
+	user, err := uc.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UserResponse{
+		Success: true,
+		Message: "Profile picture updated successfully",
+		Data:    user,
+	}, nil
+}
+
+// recordEvent stores an authentication event along with the client that caused it.
+// The security log is best effort, failing to write it doesn't fail the request.
+func (uc *userUsecase) recordEvent(ctx context.Context, event *domain.AuthEvent) {
+	recordAuthEvent(ctx, uc.eventRepo, event)
+}
+
+// signIn completes a sign in in which the user proved their identity with
+// method. Accounts with 2FA get a challenge for their TOTP code instead of a
+// session token, whichever way they signed in.
+func signIn(ctx context.Context, sessions SessionUsecase, tokens *utils.TokenService, eventRepo repository.AuthEventRepository, user *domain.User, method string) (*domain.AuthResponse, error) {
+	// A link or provider account surviving the deletion must not bring the account back
+	if user.IsDeleted() {
+		recordAuthEvent(ctx, eventRepo, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventLoginFailed, Method: method, Reason: "account_deleted"})
+		return nil, errAccountDeleted()
+	}
+
+	if user.TwoFactorEnabled {
+		challenge, err := tokens.GenerateChallengeToken(user.ID.Hex(), twoFactorChallengeTTL)
+		if err != nil {
+			return nil, err
+		}
+
+		return &domain.AuthResponse{
+			Success:           true,
+			Message:           "Two-factor authentication code required",
+			RequiresTwoFactor: true,
+			ChallengeToken:    challenge,
+		}, nil
+	}
+
+	// Generate JWT token
+	token, err := sessions.StartSession(ctx, user, "")
+	if err != nil {
+		return nil, err
+	}
+	recordAuthEvent(ctx, eventRepo, &domain.AuthEvent{UserID: user.ID.Hex(), Type: domain.AuthEventLogin, Method: method})
+
 	// Sanitize user data before returning
 	user.Sanitize()
 
-	return user, nil
-}
\ No newline at end of file
+	return &domain.AuthResponse{
+		Success: true,
+		Message: "Login successful",
+		Token:   token,
+		User:    user,
+	}, nil
+}
+
+// recordAuthEvent stores an auth event with the client details of the request.
+// Failures are logged, they must never fail the sign in itself.
+func recordAuthEvent(ctx context.Context, eventRepo repository.AuthEventRepository, event *domain.AuthEvent) {
+	client := domain.ClientInfoFromContext(ctx)
+	event.IP = client.IP
+	event.UserAgent = client.UserAgent
+	event.Country = client.Country
+
+	if err := eventRepo.Record(ctx, event); err != nil {
+		log.Printf("Failed to record %s auth event: %v", event.Type, err)
+	}
+}
+
+// errAccountSuspended is returned when a suspended user tries to sign in
+func errAccountSuspended() error {
+	return errForbidden("Your account has been suspended")
+}
+
+func errAccountDeleted() error {
+	return apperrors.NewUnauthorizedError("User no longer exists")
+}
+
+// redeemInviteCode uses up one signup of the invite code while signups are
+// invite-only, and returns the normalized code. It returns "" otherwise.
+func (uc *userUsecase) redeemInviteCode(ctx context.Context, code string, role domain.Role) (string, error) {
+	if !uc.inviteOnly {
+		return "", nil
+	}
+
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return "", errForbidden("Signups are invite-only, an invite code is required")
+	}
+
+	if _, err := uc.invites.Redeem(ctx, code, role, time.Now()); err != nil {
+		if errors.Is(err, domain.ErrInviteCodeUnavailable) {
+			return "", errForbidden("This invite code is invalid, expired or used up")
+		}
+		return "", fmt.Errorf("error redeeming invite code: %w", err)
+	}
+	return code, nil
+}
+
+// releaseInviteCode gives back the signup of an account that couldn't be created
+func (uc *userUsecase) releaseInviteCode(ctx context.Context, code string) {
+	if code == "" {
+		return
+	}
+	if err := uc.invites.Release(ctx, code); err != nil {
+		log.Printf("Failed to release invite code %s: %v", code, err)
+	}
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"job-portal-backend/domain"
+	apperrors "job-portal-backend/pkg/errors"
+	"job-portal-backend/utils"
+)
+
+// A login link requested before the account was deleted must not sign it back in
+func TestVerifyMagicLinkDeletedAccount(t *testing.T) {
+	id := primitive.NewObjectID()
+	users := &fakeUserRepo{users: map[string]*domain.User{
+		id.Hex(): {ID: id, Email: "applicant@example.com", Role: domain.Applicant, Status: domain.UserDeleted},
+	}}
+	tokens := &fakeAuthTokenRepo{tokens: []*domain.AuthToken{{
+		UserID:    id.Hex(),
+		TokenHash: utils.HashToken("raw-token"),
+		Purpose:   domain.PurposeMagicLink,
+		ExpiresAt: time.Now().Add(magicLinkTTL),
+	}}}
+	uc := NewUserUsecase(users, tokens, nil, &fakeAuthEventRepo{}, nil, &fakeMailer{}, nil, nil, nil, "http://localhost:3000", nil, false)
+
+	resp, err := uc.VerifyMagicLink(context.Background(), "raw-token")
+	if appErr, ok := apperrors.As(err); !ok || appErr.Code != http.StatusUnauthorized {
+		t.Fatalf("VerifyMagicLink() = %+v, %v, want a 401", resp, err)
+	}
+}
+
+func TestRefreshTokenDeletedAccount(t *testing.T) {
+	id := primitive.NewObjectID()
+	users := &fakeUserRepo{users: map[string]*domain.User{
+		id.Hex(): {ID: id, Email: "applicant@example.com", Role: domain.Applicant, Status: domain.UserDeleted},
+	}}
+	uc := NewUserUsecase(users, &fakeAuthTokenRepo{}, nil, &fakeAuthEventRepo{}, nil, &fakeMailer{}, nil, nil, nil, "http://localhost:3000", nil, false)
+
+	resp, err := uc.RefreshToken(context.Background(), id.Hex(), "jti", time.Now().Add(time.Hour))
+	if appErr, ok := apperrors.As(err); !ok || appErr.Code != http.StatusUnauthorized {
+		t.Fatalf("RefreshToken() = %+v, %v, want a 401", resp, err)
+	}
+}
+
+// Signing in through a provider account linked before the deletion is refused as well
+func TestSignInDeletedAccount(t *testing.T) {
+	user := &domain.User{ID: primitive.NewObjectID(), Role: domain.Applicant, Status: domain.UserDeleted}
+	events := &fakeAuthEventRepo{}
+
+	_, err := signIn(context.Background(), nil, nil, events, user, "oauth:google")
+	if appErr, ok := apperrors.As(err); !ok || appErr.Code != http.StatusUnauthorized {
+		t.Fatalf("signIn() error = %v, want a 401", err)
+	}
+	if len(events.events) != 1 || events.events[0].Reason != "account_deleted" {
+		t.Fatalf("recorded %+v, want a failed login for the deleted account", events.events)
+	}
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"job-portal-backend/domain"
+)
+
+// DescribeForm builds form field metadata from a request struct's json and
+// validate tags. Deriving it from the tags keeps the metadata in sync with the
+// rules the validator actually enforces.
+func DescribeForm(request interface{}) []domain.FormField {
+	t := reflect.TypeOf(request)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	fields := make([]domain.FormField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		field := domain.FormField{Name: name, Type: formFieldType(sf.Type)}
+	rules:
+		for _, rule := range strings.Split(sf.Tag.Get("validate"), ",") {
+			key, param, _ := strings.Cut(rule, "=")
+			switch key {
+			case "dive":
+				// The remaining rules apply to the elements of a list
+				break rules
+			case "required":
+				field.Required = true
+			case "min":
+				if n, err := strconv.Atoi(param); err == nil {
+					field.MinLength = &n
+				}
+			case "max":
+				if n, err := strconv.Atoi(param); err == nil {
+					field.MaxLength = &n
+				}
+			case "oneof":
+				field.Type = "select"
+				field.Options = strings.Fields(param)
+			}
+		}
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+func formFieldType(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return "datetime"
+		}
+		return "object"
+	case reflect.Slice:
+		return "array"
+	default:
+		return "string"
+	}
+}
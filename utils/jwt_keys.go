@@ -0,0 +1,339 @@
+package utils
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrUnknownKeyID is returned when a token names a signing key that isn't in the key set
+var ErrUnknownKeyID = errors.New("token signed with an unknown key")
+
+// minRSAKeyBits is the smallest RSA key accepted for signing or verification
+const minRSAKeyBits = 2048
+
+// Key is a key tokens can be verified with
+type Key struct {
+	Method jwt.SigningMethod
+	// Verify is a []byte secret for HS256, an *rsa.PublicKey for RS256 or an
+	// ed25519.PublicKey for EdDSA
+	Verify interface{}
+}
+
+// KeySet holds the keys tokens may be signed with, identified by the "kid"
+// token header. New tokens are signed with the active key; tokens signed with
+// any other key of the set stay valid until they expire, so a key can be
+// rotated without logging everybody out.
+type KeySet struct {
+	keys   map[string]Key
+	active string
+	// signer is the HMAC secret or private key of the active key
+	signer interface{}
+	// legacy verifies HS256 tokens issued without a kid, before keys were rotated
+	legacy []byte
+}
+
+// NewKeySet builds a key set verifying tokens with keys. legacy is the secret
+// accepted for tokens without a kid header; until UseKey or UsePrivateKey is
+// called it is also the signing key.
+func NewKeySet(keys map[string]Key, legacy []byte) *KeySet {
+	if keys == nil {
+		keys = map[string]Key{}
+	}
+	return &KeySet{keys: keys, legacy: legacy}
+}
+
+// UseKey signs new tokens with the HMAC key named kid. An empty kid selects
+// the only HMAC key of the set.
+func (s *KeySet) UseKey(kid string) error {
+	if kid == "" {
+		for id, key := range s.keys {
+			if key.Method != jwt.SigningMethodHS256 {
+				continue
+			}
+			if kid != "" {
+				return errors.New("the active signing key must be named when the key set has several keys")
+			}
+			kid = id
+		}
+		if kid == "" {
+			return errors.New("the key set has no HMAC key to sign with")
+		}
+	}
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return fmt.Errorf("active signing key %q is not in the key set", kid)
+	}
+	if key.Method != jwt.SigningMethodHS256 {
+		return fmt.Errorf("key %q is a public key, signing with it needs the private key", kid)
+	}
+
+	s.active, s.signer = kid, key.Verify
+	return nil
+}
+
+// UsePrivateKey signs new tokens with an RSA (RS256) or Ed25519 (EdDSA) private
+// key and adds its public key to the set. An empty kid is derived from the key.
+func (s *KeySet) UsePrivateKey(kid string, private crypto.Signer) error {
+	var method jwt.SigningMethod
+	switch key := private.(type) {
+	case *rsa.PrivateKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return fmt.Errorf("RSA signing keys must be at least %d bits", minRSAKeyBits)
+		}
+		method = jwt.SigningMethodRS256
+	case ed25519.PrivateKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return fmt.Errorf("unsupported private key type %T", private)
+	}
+
+	if kid == "" {
+		der, err := x509.MarshalPKIXPublicKey(private.Public())
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(der)
+		kid = base64.RawURLEncoding.EncodeToString(sum[:12])
+	}
+	if existing, ok := s.keys[kid]; ok && existing.Method != method {
+		return fmt.Errorf("kid %q is already used by a %s key", kid, existing.Method.Alg())
+	}
+
+	s.keys[kid] = Key{Method: method, Verify: private.Public()}
+	s.active, s.signer = kid, private
+	return nil
+}
+
+// signingKey returns the kid, method and key new tokens are signed with.
+// The kid is empty when signing with the legacy secret.
+func (s *KeySet) signingKey() (string, jwt.SigningMethod, interface{}, error) {
+	if s.active == "" {
+		if len(s.legacy) == 0 {
+			return "", nil, nil, errors.New("no JWT signing key configured")
+		}
+		return "", jwt.SigningMethodHS256, s.legacy, nil
+	}
+	return s.active, s.keys[s.active].Method, s.signer, nil
+}
+
+// lookup returns the key verifying a token with the given kid and algorithm
+func (s *KeySet) lookup(kid string, method jwt.SigningMethod) (interface{}, error) {
+	key := Key{Method: jwt.SigningMethodHS256, Verify: s.legacy}
+	if kid != "" {
+		var ok bool
+		if key, ok = s.keys[kid]; !ok {
+			return nil, ErrUnknownKeyID
+		}
+	} else if len(s.legacy) == 0 {
+		return nil, ErrUnknownKeyID
+	}
+
+	// Never let the token pick how its key is used (e.g. a public key as an HMAC secret)
+	if method.Alg() != key.Method.Alg() {
+		return nil, jwt.ErrTokenSignatureInvalid
+	}
+	return key.Verify, nil
+}
+
+// validMethods lists the algorithms tokens may be signed with
+func validMethods() []string {
+	return []string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}
+}
+
+// JWK is a JSON Web Key. Secret ("oct") keys are only read from configuration,
+// public keys are also published so other services can verify tokens.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	// K is the secret of "oct" keys
+	K string `json:"k,omitempty"`
+	// N and E are the modulus and exponent of "RSA" keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Crv and X are the curve and public key of "OKP" keys
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set document
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicJWKS returns the public keys of the set, the HMAC secrets are left out
+func (s *KeySet) PublicJWKS() JWKS {
+	doc := JWKS{Keys: []JWK{}}
+	for kid, key := range s.keys {
+		switch pub := key.Verify.(type) {
+		case *rsa.PublicKey:
+			doc.Keys = append(doc.Keys, JWK{
+				Kty: "RSA",
+				Kid: kid,
+				Use: "sig",
+				Alg: key.Method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			doc.Keys = append(doc.Keys, JWK{
+				Kty: "OKP",
+				Kid: kid,
+				Use: "sig",
+				Alg: key.Method.Alg(),
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(pub),
+			})
+		}
+	}
+	sort.Slice(doc.Keys, func(i, j int) bool { return doc.Keys[i].Kid < doc.Keys[j].Kid })
+	return doc
+}
+
+// ParseJWKS reads the keys of a JSON Web Key Set document. Besides HMAC
+// secrets it may list the public keys of previous asymmetric signing keys.
+func ParseJWKS(data []byte) (map[string]Key, error) {
+	var set JWKS
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS document: %v", err)
+	}
+
+	keys := make(map[string]Key, len(set.Keys))
+	for i, jwk := range set.Keys {
+		if jwk.Kid == "" {
+			return nil, fmt.Errorf("key %d: missing kid", i)
+		}
+		if _, dup := keys[jwk.Kid]; dup {
+			return nil, fmt.Errorf("duplicate kid %q", jwk.Kid)
+		}
+		key, err := jwk.key()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %v", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+// key decodes the verification key of a JWK
+func (k JWK) key() (Key, error) {
+	decode := func(s string) ([]byte, error) {
+		return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	}
+
+	switch k.Kty {
+	case "oct":
+		secret, err := decode(k.K)
+		if err != nil || len(secret) == 0 {
+			return Key{}, errors.New("invalid secret")
+		}
+		return Key{Method: jwt.SigningMethodHS256, Verify: secret}, nil
+
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return Key{}, errors.New("invalid modulus")
+		}
+		e, err := decode(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return Key{}, errors.New("invalid exponent")
+		}
+		pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		if pub.N.BitLen() < minRSAKeyBits {
+			return Key{}, fmt.Errorf("RSA keys must be at least %d bits", minRSAKeyBits)
+		}
+		return Key{Method: jwt.SigningMethodRS256, Verify: pub}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return Key{}, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decode(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return Key{}, errors.New("invalid public key")
+		}
+		return Key{Method: jwt.SigningMethodEdDSA, Verify: ed25519.PublicKey(x)}, nil
+	}
+
+	return Key{}, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+// LoadJWKS reads a JWKS document from a file path or an http(s) URL
+func LoadJWKS(ctx context.Context, source string) (map[string]Key, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, err
+		}
+		return ParseJWKS(data)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching JWKS: unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	return ParseJWKS(data)
+}
+
+// LoadPrivateKey reads a PEM encoded RSA or Ed25519 private key (PKCS#8, or PKCS#1 for RSA)
+func LoadPrivateKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return signer, nil
+}
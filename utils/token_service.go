@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// TwoFactorChallengePurpose marks tokens that only allow completing a 2FA login
+const TwoFactorChallengePurpose = "2fa_challenge"
+
+// ErrPurposeMismatch is returned when a token issued for one purpose (e.g. a 2FA
+// challenge) is presented where another kind of token is expected
+var ErrPurposeMismatch = errors.New("token was issued for a different purpose")
+
+// TokenClaims are the claims carried by the JWTs issued by the API
+type TokenClaims struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role,omitempty"`
+	// Scope is the space separated list of scopes granted to an access token
+	Scope string `json:"scope,omitempty"`
+	// Purpose is set on restricted tokens such as 2FA challenges; access tokens have none
+	Purpose string `json:"purpose,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Scopes returns the scopes granted by the token
+func (c *TokenClaims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// TokenService issues and validates JWTs. Every token is signed with the active
+// key of the key set and carries the configured issuer and audience.
+// Time based claims are checked with a leeway to tolerate clock skew between servers.
+type TokenService struct {
+	keys     *KeySet
+	issuer   string
+	audience string
+	ttl      time.Duration
+	leeway   time.Duration
+}
+
+func NewTokenService(keys *KeySet, issuer, audience string, ttl, leeway time.Duration) *TokenService {
+	return &TokenService{
+		keys:     keys,
+		issuer:   issuer,
+		audience: audience,
+		ttl:      ttl,
+		leeway:   leeway,
+	}
+}
+
+// JWKS returns the public keys tokens can be verified with
+func (s *TokenService) JWKS() JWKS {
+	return s.keys.PublicJWKS()
+}
+
+// TTL is how long access tokens stay valid
+func (s *TokenService) TTL() time.Duration {
+	return s.ttl
+}
+
+// GenerateAccessToken issues a token granting the given scopes on the API
+func (s *TokenService) GenerateAccessToken(userID, role string, scopes []string) (string, error) {
+	token, _, err := s.IssueAccessToken(userID, role, scopes)
+	return token, err
+}
+
+// IssueAccessToken is GenerateAccessToken but also returns the claims of the
+// token, e.g. to track its ID as a session
+func (s *TokenService) IssueAccessToken(userID, role string, scopes []string) (string, *TokenClaims, error) {
+	claims := &TokenClaims{UserID: userID, Role: role, Scope: strings.Join(scopes, " ")}
+	token, err := s.sign(claims, s.ttl)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, claims, nil
+}
+
+// GenerateChallengeToken issues a short-lived token for the second login step
+func (s *TokenService) GenerateChallengeToken(userID string, ttl time.Duration) (string, error) {
+	return s.sign(&TokenClaims{UserID: userID, Purpose: TwoFactorChallengePurpose}, ttl)
+}
+
+// ParseAccessToken validates an access token and returns its claims
+func (s *TokenService) ParseAccessToken(tokenString string) (*TokenClaims, error) {
+	return s.parse(tokenString, "")
+}
+
+// ParseChallengeToken validates a 2FA challenge token and returns its claims
+func (s *TokenService) ParseChallengeToken(tokenString string) (*TokenClaims, error) {
+	return s.parse(tokenString, TwoFactorChallengePurpose)
+}
+
+func (s *TokenService) sign(claims *TokenClaims, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        uuid.New().String(), // Unique token ID so the token can be revoked
+		Issuer:    s.issuer,
+		Audience:  jwt.ClaimStrings{s.audience},
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+	}
+
+	kid, method, key, err := s.keys.signingKey()
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	return token.SignedString(key)
+}
+
+func (s *TokenService) parse(tokenString, purpose string) (*TokenClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &TokenClaims{}, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return s.keys.lookup(kid, token.Method)
+	},
+		jwt.WithValidMethods(validMethods()),
+		jwt.WithIssuer(s.issuer),
+		jwt.WithAudience(s.audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(s.leeway),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*TokenClaims)
+	if !ok || !token.Valid {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	if claims.Purpose != purpose {
+		return nil, ErrPurposeMismatch
+	}
+	if claims.UserID == "" {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+
+	return claims, nil
+}